@@ -0,0 +1,65 @@
+package dto
+
+import "time"
+
+// ActivityViolation is one anti-cheat rule an activity broke.
+type ActivityViolation struct {
+	Rule   string `json:"rule"`
+	Detail string `json:"detail"`
+}
+
+// ActivityHeldResponse is returned (202) when an activity was saved but held for staff review.
+type ActivityHeldResponse struct {
+	Message    string              `json:"message"`
+	Status     string              `json:"status"`
+	Violations []ActivityViolation `json:"violations"`
+}
+
+// CampaignRunResponse is a run in the campaign review queue.
+type CampaignRunResponse struct {
+	ID              string              `json:"id"`
+	CampaignID      string              `json:"campaign_id"`
+	RunnerID        string              `json:"runner_id"`
+	UserID          string              `json:"user_id"`
+	Activity        string              `json:"activity"`
+	Distance        float64             `json:"distance"`
+	Duration        string              `json:"duration"`
+	DurationSeconds int64               `json:"duration_seconds"`
+	MoneyRaised     float64             `json:"money_raised"`
+	TrackURL        string              `json:"track_url,omitempty"`
+	ElevationGain   float64             `json:"elevation_gain,omitempty"`
+	Status          string              `json:"status"`
+	Violations      []ActivityViolation `json:"violations"`
+	ReviewedBy      string              `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time          `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time           `json:"created_at"`
+}
+
+type CampaignRunListResponse struct {
+	Runs  []CampaignRunResponse `json:"runs"`
+	Page  int                   `json:"page"`
+	Limit int                   `json:"limit"`
+}
+
+// CauseRunnerReviewResponse is a cause activity in the review queue.
+type CauseRunnerReviewResponse struct {
+	ID              string              `json:"id"`
+	CauseID         string              `json:"cause_id"`
+	UserID          string              `json:"user_id"`
+	Activity        string              `json:"activity"`
+	DistanceCovered float64             `json:"distance_covered"`
+	Duration        string              `json:"duration"`
+	DurationSeconds int64               `json:"duration_seconds"`
+	TrackURL        string              `json:"track_url,omitempty"`
+	Status          string              `json:"status"`
+	Violations      []ActivityViolation `json:"violations"`
+	ReviewedBy      string              `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time          `json:"reviewed_at,omitempty"`
+	DateJoined      time.Time           `json:"date_joined"`
+}
+
+type CauseRunnerReviewListResponse struct {
+	Runners []CauseRunnerReviewResponse `json:"runners"`
+	Page    int                         `json:"page"`
+	Limit   int                         `json:"limit"`
+}
@@ -6,62 +6,84 @@ import (
 
 // Campaign DTOs
 type CreateCampaignRequest struct {
-	Name              string  `json:"name" binding:"required,max=100"`
-	Description       string  `json:"description,omitempty"`
-	Condition         string  `json:"condition,omitempty"`
-	Mode              string  `json:"mode,omitempty" binding:"omitempty,oneof=Free Paid"`
-	Goal              string  `json:"goal,omitempty"`
-	Activity          string  `json:"activity,omitempty" binding:"omitempty,oneof=Walking Running Cycling"`
-	Location          string  `json:"location,omitempty"`
-	TargetAmount      float64 `json:"target_amount,omitempty"`
-	TargetAmountPerKm float64 `json:"target_amount_per_km,omitempty"`
-	DistanceToCover   float64 `json:"distance_to_cover,omitempty"`
-	StartDuration     string  `json:"start_duration,omitempty"`
-	EndDuration       string  `json:"end_duration,omitempty"`
-	WorkoutImg        string  `json:"workout_img,omitempty"`
-}
-
-type UpdateCampaignRequest struct {
-	Name              string   `json:"name,omitempty" binding:"omitempty,max=100"`
+	Name              string   `json:"name" binding:"required,max=100"`
 	Description       string   `json:"description,omitempty"`
 	Condition         string   `json:"condition,omitempty"`
 	Mode              string   `json:"mode,omitempty" binding:"omitempty,oneof=Free Paid"`
 	Goal              string   `json:"goal,omitempty"`
 	Activity          string   `json:"activity,omitempty" binding:"omitempty,oneof=Walking Running Cycling"`
 	Location          string   `json:"location,omitempty"`
-	TargetAmount      *float64 `json:"target_amount,omitempty"`
-	TargetAmountPerKm *float64 `json:"target_amount_per_km,omitempty"`
-	DistanceToCover   *float64 `json:"distance_to_cover,omitempty"`
+	Latitude          *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude         *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
+	TargetAmount      float64  `json:"target_amount,omitempty"`
+	TargetAmountPerKm float64  `json:"target_amount_per_km,omitempty"`
+	DistanceToCover   float64  `json:"distance_to_cover,omitempty"`
 	StartDuration     string   `json:"start_duration,omitempty"`
 	EndDuration       string   `json:"end_duration,omitempty"`
 	WorkoutImg        string   `json:"workout_img,omitempty"`
-	AcceptTac         *bool    `json:"accept_tac,omitempty"`
+	// StartsAt/EndsAt take precedence over the legacy start_duration/end_duration strings
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+	// Draft keeps the campaign closed until it is published
+	Draft bool `json:"draft,omitempty"`
+	// Visibility defaults to public; private campaigns are invite-only
+	Visibility string `json:"visibility,omitempty" binding:"omitempty,oneof=public unlisted private"`
+}
+
+type UpdateCampaignRequest struct {
+	Name              string     `json:"name,omitempty" binding:"omitempty,max=100"`
+	Description       string     `json:"description,omitempty"`
+	Condition         string     `json:"condition,omitempty"`
+	Mode              string     `json:"mode,omitempty" binding:"omitempty,oneof=Free Paid"`
+	Goal              string     `json:"goal,omitempty"`
+	Activity          string     `json:"activity,omitempty" binding:"omitempty,oneof=Walking Running Cycling"`
+	Location          string     `json:"location,omitempty"`
+	Latitude          *float64   `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude         *float64   `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
+	TargetAmount      *float64   `json:"target_amount,omitempty"`
+	TargetAmountPerKm *float64   `json:"target_amount_per_km,omitempty"`
+	DistanceToCover   *float64   `json:"distance_to_cover,omitempty"`
+	StartDuration     string     `json:"start_duration,omitempty"`
+	EndDuration       string     `json:"end_duration,omitempty"`
+	StartsAt          *time.Time `json:"starts_at,omitempty"`
+	EndsAt            *time.Time `json:"ends_at,omitempty"`
+	WorkoutImg        string     `json:"workout_img,omitempty"`
+	AcceptTac         *bool      `json:"accept_tac,omitempty"`
+	Visibility        string     `json:"visibility,omitempty" binding:"omitempty,oneof=public unlisted private"`
 }
 
 type CampaignResponse struct {
-	ID                string                `json:"id"`
-	Name              string                `json:"name"`
-	Description       string                `json:"description"`
-	Condition         string                `json:"condition"`
-	Mode              string                `json:"mode"`
-	Goal              string                `json:"goal"`
-	Activity          string                `json:"activity"`
-	AcceptTac         bool                  `json:"accept_tac"`
-	Location          string                `json:"location"`
-	MoneyRaised       float64               `json:"money_raised"`
-	TargetAmount      float64               `json:"target_amount"`
-	TargetAmountPerKm float64               `json:"target_amount_per_km"`
-	DistanceToCover   float64               `json:"distance_to_cover"`
-	DistanceCovered   float64               `json:"distance_covered"`
-	StartDuration     string                `json:"start_duration"`
-	EndDuration       string                `json:"end_duration"`
-	Members           []interface{}         `json:"members"`
-	Sponsors          []interface{}         `json:"sponsors"`
-	Owner             CampaignOwnerInfo     `json:"owner"`
-	Slug              string                `json:"slug"`
-	WorkoutImg        string                `json:"workout_img"`
-	DateCreated       time.Time             `json:"date_created"`
-	DateUpdated       time.Time             `json:"date_updated"`
+	ID                string            `json:"id"`
+	Name              string            `json:"name"`
+	Description       string            `json:"description"`
+	Condition         string            `json:"condition"`
+	Mode              string            `json:"mode"`
+	Goal              string            `json:"goal"`
+	Activity          string            `json:"activity"`
+	AcceptTac         bool              `json:"accept_tac"`
+	Location          string            `json:"location"`
+	Latitude          *float64          `json:"latitude,omitempty"`
+	Longitude         *float64          `json:"longitude,omitempty"`
+	MoneyRaised       float64           `json:"money_raised"`
+	TargetAmount      float64           `json:"target_amount"`
+	TargetAmountPerKm float64           `json:"target_amount_per_km"`
+	DistanceToCover   float64           `json:"distance_to_cover"`
+	DistanceCovered   float64           `json:"distance_covered"`
+	StartDuration     string            `json:"start_duration"`
+	EndDuration       string            `json:"end_duration"`
+	StartsAt          *time.Time        `json:"starts_at,omitempty"`
+	EndsAt            *time.Time        `json:"ends_at,omitempty"`
+	Status            string            `json:"status"`
+	AchievedAt        *time.Time        `json:"achieved_at,omitempty"`
+	ClosedAt          *time.Time        `json:"closed_at,omitempty"`
+	Visibility        string            `json:"visibility"`
+	Members           []UserSummary     `json:"members"`
+	Sponsors          []UserSummary     `json:"sponsors"`
+	Owner             CampaignOwnerInfo `json:"owner"`
+	Slug              string            `json:"slug"`
+	WorkoutImg        string            `json:"workout_img"`
+	DateCreated       time.Time         `json:"date_created"`
+	DateUpdated       time.Time         `json:"date_updated"`
 }
 
 type CampaignOwnerInfo struct {
@@ -70,6 +92,14 @@ type CampaignOwnerInfo struct {
 	Username string `json:"username"`
 }
 
+// UserSummary names a campaign member or sponsor. Username and FullName are empty for users
+// that no longer exist.
+type UserSummary struct {
+	ID       string `json:"id"`
+	Username string `json:"username,omitempty"`
+	FullName string `json:"full_name,omitempty"`
+}
+
 type CampaignSponsorInfo struct {
 	ID       string `json:"id"`
 	Name     string `json:"name"`
@@ -105,7 +135,8 @@ type ParticipateCampaignResponse struct {
 // FinishActivityRequest represents the request body for finishing a campaign activity
 type FinishActivityRequest struct {
 	DistanceCovered float64 `json:"distance_covered" binding:"required"`
-	Duration        string  `json:"duration" binding:"required"`
+	Duration        string  `json:"duration"`                   // e.g. "25:30", "1:02:03", "45 min" or "PT25M30S"
+	DurationSeconds *int64  `json:"duration_seconds,omitempty"` // takes precedence over duration
 	MoneyRaised     float64 `json:"money_raised"`
 }
 
@@ -117,11 +148,14 @@ type CampaignRunnerResponse struct {
 	Username        string    `json:"username"`
 	DistanceCovered float64   `json:"distance_covered"`
 	Duration        string    `json:"duration"`
+	DurationSeconds int64     `json:"duration_seconds"`
 	MoneyRaised     float64   `json:"money_raised"`
 	CampaignID      string    `json:"campaign_id"`
 	CoverImage      string    `json:"cover_image"`
 	Activity        string    `json:"activity"`
 	DateJoined      time.Time `json:"date_joined"`
+	TrackURL        string    `json:"track_url,omitempty"`
+	ElevationGain   float64   `json:"elevation_gain,omitempty"`
 }
 
 // Sponsor Campaign DTOs
@@ -141,25 +175,162 @@ type SponsorCampaignResponse struct {
 	TotalAmount float64     `json:"total_amount"`
 	BrandImg    string      `json:"brand_img"`
 	VideoUrl    string      `json:"video_url"`
+	Status      string      `json:"status,omitempty"` // pending until a template slot is confirmed
 	DateCreated time.Time   `json:"date_created"`
 	Paystack    interface{} `json:"paystack,omitempty"`
 }
 
 // Campaign leaderboard DTOs
-type CampaignLeaderboardEntry struct {
+type CampaignLeaderboardResponse struct {
+	CampaignSlug string             `json:"campaign_slug"`
+	Window       string             `json:"window"`
+	Activity     string             `json:"activity,omitempty"`
+	Total        int                `json:"total"` // ranked users on the whole board
+	Leaderboard  []LeaderboardEntry `json:"leaderboard"`
+	Me           *LeaderboardEntry  `json:"me,omitempty"`
+	Neighbours   []LeaderboardEntry `json:"neighbours,omitempty"`
+}
+
+// Campaign team DTOs
+type CreateTeamRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type JoinTeamRequest struct {
+	InviteCode string `json:"invite_code" binding:"required"`
+}
+
+type TransferCaptaincyRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+type TeamMemberEntry struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	FullName string `json:"full_name"`
+}
+
+// TeamResponse describes a team. InviteCode is only included for the team's own members.
+type TeamResponse struct {
+	ID          string            `json:"id"`
+	CampaignID  string            `json:"campaign_id"`
+	Name        string            `json:"name"`
+	CaptainID   string            `json:"captain_id"`
+	InviteCode  string            `json:"invite_code,omitempty"`
+	MemberCount int               `json:"member_count"`
+	Members     []TeamMemberEntry `json:"members"`
+	DateCreated time.Time         `json:"date_created"`
+}
+
+type TeamListResponse struct {
+	CampaignSlug string         `json:"campaign_slug"`
+	Teams        []TeamResponse `json:"teams"`
+}
+
+type TeamLeaderboardEntry struct {
+	Rank            int     `json:"rank"`
+	TeamID          string  `json:"team_id"`
+	Name            string  `json:"name"`
+	CaptainID       string  `json:"captain_id"`
+	MemberCount     int     `json:"member_count"`
+	DistanceCovered float64 `json:"distance_covered"`
+	MoneyRaised     float64 `json:"money_raised"`
+	Duration        string  `json:"duration"`
+	DurationSeconds int64   `json:"duration_seconds"`
+}
+
+type TeamLeaderboardResponse struct {
+	CampaignSlug string                 `json:"campaign_slug"`
+	Leaderboard  []TeamLeaderboardEntry `json:"leaderboard"`
+}
+
+// Campaign invite DTOs
+type CreateInviteRequest struct {
+	MaxUses        int `json:"max_uses,omitempty" binding:"min=0"`                  // 0 allows unlimited uses
+	ExpiresInHours int `json:"expires_in_hours,omitempty" binding:"min=0,max=2160"` // defaults to 7 days
+}
+
+type EmailInvitesRequest struct {
+	Emails         []string `json:"emails" binding:"required,min=1,max=100,dive,email"`
+	ExpiresInHours int      `json:"expires_in_hours,omitempty" binding:"min=0,max=2160"`
+}
+
+// InviteResponse describes an invite. Link is the URL to share or that was emailed.
+type InviteResponse struct {
+	ID          string     `json:"id"`
+	CampaignID  string     `json:"campaign_id"`
+	Email       string     `json:"email,omitempty"`
+	MaxUses     int        `json:"max_uses"`
+	Uses        int        `json:"uses"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	Link        string     `json:"link"`
+	Token       string     `json:"token"`
+	DateCreated time.Time  `json:"date_created"`
+}
+
+type InviteListResponse struct {
+	CampaignSlug string           `json:"campaign_slug"`
+	Invites      []InviteResponse `json:"invites"`
+}
+
+// InvitePreviewResponse shows what an invite link leads to before it is accepted.
+type InvitePreviewResponse struct {
+	Campaign  CampaignResponse `json:"campaign"`
+	ExpiresAt time.Time        `json:"expires_at"`
+	Email     string           `json:"email,omitempty"`
+}
+
+type CreateJoinRequestRequest struct {
+	Message string `json:"message,omitempty" binding:"max=500"`
+}
+
+type JoinRequestResponse struct {
+	ID          string     `json:"id"`
+	CampaignID  string     `json:"campaign_id"`
+	UserID      string     `json:"user_id"`
+	Username    string     `json:"username"`
+	Message     string     `json:"message"`
+	Status      string     `json:"status"`
+	DecidedBy   string     `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+	DateCreated time.Time  `json:"date_created"`
+}
+
+type JoinRequestListResponse struct {
+	CampaignSlug string                `json:"campaign_slug"`
+	Requests     []JoinRequestResponse `json:"requests"`
+}
+
+// Campaign close-out DTOs
+type CampaignStandingEntry struct {
+	Rank            int     `json:"rank"`
 	UserID          string  `json:"user_id"`
 	Username        string  `json:"username"`
 	FullName        string  `json:"full_name"`
 	DistanceCovered float64 `json:"distance_covered"`
 	MoneyRaised     float64 `json:"money_raised"`
-	Duration        string  `json:"duration"`
 	Activity        string  `json:"activity"`
-	CoverImage      string  `json:"cover_image,omitempty"`
 }
 
-type CampaignLeaderboardResponse struct {
-	CampaignSlug string                     `json:"campaign_slug"`
-	Leaderboard  []CampaignLeaderboardEntry `json:"leaderboard"`
+type SponsorObligationEntry struct {
+	SponsorCampaignID string        `json:"sponsor_campaign_id"`
+	Sponsors          []UserSummary `json:"sponsors"`
+	PledgedDistance   float64       `json:"pledged_distance"`
+	CreditedDistance  float64       `json:"credited_distance"`
+	AmountPerKm       float64       `json:"amount_per_km"`
+	Amount            float64       `json:"amount"`
+}
+
+// CampaignResultsResponse is the frozen outcome of a closed campaign. Obligations are only
+// included for the campaign owner and staff.
+type CampaignResultsResponse struct {
+	CampaignSlug string                   `json:"campaign_slug"`
+	Status       string                   `json:"status"`
+	AchievedAt   *time.Time               `json:"achieved_at,omitempty"`
+	ClosedAt     *time.Time               `json:"closed_at,omitempty"`
+	Standings    []CampaignStandingEntry  `json:"standings"`
+	Obligations  []SponsorObligationEntry `json:"obligations,omitempty"`
 }
 
 // Join Campaign Response
@@ -170,8 +341,19 @@ type JoinCampaignResponse struct {
 }
 
 // Search Campaigns Request
+// SearchCampaignsRequest filters and sorts campaign search. Every filter is optional.
 type SearchCampaignsRequest struct {
-	Query string `form:"q" binding:"required"`
+	Query     string     `form:"q"`
+	Activity  string     `form:"activity" binding:"omitempty,oneof=Walking Running Cycling"`
+	Mode      string     `form:"mode" binding:"omitempty,oneof=Free Paid"`
+	Location  string     `form:"location"`
+	Status    string     `form:"status" binding:"omitempty,oneof=draft scheduled active completed cancelled"`
+	From      *time.Time `form:"from" time_format:"2006-01-02"` // campaigns still running on or after this date
+	To        *time.Time `form:"to" time_format:"2006-01-02"`   // campaigns started on or before this date
+	MinTarget *float64   `form:"min_target" binding:"omitempty,min=0"`
+	MaxTarget *float64   `form:"max_target" binding:"omitempty,min=0"`
+	NearQuery
+	Sort  string `form:"sort,default=newest" binding:"oneof=newest most_funded closest_to_goal nearest"` // nearest requires near
 	Page  int    `form:"page,default=1" binding:"min=1"`
 	Limit int    `form:"limit,default=10" binding:"min=1,max=100"`
 }
@@ -183,6 +365,7 @@ type CreateCampaignRunnerRequest struct {
 	Activity        string  `json:"activity" binding:"required"`
 	DistanceCovered float64 `json:"distance_covered,omitempty"`
 	Duration        string  `json:"duration,omitempty"`
+	DurationSeconds *int64  `json:"duration_seconds,omitempty"`
 	MoneyRaised     float64 `json:"money_raised,omitempty"`
 }
 
@@ -190,6 +373,7 @@ type UpdateCampaignRunnerRequest struct {
 	Activity        string   `json:"activity,omitempty"`
 	DistanceCovered *float64 `json:"distance_covered,omitempty"`
 	Duration        string   `json:"duration,omitempty"`
+	DurationSeconds *int64   `json:"duration_seconds,omitempty"`
 	MoneyRaised     *float64 `json:"money_raised,omitempty"`
 	CoverImage      string   `json:"cover_image,omitempty"`
 }
@@ -226,3 +410,99 @@ type UpdateSponsorCampaignRequest struct {
 	BrandImg    string   `json:"brand_img,omitempty"`
 	VideoUrl    string   `json:"video_url,omitempty"`
 }
+
+// Campaign milestone DTOs
+type MilestoneRequest struct {
+	Metric  string  `json:"metric" binding:"required,oneof=distance money"`
+	Percent float64 `json:"percent,omitempty" binding:"min=0,max=100"` // share of the campaign target
+	Value   float64 `json:"value,omitempty" binding:"min=0"`           // fixed km or amount, when percent is not set
+}
+
+type SetMilestonesRequest struct {
+	Milestones []MilestoneRequest `json:"milestones" binding:"max=20,dive"`
+}
+
+// MilestoneResponse describes a milestone. Threshold is the km or amount it fires at under
+// the campaign's current targets.
+type MilestoneResponse struct {
+	ID        string     `json:"id"`
+	Metric    string     `json:"metric"`
+	Percent   float64    `json:"percent,omitempty"`
+	Value     float64    `json:"value,omitempty"`
+	Threshold float64    `json:"threshold"`
+	Label     string     `json:"label"`
+	Reached   bool       `json:"reached"`
+	ReachedAt *time.Time `json:"reached_at,omitempty"`
+}
+
+type MilestoneListResponse struct {
+	CampaignSlug    string              `json:"campaign_slug"`
+	DistanceCovered float64             `json:"distance_covered"`
+	MoneyRaised     float64             `json:"money_raised"`
+	Milestones      []MilestoneResponse `json:"milestones"`
+}
+
+// Campaign template DTOs
+type SaveCampaignTemplateRequest struct {
+	Name string `json:"name,omitempty" binding:"max=255"` // defaults to the campaign's name
+}
+
+// UpdateCampaignTemplateRequest renames a template or changes its recurring schedule. A
+// recurrence of "none" stops the schedule.
+type UpdateCampaignTemplateRequest struct {
+	Name         *string    `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
+	Recurrence   *string    `json:"recurrence,omitempty" binding:"omitempty,oneof=none weekly monthly"`
+	NextStartsAt *time.Time `json:"next_starts_at,omitempty"`                      // start of the first scheduled edition
+	LeadHours    int        `json:"lead_hours,omitempty" binding:"min=0,max=2160"` // create editions this long before they start; default 168
+}
+
+// CreateFromTemplateRequest gives the new campaign's start, either directly or as a shift in
+// days from the template's start.
+type CreateFromTemplateRequest struct {
+	StartsAt  *time.Time `json:"starts_at,omitempty"`
+	ShiftDays *int       `json:"shift_days,omitempty"`
+	Draft     bool       `json:"draft,omitempty"`
+}
+
+type TemplateSponsorSlotResponse struct {
+	Distance    float64       `json:"distance"`
+	AmountPerKm float64       `json:"amount_per_km"`
+	TotalAmount float64       `json:"total_amount"`
+	BrandImg    string        `json:"brand_img,omitempty"`
+	VideoUrl    string        `json:"video_url,omitempty"`
+	Sponsors    []UserSummary `json:"sponsors"`
+}
+
+type CampaignTemplateResponse struct {
+	ID                string                        `json:"id"`
+	OwnerID           string                        `json:"owner_id"`
+	SourceCampaignID  string                        `json:"source_campaign_id,omitempty"`
+	Name              string                        `json:"name"`
+	Description       string                        `json:"description"`
+	Mode              string                        `json:"mode"`
+	Activity          string                        `json:"activity"`
+	Location          string                        `json:"location"`
+	Visibility        string                        `json:"visibility"`
+	TargetAmount      float64                       `json:"target_amount"`
+	TargetAmountPerKm float64                       `json:"target_amount_per_km"`
+	DistanceToCover   float64                       `json:"distance_to_cover"`
+	StartsAt          *time.Time                    `json:"starts_at,omitempty"`
+	LengthSeconds     int64                         `json:"length_seconds"` // 0 for open-ended campaigns
+	SponsorSlots      []TemplateSponsorSlotResponse `json:"sponsor_slots"`
+	Milestones        []MilestoneRequest            `json:"milestones"`
+	Recurrence        string                        `json:"recurrence"`
+	NextStartsAt      *time.Time                    `json:"next_starts_at,omitempty"`
+	LeadHours         int                           `json:"lead_hours,omitempty"`
+	Editions          int                           `json:"editions"`
+	DateCreated       time.Time                     `json:"date_created"`
+}
+
+type CampaignTemplateListResponse struct {
+	Templates []CampaignTemplateResponse `json:"templates"`
+	Count     int                        `json:"count"`
+}
+
+type CampaignSponsorshipListResponse struct {
+	Sponsorships []SponsorCampaignResponse `json:"sponsorships"`
+	Count        int                       `json:"count"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// CertificateResponse describes a completion certificate. FileURL is the PDF and VerifyURL
+// the public page its QR code links to.
+type CertificateResponse struct {
+	ID              string    `json:"id"`
+	Kind            string    `json:"kind"` // campaign or cause
+	SubjectID       string    `json:"subject_id"`
+	SubjectName     string    `json:"subject_name"`
+	RunnerID        string    `json:"runner_id"`
+	UserID          string    `json:"user_id"`
+	RunnerName      string    `json:"runner_name"`
+	Distance        float64   `json:"distance"` // km
+	Duration        string    `json:"duration"`
+	DurationSeconds int64     `json:"duration_seconds"`
+	FileURL         string    `json:"file_url"`
+	VerifyURL       string    `json:"verify_url"`
+	IssuedAt        time.Time `json:"issued_at"`
+}
+
+type CertificateListResponse struct {
+	Certificates []CertificateResponse `json:"certificates"`
+	Count        int                   `json:"count"`
+}
+
+// CertificateVerificationResponse confirms a certificate is genuine and repeats what was
+// printed on it, so it can be compared with the copy being checked.
+type CertificateVerificationResponse struct {
+	Valid       bool                `json:"valid"`
+	Certificate CertificateResponse `json:"certificate"`
+}
@@ -12,12 +12,17 @@ type CreateChallengeRequest struct {
 	Condition         string  `json:"condition,omitempty"`
 	Goal              string  `json:"goal,omitempty"`
 	Location          string  `json:"location,omitempty"`
+	Latitude          *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude         *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
 	DistanceToCover   float64 `json:"distance_to_cover,omitempty"`
 	TargetAmount      float64 `json:"target_amount,omitempty"`
 	TargetAmountPerKm float64 `json:"target_amount_per_km,omitempty"`
 	StartDuration     string  `json:"start_duration,omitempty"`
 	EndDuration       string  `json:"end_duration,omitempty"`
 	NoOfWinner        int     `json:"no_of_winner,omitempty"`
+	WinningPrice      []Prize       `json:"winning_price,omitempty" binding:"omitempty,max=100,dive"`                  // prizes without a rank run first place first
+	CausePrice        []Prize       `json:"cause_price,omitempty" binding:"omitempty,max=100,dive"`                    // awarded to the causes placed by the final judging round
+	RankingCriterion  string        `json:"ranking_criterion,omitempty" binding:"omitempty,oneof=distance fastest money"` // defaults to distance
 	CoverImage        string  `json:"cover_image,omitempty"`
 	VideoUrl          string  `json:"video_url,omitempty"`
 }
@@ -29,16 +34,35 @@ type UpdateChallengeRequest struct {
 	Condition         string   `json:"condition,omitempty"`
 	Goal              string   `json:"goal,omitempty"`
 	Location          string   `json:"location,omitempty"`
+	Latitude          *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude         *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
 	DistanceToCover   *float64 `json:"distance_to_cover,omitempty"`
 	TargetAmount      *float64 `json:"target_amount,omitempty"`
 	TargetAmountPerKm *float64 `json:"target_amount_per_km,omitempty"`
 	StartDuration     string   `json:"start_duration,omitempty"`
 	EndDuration       string   `json:"end_duration,omitempty"`
 	NoOfWinner        *int     `json:"no_of_winner,omitempty"`
+	WinningPrice      []Prize       `json:"winning_price,omitempty" binding:"omitempty,max=100,dive"`                  // replaces every prize when sent
+	CausePrice        []Prize       `json:"cause_price,omitempty" binding:"omitempty,max=100,dive"`                    // replaces every cause prize when sent
+	RankingCriterion  string        `json:"ranking_criterion,omitempty" binding:"omitempty,oneof=distance fastest money"`
 	CoverImage        string   `json:"cover_image,omitempty"`
 	VideoUrl          string   `json:"video_url,omitempty"`
 }
 
+// Prize is what one place wins: an amount with its ISO 4217 currency, a description, or both.
+// Rank defaults to the prize's position in its list.
+type Prize struct {
+	Rank        int     `json:"rank,omitempty" binding:"omitempty,min=1"`
+	Amount      float64 `json:"amount,omitempty" binding:"min=0"`
+	Currency    string  `json:"currency,omitempty" binding:"required_with=Amount,omitempty,iso4217"`
+	Description string  `json:"description,omitempty" binding:"required_without=Amount,max=200"`
+}
+
+// TransferOwnershipRequest names the user a challenge or cause is handed to.
+type TransferOwnershipRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
 type ChallengeResponse struct {
 	ID                string                `json:"id"`
 	Name              string                `json:"name"`
@@ -47,14 +71,20 @@ type ChallengeResponse struct {
 	Condition         string                `json:"condition"`
 	Goal              string                `json:"goal"`
 	Location          string                `json:"location"`
+	Latitude          *float64 `json:"latitude,omitempty"`
+	Longitude         *float64 `json:"longitude,omitempty"`
 	DistanceToCover   float64               `json:"distance_to_cover"`
 	TargetAmount      float64               `json:"target_amount"`
 	TargetAmountPerKm float64               `json:"target_amount_per_km"`
 	StartDuration     string                `json:"start_duration"`
 	EndDuration       string                `json:"end_duration"`
+	EndsAt            *time.Time            `json:"ends_at,omitempty"`
 	NoOfWinner        int                   `json:"no_of_winner"`
-	WinningPrice      []interface{}         `json:"winning_price"`
-	CausePrice        []interface{}         `json:"cause_price"`
+	WinningPrice      []Prize               `json:"winning_price"`
+	RankingCriterion  string                `json:"ranking_criterion"`
+	ClosedAt          *time.Time            `json:"closed_at,omitempty"`
+	CancelledAt       *time.Time            `json:"cancelled_at,omitempty"`
+	CausePrice        []Prize               `json:"cause_price"`
 	CoverImage        string                `json:"cover_image"`
 	VideoUrl          string                `json:"video_url"`
 	Slug              string                `json:"slug"`
@@ -85,6 +115,11 @@ type ChallengeSponsorInfo struct {
 	SponsoredAt time.Time `json:"sponsored_at"`
 }
 
+type ChallengeMemberListResponse struct {
+	Members []ChallengeMemberInfo `json:"members"`
+	Total   int64                 `json:"total"`
+}
+
 type ChallengeListResponse struct {
 	Challenges []ChallengeResponse `json:"challenges"`
 	Total      int                 `json:"total"`
@@ -101,6 +136,8 @@ type CreateCauseRequest struct {
 	ProductDescription string  `json:"product_description,omitempty"`
 	Activity           string  `json:"activity,omitempty" binding:"omitempty,oneof=Walking Running Cycling"`
 	Location           string  `json:"location,omitempty"`
+	Latitude          *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude         *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
 	Description        string  `json:"description,omitempty"`
 	IsCommercial       bool    `json:"is_commercial,omitempty"`
 	WhoIdeaImpact      string  `json:"who_idea_impact,omitempty"`
@@ -123,6 +160,8 @@ type UpdateCauseRequest struct {
 	ProductDescription string   `json:"product_description,omitempty"`
 	Activity           string   `json:"activity,omitempty" binding:"omitempty,oneof=Walking Running Cycling"`
 	Location           string   `json:"location,omitempty"`
+	Latitude          *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude         *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
 	Description        string   `json:"description,omitempty"`
 	IsCommercial       *bool    `json:"is_commercial,omitempty"`
 	WhoIdeaImpact      string   `json:"who_idea_impact,omitempty"`
@@ -147,6 +186,8 @@ type CauseResponse struct {
 	ProductDescription string              `json:"product_description"`
 	Activity           string              `json:"activity"`
 	Location           string              `json:"location"`
+	Latitude          *float64 `json:"latitude,omitempty"`
+	Longitude         *float64 `json:"longitude,omitempty"`
 	Description        string              `json:"description"`
 	IsCommercial       bool                `json:"is_commercial"`
 	WhoIdeaImpact      string              `json:"who_idea_impact"`
@@ -154,10 +195,14 @@ type CauseResponse struct {
 	DistanceCovered    float64             `json:"distance_covered"`
 	AmountPerPiece     float64             `json:"amount_per_piece"`
 	Duration           string              `json:"duration"`
+	DurationSeconds    int64               `json:"duration_seconds"`
 	FundCause          bool                `json:"fund_cause"`
 	FundAmount         float64             `json:"fund_amount"`
 	WillingAmount      float64             `json:"willing_amount"`
 	UnitPrice          float64             `json:"unit_price"`
+	Stock              *int                `json:"stock"`     // null when unlimited
+	UnitsSold          int                 `json:"units_sold"`
+	Available          *int                `json:"available"` // units left, null when unlimited
 	CostToLaunch       string              `json:"cost_to_launch"`
 	BenefitDesc        string              `json:"benefit_desc"`
 	WorkoutImg         string              `json:"workout_img"`
@@ -190,6 +235,11 @@ type CauseSponsorInfo struct {
 	SponsoredAt time.Time `json:"sponsored_at"`
 }
 
+type CauseMemberListResponse struct {
+	Members []CauseMemberInfo `json:"members"`
+	Total   int64             `json:"total"`
+}
+
 type CauseListResponse struct {
 	Causes []CauseResponse `json:"causes"`
 	Total  int             `json:"total"`
@@ -212,7 +262,8 @@ type ParticipateCauseResponse struct {
 // Finish Cause DTOs
 type FinishCauseRequest struct {
 	DistanceCovered float64 `json:"distance_covered" binding:"required"`
-	Duration        string  `json:"duration" binding:"required"`
+	Duration        string  `json:"duration"`
+	DurationSeconds *int64  `json:"duration_seconds,omitempty"`
 	MoneyRaised     float64 `json:"money_raised"`
 }
 
@@ -221,7 +272,8 @@ type RecordActivityRequest struct {
 	CauseID         string  `json:"cause_id" binding:"required"`
 	DistanceToCover float64 `json:"distance_to_cover" binding:"required,gt=0"`
 	DistanceCovered float64 `json:"distance_covered" binding:"required,gt=0"`
-	Duration        string  `json:"duration" binding:"required"`
+	Duration        string  `json:"duration"`                   // e.g. "25:30", "1:02:03", "45 min" or "PT25M30S"
+	DurationSeconds *int64  `json:"duration_seconds,omitempty"` // takes precedence over duration
 	Activity        string  `json:"activity" binding:"required"`
 }
 
@@ -234,6 +286,7 @@ type CauseRunnerResponse struct {
 	DistanceToCover float64   `json:"distance_to_cover"`
 	DistanceCovered float64   `json:"distance_covered"`
 	Duration        string    `json:"duration"`
+	DurationSeconds int64     `json:"duration_seconds"`
 	MoneyRaised     float64   `json:"money_raised"`
 	CoverImage      string    `json:"cover_image"`
 	Activity        string    `json:"activity"`
@@ -281,35 +334,93 @@ type SponsorCauseResponse struct {
 	DateCreated time.Time `json:"date_created"`
 }
 
+// Cause funding DTOs
+
+// SponsorObligationResponse is what one sponsor has pledged to a cause and earned so far.
+type SponsorObligationResponse struct {
+	SponsorID    string  `json:"sponsor_id"`
+	SponsorName  string  `json:"sponsor_name,omitempty"`
+	Sponsorships int     `json:"sponsorships"`
+	Pledged      float64 `json:"pledged"` // owed if the cause covers every pledged km
+	Earned       float64 `json:"earned"`  // owed for the km covered so far
+}
+
+type CauseFundingResponse struct {
+	CauseID         string                      `json:"cause_id"`
+	Goal            float64                     `json:"goal"`
+	DistanceCovered float64                     `json:"distance_covered"`
+	Pledged         float64                     `json:"pledged"`
+	Earned          float64                     `json:"earned"`
+	Progress        float64                     `json:"progress"` // percent of the goal earned
+	Sponsors        []SponsorObligationResponse `json:"sponsors"`
+	SettledAt       *time.Time                  `json:"settled_at,omitempty"`
+}
+
+type SponsorStatementResponse struct {
+	ID              string    `json:"id"`
+	CauseID         string    `json:"cause_id"`
+	SponsorID       string    `json:"sponsor_id"`
+	Sponsorships    int       `json:"sponsorships"`
+	DistanceCovered float64   `json:"distance_covered"`
+	Pledged         float64   `json:"pledged"`
+	AmountDue       float64   `json:"amount_due"`
+	SettledAt       time.Time `json:"settled_at"`
+}
+
+type SponsorStatementListResponse struct {
+	Statements []SponsorStatementResponse `json:"statements"`
+	TotalDue   float64                    `json:"total_due"`
+}
+
 // Buy Cause DTOs
+
+// BuyCauseRequest orders units of a commercial cause. Amount is the total the buyer was shown
+// and must equal quantity times the cause's unit price.
 type BuyCauseRequest struct {
-	CauseID string  `json:"cause_id" binding:"required"`
-	Amount  float64 `json:"amount" binding:"required,gt=0"`
+	CauseID  string  `json:"cause_id" binding:"required"`
+	Quantity int     `json:"quantity" binding:"omitempty,min=1"` // defaults to 1
+	Amount   float64 `json:"amount" binding:"required,gt=0"`
 }
 
-type BuyCauseResponse struct {
-	ID          string    `json:"id"`
-	BuyerID     string    `json:"buyer_id"`
-	CauseID     string    `json:"cause_id"`
-	Amount      float64   `json:"amount"`
-	DateCreated time.Time `json:"date_created"`
+type CauseOrderResponse struct {
+	ID          string     `json:"id"`
+	CauseID     string     `json:"cause_id"`
+	BuyerID     string     `json:"buyer_id"`
+	Quantity    int        `json:"quantity"`
+	UnitPrice   float64    `json:"unit_price"`
+	Amount      float64    `json:"amount"`
+	Status      string     `json:"status"`
+	PaymentRef  string     `json:"payment_ref,omitempty"`
+	PaidAt      *time.Time `json:"paid_at,omitempty"`
+	FulfilledAt *time.Time `json:"fulfilled_at,omitempty"`
+	RefundedAt  *time.Time `json:"refunded_at,omitempty"`
+	DateCreated time.Time  `json:"date_created"`
 }
 
-// Leaderboard DTOs
-type ChallengeLeaderboardEntry struct {
-	UserID          string  `json:"user_id"`
-	Username        string  `json:"username"`
-	FullName        string  `json:"full_name"`
-	DistanceCovered float64 `json:"distance_covered"`
-	MoneyRaised     float64 `json:"money_raised"`
-	Duration        string  `json:"duration"`
-	Activity        string  `json:"activity"`
-	CoverImage      string  `json:"cover_image,omitempty"`
+type CauseOrderListResponse struct {
+	Orders []CauseOrderResponse `json:"orders"`
+	Total  int64                `json:"total"`
+}
+
+type PayCauseOrderRequest struct {
+	PaymentRef string `json:"payment_ref" binding:"required"`
 }
 
+// SetCauseStockRequest sets how many units of a cause are for sale; null lifts the limit.
+type SetCauseStockRequest struct {
+	Stock *int `json:"stock" binding:"omitempty,min=0"`
+}
+
+// Leaderboard DTOs
 type ChallengeLeaderboardResponse struct {
-	ChallengeSlug string                      `json:"challenge_slug"`
-	Leaderboard   []ChallengeLeaderboardEntry `json:"leaderboard"`
+	ChallengeID   string             `json:"challenge_id"`
+	ChallengeSlug string             `json:"challenge_slug"`
+	Window        string             `json:"window"`
+	Activity      string             `json:"activity,omitempty"`
+	Total         int                `json:"total"` // ranked users on the whole board
+	Leaderboard   []LeaderboardEntry `json:"leaderboard"`
+	Me            *LeaderboardEntry  `json:"me,omitempty"`
+	Neighbours    []LeaderboardEntry `json:"neighbours,omitempty"`
 }
 
 // General Leaderboard Response
@@ -317,22 +428,15 @@ type LeaderboardResponse struct {
 	Runners []CauseRunnerResponse `json:"runners"`
 }
 
-type CauseLeaderboardEntry struct {
-	CauseID         string  `json:"cause_id"`
-	CauseName       string  `json:"cause_name"`
-	UserID          string  `json:"user_id"`
-	Username        string  `json:"username"`
-	FullName        string  `json:"full_name"`
-	DistanceCovered float64 `json:"distance_covered"`
-	MoneyRaised     float64 `json:"money_raised"`
-	Duration        string  `json:"duration"`
-	Activity        string  `json:"activity"`
-	CoverImage      string  `json:"cover_image,omitempty"`
-}
-
 type CauseLeaderboardResponse struct {
-	ChallengeSlug string                  `json:"challenge_slug"`
-	Leaderboard   []CauseLeaderboardEntry `json:"leaderboard"`
+	CauseID     string             `json:"cause_id"`
+	CauseName   string             `json:"cause_name"`
+	Window      string             `json:"window"`
+	Activity    string             `json:"activity,omitempty"`
+	Total       int                `json:"total"` // ranked users on the whole board
+	Leaderboard []LeaderboardEntry `json:"leaderboard"`
+	Me          *LeaderboardEntry  `json:"me,omitempty"`
+	Neighbours  []LeaderboardEntry `json:"neighbours,omitempty"`
 }
 
 // Join responses
@@ -354,3 +458,193 @@ type SearchChallengesRequest struct {
 	Page  int    `form:"page,default=1" binding:"min=1"`
 	Limit int    `form:"limit,default=10" binding:"min=1,max=100"`
 }
+
+// ChallengeWinnerResponse is one placing in a closed challenge.
+type ChallengeWinnerResponse struct {
+	Rank            int         `json:"rank"`
+	UserID          string      `json:"user_id"`
+	Username        string      `json:"username,omitempty"`
+	FullName        string      `json:"full_name,omitempty"`
+	Distance        float64     `json:"distance"`
+	Duration        string      `json:"duration"`
+	DurationSeconds int64       `json:"duration_seconds"`
+	MoneyRaised     float64     `json:"money_raised"`
+	Prize           *Prize      `json:"prize,omitempty"`
+	Overridden      bool        `json:"overridden"`
+}
+
+// ChallengeWinnersResponse is the outcome of a challenge. Winners is empty until it closes.
+type ChallengeWinnersResponse struct {
+	ChallengeID      string                    `json:"challenge_id"`
+	RankingCriterion string                    `json:"ranking_criterion"`
+	ClosedAt         *time.Time                `json:"closed_at,omitempty"`
+	Winners          []ChallengeWinnerResponse `json:"winners"`
+}
+
+// OverrideChallengeWinnersRequest replaces a closed challenge's winners.
+type OverrideChallengeWinnersRequest struct {
+	UserIDs []string `json:"user_ids"`                   // winners in rank order, first place first
+	Reason  string   `json:"reason" binding:"required"` // recorded in the audit trail
+}
+
+// WinnerAuditResponse is one staff override of a challenge's winners.
+type WinnerAuditResponse struct {
+	ID        string    `json:"id"`
+	ActorID   string    `json:"actor_id"`
+	Reason    string    `json:"reason"`
+	Previous  []string  `json:"previous"`
+	Winners   []string  `json:"winners"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WinnerAuditListResponse struct {
+	Audits []WinnerAuditResponse `json:"audits"`
+}
+
+// JudgingCriterion is one line of a judging rubric.
+type JudgingCriterion struct {
+	Name     string  `json:"name" binding:"required"`
+	Weight   float64 `json:"weight" binding:"gt=0"`    // how much it counts against the other criteria
+	MaxScore float64 `json:"max_score" binding:"gt=0"` // judges rate it from 0 to this
+}
+
+// CreateJudgingRoundRequest adds a judging round to a challenge.
+type CreateJudgingRoundRequest struct {
+	Name      string             `json:"name" binding:"required"`
+	OpensAt   time.Time          `json:"opens_at" binding:"required"`
+	ClosesAt  time.Time          `json:"closes_at" binding:"required"`
+	Rubric    []JudgingCriterion `json:"rubric" binding:"required,min=1,dive"`
+	VoteShare float64            `json:"vote_share" binding:"min=0,max=1"` // part of the score from community votes
+	Final     bool               `json:"final"`                            // results award the challenge's cause prizes
+}
+
+// JudgingRoundResponse is a judging round with its status at the time of the request.
+type JudgingRoundResponse struct {
+	ID          string             `json:"id"`
+	ChallengeID string             `json:"challenge_id"`
+	Name        string             `json:"name"`
+	Status      string             `json:"status"`
+	OpensAt     time.Time          `json:"opens_at"`
+	ClosesAt    time.Time          `json:"closes_at"`
+	Rubric      []JudgingCriterion `json:"rubric"`
+	VoteShare   float64            `json:"vote_share"`
+	Final       bool               `json:"final"`
+	ClosedAt    *time.Time         `json:"closed_at,omitempty"`
+	Judges      []JudgeResponse    `json:"judges,omitempty"`
+}
+
+type JudgingRoundListResponse struct {
+	Rounds []JudgingRoundResponse `json:"rounds"`
+}
+
+// AppointJudgeRequest makes a user a judge of a round.
+type AppointJudgeRequest struct {
+	UserID string  `json:"user_id" binding:"required"`
+	Weight float64 `json:"weight" binding:"min=0"` // defaults to 1
+}
+
+type JudgeResponse struct {
+	UserID      string    `json:"user_id"`
+	Username    string    `json:"username,omitempty"`
+	Weight      float64   `json:"weight"`
+	AppointedBy string    `json:"appointed_by"`
+	AppointedAt time.Time `json:"appointed_at"`
+}
+
+// ScoreCauseRequest is a judge's rating of a cause against every rubric criterion.
+type ScoreCauseRequest struct {
+	CauseID string             `json:"cause_id" binding:"required"`
+	Scores  map[string]float64 `json:"scores" binding:"required"` // rating per criterion name
+	Comment string             `json:"comment"`
+}
+
+type JudgeScoreResponse struct {
+	CauseID   string             `json:"cause_id"`
+	JudgeID   string             `json:"judge_id"`
+	Scores    map[string]float64 `json:"scores"`
+	Total     float64            `json:"total"` // weighted score out of 100
+	Comment   string             `json:"comment,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+type JudgeScoreListResponse struct {
+	Scores []JudgeScoreResponse `json:"scores"`
+}
+
+// CastVoteRequest is a member's community vote in a round.
+type CastVoteRequest struct {
+	CauseID string `json:"cause_id" binding:"required"`
+}
+
+type VoteResponse struct {
+	RoundID string    `json:"round_id"`
+	CauseID string    `json:"cause_id"`
+	VotedAt time.Time `json:"voted_at"`
+}
+
+// JudgingResultResponse is one cause's placing in a closed round.
+type JudgingResultResponse struct {
+	Rank       int         `json:"rank"`
+	CauseID    string      `json:"cause_id"`
+	CauseName  string      `json:"cause_name,omitempty"`
+	JudgeScore float64     `json:"judge_score"` // out of 100
+	Votes      int         `json:"votes"`
+	Score      float64     `json:"score"` // judge and vote scores blended, out of 100
+	Prize      *Prize      `json:"prize,omitempty"`
+}
+
+type JudgingResultsResponse struct {
+	RoundID  string                  `json:"round_id,omitempty"`
+	ClosedAt *time.Time              `json:"closed_at,omitempty"`
+	Results  []JudgingResultResponse `json:"results"`
+}
+
+// CreateBracketRequest draws a knockout bracket for a challenge's members.
+type CreateBracketRequest struct {
+	Seeding   string    `json:"seeding" binding:"required,oneof=random distance"` // random, or most past distance first
+	StartsAt  time.Time `json:"starts_at" binding:"required"`                     // when the first round opens
+	RoundDays int       `json:"round_days" binding:"min=0,max=365"`               // length of each round, a week when 0
+}
+
+// BracketEntrant is one side of a bracket match.
+type BracketEntrant struct {
+	UserID   string  `json:"user_id"`
+	Username string  `json:"username,omitempty"`
+	Seed     int     `json:"seed"`
+	Distance float64 `json:"distance"` // counted distance logged in the round
+}
+
+// BracketMatchResponse is a match with its sides; a side is absent until it is known, and in
+// the first round an absent side is a bye.
+type BracketMatchResponse struct {
+	ID        string          `json:"id"`
+	Round     int             `json:"round"`
+	Slot      int             `json:"slot"`
+	Home      *BracketEntrant `json:"home,omitempty"`
+	Away      *BracketEntrant `json:"away,omitempty"`
+	OpensAt   time.Time       `json:"opens_at"`
+	ClosesAt  time.Time       `json:"closes_at"`
+	Outcome   string          `json:"outcome"` // pending, raced, bye or walkover
+	WinnerID  string          `json:"winner_id,omitempty"`
+	DecidedAt *time.Time      `json:"decided_at,omitempty"`
+}
+
+// BracketNodeResponse is a match with the two matches whose winners meet in it, home first.
+type BracketNodeResponse struct {
+	Match   BracketMatchResponse  `json:"match"`
+	Feeders []BracketNodeResponse `json:"feeders,omitempty"`
+}
+
+// BracketResponse is a challenge's bracket as a tree rooted at the final.
+type BracketResponse struct {
+	ID          string               `json:"id"`
+	ChallengeID string               `json:"challenge_id"`
+	Seeding     string               `json:"seeding"`
+	StartsAt    time.Time            `json:"starts_at"`
+	RoundDays   float64              `json:"round_days"`
+	Entrants    int                  `json:"entrants"`
+	Rounds      int                  `json:"rounds"`
+	ChampionID  string               `json:"champion_id,omitempty"`
+	CompletedAt *time.Time           `json:"completed_at,omitempty"`
+	Final       *BracketNodeResponse `json:"final"`
+}
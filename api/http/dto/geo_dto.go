@@ -0,0 +1,8 @@
+package dto
+
+// NearQuery limits a listing to records positioned within radius_km of near, given as
+// "lat,lon".
+type NearQuery struct {
+	Near     string  `form:"near"`
+	RadiusKm float64 `form:"radius_km,default=25" binding:"gt=0,max=1000"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+// LeaderboardQuery filters a challenge, cause or campaign leaderboard. Signed-in callers also
+// get their own place with up to neighbours users either side.
+type LeaderboardQuery struct {
+	Window     string `form:"window"` // daily, weekly, monthly or all_time (default)
+	Activity   string `form:"activity"`
+	Neighbours int    `form:"neighbours,default=2" binding:"min=0,max=10"`
+}
+
+// LeaderboardEntry is one user's totals and place on a leaderboard.
+type LeaderboardEntry struct {
+	Rank            int     `json:"rank"`
+	UserID          string  `json:"user_id"`
+	Username        string  `json:"username"`
+	FullName        string  `json:"full_name"`
+	DistanceCovered float64 `json:"distance_covered"`
+	MoneyRaised     float64 `json:"money_raised"`
+	Duration        string  `json:"duration"`
+	DurationSeconds int64   `json:"duration_seconds"`
+	Activity        string  `json:"activity"` // empty when the user logged more than one activity
+	CoverImage      string  `json:"cover_image,omitempty"`
+	Runs            int     `json:"runs"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import "time"
+
+// MediaVideoResponse is the oEmbed-style embed description of a gallery video.
+type MediaVideoResponse struct {
+	Provider     string `json:"provider"` // YouTube, Vimeo or Dailymotion
+	ProviderURL  string `json:"provider_url"`
+	VideoID      string `json:"video_id"`
+	EmbedURL     string `json:"embed_url"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	HTML         string `json:"html"` // iframe to embed
+}
+
+// MediaItemResponse is one gallery item. URL is the image, or the video's page; Video is set
+// for videos only.
+type MediaItemResponse struct {
+	ID          string              `json:"id"`
+	Type        string              `json:"type"` // image or video
+	Position    int                 `json:"position"`
+	Caption     string              `json:"caption"`
+	URL         string              `json:"url"`
+	ContentType string              `json:"content_type,omitempty"`
+	Size        int64               `json:"size,omitempty"` // bytes
+	Video       *MediaVideoResponse `json:"video,omitempty"`
+	UploaderID  string              `json:"uploader_id"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+type MediaGalleryResponse struct {
+	SubjectKind string              `json:"subject_kind"` // challenge, cause or campaign
+	SubjectID   string              `json:"subject_id"`
+	Items       []MediaItemResponse `json:"items"`
+	Count       int                 `json:"count"`
+}
+
+type AddGalleryVideoRequest struct {
+	URL     string `json:"url" binding:"required"` // YouTube, Vimeo or Dailymotion link
+	Caption string `json:"caption"`
+}
+
+type UpdateGalleryCaptionRequest struct {
+	Caption string `json:"caption"`
+}
+
+// ReorderGalleryRequest lists every item in the gallery, in the new order.
+type ReorderGalleryRequest struct {
+	ItemIDs []string `json:"item_ids" binding:"required"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+type NotificationResponse struct {
+	ID          string     `json:"id"`
+	Kind        string     `json:"kind"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	Link        string     `json:"link,omitempty"`
+	Read        bool       `json:"read"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+	DateCreated time.Time  `json:"date_created"`
+}
+
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	Count         int                    `json:"count"`
+	Unread        int64                  `json:"unread"`
+}
+
+type MarkAllReadResponse struct {
+	Marked int64 `json:"marked"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+// TrackSplit summarises one kilometre of an uploaded track; the last split may be shorter.
+type TrackSplit struct {
+	Index         int     `json:"index"`
+	Distance      float64 `json:"distance"`       // km
+	Seconds       float64 `json:"seconds"`        // 0 when the file has no timestamps
+	ElevationGain float64 `json:"elevation_gain"` // meters
+}
+
+// GeoJSONGeometry is a GeoJSON LineString; coordinates are [lon, lat] or [lon, lat, ele].
+type GeoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+// GeoJSONFeature is a GeoJSON Feature wrapping a route geometry.
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   GeoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// TrackUploadResponse reports the figures computed from an uploaded GPX/TCX file.
+type TrackUploadResponse struct {
+	RunnerID      string              `json:"runner_id"`
+	Status        string              `json:"status"` // accepted, or flagged when held for review
+	Violations    []ActivityViolation `json:"violations,omitempty"`
+	Format        string              `json:"format"`         // gpx or tcx
+	Distance      float64             `json:"distance"`       // km
+	MovingTime    int64               `json:"moving_time"`    // seconds
+	ElapsedTime   int64               `json:"elapsed_time"`   // seconds
+	Duration      string              `json:"duration"`       // moving time as stored on the runner
+	ElevationGain float64             `json:"elevation_gain"` // meters
+	Splits        []TrackSplit        `json:"splits"`
+	TrackURL      string              `json:"track_url"`
+	Route         GeoJSONFeature      `json:"route"` // simplified route for map rendering
+}
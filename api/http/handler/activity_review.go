@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/apperr"
+	activityModel "gopi.com/internal/domain/activity/model"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+)
+
+// heldViolations reports whether err means the activity was saved but held for review,
+// returning the rules it broke.
+func heldViolations(err error) ([]dto.ActivityViolation, bool) {
+	var held *activityModel.HeldForReviewError
+	if !errors.As(err, &held) {
+		return nil, false
+	}
+	return violationsToResponse(held.Violations), true
+}
+
+// respondHeldForReview writes a 202 for activities held by anti-cheat and reports whether it did.
+func respondHeldForReview(c *gin.Context, err error) bool {
+	violations, ok := heldViolations(err)
+	if !ok {
+		return false
+	}
+	c.JSON(http.StatusAccepted, dto.ActivityHeldResponse{
+		Message:    "Activity recorded and held for review",
+		Status:     string(activityModel.ReviewStatusFlagged),
+		Violations: violations,
+	})
+	return true
+}
+
+func violationsToResponse(violations []activityModel.Violation) []dto.ActivityViolation {
+	out := make([]dto.ActivityViolation, 0, len(violations))
+	for _, v := range violations {
+		out = append(out, dto.ActivityViolation{Rule: v.Rule, Detail: v.Detail})
+	}
+	return out
+}
+
+func campaignRunToResponse(run *campaignModel.CampaignRun) dto.CampaignRunResponse {
+	return dto.CampaignRunResponse{
+		ID:              run.ID,
+		CampaignID:      run.CampaignID,
+		RunnerID:        run.RunnerID,
+		UserID:          run.OwnerID,
+		Activity:        run.Activity,
+		Distance:        run.Distance,
+		Duration:        formatDuration(run.Duration),
+		DurationSeconds: model.Seconds(run.Duration),
+		MoneyRaised:     run.MoneyRaised,
+		TrackURL:        run.TrackURL,
+		ElevationGain:   run.ElevationGain,
+		Status:          string(run.Status),
+		Violations:      violationsToResponse(run.Violations),
+		ReviewedBy:      run.ReviewedBy,
+		ReviewedAt:      run.ReviewedAt,
+		CreatedAt:       run.CreatedAt,
+	}
+}
+
+func causeRunnerToReviewResponse(runner *challengeModel.CauseRunner) dto.CauseRunnerReviewResponse {
+	return dto.CauseRunnerReviewResponse{
+		ID:              runner.ID,
+		CauseID:         runner.CauseID,
+		UserID:          runner.OwnerID,
+		Activity:        runner.Activity,
+		DistanceCovered: runner.DistanceCovered,
+		Duration:        formatDuration(runner.Duration),
+		DurationSeconds: model.Seconds(runner.Duration),
+		TrackURL:        runner.TrackURL,
+		Status:          string(runner.Status),
+		Violations:      violationsToResponse(runner.Violations),
+		ReviewedBy:      runner.ReviewedBy,
+		ReviewedAt:      runner.ReviewedAt,
+		DateJoined:      runner.DateJoined,
+	}
+}
+
+// reviewPage reads page and limit query parameters, clamped like the other admin listings.
+func reviewPage(c *gin.Context) (page, limit int) {
+	page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ = strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return page, limit
+}
+
+// ListFlaggedRuns godoc
+// @Summary List campaign runs held for review (Admin)
+// @Description Get campaign runs flagged by anti-cheat checks, oldest first
+// @Tags admin-activity-review
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(20)
+// @Success 200 {object} dto.CampaignRunListResponse "Flagged runs retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff privileges required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/admin/flagged-runs [get]
+func (h *CampaignAdminHandler) ListFlaggedRuns(c *gin.Context) {
+	page, limit := reviewPage(c)
+	runs, err := h.campaignService.ListFlaggedRuns(limit, (page-1)*limit)
+	if err != nil {
+		respondError(c, apperr.E("ListFlaggedRuns", apperr.Internal, err, "Failed to fetch flagged runs"))
+		return
+	}
+
+	responses := make([]dto.CampaignRunResponse, 0, len(runs))
+	for _, run := range runs {
+		responses = append(responses, campaignRunToResponse(run))
+	}
+	c.JSON(http.StatusOK, dto.CampaignRunListResponse{Runs: responses, Page: page, Limit: limit})
+}
+
+// ApproveRun godoc
+// @Summary Approve a flagged campaign run (Admin)
+// @Description Approve a run held by anti-cheat checks and credit it to the runner and campaign totals. Runs on a campaign that no longer accepts activity or has closed can only be rejected.
+// @Tags admin-activity-review
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Campaign run ID"
+// @Success 200 {object} dto.CampaignRunResponse "Run approved"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff privileges required"
+// @Failure 404 {object} dto.ErrorResponse "Run not found"
+// @Failure 409 {object} dto.ErrorResponse "Run is not awaiting review, or the campaign no longer accepts activity"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/admin/flagged-runs/{id}/approve [post]
+func (h *CampaignAdminHandler) ApproveRun(c *gin.Context) {
+	h.reviewRun(c, "ApproveRun", true)
+}
+
+// RejectRun godoc
+// @Summary Reject a flagged campaign run (Admin)
+// @Description Reject a run held by anti-cheat checks; it is never credited
+// @Tags admin-activity-review
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Campaign run ID"
+// @Success 200 {object} dto.CampaignRunResponse "Run rejected"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff privileges required"
+// @Failure 404 {object} dto.ErrorResponse "Run not found"
+// @Failure 409 {object} dto.ErrorResponse "Run is not awaiting review"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/admin/flagged-runs/{id}/reject [post]
+func (h *CampaignAdminHandler) RejectRun(c *gin.Context) {
+	h.reviewRun(c, "RejectRun", false)
+}
+
+func (h *CampaignAdminHandler) reviewRun(c *gin.Context, op string, approve bool) {
+	reviewerID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E(op, apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+	id := c.Param("id")
+	if _, err := h.campaignService.GetRunByID(id); err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Run not found"))
+		return
+	}
+
+	run, err := h.campaignService.ReviewRun(id, reviewerID.(string), approve)
+	if err != nil {
+		if errors.Is(err, activityModel.ErrNotFlagged) {
+			respondError(c, apperr.E(op, apperr.Conflict, err, "Run is not awaiting review"))
+			return
+		}
+		if errors.Is(err, campaignModel.ErrInvalidCampaignState) {
+			respondError(c, apperr.E(op, apperr.Conflict, err, err.Error()))
+			return
+		}
+		respondError(c, apperr.E(op, apperr.Internal, err, "Failed to review run"))
+		return
+	}
+	c.JSON(http.StatusOK, campaignRunToResponse(run))
+}
+
+// ListFlaggedCauseRunners godoc
+// @Summary List cause activities held for review (Admin)
+// @Description Get cause activities flagged by anti-cheat checks, oldest first
+// @Tags admin-activity-review
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(20)
+// @Success 200 {object} dto.CauseRunnerReviewListResponse "Flagged activities retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff privileges required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/admin/flagged-runners [get]
+func (h *ChallengeHandler) ListFlaggedCauseRunners(c *gin.Context) {
+	page, limit := reviewPage(c)
+	runners, err := h.challengeService.ListFlaggedCauseRunners(limit, (page-1)*limit)
+	if err != nil {
+		respondError(c, apperr.E("ListFlaggedCauseRunners", apperr.Internal, err, "Failed to fetch flagged activities"))
+		return
+	}
+
+	responses := make([]dto.CauseRunnerReviewResponse, 0, len(runners))
+	for _, runner := range runners {
+		responses = append(responses, causeRunnerToReviewResponse(runner))
+	}
+	c.JSON(http.StatusOK, dto.CauseRunnerReviewListResponse{Runners: responses, Page: page, Limit: limit})
+}
+
+// ApproveCauseRunner godoc
+// @Summary Approve a flagged cause activity (Admin)
+// @Description Approve an activity held by anti-cheat checks and add its distance to the cause
+// @Tags admin-activity-review
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Cause runner ID"
+// @Success 200 {object} dto.CauseRunnerReviewResponse "Activity approved"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff privileges required"
+// @Failure 404 {object} dto.ErrorResponse "Cause runner not found"
+// @Failure 409 {object} dto.ErrorResponse "Activity is not awaiting review"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/admin/flagged-runners/{id}/approve [post]
+func (h *ChallengeHandler) ApproveCauseRunner(c *gin.Context) {
+	h.reviewCauseRunner(c, "ApproveCauseRunner", true)
+}
+
+// RejectCauseRunner godoc
+// @Summary Reject a flagged cause activity (Admin)
+// @Description Reject an activity held by anti-cheat checks; it stays out of the cause total and leaderboard
+// @Tags admin-activity-review
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Cause runner ID"
+// @Success 200 {object} dto.CauseRunnerReviewResponse "Activity rejected"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff privileges required"
+// @Failure 404 {object} dto.ErrorResponse "Cause runner not found"
+// @Failure 409 {object} dto.ErrorResponse "Activity is not awaiting review"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/admin/flagged-runners/{id}/reject [post]
+func (h *ChallengeHandler) RejectCauseRunner(c *gin.Context) {
+	h.reviewCauseRunner(c, "RejectCauseRunner", false)
+}
+
+func (h *ChallengeHandler) reviewCauseRunner(c *gin.Context, op string, approve bool) {
+	reviewerID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E(op, apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+	id := c.Param("id")
+	if _, err := h.challengeService.GetCauseRunnerByID(id); err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Cause runner not found"))
+		return
+	}
+
+	runner, err := h.challengeService.ReviewCauseRunner(id, reviewerID.(string), approve)
+	if err != nil {
+		if errors.Is(err, activityModel.ErrNotFlagged) {
+			respondError(c, apperr.E(op, apperr.Conflict, err, "Activity is not awaiting review"))
+			return
+		}
+		respondError(c, apperr.E(op, apperr.Internal, err, "Failed to review activity"))
+		return
+	}
+	c.JSON(http.StatusOK, causeRunnerToReviewResponse(runner))
+}
@@ -11,6 +11,7 @@ import (
 	"gopi.com/internal/app/user"
 	"gopi.com/internal/apperr"
 	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
 	userModel "gopi.com/internal/domain/user/model"
 )
 
@@ -71,9 +72,17 @@ func (h *CampaignAdminHandler) CreateCampaignRunner(c *gin.Context) {
 	}
 
 	// Update runner with additional details if provided
-	if req.DistanceCovered > 0 || req.MoneyRaised > 0 || req.Duration != "" {
-		err = h.campaignService.FinishActivity(runner.ID, req.DistanceCovered, req.Duration, req.MoneyRaised)
+	duration, hasDuration, err := requestDuration(req.Duration, req.DurationSeconds)
+	if err != nil {
+		respondError(c, apperr.E("CreateCampaignRunner", apperr.InvalidInput, err, err.Error()))
+		return
+	}
+	if req.DistanceCovered > 0 || req.MoneyRaised > 0 || hasDuration {
+		err = h.campaignService.FinishActivity(runner.ID, req.DistanceCovered, duration, req.MoneyRaised)
 		if err != nil {
+			if respondHeldForReview(c, err) {
+				return
+			}
 			respondError(c, apperr.E("CreateCampaignRunner", apperr.Internal, err, "Failed to update campaign runner"))
 			return
 		}
@@ -137,7 +146,7 @@ func (h *CampaignAdminHandler) GetCampaignRunners(c *gin.Context) {
 	} else {
 		// Get all runners - we'll need to implement this in service
 		// For now, get a representative sample by getting runners from recent campaigns
-		campaigns, campErr := h.campaignService.ListCampaigns(10, 0)
+		campaigns, campErr := h.campaignService.ListAllCampaigns(10, 0)
 		if campErr != nil {
 			respondError(c, apperr.E("GetCampaignRunners", apperr.Internal, campErr, "Failed to fetch campaigns"))
 			return
@@ -242,8 +251,13 @@ func (h *CampaignAdminHandler) UpdateCampaignRunner(c *gin.Context) {
 	if req.DistanceCovered != nil {
 		runner.DistanceCovered = *req.DistanceCovered
 	}
-	if req.Duration != "" {
-		runner.Duration = req.Duration
+	duration, hasDuration, err := requestDuration(req.Duration, req.DurationSeconds)
+	if err != nil {
+		respondError(c, apperr.E("UpdateCampaignRunner", apperr.InvalidInput, err, err.Error()))
+		return
+	}
+	if hasDuration {
+		runner.Duration = duration
 	}
 	if req.MoneyRaised != nil {
 		runner.MoneyRaised = *req.MoneyRaised
@@ -334,14 +348,9 @@ func (h *CampaignAdminHandler) CreateSponsorCampaign(c *gin.Context) {
 		return
 	}
 
-	// Prepare sponsors as []interface{} (for compatibility with new domain model)
-	var sponsors []interface{}
-	if len(req.SponsorIDs) > 0 {
-		for _, id := range req.SponsorIDs {
-			sponsors = append(sponsors, id)
-		}
-	} else if req.SponsorID != "" {
-		sponsors = append(sponsors, req.SponsorID)
+	sponsors := campaignModel.UsersByID(req.SponsorIDs...)
+	if len(sponsors) == 0 {
+		sponsors = campaignModel.UsersByID(req.SponsorID)
 	}
 
 	// Implement sponsor campaign creation
@@ -407,7 +416,7 @@ func (h *CampaignAdminHandler) GetSponsorCampaigns(c *gin.Context) {
 		sponsors, err = h.campaignService.GetSponsorCampaignsByCampaign(campaignID)
 	} else {
 		// Get sponsors from all recent campaigns
-		campaigns, campErr := h.campaignService.ListCampaigns(10, 0)
+		campaigns, campErr := h.campaignService.ListAllCampaigns(10, 0)
 		if campErr != nil {
 			respondError(c, apperr.E("GetSponsorCampaigns", apperr.Internal, campErr, "Failed to fetch campaigns"))
 			return
@@ -475,11 +484,7 @@ func (h *CampaignAdminHandler) GetSponsorCampaignByID(c *gin.Context) {
 	}
 
 	if len(sponsor.Sponsors) > 0 {
-		if id, ok := sponsor.Sponsors[0].(string); ok {
-			response.Sponsor = id // Use first sponsor ID
-		} else {
-			response.Sponsor = "" // fallback if not string
-		}
+		response.Sponsor = sponsor.Sponsors[0].ID // Use first sponsor ID
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -590,7 +595,8 @@ func (h *CampaignAdminHandler) campaignRunnerToResponse(runner *campaignModel.Ca
 		RunnerID:        runner.ID,
 		Username:        username,
 		DistanceCovered: runner.DistanceCovered,
-		Duration:        runner.Duration,
+		Duration:        formatDuration(runner.Duration),
+		DurationSeconds: model.Seconds(runner.Duration),
 		MoneyRaised:     runner.MoneyRaised,
 		CampaignID:      runner.CampaignID,
 		CoverImage:      runner.CoverImage,
@@ -609,6 +615,7 @@ func (h *CampaignAdminHandler) sponsorCampaignToResponse(sponsor *campaignModel.
 		TotalAmount: sponsor.TotalAmount,
 		BrandImg:    sponsor.BrandImg,
 		VideoUrl:    sponsor.VideoUrl,
+		Status:      string(sponsor.Status),
 		DateCreated: sponsor.CreatedAt,
 		Paystack:    nil, // Payment integration would go here
 	}
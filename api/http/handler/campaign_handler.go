@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gopi.com/api/http/dto"
@@ -10,6 +12,7 @@ import (
 	"gopi.com/internal/app/user"
 	"gopi.com/internal/apperr"
 	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
 	userModel "gopi.com/internal/domain/user/model"
 )
 
@@ -51,6 +54,11 @@ func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 		respondError(c, apperr.E("CreateCampaign", apperr.InvalidInput, err, "Invalid request body"))
 		return
 	}
+	coordinates, err := requestCoordinates(req.Latitude, req.Longitude)
+	if err != nil {
+		respondError(c, apperr.E("CreateCampaign", apperr.InvalidInput, err, err.Error()))
+		return
+	}
 
 	// Get user details for slug generation
 	user, err := h.userService.GetUserByID(userID.(string))
@@ -59,8 +67,21 @@ func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 		return
 	}
 
+	startDuration, endDuration := req.StartDuration, req.EndDuration
+	if req.StartsAt != nil {
+		startDuration = req.StartsAt.UTC().Format(time.RFC3339)
+	}
+	if req.EndsAt != nil {
+		endDuration = req.EndsAt.UTC().Format(time.RFC3339)
+	}
+
+	create := h.campaignService.CreateCampaign
+	if req.Draft {
+		create = h.campaignService.CreateDraftCampaign
+	}
+
 	// Create campaign
-	campaign, err := h.campaignService.CreateCampaign(
+	campaign, err := create(
 		userID.(string),
 		user.Username,
 		req.Name,
@@ -73,10 +94,16 @@ func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 		req.TargetAmount,
 		req.TargetAmountPerKm,
 		req.DistanceToCover,
-		req.StartDuration,
-		req.EndDuration,
+		startDuration,
+		endDuration,
+		coordinates,
+		campaignModel.CampaignVisibility(req.Visibility),
 	)
 	if err != nil {
+		if errors.Is(err, campaignModel.ErrInvalidCampaignSchedule) {
+			respondError(c, apperr.E("CreateCampaign", apperr.InvalidInput, err, err.Error()))
+			return
+		}
 		respondError(c, apperr.E("CreateCampaign", apperr.Internal, err, "Failed to create campaign"))
 		return
 	}
@@ -87,7 +114,7 @@ func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 
 // GetCampaigns godoc
 // @Summary List all campaigns
-// @Description Get a paginated list of all campaigns
+// @Description Get a paginated list of public campaigns, plus unlisted and private ones the signed-in user owns or belongs to
 // @Tags campaigns
 // @Accept json
 // @Produce json
@@ -110,7 +137,7 @@ func (h *CampaignHandler) GetCampaigns(c *gin.Context) {
 	
 	offset := (page - 1) * limit
 
-	campaigns, err := h.campaignService.ListCampaigns(limit, offset)
+	campaigns, err := h.campaignService.ListCampaigns(c.GetString("user_id"), limit, offset)
 	if err != nil {
 		respondError(c, apperr.E("GetCampaigns", apperr.Internal, err, "Failed to fetch campaigns"))
 		return
@@ -132,9 +159,89 @@ func (h *CampaignHandler) GetCampaigns(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// SearchCampaigns godoc
+// @Summary Search campaigns
+// @Description Search campaigns by text with optional filters and sorting. Text matches name, description and location.
+// @Tags campaigns
+// @Produce json
+// @Param q query string false "Search text"
+// @Param activity query string false "Activity" Enums(Walking, Running, Cycling)
+// @Param mode query string false "Mode" Enums(Free, Paid)
+// @Param location query string false "Location contains"
+// @Param status query string false "Lifecycle status" Enums(draft, scheduled, active, completed, cancelled)
+// @Param from query string false "Still running on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Started on or before this date (YYYY-MM-DD)"
+// @Param min_target query number false "Minimum target amount"
+// @Param max_target query number false "Maximum target amount"
+// @Param near query string false "Only campaigns within radius_km of this position (lat,lon)"
+// @Param radius_km query number false "Search radius in km, used with near" default(25)
+// @Param sort query string false "Sort order; nearest requires near" Enums(newest, most_funded, closest_to_goal, nearest) default(newest)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} dto.CampaignListResponse "Matching campaigns with the total number of matches"
+// @Failure 400 {object} dto.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/search [get]
+func (h *CampaignHandler) SearchCampaigns(c *gin.Context) {
+	var req dto.SearchCampaignsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, apperr.E("SearchCampaigns", apperr.InvalidInput, err, "Invalid query parameters"))
+		return
+	}
+	near, err := requestNear(req.Near, req.RadiusKm)
+	if err != nil {
+		respondError(c, apperr.E("SearchCampaigns", apperr.InvalidInput, err, "near must be lat,lon"))
+		return
+	}
+	if near == nil && req.Sort == string(campaignModel.CampaignSortNearest) {
+		respondError(c, apperr.E("SearchCampaigns", apperr.InvalidInput, nil, "sort=nearest requires near"))
+		return
+	}
+
+	filter := campaignModel.CampaignFilter{
+		Query:     req.Query,
+		Activity:  campaignModel.Activity(req.Activity),
+		Mode:      campaignModel.CampaignMode(req.Mode),
+		Location:  req.Location,
+		Status:    campaignModel.CampaignStatus(req.Status),
+		From:      req.From,
+		MinTarget: req.MinTarget,
+		MaxTarget: req.MaxTarget,
+		Near:      near,
+		Listed:    true,
+		ViewerID:  c.GetString("user_id"),
+		Sort:      campaignModel.CampaignSort(req.Sort),
+		Limit:     req.Limit,
+		Offset:    (req.Page - 1) * req.Limit,
+	}
+	if req.To != nil {
+		endOfDay := req.To.Add(24*time.Hour - time.Nanosecond)
+		filter.To = &endOfDay
+	}
+
+	campaigns, total, err := h.campaignService.SearchCampaigns(filter)
+	if err != nil {
+		respondError(c, apperr.E("SearchCampaigns", apperr.Internal, err, "Failed to search campaigns"))
+		return
+	}
+
+	responses := []dto.CampaignResponse{}
+	for _, campaign := range campaigns {
+		owner, _ := h.userService.GetUserByID(campaign.OwnerID)
+		responses = append(responses, h.campaignToResponse(campaign, owner))
+	}
+
+	c.JSON(http.StatusOK, dto.CampaignListResponse{
+		Campaigns: responses,
+		Total:     int(total),
+		Page:      req.Page,
+		Limit:     req.Limit,
+	})
+}
+
 // GetCampaignBySlug godoc
 // @Summary Get campaign by slug
-// @Description Get a specific campaign by its slug
+// @Description Get a specific campaign by its slug. Private campaigns are only shown to their owner and members.
 // @Tags campaigns
 // @Accept json
 // @Produce json
@@ -151,12 +258,27 @@ func (h *CampaignHandler) GetCampaignBySlug(c *gin.Context) {
 		respondError(c, apperr.E("GetCampaignBySlug", apperr.NotFound, err, "Campaign not found"))
 		return
 	}
+	if !campaign.VisibleTo(c.GetString("user_id")) && !c.GetBool("is_staff") {
+		respondError(c, apperr.E("GetCampaignBySlug", apperr.NotFound, nil, "Campaign not found"))
+		return
+	}
 
 	owner, _ := h.userService.GetUserByID(campaign.OwnerID)
 	response := h.campaignToResponse(campaign, owner)
 	c.JSON(http.StatusOK, response)
 }
 
+// visibleCampaign loads the :slug campaign for a viewer allowed to see it, writing a 404 when it
+// is missing or private to them, as GetCampaignBySlug does.
+func (h *CampaignHandler) visibleCampaign(c *gin.Context, op string) (*campaignModel.Campaign, bool) {
+	campaign, err := h.campaignService.GetCampaignBySlug(c.Param("slug"))
+	if err != nil || (!campaign.VisibleTo(c.GetString("user_id")) && !c.GetBool("is_staff")) {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Campaign not found"))
+		return nil, false
+	}
+	return campaign, true
+}
+
 // UpdateCampaign godoc
 // @Summary Update campaign
 // @Description Update a campaign by its slug (only owner can update)
@@ -218,8 +340,17 @@ func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
 	if req.Activity != "" {
 		campaign.Activity = campaignModel.Activity(req.Activity)
 	}
-	if req.Location != "" {
+	coordinates, err := requestCoordinates(req.Latitude, req.Longitude)
+	if err != nil {
+		respondError(c, apperr.E("UpdateCampaign", apperr.InvalidInput, err, err.Error()))
+		return
+	}
+	if req.Location != "" && req.Location != campaign.Location {
 		campaign.Location = req.Location
+		campaign.Coordinates = nil // re-geocoded from the new location unless sent below
+	}
+	if coordinates != nil {
+		campaign.Coordinates = coordinates
 	}
 	if req.TargetAmount != nil {
 		campaign.TargetAmount = *req.TargetAmount
@@ -230,11 +361,22 @@ func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
 	if req.DistanceToCover != nil {
 		campaign.DistanceToCover = *req.DistanceToCover
 	}
+	startDuration, endDuration := campaign.StartDuration, campaign.EndDuration
 	if req.StartDuration != "" {
-		campaign.StartDuration = req.StartDuration
+		startDuration = req.StartDuration
 	}
 	if req.EndDuration != "" {
-		campaign.EndDuration = req.EndDuration
+		endDuration = req.EndDuration
+	}
+	if req.StartsAt != nil {
+		startDuration = req.StartsAt.UTC().Format(time.RFC3339)
+	}
+	if req.EndsAt != nil {
+		endDuration = req.EndsAt.UTC().Format(time.RFC3339)
+	}
+	if err := campaign.SetSchedule(startDuration, endDuration); err != nil {
+		respondError(c, apperr.E("UpdateCampaign", apperr.InvalidInput, err, err.Error()))
+		return
 	}
 	if req.WorkoutImg != "" {
 		campaign.WorkoutImg = req.WorkoutImg
@@ -242,6 +384,9 @@ func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
 	if req.AcceptTac != nil {
 		campaign.AcceptTac = *req.AcceptTac
 	}
+	if req.Visibility != "" {
+		campaign.Visibility = campaignModel.CampaignVisibility(req.Visibility)
+	}
 
 	err = h.campaignService.UpdateCampaign(campaign)
 	if err != nil {
@@ -297,6 +442,74 @@ func (h *CampaignHandler) DeleteCampaign(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// PublishCampaign godoc
+// @Summary Publish a draft campaign
+// @Description Move a draft campaign to scheduled or active depending on its start time (owner or staff only)
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Success 200 {object} dto.CampaignResponse "Campaign published"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not campaign owner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 409 {object} dto.ErrorResponse "Campaign is not a draft"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/publish [post]
+func (h *CampaignHandler) PublishCampaign(c *gin.Context) {
+	h.changeCampaignStatus(c, "PublishCampaign", h.campaignService.PublishCampaign)
+}
+
+// CancelCampaign godoc
+// @Summary Cancel a campaign
+// @Description Cancel a draft, scheduled or active campaign (owner or staff only)
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Success 200 {object} dto.CampaignResponse "Campaign cancelled"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not campaign owner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 409 {object} dto.ErrorResponse "Campaign already completed or cancelled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/cancel [post]
+func (h *CampaignHandler) CancelCampaign(c *gin.Context) {
+	h.changeCampaignStatus(c, "CancelCampaign", h.campaignService.CancelCampaign)
+}
+
+func (h *CampaignHandler) changeCampaignStatus(c *gin.Context, op string, change func(campaignID string) (*campaignModel.Campaign, error)) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E(op, apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	campaign, err := h.campaignService.GetCampaignBySlug(c.Param("slug"))
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Campaign not found"))
+		return
+	}
+
+	if campaign.OwnerID != userID.(string) && !c.GetBool("is_staff") {
+		respondError(c, apperr.E(op, apperr.Forbidden, nil, "You must be the owner of this campaign"))
+		return
+	}
+
+	campaign, err = change(campaign.ID)
+	if err != nil {
+		if errors.Is(err, campaignModel.ErrInvalidCampaignState) {
+			respondError(c, apperr.E(op, apperr.Conflict, err, err.Error()))
+			return
+		}
+		respondError(c, apperr.E(op, apperr.Internal, err, "Failed to update campaign status"))
+		return
+	}
+
+	owner, _ := h.userService.GetUserByID(campaign.OwnerID)
+	c.JSON(http.StatusOK, h.campaignToResponse(campaign, owner))
+}
+
 // GetCampaignsByUser godoc
 // @Summary Get campaigns by current user
 // @Description Get all campaigns created by the authenticated user
@@ -369,7 +582,7 @@ func (h *CampaignHandler) GetCampaignsByOthers(c *gin.Context) {
 	
 	offset := (page - 1) * limit
 
-	campaigns, err := h.campaignService.GetCampaignsByNonOwner(userID.(string), limit, offset)
+	campaigns, total, err := h.campaignService.GetCampaignsByNonOwner(userID.(string), limit, offset)
 	if err != nil {
 		respondError(c, apperr.E("GetCampaignsByOthers", apperr.Internal, err, "Failed to fetch other users' campaigns"))
 		return
@@ -383,7 +596,7 @@ func (h *CampaignHandler) GetCampaignsByOthers(c *gin.Context) {
 
 	response := dto.CampaignListResponse{
 		Campaigns: responses,
-		Total:     len(responses),
+		Total:     int(total),
 		Page:      page,
 		Limit:     limit,
 	}
@@ -402,6 +615,7 @@ func (h *CampaignHandler) GetCampaignsByOthers(c *gin.Context) {
 // @Success 200 {object} dto.MessageResponse "Successfully joined campaign"
 // @Failure 400 {object} dto.ErrorResponse "Already a member"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Private campaign; an invite or approved join request is needed"
 // @Failure 404 {object} dto.ErrorResponse "Campaign not found"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /campaigns/{slug}/join [put]
@@ -419,6 +633,11 @@ func (h *CampaignHandler) JoinCampaign(c *gin.Context) {
 		return
 	}
 
+	if err := campaign.CanJoin(time.Now()); err != nil {
+		respondError(c, apperr.E("JoinCampaign", apperr.Conflict, err, err.Error()))
+		return
+	}
+
 	// Check if user is already a member
 	isMember, err := h.campaignService.IsMember(campaign.ID, userID.(string))
 	if err != nil {
@@ -434,6 +653,11 @@ func (h *CampaignHandler) JoinCampaign(c *gin.Context) {
 		return
 	}
 
+	if err := campaign.CanJoinWithoutInvite(userID.(string)); err != nil {
+		respondError(c, apperr.E("JoinCampaign", apperr.Forbidden, err, "This campaign is invite-only; use an invite link or request to join"))
+		return
+	}
+
 	err = h.campaignService.AddMember(campaign.ID, userID.(string))
 	if err != nil {
 		respondError(c, apperr.E("JoinCampaign", apperr.Internal, err, "Failed to join campaign"))
@@ -458,6 +682,7 @@ func (h *CampaignHandler) JoinCampaign(c *gin.Context) {
 // @Success 200 {object} dto.ParticipateCampaignResponse "Successfully started participation"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request body"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Private campaign and not a member"
 // @Failure 404 {object} dto.ErrorResponse "Campaign not found"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /campaigns/{slug}/participate [post]
@@ -481,9 +706,18 @@ func (h *CampaignHandler) ParticipateCampaign(c *gin.Context) {
 		return
 	}
 
+	if err := campaign.CanRecordActivity(time.Now()); err != nil {
+		respondError(c, apperr.E("ParticipateCampaign", apperr.Conflict, err, err.Error()))
+		return
+	}
+
 	// Add user as member if not already
 	isMember, _ := h.campaignService.IsMember(campaign.ID, userID.(string))
 	if !isMember {
+		if err := campaign.CanJoinWithoutInvite(userID.(string)); err != nil {
+			respondError(c, apperr.E("ParticipateCampaign", apperr.Forbidden, err, "This campaign is invite-only; use an invite link or request to join"))
+			return
+		}
 		err = h.campaignService.AddMember(campaign.ID, userID.(string))
 		if err != nil {
 			respondError(c, apperr.E("ParticipateCampaign", apperr.Internal, err, "Failed to add user as member"))
@@ -550,7 +784,7 @@ func (h *CampaignHandler) SponsorCampaign(c *gin.Context) {
 	totalAmount := req.Distance * req.AmountPerKm
 
 	// Create sponsor campaign entry
-	err = h.campaignService.SponsorCampaign(campaign.ID, []interface{}{userID.(string)}, req.Distance, req.AmountPerKm)
+	err = h.campaignService.SponsorCampaign(campaign.ID, campaignModel.UsersByID(userID.(string)), req.Distance, req.AmountPerKm)
 	if err != nil {
 		respondError(c, apperr.E("SponsorCampaign", apperr.Internal, err, "Failed to create sponsorship"))
 		return
@@ -581,51 +815,121 @@ func (h *CampaignHandler) SponsorCampaign(c *gin.Context) {
 
 // GetCampaignLeaderboard godoc
 // @Summary Get campaign leaderboard
-// @Description Get leaderboard of campaign participants ordered by distance covered
+// @Description Rank campaign participants by distance covered, then by least time, with the caller's own place and the users around them. Time windows count the runs logged in the window.
 // @Tags campaigns
-// @Accept json
+// @Security BearerAuth
 // @Produce json
 // @Param slug path string true "Campaign slug"
+// @Param window query string false "daily, weekly, monthly or all_time (default); windows start at midnight UTC and weeks on Monday"
+// @Param activity query string false "Only count this activity"
+// @Param neighbours query int false "Users either side of the caller (0-10, default 2)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Entries per page (default 50, max 100)"
 // @Success 200 {object} dto.CampaignLeaderboardResponse "Leaderboard retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid window or query"
 // @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 503 {object} dto.ErrorResponse "Time windows not available"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /campaigns/{slug}/leaderboard [get]
 func (h *CampaignHandler) GetCampaignLeaderboard(c *gin.Context) {
-	slug := c.Param("slug")
-	
-	// Get campaign runners ordered by distance covered
-	runners, err := h.campaignService.GetLeaderboard(slug)
-	if err != nil {
-		respondError(c, apperr.E("GetCampaignLeaderboard", apperr.Internal, err, "Failed to get leaderboard"))
-		return
-	}
-
-	// Convert to leaderboard response
-	var leaderboard []dto.CampaignLeaderboardEntry
-	for _, runner := range runners {
-		user, _ := h.userService.GetUserByID(runner.OwnerID)
-		entry := dto.CampaignLeaderboardEntry{
-			UserID:          runner.OwnerID,
-			Username:        "",
-			FullName:        "",
-			DistanceCovered: runner.DistanceCovered,
-			MoneyRaised:     runner.MoneyRaised,
-			Duration:        runner.Duration,
-			Activity:        runner.Activity,
-			CoverImage:      runner.CoverImage,
-		}
-		
-		if user != nil {
-			entry.Username = user.Username
-			entry.FullName = user.FirstName + " " + user.LastName
-		}
-		
-		leaderboard = append(leaderboard, entry)
+	campaign, ok := h.visibleCampaign(c, "GetCampaignLeaderboard")
+	if !ok {
+		return
+	}
+	slug := campaign.Slug
+
+	q, err := leaderboardQuery(c)
+	if err != nil {
+		respondError(c, apperr.E("GetCampaignLeaderboard", apperr.InvalidInput, err, err.Error()))
+		return
+	}
+
+	standings, err := h.campaignService.Leaderboard(slug, q)
+	if err != nil {
+		respondLeaderboardError(c, "GetCampaignLeaderboard", err, "Failed to get leaderboard")
+		return
 	}
 
 	response := dto.CampaignLeaderboardResponse{
 		CampaignSlug: slug,
-		Leaderboard:  leaderboard,
+		Window:       string(standings.Board.Window),
+		Activity:     standings.Board.Activity,
+		Total:        standings.Total,
+	}
+	response.Leaderboard, response.Me, response.Neighbours = leaderboardEntries(h.userService, standings)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCampaignResults godoc
+// @Summary Get campaign results
+// @Description Get the final standings frozen when the campaign closed. Sponsor obligations are included for the owner and staff.
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Success 200 {object} dto.CampaignResultsResponse "Results retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 409 {object} dto.ErrorResponse "Campaign has not closed yet"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/results [get]
+func (h *CampaignHandler) GetCampaignResults(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("GetCampaignResults", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	campaign, ok := h.visibleCampaign(c, "GetCampaignResults")
+	if !ok {
+		return
+	}
+
+	if campaign.ClosedAt == nil {
+		respondError(c, apperr.E("GetCampaignResults", apperr.Conflict, nil, "Campaign has not closed yet"))
+		return
+	}
+
+	standings, obligations, err := h.campaignService.GetCampaignResults(campaign.ID)
+	if err != nil {
+		respondError(c, apperr.E("GetCampaignResults", apperr.Internal, err, "Failed to get campaign results"))
+		return
+	}
+
+	response := dto.CampaignResultsResponse{
+		CampaignSlug: campaign.Slug,
+		Status:       string(campaign.StatusAt(time.Now())),
+		AchievedAt:   campaign.AchievedAt,
+		ClosedAt:     campaign.ClosedAt,
+		Standings:    []dto.CampaignStandingEntry{},
+	}
+
+	for _, standing := range standings {
+		entry := dto.CampaignStandingEntry{
+			Rank:            standing.Rank,
+			UserID:          standing.UserID,
+			DistanceCovered: standing.DistanceCovered,
+			MoneyRaised:     standing.MoneyRaised,
+			Activity:        standing.Activity,
+		}
+		if user, _ := h.userService.GetUserByID(standing.UserID); user != nil {
+			entry.Username = user.Username
+			entry.FullName = user.FirstName + " " + user.LastName
+		}
+		response.Standings = append(response.Standings, entry)
+	}
+
+	if campaign.OwnerID == userID.(string) || c.GetBool("is_staff") {
+		for _, obligation := range obligations {
+			response.Obligations = append(response.Obligations, dto.SponsorObligationEntry{
+				SponsorCampaignID: obligation.SponsorCampaignID,
+				Sponsors:          usersToResponse(obligation.Sponsors),
+				PledgedDistance:   obligation.PledgedDistance,
+				CreditedDistance:  obligation.CreditedDistance,
+				AmountPerKm:       obligation.AmountPerKm,
+				Amount:            obligation.Amount,
+			})
+		}
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -653,6 +957,8 @@ func (h *CampaignHandler) campaignToResponse(campaign *campaignModel.Campaign, o
 		Activity:          string(campaign.Activity),
 		AcceptTac:         campaign.AcceptTac,
 		Location:          campaign.Location,
+		Latitude:          model.Latitude(campaign.Coordinates),
+		Longitude:         model.Longitude(campaign.Coordinates),
 		MoneyRaised:       campaign.MoneyRaised,
 		TargetAmount:      campaign.TargetAmount,
 		TargetAmountPerKm: campaign.TargetAmountPerKm,
@@ -660,8 +966,14 @@ func (h *CampaignHandler) campaignToResponse(campaign *campaignModel.Campaign, o
 		DistanceCovered:   campaign.DistanceCovered,
 		StartDuration:     campaign.StartDuration,
 		EndDuration:       campaign.EndDuration,
-		Members:           campaign.Members,
-		Sponsors:          campaign.Sponsors,
+		StartsAt:          campaign.StartsAt,
+		EndsAt:            campaign.EndsAt,
+		Status:            string(campaign.StatusAt(time.Now())),
+		AchievedAt:        campaign.AchievedAt,
+		ClosedAt:          campaign.ClosedAt,
+		Visibility:        string(campaign.EffectiveVisibility()),
+		Members:           usersToResponse(campaign.Members),
+		Sponsors:          usersToResponse(campaign.Sponsors),
 		Owner:             ownerInfo,
 		Slug:              campaign.Slug,
 		WorkoutImg:        campaign.WorkoutImg,
@@ -670,6 +982,15 @@ func (h *CampaignHandler) campaignToResponse(campaign *campaignModel.Campaign, o
 	}
 }
 
+// usersToResponse lists members or sponsors, never as null.
+func usersToResponse(users []campaignModel.UserSummary) []dto.UserSummary {
+	result := make([]dto.UserSummary, 0, len(users))
+	for _, u := range users {
+		result = append(result, dto.UserSummary(u))
+	}
+	return result
+}
+
 // GetFinishCampaignDetails godoc
 // @Summary Get campaign finish details
 // @Description Get details for finishing a campaign run (simplified without post system)
@@ -730,6 +1051,7 @@ func (h *CampaignHandler) GetFinishCampaignDetails(c *gin.Context) {
 // @Param runner_id path string true "Campaign runner ID"
 // @Param details body dto.FinishActivityRequest true "Activity completion details"
 // @Success 200 {object} dto.CampaignRunnerResponse "Campaign run finished successfully"
+// @Success 202 {object} dto.ActivityHeldResponse "Run recorded but held for anti-cheat review"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request body"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Campaign or runner not found"
@@ -750,6 +1072,11 @@ func (h *CampaignHandler) FinishCampaignRun(c *gin.Context) {
 		respondError(c, apperr.E("FinishCampaignRun", apperr.InvalidInput, err, "Invalid request body"))
 		return
 	}
+	duration, err := requiredDuration(req.Duration, req.DurationSeconds)
+	if err != nil {
+		respondError(c, apperr.E("FinishCampaignRun", apperr.InvalidInput, err, err.Error()))
+		return
+	}
 
 	// Verify campaign exists
 	campaign, err := h.campaignService.GetCampaignBySlug(slug)
@@ -772,8 +1099,15 @@ func (h *CampaignHandler) FinishCampaignRun(c *gin.Context) {
 	}
 
 	// Update runner with completion details
-	err = h.campaignService.FinishActivity(runnerID, req.DistanceCovered, req.Duration, req.MoneyRaised)
+	err = h.campaignService.FinishActivity(runnerID, req.DistanceCovered, duration, req.MoneyRaised)
 	if err != nil {
+		if respondHeldForReview(c, err) {
+			return
+		}
+		if errors.Is(err, campaignModel.ErrInvalidCampaignState) {
+			respondError(c, apperr.E("FinishCampaignRun", apperr.Conflict, err, err.Error()))
+			return
+		}
 		respondError(c, apperr.E("FinishCampaignRun", apperr.Internal, err, "Failed to finish campaign run"))
 		return
 	}
@@ -803,11 +1137,14 @@ func (h *CampaignHandler) campaignRunnerToResponse(runner *campaignModel.Campaig
 		RunnerID:        runner.ID,
 		Username:        username,
 		DistanceCovered: runner.DistanceCovered,
-		Duration:        runner.Duration,
+		Duration:        formatDuration(runner.Duration),
+		DurationSeconds: model.Seconds(runner.Duration),
 		MoneyRaised:     runner.MoneyRaised,
 		CampaignID:      runner.CampaignID,
 		CoverImage:      runner.CoverImage,
 		Activity:        runner.Activity,
 		DateJoined:      runner.CreatedAt,
+		TrackURL:        runner.TrackURL,
+		ElevationGain:   runner.ElevationGain,
 	}
 }
@@ -0,0 +1,447 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/apperr"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+)
+
+// inviteErrorCode maps invite, join request and campaign state errors to their API codes.
+func inviteErrorCode(err error) apperr.Code {
+	switch {
+	case errors.Is(err, campaignModel.ErrInvalidInvite),
+		errors.Is(err, campaignModel.ErrJoinRequestNotFound):
+		return apperr.NotFound
+	case errors.Is(err, campaignModel.ErrInviteExpired),
+		errors.Is(err, campaignModel.ErrInviteUsedUp),
+		errors.Is(err, campaignModel.ErrAlreadyMember),
+		errors.Is(err, campaignModel.ErrJoinRequestNotNeeded),
+		errors.Is(err, campaignModel.ErrJoinRequestPending),
+		errors.Is(err, campaignModel.ErrJoinRequestDecided),
+		errors.Is(err, campaignModel.ErrInvalidCampaignState):
+		return apperr.Conflict
+	case errors.Is(err, campaignModel.ErrInviteEmailMismatch),
+		errors.Is(err, campaignModel.ErrInviteRequired):
+		return apperr.Forbidden
+	case errors.Is(err, campaignModel.ErrInvitesNotEnabled):
+		return apperr.Unavailable
+	default:
+		return apperr.Internal
+	}
+}
+
+// respondInviteError writes err with its mapped code, falling back to msg for unexpected errors.
+func respondInviteError(c *gin.Context, op string, err error, msg string) {
+	code := inviteErrorCode(err)
+	if code != apperr.Internal {
+		msg = err.Error()
+	}
+	respondError(c, apperr.E(op, code, err, msg))
+}
+
+// loadManagedCampaign resolves the :slug campaign for its owner or staff, writing an error and
+// returning false otherwise.
+func (h *CampaignHandler) loadManagedCampaign(c *gin.Context, op string) (*campaignModel.Campaign, string, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E(op, apperr.Unauthorized, nil, "User not authenticated"))
+		return nil, "", false
+	}
+
+	campaign, err := h.campaignService.GetCampaignBySlug(c.Param("slug"))
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Campaign not found"))
+		return nil, "", false
+	}
+
+	if campaign.OwnerID != userID.(string) && !c.GetBool("is_staff") {
+		respondError(c, apperr.E(op, apperr.Forbidden, nil, "You must be the owner of this campaign"))
+		return nil, "", false
+	}
+	return campaign, userID.(string), true
+}
+
+// inviteTTL converts a request's expires_in_hours, where 0 means the default lifetime.
+func inviteTTL(hours int) time.Duration {
+	return time.Duration(hours) * time.Hour
+}
+
+// CreateCampaignInvite godoc
+// @Summary Create a campaign invite link
+// @Description Create a signed invite link that expires and can be capped to a number of uses (owner or staff only)
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param invite body dto.CreateInviteRequest false "Usage cap and lifetime"
+// @Success 201 {object} dto.InviteResponse "Invite created successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not campaign owner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 409 {object} dto.ErrorResponse "Campaign no longer open to new members"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/invites [post]
+func (h *CampaignHandler) CreateCampaignInvite(c *gin.Context) {
+	campaign, userID, ok := h.loadManagedCampaign(c, "CreateCampaignInvite")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateInviteRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, apperr.E("CreateCampaignInvite", apperr.InvalidInput, err, "Invalid request body"))
+			return
+		}
+	}
+
+	invite, err := h.campaignService.CreateInvite(campaign.ID, userID, req.MaxUses, inviteTTL(req.ExpiresInHours))
+	if err != nil {
+		respondInviteError(c, "CreateCampaignInvite", err, "Failed to create invite")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.inviteToResponse(invite))
+}
+
+// EmailCampaignInvites godoc
+// @Summary Email campaign invitations
+// @Description Email a single-use invite to each address. Each invite can only be accepted by an account with that email (owner or staff only).
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param invites body dto.EmailInvitesRequest true "Addresses to invite"
+// @Success 201 {object} dto.InviteListResponse "Invitations sent"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not campaign owner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 409 {object} dto.ErrorResponse "Campaign no longer open to new members"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/invites/email [post]
+func (h *CampaignHandler) EmailCampaignInvites(c *gin.Context) {
+	campaign, userID, ok := h.loadManagedCampaign(c, "EmailCampaignInvites")
+	if !ok {
+		return
+	}
+
+	var req dto.EmailInvitesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("EmailCampaignInvites", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	invites, err := h.campaignService.InviteByEmail(campaign.ID, userID, req.Emails, inviteTTL(req.ExpiresInHours))
+	if err != nil {
+		respondInviteError(c, "EmailCampaignInvites", err, "Failed to send invitations")
+		return
+	}
+
+	response := dto.InviteListResponse{CampaignSlug: campaign.Slug, Invites: []dto.InviteResponse{}}
+	for _, invite := range invites {
+		response.Invites = append(response.Invites, h.inviteToResponse(invite))
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListCampaignInvites godoc
+// @Summary List campaign invites
+// @Description List the campaign's invite links and email invitations, newest first (owner or staff only)
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Success 200 {object} dto.InviteListResponse "Invites retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not campaign owner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/invites [get]
+func (h *CampaignHandler) ListCampaignInvites(c *gin.Context) {
+	campaign, _, ok := h.loadManagedCampaign(c, "ListCampaignInvites")
+	if !ok {
+		return
+	}
+
+	invites, err := h.campaignService.ListInvites(campaign.ID)
+	if err != nil {
+		respondInviteError(c, "ListCampaignInvites", err, "Failed to list invites")
+		return
+	}
+
+	response := dto.InviteListResponse{CampaignSlug: campaign.Slug, Invites: []dto.InviteResponse{}}
+	for _, invite := range invites {
+		response.Invites = append(response.Invites, h.inviteToResponse(invite))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// RevokeCampaignInvite godoc
+// @Summary Revoke a campaign invite
+// @Description Stop an invite link or email invitation from being used (owner or staff only)
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param invite_id path string true "Invite ID"
+// @Success 200 {object} dto.MessageResponse "Invite revoked"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not campaign owner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign or invite not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/invites/{invite_id} [delete]
+func (h *CampaignHandler) RevokeCampaignInvite(c *gin.Context) {
+	campaign, _, ok := h.loadManagedCampaign(c, "RevokeCampaignInvite")
+	if !ok {
+		return
+	}
+
+	if err := h.campaignService.RevokeInvite(campaign.ID, c.Param("invite_id")); err != nil {
+		respondInviteError(c, "RevokeCampaignInvite", err, "Failed to revoke invite")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Invite revoked",
+		Success: true,
+	})
+}
+
+// GetCampaignInvite godoc
+// @Summary Preview a campaign invite
+// @Description Show the campaign an invite token leads to, so it can be checked before accepting
+// @Tags campaigns
+// @Produce json
+// @Param token path string true "Invite token"
+// @Success 200 {object} dto.InvitePreviewResponse "Invite is valid"
+// @Failure 404 {object} dto.ErrorResponse "Invalid or revoked invite"
+// @Failure 409 {object} dto.ErrorResponse "Invite expired or used up"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/invites/{token} [get]
+func (h *CampaignHandler) GetCampaignInvite(c *gin.Context) {
+	campaign, invite, err := h.campaignService.PreviewInvite(c.Param("token"))
+	if err != nil {
+		respondInviteError(c, "GetCampaignInvite", err, "Failed to load invite")
+		return
+	}
+
+	owner, _ := h.userService.GetUserByID(campaign.OwnerID)
+	c.JSON(http.StatusOK, dto.InvitePreviewResponse{
+		Campaign:  h.campaignToResponse(campaign, owner),
+		ExpiresAt: invite.ExpiresAt,
+		Email:     invite.Email,
+	})
+}
+
+// AcceptCampaignInvite godoc
+// @Summary Accept a campaign invite
+// @Description Join the campaign an invite token leads to. Email invitations must be accepted by the invited address.
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param token path string true "Invite token"
+// @Success 200 {object} dto.CampaignResponse "Joined campaign"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Invite sent to another email address"
+// @Failure 404 {object} dto.ErrorResponse "Invalid or revoked invite"
+// @Failure 409 {object} dto.ErrorResponse "Invite expired or used up, already a member, or campaign closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/invites/{token}/accept [post]
+func (h *CampaignHandler) AcceptCampaignInvite(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("AcceptCampaignInvite", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	campaign, err := h.campaignService.AcceptInvite(c.Param("token"), userID.(string), c.GetString("user_email"))
+	if err != nil {
+		respondInviteError(c, "AcceptCampaignInvite", err, "Failed to accept invite")
+		return
+	}
+
+	owner, _ := h.userService.GetUserByID(campaign.OwnerID)
+	c.JSON(http.StatusOK, h.campaignToResponse(campaign, owner))
+}
+
+// RequestToJoinCampaign godoc
+// @Summary Request to join a private campaign
+// @Description Ask the owner of a private campaign to let the authenticated user in
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param request body dto.CreateJoinRequestRequest false "Message to the owner"
+// @Success 201 {object} dto.JoinRequestResponse "Join request created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 409 {object} dto.ErrorResponse "Already a member, request pending, campaign open to all, or campaign closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/join_requests [post]
+func (h *CampaignHandler) RequestToJoinCampaign(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("RequestToJoinCampaign", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	campaign, err := h.campaignService.GetCampaignBySlug(c.Param("slug"))
+	if err != nil {
+		respondError(c, apperr.E("RequestToJoinCampaign", apperr.NotFound, err, "Campaign not found"))
+		return
+	}
+
+	var req dto.CreateJoinRequestRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, apperr.E("RequestToJoinCampaign", apperr.InvalidInput, err, "Invalid request body"))
+			return
+		}
+	}
+
+	request, err := h.campaignService.RequestToJoin(campaign.ID, userID.(string), req.Message)
+	if err != nil {
+		respondInviteError(c, "RequestToJoinCampaign", err, "Failed to request to join")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.joinRequestToResponse(request))
+}
+
+// ListCampaignJoinRequests godoc
+// @Summary List campaign join requests
+// @Description List requests to join the campaign, oldest first (owner or staff only)
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param status query string false "Only requests in this state" Enums(pending, approved, rejected)
+// @Success 200 {object} dto.JoinRequestListResponse "Join requests retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid status"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not campaign owner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/join_requests [get]
+func (h *CampaignHandler) ListCampaignJoinRequests(c *gin.Context) {
+	campaign, _, ok := h.loadManagedCampaign(c, "ListCampaignJoinRequests")
+	if !ok {
+		return
+	}
+
+	status := campaignModel.JoinRequestStatus(c.Query("status"))
+	switch status {
+	case "", campaignModel.JoinRequestPending, campaignModel.JoinRequestApproved, campaignModel.JoinRequestRejected:
+	default:
+		respondError(c, apperr.E("ListCampaignJoinRequests", apperr.InvalidInput, nil, "status must be pending, approved or rejected"))
+		return
+	}
+
+	requests, err := h.campaignService.ListJoinRequests(campaign.ID, status)
+	if err != nil {
+		respondInviteError(c, "ListCampaignJoinRequests", err, "Failed to list join requests")
+		return
+	}
+
+	response := dto.JoinRequestListResponse{CampaignSlug: campaign.Slug, Requests: []dto.JoinRequestResponse{}}
+	for _, request := range requests {
+		response.Requests = append(response.Requests, h.joinRequestToResponse(request))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ApproveCampaignJoinRequest godoc
+// @Summary Approve a join request
+// @Description Approve a pending join request, making the requester a campaign member (owner or staff only)
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param request_id path string true "Join request ID"
+// @Success 200 {object} dto.JoinRequestResponse "Join request approved"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not campaign owner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign or join request not found"
+// @Failure 409 {object} dto.ErrorResponse "Already decided or campaign closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/join_requests/{request_id}/approve [post]
+func (h *CampaignHandler) ApproveCampaignJoinRequest(c *gin.Context) {
+	h.decideJoinRequest(c, "ApproveCampaignJoinRequest", h.campaignService.ApproveJoinRequest)
+}
+
+// RejectCampaignJoinRequest godoc
+// @Summary Reject a join request
+// @Description Turn down a pending join request (owner or staff only)
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param request_id path string true "Join request ID"
+// @Success 200 {object} dto.JoinRequestResponse "Join request rejected"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not campaign owner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign or join request not found"
+// @Failure 409 {object} dto.ErrorResponse "Already decided"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/join_requests/{request_id}/reject [post]
+func (h *CampaignHandler) RejectCampaignJoinRequest(c *gin.Context) {
+	h.decideJoinRequest(c, "RejectCampaignJoinRequest", h.campaignService.RejectJoinRequest)
+}
+
+func (h *CampaignHandler) decideJoinRequest(c *gin.Context, op string, decide func(campaignID, requestID, deciderID string) (*campaignModel.CampaignJoinRequest, error)) {
+	campaign, userID, ok := h.loadManagedCampaign(c, op)
+	if !ok {
+		return
+	}
+
+	request, err := decide(campaign.ID, c.Param("request_id"), userID)
+	if err != nil {
+		respondInviteError(c, op, err, "Failed to decide join request")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.joinRequestToResponse(request))
+}
+
+func (h *CampaignHandler) inviteToResponse(invite *campaignModel.CampaignInvite) dto.InviteResponse {
+	return dto.InviteResponse{
+		ID:          invite.ID,
+		CampaignID:  invite.CampaignID,
+		Email:       invite.Email,
+		MaxUses:     invite.MaxUses,
+		Uses:        invite.Uses,
+		ExpiresAt:   invite.ExpiresAt,
+		RevokedAt:   invite.RevokedAt,
+		Link:        h.campaignService.InviteLink(invite),
+		Token:       h.campaignService.InviteToken(invite),
+		DateCreated: invite.CreatedAt,
+	}
+}
+
+func (h *CampaignHandler) joinRequestToResponse(request *campaignModel.CampaignJoinRequest) dto.JoinRequestResponse {
+	response := dto.JoinRequestResponse{
+		ID:          request.ID,
+		CampaignID:  request.CampaignID,
+		UserID:      request.UserID,
+		Message:     request.Message,
+		Status:      string(request.Status),
+		DecidedBy:   request.DecidedBy,
+		DecidedAt:   request.DecidedAt,
+		DateCreated: request.CreatedAt,
+	}
+	if user, _ := h.userService.GetUserByID(request.UserID); user != nil {
+		response.Username = user.Username
+	}
+	return response
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/apperr"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+)
+
+// respondMilestoneError writes err with its mapped code, falling back to msg for unexpected errors.
+func respondMilestoneError(c *gin.Context, op string, err error, msg string) {
+	code := apperr.Internal
+	switch {
+	case errors.Is(err, campaignModel.ErrInvalidMilestone), errors.Is(err, campaignModel.ErrTooManyMilestones):
+		code = apperr.InvalidInput
+	case errors.Is(err, campaignModel.ErrMilestonesNotEnabled):
+		code = apperr.Unavailable
+	}
+	if code != apperr.Internal {
+		msg = err.Error()
+	}
+	respondError(c, apperr.E(op, code, err, msg))
+}
+
+// GetCampaignMilestones godoc
+// @Summary List campaign milestones
+// @Description List the campaign's progress milestones in the order they are reached, with the campaign's current totals
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Success 200 {object} dto.MilestoneListResponse "Milestones retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/milestones [get]
+func (h *CampaignHandler) GetCampaignMilestones(c *gin.Context) {
+	campaign, err := h.campaignService.GetCampaignBySlug(c.Param("slug"))
+	if err != nil || (!campaign.VisibleTo(c.GetString("user_id")) && !c.GetBool("is_staff")) {
+		respondError(c, apperr.E("GetCampaignMilestones", apperr.NotFound, err, "Campaign not found"))
+		return
+	}
+
+	milestones, err := h.campaignService.ListMilestones(campaign)
+	if err != nil {
+		respondMilestoneError(c, "GetCampaignMilestones", err, "Failed to list milestones")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.milestonesToResponse(campaign, milestones))
+}
+
+// SetCampaignMilestones godoc
+// @Summary Configure campaign milestones
+// @Description Replace the campaign's milestones that have not been reached yet (owner or staff only). Each milestone watches distance or money and is either a percent of the campaign target or a fixed value. Members and sponsors are notified in the app and by email the first time the campaign passes one. Milestones the campaign has already passed are recorded as reached without notifying anyone.
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param milestones body dto.SetMilestonesRequest true "Milestones"
+// @Success 200 {object} dto.MilestoneListResponse "Milestones saved"
+// @Failure 400 {object} dto.ErrorResponse "Invalid milestones"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not campaign owner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/milestones [put]
+func (h *CampaignHandler) SetCampaignMilestones(c *gin.Context) {
+	campaign, _, ok := h.loadManagedCampaign(c, "SetCampaignMilestones")
+	if !ok {
+		return
+	}
+
+	var req dto.SetMilestonesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("SetCampaignMilestones", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	milestones := make([]*campaignModel.CampaignMilestone, 0, len(req.Milestones))
+	for _, m := range req.Milestones {
+		milestones = append(milestones, &campaignModel.CampaignMilestone{
+			Metric:  campaignModel.MilestoneMetric(m.Metric),
+			Percent: m.Percent,
+			Value:   m.Value,
+		})
+	}
+
+	saved, err := h.campaignService.SetMilestones(campaign.ID, milestones)
+	if err != nil {
+		respondMilestoneError(c, "SetCampaignMilestones", err, "Failed to save milestones")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.milestonesToResponse(campaign, saved))
+}
+
+func (h *CampaignHandler) milestonesToResponse(campaign *campaignModel.Campaign, milestones []*campaignModel.CampaignMilestone) dto.MilestoneListResponse {
+	response := dto.MilestoneListResponse{
+		CampaignSlug:    campaign.Slug,
+		DistanceCovered: campaign.DistanceCovered,
+		MoneyRaised:     campaign.MoneyRaised,
+		Milestones:      []dto.MilestoneResponse{},
+	}
+	for _, milestone := range milestones {
+		response.Milestones = append(response.Milestones, dto.MilestoneResponse{
+			ID:        milestone.ID,
+			Metric:    string(milestone.Metric),
+			Percent:   milestone.Percent,
+			Value:     milestone.Value,
+			Threshold: milestone.Threshold(campaign),
+			Label:     milestone.Label(),
+			Reached:   milestone.ReachedAt != nil,
+			ReachedAt: milestone.ReachedAt,
+		})
+	}
+	return response
+}
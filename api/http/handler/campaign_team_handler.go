@@ -0,0 +1,449 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/apperr"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+)
+
+// teamErrorCode maps team and campaign state errors to their API codes.
+func teamErrorCode(err error) apperr.Code {
+	switch {
+	case errors.Is(err, campaignModel.ErrAlreadyInTeam),
+		errors.Is(err, campaignModel.ErrTeamNameTaken),
+		errors.Is(err, campaignModel.ErrInvalidCampaignState):
+		return apperr.Conflict
+	case errors.Is(err, campaignModel.ErrNotTeamCaptain),
+		errors.Is(err, campaignModel.ErrNotTeamMember),
+		errors.Is(err, campaignModel.ErrInviteRequired):
+		return apperr.Forbidden
+	case errors.Is(err, campaignModel.ErrInvalidInviteCode):
+		return apperr.InvalidInput
+	case errors.Is(err, campaignModel.ErrTeamsNotEnabled):
+		return apperr.Unavailable
+	default:
+		return apperr.Internal
+	}
+}
+
+// respondTeamError writes err with its mapped code, falling back to msg for unexpected errors.
+func respondTeamError(c *gin.Context, op string, err error, msg string) {
+	code := teamErrorCode(err)
+	if code != apperr.Internal {
+		msg = err.Error()
+	}
+	respondError(c, apperr.E(op, code, err, msg))
+}
+
+// loadCampaignTeam resolves the :slug campaign and its :team_id team, writing a 404 and
+// returning false if either is missing, the campaign is private to the caller or the team
+// belongs to another campaign.
+func (h *CampaignHandler) loadCampaignTeam(c *gin.Context, op string) (*campaignModel.Campaign, *campaignModel.CampaignTeam, bool) {
+	campaign, ok := h.visibleCampaign(c, op)
+	if !ok {
+		return nil, nil, false
+	}
+
+	team, err := h.campaignService.GetTeamByID(c.Param("team_id"))
+	if err != nil {
+		if errors.Is(err, campaignModel.ErrTeamsNotEnabled) {
+			respondTeamError(c, op, err, "")
+			return nil, nil, false
+		}
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Team not found"))
+		return nil, nil, false
+	}
+	if team.CampaignID != campaign.ID {
+		respondError(c, apperr.E(op, apperr.NotFound, nil, "Team not found"))
+		return nil, nil, false
+	}
+	return campaign, team, true
+}
+
+// ListCampaignTeams godoc
+// @Summary List campaign teams
+// @Description List the teams competing in a campaign
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Success 200 {object} dto.TeamListResponse "Teams retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/teams [get]
+func (h *CampaignHandler) ListCampaignTeams(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("ListCampaignTeams", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	campaign, ok := h.visibleCampaign(c, "ListCampaignTeams")
+	if !ok {
+		return
+	}
+
+	teams, err := h.campaignService.ListTeams(campaign.ID)
+	if err != nil {
+		respondTeamError(c, "ListCampaignTeams", err, "Failed to list teams")
+		return
+	}
+
+	response := dto.TeamListResponse{CampaignSlug: campaign.Slug, Teams: []dto.TeamResponse{}}
+	for _, team := range teams {
+		response.Teams = append(response.Teams, h.teamToResponse(team, userID.(string)))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateCampaignTeam godoc
+// @Summary Create a campaign team
+// @Description Create a team in the campaign with the authenticated user as captain
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param team body dto.CreateTeamRequest true "Team details"
+// @Success 201 {object} dto.TeamResponse "Team created successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Private campaign and not a member"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 409 {object} dto.ErrorResponse "Already in a team, name taken or campaign closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/teams [post]
+func (h *CampaignHandler) CreateCampaignTeam(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("CreateCampaignTeam", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	campaign, err := h.campaignService.GetCampaignBySlug(c.Param("slug"))
+	if err != nil {
+		respondError(c, apperr.E("CreateCampaignTeam", apperr.NotFound, err, "Campaign not found"))
+		return
+	}
+
+	var req dto.CreateTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("CreateCampaignTeam", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	team, err := h.campaignService.CreateTeam(campaign.ID, userID.(string), req.Name)
+	if err != nil {
+		respondTeamError(c, "CreateCampaignTeam", err, "Failed to create team")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.teamToResponse(team, userID.(string)))
+}
+
+// JoinCampaignTeam godoc
+// @Summary Join a campaign team
+// @Description Join the campaign team that owns the given invite code
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param invite body dto.JoinTeamRequest true "Invite code"
+// @Success 200 {object} dto.TeamResponse "Joined team successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body or invite code"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Private campaign and not a member"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 409 {object} dto.ErrorResponse "Already in a team or campaign closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/teams/join [post]
+func (h *CampaignHandler) JoinCampaignTeam(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("JoinCampaignTeam", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	campaign, err := h.campaignService.GetCampaignBySlug(c.Param("slug"))
+	if err != nil {
+		respondError(c, apperr.E("JoinCampaignTeam", apperr.NotFound, err, "Campaign not found"))
+		return
+	}
+
+	var req dto.JoinTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("JoinCampaignTeam", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	team, err := h.campaignService.JoinTeam(campaign.ID, userID.(string), req.InviteCode)
+	if err != nil {
+		respondTeamError(c, "JoinCampaignTeam", err, "Failed to join team")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.teamToResponse(team, userID.(string)))
+}
+
+// GetCampaignTeam godoc
+// @Summary Get a campaign team
+// @Description Get a team and its members. The invite code is only shown to members.
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param team_id path string true "Team ID"
+// @Success 200 {object} dto.TeamResponse "Team retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Campaign or team not found"
+// @Router /campaigns/{slug}/teams/{team_id} [get]
+func (h *CampaignHandler) GetCampaignTeam(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("GetCampaignTeam", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	_, team, ok := h.loadCampaignTeam(c, "GetCampaignTeam")
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, h.teamToResponse(team, userID.(string)))
+}
+
+// LeaveCampaignTeam godoc
+// @Summary Leave a campaign team
+// @Description Leave a team. A leaving captain hands the team to the longest-standing member; the last member leaving deletes the team.
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param team_id path string true "Team ID"
+// @Success 200 {object} dto.MessageResponse "Left team successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not a member of this team"
+// @Failure 404 {object} dto.ErrorResponse "Campaign or team not found"
+// @Failure 409 {object} dto.ErrorResponse "Campaign closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/teams/{team_id}/leave [post]
+func (h *CampaignHandler) LeaveCampaignTeam(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("LeaveCampaignTeam", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	_, team, ok := h.loadCampaignTeam(c, "LeaveCampaignTeam")
+	if !ok {
+		return
+	}
+
+	if err := h.campaignService.LeaveTeam(team.ID, userID.(string)); err != nil {
+		respondTeamError(c, "LeaveCampaignTeam", err, "Failed to leave team")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "You left " + team.Name,
+		Success: true,
+	})
+}
+
+// RemoveCampaignTeamMember godoc
+// @Summary Remove a team member
+// @Description Remove a member from the team. Only the captain can remove other members.
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param team_id path string true "Team ID"
+// @Param user_id path string true "Member user ID"
+// @Success 200 {object} dto.MessageResponse "Member removed successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the captain, or user not in the team"
+// @Failure 404 {object} dto.ErrorResponse "Campaign or team not found"
+// @Failure 409 {object} dto.ErrorResponse "Campaign closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/teams/{team_id}/members/{user_id} [delete]
+func (h *CampaignHandler) RemoveCampaignTeamMember(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("RemoveCampaignTeamMember", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	_, team, ok := h.loadCampaignTeam(c, "RemoveCampaignTeamMember")
+	if !ok {
+		return
+	}
+
+	if err := h.campaignService.RemoveTeamMember(team.ID, userID.(string), c.Param("user_id")); err != nil {
+		respondTeamError(c, "RemoveCampaignTeamMember", err, "Failed to remove team member")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Member removed from " + team.Name,
+		Success: true,
+	})
+}
+
+// TransferTeamCaptaincy godoc
+// @Summary Transfer team captaincy
+// @Description Hand the team to another member. Only the captain can do this.
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param team_id path string true "Team ID"
+// @Param captain body dto.TransferCaptaincyRequest true "New captain"
+// @Success 200 {object} dto.TeamResponse "Captaincy transferred successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the captain, or new captain not in the team"
+// @Failure 404 {object} dto.ErrorResponse "Campaign or team not found"
+// @Failure 409 {object} dto.ErrorResponse "Campaign closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/teams/{team_id}/captain [put]
+func (h *CampaignHandler) TransferTeamCaptaincy(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("TransferTeamCaptaincy", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	_, team, ok := h.loadCampaignTeam(c, "TransferTeamCaptaincy")
+	if !ok {
+		return
+	}
+
+	var req dto.TransferCaptaincyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("TransferTeamCaptaincy", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	team, err := h.campaignService.TransferCaptaincy(team.ID, userID.(string), req.UserID)
+	if err != nil {
+		respondTeamError(c, "TransferTeamCaptaincy", err, "Failed to transfer captaincy")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.teamToResponse(team, userID.(string)))
+}
+
+// RegenerateTeamInviteCode godoc
+// @Summary Regenerate a team invite code
+// @Description Replace the team's invite code so the old one stops working. Only the captain can do this.
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param team_id path string true "Team ID"
+// @Success 200 {object} dto.TeamResponse "Invite code regenerated successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the captain"
+// @Failure 404 {object} dto.ErrorResponse "Campaign or team not found"
+// @Failure 409 {object} dto.ErrorResponse "Campaign closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/teams/{team_id}/invite_code [post]
+func (h *CampaignHandler) RegenerateTeamInviteCode(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("RegenerateTeamInviteCode", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	_, team, ok := h.loadCampaignTeam(c, "RegenerateTeamInviteCode")
+	if !ok {
+		return
+	}
+
+	team, err := h.campaignService.RegenerateInviteCode(team.ID, userID.(string))
+	if err != nil {
+		respondTeamError(c, "RegenerateTeamInviteCode", err, "Failed to regenerate invite code")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.teamToResponse(team, userID.(string)))
+}
+
+// GetCampaignTeamLeaderboard godoc
+// @Summary Get campaign team leaderboard
+// @Description Get the campaign's teams ranked by their members' combined distance covered
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Success 200 {object} dto.TeamLeaderboardResponse "Team leaderboard retrieved successfully"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/leaderboard/teams [get]
+func (h *CampaignHandler) GetCampaignTeamLeaderboard(c *gin.Context) {
+	campaign, ok := h.visibleCampaign(c, "GetCampaignTeamLeaderboard")
+	if !ok {
+		return
+	}
+
+	standings, err := h.campaignService.GetTeamLeaderboard(campaign.ID)
+	if err != nil {
+		respondTeamError(c, "GetCampaignTeamLeaderboard", err, "Failed to get team leaderboard")
+		return
+	}
+
+	response := dto.TeamLeaderboardResponse{
+		CampaignSlug: campaign.Slug,
+		Leaderboard:  []dto.TeamLeaderboardEntry{},
+	}
+	for _, standing := range standings {
+		response.Leaderboard = append(response.Leaderboard, dto.TeamLeaderboardEntry{
+			Rank:            standing.Rank,
+			TeamID:          standing.TeamID,
+			Name:            standing.Name,
+			CaptainID:       standing.CaptainID,
+			MemberCount:     standing.MemberCount,
+			DistanceCovered: standing.DistanceCovered,
+			MoneyRaised:     standing.MoneyRaised,
+			Duration:        formatDuration(standing.Duration),
+			DurationSeconds: model.Seconds(standing.Duration),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// teamToResponse converts a team for viewerID, hiding the invite code from non-members.
+func (h *CampaignHandler) teamToResponse(team *campaignModel.CampaignTeam, viewerID string) dto.TeamResponse {
+	response := dto.TeamResponse{
+		ID:          team.ID,
+		CampaignID:  team.CampaignID,
+		Name:        team.Name,
+		CaptainID:   team.CaptainID,
+		MemberCount: len(team.Members),
+		Members:     []dto.TeamMemberEntry{},
+		DateCreated: team.CreatedAt,
+	}
+	if team.HasMember(viewerID) {
+		response.InviteCode = team.InviteCode
+	}
+
+	for _, memberID := range team.Members {
+		entry := dto.TeamMemberEntry{UserID: memberID}
+		if user, _ := h.userService.GetUserByID(memberID); user != nil {
+			entry.Username = user.Username
+			entry.FullName = user.FirstName + " " + user.LastName
+		}
+		response.Members = append(response.Members, entry)
+	}
+	return response
+}
@@ -0,0 +1,420 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/apperr"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+)
+
+// campaignTemplateErrors are the template and sponsorship slot errors a client can act on,
+// with their API codes.
+var campaignTemplateErrors = []struct {
+	err  error
+	code apperr.Code
+}{
+	{campaignModel.ErrTemplateNotFound, apperr.NotFound},
+	{campaignModel.ErrSponsorshipNotFound, apperr.NotFound},
+	{campaignModel.ErrInvalidRecurrence, apperr.InvalidInput},
+	{campaignModel.ErrInvalidCampaignSchedule, apperr.InvalidInput},
+	{campaignModel.ErrNotSlotSponsor, apperr.Forbidden},
+	{campaignModel.ErrSponsorshipNotPending, apperr.Conflict},
+	{campaignModel.ErrTemplatesNotEnabled, apperr.Unavailable},
+}
+
+// respondTemplateError writes err with its mapped code, falling back to msg for unexpected errors.
+func respondTemplateError(c *gin.Context, op string, err error, msg string) {
+	for _, known := range campaignTemplateErrors {
+		if errors.Is(err, known.err) {
+			respondError(c, apperr.E(op, known.code, err, err.Error()))
+			return
+		}
+	}
+	respondError(c, apperr.E(op, apperr.Internal, err, msg))
+}
+
+// loadOwnedTemplate loads the template in the URL and checks the caller owns it or is staff.
+// It writes the error response and returns false otherwise.
+func (h *CampaignHandler) loadOwnedTemplate(c *gin.Context, op string) (*campaignModel.CampaignTemplate, string, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E(op, apperr.Unauthorized, nil, "User not authenticated"))
+		return nil, "", false
+	}
+
+	template, err := h.campaignService.GetTemplate(c.Param("template_id"))
+	if err != nil {
+		respondTemplateError(c, op, err, "Failed to get template")
+		return nil, "", false
+	}
+	if template.OwnerID != userID.(string) && !c.GetBool("is_staff") {
+		// Other people's templates are not shown to exist.
+		respondError(c, apperr.E(op, apperr.NotFound, nil, campaignModel.ErrTemplateNotFound.Error()))
+		return nil, "", false
+	}
+	return template, userID.(string), true
+}
+
+// SaveCampaignTemplate godoc
+// @Summary Save a campaign as a template
+// @Description Save the campaign's settings, schedule length, sponsorships and milestones as a reusable template owned by the caller (owner or staff only). Progress, members and dates are not copied.
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param template body dto.SaveCampaignTemplateRequest false "Template name"
+// @Success 201 {object} dto.CampaignTemplateResponse "Template saved"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not campaign owner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/template [post]
+func (h *CampaignHandler) SaveCampaignTemplate(c *gin.Context) {
+	campaign, userID, ok := h.loadManagedCampaign(c, "SaveCampaignTemplate")
+	if !ok {
+		return
+	}
+
+	var req dto.SaveCampaignTemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, apperr.E("SaveCampaignTemplate", apperr.InvalidInput, err, "Invalid request body"))
+			return
+		}
+	}
+
+	template, err := h.campaignService.SaveAsTemplate(campaign, userID, req.Name)
+	if err != nil {
+		respondTemplateError(c, "SaveCampaignTemplate", err, "Failed to save template")
+		return
+	}
+
+	c.JSON(http.StatusCreated, templateToResponse(template))
+}
+
+// ListCampaignTemplates godoc
+// @Summary List my campaign templates
+// @Description List the caller's campaign templates, newest first
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.CampaignTemplateListResponse "Templates"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/templates [get]
+func (h *CampaignHandler) ListCampaignTemplates(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("ListCampaignTemplates", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	templates, err := h.campaignService.ListTemplates(userID.(string))
+	if err != nil {
+		respondTemplateError(c, "ListCampaignTemplates", err, "Failed to list templates")
+		return
+	}
+
+	response := dto.CampaignTemplateListResponse{Templates: make([]dto.CampaignTemplateResponse, 0, len(templates)), Count: len(templates)}
+	for _, template := range templates {
+		response.Templates = append(response.Templates, templateToResponse(template))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCampaignTemplate godoc
+// @Summary Get a campaign template
+// @Description Get one of the caller's campaign templates
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param template_id path string true "Template ID"
+// @Success 200 {object} dto.CampaignTemplateResponse "Template"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Template not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/templates/{template_id} [get]
+func (h *CampaignHandler) GetCampaignTemplate(c *gin.Context) {
+	template, _, ok := h.loadOwnedTemplate(c, "GetCampaignTemplate")
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, templateToResponse(template))
+}
+
+// UpdateCampaignTemplate godoc
+// @Summary Update a campaign template
+// @Description Rename a template or set its recurring schedule. A weekly or monthly template creates its next edition lead_hours (default one week) before next_starts_at, then moves next_starts_at on by one interval. A recurrence of none stops the schedule.
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param template_id path string true "Template ID"
+// @Param template body dto.UpdateCampaignTemplateRequest true "Changes"
+// @Success 200 {object} dto.CampaignTemplateResponse "Template updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid recurrence"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Template not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/templates/{template_id} [put]
+func (h *CampaignHandler) UpdateCampaignTemplate(c *gin.Context) {
+	template, _, ok := h.loadOwnedTemplate(c, "UpdateCampaignTemplate")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateCampaignTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("UpdateCampaignTemplate", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	if req.Name != nil {
+		template.Name = *req.Name
+	}
+	if req.Recurrence != nil {
+		recurrence := campaignModel.Recurrence(*req.Recurrence)
+		if *req.Recurrence == "none" {
+			recurrence = campaignModel.RecurrenceNone
+		}
+		next := req.NextStartsAt
+		if next == nil && recurrence == template.Recurrence {
+			next = template.NextStartsAt
+		}
+		if err := template.SetRecurrence(recurrence, next, time.Duration(req.LeadHours)*time.Hour); err != nil {
+			respondTemplateError(c, "UpdateCampaignTemplate", err, "Failed to update template")
+			return
+		}
+	}
+
+	if err := h.campaignService.UpdateTemplate(template); err != nil {
+		respondTemplateError(c, "UpdateCampaignTemplate", err, "Failed to update template")
+		return
+	}
+
+	c.JSON(http.StatusOK, templateToResponse(template))
+}
+
+// DeleteCampaignTemplate godoc
+// @Summary Delete a campaign template
+// @Description Delete a template and stop its schedule. Campaigns already created from it are kept.
+// @Tags campaigns
+// @Security BearerAuth
+// @Param template_id path string true "Template ID"
+// @Success 204 "Template deleted"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Template not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/templates/{template_id} [delete]
+func (h *CampaignHandler) DeleteCampaignTemplate(c *gin.Context) {
+	template, _, ok := h.loadOwnedTemplate(c, "DeleteCampaignTemplate")
+	if !ok {
+		return
+	}
+
+	if err := h.campaignService.DeleteTemplate(template.ID); err != nil {
+		respondTemplateError(c, "DeleteCampaignTemplate", err, "Failed to delete template")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateCampaignFromTemplate godoc
+// @Summary Create a campaign from a template
+// @Description Create a campaign from one of the caller's templates. Give starts_at, or shift_days to move the template's dates; the end keeps the template's length. Sponsorships are copied as pending slots that count once their sponsor confirms them.
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param template_id path string true "Template ID"
+// @Param campaign body dto.CreateFromTemplateRequest true "New start"
+// @Success 201 {object} dto.CampaignResponse "Campaign created"
+// @Failure 400 {object} dto.ErrorResponse "Missing or invalid start"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Template not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/templates/{template_id}/campaigns [post]
+func (h *CampaignHandler) CreateCampaignFromTemplate(c *gin.Context) {
+	template, _, ok := h.loadOwnedTemplate(c, "CreateCampaignFromTemplate")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("CreateCampaignFromTemplate", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	var start time.Time
+	switch {
+	case req.StartsAt != nil:
+		start = *req.StartsAt
+	case req.ShiftDays != nil:
+		shifted, err := template.Shifted(*req.ShiftDays)
+		if err != nil {
+			respondTemplateError(c, "CreateCampaignFromTemplate", err, "Failed to create campaign")
+			return
+		}
+		start = shifted
+	default:
+		respondError(c, apperr.E("CreateCampaignFromTemplate", apperr.InvalidInput, nil, "starts_at or shift_days is required"))
+		return
+	}
+
+	user, err := h.userService.GetUserByID(template.OwnerID)
+	if err != nil {
+		respondError(c, apperr.E("CreateCampaignFromTemplate", apperr.Internal, err, "Failed to get user details"))
+		return
+	}
+
+	campaign, err := h.campaignService.CreateFromTemplate(template, user.Username, start, req.Draft)
+	if err != nil {
+		respondTemplateError(c, "CreateCampaignFromTemplate", err, "Failed to create campaign")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.campaignToResponse(campaign, user))
+}
+
+// ListCampaignSponsorships godoc
+// @Summary List campaign sponsorships
+// @Description List the campaign's sponsorships, including slots copied from a template that are still waiting for their sponsor to confirm
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Success 200 {object} dto.CampaignSponsorshipListResponse "Sponsorships"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Campaign not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/sponsorships [get]
+func (h *CampaignHandler) ListCampaignSponsorships(c *gin.Context) {
+	campaign, err := h.campaignService.GetCampaignBySlug(c.Param("slug"))
+	if err != nil || (!campaign.VisibleTo(c.GetString("user_id")) && !c.GetBool("is_staff")) {
+		respondError(c, apperr.E("ListCampaignSponsorships", apperr.NotFound, err, "Campaign not found"))
+		return
+	}
+
+	sponsorships, err := h.campaignService.GetSponsorCampaignsByCampaign(campaign.ID)
+	if err != nil {
+		respondError(c, apperr.E("ListCampaignSponsorships", apperr.Internal, err, "Failed to list sponsorships"))
+		return
+	}
+
+	response := dto.CampaignSponsorshipListResponse{Sponsorships: make([]dto.SponsorCampaignResponse, 0, len(sponsorships)), Count: len(sponsorships)}
+	for _, sponsorship := range sponsorships {
+		response.Sponsorships = append(response.Sponsorships, sponsorshipToResponse(campaign, sponsorship))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ConfirmCampaignSponsorship godoc
+// @Summary Confirm a pending sponsorship slot
+// @Description Confirm a sponsorship slot copied from a template. A slot that names its sponsors can only be confirmed by one of them; an open slot is taken by the caller. The pledge then counts towards the campaign's money raised.
+// @Tags campaigns
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param sponsorship_id path string true "Sponsorship ID"
+// @Success 200 {object} dto.SponsorCampaignResponse "Sponsorship confirmed"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Slot reserved for another sponsor"
+// @Failure 404 {object} dto.ErrorResponse "Campaign or sponsorship not found"
+// @Failure 409 {object} dto.ErrorResponse "Sponsorship already confirmed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/sponsorships/{sponsorship_id}/confirm [post]
+func (h *CampaignHandler) ConfirmCampaignSponsorship(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("ConfirmCampaignSponsorship", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	campaign, err := h.campaignService.GetCampaignBySlug(c.Param("slug"))
+	if err != nil || (!campaign.VisibleTo(userID.(string)) && !c.GetBool("is_staff")) {
+		respondError(c, apperr.E("ConfirmCampaignSponsorship", apperr.NotFound, err, "Campaign not found"))
+		return
+	}
+
+	sponsorship, err := h.campaignService.ConfirmSponsorship(campaign.ID, c.Param("sponsorship_id"), userID.(string))
+	if err != nil {
+		respondTemplateError(c, "ConfirmCampaignSponsorship", err, "Failed to confirm sponsorship")
+		return
+	}
+
+	c.JSON(http.StatusOK, sponsorshipToResponse(campaign, sponsorship))
+}
+
+func sponsorshipToResponse(campaign *campaignModel.Campaign, sponsorship *campaignModel.SponsorCampaign) dto.SponsorCampaignResponse {
+	var sponsor string
+	if len(sponsorship.Sponsors) > 0 {
+		sponsor = sponsorship.Sponsors[0].ID
+	}
+	status := sponsorship.Status
+	if status == "" {
+		status = campaignModel.SponsorshipConfirmed
+	}
+	return dto.SponsorCampaignResponse{
+		ID:          sponsorship.ID,
+		Distance:    sponsorship.Distance,
+		Campaign:    campaign.Name,
+		Sponsor:     sponsor,
+		AmountPerKm: sponsorship.AmountPerKm,
+		TotalAmount: sponsorship.TotalAmount,
+		BrandImg:    sponsorship.BrandImg,
+		VideoUrl:    sponsorship.VideoUrl,
+		Status:      string(status),
+		DateCreated: sponsorship.CreatedAt,
+	}
+}
+
+func templateToResponse(template *campaignModel.CampaignTemplate) dto.CampaignTemplateResponse {
+	response := dto.CampaignTemplateResponse{
+		ID:                template.ID,
+		OwnerID:           template.OwnerID,
+		SourceCampaignID:  template.SourceCampaignID,
+		Name:              template.Name,
+		Description:       template.Description,
+		Mode:              string(template.Mode),
+		Activity:          string(template.Activity),
+		Location:          template.Location,
+		Visibility:        string(template.Visibility),
+		TargetAmount:      template.TargetAmount,
+		TargetAmountPerKm: template.TargetAmountPerKm,
+		DistanceToCover:   template.DistanceToCover,
+		StartsAt:          template.StartsAt,
+		LengthSeconds:     model.Seconds(template.Length),
+		SponsorSlots:      make([]dto.TemplateSponsorSlotResponse, 0, len(template.SponsorSlots)),
+		Milestones:        make([]dto.MilestoneRequest, 0, len(template.Milestones)),
+		Recurrence:        string(template.Recurrence),
+		NextStartsAt:      template.NextStartsAt,
+		LeadHours:         int(template.Lead / time.Hour),
+		Editions:          template.Editions,
+		DateCreated:       template.CreatedAt,
+	}
+	if response.Recurrence == "" {
+		response.Recurrence = "none"
+	}
+	for _, slot := range template.SponsorSlots {
+		response.SponsorSlots = append(response.SponsorSlots, dto.TemplateSponsorSlotResponse{
+			Distance:    slot.Distance,
+			AmountPerKm: slot.AmountPerKm,
+			TotalAmount: slot.Distance * slot.AmountPerKm,
+			BrandImg:    slot.BrandImg,
+			VideoUrl:    slot.VideoUrl,
+			Sponsors:    usersToResponse(slot.Sponsors),
+		})
+	}
+	for _, m := range template.Milestones {
+		response.Milestones = append(response.Milestones, dto.MilestoneRequest{Metric: string(m.Metric), Percent: m.Percent, Value: m.Value})
+	}
+	return response
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/app/certificate"
+	"gopi.com/internal/apperr"
+	certificateModel "gopi.com/internal/domain/certificate/model"
+	"gopi.com/internal/domain/model"
+)
+
+// CertificateHandler issues completion certificates to runners and lets anyone holding one
+// check that it is genuine.
+type CertificateHandler struct {
+	service *certificate.CertificateService
+}
+
+func NewCertificateHandler(service *certificate.CertificateService) *CertificateHandler {
+	return &CertificateHandler{service: service}
+}
+
+// certificateErrors are the certificate errors a client can act on, with their API codes.
+var certificateErrors = []struct {
+	err  error
+	code apperr.Code
+}{
+	{certificateModel.ErrCertificateNotFound, apperr.NotFound},
+	{certificateModel.ErrRunnerNotFound, apperr.NotFound},
+	{certificateModel.ErrNotRunnerOwner, apperr.Forbidden},
+	{certificateModel.ErrNotCompleted, apperr.Conflict},
+	{certificateModel.ErrKindNotEnabled, apperr.Unavailable},
+}
+
+// respondCertificateError writes err with its mapped code and message, falling back to msg
+// for unexpected errors.
+func respondCertificateError(c *gin.Context, op string, err error, msg string) {
+	for _, known := range certificateErrors {
+		if errors.Is(err, known.err) {
+			respondError(c, apperr.E(op, known.code, err, known.err.Error()))
+			return
+		}
+	}
+	respondError(c, apperr.E(op, apperr.Internal, err, msg))
+}
+
+// IssueCampaignCertificate godoc
+// @Summary Get a campaign completion certificate
+// @Description Issue the caller's PDF certificate for a campaign run, or return the one already issued. The campaign must be completed and the runner must have covered some distance.
+// @Tags certificates
+// @Security BearerAuth
+// @Produce json
+// @Param runner_id path string true "Campaign runner ID"
+// @Success 200 {object} dto.CertificateResponse "Certificate"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Runner belongs to another user"
+// @Failure 404 {object} dto.ErrorResponse "Runner not found"
+// @Failure 409 {object} dto.ErrorResponse "Run not completed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /certificates/campaign-runners/{runner_id} [post]
+func (h *CertificateHandler) IssueCampaignCertificate(c *gin.Context) {
+	h.issue(c, "IssueCampaignCertificate", h.service.IssueCampaignCertificate)
+}
+
+// IssueCauseCertificate godoc
+// @Summary Get a cause completion certificate
+// @Description Issue the caller's PDF certificate for a cause run, or return the one already issued. The runner must have covered its distance to cover and must not be held or rejected by review.
+// @Tags certificates
+// @Security BearerAuth
+// @Produce json
+// @Param runner_id path string true "Cause runner ID"
+// @Success 200 {object} dto.CertificateResponse "Certificate"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Runner belongs to another user"
+// @Failure 404 {object} dto.ErrorResponse "Runner not found"
+// @Failure 409 {object} dto.ErrorResponse "Run not completed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /certificates/cause-runners/{runner_id} [post]
+func (h *CertificateHandler) IssueCauseCertificate(c *gin.Context) {
+	h.issue(c, "IssueCauseCertificate", h.service.IssueCauseCertificate)
+}
+
+func (h *CertificateHandler) issue(c *gin.Context, op string, issue func(ctx context.Context, userID, runnerID string) (*certificateModel.Certificate, error)) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E(op, apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	cert, err := issue(c.Request.Context(), userID.(string), c.Param("runner_id"))
+	if err != nil {
+		respondCertificateError(c, op, err, "Failed to issue certificate")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.certificateToResponse(cert))
+}
+
+// ListMyCertificates godoc
+// @Summary List my certificates
+// @Description List the certificates issued to the caller, newest first
+// @Tags certificates
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(20)
+// @Success 200 {object} dto.CertificateListResponse "Certificates"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /certificates [get]
+func (h *CertificateHandler) ListMyCertificates(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("ListMyCertificates", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	limit, offset := parsePagination(c, 20)
+	certs, err := h.service.ListUserCertificates(userID.(string), limit, offset)
+	if err != nil {
+		respondError(c, apperr.E("ListMyCertificates", apperr.Internal, err, "Failed to list certificates"))
+		return
+	}
+
+	response := dto.CertificateListResponse{Certificates: make([]dto.CertificateResponse, 0, len(certs)), Count: len(certs)}
+	for _, cert := range certs {
+		response.Certificates = append(response.Certificates, h.certificateToResponse(cert))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// VerifyCertificate godoc
+// @Summary Verify a certificate
+// @Description Confirm that a certificate ID was issued by this service and show the details printed on it. This is where the certificate's QR code leads.
+// @Tags certificates
+// @Produce json
+// @Param id path string true "Certificate ID"
+// @Success 200 {object} dto.CertificateVerificationResponse "Genuine certificate"
+// @Failure 404 {object} dto.ErrorResponse "No certificate with this ID"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /certificates/{id}/verify [get]
+func (h *CertificateHandler) VerifyCertificate(c *gin.Context) {
+	cert, err := h.service.GetCertificate(c.Param("id"))
+	if err != nil {
+		respondCertificateError(c, "VerifyCertificate", err, "Failed to verify certificate")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CertificateVerificationResponse{Valid: true, Certificate: h.certificateToResponse(cert)})
+}
+
+func (h *CertificateHandler) certificateToResponse(cert *certificateModel.Certificate) dto.CertificateResponse {
+	return dto.CertificateResponse{
+		ID:              cert.ID,
+		Kind:            string(cert.Kind),
+		SubjectID:       cert.SubjectID,
+		SubjectName:     cert.SubjectName,
+		RunnerID:        cert.RunnerID,
+		UserID:          cert.UserID,
+		RunnerName:      cert.RunnerName,
+		Distance:        cert.Distance,
+		Duration:        formatDuration(cert.Duration),
+		DurationSeconds: model.Seconds(cert.Duration),
+		FileURL:         cert.FileURL,
+		VerifyURL:       h.service.VerifyURL(cert.ID),
+		IssuedAt:        cert.CreatedAt,
+	}
+}
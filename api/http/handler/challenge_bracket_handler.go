@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/apperr"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+)
+
+// bracketErrors are the bracket errors a client can act on, with their API codes.
+var bracketErrors = []struct {
+	err  error
+	code apperr.Code
+}{
+	{challengeModel.ErrInvalidSeeding, apperr.InvalidInput},
+	{challengeModel.ErrInvalidBracket, apperr.InvalidInput},
+	{challengeModel.ErrTooFewEntrants, apperr.Conflict},
+	{challengeModel.ErrBracketExists, apperr.Conflict},
+	{challengeModel.ErrChallengeFinished, apperr.Conflict},
+	{challengeModel.ErrNoBracket, apperr.NotFound},
+	{challengeModel.ErrBracketsNotEnabled, apperr.Unavailable},
+}
+
+// respondBracketError writes err with its mapped code and message, falling back to msg for
+// unexpected errors.
+func respondBracketError(c *gin.Context, op string, err error, msg string) {
+	for _, known := range bracketErrors {
+		if errors.Is(err, known.err) {
+			respondError(c, apperr.E(op, known.code, err, err.Error()))
+			return
+		}
+	}
+	respondError(c, apperr.E(op, apperr.Internal, err, msg))
+}
+
+// CreateBracket godoc
+// @Summary Draw challenge bracket
+// @Description Seed the challenge's members, randomly or by the distance they have logged, and draw a knockout bracket. Each round lasts round_days from starts_at; in each match the member who logs more distance in the challenge's causes during the round advances. Top seeds get byes when the members do not fill a power of two, and a member who logs nothing loses by walkover. Owner or staff only.
+// @Tags challenges
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Challenge ID"
+// @Param request body dto.CreateBracketRequest true "Bracket"
+// @Success 201 {object} dto.BracketResponse "Bracket drawn"
+// @Failure 400 {object} dto.ErrorResponse "Invalid seeding or schedule"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the challenge owner"
+// @Failure 404 {object} dto.ErrorResponse "Challenge not found"
+// @Failure 409 {object} dto.ErrorResponse "Challenge already has a bracket, has finished or has fewer than two members"
+// @Failure 503 {object} dto.ErrorResponse "Brackets not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{id}/bracket [post]
+func (h *ChallengeHandler) CreateBracket(c *gin.Context) {
+	var req dto.CreateBracketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("CreateBracket", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+	challenge, ok := h.ownedChallenge(c, "CreateBracket")
+	if !ok {
+		return
+	}
+
+	roundLength := time.Duration(req.RoundDays) * 24 * time.Hour
+	bracket, matches, err := h.challengeService.CreateBracket(challenge.ID, challengeModel.Seeding(req.Seeding), req.StartsAt, roundLength, time.Now())
+	if err != nil {
+		respondBracketError(c, "CreateBracket", err, "Failed to draw bracket")
+		return
+	}
+	c.JSON(http.StatusCreated, h.bracketToResponse(bracket, matches))
+}
+
+// GetBracket godoc
+// @Summary Get challenge bracket
+// @Description Get the challenge's knockout bracket as a tree rooted at the final. Each match lists the two matches whose winners meet in it, so first-round matches are the leaves.
+// @Tags challenges
+// @Produce json
+// @Param challenge_id path string true "Challenge ID"
+// @Success 200 {object} dto.BracketResponse "Bracket"
+// @Failure 404 {object} dto.ErrorResponse "Challenge not found or has no bracket"
+// @Failure 503 {object} dto.ErrorResponse "Brackets not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{challenge_id}/bracket [get]
+func (h *ChallengeHandler) GetBracket(c *gin.Context) {
+	challenge, err := h.challengeService.GetChallengeByID(c.Param("challenge_id"))
+	if err != nil {
+		respondError(c, apperr.E("GetBracket", apperr.NotFound, err, "Challenge not found"))
+		return
+	}
+
+	bracket, matches, err := h.challengeService.GetBracket(challenge.ID)
+	if err != nil {
+		respondBracketError(c, "GetBracket", err, "Failed to get bracket")
+		return
+	}
+	c.JSON(http.StatusOK, h.bracketToResponse(bracket, matches))
+}
+
+// bracketToResponse converts the bracket to its tree, naming each entrant once.
+func (h *ChallengeHandler) bracketToResponse(bracket *challengeModel.Bracket, matches []*challengeModel.BracketMatch) dto.BracketResponse {
+	usernames := make(map[string]string)
+	entrant := func(userID string, seed int, distance float64) *dto.BracketEntrant {
+		if userID == "" {
+			return nil
+		}
+		username, ok := usernames[userID]
+		if !ok {
+			if user, err := h.userService.GetUserByID(userID); err == nil {
+				username = user.Username
+			}
+			usernames[userID] = username
+		}
+		return &dto.BracketEntrant{UserID: userID, Username: username, Seed: seed, Distance: distance}
+	}
+
+	var node func(n *challengeModel.BracketNode) *dto.BracketNodeResponse
+	node = func(n *challengeModel.BracketNode) *dto.BracketNodeResponse {
+		m := n.Match
+		response := &dto.BracketNodeResponse{Match: dto.BracketMatchResponse{
+			ID:        m.ID,
+			Round:     m.Round,
+			Slot:      m.Slot,
+			Home:      entrant(m.HomeID, m.HomeSeed, m.HomeDistance),
+			Away:      entrant(m.AwayID, m.AwaySeed, m.AwayDistance),
+			OpensAt:   m.OpensAt,
+			ClosesAt:  m.ClosesAt,
+			Outcome:   string(m.Outcome),
+			WinnerID:  m.WinnerID,
+			DecidedAt: m.DecidedAt,
+		}}
+		for _, feeder := range n.Feeders {
+			response.Feeders = append(response.Feeders, *node(feeder))
+		}
+		return response
+	}
+
+	response := dto.BracketResponse{
+		ID:          bracket.ID,
+		ChallengeID: bracket.ChallengeID,
+		Seeding:     string(bracket.Seeding),
+		StartsAt:    bracket.StartsAt,
+		RoundDays:   bracket.RoundLength.Hours() / 24,
+		Entrants:    bracket.Entrants,
+		Rounds:      bracket.Rounds,
+		ChampionID:  bracket.ChampionID,
+		CompletedAt: bracket.CompletedAt,
+	}
+	if tree := challengeModel.BracketTree(bracket, matches); tree != nil {
+		response.Final = node(tree)
+	}
+	return response
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/apperr"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+)
+
+// respondFundingError writes err as unavailable when settlements are not enabled, falling back
+// to msg for unexpected errors.
+func respondFundingError(c *gin.Context, op string, err error, msg string) {
+	if errors.Is(err, challengeModel.ErrFundingNotEnabled) {
+		respondError(c, apperr.E(op, apperr.Unavailable, err, err.Error()))
+		return
+	}
+	respondError(c, apperr.E(op, apperr.Internal, err, msg))
+}
+
+// GetCauseFunding godoc
+// @Summary Get cause funding
+// @Description Get a cause's funding ledger: what each sponsor has earned from the distance its runners have covered, capped at the km they pledged, and progress towards the cause's fund amount. Once the cause's challenge has closed, the ledger shows the settled sponsor statements.
+// @Tags sponsorship
+// @Produce json
+// @Param id path string true "Cause ID"
+// @Success 200 {object} dto.CauseFundingResponse "Funding ledger"
+// @Failure 404 {object} dto.ErrorResponse "Cause not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/{id}/funding [get]
+func (h *ChallengeHandler) GetCauseFunding(c *gin.Context) {
+	cause, err := h.challengeService.GetCauseByID(c.Param("id"))
+	if err != nil {
+		respondError(c, apperr.E("GetCauseFunding", apperr.NotFound, err, "Cause not found"))
+		return
+	}
+
+	funding, err := h.challengeService.GetCauseFunding(cause.ID)
+	if err != nil {
+		respondFundingError(c, "GetCauseFunding", err, "Failed to get cause funding")
+		return
+	}
+
+	response := dto.CauseFundingResponse{
+		CauseID:         funding.CauseID,
+		Goal:            funding.Goal,
+		DistanceCovered: funding.DistanceCovered,
+		Pledged:         funding.Pledged,
+		Earned:          funding.Earned,
+		Progress:        funding.Progress(),
+		Sponsors:        make([]dto.SponsorObligationResponse, 0, len(funding.Sponsors)),
+		SettledAt:       funding.SettledAt,
+	}
+	for _, obligation := range funding.Sponsors {
+		info := dto.SponsorObligationResponse{
+			SponsorID:    obligation.SponsorID,
+			Sponsorships: obligation.Sponsorships,
+			Pledged:      obligation.Pledged,
+			Earned:       obligation.Earned,
+		}
+		if user, err := h.userService.GetUserByID(obligation.SponsorID); err == nil {
+			info.SponsorName = user.GetFullName()
+		}
+		response.Sponsors = append(response.Sponsors, info)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCauseStatements godoc
+// @Summary List cause sponsor statements
+// @Description List what each sponsor owes a cause, settled when its challenge closed (cause owner or staff). Empty until then.
+// @Tags sponsorship
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Cause ID"
+// @Success 200 {object} dto.SponsorStatementListResponse "Statements"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the cause owner"
+// @Failure 404 {object} dto.ErrorResponse "Cause not found"
+// @Failure 503 {object} dto.ErrorResponse "Settlements not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/{id}/statements [get]
+func (h *ChallengeHandler) GetCauseStatements(c *gin.Context) {
+	cause, err := h.challengeService.GetCauseByID(c.Param("id"))
+	if err != nil {
+		respondError(c, apperr.E("GetCauseStatements", apperr.NotFound, err, "Cause not found"))
+		return
+	}
+	if cause.OwnerID != c.GetString("user_id") && !c.GetBool("is_staff") {
+		respondError(c, apperr.E("GetCauseStatements", apperr.Forbidden, nil, "Only the cause owner can view its statements"))
+		return
+	}
+
+	statements, err := h.challengeService.ListCauseStatements(cause.ID)
+	if err != nil {
+		respondFundingError(c, "GetCauseStatements", err, "Failed to list statements")
+		return
+	}
+	c.JSON(http.StatusOK, sponsorStatementsToResponse(statements))
+}
+
+// GetMySponsorStatements godoc
+// @Summary List my sponsor statements
+// @Description List what the caller owes the causes they sponsored, latest settlement first
+// @Tags sponsorship
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.SponsorStatementListResponse "Statements"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 503 {object} dto.ErrorResponse "Settlements not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/sponsorships/statements [get]
+func (h *ChallengeHandler) GetMySponsorStatements(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("GetMySponsorStatements", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	statements, err := h.challengeService.ListSponsorStatements(userID.(string))
+	if err != nil {
+		respondFundingError(c, "GetMySponsorStatements", err, "Failed to list statements")
+		return
+	}
+	c.JSON(http.StatusOK, sponsorStatementsToResponse(statements))
+}
+
+func sponsorStatementsToResponse(statements []*challengeModel.SponsorStatement) dto.SponsorStatementListResponse {
+	response := dto.SponsorStatementListResponse{Statements: make([]dto.SponsorStatementResponse, 0, len(statements))}
+	for _, statement := range statements {
+		response.Statements = append(response.Statements, dto.SponsorStatementResponse{
+			ID:              statement.ID,
+			CauseID:         statement.CauseID,
+			SponsorID:       statement.SponsorID,
+			Sponsorships:    statement.Sponsorships,
+			DistanceCovered: statement.DistanceCovered,
+			Pledged:         statement.Pledged,
+			AmountDue:       statement.AmountDue,
+			SettledAt:       statement.SettledAt,
+		})
+		response.TotalDue += statement.AmountDue
+	}
+	response.TotalDue = math.Round(response.TotalDue*100) / 100
+	return response
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -10,6 +11,7 @@ import (
 	"gopi.com/internal/app/user"
 	"gopi.com/internal/apperr"
 	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
 	userModel "gopi.com/internal/domain/user/model"
 )
 
@@ -50,6 +52,11 @@ func (h *ChallengeHandler) CreateChallenge(c *gin.Context) {
 		respondError(c, apperr.E("CreateChallenge", apperr.InvalidInput, err, "Invalid request body"))
 		return
 	}
+	coordinates, err := requestCoordinates(req.Latitude, req.Longitude)
+	if err != nil {
+		respondError(c, apperr.E("CreateChallenge", apperr.InvalidInput, err, err.Error()))
+		return
+	}
 
 	// Create challenge
 	challenge, err := h.challengeService.CreateChallenge(
@@ -66,7 +73,16 @@ func (h *ChallengeHandler) CreateChallenge(c *gin.Context) {
 		req.StartDuration,
 		req.EndDuration,
 		req.NoOfWinner,
+		challengeModel.RankingCriterion(req.RankingCriterion),
+		prizesFromRequest(req.WinningPrice),
+		prizesFromRequest(req.CausePrice),
+		coordinates,
 	)
+	if errors.Is(err, challengeModel.ErrInvalidChallengeSchedule) || errors.Is(err, challengeModel.ErrInvalidRankingCriterion) ||
+		errors.Is(err, challengeModel.ErrInvalidPrizes) {
+		respondError(c, apperr.E("CreateChallenge", apperr.InvalidInput, err, err.Error()))
+		return
+	}
 	if err != nil {
 		respondError(c, apperr.E("CreateChallenge", apperr.Internal, err, "Failed to create challenge"))
 		return
@@ -85,12 +101,14 @@ func (h *ChallengeHandler) CreateChallenge(c *gin.Context) {
 
 // GetChallenges godoc
 // @Summary List all challenges
-// @Description Get a paginated list of all challenges
+// @Description Get a paginated list of all challenges, newest first. With near, only challenges within radius_km are listed, nearest first.
 // @Tags challenges
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Number of items per page" default(10)
+// @Param near query string false "Only challenges within radius_km of this position (lat,lon)"
+// @Param radius_km query number false "Search radius in km, used with near" default(25)
 // @Success 200 {object} dto.ChallengeListResponse "Challenges retrieved successfully"
 // @Failure 400 {object} dto.ErrorResponse "Invalid query parameters"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
@@ -108,7 +126,25 @@ func (h *ChallengeHandler) GetChallenges(c *gin.Context) {
 
 	offset := (page - 1) * limit
 
-	challenges, err := h.challengeService.ListChallenges(limit, offset)
+	var nearQuery dto.NearQuery
+	if err := c.ShouldBindQuery(&nearQuery); err != nil {
+		respondError(c, apperr.E("GetChallenges", apperr.InvalidInput, err, "Invalid query parameters"))
+		return
+	}
+	near, err := requestNear(nearQuery.Near, nearQuery.RadiusKm)
+	if err != nil {
+		respondError(c, apperr.E("GetChallenges", apperr.InvalidInput, err, "near must be lat,lon"))
+		return
+	}
+
+	var challenges []*challengeModel.Challenge
+	var total int64
+	if near != nil {
+		challenges, total, err = h.challengeService.ListChallengesNear(*near, limit, offset)
+	} else {
+		challenges, err = h.challengeService.ListChallenges(limit, offset)
+		total = int64(len(challenges))
+	}
 	if err != nil {
 		respondError(c, apperr.E("GetChallenges", apperr.Internal, err, "Failed to retrieve challenges"))
 		return
@@ -118,7 +154,7 @@ func (h *ChallengeHandler) GetChallenges(c *gin.Context) {
 		Challenges: make([]dto.ChallengeResponse, 0, len(challenges)),
 		Page:       page,
 		Limit:      limit,
-		Total:      len(challenges),
+		Total:      int(total),
 	}
 
 	for _, challenge := range challenges {
@@ -220,7 +256,7 @@ func (h *ChallengeHandler) JoinChallenge(c *gin.Context) {
 
 	err := h.challengeService.JoinChallenge(challengeID, userID.(string))
 	if err != nil {
-		respondError(c, apperr.E("JoinChallenge", apperr.Internal, err, "Failed to join challenge"))
+		respondMembershipError(c, "JoinChallenge", err, "Failed to join challenge")
 		return
 	}
 
@@ -229,6 +265,66 @@ func (h *ChallengeHandler) JoinChallenge(c *gin.Context) {
 	})
 }
 
+// LeaveChallenge godoc
+// @Summary Leave a challenge
+// @Description Leave a challenge the caller is a member of. The owner cannot leave.
+// @Tags challenges
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Challenge ID"
+// @Success 200 {object} dto.MessageResponse "Successfully left challenge"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 409 {object} dto.ErrorResponse "Not a member, or the owner"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{id}/leave [post]
+func (h *ChallengeHandler) LeaveChallenge(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("LeaveChallenge", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	if err := h.challengeService.LeaveChallenge(c.Param("id"), userID.(string)); err != nil {
+		respondMembershipError(c, "LeaveChallenge", err, "Failed to leave challenge")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Successfully left challenge",
+	})
+}
+
+// GetChallengeMembers godoc
+// @Summary List challenge members
+// @Description List the members of a challenge with their user details, earliest to join first
+// @Tags challenges
+// @Produce json
+// @Param challenge_id path string true "Challenge ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(20)
+// @Success 200 {object} dto.ChallengeMemberListResponse "Members"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{challenge_id}/members [get]
+func (h *ChallengeHandler) GetChallengeMembers(c *gin.Context) {
+	limit, offset := parsePagination(c, 20)
+	members, total, err := h.challengeService.ListChallengeMembers(c.Param("challenge_id"), limit, offset)
+	if err != nil {
+		respondError(c, apperr.E("GetChallengeMembers", apperr.Internal, err, "Failed to list members"))
+		return
+	}
+
+	response := dto.ChallengeMemberListResponse{Members: make([]dto.ChallengeMemberInfo, 0, len(members)), Total: total}
+	for _, member := range members {
+		info := dto.ChallengeMemberInfo{ID: member.UserID, JoinedAt: member.JoinedAt}
+		if user, err := h.userService.GetUserByID(member.UserID); err == nil {
+			info.FullName = user.GetFullName()
+			info.Username = user.Username
+		}
+		response.Members = append(response.Members, info)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 // CreateCause godoc
 // @Summary Create a new cause
 // @Description Create a new cause within a challenge
@@ -254,6 +350,11 @@ func (h *ChallengeHandler) CreateCause(c *gin.Context) {
 		respondError(c, apperr.E("CreateCause", apperr.InvalidInput, err, "Invalid request body"))
 		return
 	}
+	coordinates, err := requestCoordinates(req.Latitude, req.Longitude)
+	if err != nil {
+		respondError(c, apperr.E("CreateCause", apperr.InvalidInput, err, err.Error()))
+		return
+	}
 
 	// Create cause
 	cause, err := h.challengeService.CreateCause(
@@ -271,6 +372,7 @@ func (h *ChallengeHandler) CreateCause(c *gin.Context) {
 		req.FundAmount,
 		req.WillingAmount,
 		req.UnitPrice,
+		coordinates,
 	)
 	if err != nil {
 		respondError(c, apperr.E("CreateCause", apperr.Internal, err, "Failed to create cause"))
@@ -324,6 +426,64 @@ func (h *ChallengeHandler) GetCausesByChallenge(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetCausesNearby godoc
+// @Summary List causes near a position
+// @Description Get a paginated list of the causes within radius_km of near, nearest first
+// @Tags causes
+// @Produce json
+// @Param near query string true "Position (lat,lon)"
+// @Param radius_km query number false "Search radius in km" default(25)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} dto.CauseListResponse "Causes retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/nearby [get]
+func (h *ChallengeHandler) GetCausesNearby(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	var nearQuery dto.NearQuery
+	if err := c.ShouldBindQuery(&nearQuery); err != nil {
+		respondError(c, apperr.E("GetCausesNearby", apperr.InvalidInput, err, "Invalid query parameters"))
+		return
+	}
+	near, err := requestNear(nearQuery.Near, nearQuery.RadiusKm)
+	if err != nil || near == nil {
+		respondError(c, apperr.E("GetCausesNearby", apperr.InvalidInput, err, "near must be lat,lon"))
+		return
+	}
+
+	causes, total, err := h.challengeService.ListCausesNear(*near, limit, (page-1)*limit)
+	if err != nil {
+		respondError(c, apperr.E("GetCausesNearby", apperr.Internal, err, "Failed to retrieve causes"))
+		return
+	}
+
+	response := dto.CauseListResponse{
+		Causes: make([]dto.CauseResponse, 0, len(causes)),
+		Total:  int(total),
+		Page:   page,
+		Limit:  limit,
+	}
+	for _, cause := range causes {
+		owner, err := h.userService.GetUserByID(cause.OwnerID)
+		if err != nil {
+			continue // Skip if owner not found
+		}
+		response.Causes = append(response.Causes, h.causeToResponse(cause, owner))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetCauseByID godoc
 // @Summary Get cause by ID
 // @Description Get a specific cause by its ID
@@ -380,7 +540,7 @@ func (h *ChallengeHandler) JoinCause(c *gin.Context) {
 
 	err := h.challengeService.JoinCause(causeID, userID.(string))
 	if err != nil {
-		respondError(c, apperr.E("JoinCause", apperr.Internal, err, "Failed to join cause"))
+		respondMembershipError(c, "JoinCause", err, "Failed to join cause")
 		return
 	}
 
@@ -389,6 +549,77 @@ func (h *ChallengeHandler) JoinCause(c *gin.Context) {
 	})
 }
 
+// LeaveCause godoc
+// @Summary Leave a cause
+// @Description Leave a cause the caller is a member of. The owner cannot leave.
+// @Tags causes
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Cause ID"
+// @Success 200 {object} dto.MessageResponse "Successfully left cause"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 409 {object} dto.ErrorResponse "Not a member, or the owner"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/{id}/leave [post]
+func (h *ChallengeHandler) LeaveCause(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("LeaveCause", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	if err := h.challengeService.LeaveCause(c.Param("id"), userID.(string)); err != nil {
+		respondMembershipError(c, "LeaveCause", err, "Failed to leave cause")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Successfully left cause",
+	})
+}
+
+// GetCauseMembers godoc
+// @Summary List cause members
+// @Description List the members of a cause with their user details, earliest to join first
+// @Tags causes
+// @Produce json
+// @Param id path string true "Cause ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(20)
+// @Success 200 {object} dto.CauseMemberListResponse "Members"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/{id}/members [get]
+func (h *ChallengeHandler) GetCauseMembers(c *gin.Context) {
+	limit, offset := parsePagination(c, 20)
+	members, total, err := h.challengeService.ListCauseMembers(c.Param("id"), limit, offset)
+	if err != nil {
+		respondError(c, apperr.E("GetCauseMembers", apperr.Internal, err, "Failed to list members"))
+		return
+	}
+
+	response := dto.CauseMemberListResponse{Members: make([]dto.CauseMemberInfo, 0, len(members)), Total: total}
+	for _, member := range members {
+		info := dto.CauseMemberInfo{ID: member.UserID, JoinedAt: member.JoinedAt}
+		if user, err := h.userService.GetUserByID(member.UserID); err == nil {
+			info.FullName = user.GetFullName()
+			info.Username = user.Username
+		}
+		response.Members = append(response.Members, info)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// respondMembershipError writes err with its mapped code, falling back to msg for unexpected errors.
+func respondMembershipError(c *gin.Context, op string, err error, msg string) {
+	switch {
+	case errors.Is(err, challengeModel.ErrAlreadyMember), errors.Is(err, challengeModel.ErrNotMember),
+		errors.Is(err, challengeModel.ErrOwnerCannotLeave), errors.Is(err, challengeModel.ErrChallengeCancelled):
+		respondError(c, apperr.E(op, apperr.Conflict, err, err.Error()))
+	default:
+		respondError(c, apperr.E(op, apperr.Internal, err, msg))
+	}
+}
+
 // RecordCauseActivity godoc
 // @Summary Record cause activity
 // @Description Record activity for a cause (distance covered, etc.)
@@ -398,6 +629,7 @@ func (h *ChallengeHandler) JoinCause(c *gin.Context) {
 // @Produce json
 // @Param activity body dto.RecordActivityRequest true "Activity details"
 // @Success 200 {object} dto.MessageResponse "Activity recorded successfully"
+// @Success 202 {object} dto.ActivityHeldResponse "Activity recorded but held for anti-cheat review"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request body"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
@@ -414,16 +646,24 @@ func (h *ChallengeHandler) RecordCauseActivity(c *gin.Context) {
 		respondError(c, apperr.E("RecordCauseActivity", apperr.InvalidInput, err, "Invalid request body"))
 		return
 	}
+	duration, err := requiredDuration(req.Duration, req.DurationSeconds)
+	if err != nil {
+		respondError(c, apperr.E("RecordCauseActivity", apperr.InvalidInput, err, err.Error()))
+		return
+	}
 
-	err := h.challengeService.RecordCauseActivity(
+	err = h.challengeService.RecordCauseActivity(
 		req.CauseID,
 		userID.(string),
 		req.DistanceToCover,
 		req.DistanceCovered,
-		req.Duration,
+		duration,
 		req.Activity,
 	)
 	if err != nil {
+		if respondHeldForReview(c, err) {
+			return
+		}
 		respondError(c, apperr.E("RecordCauseActivity", apperr.Internal, err, "Failed to record activity"))
 		return
 	}
@@ -517,43 +757,6 @@ func (h *ChallengeHandler) SponsorCause(c *gin.Context) {
 	})
 }
 
-// BuyCause godoc
-// @Summary Buy a cause
-// @Description Purchase a cause with specified amount
-// @Tags purchase
-// @Security BearerAuth
-// @Accept json
-// @Produce json
-// @Param purchase body dto.BuyCauseRequest true "Purchase details"
-// @Success 200 {object} dto.MessageResponse "Cause purchased successfully"
-// @Failure 400 {object} dto.ErrorResponse "Invalid request body"
-// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
-// @Failure 500 {object} dto.ErrorResponse "Internal server error"
-// @Router /causes/buy [post]
-func (h *ChallengeHandler) BuyCause(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		respondError(c, apperr.E("BuyCause", apperr.Unauthorized, nil, "User not authenticated"))
-		return
-	}
-
-	var req dto.BuyCauseRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondError(c, apperr.E("BuyCause", apperr.InvalidInput, err, "Invalid request body"))
-		return
-	}
-
-	err := h.challengeService.BuyCause(req.CauseID, userID.(string), req.Amount)
-	if err != nil {
-		respondError(c, apperr.E("BuyCause", apperr.Internal, err, "Failed to buy cause"))
-		return
-	}
-
-	c.JSON(http.StatusOK, dto.MessageResponse{
-		Message: "Cause purchased successfully",
-	})
-}
-
 // GetLeaderboard godoc
 // @Summary Get leaderboard
 // @Description Get the leaderboard of top performers
@@ -596,14 +799,20 @@ func (h *ChallengeHandler) challengeToResponse(challenge *challengeModel.Challen
 		Condition:         challenge.Condition,
 		Goal:              challenge.Goal,
 		Location:          challenge.Location,
+		Latitude:          model.Latitude(challenge.Coordinates),
+		Longitude:         model.Longitude(challenge.Coordinates),
 		DistanceToCover:   challenge.DistanceToCover,
 		TargetAmount:      challenge.TargetAmount,
 		TargetAmountPerKm: challenge.TargetAmountPerKm,
 		StartDuration:     challenge.StartDuration,
 		EndDuration:       challenge.EndDuration,
+		EndsAt:            challenge.EndsAt,
 		NoOfWinner:        challenge.NoOfWinner,
-		WinningPrice:      challenge.WinningPrice,
-		CausePrice:        challenge.CausePrice,
+		WinningPrice:      prizesToResponse(challenge.WinningPrice),
+		RankingCriterion:  string(challenge.RankingCriterion),
+		ClosedAt:          challenge.ClosedAt,
+		CancelledAt:       challenge.CancelledAt,
+		CausePrice:        prizesToResponse(challenge.CausePrice),
 		CoverImage:        challenge.CoverImage,
 		VideoUrl:          challenge.VideoUrl,
 		Slug:              challenge.Slug,
@@ -625,17 +834,23 @@ func (h *ChallengeHandler) causeToResponse(cause *challengeModel.Cause, owner *u
 		ProductDescription: cause.ProductDescription,
 		Activity:           string(cause.Activity),
 		Location:           cause.Location,
+		Latitude:           model.Latitude(cause.Coordinates),
+		Longitude:          model.Longitude(cause.Coordinates),
 		Description:        cause.Description,
 		IsCommercial:       cause.IsCommercial,
 		WhoIdeaImpact:      cause.WhoIdeaImpact,
 		BuyerUser:          cause.BuyerUser,
 		DistanceCovered:    cause.DistanceCovered,
 		AmountPerPiece:     cause.AmountPerPiece,
-		Duration:           cause.Duration,
+		Duration:           formatDuration(cause.Duration),
+		DurationSeconds:    model.Seconds(cause.Duration),
 		FundCause:          cause.FundCause,
 		FundAmount:         cause.FundAmount,
 		WillingAmount:      cause.WillingAmount,
 		UnitPrice:          cause.UnitPrice,
+		Stock:              cause.Stock,
+		UnitsSold:          cause.UnitsSold,
+		Available:          causeAvailable(cause),
 		CostToLaunch:       cause.CostToLaunch,
 		BenefitDesc:        cause.BenefitDesc,
 		WorkoutImg:         cause.WorkoutImg,
@@ -658,7 +873,8 @@ func (h *ChallengeHandler) causeRunnerToResponse(runner *challengeModel.CauseRun
 		Username:        owner.Username,
 		DistanceToCover: runner.DistanceToCover,
 		DistanceCovered: runner.DistanceCovered,
-		Duration:        runner.Duration,
+		Duration:        formatDuration(runner.Duration),
+		DurationSeconds: model.Seconds(runner.Duration),
 		MoneyRaised:     runner.MoneyRaised,
 		CoverImage:      runner.CoverImage,
 		Activity:        runner.Activity,
@@ -693,11 +909,15 @@ func (h *ChallengeHandler) userToCauseOwnerInfo(user *userModel.User) dto.CauseO
 	}
 }
 
-// Convert interface{} to ChallengeMemberInfo slice (placeholder implementation)
+// Convert member user IDs to ChallengeMemberInfo; user details are listed by GetChallengeMembers
 func (h *ChallengeHandler) convertToChallengeMemberInfo(members []interface{}) []dto.ChallengeMemberInfo {
-	// In production, you would properly convert the interface{} slice to actual user objects
-	// For now, return empty slice as the junction table queries are not implemented
-	return []dto.ChallengeMemberInfo{}
+	result := make([]dto.ChallengeMemberInfo, 0, len(members))
+	for _, member := range members {
+		if userID, ok := member.(string); ok {
+			result = append(result, dto.ChallengeMemberInfo{ID: userID})
+		}
+	}
+	return result
 }
 
 // Convert interface{} to ChallengeSponsorInfo slice (placeholder implementation)
@@ -707,11 +927,15 @@ func (h *ChallengeHandler) convertToChallengeSponsorInfo(sponsors []interface{})
 	return []dto.ChallengeSponsorInfo{}
 }
 
-// Convert interface{} to CauseMemberInfo slice (placeholder implementation)
+// Convert member user IDs to CauseMemberInfo; user details are listed by GetCauseMembers
 func (h *ChallengeHandler) convertToCauseMemberInfo(members []interface{}) []dto.CauseMemberInfo {
-	// In production, you would properly convert the interface{} slice to actual user objects
-	// For now, return empty slice as the junction table queries are not implemented
-	return []dto.CauseMemberInfo{}
+	result := make([]dto.CauseMemberInfo, 0, len(members))
+	for _, member := range members {
+		if userID, ok := member.(string); ok {
+			result = append(result, dto.CauseMemberInfo{ID: userID})
+		}
+	}
+	return result
 }
 
 // Convert interface{} to CauseSponsorInfo slice (placeholder implementation)
@@ -720,3 +944,31 @@ func (h *ChallengeHandler) convertToCauseSponsorInfo(sponsors []interface{}) []d
 	// For now, return empty slice as the junction table queries are not implemented
 	return []dto.CauseSponsorInfo{}
 }
+
+// prizesFromRequest converts the prizes sent with a challenge; the service checks and orders them.
+func prizesFromRequest(prizes []dto.Prize) []challengeModel.Prize {
+	if prizes == nil {
+		return nil
+	}
+	result := make([]challengeModel.Prize, 0, len(prizes))
+	for _, p := range prizes {
+		result = append(result, challengeModel.Prize(p))
+	}
+	return result
+}
+
+func prizesToResponse(prizes []challengeModel.Prize) []dto.Prize {
+	result := make([]dto.Prize, 0, len(prizes))
+	for _, p := range prizes {
+		result = append(result, dto.Prize(p))
+	}
+	return result
+}
+
+func prizeToResponse(prize *challengeModel.Prize) *dto.Prize {
+	if prize == nil {
+		return nil
+	}
+	p := dto.Prize(*prize)
+	return &p
+}
@@ -0,0 +1,500 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/apperr"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+)
+
+// judgingErrors are the judging errors a client can act on, with their API codes.
+var judgingErrors = []struct {
+	err  error
+	code apperr.Code
+}{
+	{challengeModel.ErrInvalidRound, apperr.InvalidInput},
+	{challengeModel.ErrInvalidRubric, apperr.InvalidInput},
+	{challengeModel.ErrInvalidVoteShare, apperr.InvalidInput},
+	{challengeModel.ErrInvalidJudgeWeight, apperr.InvalidInput},
+	{challengeModel.ErrInvalidScore, apperr.InvalidInput},
+	{challengeModel.ErrCauseNotInChallenge, apperr.InvalidInput},
+	{challengeModel.ErrFinalRoundExists, apperr.Conflict},
+	{challengeModel.ErrRoundNotOpen, apperr.Conflict},
+	{challengeModel.ErrRoundClosed, apperr.Conflict},
+	{challengeModel.ErrRoundNotClosed, apperr.Conflict},
+	{challengeModel.ErrAlreadyJudge, apperr.Conflict},
+	{challengeModel.ErrAlreadyVoted, apperr.Conflict},
+	{challengeModel.ErrNotJudge, apperr.Forbidden},
+	{challengeModel.ErrNotMember, apperr.Forbidden},
+	{challengeModel.ErrJudgingNotEnabled, apperr.Unavailable},
+}
+
+// respondJudgingError writes err with its mapped code and message, falling back to msg for
+// unexpected errors.
+func respondJudgingError(c *gin.Context, op string, err error, msg string) {
+	for _, known := range judgingErrors {
+		if errors.Is(err, known.err) {
+			respondError(c, apperr.E(op, known.code, err, err.Error()))
+			return
+		}
+	}
+	respondError(c, apperr.E(op, apperr.Internal, err, msg))
+}
+
+// judgingRound loads the round named in the path, writing the error response when it cannot.
+func (h *ChallengeHandler) judgingRound(c *gin.Context, op string) (*challengeModel.JudgingRound, bool) {
+	round, err := h.challengeService.GetJudgingRound(c.Param("round_id"))
+	if errors.Is(err, challengeModel.ErrJudgingNotEnabled) {
+		respondJudgingError(c, op, err, "")
+		return nil, false
+	}
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Judging round not found"))
+		return nil, false
+	}
+	return round, true
+}
+
+// CreateJudgingRound godoc
+// @Summary Create judging round
+// @Description Add a judging round to a challenge. Between opens_at and closes_at its judges score the causes against the rubric and members vote; each cause's score blends the two by vote_share. The results of the final round award the challenge's cause prizes (staff only).
+// @Tags judging
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param challenge_id path string true "Challenge ID"
+// @Param request body dto.CreateJudgingRoundRequest true "Round"
+// @Success 201 {object} dto.JudgingRoundResponse "Round created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid schedule, rubric or vote share"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff only"
+// @Failure 404 {object} dto.ErrorResponse "Challenge not found"
+// @Failure 409 {object} dto.ErrorResponse "Challenge already has a final round"
+// @Failure 503 {object} dto.ErrorResponse "Judging not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/admin/{challenge_id}/rounds [post]
+func (h *ChallengeHandler) CreateJudgingRound(c *gin.Context) {
+	var req dto.CreateJudgingRoundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("CreateJudgingRound", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	challenge, err := h.challengeService.GetChallengeByID(c.Param("challenge_id"))
+	if err != nil {
+		respondError(c, apperr.E("CreateJudgingRound", apperr.NotFound, err, "Challenge not found"))
+		return
+	}
+
+	round := &challengeModel.JudgingRound{
+		ChallengeID: challenge.ID,
+		Name:        req.Name,
+		OpensAt:     req.OpensAt,
+		ClosesAt:    req.ClosesAt,
+		VoteShare:   req.VoteShare,
+		Final:       req.Final,
+	}
+	for _, criterion := range req.Rubric {
+		round.Rubric = append(round.Rubric, challengeModel.Criterion{
+			Name:     criterion.Name,
+			Weight:   criterion.Weight,
+			MaxScore: criterion.MaxScore,
+		})
+	}
+	if err := h.challengeService.CreateJudgingRound(round); err != nil {
+		respondJudgingError(c, "CreateJudgingRound", err, "Failed to create judging round")
+		return
+	}
+	c.JSON(http.StatusCreated, judgingRoundToResponse(round, time.Now()))
+}
+
+// GetJudgingRounds godoc
+// @Summary List judging rounds
+// @Description List a challenge's judging rounds in the order they open, with their status
+// @Tags judging
+// @Produce json
+// @Param challenge_id path string true "Challenge ID"
+// @Success 200 {object} dto.JudgingRoundListResponse "Rounds"
+// @Failure 404 {object} dto.ErrorResponse "Challenge not found"
+// @Failure 503 {object} dto.ErrorResponse "Judging not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{challenge_id}/rounds [get]
+func (h *ChallengeHandler) GetJudgingRounds(c *gin.Context) {
+	challenge, err := h.challengeService.GetChallengeByID(c.Param("challenge_id"))
+	if err != nil {
+		respondError(c, apperr.E("GetJudgingRounds", apperr.NotFound, err, "Challenge not found"))
+		return
+	}
+
+	rounds, err := h.challengeService.ListJudgingRounds(challenge.ID)
+	if err != nil {
+		respondJudgingError(c, "GetJudgingRounds", err, "Failed to list judging rounds")
+		return
+	}
+
+	now := time.Now()
+	response := dto.JudgingRoundListResponse{Rounds: make([]dto.JudgingRoundResponse, 0, len(rounds))}
+	for _, round := range rounds {
+		response.Rounds = append(response.Rounds, judgingRoundToResponse(round, now))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetJudgingRound godoc
+// @Summary Get judging round
+// @Description Get a judging round with its rubric and judges
+// @Tags judging
+// @Produce json
+// @Param round_id path string true "Round ID"
+// @Success 200 {object} dto.JudgingRoundResponse "Round"
+// @Failure 404 {object} dto.ErrorResponse "Round not found"
+// @Failure 503 {object} dto.ErrorResponse "Judging not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/rounds/{round_id} [get]
+func (h *ChallengeHandler) GetJudgingRound(c *gin.Context) {
+	round, ok := h.judgingRound(c, "GetJudgingRound")
+	if !ok {
+		return
+	}
+
+	judges, err := h.challengeService.ListJudges(round.ID)
+	if err != nil {
+		respondJudgingError(c, "GetJudgingRound", err, "Failed to list judges")
+		return
+	}
+
+	response := judgingRoundToResponse(round, time.Now())
+	for _, judge := range judges {
+		response.Judges = append(response.Judges, h.judgeToResponse(judge))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// AppointJudge godoc
+// @Summary Appoint judge
+// @Description Make a user a judge of a round. Their scores count by their weight against the other judges' (default 1). Judges can be changed until the round is tallied (staff only).
+// @Tags judging
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param round_id path string true "Round ID"
+// @Param request body dto.AppointJudgeRequest true "Judge"
+// @Success 201 {object} dto.JudgeResponse "Judge appointed"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff only"
+// @Failure 404 {object} dto.ErrorResponse "Round not found"
+// @Failure 409 {object} dto.ErrorResponse "Already a judge or round closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/admin/rounds/{round_id}/judges [post]
+func (h *ChallengeHandler) AppointJudge(c *gin.Context) {
+	staffID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("AppointJudge", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	var req dto.AppointJudgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("AppointJudge", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	round, ok := h.judgingRound(c, "AppointJudge")
+	if !ok {
+		return
+	}
+	if _, err := h.userService.GetUserByID(req.UserID); err != nil {
+		respondError(c, apperr.E("AppointJudge", apperr.NotFound, err, "User not found"))
+		return
+	}
+
+	judge, err := h.challengeService.AppointJudge(round.ID, req.UserID, req.Weight, staffID.(string))
+	if err != nil {
+		respondJudgingError(c, "AppointJudge", err, "Failed to appoint judge")
+		return
+	}
+	c.JSON(http.StatusCreated, h.judgeToResponse(judge))
+}
+
+// RemoveJudge godoc
+// @Summary Remove judge
+// @Description Withdraw a user from judging a round; scores they gave stop counting (staff only)
+// @Tags judging
+// @Security BearerAuth
+// @Produce json
+// @Param round_id path string true "Round ID"
+// @Param user_id path string true "Judge's user ID"
+// @Success 204 "Judge removed"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff only or not a judge"
+// @Failure 404 {object} dto.ErrorResponse "Round not found"
+// @Failure 409 {object} dto.ErrorResponse "Round closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/admin/rounds/{round_id}/judges/{user_id} [delete]
+func (h *ChallengeHandler) RemoveJudge(c *gin.Context) {
+	round, ok := h.judgingRound(c, "RemoveJudge")
+	if !ok {
+		return
+	}
+
+	if err := h.challengeService.RemoveJudge(round.ID, c.Param("user_id")); err != nil {
+		respondJudgingError(c, "RemoveJudge", err, "Failed to remove judge")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ScoreCause godoc
+// @Summary Score cause
+// @Description Rate a cause against every criterion of the round's rubric, from 0 to the criterion's maximum. Only the round's judges can score, while it is open; scoring a cause again replaces the earlier score.
+// @Tags judging
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param round_id path string true "Round ID"
+// @Param request body dto.ScoreCauseRequest true "Scores"
+// @Success 200 {object} dto.JudgeScoreResponse "Score saved"
+// @Failure 400 {object} dto.ErrorResponse "Invalid scores or cause not in the challenge"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not a judge of this round"
+// @Failure 404 {object} dto.ErrorResponse "Round or cause not found"
+// @Failure 409 {object} dto.ErrorResponse "Round not open"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/rounds/{round_id}/scores [put]
+func (h *ChallengeHandler) ScoreCause(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("ScoreCause", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	var req dto.ScoreCauseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("ScoreCause", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	round, ok := h.judgingRound(c, "ScoreCause")
+	if !ok {
+		return
+	}
+	cause, err := h.challengeService.GetCauseByID(req.CauseID)
+	if err != nil {
+		respondError(c, apperr.E("ScoreCause", apperr.NotFound, err, "Cause not found"))
+		return
+	}
+
+	score, err := h.challengeService.ScoreCause(round.ID, userID.(string), cause.ID, req.Scores, req.Comment, time.Now())
+	if err != nil {
+		respondJudgingError(c, "ScoreCause", err, "Failed to score cause")
+		return
+	}
+	c.JSON(http.StatusOK, judgeScoreToResponse(score))
+}
+
+// ListJudgeScores godoc
+// @Summary List judge scores
+// @Description List every judge's scores in a round, grouped by cause (staff only)
+// @Tags judging
+// @Security BearerAuth
+// @Produce json
+// @Param round_id path string true "Round ID"
+// @Success 200 {object} dto.JudgeScoreListResponse "Scores"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff only"
+// @Failure 404 {object} dto.ErrorResponse "Round not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/admin/rounds/{round_id}/scores [get]
+func (h *ChallengeHandler) ListJudgeScores(c *gin.Context) {
+	round, ok := h.judgingRound(c, "ListJudgeScores")
+	if !ok {
+		return
+	}
+
+	scores, err := h.challengeService.ListJudgeScores(round.ID)
+	if err != nil {
+		respondJudgingError(c, "ListJudgeScores", err, "Failed to list scores")
+		return
+	}
+
+	response := dto.JudgeScoreListResponse{Scores: make([]dto.JudgeScoreResponse, 0, len(scores))}
+	for _, score := range scores {
+		response.Scores = append(response.Scores, judgeScoreToResponse(score))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// CastCauseVote godoc
+// @Summary Vote for cause
+// @Description Cast a community vote for a cause while the round is open. Only members of the challenge can vote, once per round.
+// @Tags judging
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param round_id path string true "Round ID"
+// @Param request body dto.CastVoteRequest true "Vote"
+// @Success 201 {object} dto.VoteResponse "Vote cast"
+// @Failure 400 {object} dto.ErrorResponse "Cause not in the challenge"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not a member of the challenge"
+// @Failure 404 {object} dto.ErrorResponse "Round or cause not found"
+// @Failure 409 {object} dto.ErrorResponse "Round not open or already voted"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/rounds/{round_id}/votes [post]
+func (h *ChallengeHandler) CastCauseVote(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("CastCauseVote", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	var req dto.CastVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("CastCauseVote", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	round, ok := h.judgingRound(c, "CastCauseVote")
+	if !ok {
+		return
+	}
+	cause, err := h.challengeService.GetCauseByID(req.CauseID)
+	if err != nil {
+		respondError(c, apperr.E("CastCauseVote", apperr.NotFound, err, "Cause not found"))
+		return
+	}
+
+	vote, err := h.challengeService.CastCauseVote(round.ID, userID.(string), cause.ID, time.Now())
+	if err != nil {
+		respondJudgingError(c, "CastCauseVote", err, "Failed to cast vote")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.VoteResponse{RoundID: vote.RoundID, CauseID: vote.CauseID, VotedAt: vote.CreatedAt})
+}
+
+// GetJudgingResults godoc
+// @Summary Get judging round results
+// @Description Get the causes of a closed round ranked by their blended judge and vote score. In the final round each place carries its cause prize.
+// @Tags judging
+// @Produce json
+// @Param round_id path string true "Round ID"
+// @Success 200 {object} dto.JudgingResultsResponse "Results"
+// @Failure 404 {object} dto.ErrorResponse "Round not found"
+// @Failure 409 {object} dto.ErrorResponse "Round has not closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/rounds/{round_id}/results [get]
+func (h *ChallengeHandler) GetJudgingResults(c *gin.Context) {
+	round, ok := h.judgingRound(c, "GetJudgingResults")
+	if !ok {
+		return
+	}
+
+	results, err := h.challengeService.GetJudgingResults(round.ID)
+	if err != nil {
+		respondJudgingError(c, "GetJudgingResults", err, "Failed to get results")
+		return
+	}
+	c.JSON(http.StatusOK, h.judgingResultsToResponse(round, results))
+}
+
+// GetCauseAwards godoc
+// @Summary Get cause awards
+// @Description Get the results of a challenge's final judging round, whose places are awarded the challenge's cause prizes. The list is empty until the final round closes.
+// @Tags judging
+// @Produce json
+// @Param challenge_id path string true "Challenge ID"
+// @Success 200 {object} dto.JudgingResultsResponse "Awards"
+// @Failure 404 {object} dto.ErrorResponse "Challenge not found"
+// @Failure 503 {object} dto.ErrorResponse "Judging not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{challenge_id}/cause-awards [get]
+func (h *ChallengeHandler) GetCauseAwards(c *gin.Context) {
+	challenge, err := h.challengeService.GetChallengeByID(c.Param("challenge_id"))
+	if err != nil {
+		respondError(c, apperr.E("GetCauseAwards", apperr.NotFound, err, "Challenge not found"))
+		return
+	}
+
+	round, results, err := h.challengeService.GetCauseAwards(challenge.ID)
+	if err != nil {
+		respondJudgingError(c, "GetCauseAwards", err, "Failed to get cause awards")
+		return
+	}
+	c.JSON(http.StatusOK, h.judgingResultsToResponse(round, results))
+}
+
+func judgingRoundToResponse(round *challengeModel.JudgingRound, now time.Time) dto.JudgingRoundResponse {
+	response := dto.JudgingRoundResponse{
+		ID:          round.ID,
+		ChallengeID: round.ChallengeID,
+		Name:        round.Name,
+		Status:      string(round.Status(now)),
+		OpensAt:     round.OpensAt,
+		ClosesAt:    round.ClosesAt,
+		Rubric:      make([]dto.JudgingCriterion, 0, len(round.Rubric)),
+		VoteShare:   round.VoteShare,
+		Final:       round.Final,
+		ClosedAt:    round.ClosedAt,
+	}
+	for _, criterion := range round.Rubric {
+		response.Rubric = append(response.Rubric, dto.JudgingCriterion{
+			Name:     criterion.Name,
+			Weight:   criterion.Weight,
+			MaxScore: criterion.MaxScore,
+		})
+	}
+	return response
+}
+
+func (h *ChallengeHandler) judgeToResponse(judge *challengeModel.Judge) dto.JudgeResponse {
+	response := dto.JudgeResponse{
+		UserID:      judge.UserID,
+		Weight:      judge.Weight,
+		AppointedBy: judge.AppointedBy,
+		AppointedAt: judge.CreatedAt,
+	}
+	if user, err := h.userService.GetUserByID(judge.UserID); err == nil {
+		response.Username = user.Username
+	}
+	return response
+}
+
+func judgeScoreToResponse(score *challengeModel.JudgeScore) dto.JudgeScoreResponse {
+	return dto.JudgeScoreResponse{
+		CauseID:   score.CauseID,
+		JudgeID:   score.JudgeID,
+		Scores:    score.Scores,
+		Total:     score.Total,
+		Comment:   score.Comment,
+		UpdatedAt: score.UpdatedAt,
+	}
+}
+
+// judgingResultsToResponse converts the results, adding each cause's name. round is nil when
+// a challenge's final round has not closed.
+func (h *ChallengeHandler) judgingResultsToResponse(round *challengeModel.JudgingRound, results []*challengeModel.RoundResult) dto.JudgingResultsResponse {
+	response := dto.JudgingResultsResponse{Results: make([]dto.JudgingResultResponse, 0, len(results))}
+	if round != nil {
+		response.RoundID, response.ClosedAt = round.ID, round.ClosedAt
+	}
+	for _, result := range results {
+		entry := dto.JudgingResultResponse{
+			Rank:       result.Rank,
+			CauseID:    result.CauseID,
+			JudgeScore: result.JudgeScore,
+			Votes:      result.Votes,
+			Score:      result.Score,
+			Prize:      prizeToResponse(result.Prize),
+		}
+		if cause, err := h.challengeService.GetCauseByID(result.CauseID); err == nil {
+			entry.CauseName = cause.Name
+		}
+		response.Results = append(response.Results, entry)
+	}
+	return response
+}
@@ -0,0 +1,474 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/apperr"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+)
+
+// manageErrors are the challenge and cause management errors a client can act on, with their
+// API codes.
+var manageErrors = []struct {
+	err  error
+	code apperr.Code
+}{
+	{challengeModel.ErrInvalidChallengeSchedule, apperr.InvalidInput},
+	{challengeModel.ErrInvalidRankingCriterion, apperr.InvalidInput},
+	{challengeModel.ErrInvalidPrizes, apperr.InvalidInput},
+	{challengeModel.ErrAlreadyOwner, apperr.InvalidInput},
+	{challengeModel.ErrChallengeFinished, apperr.Conflict},
+	{challengeModel.ErrOutstandingSponsorships, apperr.Conflict},
+	{challengeModel.ErrSponsorsSettled, apperr.Conflict},
+	{challengeModel.ErrOwnerChanged, apperr.Conflict},
+}
+
+// respondManageError writes err with the code of the management error it wraps, falling back to
+// msg for unexpected errors.
+func respondManageError(c *gin.Context, op string, err error, msg string) {
+	for _, known := range manageErrors {
+		if errors.Is(err, known.err) {
+			respondError(c, apperr.E(op, known.code, err, err.Error()))
+			return
+		}
+	}
+	respondError(c, apperr.E(op, apperr.Internal, err, msg))
+}
+
+// ownedChallenge loads the challenge named in the path for its owner or staff, writing the
+// error response when it cannot.
+func (h *ChallengeHandler) ownedChallenge(c *gin.Context, op string) (*challengeModel.Challenge, bool) {
+	challenge, err := h.challengeService.GetChallengeByID(c.Param("id"))
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Challenge not found"))
+		return nil, false
+	}
+	if challenge.OwnerID != c.GetString("user_id") && !c.GetBool("is_staff") {
+		respondError(c, apperr.E(op, apperr.Forbidden, nil, "You must be the owner of this challenge"))
+		return nil, false
+	}
+	return challenge, true
+}
+
+// ownedCause loads the cause named in the path for its owner or staff, writing the error
+// response when it cannot.
+func (h *ChallengeHandler) ownedCause(c *gin.Context, op string) (*challengeModel.Cause, bool) {
+	cause, err := h.challengeService.GetCauseByID(c.Param("id"))
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Cause not found"))
+		return nil, false
+	}
+	if cause.OwnerID != c.GetString("user_id") && !c.GetBool("is_staff") {
+		respondError(c, apperr.E(op, apperr.Forbidden, nil, "You must be the owner of this cause"))
+		return nil, false
+	}
+	return cause, true
+}
+
+// forceDelete reports whether the request asks to delete despite outstanding sponsorships,
+// writing a forbidden response when a non-staff caller asks.
+func forceDelete(c *gin.Context, op string) (force, ok bool) {
+	if c.Query("force") != "true" {
+		return false, true
+	}
+	if !c.GetBool("is_staff") {
+		respondError(c, apperr.E(op, apperr.Forbidden, nil, "Only staff can force a delete"))
+		return false, false
+	}
+	return true, true
+}
+
+// newOwner binds the transfer request and checks its user exists, writing the error response
+// when it does not.
+func (h *ChallengeHandler) newOwner(c *gin.Context, op string) (string, bool) {
+	var req dto.TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E(op, apperr.InvalidInput, err, "Invalid request body"))
+		return "", false
+	}
+	if _, err := h.userService.GetUserByID(req.UserID); err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "User not found"))
+		return "", false
+	}
+	return req.UserID, true
+}
+
+// UpdateChallenge godoc
+// @Summary Update challenge
+// @Description Update a challenge's details. Only fields that are sent change. Closed and cancelled challenges cannot be updated. Owner or staff only.
+// @Tags challenges
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Challenge ID"
+// @Param challenge body dto.UpdateChallengeRequest true "Challenge update details"
+// @Success 200 {object} dto.ChallengeResponse "Challenge updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body, schedule or ranking criterion"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the challenge owner"
+// @Failure 404 {object} dto.ErrorResponse "Challenge not found"
+// @Failure 409 {object} dto.ErrorResponse "Challenge closed or cancelled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{id} [put]
+func (h *ChallengeHandler) UpdateChallenge(c *gin.Context) {
+	challenge, ok := h.ownedChallenge(c, "UpdateChallenge")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("UpdateChallenge", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	if req.Name != "" {
+		challenge.Name = req.Name
+	}
+	if req.Description != "" {
+		challenge.Description = req.Description
+	}
+	if req.Mode != "" {
+		challenge.Mode = challengeModel.ChallengeMode(req.Mode)
+	}
+	if req.Condition != "" {
+		challenge.Condition = req.Condition
+	}
+	if req.Goal != "" {
+		challenge.Goal = req.Goal
+	}
+	coordinates, err := requestCoordinates(req.Latitude, req.Longitude)
+	if err != nil {
+		respondError(c, apperr.E("UpdateChallenge", apperr.InvalidInput, err, err.Error()))
+		return
+	}
+	if req.Location != "" && req.Location != challenge.Location {
+		challenge.Location = req.Location
+		challenge.Coordinates = nil // re-geocoded from the new location unless sent below
+	}
+	if coordinates != nil {
+		challenge.Coordinates = coordinates
+	}
+	if req.DistanceToCover != nil {
+		challenge.DistanceToCover = *req.DistanceToCover
+	}
+	if req.TargetAmount != nil {
+		challenge.TargetAmount = *req.TargetAmount
+	}
+	if req.TargetAmountPerKm != nil {
+		challenge.TargetAmountPerKm = *req.TargetAmountPerKm
+	}
+	if req.StartDuration != "" {
+		challenge.StartDuration = req.StartDuration
+	}
+	if req.EndDuration != "" {
+		if err := challenge.SetEnd(req.EndDuration); err != nil {
+			respondError(c, apperr.E("UpdateChallenge", apperr.InvalidInput, err, err.Error()))
+			return
+		}
+	}
+	if req.NoOfWinner != nil {
+		challenge.NoOfWinner = *req.NoOfWinner
+	}
+	if req.WinningPrice != nil {
+		challenge.WinningPrice = prizesFromRequest(req.WinningPrice)
+	}
+	if req.CausePrice != nil {
+		challenge.CausePrice = prizesFromRequest(req.CausePrice)
+	}
+	if req.RankingCriterion != "" {
+		challenge.RankingCriterion = challengeModel.RankingCriterion(req.RankingCriterion)
+	}
+	if req.CoverImage != "" {
+		challenge.CoverImage = req.CoverImage
+	}
+	if req.VideoUrl != "" {
+		challenge.VideoUrl = req.VideoUrl
+	}
+
+	if err := h.challengeService.UpdateChallenge(challenge); err != nil {
+		respondManageError(c, "UpdateChallenge", err, "Failed to update challenge")
+		return
+	}
+
+	owner, _ := h.userService.GetUserByID(challenge.OwnerID)
+	c.JSON(http.StatusOK, h.challengeToResponse(challenge, owner))
+}
+
+// CancelChallenge godoc
+// @Summary Cancel a challenge
+// @Description Call off a challenge that has not closed. It can no longer be joined or updated, and it is never closed out, so no winners are chosen. Owner or staff only.
+// @Tags challenges
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Challenge ID"
+// @Success 200 {object} dto.ChallengeResponse "Challenge cancelled"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the challenge owner"
+// @Failure 404 {object} dto.ErrorResponse "Challenge not found"
+// @Failure 409 {object} dto.ErrorResponse "Challenge already closed or cancelled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{id}/cancel [post]
+func (h *ChallengeHandler) CancelChallenge(c *gin.Context) {
+	challenge, ok := h.ownedChallenge(c, "CancelChallenge")
+	if !ok {
+		return
+	}
+
+	challenge, err := h.challengeService.CancelChallenge(challenge.ID, time.Now())
+	if err != nil {
+		respondManageError(c, "CancelChallenge", err, "Failed to cancel challenge")
+		return
+	}
+
+	owner, _ := h.userService.GetUserByID(challenge.OwnerID)
+	c.JSON(http.StatusOK, h.challengeToResponse(challenge, owner))
+}
+
+// DeleteChallenge godoc
+// @Summary Delete challenge
+// @Description Delete a challenge with its causes, runners, sponsorships and members. It is refused while sponsors of the challenge or its causes are still owed a settlement unless staff force it, and always once a cause's sponsors have been settled, so their statements are kept. Owner or staff only.
+// @Tags challenges
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Challenge ID"
+// @Param force query bool false "Delete despite outstanding sponsorships (staff only)"
+// @Success 204 "Challenge deleted successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the challenge owner, or force without staff"
+// @Failure 404 {object} dto.ErrorResponse "Challenge not found"
+// @Failure 409 {object} dto.ErrorResponse "Sponsors have outstanding obligations or have been settled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{id} [delete]
+func (h *ChallengeHandler) DeleteChallenge(c *gin.Context) {
+	challenge, ok := h.ownedChallenge(c, "DeleteChallenge")
+	if !ok {
+		return
+	}
+	force, ok := forceDelete(c, "DeleteChallenge")
+	if !ok {
+		return
+	}
+
+	if err := h.challengeService.DeleteChallenge(challenge.ID, force); err != nil {
+		respondManageError(c, "DeleteChallenge", err, "Failed to delete challenge")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TransferChallenge godoc
+// @Summary Transfer challenge ownership
+// @Description Hand a challenge to another user. Owner or staff only.
+// @Tags challenges
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Challenge ID"
+// @Param owner body dto.TransferOwnershipRequest true "New owner"
+// @Success 200 {object} dto.ChallengeResponse "Ownership transferred"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body, or already the owner"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the challenge owner"
+// @Failure 404 {object} dto.ErrorResponse "Challenge or user not found"
+// @Failure 409 {object} dto.ErrorResponse "Ownership changed during the transfer"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{id}/transfer [post]
+func (h *ChallengeHandler) TransferChallenge(c *gin.Context) {
+	challenge, ok := h.ownedChallenge(c, "TransferChallenge")
+	if !ok {
+		return
+	}
+	userID, ok := h.newOwner(c, "TransferChallenge")
+	if !ok {
+		return
+	}
+
+	challenge, err := h.challengeService.TransferChallenge(challenge.ID, challenge.OwnerID, userID)
+	if err != nil {
+		respondManageError(c, "TransferChallenge", err, "Failed to transfer challenge")
+		return
+	}
+
+	owner, _ := h.userService.GetUserByID(challenge.OwnerID)
+	c.JSON(http.StatusOK, h.challengeToResponse(challenge, owner))
+}
+
+// UpdateCause godoc
+// @Summary Update cause
+// @Description Update a cause's details. Only fields that are sent change. Owner or staff only.
+// @Tags causes
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Cause ID"
+// @Param cause body dto.UpdateCauseRequest true "Cause update details"
+// @Success 200 {object} dto.CauseResponse "Cause updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the cause owner"
+// @Failure 404 {object} dto.ErrorResponse "Cause not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/{id} [put]
+func (h *ChallengeHandler) UpdateCause(c *gin.Context) {
+	cause, ok := h.ownedCause(c, "UpdateCause")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateCauseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("UpdateCause", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	if req.Name != "" {
+		cause.Name = req.Name
+	}
+	if req.Problem != "" {
+		cause.Problem = req.Problem
+	}
+	if req.Solution != "" {
+		cause.Solution = req.Solution
+	}
+	if req.ProductDescription != "" {
+		cause.ProductDescription = req.ProductDescription
+	}
+	if req.Activity != "" {
+		cause.Activity = challengeModel.Activity(req.Activity)
+	}
+	coordinates, err := requestCoordinates(req.Latitude, req.Longitude)
+	if err != nil {
+		respondError(c, apperr.E("UpdateCause", apperr.InvalidInput, err, err.Error()))
+		return
+	}
+	if req.Location != "" && req.Location != cause.Location {
+		cause.Location = req.Location
+		cause.Coordinates = nil // re-geocoded from the new location unless sent below
+	}
+	if coordinates != nil {
+		cause.Coordinates = coordinates
+	}
+	if req.Description != "" {
+		cause.Description = req.Description
+	}
+	if req.IsCommercial != nil {
+		cause.IsCommercial = *req.IsCommercial
+	}
+	if req.WhoIdeaImpact != "" {
+		cause.WhoIdeaImpact = req.WhoIdeaImpact
+	}
+	if req.BuyerUser != "" {
+		cause.BuyerUser = req.BuyerUser
+	}
+	if req.AmountPerPiece != nil {
+		cause.AmountPerPiece = *req.AmountPerPiece
+	}
+	if req.FundCause != nil {
+		cause.FundCause = *req.FundCause
+	}
+	if req.FundAmount != nil {
+		cause.FundAmount = *req.FundAmount
+	}
+	if req.WillingAmount != nil {
+		cause.WillingAmount = *req.WillingAmount
+	}
+	if req.UnitPrice != nil {
+		cause.UnitPrice = *req.UnitPrice
+	}
+	if req.CostToLaunch != "" {
+		cause.CostToLaunch = req.CostToLaunch
+	}
+	if req.BenefitDesc != "" {
+		cause.BenefitDesc = req.BenefitDesc
+	}
+	if req.WorkoutImg != "" {
+		cause.WorkoutImg = req.WorkoutImg
+	}
+	if req.VideoUrl != "" {
+		cause.VideoUrl = req.VideoUrl
+	}
+
+	if err := h.challengeService.UpdateCause(cause); err != nil {
+		respondManageError(c, "UpdateCause", err, "Failed to update cause")
+		return
+	}
+
+	owner, _ := h.userService.GetUserByID(cause.OwnerID)
+	c.JSON(http.StatusOK, h.causeToResponse(cause, owner))
+}
+
+// DeleteCause godoc
+// @Summary Delete cause
+// @Description Delete a cause with its runners, sponsorships, orders and members. It is refused while its sponsors are still owed a settlement unless staff force it, and always once they have been settled, so their statements are kept. Owner or staff only.
+// @Tags causes
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Cause ID"
+// @Param force query bool false "Delete despite outstanding sponsorships (staff only)"
+// @Success 204 "Cause deleted successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the cause owner, or force without staff"
+// @Failure 404 {object} dto.ErrorResponse "Cause not found"
+// @Failure 409 {object} dto.ErrorResponse "Sponsors have outstanding obligations or have been settled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/{id} [delete]
+func (h *ChallengeHandler) DeleteCause(c *gin.Context) {
+	cause, ok := h.ownedCause(c, "DeleteCause")
+	if !ok {
+		return
+	}
+	force, ok := forceDelete(c, "DeleteCause")
+	if !ok {
+		return
+	}
+
+	if err := h.challengeService.DeleteCause(cause.ID, force); err != nil {
+		respondManageError(c, "DeleteCause", err, "Failed to delete cause")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TransferCause godoc
+// @Summary Transfer cause ownership
+// @Description Hand a cause to another user. Owner or staff only.
+// @Tags causes
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Cause ID"
+// @Param owner body dto.TransferOwnershipRequest true "New owner"
+// @Success 200 {object} dto.CauseResponse "Ownership transferred"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body, or already the owner"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the cause owner"
+// @Failure 404 {object} dto.ErrorResponse "Cause or user not found"
+// @Failure 409 {object} dto.ErrorResponse "Ownership changed during the transfer"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/{id}/transfer [post]
+func (h *ChallengeHandler) TransferCause(c *gin.Context) {
+	cause, ok := h.ownedCause(c, "TransferCause")
+	if !ok {
+		return
+	}
+	userID, ok := h.newOwner(c, "TransferCause")
+	if !ok {
+		return
+	}
+
+	cause, err := h.challengeService.TransferCause(cause.ID, cause.OwnerID, userID)
+	if err != nil {
+		respondManageError(c, "TransferCause", err, "Failed to transfer cause")
+		return
+	}
+
+	owner, _ := h.userService.GetUserByID(cause.OwnerID)
+	c.JSON(http.StatusOK, h.causeToResponse(cause, owner))
+}
@@ -0,0 +1,385 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/apperr"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+)
+
+// causeOrderErrors are the cause order errors a client can act on, with their API codes.
+var causeOrderErrors = []struct {
+	err  error
+	code apperr.Code
+}{
+	{challengeModel.ErrCauseNotForSale, apperr.InvalidInput},
+	{challengeModel.ErrInvalidQuantity, apperr.InvalidInput},
+	{challengeModel.ErrAmountMismatch, apperr.InvalidInput},
+	{challengeModel.ErrInvalidStock, apperr.InvalidInput},
+	{challengeModel.ErrOutOfStock, apperr.Conflict},
+	{challengeModel.ErrInvalidOrderState, apperr.Conflict},
+	{challengeModel.ErrOrdersNotEnabled, apperr.Unavailable},
+}
+
+// respondCauseOrderError writes err with its mapped code and message, falling back to msg for
+// unexpected errors.
+func respondCauseOrderError(c *gin.Context, op string, err error, msg string) {
+	for _, known := range causeOrderErrors {
+		if errors.Is(err, known.err) {
+			respondError(c, apperr.E(op, known.code, err, err.Error()))
+			return
+		}
+	}
+	respondError(c, apperr.E(op, apperr.Internal, err, msg))
+}
+
+// causeOrder loads the order named in the path and its cause, writing the error response when
+// it cannot.
+func (h *ChallengeHandler) causeOrder(c *gin.Context, op string) (*challengeModel.CauseOrder, *challengeModel.Cause, bool) {
+	order, err := h.challengeService.GetCauseOrder(c.Param("order_id"))
+	if errors.Is(err, challengeModel.ErrOrdersNotEnabled) {
+		respondCauseOrderError(c, op, err, "")
+		return nil, nil, false
+	}
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Order not found"))
+		return nil, nil, false
+	}
+	cause, err := h.challengeService.GetCauseByID(order.CauseID)
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Cause not found"))
+		return nil, nil, false
+	}
+	return order, cause, true
+}
+
+// sellerCauseOrder loads the order named in the path for its cause's owner or staff, writing
+// the error response when it cannot.
+func (h *ChallengeHandler) sellerCauseOrder(c *gin.Context, op string) (*challengeModel.CauseOrder, bool) {
+	order, cause, ok := h.causeOrder(c, op)
+	if !ok {
+		return nil, false
+	}
+	if cause.OwnerID != c.GetString("user_id") && !c.GetBool("is_staff") {
+		respondError(c, apperr.E(op, apperr.Forbidden, nil, "Only the cause owner can manage its orders"))
+		return nil, false
+	}
+	return order, true
+}
+
+// BuyCause godoc
+// @Summary Order a cause
+// @Description Place a pending order for units of a commercial cause. The amount must equal quantity times the cause's unit price; stock is taken when the order is paid.
+// @Tags purchase
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param purchase body dto.BuyCauseRequest true "Order details"
+// @Success 201 {object} dto.CauseOrderResponse "Order placed"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body, cause not for sale or amount mismatch"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Cause not found"
+// @Failure 409 {object} dto.ErrorResponse "Not enough stock left"
+// @Failure 503 {object} dto.ErrorResponse "Cause orders not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/buy [post]
+func (h *ChallengeHandler) BuyCause(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("BuyCause", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	var req dto.BuyCauseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("BuyCause", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+
+	cause, err := h.challengeService.GetCauseByID(req.CauseID)
+	if err != nil {
+		respondError(c, apperr.E("BuyCause", apperr.NotFound, err, "Cause not found"))
+		return
+	}
+
+	order, err := h.challengeService.PlaceCauseOrder(cause.ID, userID.(string), req.Quantity, req.Amount)
+	if err != nil {
+		respondCauseOrderError(c, "BuyCause", err, "Failed to buy cause")
+		return
+	}
+	c.JSON(http.StatusCreated, causeOrderToResponse(order))
+}
+
+// GetMyCauseOrders godoc
+// @Summary List my cause orders
+// @Description List the caller's cause orders, newest first
+// @Tags purchase
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Orders per page" default(20)
+// @Success 200 {object} dto.CauseOrderListResponse "Orders"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 503 {object} dto.ErrorResponse "Cause orders not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/orders [get]
+func (h *ChallengeHandler) GetMyCauseOrders(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("GetMyCauseOrders", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	limit, offset := parsePagination(c, 20)
+	orders, total, err := h.challengeService.ListBuyerOrders(userID.(string), limit, offset)
+	if err != nil {
+		respondCauseOrderError(c, "GetMyCauseOrders", err, "Failed to list orders")
+		return
+	}
+	c.JSON(http.StatusOK, causeOrdersToResponse(orders, total))
+}
+
+// GetCauseOrder godoc
+// @Summary Get cause order
+// @Description Get an order; visible to its buyer, the cause owner and staff
+// @Tags purchase
+// @Security BearerAuth
+// @Produce json
+// @Param order_id path string true "Order ID"
+// @Success 200 {object} dto.CauseOrderResponse "Order"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the buyer or the cause owner"
+// @Failure 404 {object} dto.ErrorResponse "Order not found"
+// @Failure 503 {object} dto.ErrorResponse "Cause orders not enabled"
+// @Router /causes/orders/{order_id} [get]
+func (h *ChallengeHandler) GetCauseOrder(c *gin.Context) {
+	order, cause, ok := h.causeOrder(c, "GetCauseOrder")
+	if !ok {
+		return
+	}
+	userID := c.GetString("user_id")
+	if order.BuyerID != userID && cause.OwnerID != userID && !c.GetBool("is_staff") {
+		respondError(c, apperr.E("GetCauseOrder", apperr.Forbidden, nil, "You cannot view this order"))
+		return
+	}
+	c.JSON(http.StatusOK, causeOrderToResponse(order))
+}
+
+// GetCauseOrders godoc
+// @Summary List a cause's orders
+// @Description List the orders of a cause, newest first (cause owner or staff)
+// @Tags purchase
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Cause ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Orders per page" default(20)
+// @Success 200 {object} dto.CauseOrderListResponse "Orders"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the cause owner"
+// @Failure 404 {object} dto.ErrorResponse "Cause not found"
+// @Failure 503 {object} dto.ErrorResponse "Cause orders not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/{id}/orders [get]
+func (h *ChallengeHandler) GetCauseOrders(c *gin.Context) {
+	cause, err := h.challengeService.GetCauseByID(c.Param("id"))
+	if err != nil {
+		respondError(c, apperr.E("GetCauseOrders", apperr.NotFound, err, "Cause not found"))
+		return
+	}
+	if cause.OwnerID != c.GetString("user_id") && !c.GetBool("is_staff") {
+		respondError(c, apperr.E("GetCauseOrders", apperr.Forbidden, nil, "Only the cause owner can view its orders"))
+		return
+	}
+
+	limit, offset := parsePagination(c, 20)
+	orders, total, err := h.challengeService.ListCauseOrders(cause.ID, limit, offset)
+	if err != nil {
+		respondCauseOrderError(c, "GetCauseOrders", err, "Failed to list orders")
+		return
+	}
+	c.JSON(http.StatusOK, causeOrdersToResponse(orders, total))
+}
+
+// PayCauseOrder godoc
+// @Summary Confirm order payment
+// @Description Mark a pending order paid, taking its units from stock, and email the buyer a receipt (cause owner or staff)
+// @Tags purchase
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param order_id path string true "Order ID"
+// @Param request body dto.PayCauseOrderRequest true "Payment"
+// @Success 200 {object} dto.CauseOrderResponse "Order paid"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request body"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the cause owner"
+// @Failure 404 {object} dto.ErrorResponse "Order not found"
+// @Failure 409 {object} dto.ErrorResponse "Order is not pending, or the cause sold out"
+// @Failure 503 {object} dto.ErrorResponse "Cause orders not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/orders/{order_id}/pay [post]
+func (h *ChallengeHandler) PayCauseOrder(c *gin.Context) {
+	var req dto.PayCauseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("PayCauseOrder", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+	order, ok := h.sellerCauseOrder(c, "PayCauseOrder")
+	if !ok {
+		return
+	}
+
+	order, err := h.challengeService.PayCauseOrder(order.ID, req.PaymentRef, time.Now())
+	if err != nil {
+		respondCauseOrderError(c, "PayCauseOrder", err, "Failed to confirm payment")
+		return
+	}
+	c.JSON(http.StatusOK, causeOrderToResponse(order))
+}
+
+// FulfilCauseOrder godoc
+// @Summary Fulfil order
+// @Description Mark a paid order delivered (cause owner or staff)
+// @Tags purchase
+// @Security BearerAuth
+// @Produce json
+// @Param order_id path string true "Order ID"
+// @Success 200 {object} dto.CauseOrderResponse "Order fulfilled"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the cause owner"
+// @Failure 404 {object} dto.ErrorResponse "Order not found"
+// @Failure 409 {object} dto.ErrorResponse "Order is not paid"
+// @Failure 503 {object} dto.ErrorResponse "Cause orders not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/orders/{order_id}/fulfil [post]
+func (h *ChallengeHandler) FulfilCauseOrder(c *gin.Context) {
+	order, ok := h.sellerCauseOrder(c, "FulfilCauseOrder")
+	if !ok {
+		return
+	}
+
+	order, err := h.challengeService.FulfilCauseOrder(order.ID, time.Now())
+	if err != nil {
+		respondCauseOrderError(c, "FulfilCauseOrder", err, "Failed to fulfil order")
+		return
+	}
+	c.JSON(http.StatusOK, causeOrderToResponse(order))
+}
+
+// RefundCauseOrder godoc
+// @Summary Refund order
+// @Description Refund a paid or fulfilled order, returning its units to stock, and email the buyer (cause owner or staff)
+// @Tags purchase
+// @Security BearerAuth
+// @Produce json
+// @Param order_id path string true "Order ID"
+// @Success 200 {object} dto.CauseOrderResponse "Order refunded"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the cause owner"
+// @Failure 404 {object} dto.ErrorResponse "Order not found"
+// @Failure 409 {object} dto.ErrorResponse "Order is not paid"
+// @Failure 503 {object} dto.ErrorResponse "Cause orders not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/orders/{order_id}/refund [post]
+func (h *ChallengeHandler) RefundCauseOrder(c *gin.Context) {
+	order, ok := h.sellerCauseOrder(c, "RefundCauseOrder")
+	if !ok {
+		return
+	}
+
+	order, err := h.challengeService.RefundCauseOrder(order.ID, time.Now())
+	if err != nil {
+		respondCauseOrderError(c, "RefundCauseOrder", err, "Failed to refund order")
+		return
+	}
+	c.JSON(http.StatusOK, causeOrderToResponse(order))
+}
+
+// SetCauseStock godoc
+// @Summary Set cause stock
+// @Description Set how many units of a cause can be sold in all, or lift the limit with a null stock. It cannot go below the units already sold (cause owner or staff).
+// @Tags purchase
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Cause ID"
+// @Param request body dto.SetCauseStockRequest true "Stock"
+// @Success 200 {object} dto.CauseResponse "Cause with its new stock"
+// @Failure 400 {object} dto.ErrorResponse "Invalid stock"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the cause owner"
+// @Failure 404 {object} dto.ErrorResponse "Cause not found"
+// @Failure 503 {object} dto.ErrorResponse "Cause orders not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/{id}/stock [put]
+func (h *ChallengeHandler) SetCauseStock(c *gin.Context) {
+	var req dto.SetCauseStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("SetCauseStock", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	cause, err := h.challengeService.GetCauseByID(c.Param("id"))
+	if err != nil {
+		respondError(c, apperr.E("SetCauseStock", apperr.NotFound, err, "Cause not found"))
+		return
+	}
+	if cause.OwnerID != c.GetString("user_id") && !c.GetBool("is_staff") {
+		respondError(c, apperr.E("SetCauseStock", apperr.Forbidden, nil, "Only the cause owner can set its stock"))
+		return
+	}
+
+	cause, err = h.challengeService.SetCauseStock(cause.ID, req.Stock)
+	if err != nil {
+		respondCauseOrderError(c, "SetCauseStock", err, "Failed to set stock")
+		return
+	}
+	owner, err := h.userService.GetUserByID(cause.OwnerID)
+	if err != nil {
+		respondError(c, apperr.E("SetCauseStock", apperr.Internal, err, "Failed to get cause owner"))
+		return
+	}
+	c.JSON(http.StatusOK, h.causeToResponse(cause, owner))
+}
+
+// causeAvailable returns the units of the cause left to sell, or nil when stock is unlimited.
+func causeAvailable(cause *challengeModel.Cause) *int {
+	if cause.Stock == nil {
+		return nil
+	}
+	available := cause.Available()
+	return &available
+}
+
+func causeOrderToResponse(order *challengeModel.CauseOrder) dto.CauseOrderResponse {
+	return dto.CauseOrderResponse{
+		ID:          order.ID,
+		CauseID:     order.CauseID,
+		BuyerID:     order.BuyerID,
+		Quantity:    order.Quantity,
+		UnitPrice:   order.UnitPrice,
+		Amount:      order.Amount,
+		Status:      string(order.Status),
+		PaymentRef:  order.PaymentRef,
+		PaidAt:      order.PaidAt,
+		FulfilledAt: order.FulfilledAt,
+		RefundedAt:  order.RefundedAt,
+		DateCreated: order.CreatedAt,
+	}
+}
+
+func causeOrdersToResponse(orders []*challengeModel.CauseOrder, total int64) dto.CauseOrderListResponse {
+	response := dto.CauseOrderListResponse{Orders: make([]dto.CauseOrderResponse, 0, len(orders)), Total: total}
+	for _, order := range orders {
+		response.Orders = append(response.Orders, causeOrderToResponse(order))
+	}
+	return response
+}
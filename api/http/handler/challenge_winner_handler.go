@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/apperr"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+)
+
+// challengeWinnerErrors are the winner errors a client can act on, with their API codes.
+var challengeWinnerErrors = []struct {
+	err  error
+	code apperr.Code
+}{
+	{challengeModel.ErrChallengeNotClosed, apperr.Conflict},
+	{challengeModel.ErrInvalidWinners, apperr.InvalidInput},
+	{challengeModel.ErrNotParticipant, apperr.InvalidInput},
+	{challengeModel.ErrOverrideReasonRequired, apperr.InvalidInput},
+	{challengeModel.ErrWinnersNotEnabled, apperr.Unavailable},
+}
+
+// respondChallengeWinnerError writes err with its mapped code and message, falling back to msg
+// for unexpected errors.
+func respondChallengeWinnerError(c *gin.Context, op string, err error, msg string) {
+	for _, known := range challengeWinnerErrors {
+		if errors.Is(err, known.err) {
+			respondError(c, apperr.E(op, known.code, err, err.Error()))
+			return
+		}
+	}
+	respondError(c, apperr.E(op, apperr.Internal, err, msg))
+}
+
+// GetChallengeWinners godoc
+// @Summary Get challenge winners
+// @Description Get the winners of a challenge with their prizes, first place first. Winners are selected when the challenge ends, ranked by its ranking criterion; the list is empty until then.
+// @Tags challenges
+// @Produce json
+// @Param challenge_id path string true "Challenge ID"
+// @Success 200 {object} dto.ChallengeWinnersResponse "Winners"
+// @Failure 404 {object} dto.ErrorResponse "Challenge not found"
+// @Failure 503 {object} dto.ErrorResponse "Winner selection not enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{challenge_id}/winners [get]
+func (h *ChallengeHandler) GetChallengeWinners(c *gin.Context) {
+	challenge, err := h.challengeService.GetChallengeByID(c.Param("challenge_id"))
+	if err != nil {
+		respondError(c, apperr.E("GetChallengeWinners", apperr.NotFound, err, "Challenge not found"))
+		return
+	}
+
+	winners, err := h.challengeService.GetChallengeWinners(challenge.ID)
+	if err != nil {
+		respondChallengeWinnerError(c, "GetChallengeWinners", err, "Failed to get winners")
+		return
+	}
+	c.JSON(http.StatusOK, h.winnersToResponse(challenge, winners))
+}
+
+// OverrideChallengeWinners godoc
+// @Summary Override challenge winners
+// @Description Replace the winners of a closed challenge with the given participants, first place first. Prizes follow the new ranks, and the change is recorded with the reason (staff only).
+// @Tags challenges
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param challenge_id path string true "Challenge ID"
+// @Param request body dto.OverrideChallengeWinnersRequest true "New winners and reason"
+// @Success 200 {object} dto.ChallengeWinnersResponse "Winners"
+// @Failure 400 {object} dto.ErrorResponse "Invalid winners or missing reason"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff only"
+// @Failure 404 {object} dto.ErrorResponse "Challenge not found"
+// @Failure 409 {object} dto.ErrorResponse "Challenge has not closed"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/admin/{challenge_id}/winners [put]
+func (h *ChallengeHandler) OverrideChallengeWinners(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("OverrideChallengeWinners", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	var req dto.OverrideChallengeWinnersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("OverrideChallengeWinners", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	challenge, err := h.challengeService.GetChallengeByID(c.Param("challenge_id"))
+	if err != nil {
+		respondError(c, apperr.E("OverrideChallengeWinners", apperr.NotFound, err, "Challenge not found"))
+		return
+	}
+
+	winners, err := h.challengeService.OverrideChallengeWinners(challenge.ID, userID.(string), req.Reason, req.UserIDs)
+	if err != nil {
+		respondChallengeWinnerError(c, "OverrideChallengeWinners", err, "Failed to override winners")
+		return
+	}
+	c.JSON(http.StatusOK, h.winnersToResponse(challenge, winners))
+}
+
+// ListChallengeWinnerAudits godoc
+// @Summary List winner overrides
+// @Description List the staff overrides of a challenge's winners, oldest first, with who made each change and why (staff only)
+// @Tags challenges
+// @Security BearerAuth
+// @Produce json
+// @Param challenge_id path string true "Challenge ID"
+// @Success 200 {object} dto.WinnerAuditListResponse "Overrides"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Staff only"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/admin/{challenge_id}/winner-audits [get]
+func (h *ChallengeHandler) ListChallengeWinnerAudits(c *gin.Context) {
+	audits, err := h.challengeService.ListWinnerAudits(c.Param("challenge_id"))
+	if err != nil {
+		respondChallengeWinnerError(c, "ListChallengeWinnerAudits", err, "Failed to list winner overrides")
+		return
+	}
+
+	response := dto.WinnerAuditListResponse{Audits: make([]dto.WinnerAuditResponse, 0, len(audits))}
+	for _, audit := range audits {
+		response.Audits = append(response.Audits, dto.WinnerAuditResponse{
+			ID:        audit.ID,
+			ActorID:   audit.ActorID,
+			Reason:    audit.Reason,
+			Previous:  audit.Previous,
+			Winners:   audit.Winners,
+			CreatedAt: audit.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ChallengeHandler) winnersToResponse(challenge *challengeModel.Challenge, winners []*challengeModel.ChallengeWinner) dto.ChallengeWinnersResponse {
+	response := dto.ChallengeWinnersResponse{
+		ChallengeID:      challenge.ID,
+		RankingCriterion: string(challenge.RankingCriterion.OrDefault()),
+		ClosedAt:         challenge.ClosedAt,
+		Winners:          make([]dto.ChallengeWinnerResponse, 0, len(winners)),
+	}
+	for _, winner := range winners {
+		entry := dto.ChallengeWinnerResponse{
+			Rank:            winner.Rank,
+			UserID:          winner.UserID,
+			Distance:        winner.Distance,
+			Duration:        formatDuration(winner.Duration),
+			DurationSeconds: model.Seconds(winner.Duration),
+			MoneyRaised:     winner.MoneyRaised,
+			Prize:           prizeToResponse(winner.Prize),
+			Overridden:      winner.Overridden,
+		}
+		if user, err := h.userService.GetUserByID(winner.UserID); err == nil {
+			entry.Username = user.Username
+			entry.FullName = user.GetFullName()
+		}
+		response.Winners = append(response.Winners, entry)
+	}
+	return response
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+// errDurationRequired is returned by requestDuration when the activity must have a duration.
+var errDurationRequired = errors.New("duration or duration_seconds is required")
+
+// requestDuration reads the duration of a request that may send duration_seconds, a duration
+// string in any supported format, or both; duration_seconds wins. ok is false when neither was
+// sent.
+func requestDuration(text string, seconds *int64) (d time.Duration, ok bool, err error) {
+	if seconds != nil {
+		if *seconds < 0 {
+			return 0, false, model.ErrInvalidDuration
+		}
+		return model.FromSeconds(*seconds), true, nil
+	}
+	if text == "" {
+		return 0, false, nil
+	}
+	d, err = model.ParseDuration(text)
+	if err != nil {
+		return 0, false, err
+	}
+	return d, true, nil
+}
+
+// requiredDuration is requestDuration for requests that must carry a duration.
+func requiredDuration(text string, seconds *int64) (time.Duration, error) {
+	d, ok, err := requestDuration(text, seconds)
+	if err == nil && !ok {
+		err = errDurationRequired
+	}
+	return d, err
+}
+
+// formatDuration renders a stored duration for responses; no time recorded yet is "".
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return model.FormatDuration(d)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/internal/apperr"
+	"gopi.com/internal/lib/storage"
+)
+
+type FileHandler struct {
+	downloader storage.Downloader
+}
+
+func NewFileHandler(downloader storage.Downloader) *FileHandler {
+	return &FileHandler{downloader: downloader}
+}
+
+// DownloadFile godoc
+// @Summary Download a private file
+// @Description Stream a private file using a signed, expiring URL. Supports HTTP range requests.
+// @Tags files
+// @Produce octet-stream
+// @Param key path string true "Object key"
+// @Param expires query int true "Expiry as unix seconds"
+// @Param signature query string true "HMAC signature"
+// @Success 200 {file} file "File content"
+// @Success 206 {file} file "Partial file content"
+// @Failure 400 {object} dto.ErrorResponse "Missing signature parameters"
+// @Failure 403 {object} dto.ErrorResponse "Invalid or expired signature"
+// @Failure 404 {object} dto.ErrorResponse "File not found"
+// @Router /files/{key} [get]
+func (h *FileHandler) DownloadFile(c *gin.Context) {
+	key := strings.TrimLeft(c.Param("key"), "/")
+	signature := c.Query("signature")
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if key == "" || signature == "" || err != nil {
+		respondError(c, apperr.E("DownloadFile", apperr.InvalidInput, err, "Missing or invalid signature parameters"))
+		return
+	}
+
+	if err := h.downloader.Verify(key, expires, signature); err != nil {
+		msg := "Invalid signature"
+		if errors.Is(err, storage.ErrURLExpired) {
+			msg = "Link has expired"
+		}
+		respondError(c, apperr.E("DownloadFile", apperr.Forbidden, err, msg))
+		return
+	}
+
+	f, modTime, err := h.downloader.Open(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			respondError(c, apperr.E("DownloadFile", apperr.NotFound, err, "File not found"))
+			return
+		}
+		respondError(c, apperr.E("DownloadFile", apperr.Internal, err, "Failed to open file"))
+		return
+	}
+	defer f.Close()
+
+	name := path.Base(key)
+	if ct := mime.TypeByExtension(path.Ext(name)); ct != "" {
+		c.Header("Content-Type", ct)
+	}
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	c.Header("Cache-Control", "private, no-store")
+
+	// ServeContent handles Range / If-Range / If-Modified-Since
+	http.ServeContent(c.Writer, c.Request, name, modTime, f)
+}
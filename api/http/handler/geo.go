@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+
+	"gopi.com/internal/domain/model"
+)
+
+// errPartialCoordinates is returned when a request sends only one of latitude and longitude.
+var errPartialCoordinates = errors.New("latitude and longitude must be sent together")
+
+// requestCoordinates reads an optional position sent as separate latitude and longitude
+// fields. It returns nil when neither was sent.
+func requestCoordinates(lat, lon *float64) (*model.GeoPoint, error) {
+	if lat == nil && lon == nil {
+		return nil, nil
+	}
+	if lat == nil || lon == nil {
+		return nil, errPartialCoordinates
+	}
+	p, err := model.NewGeoPoint(*lat, *lon)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// requestNear reads a near=lat,lon query with its radius. It returns nil when near is empty.
+func requestNear(near string, radiusKm float64) (*model.Near, error) {
+	if near == "" {
+		return nil, nil
+	}
+	center, err := model.ParseGeoPoint(near)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Near{Center: center, RadiusKm: radiusKm}, nil
+}
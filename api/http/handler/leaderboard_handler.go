@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/app/user"
+	"gopi.com/internal/apperr"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/leaderboard"
+)
+
+// leaderboardErrors are the leaderboard errors a client can act on, with their API codes.
+var leaderboardErrors = []struct {
+	err  error
+	code apperr.Code
+}{
+	{leaderboard.ErrInvalidWindow, apperr.InvalidInput},
+	{leaderboard.ErrWindowUnavailable, apperr.Unavailable},
+}
+
+// respondLeaderboardError writes err with its mapped code and message, falling back to msg
+// for unexpected errors.
+func respondLeaderboardError(c *gin.Context, op string, err error, msg string) {
+	for _, known := range leaderboardErrors {
+		if errors.Is(err, known.err) {
+			respondError(c, apperr.E(op, known.code, err, err.Error()))
+			return
+		}
+	}
+	respondError(c, apperr.E(op, apperr.Internal, err, msg))
+}
+
+// leaderboardQuery reads the window, activity, neighbours and page of a leaderboard request.
+// Signed-in callers are looked up on the board.
+func leaderboardQuery(c *gin.Context) (leaderboard.Query, error) {
+	var query dto.LeaderboardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return leaderboard.Query{}, err
+	}
+	window, err := leaderboard.ParseWindow(query.Window)
+	if err != nil {
+		return leaderboard.Query{}, err
+	}
+
+	limit, offset := parsePagination(c, 50)
+	q := leaderboard.Query{
+		Window:     window,
+		Activity:   query.Activity,
+		Limit:      limit,
+		Offset:     offset,
+		Neighbours: query.Neighbours,
+	}
+	if userID, exists := c.Get("user_id"); exists {
+		q.UserID, _ = userID.(string)
+	}
+	return q, nil
+}
+
+// leaderboardEntries converts the page, the caller's entry and their neighbours, adding each
+// user's name. Entries whose user cannot be loaded are kept without one.
+func leaderboardEntries(users *user.UserService, standings *leaderboard.Standings) ([]dto.LeaderboardEntry, *dto.LeaderboardEntry, []dto.LeaderboardEntry) {
+	convert := func(entries []*leaderboard.Entry) []dto.LeaderboardEntry {
+		out := make([]dto.LeaderboardEntry, 0, len(entries))
+		for _, entry := range entries {
+			out = append(out, leaderboardEntry(users, entry))
+		}
+		return out
+	}
+
+	var me *dto.LeaderboardEntry
+	var neighbours []dto.LeaderboardEntry
+	if standings.Me != nil {
+		entry := leaderboardEntry(users, standings.Me)
+		me = &entry
+		neighbours = convert(standings.Neighbours)
+	}
+	return convert(standings.Entries), me, neighbours
+}
+
+func leaderboardEntry(users *user.UserService, entry *leaderboard.Entry) dto.LeaderboardEntry {
+	out := dto.LeaderboardEntry{
+		Rank:            entry.Rank,
+		UserID:          entry.UserID,
+		DistanceCovered: entry.Distance,
+		MoneyRaised:     entry.MoneyRaised,
+		Duration:        formatDuration(entry.Duration),
+		DurationSeconds: model.Seconds(entry.Duration),
+		Activity:        entry.Activity,
+		CoverImage:      entry.CoverImage,
+		Runs:            entry.Runs,
+	}
+	if u, err := users.GetUserByID(entry.UserID); err == nil && u != nil {
+		out.Username = u.Username
+		out.FullName = u.GetFullName()
+	}
+	return out
+}
+
+// GetChallengeLeaderboard godoc
+// @Summary Get challenge leaderboard
+// @Description Rank users by the distance they covered across every cause of a challenge, then by least time. Anti-cheat held and rejected runs are left out. Signed-in callers also get their own place and the users around them.
+// @Tags leaderboard
+// @Produce json
+// @Param challenge_id path string true "Challenge ID"
+// @Param window query string false "daily, weekly, monthly or all_time (default); windows start at midnight UTC and weeks on Monday"
+// @Param activity query string false "Only count this activity"
+// @Param neighbours query int false "Users either side of the caller (0-10, default 2)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Entries per page (default 50, max 100)"
+// @Success 200 {object} dto.ChallengeLeaderboardResponse "Leaderboard"
+// @Failure 400 {object} dto.ErrorResponse "Invalid window or query"
+// @Failure 404 {object} dto.ErrorResponse "Challenge not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /challenges/{challenge_id}/leaderboard [get]
+func (h *ChallengeHandler) GetChallengeLeaderboard(c *gin.Context) {
+	challenge, err := h.challengeService.GetChallengeByID(c.Param("challenge_id"))
+	if err != nil {
+		respondError(c, apperr.E("GetChallengeLeaderboard", apperr.NotFound, err, "Challenge not found"))
+		return
+	}
+
+	q, err := leaderboardQuery(c)
+	if err != nil {
+		respondError(c, apperr.E("GetChallengeLeaderboard", apperr.InvalidInput, err, err.Error()))
+		return
+	}
+
+	standings, err := h.challengeService.ChallengeLeaderboard(challenge.ID, q)
+	if err != nil {
+		respondLeaderboardError(c, "GetChallengeLeaderboard", err, "Failed to get leaderboard")
+		return
+	}
+
+	response := dto.ChallengeLeaderboardResponse{
+		ChallengeID:   challenge.ID,
+		ChallengeSlug: challenge.Slug,
+		Window:        string(standings.Board.Window),
+		Activity:      standings.Board.Activity,
+		Total:         standings.Total,
+	}
+	response.Leaderboard, response.Me, response.Neighbours = leaderboardEntries(h.userService, standings)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCauseLeaderboard godoc
+// @Summary Get cause leaderboard
+// @Description Rank users by the distance they covered in a cause, then by least time. Anti-cheat held and rejected runs are left out. Signed-in callers also get their own place and the users around them.
+// @Tags leaderboard
+// @Produce json
+// @Param id path string true "Cause ID"
+// @Param window query string false "daily, weekly, monthly or all_time (default); windows start at midnight UTC and weeks on Monday"
+// @Param activity query string false "Only count this activity"
+// @Param neighbours query int false "Users either side of the caller (0-10, default 2)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Entries per page (default 50, max 100)"
+// @Success 200 {object} dto.CauseLeaderboardResponse "Leaderboard"
+// @Failure 400 {object} dto.ErrorResponse "Invalid window or query"
+// @Failure 404 {object} dto.ErrorResponse "Cause not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/{id}/leaderboard [get]
+func (h *ChallengeHandler) GetCauseLeaderboard(c *gin.Context) {
+	cause, err := h.challengeService.GetCauseByID(c.Param("id"))
+	if err != nil {
+		respondError(c, apperr.E("GetCauseLeaderboard", apperr.NotFound, err, "Cause not found"))
+		return
+	}
+
+	q, err := leaderboardQuery(c)
+	if err != nil {
+		respondError(c, apperr.E("GetCauseLeaderboard", apperr.InvalidInput, err, err.Error()))
+		return
+	}
+
+	standings, err := h.challengeService.CauseLeaderboard(cause.ID, q)
+	if err != nil {
+		respondLeaderboardError(c, "GetCauseLeaderboard", err, "Failed to get leaderboard")
+		return
+	}
+
+	response := dto.CauseLeaderboardResponse{
+		CauseID:   cause.ID,
+		CauseName: cause.Name,
+		Window:    string(standings.Board.Window),
+		Activity:  standings.Board.Activity,
+		Total:     standings.Total,
+	}
+	response.Leaderboard, response.Me, response.Neighbours = leaderboardEntries(h.userService, standings)
+	c.JSON(http.StatusOK, response)
+}
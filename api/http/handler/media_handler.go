@@ -0,0 +1,341 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/app/media"
+	"gopi.com/internal/apperr"
+	mediaModel "gopi.com/internal/domain/media/model"
+	"gopi.com/internal/lib/oembed"
+)
+
+// maxGalleryImageSize is the largest gallery image upload, in bytes.
+const maxGalleryImageSize = 10 * 1024 * 1024
+
+// MediaHandler serves the image and video galleries of challenges, causes and campaigns.
+type MediaHandler struct {
+	service *media.MediaService
+}
+
+func NewMediaHandler(service *media.MediaService) *MediaHandler {
+	return &MediaHandler{service: service}
+}
+
+// galleryKinds maps the :kind path segment to the subject it names.
+var galleryKinds = map[string]mediaModel.SubjectKind{
+	"challenges": mediaModel.SubjectChallenge,
+	"causes":     mediaModel.SubjectCause,
+	"campaigns":  mediaModel.SubjectCampaign,
+}
+
+// galleryImageTypes are the accepted image types and the extensions they are stored under.
+var galleryImageTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// mediaErrors are the gallery errors a client can act on, with their API codes.
+var mediaErrors = []struct {
+	err  error
+	code apperr.Code
+}{
+	{mediaModel.ErrItemNotFound, apperr.NotFound},
+	{mediaModel.ErrSubjectNotFound, apperr.NotFound},
+	{mediaModel.ErrNotSubjectOwner, apperr.Forbidden},
+	{mediaModel.ErrCaptionTooLong, apperr.InvalidInput},
+	{mediaModel.ErrInvalidOrder, apperr.InvalidInput},
+	{oembed.ErrUnsupportedURL, apperr.InvalidInput},
+	{mediaModel.ErrGalleryFull, apperr.Conflict},
+	{mediaModel.ErrKindNotEnabled, apperr.Unavailable},
+}
+
+// respondMediaError writes err with its mapped code and message, falling back to msg for
+// unexpected errors.
+func respondMediaError(c *gin.Context, op string, err error, msg string) {
+	for _, known := range mediaErrors {
+		if errors.Is(err, known.err) {
+			respondError(c, apperr.E(op, known.code, err, known.err.Error()))
+			return
+		}
+	}
+	respondError(c, apperr.E(op, apperr.Internal, err, msg))
+}
+
+// galleryKind reads the :kind path segment, writing a 404 and reporting false when it names
+// nothing that has a gallery.
+func galleryKind(c *gin.Context, op string) (mediaModel.SubjectKind, bool) {
+	kind, ok := galleryKinds[c.Param("kind")]
+	if !ok {
+		respondError(c, apperr.E(op, apperr.NotFound, nil, "Galleries exist for challenges, causes and campaigns only"))
+	}
+	return kind, ok
+}
+
+// GetGallery godoc
+// @Summary Get a gallery
+// @Description List the images and videos of a challenge, cause or campaign in gallery order. A private campaign's gallery is only shown to its owner, its members and staff.
+// @Tags media
+// @Produce json
+// @Param kind path string true "Subject kind" Enums(challenges, causes, campaigns)
+// @Param subject_id path string true "Challenge, cause or campaign ID"
+// @Success 200 {object} dto.MediaGalleryResponse "Gallery"
+// @Failure 404 {object} dto.ErrorResponse "Subject not found or private"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /media/{kind}/{subject_id} [get]
+func (h *MediaHandler) GetGallery(c *gin.Context) {
+	kind, ok := galleryKind(c, "GetGallery")
+	if !ok {
+		return
+	}
+
+	items, err := h.service.ListGallery(kind, c.Param("subject_id"), c.GetString("user_id"), c.GetBool("is_staff"))
+	if err != nil {
+		respondMediaError(c, "GetGallery", err, "Failed to get gallery")
+		return
+	}
+
+	c.JSON(http.StatusOK, galleryToResponse(kind, c.Param("subject_id"), items))
+}
+
+// UploadGalleryImage godoc
+// @Summary Add an image to a gallery
+// @Description Upload a PNG, JPEG, WebP or GIF image of at most 10MB to the end of a challenge, cause or campaign gallery. Only the owner may add to a gallery, which holds at most 50 items.
+// @Tags media
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param kind path string true "Subject kind" Enums(challenges, causes, campaigns)
+// @Param subject_id path string true "Challenge, cause or campaign ID"
+// @Param image formData file true "Image file"
+// @Param caption formData string false "Caption of at most 500 characters"
+// @Success 201 {object} dto.MediaItemResponse "Added image"
+// @Failure 400 {object} dto.ErrorResponse "Missing, oversized or unsupported image, or caption too long"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the owner"
+// @Failure 404 {object} dto.ErrorResponse "Subject not found"
+// @Failure 409 {object} dto.ErrorResponse "Gallery full"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /media/{kind}/{subject_id}/images [post]
+func (h *MediaHandler) UploadGalleryImage(c *gin.Context) {
+	kind, ok := galleryKind(c, "UploadGalleryImage")
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		respondError(c, apperr.E("UploadGalleryImage", apperr.InvalidInput, err, "image file is required"))
+		return
+	}
+	if fileHeader.Size <= 0 || fileHeader.Size > maxGalleryImageSize {
+		respondError(c, apperr.E("UploadGalleryImage", apperr.InvalidInput, nil, "file too large (max 10MB)"))
+		return
+	}
+
+	src, err := fileHeader.Open()
+	if err != nil {
+		respondError(c, apperr.E("UploadGalleryImage", apperr.InvalidInput, err, "cannot open uploaded file"))
+		return
+	}
+	defer src.Close()
+
+	// Sniff the type from the content rather than trusting the client
+	buf := make([]byte, 512)
+	n, _ := io.ReadFull(src, buf)
+	contentType := http.DetectContentType(buf[:n])
+	ext, ok := galleryImageTypes[contentType]
+	if !ok {
+		respondError(c, apperr.E("UploadGalleryImage", apperr.InvalidInput, nil, "unsupported image type"))
+		return
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		respondError(c, apperr.E("UploadGalleryImage", apperr.Internal, err, "failed to read file"))
+		return
+	}
+
+	item, err := h.service.AddImage(c.Request.Context(), kind, c.Param("subject_id"), c.GetString("user_id"),
+		strings.TrimSpace(c.PostForm("caption")), src, fileHeader.Size, contentType, ext)
+	if err != nil {
+		respondMediaError(c, "UploadGalleryImage", err, "Failed to add image")
+		return
+	}
+
+	c.JSON(http.StatusCreated, mediaItemToResponse(item))
+}
+
+// AddGalleryVideo godoc
+// @Summary Add a video to a gallery
+// @Description Add a YouTube, Vimeo or Dailymotion link to the end of a challenge, cause or campaign gallery. Its player, thumbnail and size are worked out from the link. Only the owner may add to a gallery, which holds at most 50 items.
+// @Tags media
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param kind path string true "Subject kind" Enums(challenges, causes, campaigns)
+// @Param subject_id path string true "Challenge, cause or campaign ID"
+// @Param request body dto.AddGalleryVideoRequest true "Video link and caption"
+// @Success 201 {object} dto.MediaItemResponse "Added video"
+// @Failure 400 {object} dto.ErrorResponse "Unsupported link or caption too long"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the owner"
+// @Failure 404 {object} dto.ErrorResponse "Subject not found"
+// @Failure 409 {object} dto.ErrorResponse "Gallery full"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /media/{kind}/{subject_id}/videos [post]
+func (h *MediaHandler) AddGalleryVideo(c *gin.Context) {
+	kind, ok := galleryKind(c, "AddGalleryVideo")
+	if !ok {
+		return
+	}
+
+	var req dto.AddGalleryVideoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("AddGalleryVideo", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	item, err := h.service.AddVideo(kind, c.Param("subject_id"), c.GetString("user_id"), req.URL, strings.TrimSpace(req.Caption))
+	if err != nil {
+		respondMediaError(c, "AddGalleryVideo", err, "Failed to add video")
+		return
+	}
+
+	c.JSON(http.StatusCreated, mediaItemToResponse(item))
+}
+
+// ReorderGallery godoc
+// @Summary Reorder a gallery
+// @Description Put a gallery in a new order by listing every item ID, first first. Only the owner may reorder a gallery.
+// @Tags media
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param kind path string true "Subject kind" Enums(challenges, causes, campaigns)
+// @Param subject_id path string true "Challenge, cause or campaign ID"
+// @Param request body dto.ReorderGalleryRequest true "Item IDs in the new order"
+// @Success 200 {object} dto.MediaGalleryResponse "Reordered gallery"
+// @Failure 400 {object} dto.ErrorResponse "Order does not list every item exactly once"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the owner"
+// @Failure 404 {object} dto.ErrorResponse "Subject not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /media/{kind}/{subject_id}/order [put]
+func (h *MediaHandler) ReorderGallery(c *gin.Context) {
+	kind, ok := galleryKind(c, "ReorderGallery")
+	if !ok {
+		return
+	}
+
+	var req dto.ReorderGalleryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("ReorderGallery", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	items, err := h.service.ReorderGallery(kind, c.Param("subject_id"), c.GetString("user_id"), req.ItemIDs)
+	if err != nil {
+		respondMediaError(c, "ReorderGallery", err, "Failed to reorder gallery")
+		return
+	}
+
+	c.JSON(http.StatusOK, galleryToResponse(kind, c.Param("subject_id"), items))
+}
+
+// UpdateGalleryCaption godoc
+// @Summary Caption a gallery item
+// @Description Replace the caption of an image or video. Only the owner of its gallery may change it.
+// @Tags media
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param item_id path string true "Gallery item ID"
+// @Param request body dto.UpdateGalleryCaptionRequest true "New caption, empty to clear it"
+// @Success 200 {object} dto.MediaItemResponse "Updated item"
+// @Failure 400 {object} dto.ErrorResponse "Caption too long"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the owner"
+// @Failure 404 {object} dto.ErrorResponse "Item not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /media/items/{item_id} [put]
+func (h *MediaHandler) UpdateGalleryCaption(c *gin.Context) {
+	var req dto.UpdateGalleryCaptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperr.E("UpdateGalleryCaption", apperr.InvalidInput, err, "Invalid request body"))
+		return
+	}
+
+	item, err := h.service.UpdateCaption(c.Param("item_id"), c.GetString("user_id"), strings.TrimSpace(req.Caption))
+	if err != nil {
+		respondMediaError(c, "UpdateGalleryCaption", err, "Failed to update caption")
+		return
+	}
+
+	c.JSON(http.StatusOK, mediaItemToResponse(item))
+}
+
+// DeleteGalleryItem godoc
+// @Summary Remove a gallery item
+// @Description Remove an image or video from its gallery; later items move up one place. Only the owner of the gallery may remove items.
+// @Tags media
+// @Security BearerAuth
+// @Param item_id path string true "Gallery item ID"
+// @Success 204 "Removed"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not the owner"
+// @Failure 404 {object} dto.ErrorResponse "Item not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /media/items/{item_id} [delete]
+func (h *MediaHandler) DeleteGalleryItem(c *gin.Context) {
+	if err := h.service.DeleteItem(c.Request.Context(), c.Param("item_id"), c.GetString("user_id")); err != nil {
+		respondMediaError(c, "DeleteGalleryItem", err, "Failed to remove item")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func galleryToResponse(kind mediaModel.SubjectKind, subjectID string, items []*mediaModel.Item) dto.MediaGalleryResponse {
+	response := dto.MediaGalleryResponse{
+		SubjectKind: string(kind),
+		SubjectID:   subjectID,
+		Items:       make([]dto.MediaItemResponse, 0, len(items)),
+		Count:       len(items),
+	}
+	for _, item := range items {
+		response.Items = append(response.Items, mediaItemToResponse(item))
+	}
+	return response
+}
+
+func mediaItemToResponse(item *mediaModel.Item) dto.MediaItemResponse {
+	response := dto.MediaItemResponse{
+		ID:          item.ID,
+		Type:        string(item.Type),
+		Position:    item.Position,
+		Caption:     item.Caption,
+		URL:         item.URL,
+		ContentType: item.ContentType,
+		Size:        item.Size,
+		UploaderID:  item.UploaderID,
+		CreatedAt:   item.CreatedAt,
+	}
+	if item.Type == mediaModel.TypeVideo {
+		response.Video = &dto.MediaVideoResponse{
+			Provider:     item.Provider,
+			ProviderURL:  item.ProviderURL,
+			VideoID:      item.VideoID,
+			EmbedURL:     item.EmbedURL,
+			ThumbnailURL: item.ThumbnailURL,
+			Width:        item.Width,
+			Height:       item.Height,
+			HTML:         item.EmbedHTML,
+		}
+	}
+	return response
+}
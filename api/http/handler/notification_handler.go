@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/app/notification"
+	"gopi.com/internal/apperr"
+	notificationModel "gopi.com/internal/domain/notification/model"
+)
+
+// NotificationHandler serves the signed-in user's in-app notifications.
+type NotificationHandler struct {
+	service *notification.NotificationService
+}
+
+func NewNotificationHandler(service *notification.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+// ListNotifications godoc
+// @Summary List my notifications
+// @Description List the caller's notifications, newest first, with the number still unread
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(20)
+// @Success 200 {object} dto.NotificationListResponse "Notifications"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("ListNotifications", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	limit, offset := parsePagination(c, 20)
+	notifications, unread, err := h.service.ListNotifications(userID.(string), c.Query("unread") == "true", limit, offset)
+	if err != nil {
+		respondError(c, apperr.E("ListNotifications", apperr.Internal, err, "Failed to list notifications"))
+		return
+	}
+
+	response := dto.NotificationListResponse{
+		Notifications: make([]dto.NotificationResponse, 0, len(notifications)),
+		Count:         len(notifications),
+		Unread:        unread,
+	}
+	for _, n := range notifications {
+		response.Notifications = append(response.Notifications, dto.NotificationResponse{
+			ID:          n.ID,
+			Kind:        string(n.Kind),
+			Title:       n.Title,
+			Body:        n.Body,
+			Link:        n.Link,
+			Read:        n.ReadAt != nil,
+			ReadAt:      n.ReadAt,
+			DateCreated: n.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification as read
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Success 200 {object} dto.MessageResponse "Notification marked as read"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Notification not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("MarkNotificationRead", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	if err := h.service.MarkRead(userID.(string), c.Param("id")); err != nil {
+		if errors.Is(err, notificationModel.ErrNotificationNotFound) {
+			respondError(c, apperr.E("MarkNotificationRead", apperr.NotFound, err, "Notification not found"))
+			return
+		}
+		respondError(c, apperr.E("MarkNotificationRead", apperr.Internal, err, "Failed to mark notification as read"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Notification marked as read",
+		Success: true,
+	})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications as read
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.MarkAllReadResponse "Number of notifications marked"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /notifications/read_all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E("MarkAllNotificationsRead", apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	marked, err := h.service.MarkAllRead(userID.(string))
+	if err != nil {
+		respondError(c, apperr.E("MarkAllNotificationsRead", apperr.Internal, err, "Failed to mark notifications as read"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MarkAllReadResponse{Marked: marked})
+}
@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/dto"
+	"gopi.com/internal/app/campaign"
+	"gopi.com/internal/app/challenge"
+	"gopi.com/internal/apperr"
+	activityModel "gopi.com/internal/domain/activity/model"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	trackModel "gopi.com/internal/domain/track/model"
+	"gopi.com/internal/lib/gpstrack"
+	"gopi.com/internal/lib/storage"
+)
+
+const (
+	maxTrackFileSize = 20 * 1024 * 1024 // 20MB
+	// trackSimplifyTolerance is how far (m) a dropped point may lie from the stored route.
+	trackSimplifyTolerance = 5.0
+)
+
+// TrackHandler accepts GPX/TCX uploads for campaign and cause runners and credits the
+// distance computed from the file rather than client-reported figures.
+type TrackHandler struct {
+	campaignService  *campaign.CampaignService
+	challengeService *challenge.ChallengeService
+	storage          storage.Storage
+}
+
+func NewTrackHandler(campaignService *campaign.CampaignService, challengeService *challenge.ChallengeService, st storage.Storage) *TrackHandler {
+	return &TrackHandler{
+		campaignService:  campaignService,
+		challengeService: challengeService,
+		storage:          st,
+	}
+}
+
+// UploadCampaignRunTrack godoc
+// @Summary Upload a GPS track for a campaign run
+// @Description Parse a GPX or TCX file, compute distance, moving time, elevation gain and splits, and add the run to the runner and campaign totals
+// @Tags campaigns
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param slug path string true "Campaign slug"
+// @Param runner_id path string true "Campaign runner ID"
+// @Param file formData file true "GPX or TCX file (max 20MB)"
+// @Success 200 {object} dto.TrackUploadResponse "Track recorded"
+// @Success 202 {object} dto.TrackUploadResponse "Track stored but the run is held for anti-cheat review"
+// @Failure 400 {object} dto.ErrorResponse "Missing or invalid track file"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Access denied to this runner"
+// @Failure 404 {object} dto.ErrorResponse "Campaign or runner not found"
+// @Failure 409 {object} dto.ErrorResponse "Campaign is not accepting activity"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /campaigns/{slug}/finish_campaign/{runner_id}/track [post]
+func (h *TrackHandler) UploadCampaignRunTrack(c *gin.Context) {
+	const op = "UploadCampaignRunTrack"
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E(op, apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	campaign, err := h.campaignService.GetCampaignBySlug(c.Param("slug"))
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Campaign not found"))
+		return
+	}
+	runnerID := c.Param("runner_id")
+	runner, err := h.campaignService.GetRunnerByID(runnerID)
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Campaign runner not found"))
+		return
+	}
+	if runner.CampaignID != campaign.ID || runner.OwnerID != userID.(string) {
+		respondError(c, apperr.E(op, apperr.Forbidden, nil, "Access denied to this runner"))
+		return
+	}
+
+	upload, ok := h.readTrack(c, op)
+	if !ok {
+		return
+	}
+	key := fmt.Sprintf("tracks/campaign-runners/%s-%d.geojson", runnerID, time.Now().UnixNano())
+	if !h.storeRoute(c, op, key, upload) {
+		return
+	}
+
+	err = h.campaignService.RecordRunTrack(runnerID, upload.track, upload.summary, upload.trackURL)
+	if h.respondHeld(c, upload, runnerID, err) {
+		return
+	}
+	if err != nil {
+		_ = h.storage.Delete(c.Request.Context(), key)
+		if errors.Is(err, campaignModel.ErrInvalidCampaignState) {
+			respondError(c, apperr.E(op, apperr.Conflict, err, err.Error()))
+			return
+		}
+		respondError(c, apperr.E(op, apperr.Internal, err, "Failed to record track"))
+		return
+	}
+
+	c.JSON(http.StatusOK, upload.response(runnerID))
+}
+
+// UploadCauseRunTrack godoc
+// @Summary Upload a GPS track for a cause activity
+// @Description Parse a GPX or TCX file and replace the activity's reported distance and duration with the computed figures
+// @Tags causes
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param runner_id path string true "Cause runner ID"
+// @Param file formData file true "GPX or TCX file (max 20MB)"
+// @Success 200 {object} dto.TrackUploadResponse "Track recorded"
+// @Success 202 {object} dto.TrackUploadResponse "Track stored but the activity is held for anti-cheat review"
+// @Failure 400 {object} dto.ErrorResponse "Missing or invalid track file"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Access denied to this runner"
+// @Failure 404 {object} dto.ErrorResponse "Cause runner not found"
+// @Failure 409 {object} dto.ErrorResponse "Run kept changing while the track was recorded"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /causes/runners/{runner_id}/track [post]
+func (h *TrackHandler) UploadCauseRunTrack(c *gin.Context) {
+	const op = "UploadCauseRunTrack"
+	userID, exists := c.Get("user_id")
+	if !exists {
+		respondError(c, apperr.E(op, apperr.Unauthorized, nil, "User not authenticated"))
+		return
+	}
+
+	runnerID := c.Param("runner_id")
+	runner, err := h.challengeService.GetCauseRunnerByID(runnerID)
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.NotFound, err, "Cause runner not found"))
+		return
+	}
+	if runner.OwnerID != userID.(string) {
+		respondError(c, apperr.E(op, apperr.Forbidden, nil, "Access denied to this runner"))
+		return
+	}
+
+	upload, ok := h.readTrack(c, op)
+	if !ok {
+		return
+	}
+	key := fmt.Sprintf("tracks/cause-runners/%s-%d.geojson", runnerID, time.Now().UnixNano())
+	if !h.storeRoute(c, op, key, upload) {
+		return
+	}
+
+	err = h.challengeService.RecordCauseRunTrack(runnerID, upload.track, upload.summary, upload.trackURL)
+	if h.respondHeld(c, upload, runnerID, err) {
+		return
+	}
+	if err != nil {
+		_ = h.storage.Delete(c.Request.Context(), key)
+		if errors.Is(err, challengeModel.ErrRunnerChanged) {
+			respondError(c, apperr.E(op, apperr.Conflict, err, err.Error()))
+			return
+		}
+		respondError(c, apperr.E(op, apperr.Internal, err, "Failed to record track"))
+		return
+	}
+
+	c.JSON(http.StatusOK, upload.response(runnerID))
+}
+
+type trackUpload struct {
+	track    *trackModel.Track
+	format   gpstrack.Format
+	summary  trackModel.Summary
+	route    trackModel.GeoJSONFeature
+	trackURL string
+}
+
+// readTrack parses the "file" form field and computes its summary and simplified route.
+// It writes the error response itself and reports false on failure.
+func (h *TrackHandler) readTrack(c *gin.Context, op string) (*trackUpload, bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.InvalidInput, err, "GPX or TCX file is required"))
+		return nil, false
+	}
+	if fileHeader.Size <= 0 || fileHeader.Size > maxTrackFileSize {
+		respondError(c, apperr.E(op, apperr.InvalidInput, nil, "file too large (max 20MB)"))
+		return nil, false
+	}
+
+	src, err := fileHeader.Open()
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.InvalidInput, err, "cannot open uploaded file"))
+		return nil, false
+	}
+	defer src.Close()
+
+	track, format, err := gpstrack.Parse(io.LimitReader(src, maxTrackFileSize))
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.InvalidInput, err, "Invalid track file: "+err.Error()))
+		return nil, false
+	}
+	summary, err := track.Summarize()
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.InvalidInput, err, "Invalid track file: "+err.Error()))
+		return nil, false
+	}
+	if summary.Distance <= 0 {
+		respondError(c, apperr.E(op, apperr.InvalidInput, nil, "Track covers no distance"))
+		return nil, false
+	}
+
+	route := track.Simplify(trackSimplifyTolerance).GeoJSON(map[string]interface{}{
+		"distance":       summary.Distance,
+		"moving_time":    int64(summary.MovingTime.Seconds()),
+		"elapsed_time":   int64(summary.ElapsedTime.Seconds()),
+		"elevation_gain": summary.ElevationGain,
+		"splits":         summary.Splits,
+	})
+	return &trackUpload{track: track, format: format, summary: summary, route: route}, true
+}
+
+// storeRoute saves the simplified route as GeoJSON and records its URL on the upload.
+func (h *TrackHandler) storeRoute(c *gin.Context, op, key string, upload *trackUpload) bool {
+	data, err := json.Marshal(upload.route)
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.Internal, err, "Failed to encode track"))
+		return false
+	}
+	url, err := h.storage.Save(c.Request.Context(), key, bytes.NewReader(data), int64(len(data)), "application/geo+json")
+	if err != nil {
+		respondError(c, apperr.E(op, apperr.Internal, err, "Failed to store track"))
+		return false
+	}
+	upload.trackURL = url
+	return true
+}
+
+// respondHeld answers 202 when the run was held for anti-cheat review. The stored route is kept
+// so reviewers can inspect it.
+func (h *TrackHandler) respondHeld(c *gin.Context, upload *trackUpload, runnerID string, err error) bool {
+	violations, ok := heldViolations(err)
+	if !ok {
+		return false
+	}
+	resp := upload.response(runnerID)
+	resp.Status = string(activityModel.ReviewStatusFlagged)
+	resp.Violations = violations
+	c.JSON(http.StatusAccepted, resp)
+	return true
+}
+
+func (u *trackUpload) response(runnerID string) dto.TrackUploadResponse {
+	splits := make([]dto.TrackSplit, 0, len(u.summary.Splits))
+	for _, s := range u.summary.Splits {
+		splits = append(splits, dto.TrackSplit{Index: s.Index, Distance: s.Distance, Seconds: s.Seconds, ElevationGain: s.ElevationGain})
+	}
+	return dto.TrackUploadResponse{
+		RunnerID:      runnerID,
+		Status:        string(activityModel.ReviewStatusAccepted),
+		Format:        string(u.format),
+		Distance:      u.summary.Distance,
+		MovingTime:    int64(u.summary.MovingTime.Seconds()),
+		ElapsedTime:   int64(u.summary.ElapsedTime.Seconds()),
+		Duration:      model.FormatDuration(u.summary.MovingTime),
+		ElevationGain: u.summary.ElevationGain,
+		Splits:        splits,
+		TrackURL:      u.trackURL,
+		Route: dto.GeoJSONFeature{
+			Type:       u.route.Type,
+			Geometry:   dto.GeoJSONGeometry{Type: u.route.Geometry.Type, Coordinates: u.route.Geometry.Coordinates},
+			Properties: u.route.Properties,
+		},
+	}
+}
@@ -60,6 +60,25 @@ func RequireAuth(jwtService jwt.JWTServiceInterface) gin.HandlerFunc {
 	})
 }
 
+// OptionalAuth sets the user context like RequireAuth when a valid Bearer token is sent, and
+// otherwise lets the request through anonymously, for public routes that show more to
+// signed-in users.
+func OptionalAuth(jwtService jwt.JWTServiceInterface) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		tokenParts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(tokenParts) == 2 && tokenParts[0] == "Bearer" {
+			if claims, err := jwtService.ValidateToken(tokenParts[1]); err == nil {
+				c.Set("user_id", claims.UserID)
+				c.Set("user_email", claims.Email)
+				c.Set("is_staff", claims.IsStaff)
+				c.Set("is_superuser", claims.IsSuperuser)
+			}
+		}
+
+		c.Next()
+	})
+}
+
 // RequireStaff middleware ensures user has staff privileges
 func RequireStaff() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
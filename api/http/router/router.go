@@ -9,8 +9,11 @@ import (
 	"gopi.com/api/http/handler"
 	"gopi.com/api/http/routes"
 	"gopi.com/internal/app/campaign"
+	"gopi.com/internal/app/certificate"
 	"gopi.com/internal/app/challenge"
 	"gopi.com/internal/app/chat"
+	"gopi.com/internal/app/media"
+	"gopi.com/internal/app/notification"
 	"gopi.com/internal/app/post"
 	"gopi.com/internal/app/user"
 	"gopi.com/internal/lib/email"
@@ -27,6 +30,9 @@ type Dependencies struct {
 	ChallengeService     *challenge.ChallengeService
 	ChatService          *chat.ChatService
 	PostService          *post.Service
+	CertificateService   *certificate.CertificateService
+	MediaService         *media.MediaService
+	NotificationService  *notification.NotificationService
 	RedisClient          *redis.Client
 	Storage              storage.Storage
 	PasswordResetService pwreset.PasswordResetServiceInterface
@@ -66,8 +72,12 @@ func New(deps Dependencies) *gin.Engine {
 		c.File("./docs/swagger.json")
 	})
 
-	// Serve static uploads (profile images, etc.)
+	// Serve static uploads (profile images, etc.). Private prefixes are stored outside this
+	// directory and are only reachable through signed links on /api/files.
 	r.Static("/uploads", "./uploads")
+	if dl, ok := deps.Storage.(storage.Downloader); ok {
+		routes.RegisterFileRoutes(r, dl)
+	}
 
 	// Enhanced user system routes
 	if deps.JWTService != nil && deps.UserService != nil {
@@ -92,10 +102,30 @@ func New(deps Dependencies) *gin.Engine {
 		routes.RegisterChatRoutes(r, deps.ChatService, deps.UserService, deps.JWTService)
 	}
 
+	// GPS track uploads for campaign and cause runners
+	if deps.Storage != nil && deps.JWTService != nil && (deps.CampaignService != nil || deps.ChallengeService != nil) {
+		routes.RegisterTrackRoutes(r, deps.CampaignService, deps.ChallengeService, deps.JWTService, deps.Storage)
+	}
+
 	// Posts and comments routes
 	if deps.PostService != nil && deps.JWTService != nil {
 		routes.RegisterPostRoutes(r, deps.PostService, deps.JWTService, deps.Storage)
 	}
 
+	// Completion certificates and their public verification
+	if deps.CertificateService != nil && deps.JWTService != nil {
+		routes.RegisterCertificateRoutes(r, deps.CertificateService, deps.JWTService)
+	}
+
+	// Challenge, cause and campaign galleries
+	if deps.MediaService != nil && deps.JWTService != nil {
+		routes.RegisterMediaRoutes(r, deps.MediaService, deps.JWTService)
+	}
+
+	// In-app notifications
+	if deps.NotificationService != nil && deps.JWTService != nil {
+		routes.RegisterNotificationRoutes(r, deps.NotificationService, deps.JWTService)
+	}
+
 	return r
 }
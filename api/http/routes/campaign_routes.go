@@ -15,8 +15,11 @@ func RegisterCampaignRoutes(router *gin.Engine, campaignService *campaign.Campai
 
 	// Public campaign routes
 	campaigns := router.Group("/api/campaigns")
+	campaigns.Use(middleware.OptionalAuth(jwtService)) // signed-in viewers also see their private campaigns
 	{
-		campaigns.GET("", campaignHandler.GetCampaigns)            // tested
+		campaigns.GET("", campaignHandler.GetCampaigns) // tested
+		campaigns.GET("/search", campaignHandler.SearchCampaigns)
+		campaigns.GET("/invites/:token", campaignHandler.GetCampaignInvite)
 		campaigns.GET("/:slug", campaignHandler.GetCampaignBySlug) // tested
 	}
 
@@ -27,6 +30,8 @@ func RegisterCampaignRoutes(router *gin.Engine, campaignService *campaign.Campai
 		protectedCampaigns.POST("", campaignHandler.CreateCampaign)         // tested
 		protectedCampaigns.PUT("/:slug", campaignHandler.UpdateCampaign)    // tested
 		protectedCampaigns.DELETE("/:slug", campaignHandler.DeleteCampaign) // tested
+		protectedCampaigns.POST("/:slug/publish", campaignHandler.PublishCampaign)
+		protectedCampaigns.POST("/:slug/cancel", campaignHandler.CancelCampaign)
 
 		// User-specific routes
 		protectedCampaigns.GET("/by_user", campaignHandler.GetCampaignsByUser)     // tested
@@ -39,6 +44,42 @@ func RegisterCampaignRoutes(router *gin.Engine, campaignService *campaign.Campai
 
 		// Campaign info routes
 		protectedCampaigns.GET("/:slug/leaderboard", campaignHandler.GetCampaignLeaderboard) // tested
+		protectedCampaigns.GET("/:slug/leaderboard/teams", campaignHandler.GetCampaignTeamLeaderboard)
+		protectedCampaigns.GET("/:slug/results", campaignHandler.GetCampaignResults)
+		protectedCampaigns.GET("/:slug/export", campaignHandler.ExportCampaign)
+		protectedCampaigns.GET("/:slug/milestones", campaignHandler.GetCampaignMilestones)
+		protectedCampaigns.PUT("/:slug/milestones", campaignHandler.SetCampaignMilestones)
+		protectedCampaigns.GET("/:slug/sponsorships", campaignHandler.ListCampaignSponsorships)
+		protectedCampaigns.POST("/:slug/sponsorships/:sponsorship_id/confirm", campaignHandler.ConfirmCampaignSponsorship)
+
+		// Campaign template routes
+		protectedCampaigns.POST("/:slug/template", campaignHandler.SaveCampaignTemplate)
+		protectedCampaigns.GET("/templates", campaignHandler.ListCampaignTemplates)
+		protectedCampaigns.GET("/templates/:template_id", campaignHandler.GetCampaignTemplate)
+		protectedCampaigns.PUT("/templates/:template_id", campaignHandler.UpdateCampaignTemplate)
+		protectedCampaigns.DELETE("/templates/:template_id", campaignHandler.DeleteCampaignTemplate)
+		protectedCampaigns.POST("/templates/:template_id/campaigns", campaignHandler.CreateCampaignFromTemplate)
+
+		// Campaign team routes
+		protectedCampaigns.GET("/:slug/teams", campaignHandler.ListCampaignTeams)
+		protectedCampaigns.POST("/:slug/teams", campaignHandler.CreateCampaignTeam)
+		protectedCampaigns.POST("/:slug/teams/join", campaignHandler.JoinCampaignTeam)
+		protectedCampaigns.GET("/:slug/teams/:team_id", campaignHandler.GetCampaignTeam)
+		protectedCampaigns.POST("/:slug/teams/:team_id/leave", campaignHandler.LeaveCampaignTeam)
+		protectedCampaigns.DELETE("/:slug/teams/:team_id/members/:user_id", campaignHandler.RemoveCampaignTeamMember)
+		protectedCampaigns.PUT("/:slug/teams/:team_id/captain", campaignHandler.TransferTeamCaptaincy)
+		protectedCampaigns.POST("/:slug/teams/:team_id/invite_code", campaignHandler.RegenerateTeamInviteCode)
+
+		// Campaign invite and join request routes
+		protectedCampaigns.POST("/invites/:token/accept", campaignHandler.AcceptCampaignInvite)
+		protectedCampaigns.GET("/:slug/invites", campaignHandler.ListCampaignInvites)
+		protectedCampaigns.POST("/:slug/invites", campaignHandler.CreateCampaignInvite)
+		protectedCampaigns.POST("/:slug/invites/email", campaignHandler.EmailCampaignInvites)
+		protectedCampaigns.DELETE("/:slug/invites/:invite_id", campaignHandler.RevokeCampaignInvite)
+		protectedCampaigns.GET("/:slug/join_requests", campaignHandler.ListCampaignJoinRequests)
+		protectedCampaigns.POST("/:slug/join_requests", campaignHandler.RequestToJoinCampaign)
+		protectedCampaigns.POST("/:slug/join_requests/:request_id/approve", campaignHandler.ApproveCampaignJoinRequest)
+		protectedCampaigns.POST("/:slug/join_requests/:request_id/reject", campaignHandler.RejectCampaignJoinRequest)
 
 		// Campaign finish routes
 		protectedCampaigns.GET("/:slug/finish_campaign/:runner_id", campaignHandler.GetFinishCampaignDetails) // tested
@@ -63,5 +104,10 @@ func RegisterCampaignRoutes(router *gin.Engine, campaignService *campaign.Campai
 		adminCampaigns.GET("/sponsor-campaigns/:id", campaignAdminHandler.GetSponsorCampaignByID)
 		adminCampaigns.PUT("/sponsor-campaigns/:id", campaignAdminHandler.UpdateSponsorCampaign)
 		adminCampaigns.DELETE("/sponsor-campaigns/:id", campaignAdminHandler.DeleteSponsorCampaign)
+
+		// Anti-cheat review queue
+		adminCampaigns.GET("/flagged-runs", campaignAdminHandler.ListFlaggedRuns)
+		adminCampaigns.POST("/flagged-runs/:id/approve", campaignAdminHandler.ApproveRun)
+		adminCampaigns.POST("/flagged-runs/:id/reject", campaignAdminHandler.RejectRun)
 	}
 }
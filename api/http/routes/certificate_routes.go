@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/handler"
+	"gopi.com/api/http/middleware"
+	"gopi.com/internal/app/certificate"
+	"gopi.com/internal/lib/jwt"
+)
+
+// RegisterCertificateRoutes wires completion certificates and their public verification.
+func RegisterCertificateRoutes(router *gin.Engine, certificateService *certificate.CertificateService, jwtService jwt.JWTServiceInterface) {
+	certificateHandler := handler.NewCertificateHandler(certificateService)
+
+	// Public: the QR code on every certificate leads here
+	public := router.Group("/api/certificates")
+	{
+		public.GET("/:id/verify", certificateHandler.VerifyCertificate)
+	}
+
+	protected := router.Group("/api/certificates")
+	protected.Use(middleware.RequireAuth(jwtService))
+	{
+		protected.GET("", certificateHandler.ListMyCertificates)
+		protected.POST("/campaign-runners/:runner_id", certificateHandler.IssueCampaignCertificate)
+		protected.POST("/cause-runners/:runner_id", certificateHandler.IssueCauseCertificate)
+	}
+}
@@ -23,6 +23,14 @@ func RegisterChallengeRoutes(router *gin.Engine, challengeService *challenge.Cha
 
 		// Challenge-specific cause routes
 		challenges.GET("/:challenge_id/causes", challengeHandler.GetCausesByChallenge)
+		challenges.GET("/:challenge_id/members", challengeHandler.GetChallengeMembers)
+		challenges.GET("/:challenge_id/winners", challengeHandler.GetChallengeWinners)
+		challenges.GET("/:challenge_id/leaderboard", middleware.OptionalAuth(jwtService), challengeHandler.GetChallengeLeaderboard)
+		challenges.GET("/:challenge_id/rounds", challengeHandler.GetJudgingRounds)
+		challenges.GET("/:challenge_id/cause-awards", challengeHandler.GetCauseAwards)
+		challenges.GET("/:challenge_id/bracket", challengeHandler.GetBracket)
+		challenges.GET("/rounds/:round_id", challengeHandler.GetJudgingRound)
+		challenges.GET("/rounds/:round_id/results", challengeHandler.GetJudgingResults)
 		challenges.GET("/id/:id", challengeHandler.GetChallengeByID)
 
 	}
@@ -33,13 +41,38 @@ func RegisterChallengeRoutes(router *gin.Engine, challengeService *challenge.Cha
 	{
 		protectedChallenges.POST("", challengeHandler.CreateChallenge)
 		protectedChallenges.POST("/:id/join", challengeHandler.JoinChallenge)
+		protectedChallenges.POST("/:id/leave", challengeHandler.LeaveChallenge)
+		protectedChallenges.PUT("/:id", challengeHandler.UpdateChallenge)
+		protectedChallenges.DELETE("/:id", challengeHandler.DeleteChallenge)
+		protectedChallenges.POST("/:id/cancel", challengeHandler.CancelChallenge)
+		protectedChallenges.POST("/:id/transfer", challengeHandler.TransferChallenge)
+		protectedChallenges.POST("/:id/bracket", challengeHandler.CreateBracket)
 		protectedChallenges.POST("/sponsor", challengeHandler.SponsorChallenge)
+		protectedChallenges.POST("/rounds/:round_id/votes", challengeHandler.CastCauseVote)
+		protectedChallenges.PUT("/rounds/:round_id/scores", challengeHandler.ScoreCause)
+	}
+
+	// Staff overrides of challenge winners and judging rounds
+	adminChallenges := api.Group("/challenges/admin")
+	adminChallenges.Use(middleware.RequireAuth(jwtService))
+	adminChallenges.Use(middleware.RequireStaff())
+	{
+		adminChallenges.PUT("/:challenge_id/winners", challengeHandler.OverrideChallengeWinners)
+		adminChallenges.GET("/:challenge_id/winner-audits", challengeHandler.ListChallengeWinnerAudits)
+		adminChallenges.POST("/:challenge_id/rounds", challengeHandler.CreateJudgingRound)
+		adminChallenges.POST("/rounds/:round_id/judges", challengeHandler.AppointJudge)
+		adminChallenges.DELETE("/rounds/:round_id/judges/:user_id", challengeHandler.RemoveJudge)
+		adminChallenges.GET("/rounds/:round_id/scores", challengeHandler.ListJudgeScores)
 	}
 
 	// Cause routes
 	causes := api.Group("/causes")
 	{
+		causes.GET("/nearby", challengeHandler.GetCausesNearby)
 		causes.GET("/:id", challengeHandler.GetCauseByID)
+		causes.GET("/:id/members", challengeHandler.GetCauseMembers)
+		causes.GET("/:id/leaderboard", middleware.OptionalAuth(jwtService), challengeHandler.GetCauseLeaderboard)
+		causes.GET("/:id/funding", challengeHandler.GetCauseFunding)
 	}
 
 	// Protected cause routes
@@ -48,8 +81,31 @@ func RegisterChallengeRoutes(router *gin.Engine, challengeService *challenge.Cha
 	{
 		protectedCauses.POST("", challengeHandler.CreateCause)
 		protectedCauses.POST("/:id/join", challengeHandler.JoinCause)
+		protectedCauses.POST("/:id/leave", challengeHandler.LeaveCause)
+		protectedCauses.PUT("/:id", challengeHandler.UpdateCause)
+		protectedCauses.DELETE("/:id", challengeHandler.DeleteCause)
+		protectedCauses.POST("/:id/transfer", challengeHandler.TransferCause)
 		protectedCauses.POST("/activity", challengeHandler.RecordCauseActivity)
 		protectedCauses.POST("/sponsor", challengeHandler.SponsorCause)
 		protectedCauses.POST("/buy", challengeHandler.BuyCause)
+		protectedCauses.GET("/orders", challengeHandler.GetMyCauseOrders)
+		protectedCauses.GET("/orders/:order_id", challengeHandler.GetCauseOrder)
+		protectedCauses.POST("/orders/:order_id/pay", challengeHandler.PayCauseOrder)
+		protectedCauses.POST("/orders/:order_id/fulfil", challengeHandler.FulfilCauseOrder)
+		protectedCauses.POST("/orders/:order_id/refund", challengeHandler.RefundCauseOrder)
+		protectedCauses.GET("/:id/orders", challengeHandler.GetCauseOrders)
+		protectedCauses.PUT("/:id/stock", challengeHandler.SetCauseStock)
+		protectedCauses.GET("/:id/statements", challengeHandler.GetCauseStatements)
+		protectedCauses.GET("/sponsorships/statements", challengeHandler.GetMySponsorStatements)
+	}
+
+	// Anti-cheat review queue for cause activities
+	adminCauses := api.Group("/causes/admin")
+	adminCauses.Use(middleware.RequireAuth(jwtService))
+	adminCauses.Use(middleware.RequireStaff())
+	{
+		adminCauses.GET("/flagged-runners", challengeHandler.ListFlaggedCauseRunners)
+		adminCauses.POST("/flagged-runners/:id/approve", challengeHandler.ApproveCauseRunner)
+		adminCauses.POST("/flagged-runners/:id/reject", challengeHandler.RejectCauseRunner)
 	}
 }
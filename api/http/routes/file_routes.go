@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/handler"
+	"gopi.com/internal/lib/storage"
+)
+
+// RegisterFileRoutes exposes signed downloads for private objects. Access is granted by the
+// URL signature alone, so no auth middleware is applied.
+func RegisterFileRoutes(router *gin.Engine, downloader storage.Downloader) {
+	fileHandler := handler.NewFileHandler(downloader)
+
+	files := router.Group("/api/files")
+	{
+		files.GET("/*key", fileHandler.DownloadFile)
+	}
+}
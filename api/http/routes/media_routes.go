@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/handler"
+	"gopi.com/api/http/middleware"
+	"gopi.com/internal/app/media"
+	"gopi.com/internal/lib/jwt"
+)
+
+// RegisterMediaRoutes wires the image and video galleries of challenges, causes and campaigns.
+func RegisterMediaRoutes(router *gin.Engine, mediaService *media.MediaService, jwtService jwt.JWTServiceInterface) {
+	mediaHandler := handler.NewMediaHandler(mediaService)
+
+	// Public: galleries are shown on the challenge, cause and campaign pages
+	public := router.Group("/api/media")
+	public.Use(middleware.OptionalAuth(jwtService)) // signed-in viewers also see their private campaigns' galleries
+	{
+		public.GET("/:kind/:subject_id", mediaHandler.GetGallery)
+	}
+
+	protected := router.Group("/api/media")
+	protected.Use(middleware.RequireAuth(jwtService))
+	{
+		protected.POST("/:kind/:subject_id/images", mediaHandler.UploadGalleryImage)
+		protected.POST("/:kind/:subject_id/videos", mediaHandler.AddGalleryVideo)
+		protected.PUT("/:kind/:subject_id/order", mediaHandler.ReorderGallery)
+		protected.PUT("/items/:item_id", mediaHandler.UpdateGalleryCaption)
+		protected.DELETE("/items/:item_id", mediaHandler.DeleteGalleryItem)
+	}
+}
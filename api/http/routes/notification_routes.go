@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/handler"
+	"gopi.com/api/http/middleware"
+	"gopi.com/internal/app/notification"
+	"gopi.com/internal/lib/jwt"
+)
+
+// RegisterNotificationRoutes wires the signed-in user's in-app notifications.
+func RegisterNotificationRoutes(router *gin.Engine, notificationService *notification.NotificationService, jwtService jwt.JWTServiceInterface) {
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+
+	notifications := router.Group("/api/notifications")
+	notifications.Use(middleware.RequireAuth(jwtService))
+	{
+		notifications.GET("", notificationHandler.ListNotifications)
+		notifications.POST("/read_all", notificationHandler.MarkAllNotificationsRead)
+		notifications.POST("/:id/read", notificationHandler.MarkNotificationRead)
+	}
+}
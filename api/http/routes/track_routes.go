@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gopi.com/api/http/handler"
+	"gopi.com/api/http/middleware"
+	"gopi.com/internal/app/campaign"
+	"gopi.com/internal/app/challenge"
+	"gopi.com/internal/lib/jwt"
+	"gopi.com/internal/lib/storage"
+)
+
+// RegisterTrackRoutes wires GPX/TCX uploads for campaign and cause runners.
+func RegisterTrackRoutes(router *gin.Engine, campaignService *campaign.CampaignService, challengeService *challenge.ChallengeService, jwtService jwt.JWTServiceInterface, st storage.Storage) {
+	trackHandler := handler.NewTrackHandler(campaignService, challengeService, st)
+
+	api := router.Group("/api")
+	api.Use(middleware.RequireAuth(jwtService))
+	{
+		if campaignService != nil {
+			api.POST("/campaigns/:slug/finish_campaign/:runner_id/track", trackHandler.UploadCampaignRunTrack)
+		}
+		if challengeService != nil {
+			api.POST("/causes/runners/:runner_id/track", trackHandler.UploadCauseRunTrack)
+		}
+	}
+}
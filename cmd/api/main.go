@@ -18,6 +18,7 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
@@ -26,23 +27,35 @@ import (
 	"gopi.com/config"
 	docs "gopi.com/docs"
 	"gopi.com/internal/app/campaign"
+	"gopi.com/internal/app/certificate"
 	"gopi.com/internal/app/challenge"
 	"gopi.com/internal/app/chat"
+	"gopi.com/internal/app/media"
+	"gopi.com/internal/app/notification"
 	postApp "gopi.com/internal/app/post"
 	"gopi.com/internal/app/user"
 	campaignGorm "gopi.com/internal/data/campaign/model/gorm"
 	campaignDataRepo "gopi.com/internal/data/campaign/repo"
+	certificateGorm "gopi.com/internal/data/certificate/model/gorm"
+	certificateDataRepo "gopi.com/internal/data/certificate/repo"
 	challengeGorm "gopi.com/internal/data/challenge/model/gorm"
 	challengeDataRepo "gopi.com/internal/data/challenge/repo"
 	chatGorm "gopi.com/internal/data/chat/model/gorm"
 	chatDataRepo "gopi.com/internal/data/chat/repo"
+	mediaGorm "gopi.com/internal/data/media/model/gorm"
+	mediaDataRepo "gopi.com/internal/data/media/repo"
+	notificationGorm "gopi.com/internal/data/notification/model/gorm"
+	notificationDataRepo "gopi.com/internal/data/notification/repo"
 	postGorm "gopi.com/internal/data/post/model/gorm"
 	postDataRepo "gopi.com/internal/data/post/repo"
 	userGorm "gopi.com/internal/data/user/model/gorm"
 	dataRepo "gopi.com/internal/data/user/repo"
 	"gopi.com/internal/db"
+	activityModel "gopi.com/internal/domain/activity/model"
 	"gopi.com/internal/lib/email"
+	"gopi.com/internal/lib/geo"
 	jwtLib "gopi.com/internal/lib/jwt"
+	"gopi.com/internal/lib/leaderboard"
 	"gopi.com/internal/lib/pwreset"
 	pwresetGorm "gopi.com/internal/lib/pwreset"
 	"gopi.com/internal/lib/storage"
@@ -58,6 +71,11 @@ func main() {
 	lg := logger.New(cfg)
 	slog.SetDefault(lg)
 
+	if err := cfg.Validate(); err != nil {
+		slog.Error("invalid config, aborting", "err", err)
+		return
+	}
+
 	// Configure swagger metadata at runtime
 	docs.SwaggerInfo.BasePath = "/api"
 
@@ -99,11 +117,38 @@ func main() {
 		&challengeGorm.ChallengeSponsor{},
 		&challengeGorm.CauseMember{},
 		&challengeGorm.CauseSponsorMember{},
+		&challengeGorm.ChallengeWinner{},
+		&challengeGorm.ChallengeWinnerAudit{},
+		&challengeGorm.JudgingRound{},
+		&challengeGorm.JudgingJudge{},
+		&challengeGorm.JudgingScore{},
+		&challengeGorm.JudgingVote{},
+		&challengeGorm.JudgingResult{},
+		&challengeGorm.CauseOrder{},
+		&challengeGorm.SponsorStatement{},
+		&challengeGorm.ChallengeBracket{},
+		&challengeGorm.BracketMatch{},
 	}
 	if err := gdb.AutoMigrate(challengeGormModels...); err != nil {
 		slog.Error("challenge migrate error", "err", err)
 		return
 	}
+	if err := db.MigrateLegacyDurations(gdb, &challengeGorm.Cause{}, &challengeGorm.CauseRunner{}); err != nil {
+		slog.Error("challenge duration migrate error", "err", err)
+		return
+	}
+	if err := challengeGorm.MigrateChallengeEnds(gdb); err != nil {
+		slog.Error("challenge end migrate error", "err", err)
+		return
+	}
+	if err := challengeGorm.MigrateChallengePrizes(gdb); err != nil {
+		slog.Error("challenge prize migrate error", "err", err)
+		return
+	}
+	if err := challengeGorm.MigrateFinalRounds(gdb); err != nil {
+		slog.Error("judging final round migrate error", "err", err)
+		return
+	}
 
 	// campaign models
 	campaignGormModels := []interface{}{
@@ -112,11 +157,36 @@ func main() {
 		&campaignGorm.CampaignSponsor{},
 		&campaignGorm.CampaignRunner{},
 		&campaignGorm.SponsorCampaign{},
+		&campaignGorm.CampaignStanding{},
+		&campaignGorm.SponsorObligation{},
+		&campaignGorm.CampaignRun{},
+		&campaignGorm.CampaignTeam{},
+		&campaignGorm.CampaignTeamMember{},
+		&campaignGorm.CampaignInvite{},
+		&campaignGorm.CampaignJoinRequest{},
+		&campaignGorm.CampaignMilestone{},
+		&campaignGorm.CampaignTemplate{},
 	}
 	if err := gdb.AutoMigrate(campaignGormModels...); err != nil {
 		slog.Error("campaign migrate error", "err", err)
 		return
 	}
+	if err := campaignGorm.MigrateCampaignLifecycle(gdb); err != nil {
+		slog.Error("campaign lifecycle migrate error", "err", err)
+		return
+	}
+	if err := campaignGorm.MigrateCampaignSearch(gdb); err != nil {
+		slog.Error("campaign search migrate error", "err", err)
+		return
+	}
+	if err := campaignGorm.MigrateSponsorLists(gdb); err != nil {
+		slog.Error("campaign sponsor list migrate error", "err", err)
+		return
+	}
+	if err := db.MigrateLegacyDurations(gdb, &campaignGorm.CampaignRunner{}, &campaignGorm.CampaignRun{}); err != nil {
+		slog.Error("campaign duration migrate error", "err", err)
+		return
+	}
 
 	// chat models
 	chatGormModels := []interface{}{
@@ -138,6 +208,24 @@ func main() {
 		return
 	}
 
+	// certificate models
+	if err := gdb.AutoMigrate(&certificateGorm.Certificate{}); err != nil {
+		slog.Error("certificate migrate error", "err", err)
+		return
+	}
+
+	// media models
+	if err := gdb.AutoMigrate(&mediaGorm.MediaItem{}); err != nil {
+		slog.Error("media migrate error", "err", err)
+		return
+	}
+
+	// notification models
+	if err := gdb.AutoMigrate(&notificationGorm.Notification{}); err != nil {
+		slog.Error("notification migrate error", "err", err)
+		return
+	}
+
 	// JWT and Password Reset models (only if using database implementations)
 	if cfg.UseDatabaseJWT || cfg.UseDatabasePWReset {
 		serviceModels := []interface{}{}
@@ -156,6 +244,17 @@ func main() {
 		}
 	}
 
+	// Geocoding: a missing gazetteer only disables automatic positions
+	geocoder, err := geo.New(geo.Config{Backend: cfg.GeocoderBackend, GazetteerPath: cfg.GazetteerPath})
+	if err != nil {
+		slog.Warn("geocoder unavailable, locations will not be positioned automatically", "err", err)
+	}
+	if err := db.GeocodeMissingCoordinates(context.Background(), gdb, geocoder,
+		&campaignGorm.Campaign{}, &challengeGorm.Challenge{}, &challengeGorm.Cause{}); err != nil {
+		slog.Error("geocode migrate error", "err", err)
+		return
+	}
+
 	slog.Info("db migrated")
 
 	slog.Info("creating services")
@@ -220,6 +319,9 @@ func main() {
 	campaignRepo := campaignDataRepo.NewGormCampaignRepository(gdb)
 	campaignRunnerRepo := campaignDataRepo.NewGormCampaignRunnerRepository(gdb)
 	campaignSponRepo := campaignDataRepo.NewGormSponsorCampaignRepository(gdb)
+	campaignResultRepo := campaignDataRepo.NewGormCampaignResultRepository(gdb)
+	campaignRunRepo := campaignDataRepo.NewGormCampaignRunRepository(gdb)
+	campaignTeamRepo := campaignDataRepo.NewGormCampaignTeamRepository(gdb)
 	challengeRepo := challengeDataRepo.NewGormChallengeRepository(gdb)
 	causeRepo := challengeDataRepo.NewGormCauseRepository(gdb)
 	causeRunnerRepo := challengeDataRepo.NewGormCauseRunnerRepository(gdb)
@@ -233,30 +335,37 @@ func main() {
 	// Post repositories
 	postRepo := postDataRepo.NewGormPostRepository(gdb)
 	commentRepo := postDataRepo.NewGormCommentRepository(gdb)
+	notificationRepo := notificationDataRepo.NewGormNotificationRepository(gdb)
 	slog.Info("repos created")
 
-	userSvc := user.NewUserService(userRepo, emailService)
-	campaignSvc := campaign.NewCampaignService(campaignRepo, campaignRunnerRepo, campaignSponRepo)
-	challengeSvc := challenge.NewChallengeService(challengeRepo, causeRepo, causeRunnerRepo, sponsorRepo, sponsorCauseRepo, causeBuyerRepo)
-	chatSvc := chat.NewChatService(groupRepo, messageRepo)
-	postSvc := postApp.NewPostService(postRepo, commentRepo)
-	slog.Info("services created")
+	// Leaderboards are kept in Redis sorted sets when Redis is configured, and ranked from the
+	// database otherwise
+	leaderboards := leaderboard.NewLeaderboardsFactory(redisClient, time.Minute)
 
 	// Storage initialization
 	var store storage.Storage
 	switch cfg.StorageBackend {
 	case "s3":
 		slog.Info("initializing s3 storage")
+		// Deployments from before private prefixes have a single bucket; keep them starting
+		// and serving everything publicly until a private bucket is configured.
+		privatePrefixes := cfg.PrivatePrefixes
+		if cfg.S3PrivateBucket == "" && len(privatePrefixes) > 0 {
+			slog.Warn("S3_PRIVATE_BUCKET is not set; private storage prefixes are disabled", "prefixes", privatePrefixes)
+			privatePrefixes = nil
+		}
 		s3Store, err := storage.NewS3Storage(storage.Config{
 			Backend:           "s3",
 			S3Endpoint:        cfg.S3Endpoint,
 			S3Region:          cfg.S3Region,
 			S3Bucket:          cfg.S3Bucket,
+			S3PrivateBucket:   cfg.S3PrivateBucket,
 			S3AccessKeyID:     cfg.S3AccessKeyID,
 			S3SecretAccessKey: cfg.S3SecretAccessKey,
 			S3UseSSL:          cfg.S3UseSSL,
 			S3ForcePathStyle:  cfg.S3ForcePathStyle,
 			S3PublicBaseURL:   cfg.S3PublicBaseURL,
+			PrivatePrefixes:   privatePrefixes,
 		})
 		if err != nil {
 			slog.Error("failed to init s3 storage, aborting", "err", err)
@@ -265,9 +374,67 @@ func main() {
 		store = s3Store
 	default:
 		slog.Info("initializing local storage")
-		store = storage.NewLocalStorage(cfg.UploadBaseDir, cfg.UploadPublicBaseURL)
+		localStore, err := storage.NewLocalStorageFromConfig(storage.Config{
+			Backend:            "local",
+			LocalBaseDir:       cfg.UploadBaseDir,
+			LocalPublicBaseURL: cfg.UploadPublicBaseURL,
+			LocalPrivateDir:    cfg.UploadPrivateDir,
+			LocalSignedBaseURL: cfg.SignedURLBase,
+			PrivatePrefixes:    cfg.PrivatePrefixes,
+			SigningSecret:      cfg.StorageSigningKey,
+		})
+		if err != nil {
+			slog.Error("failed to init local storage, aborting", "err", err)
+			return
+		}
+		store = localStore
 	}
 
+	// Gallery images share the upload storage; deleting a challenge, cause or campaign removes its gallery
+	mediaSvc := media.NewMediaService(mediaDataRepo.NewGormMediaRepository(gdb), store,
+		media.WithChallenges(challengeRepo, causeRepo),
+		media.WithCampaigns(campaignRepo))
+
+	userSvc := user.NewUserService(userRepo, emailService)
+	campaignSvc := campaign.NewCampaignService(campaignRepo, campaignRunnerRepo, campaignSponRepo,
+		campaign.WithUnitOfWork(campaignDataRepo.NewGormUnitOfWork(gdb)),
+		campaign.WithCloseout(campaignResultRepo, userRepo, emailService),
+		campaign.WithAntiCheat(campaignRunRepo, activityModel.NewRules(nil)),
+		campaign.WithTeams(campaignTeamRepo),
+		campaign.WithGeocoder(geocoder),
+		campaign.WithInvites(campaignDataRepo.NewGormCampaignInviteRepository(gdb), campaignDataRepo.NewGormCampaignJoinRequestRepository(gdb),
+			cfg.InviteSigningKey, cfg.PublicHost+"/campaigns/invite"),
+		campaign.WithMilestones(campaignDataRepo.NewGormCampaignMilestoneRepository(gdb), notificationRepo, userRepo, emailService),
+		campaign.WithTemplates(campaignDataRepo.NewGormCampaignTemplateRepository(gdb)),
+		campaign.WithLeaderboards(leaderboards),
+		campaign.WithGalleries(mediaSvc))
+	challengeSvc := challenge.NewChallengeService(challengeRepo, causeRepo, causeRunnerRepo, sponsorRepo, sponsorCauseRepo, causeBuyerRepo,
+		challenge.WithUnitOfWork(challengeDataRepo.NewGormUnitOfWork(gdb)),
+		challenge.WithAntiCheat(activityModel.NewRules(nil)),
+		challenge.WithGeocoder(geocoder),
+		challenge.WithWinners(challengeDataRepo.NewGormChallengeWinnerRepository(gdb), notificationRepo, userRepo, emailService),
+		challenge.WithLeaderboards(leaderboards),
+		challenge.WithJudging(challengeDataRepo.NewGormJudgingRepository(gdb)),
+		challenge.WithCauseOrders(challengeDataRepo.NewGormCauseOrderRepository(gdb), userRepo, emailService),
+		challenge.WithFunding(challengeDataRepo.NewGormSponsorStatementRepository(gdb)),
+		challenge.WithBrackets(challengeDataRepo.NewGormBracketRepository(gdb)),
+		challenge.WithGalleries(mediaSvc))
+	chatSvc := chat.NewChatService(groupRepo, messageRepo)
+	notificationSvc := notification.NewNotificationService(notificationRepo)
+	postSvc := postApp.NewPostService(postRepo, commentRepo)
+	slog.Info("services created")
+
+	// Background jobs
+	campaign.NewScheduler(campaignSvc, time.Minute).Start(context.Background())
+	challenge.NewScheduler(challengeSvc, time.Minute).Start(context.Background())
+
+	// Certificates are rendered to PDF and kept in the same storage as other uploads
+	certificateSvc := certificate.NewCertificateService(certificateDataRepo.NewGormCertificateRepository(gdb), userRepo, store,
+		cfg.PublicHost+"/api/certificates",
+		certificate.WithCampaigns(campaignRepo, campaignRunnerRepo, campaignSponRepo),
+		certificate.WithCauses(causeRepo, causeRunnerRepo, sponsorCauseRepo),
+		certificate.WithLogoLoader(certificate.NewLogoLoader(cfg.UploadPublicBaseURL, cfg.UploadBaseDir, cfg.S3PublicBaseURL)))
+
 	slog.Info("creating handlers")
 	slog.Info("handlers created")
 
@@ -279,6 +446,9 @@ func main() {
 		ChallengeService:     challengeSvc,
 		ChatService:          chatSvc,
 		PostService:          postSvc,
+		CertificateService:   certificateSvc,
+		MediaService:         mediaSvc,
+		NotificationService:  notificationSvc,
 		RedisClient:          redisClient,
 		SessionMW:            nil, // We'll use JWT instead of sessions
 		Storage:              store,
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/lpernett/godotenv"
 )
@@ -32,6 +34,9 @@ type Config struct {
 	JWTSecret      string
 	UseDatabaseJWT bool
 
+	// Campaign invite links
+	InviteSigningKey string
+
 	// Email Configuration
 	EmailHost     string
 	EmailPort     int
@@ -53,22 +58,32 @@ type Config struct {
 	StorageBackend      string // local or s3
 	UploadBaseDir       string // e.g. ./uploads
 	UploadPublicBaseURL string // e.g. /uploads
+	UploadPrivateDir    string // e.g. ./private_uploads, never served statically
+	SignedURLBase       string // e.g. /api/files
+	StorageSigningKey   string
+	PrivatePrefixes     []string
 
 	// S3 Configuration
 	S3Endpoint        string
 	S3Region          string
 	S3Bucket          string
+	S3PrivateBucket   string
 	S3AccessKeyID     string
 	S3SecretAccessKey string
 	S3UseSSL          bool
 	S3ForcePathStyle  bool
 	S3PublicBaseURL   string
+
+	// Geocoding Configuration
+	GeocoderBackend string // gazetteer or none
+	GazetteerPath   string // CSV of name,latitude,longitude used by the gazetteer backend
 }
 
 var Envs = initConfig()
 
 func initConfig() Config {
 	godotenv.Load()
+	runMode := getEnv("GIN_MODE", "debug")
 
 	return Config{
 		PublicHost: getEnv("PUBLIC_HOST", "http://localhost"),
@@ -82,7 +97,7 @@ func initConfig() Config {
 		LogLevel:  getEnv("LOG_LEVEL", "info"),
 		LogFile:   getEnv("LOG_FILE", "logs/app.log"),
 		LogToFile: getEnvBool("LOG_FILE_ENABLED", false),
-		RunMode:   getEnv("GIN_MODE", "debug"),
+		RunMode:   runMode,
 
 		SessionSecret: getEnv("SESSION_SECRET", "dev-secret-change-me"),
 		SessionName:   getEnv("SESSION_NAME", "hor_session"),
@@ -94,6 +109,9 @@ func initConfig() Config {
 		JWTSecret:      getEnv("JWT_SECRET", "dev-jwt-secret-change-me-in-production"),
 		UseDatabaseJWT: getEnvBool("USE_DATABASE_JWT", false),
 
+		// Campaign invite links
+		InviteSigningKey: getEnv("INVITE_SIGNING_KEY", devOnly(runMode, "dev-invite-signing-key-change-me")),
+
 		// Email Configuration
 		EmailHost:     getEnv("EMAIL_HOST", "smtp.gmail.com"),
 		EmailPort:     getEnvInt("EMAIL_PORT", 587),
@@ -115,17 +133,59 @@ func initConfig() Config {
 		StorageBackend:      getEnv("STORAGE_BACKEND", "local"),
 		UploadBaseDir:       getEnv("UPLOAD_BASE_DIR", "./uploads"),
 		UploadPublicBaseURL: getEnv("UPLOAD_PUBLIC_BASE_URL", "/uploads"),
+		UploadPrivateDir:    getEnv("UPLOAD_PRIVATE_DIR", "./private_uploads"),
+		SignedURLBase:       getEnv("SIGNED_URL_BASE", "/api/files"),
+		StorageSigningKey:   getEnv("STORAGE_SIGNING_KEY", devOnly(runMode, "dev-storage-signing-key-change-me")),
+		PrivatePrefixes:     getEnvList("STORAGE_PRIVATE_PREFIXES", []string{"exports/", "contracts/", "chat/"}),
 
 		// S3 Configuration
 		S3Endpoint:        getEnv("S3_ENDPOINT", ""),
 		S3Region:          getEnv("S3_REGION", "us-east-1"),
 		S3Bucket:          getEnv("S3_BUCKET", ""),
+		S3PrivateBucket:   getEnv("S3_PRIVATE_BUCKET", ""),
 		S3AccessKeyID:     getEnv("S3_ACCESS_KEY_ID", ""),
 		S3SecretAccessKey: getEnv("S3_SECRET_ACCESS_KEY", ""),
 		S3UseSSL:          getEnvBool("S3_USE_SSL", true),
 		S3ForcePathStyle:  getEnvBool("S3_FORCE_PATH_STYLE", false),
 		S3PublicBaseURL:   getEnv("S3_PUBLIC_BASE_URL", ""),
+
+		// Geocoding Configuration
+		GeocoderBackend: getEnv("GEOCODER_BACKEND", "gazetteer"),
+		GazetteerPath:   getEnv("GAZETTEER_PATH", "./data/gazetteer.csv"),
+	}
+}
+
+// Dev reports whether the server runs in gin's debug or test mode, the only modes allowed to
+// fall back to built-in development secrets.
+func (c Config) Dev() bool {
+	return isDev(c.RunMode)
+}
+
+// Validate returns an error for settings the server must not start with.
+func (c Config) Validate() error {
+	if c.Dev() {
+		return nil
+	}
+	if c.InviteSigningKey == "" {
+		return errors.New("INVITE_SIGNING_KEY must be set outside dev")
+	}
+	if c.StorageSigningKey == "" {
+		return errors.New("STORAGE_SIGNING_KEY must be set outside dev")
 	}
+	return nil
+}
+
+func isDev(runMode string) bool {
+	return runMode == "debug" || runMode == "test"
+}
+
+// devOnly returns fallback in dev and nothing otherwise, so a built-in secret never reaches
+// a release.
+func devOnly(runMode, fallback string) string {
+	if isDev(runMode) {
+		return fallback
+	}
+	return ""
 }
 
 func getEnv(key, fallback string) string {
@@ -159,3 +219,16 @@ func getEnvInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func getEnvList(key string, fallback []string) []string {
+	if value, ok := os.LookupEnv(key); ok {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return fallback
+}
@@ -15,14 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/campaign-runners": {
-            "get": {
+        "/admin/bulk-email": {
+            "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Get a paginated list of all campaign runners",
+                "description": "Send email to multiple users at once (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,71 +30,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-campaign-runners"
+                    "Admin"
                 ],
-                "summary": "Get all campaign runners (Admin)",
+                "summary": "Send Bulk Email",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by campaign ID",
-                        "name": "campaign_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by user ID",
-                        "name": "user_id",
-                        "in": "query"
+                        "description": "Bulk email details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkEmailRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Campaign runners retrieved successfully",
+                        "description": "Emails sent successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignRunnerListResponse"
+                            "$ref": "#/definitions/dto.AdminActionResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid request format",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/send-apology-emails": {
             "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Create a new campaign runner entry for admin purposes",
+                "description": "Send apology emails to specified users (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -102,62 +93,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-campaign-runners"
+                    "Admin"
                 ],
-                "summary": "Create a new campaign runner (Admin)",
+                "summary": "Send Apology Emails",
                 "parameters": [
                     {
-                        "description": "Campaign runner details",
-                        "name": "runner",
+                        "description": "Apology email details",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateCampaignRunnerRequest"
+                            "$ref": "#/definitions/dto.ApologyEmailRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Campaign runner created successfully",
+                    "200": {
+                        "description": "Apology emails sent successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignRunnerResponse"
+                            "$ref": "#/definitions/dto.AdminActionResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request format",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Campaign or user not found",
+                    "403": {
+                        "description": "Forbidden - admin access required",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/admin/campaign-runners/{id}": {
+        "/admin/stats": {
             "get": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Get a specific campaign runner by its ID",
+                "description": "Get comprehensive user statistics for admin dashboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -165,46 +156,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-campaign-runners"
-                ],
-                "summary": "Get campaign runner by ID (Admin)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Campaign runner ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Admin"
                 ],
+                "summary": "Get User Statistics",
                 "responses": {
                     "200": {
-                        "description": "Campaign runner retrieved successfully",
+                        "description": "User statistics retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignRunnerResponse"
+                            "$ref": "#/definitions/dto.UserStatsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Campaign runner not found",
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/users/search": {
+            "get": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Update a campaign runner by its ID",
+                "description": "Search for users by email, username, first name, or last name",
                 "consumes": [
                     "application/json"
                 ],
@@ -212,67 +202,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-campaign-runners"
+                    "Admin"
                 ],
-                "summary": "Update campaign runner (Admin)",
+                "summary": "Search Users",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Campaign runner ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Search query (email, username, first name, or last name)",
+                        "name": "q",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Campaign runner update details",
-                        "name": "runner",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateCampaignRunnerRequest"
-                        }
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of users per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Campaign runner updated successfully",
+                        "description": "Users found successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignRunnerResponse"
+                            "$ref": "#/definitions/dto.GetUsersResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Search query is required",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Campaign runner not found",
+                    "403": {
+                        "description": "Forbidden - admin access required",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/users/{id}/activate": {
+            "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Delete a campaign runner by its ID",
+                "description": "Activate a user account (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -280,51 +277,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-campaign-runners"
+                    "Admin"
                 ],
-                "summary": "Delete campaign runner (Admin)",
+                "summary": "Activate User",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Campaign runner ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Campaign runner deleted successfully"
+                    "200": {
+                        "description": "User activated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdminActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Campaign runner not found",
+                        "description": "User not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/admin/sponsor-campaigns": {
-            "get": {
+        "/admin/users/{id}/deactivate": {
+            "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Get a paginated list of all sponsor campaigns",
+                "description": "Deactivate a user account (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -332,65 +344,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-sponsor-campaigns"
+                    "Admin"
                 ],
-                "summary": "Get all sponsor campaigns (Admin)",
+                "summary": "Deactivate User",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Filter by campaign ID",
-                        "name": "campaign_id",
-                        "in": "query"
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sponsor campaigns retrieved successfully",
+                        "description": "User deactivated successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.SponsorCampaignListResponse"
+                            "$ref": "#/definitions/dto.AdminActionResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid user ID",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/users/{id}/force-verify": {
             "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Create a new sponsor campaign entry for admin purposes",
+                "description": "Force verify a user account without OTP (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -398,62 +411,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-sponsor-campaigns"
+                    "Admin"
                 ],
-                "summary": "Create a new sponsor campaign (Admin)",
+                "summary": "Force Verify User",
                 "parameters": [
                     {
-                        "description": "Sponsor campaign details",
-                        "name": "sponsor",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateSponsorCampaignRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Sponsor campaign created successfully",
+                    "200": {
+                        "description": "User verified successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.SponsorCampaignResponse"
+                            "$ref": "#/definitions/dto.AdminActionResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid user ID",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Campaign not found",
+                        "description": "User not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/admin/sponsor-campaigns/{id}": {
-            "get": {
+        "/admin/users/{id}/make-staff": {
+            "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Get a specific sponsor campaign by its ID",
+                "description": "Grant staff privileges to a user (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -461,13 +478,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-sponsor-campaigns"
+                    "Admin"
                 ],
-                "summary": "Get sponsor campaign by ID (Admin)",
+                "summary": "Promote User to Staff",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sponsor campaign ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -475,32 +492,52 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sponsor campaign retrieved successfully",
+                        "description": "User promoted to staff successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.SponsorCampaignResponse"
+                            "$ref": "#/definitions/dto.AdminActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Sponsor campaign not found",
+                        "description": "User not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/users/{id}/remove-staff": {
+            "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Update a sponsor campaign by its ID",
+                "description": "Remove staff privileges from a user (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -508,67 +545,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-sponsor-campaigns"
+                    "Admin"
                 ],
-                "summary": "Update sponsor campaign (Admin)",
+                "summary": "Remove Staff Privileges",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sponsor campaign ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Sponsor campaign update details",
-                        "name": "sponsor",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateSponsorCampaignRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sponsor campaign updated successfully",
+                        "description": "Staff privileges removed successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.SponsorCampaignResponse"
+                            "$ref": "#/definitions/dto.AdminActionResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid user ID",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Sponsor campaign not found",
+                        "description": "User not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/change-password": {
+            "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "Delete a sponsor campaign by its ID",
+                "description": "Change user password with current password verification",
                 "consumes": [
                     "application/json"
                 ],
@@ -576,51 +612,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-sponsor-campaigns"
+                    "Authentication"
                 ],
-                "summary": "Delete sponsor campaign (Admin)",
+                "summary": "Change Password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Sponsor campaign ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Password change details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangePasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Sponsor campaign deleted successfully"
+                    "200": {
+                        "description": "Password changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangePasswordResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangePasswordResponse"
+                        }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized or invalid current password",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.ChangePasswordResponse"
                         }
                     },
                     "404": {
-                        "description": "Sponsor campaign not found",
+                        "description": "User not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.ChangePasswordResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.ChangePasswordResponse"
                         }
                     }
                 }
             }
         },
-        "/api/admin/bulk-email": {
-            "post": {
+        "/auth/delete-account": {
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Send email to multiple users at once (admin only)",
+                "description": "Permanently delete the authenticated user's account",
                 "consumes": [
                     "application/json"
                 ],
@@ -628,31 +675,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
-                ],
-                "summary": "Send Bulk Email",
-                "parameters": [
-                    {
-                        "description": "Bulk email details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.BulkEmailRequest"
-                        }
-                    }
+                    "Authentication"
                 ],
+                "summary": "Delete User Account",
                 "responses": {
                     "200": {
-                        "description": "Emails sent successfully",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AdminActionResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request format",
+                        "description": "Account deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.DeleteAccountResponse"
                         }
                     },
                     "401": {
@@ -661,8 +691,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - admin access required",
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
@@ -676,14 +706,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/send-apology-emails": {
+        "/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Send apology emails to specified users (admin only)",
+                "description": "Authenticate user and return JWT tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -691,62 +716,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Authentication"
                 ],
-                "summary": "Send Apology Emails",
+                "summary": "User Login",
                 "parameters": [
                     {
-                        "description": "Apology email details",
+                        "description": "Login credentials",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ApologyEmailRequest"
+                            "$ref": "#/definitions/dto.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Apology emails sent successfully",
+                        "description": "Login successful with JWT tokens",
                         "schema": {
-                            "$ref": "#/definitions/dto.AdminActionResponse"
+                            "$ref": "#/definitions/dto.LoginResponse"
                         }
                     },
                     "400": {
                         "description": "Invalid request format",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.LoginResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                        "description": "Invalid credentials",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.LoginResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - admin access required",
+                        "description": "Account not verified or inactive",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.LoginResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.LoginResponse"
                         }
                     }
                 }
             }
         },
-        "/api/admin/stats": {
-            "get": {
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get comprehensive user statistics for admin dashboard",
+                "description": "Log out user and invalidate JWT tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -754,45 +779,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Authentication"
                 ],
-                "summary": "Get User Statistics",
+                "summary": "User Logout",
                 "responses": {
                     "200": {
-                        "description": "User statistics retrieved successfully",
+                        "description": "Logout successful",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserStatsResponse"
+                            "$ref": "#/definitions/dto.LogoutResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing token",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - admin access required",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/admin/users/search": {
-            "get": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Search for users by email, username, first name, or last name",
+        "/auth/password-reset/confirm": {
+            "post": {
+                "description": "Validate a password reset token and set a new password. Token is single-use and expires after TTL.",
                 "consumes": [
                     "application/json"
                 ],
@@ -800,74 +814,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Authentication"
                 ],
-                "summary": "Search Users",
+                "summary": "Confirm Password Reset",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Search query (email, username, first name, or last name)",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number for pagination",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Number of users per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PasswordResetConfirmRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Users found successfully",
+                        "description": "Password has been reset successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetUsersResponse"
+                            "$ref": "#/definitions/dto.PasswordResetConfirmResponse"
                         }
                     },
                     "400": {
-                        "description": "Search query is required",
+                        "description": "Invalid or expired token, or invalid payload",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.PasswordResetConfirmResponse"
                         }
-                    },
-                    "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                    }
+                }
+            }
+        },
+        "/auth/password-reset/request": {
+            "post": {
+                "description": "Generate a password reset token and send reset link to email. Always returns 200 to prevent user enumeration.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Request Password Reset",
+                "parameters": [
+                    {
+                        "description": "Email address to reset",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.PasswordResetRequest"
                         }
-                    },
-                    "403": {
-                        "description": "Forbidden - admin access required",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "If that email exists, a reset link has been sent.",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.PasswordResetResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Invalid request payload",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.PasswordResetResponse"
                         }
                     }
                 }
             }
         },
-        "/api/admin/users/{id}/activate": {
+        "/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Activate a user account (admin only)",
+                "description": "Register a new user account with email verification",
                 "consumes": [
                     "application/json"
                 ],
@@ -875,66 +894,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Authentication"
                 ],
-                "summary": "Activate User",
+                "summary": "User Registration",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Registration details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegistrationRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User activated successfully",
+                    "201": {
+                        "description": "User registered successfully, OTP sent to email",
                         "schema": {
-                            "$ref": "#/definitions/dto.AdminActionResponse"
+                            "$ref": "#/definitions/dto.RegistrationResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - invalid or missing token",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - admin access required",
+                        "description": "Invalid input or validation error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.RegistrationResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "409": {
+                        "description": "User already exists",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.RegistrationResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.RegistrationResponse"
                         }
                     }
                 }
             }
         },
-        "/api/admin/users/{id}/deactivate": {
+        "/auth/resend-otp/{id}": {
             "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Deactivate a user account (admin only)",
+                "description": "Resend verification OTP to user's email",
                 "consumes": [
                     "application/json"
                 ],
@@ -942,9 +946,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Authentication"
                 ],
-                "summary": "Deactivate User",
+                "summary": "Resend OTP",
                 "parameters": [
                     {
                         "type": "string",
@@ -956,9 +960,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deactivated successfully",
+                        "description": "OTP sent successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.AdminActionResponse"
+                            "$ref": "#/definitions/dto.ResendOTPResponse"
                         }
                     },
                     "400": {
@@ -967,20 +971,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - invalid or missing token",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - admin access required",
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "429": {
+                        "description": "Too many requests - rate limited",
                         "schema": {
                             "$ref": "#/definitions/dto.AuthErrorResponse"
                         }
@@ -994,14 +992,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/users/{id}/force-verify": {
+        "/auth/verify-otp": {
             "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Force verify a user account without OTP (admin only)",
+                "description": "Verify email OTP to activate user account",
                 "consumes": [
                     "application/json"
                 ],
@@ -1009,66 +1002,111 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Authentication"
                 ],
-                "summary": "Force Verify User",
+                "summary": "Verify OTP",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "OTP verification details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VerifyOTPRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User verified successfully",
+                        "description": "OTP verified successfully, account activated",
                         "schema": {
-                            "$ref": "#/definitions/dto.AdminActionResponse"
+                            "$ref": "#/definitions/dto.VerifyOTPResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid request format",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.VerifyOTPResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - admin access required",
+                        "description": "Invalid or expired OTP",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.VerifyOTPResponse"
                         }
                     },
                     "404": {
                         "description": "User not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.VerifyOTPResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.VerifyOTPResponse"
                         }
                     }
                 }
             }
         },
-        "/api/admin/users/{id}/make-staff": {
+        "/campaigns": {
+            "get": {
+                "description": "Get a paginated list of public campaigns, plus unlisted and private ones the signed-in user owns or belongs to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "campaigns"
+                ],
+                "summary": "List all campaigns",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Campaigns retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CampaignListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Grant staff privileges to a user (admin only)",
+                "description": "Create a new campaign with the provided details",
                 "consumes": [
                     "application/json"
                 ],
@@ -1076,66 +1114,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "campaigns"
                 ],
-                "summary": "Promote User to Staff",
+                "summary": "Create a new campaign",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Campaign details",
+                        "name": "campaign",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCampaignRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User promoted to staff successfully",
+                    "201": {
+                        "description": "Campaign created successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.AdminActionResponse"
+                            "$ref": "#/definitions/dto.CampaignResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid request body",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - admin access required",
+                        "description": "Unauthorized - not a member or sponsor",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Campaign not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/admin/users/{id}/remove-staff": {
-            "post": {
+        "/campaigns/admin/campaign-runners": {
+            "get": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Remove staff privileges from a user (admin only)",
+                "description": "Get a paginated list of all campaign runners",
                 "consumes": [
                     "application/json"
                 ],
@@ -1143,66 +1177,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "admin-campaign-runners"
                 ],
-                "summary": "Remove Staff Privileges",
+                "summary": "Get all campaign runners (Admin)",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by campaign ID",
+                        "name": "campaign_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Staff privileges removed successfully",
+                        "description": "Campaign runners retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.AdminActionResponse"
+                            "$ref": "#/definitions/dto.CampaignRunnerListResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid query parameters",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - admin access required",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/auth/change-password": {
+            },
             "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Change user password with current password verification",
+                "description": "Create a new campaign runner entry for admin purposes",
                 "consumes": [
                     "application/json"
                 ],
@@ -1210,62 +1249,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "admin-campaign-runners"
                 ],
-                "summary": "Change Password",
+                "summary": "Create a new campaign runner (Admin)",
                 "parameters": [
                     {
-                        "description": "Password change details",
-                        "name": "request",
+                        "description": "Campaign runner details",
+                        "name": "runner",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ChangePasswordRequest"
+                            "$ref": "#/definitions/dto.CreateCampaignRunnerRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Password changed successfully",
+                    "201": {
+                        "description": "Campaign runner created successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.ChangePasswordResponse"
+                            "$ref": "#/definitions/dto.CampaignRunnerResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request format",
+                        "description": "Invalid request body",
                         "schema": {
-                            "$ref": "#/definitions/dto.ChangePasswordResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized or invalid current password",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.ChangePasswordResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Campaign or user not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.ChangePasswordResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.ChangePasswordResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/auth/delete-account": {
-            "delete": {
+        "/campaigns/admin/campaign-runners/{id}": {
+            "get": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Permanently delete the authenticated user's account",
+                "description": "Get a specific campaign runner by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1273,40 +1312,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "admin-campaign-runners"
+                ],
+                "summary": "Get campaign runner by ID (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign runner ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Delete User Account",
                 "responses": {
                     "200": {
-                        "description": "Account deleted successfully",
-                        "schema": {
-                            "$ref": "#/definitions/dto.DeleteAccountResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                        "description": "Campaign runner retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.CampaignRunnerResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Campaign runner not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/auth/login": {
-            "post": {
-                "description": "Authenticate user and return JWT tokens",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a campaign runner by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1314,62 +1359,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "admin-campaign-runners"
                 ],
-                "summary": "User Login",
+                "summary": "Update campaign runner (Admin)",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Campaign runner ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campaign runner update details",
+                        "name": "runner",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
+                            "$ref": "#/definitions/dto.UpdateCampaignRunnerRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful with JWT tokens",
+                        "description": "Campaign runner updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginResponse"
+                            "$ref": "#/definitions/dto.CampaignRunnerResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request format",
+                        "description": "Invalid request body",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Account not verified or inactive",
+                    "404": {
+                        "description": "Campaign runner not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/auth/logout": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Log out user and invalidate JWT tokens",
+                "description": "Delete a campaign runner by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1377,18 +1427,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "admin-campaign-runners"
+                ],
+                "summary": "Delete campaign runner (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign runner ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "User Logout",
                 "responses": {
-                    "200": {
-                        "description": "Logout successful",
+                    "204": {
+                        "description": "Campaign runner deleted successfully"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.LogoutResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                    "404": {
+                        "description": "Campaign runner not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1402,155 +1464,148 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/password-reset/confirm": {
-            "post": {
-                "description": "Validate a password reset token and set a new password. Token is single-use and expires after TTL.",
-                "consumes": [
-                    "application/json"
+        "/campaigns/admin/flagged-runs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get campaign runs flagged by anti-cheat checks, oldest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "admin-activity-review"
                 ],
-                "summary": "Confirm Password Reset",
+                "summary": "List campaign runs held for review (Admin)",
                 "parameters": [
                     {
-                        "description": "Token and new password",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.PasswordResetConfirmRequest"
-                        }
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Password has been reset successfully",
+                        "description": "Flagged runs retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.PasswordResetConfirmResponse"
+                            "$ref": "#/definitions/dto.CampaignRunListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid or expired token, or invalid payload",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.PasswordResetConfirmResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff privileges required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/auth/password-reset/request": {
+        "/campaigns/admin/flagged-runs/{id}/approve": {
             "post": {
-                "description": "Generate a password reset token and send reset link to email. Always returns 200 to prevent user enumeration.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Approve a run held by anti-cheat checks and credit it to the runner and campaign totals. Runs on a campaign that no longer accepts activity or has closed can only be rejected.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "admin-activity-review"
                 ],
-                "summary": "Request Password Reset",
+                "summary": "Approve a flagged campaign run (Admin)",
                 "parameters": [
                     {
-                        "description": "Email address to reset",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.PasswordResetRequest"
-                        }
+                        "type": "string",
+                        "description": "Campaign run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "If that email exists, a reset link has been sent.",
+                        "description": "Run approved",
                         "schema": {
-                            "$ref": "#/definitions/dto.PasswordResetResponse"
+                            "$ref": "#/definitions/dto.CampaignRunResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request payload",
-                        "schema": {
-                            "$ref": "#/definitions/dto.PasswordResetResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/auth/register": {
-            "post": {
-                "description": "Register a new user account with email verification",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "User Registration",
-                "parameters": [
-                    {
-                        "description": "Registration details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegistrationRequest"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "User registered successfully, OTP sent to email",
+                    },
+                    "403": {
+                        "description": "Staff privileges required",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegistrationResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid input or validation error",
+                    "404": {
+                        "description": "Run not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegistrationResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "User already exists",
+                        "description": "Run is not awaiting review, or the campaign no longer accepts activity",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegistrationResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegistrationResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/auth/resend-otp/{id}": {
+        "/campaigns/admin/flagged-runs/{id}/reject": {
             "post": {
-                "description": "Resend verification OTP to user's email",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Reject a run held by anti-cheat checks; it is never credited",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "admin-activity-review"
                 ],
-                "summary": "Resend OTP",
+                "summary": "Reject a flagged campaign run (Admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Campaign run ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1558,41 +1613,52 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP sent successfully",
+                        "description": "Run rejected",
                         "schema": {
-                            "$ref": "#/definitions/dto.ResendOTPResponse"
+                            "$ref": "#/definitions/dto.CampaignRunResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid user ID",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff privileges required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Run not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "429": {
-                        "description": "Too many requests - rate limited",
+                    "409": {
+                        "description": "Run is not awaiting review",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/auth/verify-otp": {
-            "post": {
-                "description": "Verify email OTP to activate user account",
+        "/campaigns/admin/sponsor-campaigns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of all sponsor campaigns",
                 "consumes": [
                     "application/json"
                 ],
@@ -1600,117 +1666,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "admin-sponsor-campaigns"
                 ],
-                "summary": "Verify OTP",
+                "summary": "Get all sponsor campaigns (Admin)",
                 "parameters": [
                     {
-                        "description": "OTP verification details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.VerifyOTPRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OTP verified successfully, account activated",
-                        "schema": {
-                            "$ref": "#/definitions/dto.VerifyOTPResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request format",
-                        "schema": {
-                            "$ref": "#/definitions/dto.VerifyOTPResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Invalid or expired OTP",
-                        "schema": {
-                            "$ref": "#/definitions/dto.VerifyOTPResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.VerifyOTPResponse"
-                        }
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/dto.VerifyOTPResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/user/profile/image": {
-            "post": {
-                "security": [
                     {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Upload or update the authenticated user's profile image",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Upload/Update Profile Image",
-                "parameters": [
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
-                        "type": "file",
-                        "description": "Profile image file (png, jpg, jpeg, webp, gif)",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "Filter by campaign ID",
+                        "name": "campaign_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile image updated",
+                        "description": "Sponsor campaigns retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserProfileResponse"
+                            "$ref": "#/definitions/dto.SponsorCampaignListResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request or file",
+                        "description": "Invalid query parameters",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/users": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all users in the system (admin access required)",
+                "description": "Create a new sponsor campaign entry for admin purposes",
                 "consumes": [
                     "application/json"
                 ],
@@ -1718,61 +1732,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "admin-sponsor-campaigns"
                 ],
-                "summary": "Get All Users",
+                "summary": "Create a new sponsor campaign (Admin)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number for pagination",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Number of users per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Sponsor campaign details",
+                        "name": "sponsor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSponsorCampaignRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Users retrieved successfully",
+                    "201": {
+                        "description": "Sponsor campaign created successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetUsersResponse"
+                            "$ref": "#/definitions/dto.SponsorCampaignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - admin access required",
+                    "404": {
+                        "description": "Campaign not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/users/profile": {
+        "/campaigns/admin/sponsor-campaigns/{id}": {
             "get": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Get the profile information of the authenticated user",
+                "description": "Get a specific sponsor campaign by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1780,32 +1795,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "admin-sponsor-campaigns"
+                ],
+                "summary": "Get sponsor campaign by ID (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sponsor campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get User Profile",
                 "responses": {
                     "200": {
-                        "description": "User profile retrieved successfully",
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserProfileResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                        "description": "Sponsor campaign retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.SponsorCampaignResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Sponsor campaign not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
@@ -1813,10 +1831,10 @@ const docTemplate = `{
             "put": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Update the authenticated user's profile information",
+                "description": "Update a sponsor campaign by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1824,62 +1842,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "admin-sponsor-campaigns"
                 ],
-                "summary": "Update User Profile",
+                "summary": "Update sponsor campaign (Admin)",
                 "parameters": [
                     {
-                        "description": "Profile update details",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Sponsor campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sponsor campaign update details",
+                        "name": "sponsor",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                            "$ref": "#/definitions/dto.UpdateSponsorCampaignRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile updated successfully",
+                        "description": "Sponsor campaign updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserProfileResponse"
+                            "$ref": "#/definitions/dto.SponsorCampaignResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request format",
+                        "description": "Invalid request body",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Sponsor campaign not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/users/staff": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all staff users (admin access required)",
+                "description": "Delete a sponsor campaign by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1887,45 +1910,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "admin-sponsor-campaigns"
+                ],
+                "summary": "Delete sponsor campaign (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sponsor campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get Staff Users",
                 "responses": {
-                    "200": {
-                        "description": "Staff users retrieved successfully",
-                        "schema": {
-                            "$ref": "#/definitions/dto.GetUsersResponse"
-                        }
+                    "204": {
+                        "description": "Sponsor campaign deleted successfully"
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - admin access required",
+                    "404": {
+                        "description": "Sponsor campaign not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/users/unverified": {
+        "/campaigns/by_others": {
             "get": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all unverified users (admin access required)",
+                "description": "Get all campaigns created by users other than the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1933,91 +1962,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "campaigns"
                 ],
-                "summary": "Get Unverified Users",
-                "responses": {
-                    "200": {
-                        "description": "Unverified users retrieved successfully",
-                        "schema": {
-                            "$ref": "#/definitions/dto.GetUsersResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - invalid or missing token",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - admin access required",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
+                "summary": "Get campaigns by other users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/users/verified": {
-            "get": {
-                "security": [
                     {
-                        "Bearer": []
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
-                "description": "Retrieve all verified users (admin access required)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Get Verified Users",
                 "responses": {
                     "200": {
-                        "description": "Verified users retrieved successfully",
+                        "description": "Other users' campaigns retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetUsersResponse"
+                            "$ref": "#/definitions/dto.CampaignListResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - admin access required",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/users/{id}": {
+        "/campaigns/by_user": {
             "get": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific user by their ID (admin access required)",
+                "description": "Get all campaigns created by the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -2025,96 +2018,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
-                ],
-                "summary": "Get User by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "campaigns"
                 ],
+                "summary": "Get campaigns by current user",
                 "responses": {
                     "200": {
-                        "description": "User retrieved successfully",
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserProfileResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid user ID",
+                        "description": "User campaigns retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.CampaignListResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - admin access required",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/campaigns": {
+        "/campaigns/invites/{token}": {
             "get": {
-                "description": "Get a paginated list of all campaigns",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Show the campaign an invite token leads to, so it can be checked before accepting",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "List all campaigns",
+                "summary": "Preview a campaign invite",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Invite token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Campaigns retrieved successfully",
+                        "description": "Invite is valid",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignListResponse"
+                            "$ref": "#/definitions/dto.InvitePreviewResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid query parameters",
+                    "404": {
+                        "description": "Invalid or revoked invite",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invite expired or used up",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2126,56 +2088,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/campaigns/invites/{token}/accept": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new campaign with the provided details",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Join the campaign an invite token leads to. Email invitations must be accepted by the invited address.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Create a new campaign",
+                "summary": "Accept a campaign invite",
                 "parameters": [
                     {
-                        "description": "Campaign details",
-                        "name": "campaign",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateCampaignRequest"
-                        }
+                        "type": "string",
+                        "description": "Invite token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Campaign created successfully",
+                    "200": {
+                        "description": "Joined campaign",
                         "schema": {
                             "$ref": "#/definitions/dto.CampaignResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - not a member or sponsor",
+                    "403": {
+                        "description": "Invite sent to another email address",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Campaign not found",
+                        "description": "Invalid or revoked invite",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invite expired or used up, already a member, or campaign closed",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2189,25 +2154,113 @@ const docTemplate = `{
                 }
             }
         },
-        "/campaigns/by_others": {
+        "/campaigns/search": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all campaigns created by users other than the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Search campaigns by text with optional filters and sorting. Text matches name, description and location.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Get campaigns by other users",
+                "summary": "Search campaigns",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "Walking",
+                            "Running",
+                            "Cycling"
+                        ],
+                        "type": "string",
+                        "description": "Activity",
+                        "name": "activity",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "Free",
+                            "Paid"
+                        ],
+                        "type": "string",
+                        "description": "Mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location contains",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "scheduled",
+                            "active",
+                            "completed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Lifecycle status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Still running on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Started on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum target amount",
+                        "name": "min_target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum target amount",
+                        "name": "max_target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only campaigns within radius_km of this position (lat,lon)",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 25,
+                        "description": "Search radius in km, used with near",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "most_funded",
+                            "closest_to_goal",
+                            "nearest"
+                        ],
+                        "type": "string",
+                        "default": "newest",
+                        "description": "Sort order; nearest requires near",
+                        "name": "sort",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -2225,13 +2278,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Other users' campaigns retrieved successfully",
+                        "description": "Matching campaigns with the total number of matches",
                         "schema": {
                             "$ref": "#/definitions/dto.CampaignListResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2245,29 +2298,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/campaigns/by_user": {
+        "/campaigns/templates": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all campaigns created by the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the caller's campaign templates, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Get campaigns by current user",
+                "summary": "List my campaign templates",
                 "responses": {
                     "200": {
-                        "description": "User campaigns retrieved successfully",
+                        "description": "Templates",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignListResponse"
+                            "$ref": "#/definitions/dto.CampaignTemplateListResponse"
                         }
                     },
                     "401": {
@@ -2285,37 +2335,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/campaigns/{slug}": {
+        "/campaigns/templates/{template_id}": {
             "get": {
-                "description": "Get a specific campaign by its slug",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get one of the caller's campaign templates",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Get campaign by slug",
+                "summary": "Get a campaign template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Campaign slug",
-                        "name": "slug",
+                        "description": "Template ID",
+                        "name": "template_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Campaign retrieved successfully",
+                        "description": "Template",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignResponse"
+                            "$ref": "#/definitions/dto.CampaignTemplateResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Campaign not found",
+                        "description": "Template not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2334,7 +2392,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a campaign by its slug (only owner can update)",
+                "description": "Rename a template or set its recurring schedule. A weekly or monthly template creates its next edition lead_hours (default one week) before next_starts_at, then moves next_starts_at on by one interval. A recurrence of none stops the schedule.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2344,34 +2402,34 @@ const docTemplate = `{
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Update campaign",
+                "summary": "Update a campaign template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Campaign slug",
-                        "name": "slug",
+                        "description": "Template ID",
+                        "name": "template_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Campaign update details",
-                        "name": "campaign",
+                        "description": "Changes",
+                        "name": "template",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateCampaignRequest"
+                            "$ref": "#/definitions/dto.UpdateCampaignTemplateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Campaign updated successfully",
+                        "description": "Template updated",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignResponse"
+                            "$ref": "#/definitions/dto.CampaignTemplateResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid recurrence",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2382,14 +2440,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - not campaign owner",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "404": {
-                        "description": "Campaign not found",
+                        "description": "Template not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2408,29 +2460,23 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a campaign by its slug (only owner can delete)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Delete a template and stop its schedule. Campaigns already created from it are kept.",
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Delete campaign",
+                "summary": "Delete a campaign template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Campaign slug",
-                        "name": "slug",
+                        "description": "Template ID",
+                        "name": "template_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "Campaign deleted successfully"
+                        "description": "Template deleted"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -2438,14 +2484,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - not campaign owner",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "404": {
-                        "description": "Campaign not found",
+                        "description": "Template not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2459,14 +2499,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/campaigns/{slug}/finish_campaign/{runner_id}": {
-            "get": {
+        "/campaigns/templates/{template_id}/campaigns": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details for finishing a campaign run (simplified without post system)",
+                "description": "Create a campaign from one of the caller's templates. Give starts_at, or shift_days to move the template's dates; the end keeps the template's length. Sponsorships are copied as pending slots that count once their sponsor confirms them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2476,28 +2516,36 @@ const docTemplate = `{
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Get campaign finish details",
+                "summary": "Create a campaign from a template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Campaign slug",
-                        "name": "slug",
+                        "description": "Template ID",
+                        "name": "template_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Campaign runner ID",
-                        "name": "runner_id",
-                        "in": "path",
-                        "required": true
+                        "description": "New start",
+                        "name": "campaign",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateFromTemplateRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Campaign runner details retrieved successfully",
+                    "201": {
+                        "description": "Campaign created",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignRunnerResponse"
+                            "$ref": "#/definitions/dto.CampaignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid start",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -2507,7 +2555,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Campaign or runner not found",
+                        "description": "Template not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2519,14 +2567,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Complete a campaign run by updating runner details",
+            }
+        },
+        "/campaigns/{slug}": {
+            "get": {
+                "description": "Get a specific campaign by its slug. Private campaigns are only shown to their owner and members.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2536,7 +2581,7 @@ const docTemplate = `{
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Finish a campaign run",
+                "summary": "Get campaign by slug",
                 "parameters": [
                     {
                         "type": "string",
@@ -2544,45 +2589,17 @@ const docTemplate = `{
                         "name": "slug",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Campaign runner ID",
-                        "name": "runner_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Activity completion details",
-                        "name": "details",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.FinishActivityRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Campaign run finished successfully",
-                        "schema": {
-                            "$ref": "#/definitions/dto.CampaignRunnerResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Campaign retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.CampaignResponse"
                         }
                     },
                     "404": {
-                        "description": "Campaign or runner not found",
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2594,16 +2611,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/campaigns/{slug}/join": {
+            },
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add the authenticated user as a member of the campaign",
+                "description": "Update a campaign by its slug (only owner can update)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2613,7 +2628,7 @@ const docTemplate = `{
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Join a campaign",
+                "summary": "Update campaign",
                 "parameters": [
                     {
                         "type": "string",
@@ -2621,17 +2636,26 @@ const docTemplate = `{
                         "name": "slug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Campaign update details",
+                        "name": "campaign",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCampaignRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully joined campaign",
+                        "description": "Campaign updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/dto.CampaignResponse"
                         }
                     },
                     "400": {
-                        "description": "Already a member",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2642,6 +2666,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden - not campaign owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Campaign not found",
                         "schema": {
@@ -2655,11 +2685,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/campaigns/{slug}/leaderboard": {
-            "get": {
-                "description": "Get leaderboard of campaign participants ordered by distance covered",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a campaign by its slug (only owner can delete)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2669,7 +2702,7 @@ const docTemplate = `{
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Get campaign leaderboard",
+                "summary": "Delete campaign",
                 "parameters": [
                     {
                         "type": "string",
@@ -2680,10 +2713,19 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Leaderboard retrieved successfully",
+                    "204": {
+                        "description": "Campaign deleted successfully"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignLeaderboardResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not campaign owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
@@ -2701,24 +2743,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/campaigns/{slug}/participate": {
+        "/campaigns/{slug}/cancel": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Start participating in a campaign by creating a runner entry",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Cancel a draft, scheduled or active campaign (owner or staff only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Participate in a campaign",
+                "summary": "Cancel a campaign",
                 "parameters": [
                     {
                         "type": "string",
@@ -2726,32 +2765,23 @@ const docTemplate = `{
                         "name": "slug",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Participation details",
-                        "name": "participation",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ParticipateCampaignRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully started participation",
+                        "description": "Campaign cancelled",
                         "schema": {
-                            "$ref": "#/definitions/dto.ParticipateCampaignResponse"
+                            "$ref": "#/definitions/dto.CampaignResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden - not campaign owner",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2762,6 +2792,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Campaign already completed or cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2771,24 +2807,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/campaigns/{slug}/sponsor": {
-            "post": {
+        "/campaigns/{slug}/export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add sponsorship to a campaign",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Download the runner roster (distance, duration and money raised per runner) and the sponsor ledger (what each sponsorship owes for the distance covered so far). CSV is streamed with the ledger after the roster, separated by a blank line; XLSX has a sheet for each (owner or staff only).",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "campaigns"
                 ],
-                "summary": "Sponsor a campaign",
+                "summary": "Export campaign results",
                 "parameters": [
                     {
                         "type": "string",
@@ -2798,24 +2832,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Sponsorship details",
-                        "name": "sponsorship",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SponsorCampaignRequest"
-                        }
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully sponsored campaign",
+                        "description": "Export file",
                         "schema": {
-                            "$ref": "#/definitions/dto.SponsorCampaignResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Unsupported format",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2826,6 +2862,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden - not campaign owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Campaign not found",
                         "schema": {
@@ -2841,14 +2883,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/causes": {
-            "post": {
+        "/campaigns/{slug}/finish_campaign/{runner_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new cause within a challenge",
+                "description": "Get details for finishing a campaign run (simplified without post system)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2856,35 +2898,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "causes"
+                    "campaigns"
                 ],
-                "summary": "Create a new cause",
+                "summary": "Get campaign finish details",
                 "parameters": [
                     {
-                        "description": "Cause details",
-                        "name": "cause",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateCauseRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Cause created successfully",
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campaign runner ID",
+                        "name": "runner_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Campaign runner details retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.CauseResponse"
+                            "$ref": "#/definitions/dto.CampaignRunnerResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Campaign or runner not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2896,16 +2943,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/causes/activity": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Record activity for a cause (distance covered, etc.)",
+                "description": "Complete a campaign run by updating runner details",
                 "consumes": [
                     "application/json"
                 ],
@@ -2913,25 +2958,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "causes"
+                    "campaigns"
                 ],
-                "summary": "Record cause activity",
+                "summary": "Finish a campaign run",
                 "parameters": [
                     {
-                        "description": "Activity details",
-                        "name": "activity",
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campaign runner ID",
+                        "name": "runner_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Activity completion details",
+                        "name": "details",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RecordActivityRequest"
+                            "$ref": "#/definitions/dto.FinishActivityRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Activity recorded successfully",
+                        "description": "Campaign run finished successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/dto.CampaignRunnerResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Run recorded but held for anti-cheat review",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ActivityHeldResponse"
                         }
                     },
                     "400": {
@@ -2946,6 +3011,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Campaign or runner not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2955,44 +3026,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/causes/buy": {
+        "/campaigns/{slug}/finish_campaign/{runner_id}/track": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Purchase a cause with specified amount",
+                "description": "Parse a GPX or TCX file, compute distance, moving time, elevation gain and splits, and add the run to the runner and campaign totals",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "purchase"
+                    "campaigns"
                 ],
-                "summary": "Buy a cause",
+                "summary": "Upload a GPS track for a campaign run",
                 "parameters": [
                     {
-                        "description": "Purchase details",
-                        "name": "purchase",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.BuyCauseRequest"
-                        }
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campaign runner ID",
+                        "name": "runner_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "GPX or TCX file (max 20MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cause purchased successfully",
+                        "description": "Track recorded",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/dto.TrackUploadResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Track stored but the run is held for anti-cheat review",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrackUploadResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Missing or invalid track file",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3003,6 +3092,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Access denied to this runner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Campaign or runner not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Campaign is not accepting activity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -3012,50 +3119,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/causes/sponsor": {
-            "post": {
+        "/campaigns/{slug}/invites": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Sponsor a cause with amount per kilometer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the campaign's invite links and email invitations, newest first (owner or staff only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sponsorship"
+                    "campaigns"
                 ],
-                "summary": "Sponsor a cause",
+                "summary": "List campaign invites",
                 "parameters": [
                     {
-                        "description": "Sponsorship details",
-                        "name": "sponsorship",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SponsorCauseRequest"
-                        }
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cause sponsored successfully",
+                        "description": "Invites retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/dto.InviteListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden - not campaign owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3067,11 +3175,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/causes/{id}": {
-            "get": {
-                "description": "Get a specific cause by its ID",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a signed invite link that expires and can be capped to a number of uses (owner or staff only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3079,27 +3190,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "causes"
+                    "campaigns"
                 ],
-                "summary": "Get cause by ID",
+                "summary": "Create a campaign invite link",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cause ID",
-                        "name": "id",
+                        "description": "Campaign slug",
+                        "name": "slug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Usage cap and lifetime",
+                        "name": "invite",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateInviteRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Cause retrieved successfully",
+                    "201": {
+                        "description": "Invite created successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.CauseResponse"
+                            "$ref": "#/definitions/dto.InviteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not campaign owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Cause not found",
+                        "description": "Campaign not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Campaign no longer open to new members",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3113,14 +3256,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/causes/{id}/join": {
+        "/campaigns/{slug}/invites/email": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Join a cause as a member",
+                "description": "Email a single-use invite to each address. Each invite can only be accepted by an account with that email (owner or staff only).",
                 "consumes": [
                     "application/json"
                 ],
@@ -3128,27 +3271,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "causes"
+                    "campaigns"
                 ],
-                "summary": "Join a cause",
+                "summary": "Email campaign invitations",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cause ID",
-                        "name": "id",
+                        "description": "Campaign slug",
+                        "name": "slug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Addresses to invite",
+                        "name": "invites",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmailInvitesRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully joined cause",
+                    "201": {
+                        "description": "Invitations sent",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/dto.InviteListResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3159,8 +3311,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden - not campaign owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Cause not found",
+                        "description": "Campaign not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Campaign no longer open to new members",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3174,44 +3338,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/challenges": {
-            "get": {
-                "description": "Get a paginated list of all challenges",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/campaigns/{slug}/invites/{invite_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop an invite link or email invitation from being used (owner or staff only)",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "challenges"
+                    "campaigns"
                 ],
-                "summary": "List all challenges",
+                "summary": "Revoke a campaign invite",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Invite ID",
+                        "name": "invite_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Challenges retrieved successfully",
+                        "description": "Invite revoked",
                         "schema": {
-                            "$ref": "#/definitions/dto.ChallengeListResponse"
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid query parameters",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not campaign owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Campaign or invite not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3223,14 +3401,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/campaigns/{slug}/join": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new challenge with the provided details",
+                "description": "Add the authenticated user as a member of the campaign",
                 "consumes": [
                     "application/json"
                 ],
@@ -3238,29 +3418,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "challenges"
+                    "campaigns"
                 ],
-                "summary": "Create a new challenge",
+                "summary": "Join a campaign",
                 "parameters": [
                     {
-                        "description": "Challenge details",
-                        "name": "challenge",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateChallengeRequest"
-                        }
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Challenge created successfully",
+                    "200": {
+                        "description": "Successfully joined campaign",
                         "schema": {
-                            "$ref": "#/definitions/dto.ChallengeResponse"
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Already a member",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3271,33 +3449,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Private campaign; an invite or approved join request is needed",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/challenges/leaderboard": {
-            "get": {
-                "description": "Get the leaderboard of top performers",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "leaderboard"
-                ],
-                "summary": "Get leaderboard",
-                "responses": {
-                    "200": {
-                        "description": "Leaderboard retrieved successfully",
+                    },
+                    "404": {
+                        "description": "Campaign not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.LeaderboardResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
@@ -3309,37 +3470,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/challenges/slug/{slug}": {
+        "/campaigns/{slug}/join_requests": {
             "get": {
-                "description": "Get a specific challenge by its slug",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List requests to join the campaign, oldest first (owner or staff only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "challenges"
+                    "campaigns"
                 ],
-                "summary": "Get challenge by slug",
+                "summary": "List campaign join requests",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Challenge slug",
+                        "description": "Campaign slug",
                         "name": "slug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Only requests in this state",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Challenge retrieved successfully",
+                        "description": "Join requests retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.ChallengeResponse"
+                            "$ref": "#/definitions/dto.JoinRequestListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not campaign owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Challenge not found",
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3351,16 +3543,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/challenges/sponsor": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Sponsor a challenge with amount per kilometer",
+                "description": "Ask the owner of a private campaign to let the authenticated user in",
                 "consumes": [
                     "application/json"
                 ],
@@ -3368,25 +3558,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sponsorship"
+                    "campaigns"
                 ],
-                "summary": "Sponsor a challenge",
+                "summary": "Request to join a private campaign",
                 "parameters": [
                     {
-                        "description": "Sponsorship details",
-                        "name": "sponsorship",
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message to the owner",
+                        "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SponsorChallengeRequest"
+                            "$ref": "#/definitions/dto.CreateJoinRequestRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Challenge sponsored successfully",
+                    "201": {
+                        "description": "Join request created",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/dto.JoinRequestResponse"
                         }
                     },
                     "400": {
@@ -3401,6 +3597,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Campaign not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already a member, request pending, campaign open to all, or campaign closed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -3410,37 +3618,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/challenges/{challenge_id}/causes": {
-            "get": {
-                "description": "Get all causes for a specific challenge",
-                "consumes": [
-                    "application/json"
+        "/campaigns/{slug}/join_requests/{request_id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Approve a pending join request, making the requester a campaign member (owner or staff only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "causes"
+                    "campaigns"
                 ],
-                "summary": "Get causes by challenge",
+                "summary": "Approve a join request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Challenge ID",
-                        "name": "challenge_id",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Join request ID",
+                        "name": "request_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Causes retrieved successfully",
+                        "description": "Join request approved",
                         "schema": {
-                            "$ref": "#/definitions/dto.CauseListResponse"
+                            "$ref": "#/definitions/dto.JoinRequestResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not campaign owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Challenge not found",
+                        "description": "Campaign or join request not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already decided or campaign closed",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3454,98 +3689,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/challenges/{id}": {
-            "get": {
-                "description": "Get a specific challenge by its ID",
-                "consumes": [
-                    "application/json"
+        "/campaigns/{slug}/join_requests/{request_id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Turn down a pending join request (owner or staff only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "challenges"
+                    "campaigns"
                 ],
-                "summary": "Get challenge by ID",
+                "summary": "Reject a join request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Challenge ID",
-                        "name": "id",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Join request ID",
+                        "name": "request_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Challenge retrieved successfully",
+                        "description": "Join request rejected",
                         "schema": {
-                            "$ref": "#/definitions/dto.ChallengeResponse"
+                            "$ref": "#/definitions/dto.JoinRequestResponse"
                         }
                     },
-                    "404": {
-                        "description": "Challenge not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/challenges/{id}/join": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Join a challenge as a member",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "challenges"
-                ],
-                "summary": "Join a challenge",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Challenge ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Successfully joined challenge",
-                        "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                    "403": {
+                        "description": "Forbidden - not campaign owner",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Campaign or join request not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Challenge not found",
+                    "409": {
+                        "description": "Already decided",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3559,60 +3760,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/admin/groups/search": {
+        "/campaigns/{slug}/leaderboard": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Case-insensitive search over group names with pagination (staff only)",
+                "description": "Rank campaign participants by distance covered, then by least time, with the caller's own place and the users around them. Time windows count the runs logged in the window.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat",
-                    "admin"
+                    "campaigns"
                 ],
-                "summary": "Admin: search chat groups by name",
+                "summary": "Get campaign leaderboard",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search query",
-                        "name": "q",
-                        "in": "query",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "daily, weekly, monthly or all_time (default); windows start at midnight UTC and weeks on Monday",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count this activity",
+                        "name": "activity",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
+                        "description": "Users either side of the caller (0-10, default 2)",
+                        "name": "neighbours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
                         "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
+                        "description": "Entries per page (default 50, max 100)",
                         "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Groups retrieved successfully",
+                        "description": "Leaderboard retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.GroupListResponse"
+                            "$ref": "#/definitions/dto.CampaignLeaderboardResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid window or query",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - staff only",
+                    "404": {
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3622,53 +3838,49 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Time windows not available",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/chat/groups": {
+        "/campaigns/{slug}/leaderboard/teams": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of all groups where the user is a member or creator",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the campaign's teams ranked by their members' combined distance covered",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "campaigns"
                 ],
-                "summary": "List all groups for current user",
+                "summary": "Get campaign team leaderboard",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Groups retrieved successfully",
+                        "description": "Team leaderboard retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.GroupListResponse"
+                            "$ref": "#/definitions/dto.TeamLeaderboardResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3680,50 +3892,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/campaigns/{slug}/milestones": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new chat group with the provided details",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the campaign's progress milestones in the order they are reached, with the campaign's current totals",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "campaigns"
                 ],
-                "summary": "Create a new chat group",
+                "summary": "List campaign milestones",
                 "parameters": [
                     {
-                        "description": "Group details",
-                        "name": "group",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateGroupRequest"
-                        }
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Group created successfully",
+                    "200": {
+                        "description": "Milestones retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.GroupResponse"
+                            "$ref": "#/definitions/dto.MilestoneListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3735,16 +3944,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/chat/groups/{slug}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a specific group by its slug",
+                "description": "Replace the campaign's milestones that have not been reached yet (owner or staff only). Each milestone watches distance or money and is either a percent of the campaign target or a fixed value. Members and sponsors are notified in the app and by email the first time the campaign passes one. Milestones the campaign has already passed are recorded as reached without notifying anyone.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3752,23 +3959,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "campaigns"
                 ],
-                "summary": "Get group by slug",
+                "summary": "Configure campaign milestones",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group slug",
+                        "description": "Campaign slug",
                         "name": "slug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Milestones",
+                        "name": "milestones",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetMilestonesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Group retrieved successfully",
+                        "description": "Milestones saved",
                         "schema": {
-                            "$ref": "#/definitions/dto.GroupResponse"
+                            "$ref": "#/definitions/dto.MilestoneListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid milestones",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -3778,13 +4000,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - not a member",
+                        "description": "Forbidden - not campaign owner",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Group not found",
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3796,14 +4018,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/campaigns/{slug}/participate": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a group by its slug (only creator can update)",
+                "description": "Start participating in a campaign by creating a runner entry",
                 "consumes": [
                     "application/json"
                 ],
@@ -3811,32 +4035,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "campaigns"
                 ],
-                "summary": "Update group",
+                "summary": "Participate in a campaign",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group slug",
+                        "description": "Campaign slug",
                         "name": "slug",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Group update details",
-                        "name": "group",
+                        "description": "Participation details",
+                        "name": "participation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateGroupRequest"
+                            "$ref": "#/definitions/dto.ParticipateCampaignRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Group updated successfully",
+                        "description": "Successfully started participation",
                         "schema": {
-                            "$ref": "#/definitions/dto.GroupResponse"
+                            "$ref": "#/definitions/dto.ParticipateCampaignResponse"
                         }
                     },
                     "400": {
@@ -3852,13 +4076,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - not group creator",
+                        "description": "Private campaign and not a member",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Group not found",
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3870,36 +4094,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/campaigns/{slug}/publish": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a group by its slug (only creator can delete)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Move a draft campaign to scheduled or active depending on its start time (owner or staff only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "campaigns"
                 ],
-                "summary": "Delete group",
+                "summary": "Publish a draft campaign",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group slug",
+                        "description": "Campaign slug",
                         "name": "slug",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Group deleted successfully"
+                    "200": {
+                        "description": "Campaign published",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CampaignResponse"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -3908,13 +4134,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - not group creator",
+                        "description": "Forbidden - not campaign owner",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Group not found",
+                        "description": "Campaign not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Campaign is not a draft",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3928,28 +4160,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/groups/{slug}/join": {
-            "post": {
+        "/campaigns/{slug}/results": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add the authenticated user as a member of the group",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the final standings frozen when the campaign closed. Sponsor obligations are included for the owner and staff.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "campaigns"
                 ],
-                "summary": "Join a chat group",
+                "summary": "Get campaign results",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group slug",
+                        "description": "Campaign slug",
                         "name": "slug",
                         "in": "path",
                         "required": true
@@ -3957,25 +4186,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully joined group",
+                        "description": "Results retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.GroupMemberResponse"
+                            "$ref": "#/definitions/dto.CampaignResultsResponse"
                         }
                     },
-                    "400": {
-                        "description": "Already a member",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Group not found",
+                    "409": {
+                        "description": "Campaign has not closed yet",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -3989,14 +4218,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/groups/{slug}/leave": {
+        "/campaigns/{slug}/sponsor": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove the authenticated user from the group",
+                "description": "Add sponsorship to a campaign",
                 "consumes": [
                     "application/json"
                 ],
@@ -4004,27 +4233,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "campaigns"
                 ],
-                "summary": "Leave a chat group",
+                "summary": "Sponsor a campaign",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group slug",
+                        "description": "Campaign slug",
                         "name": "slug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Sponsorship details",
+                        "name": "sponsorship",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SponsorCampaignRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully left group",
+                        "description": "Successfully sponsored campaign",
                         "schema": {
-                            "$ref": "#/definitions/dto.GroupMemberResponse"
+                            "$ref": "#/definitions/dto.SponsorCampaignResponse"
                         }
                     },
                     "400": {
-                        "description": "Not a member",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -4036,7 +4274,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Group not found",
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -4050,52 +4288,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/groups/{slug}/messages": {
+        "/campaigns/{slug}/sponsorships": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of messages from a specific group",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the campaign's sponsorships, including slots copied from a template that are still waiting for their sponsor to confirm",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "campaigns"
                 ],
-                "summary": "Get messages from a group",
+                "summary": "List campaign sponsorships",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group slug",
+                        "description": "Campaign slug",
                         "name": "slug",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Messages retrieved successfully",
+                        "description": "Sponsorships",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageListResponse"
+                            "$ref": "#/definitions/dto.CampaignSponsorshipListResponse"
                         }
                     },
                     "401": {
@@ -4104,14 +4325,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - not a member",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "404": {
-                        "description": "Group not found",
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -4123,53 +4338,44 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/campaigns/{slug}/sponsorships/{sponsorship_id}/confirm": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send a message to a specific group (only members can send)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Confirm a sponsorship slot copied from a template. A slot that names its sponsors can only be confirmed by one of them; an open slot is taken by the caller. The pledge then counts towards the campaign's money raised.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "campaigns"
                 ],
-                "summary": "Send a message to a group",
+                "summary": "Confirm a pending sponsorship slot",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group slug",
+                        "description": "Campaign slug",
                         "name": "slug",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Message details",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SendMessageRequest"
-                        }
+                        "type": "string",
+                        "description": "Sponsorship ID",
+                        "name": "sponsorship_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Message sent successfully",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ChatMessageResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body",
+                    "200": {
+                        "description": "Sponsorship confirmed",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.SponsorCampaignResponse"
                         }
                     },
                     "401": {
@@ -4179,13 +4385,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - not a member",
+                        "description": "Slot reserved for another sponsor",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Group not found",
+                        "description": "Campaign or sponsorship not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sponsorship already confirmed",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -4199,65 +4411,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/comments": {
-            "post": {
+        "/campaigns/{slug}/teams": {
+            "get": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Create a comment on any target (auth required)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the teams competing in a campaign",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "campaigns"
                 ],
-                "summary": "Create a comment",
+                "summary": "List campaign teams",
                 "parameters": [
                     {
-                        "description": "Comment payload",
-                        "name": "comment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateCommentRequest"
-                        }
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Teams retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.TeamListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Campaign not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/comments/{id}": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Update a comment (author only)",
+                "description": "Create a team in the campaign with the authenticated user as captain",
                 "consumes": [
                     "application/json"
                 ],
@@ -4265,252 +4476,216 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "campaigns"
                 ],
-                "summary": "Update a comment",
+                "summary": "Create a campaign team",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Comment ID",
-                        "name": "id",
+                        "description": "Campaign slug",
+                        "name": "slug",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Comment update payload",
-                        "name": "comment",
+                        "description": "Team details",
+                        "name": "team",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateCommentRequest"
+                            "$ref": "#/definitions/dto.CreateTeamRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Team created successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.TeamResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid request body",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Private campaign and not a member",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Campaign not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already in a team, name taken or campaign closed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/campaigns/{slug}/teams/join": {
+            "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Delete a comment (author only)",
+                "description": "Join the campaign team that owns the given invite code",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "campaigns"
                 ],
-                "summary": "Delete a comment",
+                "summary": "Join a campaign team",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Comment ID",
-                        "name": "id",
+                        "description": "Campaign slug",
+                        "name": "slug",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Invite code",
+                        "name": "invite",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.JoinTeamRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Joined team successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.TeamResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid request body or invite code",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Private campaign and not a member",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Campaign not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/comments/{targetType}/{targetID}": {
-            "get": {
-                "description": "Get comments for a target type and ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "comments"
-                ],
-                "summary": "List comments for a target",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Target type",
-                        "name": "targetType",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Target ID",
-                        "name": "targetID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
                     },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "409": {
+                        "description": "Already in a team or campaign closed",
                         "schema": {
-                            "$ref": "#/definitions/dto.ListCommentsResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/health": {
+        "/campaigns/{slug}/teams/{team_id}": {
             "get": {
-                "description": "Health check endpoint to verify service status including Redis connection",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "System"
-                ],
-                "summary": "Health Check",
-                "responses": {
-                    "200": {
-                        "description": "Service is healthy",
-                        "schema": {
-                            "$ref": "#/definitions/handler.HealthResponse"
-                        }
-                    },
-                    "503": {
-                        "description": "Service is unhealthy",
-                        "schema": {
-                            "$ref": "#/definitions/handler.HealthResponse"
-                        }
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
-                }
-            }
-        },
-        "/posts": {
-            "get": {
-                "description": "Get a paginated list of published posts",
+                ],
+                "description": "Get a team and its members. The invite code is only shown to members.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "campaigns"
                 ],
-                "summary": "List published posts",
+                "summary": "Get a campaign team",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "team_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Team retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.ListPostsResponse"
+                            "$ref": "#/definitions/dto.TeamResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Campaign or team not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/posts/admin": {
-            "post": {
+        "/campaigns/{slug}/teams/{team_id}/captain": {
+            "put": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Create a new post (staff only)",
+                "description": "Hand the team to another member. Only the captain can do this.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4518,475 +4693,8865 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "campaigns"
                 ],
-                "summary": "Create a post",
+                "summary": "Transfer team captaincy",
                 "parameters": [
                     {
-                        "description": "Post payload",
-                        "name": "post",
+                        "type": "string",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "team_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New captain",
+                        "name": "captain",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreatePostRequest"
+                            "$ref": "#/definitions/dto.TransferCaptaincyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Captaincy transferred successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.TeamResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid request body",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Not the captain, or new captain not in the team",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Campaign or team not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Campaign closed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/posts/admin/{id}": {
-            "put": {
+        "/campaigns/{slug}/teams/{team_id}/invite_code": {
+            "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Update a post (staff only)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Replace the team's invite code so the old one stops working. Only the captain can do this.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "campaigns"
                 ],
-                "summary": "Update a post",
+                "summary": "Regenerate a team invite code",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ID",
-                        "name": "id",
+                        "description": "Campaign slug",
+                        "name": "slug",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Post update payload",
-                        "name": "post",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdatePostRequest"
-                        }
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "team_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Invite code regenerated successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.TeamResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Not the captain",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Campaign or team not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Campaign closed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/posts/admin/{id}/publish": {
+        "/campaigns/{slug}/teams/{team_id}/leave": {
             "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Publish a post (staff only)",
+                "description": "Leave a team. A leaving captain hands the team to the longest-standing member; the last member leaving deletes the team.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "campaigns"
                 ],
-                "summary": "Publish a post",
+                "summary": "Leave a campaign team",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ID",
-                        "name": "id",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "team_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Left team successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Not a member of this team",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/posts/admin/{id}/unpublish": {
-            "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Unpublish a post (staff only)",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "posts"
-                ],
-                "summary": "Unpublish a post",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Post ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Campaign or team not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Campaign closed",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/posts/{id}": {
+        "/campaigns/{slug}/teams/{team_id}/members/{user_id}": {
             "delete": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Delete a post. Only the author or a superuser may delete.",
+                "description": "Remove a member from the team. Only the captain can remove other members.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "campaigns"
                 ],
-                "summary": "Delete a post",
+                "summary": "Remove a team member",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ID",
-                        "name": "id",
+                        "description": "Campaign slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "team_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member user ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Member removed successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Not the captain, or user not in the team",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Campaign or team not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Campaign closed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/posts/{id}/cover": {
+        "/campaigns/{slug}/template": {
             "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Upload or update the cover image for a post. Only the author, staff, or superuser may upload.",
+                "description": "Save the campaign's settings, schedule length, sponsorships and milestones as a reusable template owned by the caller (owner or staff only). Progress, members and dates are not copied.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "campaigns"
                 ],
-                "summary": "Upload post cover image",
+                "summary": "Save a campaign as a template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ID",
-                        "name": "id",
+                        "description": "Campaign slug",
+                        "name": "slug",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Cover image file (png, jpg, jpeg, webp, gif)",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                        "description": "Template name",
+                        "name": "template",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SaveCampaignTemplateRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Template saved",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.CampaignTemplateResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid request body",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Forbidden - not campaign owner",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Campaign not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/posts/{slug}": {
-            "get": {
-                "description": "Retrieve a single published post by slug",
+        "/causes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new cause within a challenge",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "causes"
                 ],
-                "summary": "Get post by slug",
+                "summary": "Create a new cause",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Post slug",
-                        "name": "slug",
-                        "in": "path",
-                        "required": true
+                        "description": "Cause details",
+                        "name": "cause",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCauseRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Cause created successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.CauseResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Invalid request body",
                         "schema": {
-                            "$ref": "#/definitions/dto.PostResponse"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/ws/chat/groups/{groupSlug}": {
-            "get": {
+        "/causes/activity": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upgrade the HTTP connection to a WebSocket for realtime chat within a group. Requires JWT auth. Alias route: /ws/chat/group/{groupSlug}.",
+                "description": "Record activity for a cause (distance covered, etc.)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "chat"
+                    "causes"
                 ],
-                "summary": "WebSocket chat connection",
+                "summary": "Record cause activity",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Group slug",
-                        "name": "groupSlug",
-                        "in": "path",
-                        "required": true
+                        "description": "Activity details",
+                        "name": "activity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecordActivityRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "101": {
-                        "description": "Switching Protocols",
+                    "200": {
+                        "description": "Activity recorded successfully",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "202": {
+                        "description": "Activity recorded but held for anti-cheat review",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ActivityHeldResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Invalid request body",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Group not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AdminActionResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                },
+        },
+        "/causes/admin/flagged-runners": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get cause activities flagged by anti-cheat checks, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-activity-review"
+                ],
+                "summary": "List cause activities held for review (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Flagged activities retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseRunnerReviewListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff privileges required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/admin/flagged-runners/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve an activity held by anti-cheat checks and add its distance to the cause",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-activity-review"
+                ],
+                "summary": "Approve a flagged cause activity (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause runner ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Activity approved",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseRunnerReviewResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff privileges required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause runner not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Activity is not awaiting review",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/admin/flagged-runners/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject an activity held by anti-cheat checks; it stays out of the cause total and leaderboard",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-activity-review"
+                ],
+                "summary": "Reject a flagged cause activity (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause runner ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Activity rejected",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseRunnerReviewResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff privileges required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause runner not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Activity is not awaiting review",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/buy": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Place a pending order for units of a commercial cause. The amount must equal quantity times the cause's unit price; stock is taken when the order is paid.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "summary": "Order a cause",
+                "parameters": [
+                    {
+                        "description": "Order details",
+                        "name": "purchase",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BuyCauseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order placed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, cause not for sale or amount mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not enough stock left",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Cause orders not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/nearby": {
+            "get": {
+                "description": "Get a paginated list of the causes within radius_km of near, nearest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "causes"
+                ],
+                "summary": "List causes near a position",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Position (lat,lon)",
+                        "name": "near",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "default": 25,
+                        "description": "Search radius in km",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Causes retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the caller's cause orders, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "summary": "List my cause orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Orders per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Orders",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseOrderListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Cause orders not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/orders/{order_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an order; visible to its buyer, the cause owner and staff",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "summary": "Get cause order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseOrderResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the buyer or the cause owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Cause orders not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/orders/{order_id}/fulfil": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a paid order delivered (cause owner or staff)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "summary": "Fulfil order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order fulfilled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseOrderResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the cause owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not paid",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Cause orders not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/orders/{order_id}/pay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a pending order paid, taking its units from stock, and email the buyer a receipt (cause owner or staff)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "summary": "Confirm order payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PayCauseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order paid",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the cause owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not pending, or the cause sold out",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Cause orders not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/orders/{order_id}/refund": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refund a paid or fulfilled order, returning its units to stock, and email the buyer (cause owner or staff)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "summary": "Refund order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order refunded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseOrderResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the cause owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not paid",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Cause orders not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/runners/{runner_id}/track": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Parse a GPX or TCX file and replace the activity's reported distance and duration with the computed figures",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "causes"
+                ],
+                "summary": "Upload a GPS track for a cause activity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause runner ID",
+                        "name": "runner_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "GPX or TCX file (max 20MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Track recorded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrackUploadResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Track stored but the activity is held for anti-cheat review",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrackUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid track file",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied to this runner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause runner not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Run kept changing while the track was recorded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/sponsor": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sponsor a cause with amount per kilometer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sponsorship"
+                ],
+                "summary": "Sponsor a cause",
+                "parameters": [
+                    {
+                        "description": "Sponsorship details",
+                        "name": "sponsorship",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SponsorCauseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cause sponsored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/sponsorships/statements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List what the caller owes the causes they sponsored, latest settlement first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sponsorship"
+                ],
+                "summary": "List my sponsor statements",
+                "responses": {
+                    "200": {
+                        "description": "Statements",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SponsorStatementListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Settlements not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/{id}": {
+            "get": {
+                "description": "Get a specific cause by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "causes"
+                ],
+                "summary": "Get cause by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cause retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a cause's details. Only fields that are sent change. Owner or staff only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "causes"
+                ],
+                "summary": "Update cause",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cause update details",
+                        "name": "cause",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCauseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cause updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the cause owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a cause with its runners, sponsorships, orders and members. It is refused while its sponsors are still owed a settlement unless staff force it, and always once they have been settled, so their statements are kept. Owner or staff only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "causes"
+                ],
+                "summary": "Delete cause",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete despite outstanding sponsorships (staff only)",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Cause deleted successfully"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the cause owner, or force without staff",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sponsors have outstanding obligations or have been settled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/{id}/funding": {
+            "get": {
+                "description": "Get a cause's funding ledger: what each sponsor has earned from the distance its runners have covered, capped at the km they pledged, and progress towards the cause's fund amount. Once the cause's challenge has closed, the ledger shows the settled sponsor statements.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sponsorship"
+                ],
+                "summary": "Get cause funding",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Funding ledger",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseFundingResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/{id}/join": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Join a cause as a member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "causes"
+                ],
+                "summary": "Join a cause",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully joined cause",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/{id}/leaderboard": {
+            "get": {
+                "description": "Rank users by the distance they covered in a cause, then by least time. Anti-cheat held and rejected runs are left out. Signed-in callers also get their own place and the users around them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard"
+                ],
+                "summary": "Get cause leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "daily, weekly, monthly or all_time (default); windows start at midnight UTC and weeks on Monday",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count this activity",
+                        "name": "activity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Users either side of the caller (0-10, default 2)",
+                        "name": "neighbours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries per page (default 50, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leaderboard",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseLeaderboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid window or query",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/{id}/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Leave a cause the caller is a member of. The owner cannot leave.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "causes"
+                ],
+                "summary": "Leave a cause",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully left cause",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not a member, or the owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/{id}/members": {
+            "get": {
+                "description": "List the members of a cause with their user details, earliest to join first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "causes"
+                ],
+                "summary": "List cause members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Members",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseMemberListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/{id}/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the orders of a cause, newest first (cause owner or staff)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "summary": "List a cause's orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Orders per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Orders",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseOrderListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the cause owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Cause orders not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/{id}/statements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List what each sponsor owes a cause, settled when its challenge closed (cause owner or staff). Empty until then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sponsorship"
+                ],
+                "summary": "List cause sponsor statements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statements",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SponsorStatementListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the cause owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Settlements not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/{id}/stock": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set how many units of a cause can be sold in all, or lift the limit with a null stock. It cannot go below the units already sold (cause owner or staff).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "summary": "Set cause stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetCauseStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cause with its new stock",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the cause owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Cause orders not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/causes/{id}/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hand a cause to another user. Owner or staff only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "causes"
+                ],
+                "summary": "Transfer cause ownership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New owner",
+                        "name": "owner",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferOwnershipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ownership transferred",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, or already the owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the cause owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cause or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ownership changed during the transfer",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/certificates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the certificates issued to the caller, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "certificates"
+                ],
+                "summary": "List my certificates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Certificates",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CertificateListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/certificates/campaign-runners/{runner_id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue the caller's PDF certificate for a campaign run, or return the one already issued. The campaign must be completed and the runner must have covered some distance.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "certificates"
+                ],
+                "summary": "Get a campaign completion certificate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign runner ID",
+                        "name": "runner_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Certificate",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CertificateResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Runner belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Runner not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Run not completed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/certificates/cause-runners/{runner_id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue the caller's PDF certificate for a cause run, or return the one already issued. The runner must have covered its distance to cover and must not be held or rejected by review.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "certificates"
+                ],
+                "summary": "Get a cause completion certificate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cause runner ID",
+                        "name": "runner_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Certificate",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CertificateResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Runner belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Runner not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Run not completed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/certificates/{id}/verify": {
+            "get": {
+                "description": "Confirm that a certificate ID was issued by this service and show the details printed on it. This is where the certificate's QR code leads.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "certificates"
+                ],
+                "summary": "Verify a certificate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Certificate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Genuine certificate",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CertificateVerificationResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No certificate with this ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges": {
+            "get": {
+                "description": "Get a paginated list of all challenges, newest first. With near, only challenges within radius_km are listed, nearest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "List all challenges",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only challenges within radius_km of this position (lat,lon)",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 25,
+                        "description": "Search radius in km, used with near",
+                        "name": "radius_km",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Challenges retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChallengeListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new challenge with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Create a new challenge",
+                "parameters": [
+                    {
+                        "description": "Challenge details",
+                        "name": "challenge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateChallengeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Challenge created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/admin/rounds/{round_id}/judges": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make a user a judge of a round. Their scores count by their weight against the other judges' (default 1). Judges can be changed until the round is tallied (staff only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "judging"
+                ],
+                "summary": "Appoint judge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Round ID",
+                        "name": "round_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Judge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AppointJudgeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Judge appointed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JudgeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff only",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Round not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already a judge or round closed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/admin/rounds/{round_id}/judges/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Withdraw a user from judging a round; scores they gave stop counting (staff only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "judging"
+                ],
+                "summary": "Remove judge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Round ID",
+                        "name": "round_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Judge's user ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Judge removed"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff only or not a judge",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Round not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Round closed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/admin/rounds/{round_id}/scores": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every judge's scores in a round, grouped by cause (staff only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "judging"
+                ],
+                "summary": "List judge scores",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Round ID",
+                        "name": "round_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scores",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JudgeScoreListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff only",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Round not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/admin/{challenge_id}/rounds": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a judging round to a challenge. Between opens_at and closes_at its judges score the causes against the rubric and members vote; each cause's score blends the two by vote_share. The results of the final round award the challenge's cause prizes (staff only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "judging"
+                ],
+                "summary": "Create judging round",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "challenge_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Round",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateJudgingRoundRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Round created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JudgingRoundResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule, rubric or vote share",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff only",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Challenge already has a final round",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Judging not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/admin/{challenge_id}/winner-audits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the staff overrides of a challenge's winners, oldest first, with who made each change and why (staff only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "List winner overrides",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "challenge_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Overrides",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WinnerAuditListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff only",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/admin/{challenge_id}/winners": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the winners of a closed challenge with the given participants, first place first. Prizes follow the new ranks, and the change is recorded with the reason (staff only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Override challenge winners",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "challenge_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New winners and reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OverrideChallengeWinnersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Winners",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChallengeWinnersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid winners or missing reason",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff only",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Challenge has not closed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/id/{id}": {
+            "get": {
+                "description": "Get a specific challenge by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Get challenge by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Challenge retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChallengeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/leaderboard": {
+            "get": {
+                "description": "Get the leaderboard of top performers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard"
+                ],
+                "summary": "Get leaderboard",
+                "responses": {
+                    "200": {
+                        "description": "Leaderboard retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeaderboardResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/rounds/{round_id}": {
+            "get": {
+                "description": "Get a judging round with its rubric and judges",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "judging"
+                ],
+                "summary": "Get judging round",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Round ID",
+                        "name": "round_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Round",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JudgingRoundResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Round not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Judging not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/rounds/{round_id}/results": {
+            "get": {
+                "description": "Get the causes of a closed round ranked by their blended judge and vote score. In the final round each place carries its cause prize.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "judging"
+                ],
+                "summary": "Get judging round results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Round ID",
+                        "name": "round_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Results",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JudgingResultsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Round not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Round has not closed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/rounds/{round_id}/scores": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rate a cause against every criterion of the round's rubric, from 0 to the criterion's maximum. Only the round's judges can score, while it is open; scoring a cause again replaces the earlier score.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "judging"
+                ],
+                "summary": "Score cause",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Round ID",
+                        "name": "round_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scores",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScoreCauseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Score saved",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JudgeScoreResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid scores or cause not in the challenge",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a judge of this round",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Round or cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Round not open",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/rounds/{round_id}/votes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cast a community vote for a cause while the round is open. Only members of the challenge can vote, once per round.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "judging"
+                ],
+                "summary": "Vote for cause",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Round ID",
+                        "name": "round_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vote",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CastVoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Vote cast",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Cause not in the challenge",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a member of the challenge",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Round or cause not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Round not open or already voted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/slug/{slug}": {
+            "get": {
+                "description": "Get a specific challenge by its slug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Get challenge by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Challenge retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChallengeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/sponsor": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sponsor a challenge with amount per kilometer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sponsorship"
+                ],
+                "summary": "Sponsor a challenge",
+                "parameters": [
+                    {
+                        "description": "Sponsorship details",
+                        "name": "sponsorship",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SponsorChallengeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Challenge sponsored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{challenge_id}/bracket": {
+            "get": {
+                "description": "Get the challenge's knockout bracket as a tree rooted at the final. Each match lists the two matches whose winners meet in it, so first-round matches are the leaves.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Get challenge bracket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "challenge_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bracket",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BracketResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found or has no bracket",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Brackets not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{challenge_id}/cause-awards": {
+            "get": {
+                "description": "Get the results of a challenge's final judging round, whose places are awarded the challenge's cause prizes. The list is empty until the final round closes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "judging"
+                ],
+                "summary": "Get cause awards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "challenge_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Awards",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JudgingResultsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Judging not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{challenge_id}/causes": {
+            "get": {
+                "description": "Get all causes for a specific challenge",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "causes"
+                ],
+                "summary": "Get causes by challenge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "challenge_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Causes retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CauseListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{challenge_id}/leaderboard": {
+            "get": {
+                "description": "Rank users by the distance they covered across every cause of a challenge, then by least time. Anti-cheat held and rejected runs are left out. Signed-in callers also get their own place and the users around them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard"
+                ],
+                "summary": "Get challenge leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "challenge_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "daily, weekly, monthly or all_time (default); windows start at midnight UTC and weeks on Monday",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count this activity",
+                        "name": "activity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Users either side of the caller (0-10, default 2)",
+                        "name": "neighbours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries per page (default 50, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leaderboard",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChallengeLeaderboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid window or query",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{challenge_id}/members": {
+            "get": {
+                "description": "List the members of a challenge with their user details, earliest to join first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "List challenge members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "challenge_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Members",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChallengeMemberListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{challenge_id}/rounds": {
+            "get": {
+                "description": "List a challenge's judging rounds in the order they open, with their status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "judging"
+                ],
+                "summary": "List judging rounds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "challenge_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rounds",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JudgingRoundListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Judging not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{challenge_id}/winners": {
+            "get": {
+                "description": "Get the winners of a challenge with their prizes, first place first. Winners are selected when the challenge ends, ranked by its ranking criterion; the list is empty until then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Get challenge winners",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "challenge_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Winners",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChallengeWinnersResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Winner selection not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a challenge's details. Only fields that are sent change. Closed and cancelled challenges cannot be updated. Owner or staff only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Update challenge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Challenge update details",
+                        "name": "challenge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateChallengeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Challenge updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, schedule or ranking criterion",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the challenge owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Challenge closed or cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a challenge with its causes, runners, sponsorships and members. It is refused while sponsors of the challenge or its causes are still owed a settlement unless staff force it, and always once a cause's sponsors have been settled, so their statements are kept. Owner or staff only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Delete challenge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete despite outstanding sponsorships (staff only)",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Challenge deleted successfully"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the challenge owner, or force without staff",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sponsors have outstanding obligations or have been settled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{id}/bracket": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Seed the challenge's members, randomly or by the distance they have logged, and draw a knockout bracket. Each round lasts round_days from starts_at; in each match the member who logs more distance in the challenge's causes during the round advances. Top seeds get byes when the members do not fill a power of two, and a member who logs nothing loses by walkover. Owner or staff only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Draw challenge bracket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Bracket",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateBracketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Bracket drawn",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BracketResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid seeding or schedule",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the challenge owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Challenge already has a bracket, has finished or has fewer than two members",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Brackets not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Call off a challenge that has not closed. It can no longer be joined or updated, and it is never closed out, so no winners are chosen. Owner or staff only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Cancel a challenge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Challenge cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChallengeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the challenge owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Challenge already closed or cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{id}/join": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Join a challenge as a member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Join a challenge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully joined challenge",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{id}/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Leave a challenge the caller is a member of. The owner cannot leave.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Leave a challenge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully left challenge",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not a member, or the owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/challenges/{id}/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hand a challenge to another user. Owner or staff only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "challenges"
+                ],
+                "summary": "Transfer challenge ownership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Challenge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New owner",
+                        "name": "owner",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferOwnershipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ownership transferred",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, or already the owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the challenge owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Challenge or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ownership changed during the transfer",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/admin/groups/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Case-insensitive search over group names with pagination (staff only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat",
+                    "admin"
+                ],
+                "summary": "Admin: search chat groups by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Groups retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GroupListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - staff only",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/groups": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of all groups where the user is a member or creator",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "List all groups for current user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Groups retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GroupListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new chat group with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Create a new chat group",
+                "parameters": [
+                    {
+                        "description": "Group details",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Group created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/groups/{slug}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a specific group by its slug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Get group by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GroupResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a member",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a group by its slug (only creator can update)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Update group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Group update details",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not group creator",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a group by its slug (only creator can delete)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Delete group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Group deleted successfully"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not group creator",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/groups/{slug}/join": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add the authenticated user as a member of the group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Join a chat group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully joined group",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GroupMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Already a member",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/groups/{slug}/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the authenticated user from the group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Leave a chat group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully left group",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GroupMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Not a member",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/groups/{slug}/messages": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of messages from a specific group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Get messages from a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Messages retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a member",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a message to a specific group (only members can send)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Send a message to a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message details",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SendMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Message sent successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChatMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not a member",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/comments": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a comment on any target (auth required)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Create a comment",
+                "parameters": [
+                    {
+                        "description": "Comment payload",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/comments/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update a comment (author only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Update a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment update payload",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a comment (author only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Delete a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/comments/{targetType}/{targetID}": {
+            "get": {
+                "description": "Get comments for a target type and ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "List comments for a target",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Target type",
+                        "name": "targetType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target ID",
+                        "name": "targetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListCommentsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{key}": {
+            "get": {
+                "description": "Stream a private file using a signed, expiring URL. Supports HTTP range requests.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Download a private file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Object key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry as unix seconds",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial file content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing signature parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid or expired signature",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "File not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Health check endpoint to verify service status including Redis connection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Health Check",
+                "responses": {
+                    "200": {
+                        "description": "Service is healthy",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service is unhealthy",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/items/{item_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the caption of an image or video. Only the owner of its gallery may change it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Caption a gallery item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gallery item ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New caption, empty to clear it",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateGalleryCaptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated item",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MediaItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Caption too long",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an image or video from its gallery; later items move up one place. Only the owner of the gallery may remove items.",
+                "tags": [
+                    "media"
+                ],
+                "summary": "Remove a gallery item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gallery item ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Removed"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{kind}/{subject_id}": {
+            "get": {
+                "description": "List the images and videos of a challenge, cause or campaign in gallery order. A private campaign's gallery is only shown to its owner, its members and staff.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Get a gallery",
+                "parameters": [
+                    {
+                        "enum": [
+                            "challenges",
+                            "causes",
+                            "campaigns"
+                        ],
+                        "type": "string",
+                        "description": "Subject kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Challenge, cause or campaign ID",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Gallery",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MediaGalleryResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subject not found or private",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{kind}/{subject_id}/images": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a PNG, JPEG, WebP or GIF image of at most 10MB to the end of a challenge, cause or campaign gallery. Only the owner may add to a gallery, which holds at most 50 items.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Add an image to a gallery",
+                "parameters": [
+                    {
+                        "enum": [
+                            "challenges",
+                            "causes",
+                            "campaigns"
+                        ],
+                        "type": "string",
+                        "description": "Subject kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Challenge, cause or campaign ID",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caption of at most 500 characters",
+                        "name": "caption",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Added image",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MediaItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing, oversized or unsupported image, or caption too long",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subject not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Gallery full",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{kind}/{subject_id}/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Put a gallery in a new order by listing every item ID, first first. Only the owner may reorder a gallery.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Reorder a gallery",
+                "parameters": [
+                    {
+                        "enum": [
+                            "challenges",
+                            "causes",
+                            "campaigns"
+                        ],
+                        "type": "string",
+                        "description": "Subject kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Challenge, cause or campaign ID",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Item IDs in the new order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReorderGalleryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reordered gallery",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MediaGalleryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Order does not list every item exactly once",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subject not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{kind}/{subject_id}/videos": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a YouTube, Vimeo or Dailymotion link to the end of a challenge, cause or campaign gallery. Its player, thumbnail and size are worked out from the link. Only the owner may add to a gallery, which holds at most 50 items.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Add a video to a gallery",
+                "parameters": [
+                    {
+                        "enum": [
+                            "challenges",
+                            "causes",
+                            "campaigns"
+                        ],
+                        "type": "string",
+                        "description": "Subject kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Challenge, cause or campaign ID",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Video link and caption",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddGalleryVideoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Added video",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MediaItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Unsupported link or caption too long",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subject not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Gallery full",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the caller's notifications, newest first, with the number still unread",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "List my notifications",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notifications",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read_all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "Number of notifications marked",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MarkAllReadResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark a notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification marked as read",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts": {
+            "get": {
+                "description": "Get a paginated list of published posts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "List published posts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListPostsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/admin": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a new post (staff only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Create a post",
+                "parameters": [
+                    {
+                        "description": "Post payload",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/admin/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update a post (staff only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Update a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Post update payload",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/admin/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Publish a post (staff only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Publish a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/admin/{id}/unpublish": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Unpublish a post (staff only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Unpublish a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a post. Only the author or a superuser may delete.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Delete a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/cover": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Upload or update the cover image for a post. Only the author, staff, or superuser may upload.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Upload post cover image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Cover image file (png, jpg, jpeg, webp, gif)",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{slug}": {
+            "get": {
+                "description": "Retrieve a single published post by slug",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Get post by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/admin/staff": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retrieve all staff users (admin access required)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get Staff Users",
+                "responses": {
+                    "200": {
+                        "description": "Staff users retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetUsersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/admin/unverified": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retrieve all unverified users (admin access required)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get Unverified Users",
+                "responses": {
+                    "200": {
+                        "description": "Unverified users retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetUsersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retrieve all users in the system (admin access required)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get All Users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of users per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetUsersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/admin/verified": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retrieve all verified users (admin access required)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get Verified Users",
+                "responses": {
+                    "200": {
+                        "description": "Verified users retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetUsersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/admin/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retrieve a specific user by their ID (admin access required)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the profile information of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User Profile",
+                "responses": {
+                    "200": {
+                        "description": "User profile retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update the authenticated user's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update User Profile",
+                "parameters": [
+                    {
+                        "description": "Profile update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile/image": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Upload or update the authenticated user's profile image",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Upload/Update Profile Image",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Profile image file (png, jpg, jpeg, webp, gif)",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile image updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or file",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/chat/groups/{groupSlug}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upgrade the HTTP connection to a WebSocket for realtime chat within a group. Requires JWT auth. Alias route: /ws/chat/group/{groupSlug}.",
+                "tags": [
+                    "chat"
+                ],
+                "summary": "WebSocket chat connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group slug",
+                        "name": "groupSlug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.ActivityHeldResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ActivityViolation"
+                    }
+                }
+            }
+        },
+        "dto.ActivityViolation": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AddGalleryVideoRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "caption": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "YouTube, Vimeo or Dailymotion link",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AdminActionResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApologyEmailRequest": {
+            "type": "object",
+            "required": [
+                "users"
+            ],
+            "properties": {
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "dto.AppointJudgeRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "defaults to 1",
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.AuthErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "error_message": {
+                    "type": "string"
+                },
+                "errors": {},
+                "status_code": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.BracketEntrant": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "description": "counted distance logged in the round",
+                    "type": "number"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BracketMatchResponse": {
+            "type": "object",
+            "properties": {
+                "away": {
+                    "$ref": "#/definitions/dto.BracketEntrant"
+                },
+                "closes_at": {
+                    "type": "string"
+                },
+                "decided_at": {
+                    "type": "string"
+                },
+                "home": {
+                    "$ref": "#/definitions/dto.BracketEntrant"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "opens_at": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "description": "pending, raced, bye or walkover",
+                    "type": "string"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "winner_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BracketNodeResponse": {
+            "type": "object",
+            "properties": {
+                "feeders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BracketNodeResponse"
+                    }
+                },
+                "match": {
+                    "$ref": "#/definitions/dto.BracketMatchResponse"
+                }
+            }
+        },
+        "dto.BracketResponse": {
+            "type": "object",
+            "properties": {
+                "challenge_id": {
+                    "type": "string"
+                },
+                "champion_id": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "entrants": {
+                    "type": "integer"
+                },
+                "final": {
+                    "$ref": "#/definitions/dto.BracketNodeResponse"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "round_days": {
+                    "type": "number"
+                },
+                "rounds": {
+                    "type": "integer"
+                },
+                "seeding": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BulkEmailRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "subject",
+                "user_ids"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.BuyCauseRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "cause_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "cause_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "defaults to 1",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.CampaignLeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "type": "string"
+                },
+                "campaign_slug": {
+                    "type": "string"
+                },
+                "leaderboard": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
+                },
+                "me": {
+                    "$ref": "#/definitions/dto.LeaderboardEntry"
+                },
+                "neighbours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
+                },
+                "total": {
+                    "description": "ranked users on the whole board",
+                    "type": "integer"
+                },
+                "window": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CampaignListResponse": {
+            "type": "object",
+            "properties": {
+                "campaigns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CampaignResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CampaignOwnerInfo": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CampaignResponse": {
+            "type": "object",
+            "properties": {
+                "accept_tac": {
+                    "type": "boolean"
+                },
+                "achieved_at": {
+                    "type": "string"
+                },
+                "activity": {
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "date_updated": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance_covered": {
+                    "type": "number"
+                },
+                "distance_to_cover": {
+                    "type": "number"
+                },
+                "end_duration": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "goal": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UserSummary"
+                    }
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "money_raised": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "$ref": "#/definitions/dto.CampaignOwnerInfo"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "sponsors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UserSummary"
+                    }
+                },
+                "start_duration": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "target_amount": {
+                    "type": "number"
+                },
+                "target_amount_per_km": {
+                    "type": "number"
+                },
+                "visibility": {
+                    "type": "string"
+                },
+                "workout_img": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CampaignResultsResponse": {
+            "type": "object",
+            "properties": {
+                "achieved_at": {
+                    "type": "string"
+                },
+                "campaign_slug": {
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "obligations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SponsorObligationEntry"
+                    }
+                },
+                "standings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CampaignStandingEntry"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CampaignRunListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CampaignRunResponse"
+                    }
+                }
+            }
+        },
+        "dto.CampaignRunResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "type": "string"
+                },
+                "campaign_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "elevation_gain": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "money_raised": {
+                    "type": "number"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "runner_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "track_url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ActivityViolation"
+                    }
+                }
+            }
+        },
+        "dto.CampaignRunnerListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "runners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CampaignRunnerResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CampaignRunnerResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "type": "string"
+                },
+                "campaign_id": {
+                    "type": "string"
+                },
+                "cover_image": {
+                    "type": "string"
+                },
+                "date_joined": {
+                    "type": "string"
+                },
+                "distance_covered": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "elevation_gain": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "money_raised": {
+                    "type": "number"
+                },
+                "runner_id": {
+                    "type": "string"
+                },
+                "track_url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CampaignSponsorshipListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "sponsorships": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SponsorCampaignResponse"
+                    }
+                }
+            }
+        },
+        "dto.CampaignStandingEntry": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "type": "string"
+                },
+                "distance_covered": {
+                    "type": "number"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "money_raised": {
+                    "type": "number"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CampaignTemplateListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CampaignTemplateResponse"
+                    }
+                }
+            }
+        },
+        "dto.CampaignTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance_to_cover": {
+                    "type": "number"
+                },
+                "editions": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lead_hours": {
+                    "type": "integer"
+                },
+                "length_seconds": {
+                    "description": "0 for open-ended campaigns",
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "milestones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MilestoneRequest"
+                    }
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_starts_at": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "type": "string"
+                },
+                "source_campaign_id": {
+                    "type": "string"
+                },
+                "sponsor_slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TemplateSponsorSlotResponse"
+                    }
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "target_amount": {
+                    "type": "number"
+                },
+                "target_amount_per_km": {
+                    "type": "number"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CastVoteRequest": {
+            "type": "object",
+            "required": [
+                "cause_id"
+            ],
+            "properties": {
+                "cause_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CauseFundingResponse": {
+            "type": "object",
+            "properties": {
+                "cause_id": {
+                    "type": "string"
+                },
+                "distance_covered": {
+                    "type": "number"
+                },
+                "earned": {
+                    "type": "number"
+                },
+                "goal": {
+                    "type": "number"
+                },
+                "pledged": {
+                    "type": "number"
+                },
+                "progress": {
+                    "description": "percent of the goal earned",
+                    "type": "number"
+                },
+                "settled_at": {
+                    "type": "string"
+                },
+                "sponsors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SponsorObligationResponse"
+                    }
+                }
+            }
+        },
+        "dto.CauseLeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "type": "string"
+                },
+                "cause_id": {
+                    "type": "string"
+                },
+                "cause_name": {
+                    "type": "string"
+                },
+                "leaderboard": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
+                },
+                "me": {
+                    "$ref": "#/definitions/dto.LeaderboardEntry"
+                },
+                "neighbours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
+                },
+                "total": {
+                    "description": "ranked users on the whole board",
+                    "type": "integer"
+                },
+                "window": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CauseListResponse": {
+            "type": "object",
+            "properties": {
+                "causes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CauseResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CauseMemberInfo": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CauseMemberListResponse": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CauseMemberInfo"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CauseOrderListResponse": {
+            "type": "object",
+            "properties": {
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CauseOrderResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CauseOrderResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "buyer_id": {
+                    "type": "string"
+                },
+                "cause_id": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "fulfilled_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "payment_ref": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.CauseOwnerInfo": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CauseResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "type": "string"
+                },
+                "amount_per_piece": {
+                    "type": "number"
+                },
+                "available": {
+                    "description": "units left, null when unlimited",
+                    "type": "integer"
+                },
+                "benefit_desc": {
+                    "type": "string"
+                },
+                "buyer_user": {
+                    "type": "string"
+                },
+                "challenge_id": {
+                    "type": "string"
+                },
+                "cost_to_launch": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "date_updated": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance_covered": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "fund_amount": {
+                    "type": "number"
+                },
+                "fund_cause": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_commercial": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CauseMemberInfo"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "$ref": "#/definitions/dto.CauseOwnerInfo"
+                },
+                "problem": {
+                    "type": "string"
+                },
+                "product_description": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "solution": {
+                    "type": "string"
+                },
+                "sponsors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CauseSponsorInfo"
+                    }
+                },
+                "stock": {
+                    "description": "null when unlimited",
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "type": "number"
+                },
+                "units_sold": {
+                    "type": "integer"
+                },
+                "video_url": {
+                    "type": "string"
+                },
+                "who_idea_impact": {
+                    "type": "string"
+                },
+                "willing_amount": {
+                    "type": "number"
+                },
+                "workout_img": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CauseRunnerResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "type": "string"
+                },
+                "cause_id": {
+                    "type": "string"
+                },
+                "cover_image": {
+                    "type": "string"
+                },
+                "date_joined": {
+                    "type": "string"
+                },
+                "distance_covered": {
+                    "type": "number"
+                },
+                "distance_to_cover": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "money_raised": {
+                    "type": "number"
+                },
+                "runner_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CauseRunnerReviewListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "runners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CauseRunnerReviewResponse"
+                    }
+                }
+            }
+        },
+        "dto.CauseRunnerReviewResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "type": "string"
+                },
+                "cause_id": {
+                    "type": "string"
+                },
+                "date_joined": {
+                    "type": "string"
+                },
+                "distance_covered": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "track_url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ActivityViolation"
+                    }
+                }
+            }
+        },
+        "dto.CauseSponsorInfo": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sponsored_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CertificateListResponse": {
+            "type": "object",
+            "properties": {
+                "certificates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CertificateResponse"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CertificateResponse": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "description": "km",
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "campaign or cause",
+                    "type": "string"
+                },
+                "runner_id": {
+                    "type": "string"
+                },
+                "runner_name": {
+                    "type": "string"
+                },
+                "subject_id": {
+                    "type": "string"
+                },
+                "subject_name": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "verify_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CertificateVerificationResponse": {
+            "type": "object",
+            "properties": {
+                "certificate": {
+                    "$ref": "#/definitions/dto.CertificateResponse"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ChallengeLeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "type": "string"
+                },
+                "challenge_id": {
+                    "type": "string"
+                },
+                "challenge_slug": {
+                    "type": "string"
+                },
+                "leaderboard": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
+                },
+                "me": {
+                    "$ref": "#/definitions/dto.LeaderboardEntry"
+                },
+                "neighbours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
+                },
+                "total": {
+                    "description": "ranked users on the whole board",
+                    "type": "integer"
+                },
+                "window": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChallengeListResponse": {
+            "type": "object",
+            "properties": {
+                "challenges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ChallengeResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ChallengeMemberInfo": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChallengeMemberListResponse": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ChallengeMemberInfo"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ChallengeOwnerInfo": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChallengeResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "cause_price": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Prize"
+                    }
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string"
+                },
+                "cover_image": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "date_updated": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance_to_cover": {
+                    "type": "number"
+                },
+                "end_duration": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "goal": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ChallengeMemberInfo"
+                    }
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "no_of_winner": {
+                    "type": "integer"
+                },
+                "owner": {
+                    "$ref": "#/definitions/dto.ChallengeOwnerInfo"
+                },
+                "ranking_criterion": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "sponsors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ChallengeSponsorInfo"
+                    }
+                },
+                "start_duration": {
+                    "type": "string"
+                },
+                "target_amount": {
+                    "type": "number"
+                },
+                "target_amount_per_km": {
+                    "type": "number"
+                },
+                "video_url": {
+                    "type": "string"
+                },
+                "winning_price": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Prize"
+                    }
+                }
+            }
+        },
+        "dto.ChallengeSponsorInfo": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sponsored_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChallengeWinnerResponse": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "money_raised": {
+                    "type": "number"
+                },
+                "overridden": {
+                    "type": "boolean"
+                },
+                "prize": {
+                    "$ref": "#/definitions/dto.Prize"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChallengeWinnersResponse": {
+            "type": "object",
+            "properties": {
+                "challenge_id": {
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "ranking_criterion": {
+                    "type": "string"
+                },
+                "winners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ChallengeWinnerResponse"
+                    }
+                }
+            }
+        },
+        "dto.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChangePasswordResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error_message": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ChatMessageResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "sender_id": {
+                    "type": "string"
+                },
+                "sender_image_url": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
+                    "type": "string"
+                },
                 "status_code": {
                     "type": "integer"
                 },
-                "success": {
-                    "type": "boolean"
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.CreateBracketRequest": {
+            "type": "object",
+            "required": [
+                "seeding",
+                "starts_at"
+            ],
+            "properties": {
+                "round_days": {
+                    "description": "length of each round, a week when 0",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 0
+                },
+                "seeding": {
+                    "description": "random, or most past distance first",
+                    "type": "string",
+                    "enum": [
+                        "random",
+                        "distance"
+                    ]
+                },
+                "starts_at": {
+                    "description": "when the first round opens",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateCampaignRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "activity": {
+                    "type": "string",
+                    "enum": [
+                        "Walking",
+                        "Running",
+                        "Cycling"
+                    ]
+                },
+                "condition": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance_to_cover": {
+                    "type": "number"
+                },
+                "draft": {
+                    "description": "Draft keeps the campaign closed until it is published",
+                    "type": "boolean"
+                },
+                "end_duration": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "goal": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "Free",
+                        "Paid"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "start_duration": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "description": "StartsAt/EndsAt take precedence over the legacy start_duration/end_duration strings",
+                    "type": "string"
+                },
+                "target_amount": {
+                    "type": "number"
+                },
+                "target_amount_per_km": {
+                    "type": "number"
+                },
+                "visibility": {
+                    "description": "Visibility defaults to public; private campaigns are invite-only",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ]
+                },
+                "workout_img": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateCampaignRunnerRequest": {
+            "type": "object",
+            "required": [
+                "activity",
+                "campaign_id",
+                "user_id"
+            ],
+            "properties": {
+                "activity": {
+                    "type": "string"
+                },
+                "campaign_id": {
+                    "type": "string"
+                },
+                "distance_covered": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "money_raised": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateCauseRequest": {
+            "type": "object",
+            "required": [
+                "challenge_id",
+                "name"
+            ],
+            "properties": {
+                "activity": {
+                    "type": "string",
+                    "enum": [
+                        "Walking",
+                        "Running",
+                        "Cycling"
+                    ]
+                },
+                "amount_per_piece": {
+                    "type": "number"
+                },
+                "benefit_desc": {
+                    "type": "string"
+                },
+                "buyer_user": {
+                    "type": "string"
+                },
+                "challenge_id": {
+                    "type": "string"
+                },
+                "cost_to_launch": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fund_amount": {
+                    "type": "number"
+                },
+                "fund_cause": {
+                    "type": "boolean"
+                },
+                "is_commercial": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "problem": {
+                    "type": "string"
+                },
+                "product_description": {
+                    "type": "string"
+                },
+                "solution": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "type": "number"
+                },
+                "video_url": {
+                    "type": "string"
+                },
+                "who_idea_impact": {
+                    "type": "string"
+                },
+                "willing_amount": {
+                    "type": "number"
+                },
+                "workout_img": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateChallengeRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "cause_price": {
+                    "description": "awarded to the causes placed by the final judging round",
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/dto.Prize"
+                    }
+                },
+                "condition": {
+                    "type": "string"
+                },
+                "cover_image": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance_to_cover": {
+                    "type": "number"
+                },
+                "end_duration": {
+                    "type": "string"
+                },
+                "goal": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "Free",
+                        "Paid"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "no_of_winner": {
+                    "type": "integer"
+                },
+                "ranking_criterion": {
+                    "description": "defaults to distance",
+                    "type": "string",
+                    "enum": [
+                        "distance",
+                        "fastest",
+                        "money"
+                    ]
+                },
+                "start_duration": {
+                    "type": "string"
+                },
+                "target_amount": {
+                    "type": "number"
+                },
+                "target_amount_per_km": {
+                    "type": "number"
+                },
+                "video_url": {
+                    "type": "string"
+                },
+                "winning_price": {
+                    "description": "prizes without a rank run first place first",
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/dto.Prize"
+                    }
+                }
+            }
+        },
+        "dto.CreateCommentRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "target_id",
+                "target_type"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateFromTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "draft": {
+                    "type": "boolean"
+                },
+                "shift_days": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateGroupRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "image": {
+                    "type": "string"
+                },
+                "member_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "dto.CreateInviteRequest": {
+            "type": "object",
+            "properties": {
+                "expires_in_hours": {
+                    "description": "defaults to 7 days",
+                    "type": "integer",
+                    "maximum": 2160,
+                    "minimum": 0
+                },
+                "max_uses": {
+                    "description": "0 allows unlimited uses",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.CreateJoinRequestRequest": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "dto.CreateJudgingRoundRequest": {
+            "type": "object",
+            "required": [
+                "closes_at",
+                "name",
+                "opens_at",
+                "rubric"
+            ],
+            "properties": {
+                "closes_at": {
+                    "type": "string"
+                },
+                "final": {
+                    "description": "results award the challenge's cause prizes",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opens_at": {
+                    "type": "string"
+                },
+                "rubric": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.JudgingCriterion"
+                    }
+                },
+                "vote_share": {
+                    "description": "part of the score from community votes",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.CreatePostRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "cover_image_url": {
+                    "type": "string"
+                },
+                "publish": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200
+                }
+            }
+        },
+        "dto.CreateSponsorCampaignRequest": {
+            "type": "object",
+            "required": [
+                "amount_per_km",
+                "campaign_id",
+                "distance",
+                "sponsor_id"
+            ],
+            "properties": {
+                "amount_per_km": {
+                    "type": "number"
+                },
+                "brand_img": {
+                    "type": "string"
+                },
+                "campaign_id": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "sponsor_id": {
+                    "type": "string"
+                },
+                "sponsor_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "video_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateTeamRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.DeleteAccountResponse": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EmailInvitesRequest": {
+            "type": "object",
+            "required": [
+                "emails"
+            ],
+            "properties": {
+                "emails": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expires_in_hours": {
+                    "type": "integer",
+                    "maximum": 2160,
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {}
+            }
+        },
+        "dto.FinishActivityRequest": {
+            "type": "object",
+            "required": [
+                "distance_covered"
+            ],
+            "properties": {
+                "distance_covered": {
+                    "type": "number"
+                },
+                "duration": {
+                    "description": "e.g. \"25:30\", \"1:02:03\", \"45 min\" or \"PT25M30S\"",
+                    "type": "string"
+                },
+                "duration_seconds": {
+                    "description": "takes precedence over duration",
+                    "type": "integer"
+                },
+                "money_raised": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.GeoJSONFeature": {
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "$ref": "#/definitions/dto.GeoJSONGeometry"
+                },
+                "properties": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "dto.ApologyEmailRequest": {
+        "dto.GeoJSONGeometry": {
             "type": "object",
-            "required": [
-                "users"
-            ],
             "properties": {
-                "users": {
+                "coordinates": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "additionalProperties": {
-                            "type": "string"
+                        "type": "array",
+                        "items": {
+                            "type": "number"
                         }
                     }
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "dto.AuthErrorResponse": {
+        "dto.GetUsersResponse": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "error_message": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UserData"
+                    }
                 },
-                "errors": {},
                 "status_code": {
                     "type": "integer"
                 },
@@ -4995,245 +13560,396 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.BulkEmailRequest": {
+        "dto.GroupListResponse": {
             "type": "object",
-            "required": [
-                "content",
-                "subject",
-                "user_ids"
-            ],
             "properties": {
-                "content": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GroupResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GroupMemberResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
                     "type": "string"
                 },
-                "subject": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.GroupResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "user_ids": {
+                "creator_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "member_ids": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "dto.BuyCauseRequest": {
+        "dto.InviteListResponse": {
             "type": "object",
-            "required": [
-                "amount",
-                "cause_id"
-            ],
             "properties": {
-                "amount": {
-                    "type": "number"
+                "campaign_slug": {
+                    "type": "string"
                 },
-                "cause_id": {
+                "invites": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InviteResponse"
+                    }
+                }
+            }
+        },
+        "dto.InvitePreviewResponse": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "$ref": "#/definitions/dto.CampaignResponse"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
                     "type": "string"
                 }
             }
         },
-        "dto.CampaignLeaderboardEntry": {
+        "dto.InviteResponse": {
             "type": "object",
             "properties": {
-                "activity": {
+                "campaign_id": {
                     "type": "string"
                 },
-                "cover_image": {
+                "date_created": {
                     "type": "string"
                 },
-                "distance_covered": {
-                    "type": "number"
+                "email": {
+                    "type": "string"
                 },
-                "duration": {
+                "expires_at": {
                     "type": "string"
                 },
-                "full_name": {
+                "id": {
                     "type": "string"
                 },
-                "money_raised": {
-                    "type": "number"
+                "link": {
+                    "type": "string"
                 },
-                "user_id": {
+                "max_uses": {
+                    "type": "integer"
+                },
+                "revoked_at": {
                     "type": "string"
                 },
-                "username": {
+                "token": {
                     "type": "string"
+                },
+                "uses": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.CampaignLeaderboardResponse": {
+        "dto.JoinRequestListResponse": {
             "type": "object",
             "properties": {
                 "campaign_slug": {
                     "type": "string"
                 },
-                "leaderboard": {
+                "requests": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.CampaignLeaderboardEntry"
+                        "$ref": "#/definitions/dto.JoinRequestResponse"
                     }
                 }
             }
         },
-        "dto.CampaignListResponse": {
+        "dto.JoinRequestResponse": {
             "type": "object",
             "properties": {
-                "campaigns": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.CampaignResponse"
-                    }
+                "campaign_id": {
+                    "type": "string"
                 },
-                "limit": {
-                    "type": "integer"
+                "date_created": {
+                    "type": "string"
                 },
-                "page": {
-                    "type": "integer"
+                "decided_at": {
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "decided_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "dto.CampaignOwnerInfo": {
+        "dto.JoinTeamRequest": {
+            "type": "object",
+            "required": [
+                "invite_code"
+            ],
+            "properties": {
+                "invite_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JudgeResponse": {
             "type": "object",
             "properties": {
-                "full_name": {
+                "appointed_at": {
                     "type": "string"
                 },
-                "id": {
+                "appointed_by": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 },
                 "username": {
                     "type": "string"
+                },
+                "weight": {
+                    "type": "number"
                 }
             }
         },
-        "dto.CampaignResponse": {
+        "dto.JudgeScoreListResponse": {
             "type": "object",
             "properties": {
-                "accept_tac": {
-                    "type": "boolean"
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JudgeScoreResponse"
+                    }
+                }
+            }
+        },
+        "dto.JudgeScoreResponse": {
+            "type": "object",
+            "properties": {
+                "cause_id": {
+                    "type": "string"
                 },
-                "activity": {
+                "comment": {
                     "type": "string"
                 },
-                "condition": {
+                "judge_id": {
                     "type": "string"
                 },
-                "date_created": {
+                "scores": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "total": {
+                    "description": "weighted score out of 100",
+                    "type": "number"
+                },
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.JudgingCriterion": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "max_score": {
+                    "description": "judges rate it from 0 to this",
+                    "type": "number"
                 },
-                "date_updated": {
+                "name": {
                     "type": "string"
                 },
-                "description": {
+                "weight": {
+                    "description": "how much it counts against the other criteria",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.JudgingResultResponse": {
+            "type": "object",
+            "properties": {
+                "cause_id": {
                     "type": "string"
                 },
-                "distance_covered": {
+                "cause_name": {
+                    "type": "string"
+                },
+                "judge_score": {
+                    "description": "out of 100",
                     "type": "number"
                 },
-                "distance_to_cover": {
+                "prize": {
+                    "$ref": "#/definitions/dto.Prize"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "judge and vote scores blended, out of 100",
                     "type": "number"
                 },
-                "end_duration": {
+                "votes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.JudgingResultsResponse": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
                     "type": "string"
                 },
-                "goal": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JudgingResultResponse"
+                    }
+                },
+                "round_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JudgingRoundListResponse": {
+            "type": "object",
+            "properties": {
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JudgingRoundResponse"
+                    }
+                }
+            }
+        },
+        "dto.JudgingRoundResponse": {
+            "type": "object",
+            "properties": {
+                "challenge_id": {
                     "type": "string"
                 },
-                "id": {
+                "closed_at": {
                     "type": "string"
                 },
-                "location": {
+                "closes_at": {
                     "type": "string"
                 },
-                "members": {
-                    "type": "array",
-                    "items": {}
+                "final": {
+                    "type": "boolean"
                 },
-                "mode": {
+                "id": {
                     "type": "string"
                 },
-                "money_raised": {
-                    "type": "number"
+                "judges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JudgeResponse"
+                    }
                 },
                 "name": {
                     "type": "string"
                 },
-                "owner": {
-                    "$ref": "#/definitions/dto.CampaignOwnerInfo"
-                },
-                "slug": {
+                "opens_at": {
                     "type": "string"
                 },
-                "sponsors": {
+                "rubric": {
                     "type": "array",
-                    "items": {}
+                    "items": {
+                        "$ref": "#/definitions/dto.JudgingCriterion"
+                    }
                 },
-                "start_duration": {
+                "status": {
                     "type": "string"
                 },
-                "target_amount": {
-                    "type": "number"
-                },
-                "target_amount_per_km": {
+                "vote_share": {
                     "type": "number"
-                },
-                "workout_img": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.CampaignRunnerListResponse": {
-            "type": "object",
-            "properties": {
-                "limit": {
-                    "type": "integer"
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "runners": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.CampaignRunnerResponse"
-                    }
-                },
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "dto.CampaignRunnerResponse": {
+        "dto.LeaderboardEntry": {
             "type": "object",
             "properties": {
                 "activity": {
-                    "type": "string"
-                },
-                "campaign_id": {
+                    "description": "empty when the user logged more than one activity",
                     "type": "string"
                 },
                 "cover_image": {
                     "type": "string"
                 },
-                "date_joined": {
-                    "type": "string"
-                },
                 "distance_covered": {
                     "type": "number"
                 },
                 "duration": {
                     "type": "string"
                 },
-                "id": {
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "full_name": {
                     "type": "string"
                 },
                 "money_raised": {
                     "type": "number"
                 },
-                "runner_id": {
-                    "type": "string"
+                "rank": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "integer"
                 },
                 "user_id": {
                     "type": "string"
@@ -5243,225 +13959,211 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.CauseListResponse": {
+        "dto.LeaderboardResponse": {
             "type": "object",
             "properties": {
-                "causes": {
+                "runners": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.CauseResponse"
+                        "$ref": "#/definitions/dto.CauseRunnerResponse"
                     }
-                },
-                "limit": {
+                }
+            }
+        },
+        "dto.ListCommentsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
                     "type": "integer"
                 },
-                "page": {
+                "data": {},
+                "status_code": {
                     "type": "integer"
                 },
-                "total": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.CauseMemberInfo": {
+        "dto.ListPostsResponse": {
             "type": "object",
             "properties": {
-                "full_name": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "joined_at": {
-                    "type": "string"
+                "data": {},
+                "status_code": {
+                    "type": "integer"
                 },
-                "username": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.CauseOwnerInfo": {
+        "dto.LoginRequest": {
             "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
             "properties": {
-                "full_name": {
-                    "type": "string"
-                },
-                "id": {
+                "email": {
                     "type": "string"
                 },
-                "username": {
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "dto.CauseResponse": {
+        "dto.LoginResponse": {
             "type": "object",
             "properties": {
-                "activity": {
-                    "type": "string"
-                },
-                "amount_per_piece": {
-                    "type": "number"
-                },
-                "benefit_desc": {
-                    "type": "string"
-                },
-                "buyer_user": {
-                    "type": "string"
-                },
-                "challenge_id": {
-                    "type": "string"
-                },
-                "cost_to_launch": {
-                    "type": "string"
-                },
-                "date_created": {
-                    "type": "string"
-                },
-                "date_updated": {
-                    "type": "string"
-                },
-                "description": {
+                "error_message": {
                     "type": "string"
                 },
-                "distance_covered": {
-                    "type": "number"
-                },
-                "duration": {
+                "message": {
                     "type": "string"
                 },
-                "fund_amount": {
-                    "type": "number"
-                },
-                "fund_cause": {
-                    "type": "boolean"
-                },
-                "id": {
-                    "type": "string"
+                "status_code": {
+                    "type": "integer"
                 },
-                "is_commercial": {
+                "success": {
                     "type": "boolean"
                 },
-                "location": {
+                "token": {
                     "type": "string"
                 },
-                "members": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.CauseMemberInfo"
-                    }
-                },
-                "name": {
+                "user_email": {
                     "type": "string"
                 },
-                "owner": {
-                    "$ref": "#/definitions/dto.CauseOwnerInfo"
-                },
-                "problem": {
+                "user_id": {
                     "type": "string"
-                },
-                "product_description": {
+                }
+            }
+        },
+        "dto.LogoutResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
                     "type": "string"
                 },
-                "slug": {
-                    "type": "string"
+                "status_code": {
+                    "type": "integer"
                 },
-                "solution": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.MarkAllReadResponse": {
+            "type": "object",
+            "properties": {
+                "marked": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MediaGalleryResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "sponsors": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.CauseSponsorInfo"
+                        "$ref": "#/definitions/dto.MediaItemResponse"
                     }
                 },
-                "unit_price": {
-                    "type": "number"
-                },
-                "video_url": {
-                    "type": "string"
-                },
-                "who_idea_impact": {
+                "subject_id": {
                     "type": "string"
                 },
-                "willing_amount": {
-                    "type": "number"
-                },
-                "workout_img": {
+                "subject_kind": {
+                    "description": "challenge, cause or campaign",
                     "type": "string"
                 }
             }
         },
-        "dto.CauseRunnerResponse": {
+        "dto.MediaItemResponse": {
             "type": "object",
             "properties": {
-                "activity": {
+                "caption": {
                     "type": "string"
                 },
-                "cause_id": {
+                "content_type": {
                     "type": "string"
                 },
-                "cover_image": {
+                "created_at": {
                     "type": "string"
                 },
-                "date_joined": {
+                "id": {
                     "type": "string"
                 },
-                "distance_covered": {
-                    "type": "number"
-                },
-                "distance_to_cover": {
-                    "type": "number"
+                "position": {
+                    "type": "integer"
                 },
-                "duration": {
-                    "type": "string"
+                "size": {
+                    "description": "bytes",
+                    "type": "integer"
                 },
-                "id": {
+                "type": {
+                    "description": "image or video",
                     "type": "string"
                 },
-                "money_raised": {
-                    "type": "number"
-                },
-                "runner_id": {
+                "uploader_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "url": {
                     "type": "string"
                 },
-                "username": {
-                    "type": "string"
+                "video": {
+                    "$ref": "#/definitions/dto.MediaVideoResponse"
                 }
             }
         },
-        "dto.CauseSponsorInfo": {
+        "dto.MediaVideoResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "embed_url": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "html": {
+                    "description": "iframe to embed",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "YouTube, Vimeo or Dailymotion",
                     "type": "string"
                 },
-                "name": {
+                "provider_url": {
                     "type": "string"
                 },
-                "sponsored_at": {
+                "thumbnail_url": {
                     "type": "string"
                 },
-                "username": {
+                "video_id": {
                     "type": "string"
+                },
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.ChallengeListResponse": {
+        "dto.MessageListResponse": {
             "type": "object",
             "properties": {
-                "challenges": {
+                "limit": {
+                    "type": "integer"
+                },
+                "messages": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.ChallengeResponse"
+                        "$ref": "#/definitions/dto.ChatMessageResponse"
                     }
                 },
-                "limit": {
-                    "type": "integer"
-                },
                 "page": {
                     "type": "integer"
                 },
@@ -5470,197 +14172,202 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.ChallengeMemberInfo": {
+        "dto.MessageResponse": {
             "type": "object",
             "properties": {
-                "full_name": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "joined_at": {
+                "message": {
                     "type": "string"
                 },
-                "username": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.ChallengeOwnerInfo": {
+        "dto.MilestoneListResponse": {
             "type": "object",
             "properties": {
-                "full_name": {
+                "campaign_slug": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "distance_covered": {
+                    "type": "number"
                 },
-                "username": {
-                    "type": "string"
+                "milestones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MilestoneResponse"
+                    }
+                },
+                "money_raised": {
+                    "type": "number"
                 }
             }
         },
-        "dto.ChallengeResponse": {
+        "dto.MilestoneRequest": {
             "type": "object",
+            "required": [
+                "metric"
+            ],
             "properties": {
-                "cause_price": {
-                    "type": "array",
-                    "items": {}
-                },
-                "condition": {
-                    "type": "string"
+                "metric": {
+                    "type": "string",
+                    "enum": [
+                        "distance",
+                        "money"
+                    ]
                 },
-                "cover_image": {
-                    "type": "string"
+                "percent": {
+                    "description": "share of the campaign target",
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
                 },
-                "date_created": {
+                "value": {
+                    "description": "fixed km or amount, when percent is not set",
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.MilestoneResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string"
                 },
-                "date_updated": {
+                "label": {
                     "type": "string"
                 },
-                "description": {
+                "metric": {
                     "type": "string"
                 },
-                "distance_to_cover": {
+                "percent": {
                     "type": "number"
                 },
-                "end_duration": {
-                    "type": "string"
+                "reached": {
+                    "type": "boolean"
                 },
-                "goal": {
+                "reached_at": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "threshold": {
+                    "type": "number"
                 },
-                "location": {
-                    "type": "string"
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.NotificationListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "members": {
+                "notifications": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.ChallengeMemberInfo"
+                        "$ref": "#/definitions/dto.NotificationResponse"
                     }
                 },
-                "mode": {
+                "unread": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
                     "type": "string"
                 },
-                "name": {
+                "date_created": {
                     "type": "string"
                 },
-                "no_of_winner": {
-                    "type": "integer"
-                },
-                "owner": {
-                    "$ref": "#/definitions/dto.ChallengeOwnerInfo"
-                },
-                "slug": {
+                "id": {
                     "type": "string"
                 },
-                "sponsors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ChallengeSponsorInfo"
-                    }
-                },
-                "start_duration": {
+                "kind": {
                     "type": "string"
                 },
-                "target_amount": {
-                    "type": "number"
+                "link": {
+                    "type": "string"
                 },
-                "target_amount_per_km": {
-                    "type": "number"
+                "read": {
+                    "type": "boolean"
                 },
-                "video_url": {
+                "read_at": {
                     "type": "string"
                 },
-                "winning_price": {
-                    "type": "array",
-                    "items": {}
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "dto.ChallengeSponsorInfo": {
+        "dto.OverrideChallengeWinnersRequest": {
             "type": "object",
+            "required": [
+                "reason"
+            ],
             "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "sponsored_at": {
+                "reason": {
+                    "description": "recorded in the audit trail",
                     "type": "string"
                 },
-                "username": {
-                    "type": "string"
+                "user_ids": {
+                    "description": "winners in rank order, first place first",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.ChangePasswordRequest": {
+        "dto.ParticipateCampaignRequest": {
             "type": "object",
             "required": [
-                "new_password",
-                "old_password"
+                "activity"
             ],
             "properties": {
-                "new_password": {
-                    "type": "string",
-                    "minLength": 8
-                },
-                "old_password": {
+                "activity": {
                     "type": "string"
                 }
             }
         },
-        "dto.ChangePasswordResponse": {
+        "dto.ParticipateCampaignResponse": {
             "type": "object",
             "properties": {
-                "data": {},
-                "error_message": {
+                "message": {
                     "type": "string"
                 },
-                "message": {
+                "post_id": {
                     "type": "string"
                 },
-                "status_code": {
-                    "type": "integer"
+                "runner_id": {
+                    "type": "string"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "dto.ChatMessageResponse": {
+        "dto.PasswordResetConfirmRequest": {
             "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
             "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "group_id": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "sender_id": {
-                    "type": "string"
-                },
-                "sender_image_url": {
-                    "type": "string"
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8
                 },
-                "updated_at": {
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "dto.CommentResponse": {
+        "dto.PasswordResetConfirmResponse": {
             "type": "object",
             "properties": {
                 "data": {},
@@ -5675,932 +14382,950 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.CreateCampaignRequest": {
+        "dto.PasswordResetRequest": {
             "type": "object",
             "required": [
-                "name"
+                "email"
             ],
             "properties": {
-                "activity": {
-                    "type": "string",
-                    "enum": [
-                        "Walking",
-                        "Running",
-                        "Cycling"
-                    ]
-                },
-                "condition": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "distance_to_cover": {
-                    "type": "number"
-                },
-                "end_duration": {
-                    "type": "string"
-                },
-                "goal": {
-                    "type": "string"
-                },
-                "location": {
+                "email": {
                     "type": "string"
-                },
-                "mode": {
-                    "type": "string",
-                    "enum": [
-                        "Free",
-                        "Paid"
-                    ]
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "start_duration": {
+                }
+            }
+        },
+        "dto.PasswordResetResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
                     "type": "string"
                 },
-                "target_amount": {
-                    "type": "number"
-                },
-                "target_amount_per_km": {
-                    "type": "number"
-                },
-                "workout_img": {
-                    "type": "string"
+                "status_code": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.CreateCampaignRunnerRequest": {
+        "dto.PayCauseOrderRequest": {
             "type": "object",
             "required": [
-                "activity",
-                "campaign_id",
-                "user_id"
+                "payment_ref"
             ],
             "properties": {
-                "activity": {
+                "payment_ref": {
                     "type": "string"
-                },
-                "campaign_id": {
+                }
+            }
+        },
+        "dto.PostResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
                     "type": "string"
                 },
-                "distance_covered": {
-                    "type": "number"
+                "status_code": {
+                    "type": "integer"
                 },
-                "duration": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.Prize": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "currency": {
                     "type": "string"
                 },
-                "money_raised": {
-                    "type": "number"
+                "description": {
+                    "type": "string",
+                    "maxLength": 200
                 },
-                "user_id": {
-                    "type": "string"
+                "rank": {
+                    "type": "integer",
+                    "minimum": 1
                 }
             }
         },
-        "dto.CreateCauseRequest": {
+        "dto.RecordActivityRequest": {
             "type": "object",
             "required": [
-                "challenge_id",
-                "name"
+                "activity",
+                "cause_id",
+                "distance_covered",
+                "distance_to_cover"
             ],
             "properties": {
                 "activity": {
-                    "type": "string",
-                    "enum": [
-                        "Walking",
-                        "Running",
-                        "Cycling"
-                    ]
-                },
-                "amount_per_piece": {
-                    "type": "number"
-                },
-                "benefit_desc": {
-                    "type": "string"
-                },
-                "buyer_user": {
-                    "type": "string"
-                },
-                "challenge_id": {
-                    "type": "string"
-                },
-                "cost_to_launch": {
                     "type": "string"
                 },
-                "description": {
+                "cause_id": {
                     "type": "string"
                 },
-                "fund_amount": {
+                "distance_covered": {
                     "type": "number"
                 },
-                "fund_cause": {
-                    "type": "boolean"
-                },
-                "is_commercial": {
-                    "type": "boolean"
-                },
-                "location": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "problem": {
-                    "type": "string"
-                },
-                "product_description": {
-                    "type": "string"
-                },
-                "solution": {
-                    "type": "string"
-                },
-                "unit_price": {
+                "distance_to_cover": {
                     "type": "number"
                 },
-                "video_url": {
-                    "type": "string"
-                },
-                "who_idea_impact": {
+                "duration": {
+                    "description": "e.g. \"25:30\", \"1:02:03\", \"45 min\" or \"PT25M30S\"",
                     "type": "string"
                 },
-                "willing_amount": {
-                    "type": "number"
-                },
-                "workout_img": {
-                    "type": "string"
+                "duration_seconds": {
+                    "description": "takes precedence over duration",
+                    "type": "integer"
                 }
             }
         },
-        "dto.CreateChallengeRequest": {
+        "dto.RegistrationRequest": {
             "type": "object",
             "required": [
-                "name"
+                "email",
+                "first_name",
+                "height",
+                "last_name",
+                "password",
+                "username",
+                "weight"
             ],
             "properties": {
-                "condition": {
-                    "type": "string"
-                },
-                "cover_image": {
+                "email": {
                     "type": "string"
                 },
-                "description": {
+                "first_name": {
                     "type": "string"
                 },
-                "distance_to_cover": {
+                "height": {
                     "type": "number"
                 },
-                "end_duration": {
-                    "type": "string"
-                },
-                "goal": {
-                    "type": "string"
-                },
-                "location": {
+                "last_name": {
                     "type": "string"
                 },
-                "mode": {
-                    "type": "string",
-                    "enum": [
-                        "Free",
-                        "Paid"
-                    ]
-                },
-                "name": {
+                "password": {
                     "type": "string",
-                    "maxLength": 100
-                },
-                "no_of_winner": {
-                    "type": "integer"
+                    "minLength": 8
                 },
-                "start_duration": {
+                "username": {
                     "type": "string"
                 },
-                "target_amount": {
-                    "type": "number"
-                },
-                "target_amount_per_km": {
+                "weight": {
                     "type": "number"
-                },
-                "video_url": {
-                    "type": "string"
                 }
             }
         },
-        "dto.CreateCommentRequest": {
+        "dto.RegistrationResponse": {
             "type": "object",
-            "required": [
-                "content",
-                "target_id",
-                "target_type"
-            ],
             "properties": {
-                "content": {
+                "data": {
+                    "$ref": "#/definitions/dto.UserData"
+                },
+                "date_joined": {
                     "type": "string"
                 },
-                "parent_id": {
+                "error_message": {
                     "type": "string"
                 },
-                "target_id": {
+                "is_verified": {
+                    "type": "boolean"
+                },
+                "response": {
                     "type": "string"
                 },
-                "target_type": {
+                "status_code": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateGroupRequest": {
+        "dto.ReorderGalleryRequest": {
             "type": "object",
             "required": [
-                "name"
+                "item_ids"
             ],
             "properties": {
-                "image": {
-                    "type": "string"
-                },
-                "member_ids": {
+                "item_ids": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 20
                 }
             }
         },
-        "dto.CreatePostRequest": {
+        "dto.ResendOTPResponse": {
             "type": "object",
-            "required": [
-                "content",
-                "title"
-            ],
             "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "cover_image_url": {
+                "data": {},
+                "message": {
                     "type": "string"
                 },
-                "publish": {
+                "sent": {
                     "type": "boolean"
                 },
-                "title": {
-                    "type": "string",
-                    "maxLength": 200
-                }
-            }
-        },
-        "dto.CreateSponsorCampaignRequest": {
-            "type": "object",
-            "required": [
-                "amount_per_km",
-                "campaign_id",
-                "distance",
-                "sponsor_id"
-            ],
-            "properties": {
-                "amount_per_km": {
-                    "type": "number"
-                },
-                "brand_img": {
-                    "type": "string"
-                },
-                "campaign_id": {
-                    "type": "string"
-                },
-                "distance": {
-                    "type": "number"
-                },
-                "sponsor_id": {
-                    "type": "string"
-                },
-                "sponsor_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "video_url": {
-                    "type": "string"
+                "status_code": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.DeleteAccountResponse": {
+        "dto.SaveCampaignTemplateRequest": {
             "type": "object",
-            "properties": {
-                "detail": {
-                    "type": "string"
+            "properties": {
+                "name": {
+                    "description": "defaults to the campaign's name",
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "dto.ErrorResponse": {
+        "dto.ScoreCauseRequest": {
             "type": "object",
+            "required": [
+                "cause_id",
+                "scores"
+            ],
             "properties": {
-                "code": {
+                "cause_id": {
                     "type": "string"
                 },
-                "message": {
+                "comment": {
                     "type": "string"
                 },
-                "meta": {}
+                "scores": {
+                    "description": "rating per criterion name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
             }
         },
-        "dto.FinishActivityRequest": {
+        "dto.SendMessageRequest": {
             "type": "object",
             "required": [
-                "distance_covered",
-                "duration"
+                "content"
             ],
             "properties": {
-                "distance_covered": {
-                    "type": "number"
-                },
-                "duration": {
-                    "type": "string"
-                },
-                "money_raised": {
-                    "type": "number"
+                "content": {
+                    "type": "string",
+                    "maxLength": 1000
                 }
             }
         },
-        "dto.GetUsersResponse": {
+        "dto.SetCauseStockRequest": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
-                },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.UserData"
-                    }
-                },
-                "status_code": {
-                    "type": "integer"
-                },
-                "success": {
-                    "type": "boolean"
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "dto.GroupListResponse": {
+        "dto.SetMilestonesRequest": {
             "type": "object",
             "properties": {
-                "groups": {
+                "milestones": {
                     "type": "array",
+                    "maxItems": 20,
                     "items": {
-                        "$ref": "#/definitions/dto.GroupResponse"
+                        "$ref": "#/definitions/dto.MilestoneRequest"
                     }
-                },
+                }
+            }
+        },
+        "dto.SponsorCampaignListResponse": {
+            "type": "object",
+            "properties": {
                 "limit": {
                     "type": "integer"
                 },
                 "page": {
                     "type": "integer"
                 },
+                "sponsors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SponsorCampaignResponse"
+                    }
+                },
                 "total": {
                     "type": "integer"
                 }
             }
         },
-        "dto.GroupMemberResponse": {
+        "dto.SponsorCampaignRequest": {
             "type": "object",
+            "required": [
+                "amount_per_km",
+                "distance"
+            ],
             "properties": {
-                "message": {
+                "amount_per_km": {
+                    "type": "number"
+                },
+                "brand_img": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "distance": {
+                    "type": "number"
+                },
+                "video_url": {
+                    "type": "string"
                 }
             }
         },
-        "dto.GroupResponse": {
+        "dto.SponsorCampaignResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "amount_per_km": {
+                    "type": "number"
                 },
-                "creator_id": {
+                "brand_img": {
                     "type": "string"
                 },
-                "id": {
+                "campaign": {
                     "type": "string"
                 },
-                "image": {
+                "date_created": {
                     "type": "string"
                 },
-                "member_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "distance": {
+                    "type": "number"
                 },
-                "name": {
+                "id": {
                     "type": "string"
                 },
-                "slug": {
+                "paystack": {},
+                "sponsor": {
                     "type": "string"
                 },
-                "updated_at": {
+                "status": {
+                    "description": "pending until a template slot is confirmed",
                     "type": "string"
-                }
-            }
-        },
-        "dto.LeaderboardResponse": {
-            "type": "object",
-            "properties": {
-                "runners": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.CauseRunnerResponse"
-                    }
-                }
-            }
-        },
-        "dto.ListCommentsResponse": {
-            "type": "object",
-            "properties": {
-                "count": {
-                    "type": "integer"
                 },
-                "data": {},
-                "status_code": {
-                    "type": "integer"
+                "total_amount": {
+                    "type": "number"
                 },
-                "success": {
-                    "type": "boolean"
+                "video_url": {
+                    "type": "string"
                 }
             }
         },
-        "dto.ListPostsResponse": {
+        "dto.SponsorCauseRequest": {
             "type": "object",
+            "required": [
+                "amount_per_km",
+                "cause_id",
+                "distance"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
+                "amount_per_km": {
+                    "type": "number"
                 },
-                "data": {},
-                "status_code": {
-                    "type": "integer"
+                "brand_img": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "cause_id": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "video_url": {
+                    "type": "string"
                 }
             }
         },
-        "dto.LoginRequest": {
+        "dto.SponsorChallengeRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "amount_per_km",
+                "challenge_id",
+                "distance"
             ],
             "properties": {
-                "email": {
+                "amount_per_km": {
+                    "type": "number"
+                },
+                "brand_img": {
                     "type": "string"
                 },
-                "password": {
+                "challenge_id": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "video_url": {
                     "type": "string"
                 }
             }
         },
-        "dto.LoginResponse": {
+        "dto.SponsorObligationEntry": {
             "type": "object",
             "properties": {
-                "error_message": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
+                "amount": {
+                    "type": "number"
                 },
-                "status_code": {
-                    "type": "integer"
+                "amount_per_km": {
+                    "type": "number"
                 },
-                "success": {
-                    "type": "boolean"
+                "credited_distance": {
+                    "type": "number"
                 },
-                "token": {
-                    "type": "string"
+                "pledged_distance": {
+                    "type": "number"
                 },
-                "user_email": {
+                "sponsor_campaign_id": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "sponsors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UserSummary"
+                    }
                 }
             }
         },
-        "dto.LogoutResponse": {
+        "dto.SponsorObligationResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "earned": {
+                    "description": "owed for the km covered so far",
+                    "type": "number"
+                },
+                "pledged": {
+                    "description": "owed if the cause covers every pledged km",
+                    "type": "number"
+                },
+                "sponsor_id": {
                     "type": "string"
                 },
-                "status_code": {
-                    "type": "integer"
+                "sponsor_name": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "sponsorships": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.MessageListResponse": {
+        "dto.SponsorStatementListResponse": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer"
-                },
-                "messages": {
+                "statements": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.ChatMessageResponse"
+                        "$ref": "#/definitions/dto.SponsorStatementResponse"
                     }
                 },
-                "page": {
-                    "type": "integer"
-                },
-                "total": {
-                    "type": "integer"
+                "total_due": {
+                    "type": "number"
                 }
             }
         },
-        "dto.MessageResponse": {
+        "dto.SponsorStatementResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "amount_due": {
+                    "type": "number"
+                },
+                "cause_id": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "dto.ParticipateCampaignRequest": {
-            "type": "object",
-            "required": [
-                "activity"
-            ],
-            "properties": {
-                "activity": {
+                "distance_covered": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "pledged": {
+                    "type": "number"
+                },
+                "settled_at": {
+                    "type": "string"
+                },
+                "sponsor_id": {
                     "type": "string"
+                },
+                "sponsorships": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.ParticipateCampaignResponse": {
+        "dto.TeamLeaderboardEntry": {
             "type": "object",
             "properties": {
-                "message": {
+                "captain_id": {
                     "type": "string"
                 },
-                "post_id": {
+                "distance_covered": {
+                    "type": "number"
+                },
+                "duration": {
                     "type": "string"
                 },
-                "runner_id": {
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "member_count": {
+                    "type": "integer"
+                },
+                "money_raised": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "dto.PasswordResetConfirmRequest": {
-            "type": "object",
-            "required": [
-                "new_password",
-                "token"
-            ],
-            "properties": {
-                "new_password": {
-                    "type": "string",
-                    "minLength": 8
+                "rank": {
+                    "type": "integer"
                 },
-                "token": {
+                "team_id": {
                     "type": "string"
                 }
             }
         },
-        "dto.PasswordResetConfirmResponse": {
+        "dto.TeamLeaderboardResponse": {
             "type": "object",
             "properties": {
-                "data": {},
-                "message": {
+                "campaign_slug": {
                     "type": "string"
                 },
-                "status_code": {
-                    "type": "integer"
-                },
-                "success": {
-                    "type": "boolean"
+                "leaderboard": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TeamLeaderboardEntry"
+                    }
                 }
             }
         },
-        "dto.PasswordResetRequest": {
+        "dto.TeamListResponse": {
             "type": "object",
-            "required": [
-                "email"
-            ],
             "properties": {
-                "email": {
+                "campaign_slug": {
                     "type": "string"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TeamResponse"
+                    }
                 }
             }
         },
-        "dto.PasswordResetResponse": {
+        "dto.TeamMemberEntry": {
             "type": "object",
             "properties": {
-                "data": {},
-                "message": {
+                "full_name": {
                     "type": "string"
                 },
-                "status_code": {
-                    "type": "integer"
+                "user_id": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "dto.PostResponse": {
+        "dto.TeamResponse": {
             "type": "object",
             "properties": {
-                "data": {},
-                "message": {
+                "campaign_id": {
                     "type": "string"
                 },
-                "status_code": {
+                "captain_id": {
+                    "type": "string"
+                },
+                "date_created": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invite_code": {
+                    "type": "string"
+                },
+                "member_count": {
                     "type": "integer"
                 },
-                "success": {
-                    "type": "boolean"
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TeamMemberEntry"
+                    }
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "dto.RecordActivityRequest": {
+        "dto.TemplateSponsorSlotResponse": {
             "type": "object",
-            "required": [
-                "activity",
-                "cause_id",
-                "distance_covered",
-                "distance_to_cover",
-                "duration"
-            ],
             "properties": {
-                "activity": {
-                    "type": "string"
+                "amount_per_km": {
+                    "type": "number"
                 },
-                "cause_id": {
+                "brand_img": {
                     "type": "string"
                 },
-                "distance_covered": {
+                "distance": {
                     "type": "number"
                 },
-                "distance_to_cover": {
+                "sponsors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UserSummary"
+                    }
+                },
+                "total_amount": {
                     "type": "number"
                 },
-                "duration": {
+                "video_url": {
                     "type": "string"
                 }
             }
         },
-        "dto.RegistrationRequest": {
+        "dto.TrackSplit": {
             "type": "object",
-            "required": [
-                "email",
-                "first_name",
-                "height",
-                "last_name",
-                "password",
-                "username",
-                "weight"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "first_name": {
-                    "type": "string"
-                },
-                "height": {
+                "distance": {
+                    "description": "km",
                     "type": "number"
                 },
-                "last_name": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "elevation_gain": {
+                    "description": "meters",
+                    "type": "number"
                 },
-                "username": {
-                    "type": "string"
+                "index": {
+                    "type": "integer"
                 },
-                "weight": {
+                "seconds": {
+                    "description": "0 when the file has no timestamps",
                     "type": "number"
                 }
             }
         },
-        "dto.RegistrationResponse": {
+        "dto.TrackUploadResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/dto.UserData"
+                "distance": {
+                    "description": "km",
+                    "type": "number"
                 },
-                "date_joined": {
+                "duration": {
+                    "description": "moving time as stored on the runner",
                     "type": "string"
                 },
-                "error_message": {
-                    "type": "string"
+                "elapsed_time": {
+                    "description": "seconds",
+                    "type": "integer"
                 },
-                "is_verified": {
-                    "type": "boolean"
+                "elevation_gain": {
+                    "description": "meters",
+                    "type": "number"
                 },
-                "response": {
+                "format": {
+                    "description": "gpx or tcx",
                     "type": "string"
                 },
-                "status_code": {
+                "moving_time": {
+                    "description": "seconds",
                     "type": "integer"
                 },
-                "success": {
-                    "type": "boolean"
+                "route": {
+                    "description": "simplified route for map rendering",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.GeoJSONFeature"
+                        }
+                    ]
                 },
-                "token": {
+                "runner_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "splits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TrackSplit"
+                    }
+                },
+                "status": {
+                    "description": "accepted, or flagged when held for review",
+                    "type": "string"
+                },
+                "track_url": {
                     "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ActivityViolation"
+                    }
                 }
             }
         },
-        "dto.ResendOTPResponse": {
+        "dto.TransferCaptaincyRequest": {
             "type": "object",
+            "required": [
+                "user_id"
+            ],
             "properties": {
-                "data": {},
-                "message": {
+                "user_id": {
                     "type": "string"
-                },
-                "sent": {
-                    "type": "boolean"
-                },
-                "status_code": {
-                    "type": "integer"
                 }
             }
         },
-        "dto.SendMessageRequest": {
+        "dto.TransferOwnershipRequest": {
             "type": "object",
             "required": [
-                "content"
+                "user_id"
             ],
             "properties": {
-                "content": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateCampaignRequest": {
+            "type": "object",
+            "properties": {
+                "accept_tac": {
+                    "type": "boolean"
+                },
+                "activity": {
+                    "type": "string",
+                    "enum": [
+                        "Walking",
+                        "Running",
+                        "Cycling"
+                    ]
+                },
+                "condition": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance_to_cover": {
+                    "type": "number"
+                },
+                "end_duration": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "goal": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "Free",
+                        "Paid"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "start_duration": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "target_amount": {
+                    "type": "number"
+                },
+                "target_amount_per_km": {
+                    "type": "number"
+                },
+                "visibility": {
                     "type": "string",
-                    "maxLength": 1000
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ]
+                },
+                "workout_img": {
+                    "type": "string"
                 }
             }
         },
-        "dto.SponsorCampaignListResponse": {
+        "dto.UpdateCampaignRunnerRequest": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer"
+                "activity": {
+                    "type": "string"
                 },
-                "page": {
-                    "type": "integer"
+                "cover_image": {
+                    "type": "string"
                 },
-                "sponsors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.SponsorCampaignResponse"
-                    }
+                "distance_covered": {
+                    "type": "number"
                 },
-                "total": {
+                "duration": {
+                    "type": "string"
+                },
+                "duration_seconds": {
                     "type": "integer"
+                },
+                "money_raised": {
+                    "type": "number"
                 }
             }
         },
-        "dto.SponsorCampaignRequest": {
+        "dto.UpdateCampaignTemplateRequest": {
             "type": "object",
-            "required": [
-                "amount_per_km",
-                "distance"
-            ],
             "properties": {
-                "amount_per_km": {
-                    "type": "number"
-                },
-                "brand_img": {
-                    "type": "string"
+                "lead_hours": {
+                    "description": "create editions this long before they start; default 168",
+                    "type": "integer",
+                    "maximum": 2160,
+                    "minimum": 0
                 },
-                "distance": {
-                    "type": "number"
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "video_url": {
+                "next_starts_at": {
+                    "description": "start of the first scheduled edition",
                     "type": "string"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "weekly",
+                        "monthly"
+                    ]
                 }
             }
         },
-        "dto.SponsorCampaignResponse": {
+        "dto.UpdateCauseRequest": {
             "type": "object",
             "properties": {
-                "amount_per_km": {
+                "activity": {
+                    "type": "string",
+                    "enum": [
+                        "Walking",
+                        "Running",
+                        "Cycling"
+                    ]
+                },
+                "amount_per_piece": {
                     "type": "number"
                 },
-                "brand_img": {
+                "benefit_desc": {
                     "type": "string"
                 },
-                "campaign": {
+                "buyer_user": {
                     "type": "string"
                 },
-                "date_created": {
+                "cost_to_launch": {
                     "type": "string"
                 },
-                "distance": {
+                "description": {
+                    "type": "string"
+                },
+                "fund_amount": {
                     "type": "number"
                 },
-                "id": {
-                    "type": "string"
+                "fund_cause": {
+                    "type": "boolean"
                 },
-                "paystack": {},
-                "sponsor": {
+                "is_commercial": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "location": {
                     "type": "string"
                 },
-                "total_amount": {
-                    "type": "number"
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
                 },
-                "video_url": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "problem": {
                     "type": "string"
-                }
-            }
-        },
-        "dto.SponsorCauseRequest": {
-            "type": "object",
-            "required": [
-                "amount_per_km",
-                "cause_id",
-                "distance"
-            ],
-            "properties": {
-                "amount_per_km": {
-                    "type": "number"
                 },
-                "brand_img": {
+                "product_description": {
                     "type": "string"
                 },
-                "cause_id": {
+                "solution": {
                     "type": "string"
                 },
-                "distance": {
+                "unit_price": {
                     "type": "number"
                 },
                 "video_url": {
                     "type": "string"
-                }
-            }
-        },
-        "dto.SponsorChallengeRequest": {
-            "type": "object",
-            "required": [
-                "amount_per_km",
-                "challenge_id",
-                "distance"
-            ],
-            "properties": {
-                "amount_per_km": {
-                    "type": "number"
-                },
-                "brand_img": {
-                    "type": "string"
                 },
-                "challenge_id": {
+                "who_idea_impact": {
                     "type": "string"
                 },
-                "distance": {
+                "willing_amount": {
                     "type": "number"
                 },
-                "video_url": {
+                "workout_img": {
                     "type": "string"
                 }
             }
         },
-        "dto.UpdateCampaignRequest": {
+        "dto.UpdateChallengeRequest": {
             "type": "object",
             "properties": {
-                "accept_tac": {
-                    "type": "boolean"
-                },
-                "activity": {
-                    "type": "string",
-                    "enum": [
-                        "Walking",
-                        "Running",
-                        "Cycling"
-                    ]
+                "cause_price": {
+                    "description": "replaces every cause prize when sent",
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/dto.Prize"
+                    }
                 },
                 "condition": {
                     "type": "string"
                 },
+                "cover_image": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
@@ -6613,9 +15338,19 @@ const docTemplate = `{
                 "goal": {
                     "type": "string"
                 },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
                 "location": {
                     "type": "string"
                 },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
                 "mode": {
                     "type": "string",
                     "enum": [
@@ -6627,6 +15362,17 @@ const docTemplate = `{
                     "type": "string",
                     "maxLength": 100
                 },
+                "no_of_winner": {
+                    "type": "integer"
+                },
+                "ranking_criterion": {
+                    "type": "string",
+                    "enum": [
+                        "distance",
+                        "fastest",
+                        "money"
+                    ]
+                },
                 "start_duration": {
                     "type": "string"
                 },
@@ -6636,28 +15382,16 @@ const docTemplate = `{
                 "target_amount_per_km": {
                     "type": "number"
                 },
-                "workout_img": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.UpdateCampaignRunnerRequest": {
-            "type": "object",
-            "properties": {
-                "activity": {
-                    "type": "string"
-                },
-                "cover_image": {
-                    "type": "string"
-                },
-                "distance_covered": {
-                    "type": "number"
-                },
-                "duration": {
+                "video_url": {
                     "type": "string"
                 },
-                "money_raised": {
-                    "type": "number"
+                "winning_price": {
+                    "description": "replaces every prize when sent",
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/dto.Prize"
+                    }
                 }
             }
         },
@@ -6672,6 +15406,14 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UpdateGalleryCaptionRequest": {
+            "type": "object",
+            "properties": {
+                "caption": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.UpdateGroupRequest": {
             "type": "object",
             "properties": {
@@ -6820,6 +15562,20 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UserSummary": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.VerifyOTPRequest": {
             "type": "object",
             "required": [
@@ -6859,6 +15615,60 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.VoteResponse": {
+            "type": "object",
+            "properties": {
+                "cause_id": {
+                    "type": "string"
+                },
+                "round_id": {
+                    "type": "string"
+                },
+                "voted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WinnerAuditListResponse": {
+            "type": "object",
+            "properties": {
+                "audits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WinnerAuditResponse"
+                    }
+                }
+            }
+        },
+        "dto.WinnerAuditResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "previous": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "winners": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "handler.HealthResponse": {
             "type": "object",
             "properties": {
@@ -0,0 +1,255 @@
+package campaign
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/campaign/repo"
+	userModel "gopi.com/internal/domain/user/model"
+	userRepo "gopi.com/internal/domain/user/repo"
+	"gopi.com/internal/lib/email"
+)
+
+// WithCloseout enables close-out when a campaign achieves its goal or completes: standings and
+// sponsor obligations are frozen in resultRepo and a summary is emailed to the owner, members
+// and sponsors. Without it, achieved campaigns are still marked but nothing is frozen or sent.
+func WithCloseout(resultRepo repo.CampaignResultRepository, userRepo userRepo.UserRepository, emailService email.EmailServiceInterface) Option {
+	return func(s *CampaignService) {
+		s.resultRepo = resultRepo
+		s.userRepo = userRepo
+		s.emailService = emailService
+	}
+}
+
+// checkGoal marks the campaign achieved the first time its distance or funding goal is met and
+// closes it out. Failures are logged rather than returned because the activity or sponsorship
+// that triggered the check has already been saved.
+func (s *CampaignService) checkGoal(campaign *campaignModel.Campaign) {
+	if campaign.AchievedAt != nil || !campaign.GoalReached() {
+		return
+	}
+
+	now := time.Now()
+	ok, err := s.closeOut(campaign, func(r repo.Repositories) (bool, error) {
+		return r.Campaigns.MarkAchieved(campaign.ID, now)
+	})
+	if err != nil {
+		slog.Error("campaign close-out failed", "campaign_id", campaign.ID, "err", err)
+		return
+	}
+	if !ok {
+		return // already achieved, or not active
+	}
+	campaign.AchievedAt = &now
+	campaign.Status = campaignModel.CampaignStatusCompleted
+}
+
+// CloseOut freezes the final leaderboard, computes each sponsor's obligation and emails the
+// close-out summary. The closed stamp, standings and obligations are written in one unit of
+// work, so a failed close leaves the campaign open for a later retry. It runs at most once per
+// campaign; later calls are no-ops.
+func (s *CampaignService) CloseOut(campaign *campaignModel.Campaign) error {
+	_, err := s.closeOut(campaign, nil)
+	return err
+}
+
+// closeOut runs complete, the write that moves the campaign to completed, in the same unit of
+// work as the close-out. A failed close-out therefore also undoes the status change, and the
+// next goal check or scheduler tick tries both again. It reports whether complete won; a nil
+// complete always does.
+func (s *CampaignService) closeOut(campaign *campaignModel.Campaign, complete func(r repo.Repositories) (bool, error)) (bool, error) {
+	now := time.Now()
+	var (
+		completed   bool
+		closed      *campaignModel.Campaign
+		standings   []*campaignModel.CampaignStanding
+		obligations []*campaignModel.SponsorObligation
+	)
+	err := s.uow.Do(func(r repo.Repositories) error {
+		if complete != nil {
+			ok, err := complete(r)
+			if err != nil || !ok {
+				return err
+			}
+		}
+		completed = true
+		if s.resultRepo == nil {
+			return nil
+		}
+
+		ok, err := r.Campaigns.MarkClosed(campaign.ID, now)
+		if err != nil || !ok {
+			return err
+		}
+
+		// Reload so totals and members reflect every write that landed before the close.
+		closed, err = r.Campaigns.GetByID(campaign.ID)
+		if err != nil {
+			return err
+		}
+
+		runners, err := r.Runners.GetByCampaignID(campaign.ID)
+		if err != nil {
+			return err
+		}
+		standings = campaignModel.BuildStandings(campaign.ID, runners)
+		if err := r.Results.SaveStandings(campaign.ID, standings); err != nil {
+			return err
+		}
+
+		sponsorships, err := r.Sponsors.GetByCampaignID(campaign.ID)
+		if err != nil {
+			return err
+		}
+		for _, sponsorship := range sponsorships {
+			// A slot nobody confirmed was never pledged.
+			if !sponsorship.Confirmed() {
+				continue
+			}
+			obligations = append(obligations, sponsorship.Obligation(closed.DistanceCovered))
+		}
+		return r.Results.SaveObligations(campaign.ID, obligations)
+	})
+	if err != nil {
+		return false, err
+	}
+	if closed == nil {
+		return completed, nil
+	}
+	campaign.ClosedAt = &now
+
+	s.sendCloseoutEmails(closed, standings, obligations)
+	return completed, nil
+}
+
+// GetCampaignResults returns the standings and sponsor obligations frozen at close-out.
+// Both are empty until the campaign has been closed.
+func (s *CampaignService) GetCampaignResults(campaignID string) ([]*campaignModel.CampaignStanding, []*campaignModel.SponsorObligation, error) {
+	if s.resultRepo == nil {
+		return nil, nil, nil
+	}
+
+	standings, err := s.resultRepo.GetStandings(campaignID)
+	if err != nil {
+		return nil, nil, err
+	}
+	obligations, err := s.resultRepo.GetObligations(campaignID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return standings, obligations, nil
+}
+
+func (s *CampaignService) sendCloseoutEmails(campaign *campaignModel.Campaign, standings []*campaignModel.CampaignStanding, obligations []*campaignModel.SponsorObligation) {
+	if s.emailService == nil || s.userRepo == nil {
+		return
+	}
+
+	names := make(map[string]string)
+	lookup := func(userID string) *userModel.User {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil || user == nil {
+			return nil
+		}
+		names[userID] = user.Username
+		return user
+	}
+
+	// The owner and members share one summary.
+	seen := make(map[string]bool)
+	var recipients []string
+	for _, id := range append([]string{campaign.OwnerID}, campaignModel.UserIDs(campaign.Members)...) {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if user := lookup(id); user != nil && user.Email != "" {
+			recipients = append(recipients, user.Email)
+		}
+	}
+	for _, st := range standings {
+		if _, ok := names[st.UserID]; !ok {
+			lookup(st.UserID)
+		}
+	}
+
+	data := closeoutEmailData{Campaign: campaign, Standings: standings, Names: names}
+	subject := fmt.Sprintf("%s has closed", campaign.Name)
+	if len(recipients) > 0 {
+		body, err := renderCloseoutEmail(data)
+		if err == nil {
+			err = s.emailService.SendBulkEmail(recipients, subject, body)
+		}
+		if err != nil {
+			slog.Error("campaign close-out email failed", "campaign_id", campaign.ID, "err", err)
+		}
+	}
+
+	// Each sponsor gets the summary plus what their sponsorship owes.
+	for _, obligation := range obligations {
+		for _, id := range campaignModel.UserIDs(obligation.Sponsors) {
+			user := lookup(id)
+			if user == nil || user.Email == "" {
+				continue
+			}
+			data.Obligation = obligation
+			body, err := renderCloseoutEmail(data)
+			if err == nil {
+				err = s.emailService.SendBulkEmail([]string{user.Email}, subject, body)
+			}
+			if err != nil {
+				slog.Error("sponsor close-out email failed", "campaign_id", campaign.ID, "sponsor_id", id, "err", err)
+			}
+		}
+	}
+}
+
+type closeoutEmailData struct {
+	Campaign   *campaignModel.Campaign
+	Standings  []*campaignModel.CampaignStanding
+	Names      map[string]string
+	Obligation *campaignModel.SponsorObligation
+}
+
+var closeoutEmailTemplate = template.Must(template.New("closeout").Parse(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background-color: #28a745; color: white; padding: 20px; text-align: center;">
+		<h1>{{.Campaign.Name}} has closed</h1>
+	</div>
+	<div style="padding: 20px;">
+		{{if .Campaign.AchievedAt}}<p>The campaign reached its goal. Thank you to everyone who took part!</p>{{else}}<p>The campaign has come to an end. Thank you to everyone who took part!</p>{{end}}
+		<p><strong>Distance covered:</strong> {{printf "%.2f" .Campaign.DistanceCovered}} km{{if gt .Campaign.DistanceToCover 0.0}} of {{printf "%.2f" .Campaign.DistanceToCover}} km{{end}}</p>
+		<p><strong>Money raised:</strong> {{printf "%.2f" .Campaign.MoneyRaised}}{{if gt .Campaign.TargetAmount 0.0}} of {{printf "%.2f" .Campaign.TargetAmount}}{{end}}</p>
+		{{with .Obligation}}
+		<h2>Your sponsorship</h2>
+		<p>{{printf "%.2f" .CreditedDistance}} km credited (of {{printf "%.2f" .PledgedDistance}} km pledged) at {{printf "%.2f" .AmountPerKm}} per km.</p>
+		<p><strong>Amount due: {{printf "%.2f" .Amount}}</strong></p>
+		{{end}}
+		{{if .Standings}}
+		<h2>Final leaderboard</h2>
+		<table style="width: 100%; border-collapse: collapse;">
+			<tr><th align="left">#</th><th align="left">Runner</th><th align="right">Distance (km)</th></tr>
+			{{range .Standings}}<tr><td>{{.Rank}}</td><td>{{index $.Names .UserID}}</td><td align="right">{{printf "%.2f" .DistanceCovered}}</td></tr>
+			{{end}}
+		</table>
+		{{end}}
+	</div>
+	<div style="background-color: #f8f9fa; padding: 20px; text-align: center; color: #6c757d;">
+		<p>&copy; 2024 GoPadi. All rights reserved.</p>
+	</div>
+</body>
+</html>
+`))
+
+func renderCloseoutEmail(data closeoutEmailData) (string, error) {
+	var buf bytes.Buffer
+	if err := closeoutEmailTemplate.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
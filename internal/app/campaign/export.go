@@ -0,0 +1,252 @@
+package campaign
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	"gopi.com/internal/lib/xlsx"
+)
+
+var (
+	runnerExportColumns = []string{"runner_id", "user_id", "username", "name", "activity", "date_joined",
+		"distance_km", "duration", "duration_seconds", "money_raised", "elevation_gain_m"}
+	sponsorExportColumns = []string{"sponsorship_id", "sponsors", "pledged_distance_km", "amount_per_km",
+		"pledged_total", "credited_distance_km", "amount_owed"}
+)
+
+// CampaignExport is a campaign's runner roster and sponsor ledger. Everything that can fail is
+// loaded by ExportCampaign, so writing can stream straight to the client.
+type CampaignExport struct {
+	Campaign     *campaignModel.Campaign
+	runners      []*campaignModel.CampaignRunner
+	sponsorships []*campaignModel.SponsorCampaign
+	users        map[string]*userModel.User
+	obligations  map[string]*campaignModel.SponsorObligation // frozen at close-out, by sponsorship
+}
+
+// ExportCampaign loads the runners and sponsorships of a campaign for export, along with their
+// users and, once the campaign is closed, the sponsor obligations frozen at close-out.
+func (s *CampaignService) ExportCampaign(campaignID string) (*CampaignExport, error) {
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	runners, err := s.campaignRunnerRepo.GetByCampaignID(campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+	all, err := s.sponsorRepo.GetByCampaignID(campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+	var sponsorships []*campaignModel.SponsorCampaign
+	for _, sponsorship := range all {
+		if sponsorship.Confirmed() {
+			sponsorships = append(sponsorships, sponsorship)
+		}
+	}
+
+	export := &CampaignExport{
+		Campaign:     campaign,
+		runners:      runners,
+		sponsorships: sponsorships,
+		users:        make(map[string]*userModel.User),
+		obligations:  make(map[string]*campaignModel.SponsorObligation),
+	}
+
+	if s.userRepo != nil {
+		var ids []string
+		for _, runner := range runners {
+			ids = append(ids, runner.OwnerID)
+		}
+		for _, sponsorship := range sponsorships {
+			ids = append(ids, campaignModel.UserIDs(sponsorship.Sponsors)...)
+		}
+		users, err := s.userRepo.GetByIDs(uniqueIDs(ids))
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			export.users[user.ID] = user
+		}
+	}
+
+	if campaign.ClosedAt != nil && s.resultRepo != nil {
+		obligations, err := s.resultRepo.GetObligations(campaign.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, obligation := range obligations {
+			export.obligations[obligation.SponsorCampaignID] = obligation
+		}
+	}
+	return export, nil
+}
+
+// Write writes the export in format to w.
+func (e *CampaignExport) Write(w io.Writer, format campaignModel.ExportFormat) error {
+	switch format {
+	case campaignModel.ExportFormatCSV:
+		return e.WriteCSV(w)
+	case campaignModel.ExportFormatXLSX:
+		return e.WriteXLSX(w)
+	default:
+		return campaignModel.ErrInvalidExportFormat
+	}
+}
+
+// WriteCSV writes the runner roster followed by a blank line and the sponsor ledger, each
+// with its own header row.
+func (e *CampaignExport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	write := func(cells []interface{}) error {
+		record := make([]string, len(cells))
+		for i, cell := range cells {
+			record[i] = csvCell(cell)
+		}
+		return cw.Write(record)
+	}
+
+	if err := cw.Write(runnerExportColumns); err != nil {
+		return err
+	}
+	for _, runner := range e.runners {
+		if err := write(e.runnerRow(runner)); err != nil {
+			return err
+		}
+	}
+
+	if err := cw.Write(nil); err != nil {
+		return err
+	}
+	if err := cw.Write(sponsorExportColumns); err != nil {
+		return err
+	}
+	for _, sponsorship := range e.sponsorships {
+		if err := write(e.sponsorRow(sponsorship)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteXLSX writes a workbook with a Runners sheet and a Sponsor ledger sheet.
+func (e *CampaignExport) WriteXLSX(w io.Writer) error {
+	xw := xlsx.NewWriter(w)
+
+	if err := xw.NewSheet("Runners"); err != nil {
+		return err
+	}
+	if err := xw.WriteHeader(runnerExportColumns...); err != nil {
+		return err
+	}
+	for _, runner := range e.runners {
+		if err := xw.WriteRow(e.runnerRow(runner)...); err != nil {
+			return err
+		}
+	}
+
+	if err := xw.NewSheet("Sponsor ledger"); err != nil {
+		return err
+	}
+	if err := xw.WriteHeader(sponsorExportColumns...); err != nil {
+		return err
+	}
+	for _, sponsorship := range e.sponsorships {
+		if err := xw.WriteRow(e.sponsorRow(sponsorship)...); err != nil {
+			return err
+		}
+	}
+
+	return xw.Close()
+}
+
+func (e *CampaignExport) runnerRow(runner *campaignModel.CampaignRunner) []interface{} {
+	var username, name string
+	if user := e.users[runner.OwnerID]; user != nil {
+		username = user.Username
+		name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+	}
+	var joined interface{}
+	if !runner.DateJoined.IsZero() {
+		joined = runner.DateJoined.UTC()
+	}
+	return []interface{}{
+		runner.ID, runner.OwnerID, username, name, runner.Activity, joined,
+		roundTo(runner.DistanceCovered, 3), model.FormatDuration(runner.Duration), model.Seconds(runner.Duration),
+		roundTo(runner.MoneyRaised, 2), roundTo(runner.ElevationGain, 1),
+	}
+}
+
+// sponsorRow shows what the sponsorship owes: the obligation frozen at close-out once the
+// campaign is closed, and otherwise what it would owe for the distance covered so far.
+func (e *CampaignExport) sponsorRow(sponsorship *campaignModel.SponsorCampaign) []interface{} {
+	var sponsors []string
+	for _, id := range campaignModel.UserIDs(sponsorship.Sponsors) {
+		if user := e.users[id]; user != nil && user.Username != "" {
+			id = user.Username
+		}
+		sponsors = append(sponsors, id)
+	}
+
+	obligation, ok := e.obligations[sponsorship.ID]
+	if !ok {
+		obligation = sponsorship.Obligation(e.Campaign.DistanceCovered)
+	}
+	return []interface{}{
+		sponsorship.ID, strings.Join(sponsors, "; "), roundTo(sponsorship.Distance, 3), roundTo(sponsorship.AmountPerKm, 2),
+		roundTo(sponsorship.Distance*sponsorship.AmountPerKm, 2), roundTo(obligation.CreditedDistance, 3), obligation.Amount,
+	}
+}
+
+// uniqueIDs drops empty and repeated ids, keeping the first occurrence of each.
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	var unique []string
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+func roundTo(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
+
+// csvCell renders a cell for CSV. Text that a spreadsheet would run as a formula is prefixed
+// with a quote so names like "=HYPERLINK(...)" stay text.
+func csvCell(cell interface{}) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return ""
+	}
+}
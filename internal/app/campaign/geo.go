@@ -0,0 +1,23 @@
+package campaign
+
+import (
+	"context"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/lib/geo"
+)
+
+// WithGeocoder positions campaigns saved with a location but no coordinates. Without it,
+// campaigns only have a position when one is supplied.
+func WithGeocoder(geocoder geo.Geocoder) Option {
+	return func(s *CampaignService) {
+		s.geocoder = geocoder
+	}
+}
+
+// locate fills in the campaign's coordinates from its location when it has none.
+func (s *CampaignService) locate(campaign *campaignModel.Campaign) {
+	if campaign.Coordinates == nil {
+		campaign.Coordinates = geo.Locate(context.Background(), s.geocoder, campaign.Location)
+	}
+}
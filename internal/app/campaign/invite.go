@@ -0,0 +1,429 @@
+package campaign
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"html/template"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/campaign/repo"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+)
+
+// DefaultInviteTTL is how long an invite stays valid when no lifetime is given.
+const DefaultInviteTTL = 7 * 24 * time.Hour
+
+// WithInvites enables invite links, email invitations and join requests. Invite tokens are
+// signed with signingKey, and links point at linkBase with the token in the query string.
+// Invitations are emailed through the service configured by WithCloseout, if any.
+func WithInvites(inviteRepo repo.CampaignInviteRepository, requestRepo repo.CampaignJoinRequestRepository, signingKey, linkBase string) Option {
+	return func(s *CampaignService) {
+		s.inviteRepo = inviteRepo
+		s.requestRepo = requestRepo
+		s.inviteKey = []byte(signingKey)
+		s.inviteLinkBase = linkBase
+	}
+}
+
+// CreateInvite creates an invite link to the campaign valid for ttl and, when maxUses is
+// positive, for that many joins.
+func (s *CampaignService) CreateInvite(campaignID, creatorID string, maxUses int, ttl time.Duration) (*campaignModel.CampaignInvite, error) {
+	if s.inviteRepo == nil {
+		return nil, campaignModel.ErrInvitesNotEnabled
+	}
+
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if err := campaign.CanJoin(time.Now()); err != nil {
+		return nil, err
+	}
+
+	invite := newInvite(campaign.ID, creatorID, "", maxUses, ttl)
+	if err := s.inviteRepo.Create(invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// InviteByEmail creates a single-use invite for each address and emails it the link. Each
+// invite can only be redeemed by a user signed in with that address.
+func (s *CampaignService) InviteByEmail(campaignID, creatorID string, emails []string, ttl time.Duration) ([]*campaignModel.CampaignInvite, error) {
+	if s.inviteRepo == nil {
+		return nil, campaignModel.ErrInvitesNotEnabled
+	}
+
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if err := campaign.CanJoin(time.Now()); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var invites []*campaignModel.CampaignInvite
+	for _, address := range emails {
+		address = strings.ToLower(strings.TrimSpace(address))
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+
+		invite := newInvite(campaign.ID, creatorID, address, 1, ttl)
+		if err := s.inviteRepo.Create(invite); err != nil {
+			return invites, err
+		}
+		invites = append(invites, invite)
+		s.sendInviteEmail(campaign, invite)
+	}
+	return invites, nil
+}
+
+func newInvite(campaignID, creatorID, email string, maxUses int, ttl time.Duration) *campaignModel.CampaignInvite {
+	if ttl <= 0 {
+		ttl = DefaultInviteTTL
+	}
+	if maxUses < 0 {
+		maxUses = 0
+	}
+	now := time.Now()
+	return &campaignModel.CampaignInvite{
+		Base: model.Base{
+			ID:        id.New(),
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		CampaignID: campaignID,
+		CreatedBy:  creatorID,
+		Email:      email,
+		MaxUses:    maxUses,
+		// Stored to the second so the expiry in the token matches the row exactly.
+		ExpiresAt: now.Add(ttl).Truncate(time.Second),
+	}
+}
+
+func (s *CampaignService) ListInvites(campaignID string) ([]*campaignModel.CampaignInvite, error) {
+	if s.inviteRepo == nil {
+		return nil, campaignModel.ErrInvitesNotEnabled
+	}
+	return s.inviteRepo.ListByCampaign(campaignID)
+}
+
+// RevokeInvite stops an invite from being used again.
+func (s *CampaignService) RevokeInvite(campaignID, inviteID string) error {
+	if s.inviteRepo == nil {
+		return campaignModel.ErrInvitesNotEnabled
+	}
+
+	invite, err := s.inviteRepo.GetByID(inviteID)
+	if err != nil {
+		return err
+	}
+	if invite.CampaignID != campaignID {
+		return campaignModel.ErrInvalidInvite
+	}
+	return s.inviteRepo.Revoke(invite.ID, time.Now())
+}
+
+// InviteToken returns the signed token that redeems invite. Tokens have the form
+// "<invite id>.<expiry, unix seconds>.<hex HMAC-SHA256 of both>".
+func (s *CampaignService) InviteToken(invite *campaignModel.CampaignInvite) string {
+	expires := strconv.FormatInt(invite.ExpiresAt.Unix(), 10)
+	return invite.ID + "." + expires + "." + s.signInvite(invite.ID, expires)
+}
+
+// InviteLink returns the URL people follow to accept invite.
+func (s *CampaignService) InviteLink(invite *campaignModel.CampaignInvite) string {
+	return s.inviteLinkBase + "?token=" + url.QueryEscape(s.InviteToken(invite))
+}
+
+func (s *CampaignService) signInvite(inviteID, expires string) string {
+	mac := hmac.New(sha256.New, s.inviteKey)
+	mac.Write([]byte(inviteID))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// PreviewInvite checks token and returns the campaign it invites to, so people can see what
+// they are joining before accepting.
+func (s *CampaignService) PreviewInvite(token string) (*campaignModel.Campaign, *campaignModel.CampaignInvite, error) {
+	invite, err := s.inviteFromToken(token, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	campaign, err := s.campaignRepo.GetByID(invite.CampaignID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return campaign, invite, nil
+}
+
+// AcceptInvite redeems token for userID, whose account email is userEmail, and makes them a
+// campaign member. Members accepting again do not use up the invite.
+func (s *CampaignService) AcceptInvite(token, userID, userEmail string) (*campaignModel.Campaign, error) {
+	now := time.Now()
+	invite, err := s.inviteFromToken(token, now)
+	if err != nil {
+		return nil, err
+	}
+	if invite.Email != "" && !strings.EqualFold(invite.Email, strings.TrimSpace(userEmail)) {
+		return nil, campaignModel.ErrInviteEmailMismatch
+	}
+
+	campaign, err := s.campaignRepo.GetByID(invite.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+	if err := campaign.CanJoin(now); err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(func(r repo.Repositories) error {
+		isMember, err := r.Campaigns.IsMember(campaign.ID, userID)
+		if err != nil {
+			return err
+		}
+		if isMember {
+			return campaignModel.ErrAlreadyMember
+		}
+		ok, err := r.Invites.Redeem(invite.ID, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return campaignModel.ErrInviteUsedUp // used up or revoked since it was loaded
+		}
+		return r.Campaigns.AddMember(campaign.ID, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	campaign.Members = append(campaign.Members, campaignModel.UserSummary{ID: userID})
+	return campaign, nil
+}
+
+// inviteFromToken verifies the token's signature and expiry, then loads the invite and checks
+// it can still be used.
+func (s *CampaignService) inviteFromToken(token string, now time.Time) (*campaignModel.CampaignInvite, error) {
+	if s.inviteRepo == nil {
+		return nil, campaignModel.ErrInvitesNotEnabled
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, campaignModel.ErrInvalidInvite
+	}
+	inviteID, expires, signature := parts[0], parts[1], parts[2]
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return nil, campaignModel.ErrInvalidInvite
+	}
+	want, _ := hex.DecodeString(s.signInvite(inviteID, expires))
+	if !hmac.Equal(got, want) {
+		return nil, campaignModel.ErrInvalidInvite
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return nil, campaignModel.ErrInvalidInvite
+	}
+	if !now.Before(time.Unix(unix, 0)) {
+		return nil, campaignModel.ErrInviteExpired
+	}
+
+	invite, err := s.inviteRepo.GetByID(inviteID)
+	if err != nil {
+		return nil, err
+	}
+	if invite.ExpiresAt.Unix() != unix {
+		return nil, campaignModel.ErrInvalidInvite
+	}
+	if err := invite.Usable(now); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// RequestToJoin asks the owner of a private campaign to let userID in.
+func (s *CampaignService) RequestToJoin(campaignID, userID, message string) (*campaignModel.CampaignJoinRequest, error) {
+	if s.requestRepo == nil {
+		return nil, campaignModel.ErrInvitesNotEnabled
+	}
+
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if err := campaign.CanJoin(time.Now()); err != nil {
+		return nil, err
+	}
+	if campaign.HasMember(userID) {
+		return nil, campaignModel.ErrAlreadyMember
+	}
+	if campaign.CanJoinWithoutInvite(userID) == nil {
+		return nil, campaignModel.ErrJoinRequestNotNeeded
+	}
+
+	pending, err := s.requestRepo.GetPending(campaign.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, campaignModel.ErrJoinRequestPending
+	}
+
+	now := time.Now()
+	request := &campaignModel.CampaignJoinRequest{
+		Base: model.Base{
+			ID:        id.New(),
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		CampaignID: campaign.ID,
+		UserID:     userID,
+		Message:    strings.TrimSpace(message),
+		Status:     campaignModel.JoinRequestPending,
+	}
+	if err := s.requestRepo.Create(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ListJoinRequests returns the campaign's join requests oldest first, all of them when status is empty.
+func (s *CampaignService) ListJoinRequests(campaignID string, status campaignModel.JoinRequestStatus) ([]*campaignModel.CampaignJoinRequest, error) {
+	if s.requestRepo == nil {
+		return nil, campaignModel.ErrInvitesNotEnabled
+	}
+	return s.requestRepo.ListByCampaign(campaignID, status)
+}
+
+// ApproveJoinRequest accepts a pending request and makes its user a campaign member.
+func (s *CampaignService) ApproveJoinRequest(campaignID, requestID, deciderID string) (*campaignModel.CampaignJoinRequest, error) {
+	return s.decideJoinRequest(campaignID, requestID, deciderID, campaignModel.JoinRequestApproved)
+}
+
+// RejectJoinRequest turns down a pending request.
+func (s *CampaignService) RejectJoinRequest(campaignID, requestID, deciderID string) (*campaignModel.CampaignJoinRequest, error) {
+	return s.decideJoinRequest(campaignID, requestID, deciderID, campaignModel.JoinRequestRejected)
+}
+
+func (s *CampaignService) decideJoinRequest(campaignID, requestID, deciderID string, status campaignModel.JoinRequestStatus) (*campaignModel.CampaignJoinRequest, error) {
+	if s.requestRepo == nil {
+		return nil, campaignModel.ErrInvitesNotEnabled
+	}
+
+	request, err := s.requestRepo.GetByID(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.CampaignID != campaignID {
+		return nil, campaignModel.ErrJoinRequestNotFound
+	}
+	if request.Status != campaignModel.JoinRequestPending {
+		return nil, campaignModel.ErrJoinRequestDecided
+	}
+
+	now := time.Now()
+	if status == campaignModel.JoinRequestApproved {
+		campaign, err := s.campaignRepo.GetByID(campaignID)
+		if err != nil {
+			return nil, err
+		}
+		if err := campaign.CanJoin(now); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.uow.Do(func(r repo.Repositories) error {
+		ok, err := r.Requests.Decide(request.ID, status, deciderID, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return campaignModel.ErrJoinRequestDecided
+		}
+		if status != campaignModel.JoinRequestApproved {
+			return nil
+		}
+		isMember, err := r.Campaigns.IsMember(campaignID, request.UserID)
+		if err != nil || isMember {
+			return err
+		}
+		return r.Campaigns.AddMember(campaignID, request.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request.Status, request.DecidedBy, request.DecidedAt, request.UpdatedAt = status, deciderID, &now, now
+	return request, nil
+}
+
+func (s *CampaignService) sendInviteEmail(campaign *campaignModel.Campaign, invite *campaignModel.CampaignInvite) {
+	if s.emailService == nil {
+		return
+	}
+
+	data := inviteEmailData{Campaign: campaign, Link: s.InviteLink(invite), ExpiresAt: invite.ExpiresAt}
+	if s.userRepo != nil {
+		if owner, err := s.userRepo.GetByID(campaign.OwnerID); err == nil && owner != nil {
+			data.Inviter = owner.Username
+		}
+	}
+
+	body, err := renderInviteEmail(data)
+	if err == nil {
+		err = s.emailService.SendBulkEmail([]string{invite.Email}, "You're invited to join "+campaign.Name, body)
+	}
+	if err != nil {
+		slog.Error("campaign invite email failed", "campaign_id", campaign.ID, "invite_id", invite.ID, "err", err)
+	}
+}
+
+type inviteEmailData struct {
+	Campaign  *campaignModel.Campaign
+	Inviter   string
+	Link      string
+	ExpiresAt time.Time
+}
+
+var inviteEmailTemplate = template.Must(template.New("invite").Parse(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background-color: #28a745; color: white; padding: 20px; text-align: center;">
+		<h1>You're invited to {{.Campaign.Name}}</h1>
+	</div>
+	<div style="padding: 20px;">
+		<p>{{if .Inviter}}{{.Inviter}} has invited you{{else}}You have been invited{{end}} to join the {{.Campaign.Name}} campaign on GoPadi.</p>
+		{{if .Campaign.Description}}<p>{{.Campaign.Description}}</p>{{end}}
+		<p style="text-align: center; margin: 30px 0;">
+			<a href="{{.Link}}" style="background-color: #28a745; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px;">Join the campaign</a>
+		</p>
+		<p>This invitation is for you only and expires on {{.ExpiresAt.Format "2 January 2006"}}.</p>
+	</div>
+	<div style="background-color: #f8f9fa; padding: 20px; text-align: center; color: #6c757d;">
+		<p>&copy; 2024 GoPadi. All rights reserved.</p>
+	</div>
+</body>
+</html>
+`))
+
+func renderInviteEmail(data inviteEmailData) (string, error) {
+	var buf bytes.Buffer
+	if err := inviteEmailTemplate.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
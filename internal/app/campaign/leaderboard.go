@@ -0,0 +1,79 @@
+package campaign
+
+import (
+	"strings"
+	"time"
+
+	"gopi.com/internal/lib/leaderboard"
+)
+
+// WithLeaderboards serves campaign leaderboards from l, which may keep them in Redis. Without
+// it every query ranks straight from the database.
+func WithLeaderboards(l *leaderboard.Leaderboards) Option {
+	return func(s *CampaignService) {
+		s.leaderboards = l
+	}
+}
+
+// Leaderboard ranks the campaign's runners. All-time boards use the runner totals; windowed
+// boards need the run log kept by WithAntiCheat and return leaderboard.ErrWindowUnavailable
+// without it.
+func (s *CampaignService) Leaderboard(campaignSlug string, q leaderboard.Query) (*leaderboard.Standings, error) {
+	campaign, err := s.campaignRepo.GetBySlug(campaignSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := leaderboard.Scope{Kind: leaderboard.ScopeCampaign, ID: campaign.ID}
+	return s.leaderboards.Standings(scope, q, func(since time.Time, activity string) ([]*leaderboard.Run, error) {
+		if since.IsZero() {
+			return s.runnerTotals(campaign.ID, activity)
+		}
+		if s.runRepo == nil {
+			return nil, leaderboard.ErrWindowUnavailable
+		}
+
+		logged, err := s.runRepo.ListForLeaderboard(campaign.ID, since, activity)
+		if err != nil {
+			return nil, err
+		}
+		runs := make([]*leaderboard.Run, 0, len(logged))
+		for _, run := range logged {
+			runs = append(runs, &leaderboard.Run{
+				UserID:      run.OwnerID,
+				Activity:    run.Activity,
+				Distance:    run.Distance,
+				Duration:    run.Duration,
+				MoneyRaised: run.MoneyRaised,
+			})
+		}
+		return runs, nil
+	})
+}
+
+func (s *CampaignService) runnerTotals(campaignID, activity string) ([]*leaderboard.Run, error) {
+	runners, err := s.campaignRunnerRepo.GetByCampaignID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]*leaderboard.Run, 0, len(runners))
+	for _, runner := range runners {
+		if activity != "" && !strings.EqualFold(runner.Activity, activity) {
+			continue
+		}
+		runs = append(runs, &leaderboard.Run{
+			UserID:      runner.OwnerID,
+			Activity:    runner.Activity,
+			Distance:    runner.DistanceCovered,
+			Duration:    runner.Duration,
+			MoneyRaised: runner.MoneyRaised,
+			CoverImage:  runner.CoverImage,
+		})
+	}
+	return runs, nil
+}
+
+// refreshBoards drops the stored leaderboards of a campaign after its runs change.
+func (s *CampaignService) refreshBoards(campaignID string) {
+	s.leaderboards.Invalidate(leaderboard.Scope{Kind: leaderboard.ScopeCampaign, ID: campaignID})
+}
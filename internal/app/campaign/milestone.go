@@ -0,0 +1,253 @@
+package campaign
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"sort"
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/campaign/repo"
+	notificationModel "gopi.com/internal/domain/notification/model"
+	notificationRepo "gopi.com/internal/domain/notification/repo"
+	userRepo "gopi.com/internal/domain/user/repo"
+	"gopi.com/internal/lib/email"
+	"gopi.com/internal/lib/id"
+)
+
+// WithMilestones enables progress milestones. Each milestone fires once, the first time a run
+// or sponsorship takes the campaign past it, and tells the owner, members and sponsors in the
+// app and by email. Without it, milestones cannot be configured.
+func WithMilestones(milestoneRepo repo.CampaignMilestoneRepository, notifications notificationRepo.NotificationRepository, userRepo userRepo.UserRepository, emailService email.EmailServiceInterface) Option {
+	return func(s *CampaignService) {
+		s.milestoneRepo = milestoneRepo
+		s.notificationRepo = notifications
+		s.userRepo = userRepo
+		s.emailService = emailService
+	}
+}
+
+// ListMilestones returns the campaign's milestones in the order they are reached.
+func (s *CampaignService) ListMilestones(campaign *campaignModel.Campaign) ([]*campaignModel.CampaignMilestone, error) {
+	if s.milestoneRepo == nil {
+		return nil, campaignModel.ErrMilestonesNotEnabled
+	}
+
+	milestones, err := s.milestoneRepo.ListByCampaign(campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+	sortMilestones(campaign, milestones)
+	return milestones, nil
+}
+
+// SetMilestones replaces the campaign's milestones that have not been reached yet. Reached
+// milestones are kept so they never fire again, and new ones the campaign has already passed
+// are recorded as reached without notifying anyone.
+func (s *CampaignService) SetMilestones(campaignID string, milestones []*campaignModel.CampaignMilestone) ([]*campaignModel.CampaignMilestone, error) {
+	if s.milestoneRepo == nil {
+		return nil, campaignModel.ErrMilestonesNotEnabled
+	}
+	if len(milestones) > campaignModel.MaxMilestones {
+		return nil, campaignModel.ErrTooManyMilestones
+	}
+
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.milestoneRepo.ListByCampaign(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	var kept []*campaignModel.CampaignMilestone
+	for _, milestone := range existing {
+		if milestone.ReachedAt != nil {
+			kept = append(kept, milestone)
+		}
+	}
+
+	now := time.Now()
+	var pending []*campaignModel.CampaignMilestone
+	for _, milestone := range milestones {
+		if err := milestone.Validate(); err != nil {
+			return nil, err
+		}
+		if containsMilestone(kept, milestone) || containsMilestone(pending, milestone) {
+			continue
+		}
+		milestone.ID, milestone.CampaignID = id.New(), campaignID
+		milestone.CreatedAt, milestone.UpdatedAt = now, now
+		milestone.ReachedAt = nil
+		if milestone.ReachedBy(campaign) {
+			milestone.ReachedAt = &now
+		}
+		pending = append(pending, milestone)
+	}
+	if len(kept)+len(pending) > campaignModel.MaxMilestones {
+		return nil, campaignModel.ErrTooManyMilestones
+	}
+
+	if err := s.milestoneRepo.ReplacePending(campaignID, pending); err != nil {
+		return nil, err
+	}
+	all := append(kept, pending...)
+	sortMilestones(campaign, all)
+	return all, nil
+}
+
+func containsMilestone(milestones []*campaignModel.CampaignMilestone, m *campaignModel.CampaignMilestone) bool {
+	for _, milestone := range milestones {
+		if milestone.Same(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortMilestones orders milestones by metric, then by how far into the campaign they are.
+func sortMilestones(campaign *campaignModel.Campaign, milestones []*campaignModel.CampaignMilestone) {
+	sort.SliceStable(milestones, func(i, j int) bool {
+		if milestones[i].Metric != milestones[j].Metric {
+			return milestones[i].Metric < milestones[j].Metric
+		}
+		return milestones[i].Threshold(campaign) < milestones[j].Threshold(campaign)
+	})
+}
+
+// checkMilestones fires the milestones the campaign's totals have just passed. The campaign must
+// carry the totals read back after the write that moved them. A milestone fires for whichever
+// caller stamps it first, so concurrent runs crossing it together notify once. Failures are
+// logged rather than returned because the triggering write has already been saved.
+func (s *CampaignService) checkMilestones(campaign *campaignModel.Campaign) {
+	if s.milestoneRepo == nil {
+		return
+	}
+
+	milestones, err := s.milestoneRepo.ListByCampaign(campaign.ID)
+	if err != nil {
+		slog.Error("list campaign milestones failed", "campaign_id", campaign.ID, "err", err)
+		return
+	}
+	sortMilestones(campaign, milestones)
+
+	now := time.Now()
+	for _, milestone := range milestones {
+		if milestone.ReachedAt != nil || !milestone.ReachedBy(campaign) {
+			continue
+		}
+		ok, err := s.milestoneRepo.MarkReached(milestone.ID, now)
+		if err != nil {
+			slog.Error("mark campaign milestone reached failed", "campaign_id", campaign.ID, "milestone_id", milestone.ID, "err", err)
+			continue
+		}
+		if !ok {
+			continue // fired by a concurrent write
+		}
+		milestone.ReachedAt = &now
+		s.notifyMilestone(campaign, milestone)
+	}
+}
+
+// notifyMilestone tells the owner, members and sponsors that the campaign reached a milestone.
+func (s *CampaignService) notifyMilestone(campaign *campaignModel.Campaign, milestone *campaignModel.CampaignMilestone) {
+	recipients := s.milestoneRecipients(campaign)
+	title := fmt.Sprintf("%s reached %s", campaign.Name, milestone.Label())
+	body := fmt.Sprintf("%s has covered %.2f km and raised %.2f so far.", campaign.Name, campaign.DistanceCovered, campaign.MoneyRaised)
+
+	if s.notificationRepo != nil {
+		notifications := make([]*notificationModel.Notification, 0, len(recipients))
+		for _, userID := range recipients {
+			notifications = append(notifications, &notificationModel.Notification{
+				UserID:   userID,
+				Kind:     notificationModel.KindCampaignMilestone,
+				SourceID: milestone.ID,
+				Title:    title,
+				Body:     body,
+				Link:     "/campaigns/" + campaign.Slug,
+			})
+		}
+		if err := s.notificationRepo.CreateBatch(notifications); err != nil {
+			slog.Error("campaign milestone notifications failed", "campaign_id", campaign.ID, "milestone_id", milestone.ID, "err", err)
+		}
+	}
+
+	if s.emailService == nil || s.userRepo == nil {
+		return
+	}
+	var emails []string
+	for _, userID := range recipients {
+		if user, err := s.userRepo.GetByID(userID); err == nil && user != nil && user.Email != "" {
+			emails = append(emails, user.Email)
+		}
+	}
+	if len(emails) == 0 {
+		return
+	}
+	html, err := renderMilestoneEmail(milestoneEmailData{Campaign: campaign, Milestone: milestone.Label()})
+	if err == nil {
+		err = s.emailService.SendBulkEmail(emails, title, html)
+	}
+	if err != nil {
+		slog.Error("campaign milestone email failed", "campaign_id", campaign.ID, "milestone_id", milestone.ID, "err", err)
+	}
+}
+
+// milestoneRecipients returns the owner, members and sponsors of the campaign, each once.
+func (s *CampaignService) milestoneRecipients(campaign *campaignModel.Campaign) []string {
+	userIDs := append([]string{campaign.OwnerID}, campaignModel.UserIDs(campaign.Members)...)
+	userIDs = append(userIDs, campaignModel.UserIDs(campaign.Sponsors)...)
+	if sponsorships, err := s.sponsorRepo.GetByCampaignID(campaign.ID); err == nil {
+		for _, sponsorship := range sponsorships {
+			if sponsorship.Confirmed() {
+				userIDs = append(userIDs, campaignModel.UserIDs(sponsorship.Sponsors)...)
+			}
+		}
+	} else {
+		slog.Error("list campaign sponsorships failed", "campaign_id", campaign.ID, "err", err)
+	}
+
+	seen := make(map[string]bool)
+	var recipients []string
+	for _, userID := range userIDs {
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		recipients = append(recipients, userID)
+	}
+	return recipients
+}
+
+type milestoneEmailData struct {
+	Campaign  *campaignModel.Campaign
+	Milestone string
+}
+
+var milestoneEmailTemplate = template.Must(template.New("milestone").Parse(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background-color: #28a745; color: white; padding: 20px; text-align: center;">
+		<h1>{{.Campaign.Name}} reached {{.Milestone}}</h1>
+	</div>
+	<div style="padding: 20px;">
+		<p>Great progress! {{.Campaign.Name}} just reached {{.Milestone}}.</p>
+		<p><strong>Distance covered:</strong> {{printf "%.2f" .Campaign.DistanceCovered}} km{{if gt .Campaign.DistanceToCover 0.0}} of {{printf "%.2f" .Campaign.DistanceToCover}} km{{end}}</p>
+		<p><strong>Money raised:</strong> {{printf "%.2f" .Campaign.MoneyRaised}}{{if gt .Campaign.TargetAmount 0.0}} of {{printf "%.2f" .Campaign.TargetAmount}}{{end}}</p>
+	</div>
+	<div style="background-color: #f8f9fa; padding: 20px; text-align: center; color: #6c757d;">
+		<p>&copy; 2024 GoPadi. All rights reserved.</p>
+	</div>
+</body>
+</html>
+`))
+
+func renderMilestoneEmail(data milestoneEmailData) (string, error) {
+	var buf bytes.Buffer
+	if err := milestoneEmailTemplate.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
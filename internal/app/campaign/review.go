@@ -0,0 +1,153 @@
+package campaign
+
+import (
+	"fmt"
+	"time"
+
+	activityModel "gopi.com/internal/domain/activity/model"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/campaign/repo"
+	trackModel "gopi.com/internal/domain/track/model"
+)
+
+// WithAntiCheat checks every recorded run against rules. Runs are logged in runRepo; those that
+// break a rule are held out of runner and campaign totals until staff approve them. Without it,
+// runs are credited as reported.
+func WithAntiCheat(runRepo repo.CampaignRunRepository, rules *activityModel.Rules) Option {
+	return func(s *CampaignService) {
+		s.runRepo = runRepo
+		s.rules = rules
+	}
+}
+
+// checkRun returns the rules run breaks. It is a no-op when anti-cheat is disabled.
+func (s *CampaignService) checkRun(run *campaignModel.CampaignRun, track *trackModel.Track) ([]activityModel.Violation, error) {
+	if s.rules == nil || s.runRepo == nil || run.Distance <= 0 {
+		return nil, nil
+	}
+
+	today, err := s.runRepo.SumDistanceSince(run.OwnerID, activityModel.StartOfDay(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	return s.rules.Check(activityModel.Run{
+		Activity:      run.Activity,
+		Distance:      run.Distance,
+		Duration:      run.Duration,
+		DistanceToday: today,
+		Track:         track,
+	}), nil
+}
+
+// holdRun saves run as flagged. Callers report it as held for review once the write commits.
+func holdRun(r repo.Repositories, run *campaignModel.CampaignRun, violations []activityModel.Violation) error {
+	run.Status = activityModel.ReviewStatusFlagged
+	run.Violations = violations
+	return r.Runs.Create(run)
+}
+
+// logRun records a credited run so it counts towards the owner's daily distance.
+func (s *CampaignService) logRun(r repo.Repositories, run *campaignModel.CampaignRun) error {
+	if s.rules == nil || r.Runs == nil || run.Distance <= 0 {
+		return nil
+	}
+	run.Status = activityModel.ReviewStatusAccepted
+	return r.Runs.Create(run)
+}
+
+// creditRun adds a run to its runner and campaign totals.
+func creditRun(r repo.Repositories, run *campaignModel.CampaignRun) error {
+	if err := r.Runners.IncrementProgress(run.RunnerID, run.Distance, run.MoneyRaised, run.Duration); err != nil {
+		return err
+	}
+	if run.TrackURL != "" {
+		if err := r.Runners.AttachTrack(run.RunnerID, run.TrackURL, run.ElevationGain); err != nil {
+			return err
+		}
+	}
+	return r.Campaigns.IncrementTotals(run.CampaignID, run.Distance, run.MoneyRaised)
+}
+
+// ListFlaggedRuns returns the review queue, oldest first.
+func (s *CampaignService) ListFlaggedRuns(limit, offset int) ([]*campaignModel.CampaignRun, error) {
+	if s.runRepo == nil {
+		return nil, nil
+	}
+	return s.runRepo.ListByStatus(activityModel.ReviewStatusFlagged, limit, offset)
+}
+
+// GetRunByID returns a logged run.
+func (s *CampaignService) GetRunByID(runID string) (*campaignModel.CampaignRun, error) {
+	if s.runRepo == nil {
+		return nil, fmt.Errorf("run %s: anti-cheat is not enabled", runID)
+	}
+	return s.runRepo.GetByID(runID)
+}
+
+// ReviewRun approves or rejects a flagged run. Approval credits the run to its runner and
+// campaign exactly as if it had passed the checks when it was recorded, so it is refused with
+// ErrInvalidCampaignState once the campaign no longer accepts activity or has been closed out;
+// such runs can still be rejected.
+func (s *CampaignService) ReviewRun(runID, reviewerID string, approve bool) (*campaignModel.CampaignRun, error) {
+	if s.runRepo == nil {
+		return nil, fmt.Errorf("%w: anti-cheat is not enabled", activityModel.ErrNotFlagged)
+	}
+
+	run, err := s.runRepo.GetByID(runID)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status != activityModel.ReviewStatusFlagged {
+		return nil, activityModel.ErrNotFlagged
+	}
+
+	now := time.Now()
+	status := activityModel.ReviewStatusRejected
+	if approve {
+		status = activityModel.ReviewStatusApproved
+	}
+
+	var campaign *campaignModel.Campaign
+	err = s.uow.Do(func(r repo.Repositories) error {
+		if approve {
+			// Crediting after close-out would move totals away from the frozen standings.
+			current, err := r.Campaigns.GetByID(run.CampaignID)
+			if err != nil {
+				return err
+			}
+			if current.ClosedAt != nil {
+				return fmt.Errorf("%w: campaign has been closed out", campaignModel.ErrInvalidCampaignState)
+			}
+			if err := current.CanRecordActivity(now); err != nil {
+				return err
+			}
+		}
+
+		ok, err := r.Runs.Review(runID, status, reviewerID, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return activityModel.ErrNotFlagged // reviewed concurrently
+		}
+		if !approve {
+			return nil
+		}
+		if err := creditRun(r, run); err != nil {
+			return err
+		}
+		campaign, err = r.Campaigns.GetByID(run.CampaignID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	run.Status, run.ReviewedBy, run.ReviewedAt = status, reviewerID, &now
+	if campaign != nil {
+		s.refreshBoards(campaign.ID)
+		s.checkMilestones(campaign)
+		s.checkGoal(campaign)
+	}
+	return run, nil
+}
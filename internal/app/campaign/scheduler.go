@@ -0,0 +1,57 @@
+package campaign
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Scheduler periodically applies time-driven campaign state transitions and creates the
+// editions of recurring campaign templates.
+type Scheduler struct {
+	service  *CampaignService
+	interval time.Duration
+}
+
+func NewScheduler(service *CampaignService, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{service: service, interval: interval}
+}
+
+// Start runs the scheduler in the background until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	go s.run(ctx)
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.tick(time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.tick(now)
+		}
+	}
+}
+
+func (s *Scheduler) tick(now time.Time) {
+	changed, err := s.service.AdvanceLifecycle(now)
+	if err != nil {
+		slog.Error("campaign lifecycle tick failed", "err", err)
+	} else if changed > 0 {
+		slog.Info("campaign lifecycle advanced", "campaigns", changed)
+	}
+
+	created, err := s.service.CreateDueEditions(now)
+	if err != nil {
+		slog.Error("campaign template tick failed", "err", err)
+	} else if created > 0 {
+		slog.Info("campaign template editions created", "campaigns", created)
+	}
+}
@@ -3,31 +3,115 @@ package campaign
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 
+	activityModel "gopi.com/internal/domain/activity/model"
 	campaignModel "gopi.com/internal/domain/campaign/model"
 	"gopi.com/internal/domain/campaign/repo"
+	mediaModel "gopi.com/internal/domain/media/model"
 	"gopi.com/internal/domain/model"
+	notificationRepo "gopi.com/internal/domain/notification/repo"
+	trackModel "gopi.com/internal/domain/track/model"
+	userRepo "gopi.com/internal/domain/user/repo"
+	"gopi.com/internal/lib/email"
+	"gopi.com/internal/lib/geo"
 	"gopi.com/internal/lib/id"
+	"gopi.com/internal/lib/leaderboard"
 )
 
 type CampaignService struct {
 	campaignRepo       repo.CampaignRepository
 	campaignRunnerRepo repo.CampaignRunnerRepository
 	sponsorRepo        repo.SponsorCampaignRepository
+	uow                repo.UnitOfWork
+
+	// close-out collaborators, set by WithCloseout
+	resultRepo   repo.CampaignResultRepository
+	userRepo     userRepo.UserRepository
+	emailService email.EmailServiceInterface
+
+	// anti-cheat collaborators, set by WithAntiCheat
+	runRepo repo.CampaignRunRepository
+	rules   *activityModel.Rules
+
+	// team collaborator, set by WithTeams
+	teamRepo repo.CampaignTeamRepository
+
+	// set by WithGeocoder
+	geocoder geo.Geocoder
+
+	// invite collaborators, set by WithInvites
+	inviteRepo     repo.CampaignInviteRepository
+	requestRepo    repo.CampaignJoinRequestRepository
+	inviteKey      []byte
+	inviteLinkBase string
+
+	// milestone collaborators, set by WithMilestones
+	milestoneRepo    repo.CampaignMilestoneRepository
+	notificationRepo notificationRepo.NotificationRepository
+
+	// set by WithTemplates
+	templateRepo repo.CampaignTemplateRepository
+
+	// set by WithLeaderboards
+	leaderboards *leaderboard.Leaderboards
+
+	// set by WithGalleries
+	galleries GalleryRemover
 }
 
 func NewCampaignService(
 	campaignRepo repo.CampaignRepository,
 	campaignRunnerRepo repo.CampaignRunnerRepository,
 	sponsorRepo repo.SponsorCampaignRepository,
+	opts ...Option,
 ) *CampaignService {
-	return &CampaignService{
+	s := &CampaignService{
 		campaignRepo:       campaignRepo,
 		campaignRunnerRepo: campaignRunnerRepo,
 		sponsorRepo:        sponsorRepo,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.leaderboards == nil {
+		s.leaderboards = leaderboard.New(nil, 0)
+	}
+	if s.uow == nil {
+		s.uow = directUnitOfWork{repos: repo.Repositories{
+			Campaigns: campaignRepo,
+			Runners:   campaignRunnerRepo,
+			Sponsors:  sponsorRepo,
+			Runs:      s.runRepo,
+			Teams:     s.teamRepo,
+			Invites:   s.inviteRepo,
+			Requests:  s.requestRepo,
+			Results:   s.resultRepo,
+		}}
+	}
+	return s
+}
+
+// Option configures optional CampaignService collaborators.
+type Option func(*CampaignService)
+
+// WithUnitOfWork makes runner, sponsorship and campaign total writes commit atomically.
+func WithUnitOfWork(uow repo.UnitOfWork) Option {
+	return func(s *CampaignService) {
+		s.uow = uow
+	}
+}
+
+// directUnitOfWork runs against the service's own repositories without a transaction.
+// It is used when no UnitOfWork is configured; totals are still incremented atomically.
+type directUnitOfWork struct {
+	repos repo.Repositories
+}
+
+func (u directUnitOfWork) Do(fn func(repos repo.Repositories) error) error {
+	return fn(u.repos)
 }
 
 func (s *CampaignService) CreateCampaign(
@@ -36,7 +120,62 @@ func (s *CampaignService) CreateCampaign(
 	activity campaignModel.Activity,
 	targetAmount, targetAmountPerKm, distanceToCover float64,
 	startDuration, endDuration string,
+	coordinates *model.GeoPoint,
+	visibility campaignModel.CampaignVisibility,
+) (*campaignModel.Campaign, error) {
+	campaign, err := newCampaign(ownerID, ownerUsername, name, description, condition, goal, location,
+		mode, activity, targetAmount, targetAmountPerKm, distanceToCover, startDuration, endDuration, coordinates, visibility)
+	if err != nil {
+		return nil, err
+	}
+	campaign.Status = campaign.ScheduledStatus(time.Now())
+	s.locate(campaign)
+
+	if err := s.campaignRepo.Create(campaign); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// CreateDraftCampaign creates a campaign in the draft state. It stays hidden from joins and
+// activity until PublishCampaign is called.
+func (s *CampaignService) CreateDraftCampaign(
+	ownerID, ownerUsername, name, description, condition, goal, location string,
+	mode campaignModel.CampaignMode,
+	activity campaignModel.Activity,
+	targetAmount, targetAmountPerKm, distanceToCover float64,
+	startDuration, endDuration string,
+	coordinates *model.GeoPoint,
+	visibility campaignModel.CampaignVisibility,
+) (*campaignModel.Campaign, error) {
+	campaign, err := newCampaign(ownerID, ownerUsername, name, description, condition, goal, location,
+		mode, activity, targetAmount, targetAmountPerKm, distanceToCover, startDuration, endDuration, coordinates, visibility)
+	if err != nil {
+		return nil, err
+	}
+	campaign.Status = campaignModel.CampaignStatusDraft
+	s.locate(campaign)
+
+	if err := s.campaignRepo.Create(campaign); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+func newCampaign(
+	ownerID, ownerUsername, name, description, condition, goal, location string,
+	mode campaignModel.CampaignMode,
+	activity campaignModel.Activity,
+	targetAmount, targetAmountPerKm, distanceToCover float64,
+	startDuration, endDuration string,
+	coordinates *model.GeoPoint,
+	visibility campaignModel.CampaignVisibility,
 ) (*campaignModel.Campaign, error) {
+	if visibility == "" {
+		visibility = campaignModel.CampaignVisibilityPublic
+	}
 	campaign := &campaignModel.Campaign{
 		Base: model.Base{
 			ID:        id.New(),
@@ -50,6 +189,8 @@ func (s *CampaignService) CreateCampaign(
 		Goal:              goal,
 		Activity:          activity,
 		Location:          location,
+		Coordinates:       coordinates,
+		Visibility:        visibility,
 		TargetAmount:      targetAmount,
 		TargetAmountPerKm: targetAmountPerKm,
 		DistanceToCover:   distanceToCover,
@@ -59,13 +200,12 @@ func (s *CampaignService) CreateCampaign(
 		AcceptTac:         false,
 		MoneyRaised:       0,
 		DistanceCovered:   0,
-		Members:           []interface{}{},
-		Sponsors:          []interface{}{},
+		Members:           []campaignModel.UserSummary{},
+		Sponsors:          []campaignModel.UserSummary{},
 		Slug:              generateSlug(ownerUsername, name),
 	}
 
-	err := s.campaignRepo.Create(campaign)
-	if err != nil {
+	if err := campaign.SetSchedule(startDuration, endDuration); err != nil {
 		return nil, err
 	}
 
@@ -85,21 +225,47 @@ func (s *CampaignService) GetCampaignsByOwner(ownerID string) ([]*campaignModel.
 }
 
 func (s *CampaignService) JoinCampaign(campaignID, userID string) error {
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return err
+	}
+
+	return s.joinCampaign(campaign, userID)
+}
+
+func (s *CampaignService) joinCampaign(campaign *campaignModel.Campaign, userID string) error {
+	if err := campaign.CanJoin(time.Now()); err != nil {
+		return err
+	}
+
 	// Check if user is already a member
-	isMember, err := s.campaignRepo.IsMember(campaignID, userID)
+	isMember, err := s.campaignRepo.IsMember(campaign.ID, userID)
 	if err != nil {
 		return err
 	}
 
 	if isMember {
-		return errors.New("user is already a member of this campaign")
+		return campaignModel.ErrAlreadyMember
+	}
+
+	if err := campaign.CanJoinWithoutInvite(userID); err != nil {
+		return err
 	}
 
 	// Add user to members using repository method
-	return s.campaignRepo.AddMember(campaignID, userID)
+	return s.campaignRepo.AddMember(campaign.ID, userID)
 }
 
-func (s *CampaignService) RecordActivity(campaignID, userID string, distance float64, duration, activity string) error {
+func (s *CampaignService) RecordActivity(campaignID, userID string, distance float64, duration time.Duration, activity string) error {
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return err
+	}
+
+	if err := campaign.CanRecordActivity(time.Now()); err != nil {
+		return err
+	}
+
 	campaignRunner := &campaignModel.CampaignRunner{
 		Base: model.Base{
 			ID:        id.New(),
@@ -113,25 +279,58 @@ func (s *CampaignService) RecordActivity(campaignID, userID string, distance flo
 		OwnerID:         userID,
 		DateJoined:      time.Now(),
 	}
+	run := &campaignModel.CampaignRun{
+		CampaignID: campaignID,
+		RunnerID:   campaignRunner.ID,
+		OwnerID:    userID,
+		Activity:   activity,
+		Distance:   distance,
+		Duration:   duration,
+	}
 
-	err := s.campaignRunnerRepo.Create(campaignRunner)
+	violations, err := s.checkRun(run, nil)
 	if err != nil {
 		return err
 	}
+	if len(violations) > 0 {
+		// The runner joins the board with nothing credited until the run is approved.
+		campaignRunner.DistanceCovered, campaignRunner.Duration = 0, 0
+		err = s.uow.Do(func(r repo.Repositories) error {
+			if err := r.Runners.Create(campaignRunner); err != nil {
+				return err
+			}
+			return holdRun(r, run, violations)
+		})
+		if err != nil {
+			return err
+		}
+		return &activityModel.HeldForReviewError{Violations: violations}
+	}
 
-	// Update campaign's total distance covered
-	campaign, err := s.campaignRepo.GetByID(campaignID)
+	err = s.uow.Do(func(r repo.Repositories) error {
+		if err := r.Runners.Create(campaignRunner); err != nil {
+			return err
+		}
+		if err := r.Campaigns.IncrementTotals(campaignID, distance, 0); err != nil {
+			return err
+		}
+		if err := s.logRun(r, run); err != nil {
+			return err
+		}
+		campaign, err = r.Campaigns.GetByID(campaignID)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	campaign.DistanceCovered += distance
-	campaign.UpdatedAt = time.Now()
-
-	return s.campaignRepo.Update(campaign)
+	s.refreshBoards(campaign.ID)
+	s.checkMilestones(campaign)
+	s.checkGoal(campaign)
+	return nil
 }
 
-func (s *CampaignService) SponsorCampaign(campaignID string, sponsors []interface{}, distance, amountPerKm float64) error {
+func (s *CampaignService) SponsorCampaign(campaignID string, sponsors []campaignModel.UserSummary, distance, amountPerKm float64) error {
 	sponsor := &campaignModel.SponsorCampaign{
 		Base: model.Base{
 			ID:        id.New(),
@@ -147,44 +346,61 @@ func (s *CampaignService) SponsorCampaign(campaignID string, sponsors []interfac
 	// Calculate total amount
 	sponsor.CalculateTotalAmount()
 
-	err := s.sponsorRepo.Create(sponsor)
+	campaign, err := s.createSponsorship(sponsor)
 	if err != nil {
 		return err
 	}
 
-	// Update campaign's money raised
-	campaign, err := s.campaignRepo.GetByID(campaignID)
-	if err != nil {
-		return err
-	}
+	s.checkMilestones(campaign)
+	s.checkGoal(campaign)
+	return nil
+}
 
-	campaign.MoneyRaised += sponsor.TotalAmount
-	campaign.UpdatedAt = time.Now()
+// createSponsorship stores the sponsorship and adds its total to the campaign's money raised
+// in one unit of work, returning the campaign with its updated totals.
+func (s *CampaignService) createSponsorship(sponsor *campaignModel.SponsorCampaign) (*campaignModel.Campaign, error) {
+	var campaign *campaignModel.Campaign
+	err := s.uow.Do(func(r repo.Repositories) error {
+		if err := r.Sponsors.Create(sponsor); err != nil {
+			return err
+		}
+		if err := r.Campaigns.IncrementTotals(sponsor.CampaignID, 0, sponsor.TotalAmount); err != nil {
+			return err
+		}
+		var err error
+		campaign, err = r.Campaigns.GetByID(sponsor.CampaignID)
+		return err
+	})
+	return campaign, err
+}
 
-	return s.campaignRepo.Update(campaign)
+// ListCampaigns pages through the campaigns listed for viewerID, newest first: public ones,
+// plus unlisted and private ones they own or belong to. viewerID is empty for anonymous viewers.
+func (s *CampaignService) ListCampaigns(viewerID string, limit, offset int) ([]*campaignModel.Campaign, error) {
+	campaigns, _, err := s.campaignRepo.Find(campaignModel.CampaignFilter{
+		Listed:   true,
+		ViewerID: viewerID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	return campaigns, err
 }
 
-func (s *CampaignService) ListCampaigns(limit, offset int) ([]*campaignModel.Campaign, error) {
+// ListAllCampaigns pages through every campaign whatever its visibility, for staff tools.
+func (s *CampaignService) ListAllCampaigns(limit, offset int) ([]*campaignModel.Campaign, error) {
 	return s.campaignRepo.List(limit, offset)
 }
 
-func (s *CampaignService) GetCampaignsByNonOwner(excludeUserID string, limit, offset int) ([]*campaignModel.Campaign, error) {
-	campaigns, err := s.campaignRepo.List(limit*10, 0) // Get more to filter
-	if err != nil {
-		return nil, err
-	}
-
-	var result []*campaignModel.Campaign
-	count := 0
-	for _, campaign := range campaigns {
-		if campaign.OwnerID != excludeUserID {
-			if count >= offset && len(result) < limit {
-				result = append(result, campaign)
-			}
-			count++
-		}
-	}
-	return result, nil
+// GetCampaignsByNonOwner pages through the campaigns listed for excludeUserID that they do
+// not own, newest first, and returns the total number of such campaigns.
+func (s *CampaignService) GetCampaignsByNonOwner(excludeUserID string, limit, offset int) ([]*campaignModel.Campaign, int64, error) {
+	return s.campaignRepo.Find(campaignModel.CampaignFilter{
+		ExcludeOwnerID: excludeUserID,
+		Listed:         true,
+		ViewerID:       excludeUserID,
+		Limit:          limit,
+		Offset:         offset,
+	})
 }
 
 func (s *CampaignService) IsMember(campaignID, userID string) (bool, error) {
@@ -211,13 +427,20 @@ func (s *CampaignService) RemoveSponsor(campaignID, userID string) error {
 	return s.campaignRepo.RemoveSponsor(campaignID, userID)
 }
 
+// UpdateCampaign saves campaign, geocoding its location if it has no coordinates.
 func (s *CampaignService) UpdateCampaign(campaign *campaignModel.Campaign) error {
 	campaign.UpdatedAt = time.Now()
+	s.locate(campaign)
 	return s.campaignRepo.Update(campaign)
 }
 
+// DeleteCampaign removes the campaign along with its gallery.
 func (s *CampaignService) DeleteCampaign(id string) error {
-	return s.campaignRepo.Delete(id)
+	if err := s.campaignRepo.Delete(id); err != nil {
+		return err
+	}
+	s.deleteGallery(mediaModel.SubjectCampaign, id)
+	return nil
 }
 
 func (s *CampaignService) GetLeaderboard(campaignSlug string) ([]*campaignModel.CampaignRunner, error) {
@@ -235,8 +458,17 @@ func (s *CampaignService) ParticipateCampaign(campaignSlug, userID, activity str
 		return nil, err
 	}
 
+	if err := campaign.CanRecordActivity(time.Now()); err != nil {
+		return nil, err
+	}
+	if !campaign.HasMember(userID) {
+		if err := campaign.CanJoinWithoutInvite(userID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Add user to members if not already
-	if err := s.JoinCampaign(campaign.ID, userID); err != nil {
+	if err := s.joinCampaign(campaign, userID); err != nil {
 		// Ignore error if already a member
 	}
 
@@ -261,33 +493,185 @@ func (s *CampaignService) ParticipateCampaign(campaignSlug, userID, activity str
 	return campaignRunner, nil
 }
 
-func (s *CampaignService) FinishActivity(runnerID string, distance float64, duration string, moneyRaised float64) error {
+func (s *CampaignService) FinishActivity(runnerID string, distance float64, duration time.Duration, moneyRaised float64) error {
+	return s.finishActivity(runnerID, &campaignModel.CampaignRun{
+		Distance:    distance,
+		Duration:    duration,
+		MoneyRaised: moneyRaised,
+	}, nil)
+}
+
+// RecordRunTrack credits a runner with a run computed from an uploaded GPS track instead of
+// client-reported figures. trackURL points at the stored, simplified route.
+func (s *CampaignService) RecordRunTrack(runnerID string, track *trackModel.Track, summary trackModel.Summary, trackURL string) error {
+	return s.finishActivity(runnerID, &campaignModel.CampaignRun{
+		Distance:      summary.Distance,
+		Duration:      summary.MovingTime.Truncate(time.Second),
+		TrackURL:      trackURL,
+		ElevationGain: summary.ElevationGain,
+	}, track)
+}
+
+// finishActivity adds a run to the runner and campaign totals, or holds it for review when it
+// breaks the anti-cheat rules.
+func (s *CampaignService) finishActivity(runnerID string, run *campaignModel.CampaignRun, track *trackModel.Track) error {
 	runner, err := s.campaignRunnerRepo.GetByID(runnerID)
 	if err != nil {
 		return err
 	}
 
-	runner.DistanceCovered += distance
-	runner.Duration = duration
-	runner.MoneyRaised += moneyRaised
-	runner.UpdatedAt = time.Now()
+	campaign, err := s.campaignRepo.GetByID(runner.CampaignID)
+	if err != nil {
+		return err
+	}
+
+	if err := campaign.CanRecordActivity(time.Now()); err != nil {
+		return err
+	}
 
-	err = s.campaignRunnerRepo.Update(runner)
+	run.CampaignID, run.RunnerID, run.OwnerID, run.Activity = campaign.ID, runner.ID, runner.OwnerID, runner.Activity
+	violations, err := s.checkRun(run, track)
 	if err != nil {
 		return err
 	}
+	if len(violations) > 0 {
+		err := s.uow.Do(func(r repo.Repositories) error {
+			return holdRun(r, run, violations)
+		})
+		if err != nil {
+			return err
+		}
+		return &activityModel.HeldForReviewError{Violations: violations}
+	}
 
-	// Update campaign totals
-	campaign, err := s.campaignRepo.GetByID(runner.CampaignID)
+	// Runner and campaign totals move together so the leaderboard always sums to the campaign.
+	err = s.uow.Do(func(r repo.Repositories) error {
+		if err := creditRun(r, run); err != nil {
+			return err
+		}
+		if err := s.logRun(r, run); err != nil {
+			return err
+		}
+		campaign, err = r.Campaigns.GetByID(campaign.ID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.refreshBoards(campaign.ID)
+	s.checkMilestones(campaign)
+	s.checkGoal(campaign)
+	return nil
+}
+
+// PublishCampaign moves a draft campaign into the state implied by its schedule.
+func (s *CampaignService) PublishCampaign(campaignID string) (*campaignModel.Campaign, error) {
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	if campaign.Status != campaignModel.CampaignStatusDraft {
+		return nil, fmt.Errorf("%w: only draft campaigns can be published", campaignModel.ErrInvalidCampaignState)
+	}
+
+	next := campaign.ScheduledStatus(time.Now())
+	if err := s.transition(campaign, next); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// CancelCampaign cancels a campaign that has not already completed or been cancelled.
+func (s *CampaignService) CancelCampaign(campaignID string) (*campaignModel.Campaign, error) {
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.transition(campaign, campaignModel.CampaignStatusCancelled); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// AdvanceLifecycle persists time-driven transitions (scheduled -> active, active -> completed)
+// that are due at now. A scheduled campaign whose whole window passed between ticks is started
+// and completed in the same tick. It returns the number of campaigns that changed state.
+func (s *CampaignService) AdvanceLifecycle(now time.Time) (int, error) {
+	due, err := s.campaignRepo.ListDueForTransition(now)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, campaign := range due {
+		from, next := currentStatus(campaign), campaign.ScheduledStatus(now)
+		if next == from {
+			continue
+		}
+		steps := []campaignModel.CampaignStatus{next}
+		if from == campaignModel.CampaignStatusScheduled && next == campaignModel.CampaignStatusCompleted {
+			steps = []campaignModel.CampaignStatus{campaignModel.CampaignStatusActive, next}
+		}
+		var err error
+		for _, step := range steps {
+			if err = s.transition(campaign, step); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			if errors.Is(err, campaignModel.ErrInvalidCampaignState) {
+				// Changed concurrently; picked up on the next tick if still due.
+				slog.Warn("campaign lifecycle transition skipped", "campaign_id", campaign.ID, "from", from, "to", next, "err", err)
+				continue
+			}
+			return changed, err
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// transition applies a state change guarded by the transition table and a conditional update,
+// so concurrent schedulers or owner actions cannot both win. Completing a campaign closes it
+// out in the same unit of work.
+func (s *CampaignService) transition(campaign *campaignModel.Campaign, next campaignModel.CampaignStatus) error {
+	from := currentStatus(campaign)
+	if !from.CanTransitionTo(next) {
+		return fmt.Errorf("%w: cannot move from %s to %s", campaignModel.ErrInvalidCampaignState, from, next)
+	}
+
+	var (
+		ok  bool
+		err error
+	)
+	if next == campaignModel.CampaignStatusCompleted {
+		ok, err = s.closeOut(campaign, func(r repo.Repositories) (bool, error) {
+			return r.Campaigns.UpdateStatus(campaign.ID, campaign.Status, next)
+		})
+	} else {
+		ok, err = s.campaignRepo.UpdateStatus(campaign.ID, campaign.Status, next)
+	}
 	if err != nil {
 		return err
 	}
+	if !ok {
+		return fmt.Errorf("%w: campaign status changed concurrently", campaignModel.ErrInvalidCampaignState)
+	}
 
-	campaign.DistanceCovered += distance
-	campaign.MoneyRaised += moneyRaised
+	campaign.Status = next
 	campaign.UpdatedAt = time.Now()
+	return nil
+}
 
-	return s.campaignRepo.Update(campaign)
+// currentStatus treats rows created before the lifecycle existed as active.
+func currentStatus(campaign *campaignModel.Campaign) campaignModel.CampaignStatus {
+	if campaign.Status == "" {
+		return campaignModel.CampaignStatusActive
+	}
+	return campaign.Status
 }
 
 func (s *CampaignService) GetRunnersByUser(userID string) ([]*campaignModel.CampaignRunner, error) {
@@ -306,7 +690,7 @@ func (s *CampaignService) DeleteRunner(runnerID string) error {
 	return s.campaignRunnerRepo.Delete(runnerID)
 }
 
-func (s *CampaignService) CreateSponsorCampaign(campaignID string, sponsors []interface{}, distance, amountPerKm float64, brandImg, videoUrl string) (*campaignModel.SponsorCampaign, error) {
+func (s *CampaignService) CreateSponsorCampaign(campaignID string, sponsors []campaignModel.UserSummary, distance, amountPerKm float64, brandImg, videoUrl string) (*campaignModel.SponsorCampaign, error) {
 	sponsor := &campaignModel.SponsorCampaign{
 		Base: model.Base{
 			ID:        id.New(),
@@ -324,21 +708,13 @@ func (s *CampaignService) CreateSponsorCampaign(campaignID string, sponsors []in
 	// Calculate total amount
 	sponsor.CalculateTotalAmount()
 
-	err := s.sponsorRepo.Create(sponsor)
-	if err != nil {
-		return nil, err
-	}
-
-	// Update campaign's money raised
-	campaign, err := s.campaignRepo.GetByID(campaignID)
+	campaign, err := s.createSponsorship(sponsor)
 	if err != nil {
 		return nil, err
 	}
 
-	campaign.MoneyRaised += sponsor.TotalAmount
-	campaign.UpdatedAt = time.Now()
-	s.campaignRepo.Update(campaign)
-
+	s.checkMilestones(campaign)
+	s.checkGoal(campaign)
 	return sponsor, nil
 }
 
@@ -358,30 +734,9 @@ func (s *CampaignService) DeleteSponsorCampaign(sponsorID string) error {
 	return s.sponsorRepo.Delete(sponsorID)
 }
 
-func (s *CampaignService) SearchCampaigns(query string, limit, offset int) ([]*campaignModel.Campaign, error) {
-	// This is a simplified implementation
-	// In a real implementation, you'd use database full-text search
-	campaigns, err := s.campaignRepo.List(limit*2, offset) // Get more to filter
-	if err != nil {
-		return nil, err
-	}
-
-	query = strings.ToLower(query)
-	var result []*campaignModel.Campaign
-	for _, campaign := range campaigns {
-		if strings.Contains(strings.ToLower(campaign.Name), query) ||
-			strings.Contains(strings.ToLower(campaign.Description), query) ||
-			strings.Contains(strings.ToLower(campaign.Location), query) {
-			result = append(result, campaign)
-		}
-	}
-
-	// Limit results
-	if len(result) > limit {
-		result = result[:limit]
-	}
-
-	return result, nil
+// SearchCampaigns returns one page of campaigns matching filter and the total number of matches.
+func (s *CampaignService) SearchCampaigns(filter campaignModel.CampaignFilter) ([]*campaignModel.Campaign, int64, error) {
+	return s.campaignRepo.Find(filter)
 }
 
 // Helper function to generate slug from username and name (like Django)
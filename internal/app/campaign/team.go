@@ -0,0 +1,305 @@
+package campaign
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/campaign/repo"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+)
+
+// WithTeams lets campaign members form teams stored in teamRepo. Without it, team operations
+// return campaignModel.ErrTeamsNotEnabled.
+func WithTeams(teamRepo repo.CampaignTeamRepository) Option {
+	return func(s *CampaignService) {
+		s.teamRepo = teamRepo
+	}
+}
+
+// inviteAlphabet leaves out characters that are easy to misread when a code is shared aloud.
+const inviteAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+func newInviteCode() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	for i := range b {
+		b[i] = inviteAlphabet[int(b[i])%len(inviteAlphabet)]
+	}
+	return string(b[:])
+}
+
+// CreateTeam creates a team captained by userID, who must not already be in a team for the
+// campaign. The captain is added as a campaign member if they are not one yet.
+func (s *CampaignService) CreateTeam(campaignID, userID, name string) (*campaignModel.CampaignTeam, error) {
+	if s.teamRepo == nil {
+		return nil, campaignModel.ErrTeamsNotEnabled
+	}
+
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if err := campaign.CanChangeTeams(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotInTeam(campaignID, userID); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	teams, err := s.teamRepo.ListByCampaign(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range teams {
+		if strings.EqualFold(team.Name, name) {
+			return nil, campaignModel.ErrTeamNameTaken
+		}
+	}
+
+	now := time.Now()
+	team := &campaignModel.CampaignTeam{
+		Base: model.Base{
+			ID:        id.New(),
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		CampaignID: campaignID,
+		Name:       name,
+		CaptainID:  userID,
+		InviteCode: newInviteCode(),
+	}
+	err = s.uow.Do(func(r repo.Repositories) error {
+		if err := r.Teams.Create(team); err != nil {
+			return err
+		}
+		return addTeamMember(r, team, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	team.Members = []string{userID}
+	return team, nil
+}
+
+// JoinTeam adds userID to the campaign team whose invite code is inviteCode.
+func (s *CampaignService) JoinTeam(campaignID, userID, inviteCode string) (*campaignModel.CampaignTeam, error) {
+	if s.teamRepo == nil {
+		return nil, campaignModel.ErrTeamsNotEnabled
+	}
+
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if err := campaign.CanChangeTeams(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotInTeam(campaignID, userID); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.GetByInviteCode(campaignID, strings.ToUpper(strings.TrimSpace(inviteCode)))
+	if err != nil {
+		return nil, err
+	}
+	err = s.uow.Do(func(r repo.Repositories) error {
+		return addTeamMember(r, team, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	team.Members = append(team.Members, userID)
+	return team, nil
+}
+
+// LeaveTeam removes userID from a team. A departing captain hands the team to the
+// longest-standing remaining member; the last member leaving deletes the team.
+func (s *CampaignService) LeaveTeam(teamID, userID string) error {
+	team, err := s.teamForChange(teamID)
+	if err != nil {
+		return err
+	}
+	if !team.HasMember(userID) {
+		return campaignModel.ErrNotTeamMember
+	}
+
+	return s.uow.Do(func(r repo.Repositories) error {
+		// Decide from the members as they are now, not as first read, so two members leaving
+		// together cannot both skip deleting the team or hand it to each other.
+		team, err := r.Teams.GetByID(teamID)
+		if err != nil {
+			return err
+		}
+		if !team.HasMember(userID) {
+			return campaignModel.ErrNotTeamMember
+		}
+		if len(team.Members) == 1 {
+			return r.Teams.Delete(team.ID)
+		}
+		if err := r.Teams.RemoveMember(team.ID, userID); err != nil {
+			return err
+		}
+		if team.CaptainID != userID {
+			return nil
+		}
+		for _, member := range team.Members {
+			if member != userID {
+				team.CaptainID = member
+				break
+			}
+		}
+		team.UpdatedAt = time.Now()
+		return r.Teams.Update(team)
+	})
+}
+
+// RemoveTeamMember lets the captain remove another member. A captain removing themselves
+// leaves the team.
+func (s *CampaignService) RemoveTeamMember(teamID, captainID, userID string) error {
+	if captainID == userID {
+		return s.LeaveTeam(teamID, userID)
+	}
+
+	team, err := s.teamForChange(teamID)
+	if err != nil {
+		return err
+	}
+	if team.CaptainID != captainID {
+		return campaignModel.ErrNotTeamCaptain
+	}
+	if !team.HasMember(userID) {
+		return campaignModel.ErrNotTeamMember
+	}
+	return s.teamRepo.RemoveMember(team.ID, userID)
+}
+
+// TransferCaptaincy hands the team from its captain to another member.
+func (s *CampaignService) TransferCaptaincy(teamID, captainID, newCaptainID string) (*campaignModel.CampaignTeam, error) {
+	team, err := s.teamForChange(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team.CaptainID != captainID {
+		return nil, campaignModel.ErrNotTeamCaptain
+	}
+	if !team.HasMember(newCaptainID) {
+		return nil, campaignModel.ErrNotTeamMember
+	}
+
+	team.CaptainID = newCaptainID
+	team.UpdatedAt = time.Now()
+	if err := s.teamRepo.Update(team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// RegenerateInviteCode replaces the team's invite code so the old one stops working.
+func (s *CampaignService) RegenerateInviteCode(teamID, captainID string) (*campaignModel.CampaignTeam, error) {
+	team, err := s.teamForChange(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team.CaptainID != captainID {
+		return nil, campaignModel.ErrNotTeamCaptain
+	}
+
+	team.InviteCode = newInviteCode()
+	team.UpdatedAt = time.Now()
+	if err := s.teamRepo.Update(team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (s *CampaignService) GetTeamByID(teamID string) (*campaignModel.CampaignTeam, error) {
+	if s.teamRepo == nil {
+		return nil, campaignModel.ErrTeamsNotEnabled
+	}
+	return s.teamRepo.GetByID(teamID)
+}
+
+func (s *CampaignService) ListTeams(campaignID string) ([]*campaignModel.CampaignTeam, error) {
+	if s.teamRepo == nil {
+		return nil, campaignModel.ErrTeamsNotEnabled
+	}
+	return s.teamRepo.ListByCampaign(campaignID)
+}
+
+// GetTeamLeaderboard ranks the campaign's teams by their members' combined runner totals.
+func (s *CampaignService) GetTeamLeaderboard(campaignID string) ([]*campaignModel.TeamStanding, error) {
+	if s.teamRepo == nil {
+		return nil, campaignModel.ErrTeamsNotEnabled
+	}
+
+	standings, err := s.teamRepo.Standings(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	return campaignModel.RankTeamStandings(standings), nil
+}
+
+func (s *CampaignService) checkNotInTeam(campaignID, userID string) error {
+	current, err := s.teamRepo.TeamOf(campaignID, userID)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		return campaignModel.ErrAlreadyInTeam
+	}
+	return nil
+}
+
+// teamForChange loads a team whose campaign still allows team changes.
+func (s *CampaignService) teamForChange(teamID string) (*campaignModel.CampaignTeam, error) {
+	if s.teamRepo == nil {
+		return nil, campaignModel.ErrTeamsNotEnabled
+	}
+
+	team, err := s.teamRepo.GetByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	campaign, err := s.campaignRepo.GetByID(team.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+	if err := campaign.CanChangeTeams(time.Now()); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// addTeamMember puts userID in the team, making them a campaign member first if needed. A
+// private campaign only takes new members through an invite, so outsiders cannot join it by
+// way of a team.
+func addTeamMember(r repo.Repositories, team *campaignModel.CampaignTeam, userID string) error {
+	isMember, err := r.Campaigns.IsMember(team.CampaignID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		campaign, err := r.Campaigns.GetByID(team.CampaignID)
+		if err != nil {
+			return err
+		}
+		if err := campaign.CanJoinWithoutInvite(userID); err != nil {
+			return err
+		}
+		if err := r.Campaigns.AddMember(team.CampaignID, userID); err != nil {
+			return err
+		}
+	}
+	return r.Teams.AddMember(&campaignModel.CampaignTeamMember{
+		TeamID:     team.ID,
+		CampaignID: team.CampaignID,
+		UserID:     userID,
+		JoinedAt:   time.Now(),
+	})
+}
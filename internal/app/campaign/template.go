@@ -0,0 +1,269 @@
+package campaign
+
+import (
+	"log/slog"
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/campaign/repo"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+)
+
+// WithTemplates enables campaign templates and their recurring editions. Without it, templates
+// cannot be saved or used.
+func WithTemplates(templateRepo repo.CampaignTemplateRepository) Option {
+	return func(s *CampaignService) {
+		s.templateRepo = templateRepo
+	}
+}
+
+// SaveAsTemplate copies a campaign's settings, schedule length, sponsorships and milestones
+// into a new template owned by ownerID.
+func (s *CampaignService) SaveAsTemplate(campaign *campaignModel.Campaign, ownerID, name string) (*campaignModel.CampaignTemplate, error) {
+	if s.templateRepo == nil {
+		return nil, campaignModel.ErrTemplatesNotEnabled
+	}
+
+	sponsorships, err := s.sponsorRepo.GetByCampaignID(campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+	var milestones []*campaignModel.CampaignMilestone
+	if s.milestoneRepo != nil {
+		if milestones, err = s.milestoneRepo.ListByCampaign(campaign.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	template := campaignModel.NewTemplateFromCampaign(campaign, sponsorships, milestones)
+	template.Base = model.Base{ID: id.New(), CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	template.OwnerID = ownerID
+	if name != "" {
+		template.Name = name
+	}
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// GetTemplate returns a template by ID, or model.ErrTemplateNotFound.
+func (s *CampaignService) GetTemplate(templateID string) (*campaignModel.CampaignTemplate, error) {
+	if s.templateRepo == nil {
+		return nil, campaignModel.ErrTemplatesNotEnabled
+	}
+	return s.templateRepo.GetByID(templateID)
+}
+
+// ListTemplates returns the owner's templates, newest first.
+func (s *CampaignService) ListTemplates(ownerID string) ([]*campaignModel.CampaignTemplate, error) {
+	if s.templateRepo == nil {
+		return nil, campaignModel.ErrTemplatesNotEnabled
+	}
+	return s.templateRepo.ListByOwner(ownerID)
+}
+
+// UpdateTemplate stores changes to a template, such as a new name or recurrence.
+func (s *CampaignService) UpdateTemplate(template *campaignModel.CampaignTemplate) error {
+	if s.templateRepo == nil {
+		return campaignModel.ErrTemplatesNotEnabled
+	}
+	return s.templateRepo.Update(template)
+}
+
+// DeleteTemplate deletes a template and stops its schedule. Campaigns created from it are kept.
+func (s *CampaignService) DeleteTemplate(templateID string) error {
+	if s.templateRepo == nil {
+		return campaignModel.ErrTemplatesNotEnabled
+	}
+	return s.templateRepo.Delete(templateID)
+}
+
+// CreateFromTemplate creates a campaign from a template starting at start, ending the
+// template's length later. The template's sponsorships become pending slots that count only
+// once a sponsor confirms them, and its milestones are configured on the new campaign.
+func (s *CampaignService) CreateFromTemplate(template *campaignModel.CampaignTemplate, ownerUsername string, start time.Time, draft bool) (*campaignModel.Campaign, error) {
+	if s.templateRepo == nil {
+		return nil, campaignModel.ErrTemplatesNotEnabled
+	}
+	campaign, err := s.createEdition(template, ownerUsername, start, draft)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.templateRepo.CountEdition(template.ID); err != nil {
+		slog.Error("count campaign template edition failed", "template_id", template.ID, "err", err)
+	}
+	return campaign, nil
+}
+
+func (s *CampaignService) createEdition(template *campaignModel.CampaignTemplate, ownerUsername string, start time.Time, draft bool) (*campaignModel.Campaign, error) {
+	startsAt, endsAt := template.Schedule(start)
+	startDuration, endDuration := startsAt.Format(time.RFC3339), ""
+	if endsAt != nil {
+		endDuration = endsAt.Format(time.RFC3339)
+	}
+
+	campaign, err := newCampaign(template.OwnerID, ownerUsername, template.Name, template.Description,
+		template.Condition, template.Goal, template.Location, template.Mode, template.Activity,
+		template.TargetAmount, template.TargetAmountPerKm, template.DistanceToCover,
+		startDuration, endDuration, template.Coordinates, template.Visibility)
+	if err != nil {
+		return nil, err
+	}
+	campaign.WorkoutImg = template.WorkoutImg
+	campaign.Status = campaign.ScheduledStatus(time.Now())
+	if draft {
+		campaign.Status = campaignModel.CampaignStatusDraft
+	}
+	s.locate(campaign)
+
+	err = s.uow.Do(func(r repo.Repositories) error {
+		if err := r.Campaigns.Create(campaign); err != nil {
+			return err
+		}
+		for _, slot := range template.SponsorSlots {
+			sponsorship := &campaignModel.SponsorCampaign{
+				Base:        model.Base{ID: id.New(), CreatedAt: time.Now(), UpdatedAt: time.Now()},
+				CampaignID:  campaign.ID,
+				Sponsors:    slot.Sponsors,
+				Distance:    slot.Distance,
+				AmountPerKm: slot.AmountPerKm,
+				BrandImg:    slot.BrandImg,
+				VideoUrl:    slot.VideoUrl,
+				Status:      campaignModel.SponsorshipPending,
+			}
+			sponsorship.CalculateTotalAmount()
+			if err := r.Sponsors.Create(sponsorship); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(template.Milestones) > 0 && s.milestoneRepo != nil {
+		milestones := make([]*campaignModel.CampaignMilestone, 0, len(template.Milestones))
+		for _, m := range template.Milestones {
+			milestones = append(milestones, &campaignModel.CampaignMilestone{Metric: m.Metric, Percent: m.Percent, Value: m.Value})
+		}
+		if _, err := s.SetMilestones(campaign.ID, milestones); err != nil {
+			slog.Error("copy template milestones failed", "template_id", template.ID, "campaign_id", campaign.ID, "err", err)
+		}
+	}
+	return campaign, nil
+}
+
+// CreateDueEditions creates the next edition of every recurring template whose lead time has
+// been reached at now and moves its schedule on by one interval. Editions that would already
+// have ended are skipped. It returns the number of campaigns created.
+func (s *CampaignService) CreateDueEditions(now time.Time) (int, error) {
+	if s.templateRepo == nil {
+		return 0, nil
+	}
+
+	templates, err := s.templateRepo.ListRecurring()
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, template := range templates {
+		if !template.DueAt(now) {
+			continue
+		}
+		start := *template.NextStartsAt
+		// Only the caller that moves the schedule on creates the edition, so concurrent
+		// schedulers never create it twice.
+		advanced, err := s.templateRepo.AdvanceSchedule(template.ID, start, template.Recurrence.Next(start))
+		if err != nil {
+			slog.Error("advance campaign template schedule failed", "template_id", template.ID, "err", err)
+			continue
+		}
+		if !advanced {
+			continue
+		}
+		if template.Length > 0 && !start.Add(template.Length).After(now) {
+			slog.Warn("campaign template edition skipped", "template_id", template.ID, "starts_at", start)
+			continue
+		}
+
+		if _, err := s.CreateFromTemplate(template, s.ownerUsername(template.OwnerID), start, false); err != nil {
+			slog.Error("create campaign template edition failed", "template_id", template.ID, "err", err)
+			continue
+		}
+		created++
+	}
+	return created, nil
+}
+
+// ownerUsername is the username used in the slug of a scheduled edition, or the owner ID when
+// it cannot be looked up.
+func (s *CampaignService) ownerUsername(ownerID string) string {
+	if s.userRepo != nil {
+		if user, err := s.userRepo.GetByID(ownerID); err == nil {
+			return user.Username
+		}
+	}
+	return ownerID
+}
+
+// ConfirmSponsorship confirms a pending sponsorship slot for userID. A slot that names its
+// sponsors can only be confirmed by one of them; an open slot is taken by userID. Once
+// confirmed, the sponsorship counts towards the campaign's money raised and its sponsors are
+// listed on the campaign.
+func (s *CampaignService) ConfirmSponsorship(campaignID, sponsorshipID, userID string) (*campaignModel.SponsorCampaign, error) {
+	sponsorship, err := s.sponsorRepo.GetByID(sponsorshipID)
+	if err != nil || sponsorship.CampaignID != campaignID {
+		return nil, campaignModel.ErrSponsorshipNotFound
+	}
+	if sponsorship.Confirmed() {
+		return nil, campaignModel.ErrSponsorshipNotPending
+	}
+
+	sponsors := sponsorship.Sponsors
+	if len(sponsors) == 0 {
+		sponsors = campaignModel.UsersByID(userID)
+	} else if !campaignModel.HasUser(sponsors, userID) {
+		return nil, campaignModel.ErrNotSlotSponsor
+	}
+
+	var campaign *campaignModel.Campaign
+	err = s.uow.Do(func(r repo.Repositories) error {
+		confirmed, err := r.Sponsors.Confirm(sponsorship.ID, sponsors)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return campaignModel.ErrSponsorshipNotPending
+		}
+		if err := r.Campaigns.IncrementTotals(campaignID, 0, sponsorship.TotalAmount); err != nil {
+			return err
+		}
+		for _, sponsorID := range campaignModel.UserIDs(sponsors) {
+			isSponsor, err := r.Campaigns.IsSponsor(campaignID, sponsorID)
+			if err != nil {
+				return err
+			}
+			if !isSponsor {
+				if err := r.Campaigns.AddSponsor(campaignID, sponsorID); err != nil {
+					return err
+				}
+			}
+		}
+		campaign, err = r.Campaigns.GetByID(campaignID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sponsorship.Sponsors = sponsors
+	sponsorship.Status = campaignModel.SponsorshipConfirmed
+
+	s.checkMilestones(campaign)
+	s.checkGoal(campaign)
+	return sponsorship, nil
+}
+
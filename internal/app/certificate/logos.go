@@ -0,0 +1,114 @@
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxLogoSize caps how much of a sponsor logo is read.
+const maxLogoSize = 2 << 20
+
+// ErrLogoNotAllowed is returned for brand image URLs outside the configured upload locations.
+var ErrLogoNotAllowed = errors.New("brand image is not hosted by this service")
+
+// LogoLoader fetches the sponsor brand images printed on certificates.
+type LogoLoader interface {
+	Load(ctx context.Context, url string) ([]byte, error)
+}
+
+// URLLogoLoader loads brand images that were uploaded through the service's storage. Images
+// under the local public URL are read straight from disk and remote images are fetched only
+// from the allowed prefixes, so a sponsor-supplied URL cannot make the server request
+// arbitrary hosts.
+type URLLogoLoader struct {
+	localBaseURL   string
+	localDir       string
+	remotePrefixes []string
+	client         *http.Client
+}
+
+// NewLogoLoader serves URLs under localBaseURL (e.g. /uploads) from localDir and fetches URLs
+// that start with one of remotePrefixes (e.g. the S3 public base URL). Empty prefixes are
+// ignored.
+func NewLogoLoader(localBaseURL, localDir string, remotePrefixes ...string) *URLLogoLoader {
+	l := &URLLogoLoader{
+		localBaseURL: strings.TrimRight(localBaseURL, "/") + "/",
+		localDir:     localDir,
+		client: &http.Client{
+			Timeout: 5 * time.Second,
+			// A redirect could lead anywhere, so the first response is final.
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}
+	for _, prefix := range remotePrefixes {
+		if prefix = strings.TrimRight(prefix, "/"); prefix != "" {
+			// The trailing slash stops https://cdn.example.com matching cdn.example.com.evil.net.
+			l.remotePrefixes = append(l.remotePrefixes, prefix+"/")
+		}
+	}
+	return l
+}
+
+func (l *URLLogoLoader) Load(ctx context.Context, url string) ([]byte, error) {
+	if l.localDir != "" && l.localBaseURL != "/" && strings.HasPrefix(url, l.localBaseURL) {
+		return l.loadLocal(strings.TrimPrefix(url, l.localBaseURL))
+	}
+	for _, prefix := range l.remotePrefixes {
+		if strings.HasPrefix(url, prefix) {
+			return l.loadRemote(ctx, url)
+		}
+	}
+	return nil, ErrLogoNotAllowed
+}
+
+func (l *URLLogoLoader) loadLocal(key string) ([]byte, error) {
+	if i := strings.IndexAny(key, "?#"); i >= 0 {
+		key = key[:i]
+	}
+	// Cleaning against the root keeps ".." from climbing out of localDir.
+	key = strings.TrimPrefix(path.Clean("/"+key), "/")
+	if key == "" {
+		return nil, ErrLogoNotAllowed
+	}
+	f, err := os.Open(filepath.Join(l.localDir, filepath.FromSlash(key)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readLogo(f)
+}
+
+func (l *URLLogoLoader) loadRemote(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch brand image: %s", resp.Status)
+	}
+	return readLogo(resp.Body)
+}
+
+func readLogo(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxLogoSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxLogoSize {
+		return nil, errors.New("brand image is too large")
+	}
+	return data, nil
+}
@@ -0,0 +1,152 @@
+package certificate
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+
+	certificateModel "gopi.com/internal/domain/certificate/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/pdf"
+	"gopi.com/internal/lib/qr"
+)
+
+// maxLogos is how many sponsor logos fit in the row on the certificate.
+const maxLogos = 6
+
+var (
+	colorNavy  = color.RGBA{R: 0x1b, G: 0x2a, B: 0x4a, A: 0xff}
+	colorGold  = color.RGBA{R: 0xc8, G: 0x9b, B: 0x3c, A: 0xff}
+	colorMuted = color.RGBA{R: 0x6b, G: 0x72, B: 0x80, A: 0xff}
+)
+
+// render lays out the certificate as a single landscape A4 page: the runner and what they
+// completed in the middle, their figures below, a row of sponsor logos and a QR code that
+// links to verifyURL.
+func render(certificate *certificateModel.Certificate, logos [][]byte, verifyURL string) ([]byte, error) {
+	const (
+		width  = pdf.A4Height
+		height = pdf.A4Width
+		margin = 28.0
+		center = width / 2
+	)
+
+	doc := pdf.NewDocument(width, height)
+	doc.SetTitle("Certificate of completion - " + certificate.RunnerName)
+	page := doc.AddPage()
+
+	page.FillRect(0, 0, width, height, color.White)
+	page.StrokeRect(margin, margin, width-2*margin, height-2*margin, 4, colorNavy)
+	page.StrokeRect(margin+10, margin+10, width-2*margin-20, height-2*margin-20, 1, colorGold)
+
+	page.TextCentered(center, 112, pdf.HelveticaBold, 32, colorNavy, "CERTIFICATE OF COMPLETION")
+	page.TextCentered(center, 160, pdf.Helvetica, 14, colorMuted, "This certifies that")
+	page.TextCentered(center, 212, pdf.HelveticaBold, fitSize(pdf.HelveticaBold, 34, 560, certificate.RunnerName),
+		colorNavy, certificate.RunnerName)
+	page.Line(center-220, 228, center+220, 228, 1, colorGold)
+
+	completed := "completed the campaign"
+	if certificate.Kind == certificateModel.KindCause {
+		completed = "completed the cause"
+	}
+	page.TextCentered(center, 262, pdf.Helvetica, 14, colorMuted, completed)
+	page.TextCentered(center, 296, pdf.HelveticaBold, fitSize(pdf.HelveticaBold, 22, 600, certificate.SubjectName),
+		colorNavy, certificate.SubjectName)
+
+	stats := [][2]string{{"DISTANCE", fmt.Sprintf("%.2f km", certificate.Distance)}}
+	if certificate.Duration > 0 {
+		stats = append(stats, [2]string{"TIME", model.FormatDuration(certificate.Duration)})
+	}
+	stats = append(stats, [2]string{"DATE", certificate.CreatedAt.Format("2 January 2006")})
+	const statWidth = 170.0
+	x := center - statWidth*float64(len(stats)-1)/2
+	for _, stat := range stats {
+		page.TextCentered(x, 344, pdf.Helvetica, 10, colorMuted, stat[0])
+		page.TextCentered(x, 366, pdf.HelveticaBold, 18, colorNavy, stat[1])
+		x += statWidth
+	}
+
+	drawLogos(doc, page, logos, center, 408)
+
+	const qrSize = 96.0
+	qrX, qrY := width-margin-24-qrSize, height-margin-24-qrSize
+	if err := drawQR(page, verifyURL, qrX, qrY, qrSize); err != nil {
+		return nil, err
+	}
+	page.Text(margin+28, height-margin-44, pdf.Helvetica, 9, colorMuted, "Certificate ID: "+certificate.ID)
+	page.Text(margin+28, height-margin-30, pdf.Helvetica, 9, colorMuted, "Verify at "+verifyURL)
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// drawLogos centres the sponsor logos in a row below top, each scaled to fit its slot.
+// Logos that are not a supported image are left out.
+func drawLogos(doc *pdf.Document, page *pdf.Page, logos [][]byte, center, top float64) {
+	const (
+		slotWidth  = 110.0
+		slotHeight = 50.0
+		gap        = 20.0
+	)
+
+	var images []*pdf.Image
+	for _, logo := range logos {
+		img, err := doc.AddImage(logo)
+		if err != nil {
+			continue
+		}
+		images = append(images, img)
+	}
+	if len(images) == 0 {
+		return
+	}
+
+	page.TextCentered(center, top, pdf.Helvetica, 10, colorMuted, "SUPPORTED BY")
+	rowWidth := float64(len(images))*slotWidth + float64(len(images)-1)*gap
+	x := center - rowWidth/2
+	for _, img := range images {
+		scale := min(slotWidth/float64(img.Width()), slotHeight/float64(img.Height()))
+		w, h := float64(img.Width())*scale, float64(img.Height())*scale
+		page.Image(img, x+(slotWidth-w)/2, top+12+(slotHeight-h)/2, w, h)
+		x += slotWidth + gap
+	}
+}
+
+// drawQR draws a QR code for text in a size by size square, quiet zone included, merging
+// each row's dark modules into runs to keep the page small.
+func drawQR(page *pdf.Page, text string, x, y, size float64) error {
+	code, err := qr.Encode(text)
+	if err != nil {
+		return err
+	}
+
+	const quiet = 4
+	module := size / float64(code.Size()+2*quiet)
+	page.FillRect(x, y, size, size, color.White)
+	for row := 0; row < code.Size(); row++ {
+		for col := 0; col < code.Size(); {
+			if !code.Dark(col, row) {
+				col++
+				continue
+			}
+			start := col
+			for col < code.Size() && code.Dark(col, row) {
+				col++
+			}
+			page.FillRect(x+float64(quiet+start)*module, y+float64(quiet+row)*module,
+				float64(col-start)*module, module, color.Black)
+		}
+	}
+	return nil
+}
+
+// fitSize shrinks size until s fits in width, down to 12 points.
+func fitSize(font pdf.Font, size, width float64, s string) float64 {
+	for size > 12 && pdf.TextWidth(font, size, s) > width {
+		size--
+	}
+	return size
+}
@@ -0,0 +1,278 @@
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+	certificateModel "gopi.com/internal/domain/certificate/model"
+	certificateRepo "gopi.com/internal/domain/certificate/repo"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+	"gopi.com/internal/domain/model"
+	userRepo "gopi.com/internal/domain/user/repo"
+	"gopi.com/internal/lib/id"
+	"gopi.com/internal/lib/storage"
+)
+
+// CertificateService issues PDF completion certificates for campaign and cause runners and
+// looks them up for verification.
+type CertificateService struct {
+	certificateRepo certificateRepo.CertificateRepository
+	userRepo        userRepo.UserRepository
+	storage         storage.Storage
+	verifyBase      string
+
+	// campaign collaborators, set by WithCampaigns
+	campaignRepo        campaignRepo.CampaignRepository
+	campaignRunnerRepo  campaignRepo.CampaignRunnerRepository
+	campaignSponsorRepo campaignRepo.SponsorCampaignRepository
+
+	// cause collaborators, set by WithCauses
+	causeRepo        challengeRepo.CauseRepository
+	causeRunnerRepo  challengeRepo.CauseRunnerRepository
+	causeSponsorRepo challengeRepo.SponsorCauseRepository
+
+	// set by WithLogoLoader
+	logos LogoLoader
+}
+
+// NewCertificateService returns a service that stores certificates through st. verifyBase is
+// the public URL the QR code points at, followed by "/<certificate id>/verify".
+func NewCertificateService(
+	certificateRepo certificateRepo.CertificateRepository,
+	userRepo userRepo.UserRepository,
+	st storage.Storage,
+	verifyBase string,
+	opts ...Option,
+) *CertificateService {
+	s := &CertificateService{
+		certificateRepo: certificateRepo,
+		userRepo:        userRepo,
+		storage:         st,
+		verifyBase:      strings.TrimRight(verifyBase, "/"),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Option configures optional CertificateService collaborators.
+type Option func(*CertificateService)
+
+// WithCampaigns enables certificates for runners of completed campaigns.
+func WithCampaigns(campaigns campaignRepo.CampaignRepository, runners campaignRepo.CampaignRunnerRepository, sponsors campaignRepo.SponsorCampaignRepository) Option {
+	return func(s *CertificateService) {
+		s.campaignRepo = campaigns
+		s.campaignRunnerRepo = runners
+		s.campaignSponsorRepo = sponsors
+	}
+}
+
+// WithCauses enables certificates for cause runners who covered their distance.
+func WithCauses(causes challengeRepo.CauseRepository, runners challengeRepo.CauseRunnerRepository, sponsors challengeRepo.SponsorCauseRepository) Option {
+	return func(s *CertificateService) {
+		s.causeRepo = causes
+		s.causeRunnerRepo = runners
+		s.causeSponsorRepo = sponsors
+	}
+}
+
+// WithLogoLoader prints sponsor brand images on certificates. Without it certificates carry
+// no logos.
+func WithLogoLoader(logos LogoLoader) Option {
+	return func(s *CertificateService) {
+		s.logos = logos
+	}
+}
+
+// VerifyURL is the address printed on, and encoded in the QR code of, a certificate.
+func (s *CertificateService) VerifyURL(certificateID string) string {
+	return s.verifyBase + "/" + certificateID + "/verify"
+}
+
+// IssueCampaignCertificate returns the certificate for a campaign runner, creating it on the
+// first call. The runner must belong to userID, the campaign must be completed and the runner
+// must have covered some distance.
+func (s *CertificateService) IssueCampaignCertificate(ctx context.Context, userID, runnerID string) (*certificateModel.Certificate, error) {
+	if s.campaignRepo == nil {
+		return nil, certificateModel.ErrKindNotEnabled
+	}
+
+	runner, err := s.campaignRunnerRepo.GetByID(runnerID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", certificateModel.ErrRunnerNotFound, err)
+	}
+	if runner.OwnerID != userID {
+		return nil, certificateModel.ErrNotRunnerOwner
+	}
+	if existing, err := s.existing(certificateModel.KindCampaign, runner.ID); existing != nil || err != nil {
+		return existing, err
+	}
+
+	campaign, err := s.campaignRepo.GetByID(runner.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.StatusAt(time.Now()) != campaignModel.CampaignStatusCompleted || runner.DistanceCovered <= 0 {
+		return nil, certificateModel.ErrNotCompleted
+	}
+
+	sponsorships, err := s.campaignSponsorRepo.GetByCampaignID(campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+	brandImages := make([]string, 0, len(sponsorships))
+	for _, sponsorship := range sponsorships {
+		if sponsorship.Confirmed() {
+			brandImages = append(brandImages, sponsorship.BrandImg)
+		}
+	}
+
+	return s.issue(ctx, &certificateModel.Certificate{
+		Kind:        certificateModel.KindCampaign,
+		SubjectID:   campaign.ID,
+		SubjectName: campaign.Name,
+		RunnerID:    runner.ID,
+		UserID:      runner.OwnerID,
+		Distance:    runner.DistanceCovered,
+		Duration:    runner.Duration,
+	}, brandImages)
+}
+
+// IssueCauseCertificate returns the certificate for a cause runner, creating it on the first
+// call. The runner must belong to userID, must not be held or rejected by anti-cheat review
+// and must have covered its distance to cover, or some distance when none was set.
+func (s *CertificateService) IssueCauseCertificate(ctx context.Context, userID, runnerID string) (*certificateModel.Certificate, error) {
+	if s.causeRepo == nil {
+		return nil, certificateModel.ErrKindNotEnabled
+	}
+
+	runner, err := s.causeRunnerRepo.GetByID(runnerID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", certificateModel.ErrRunnerNotFound, err)
+	}
+	if runner.OwnerID != userID {
+		return nil, certificateModel.ErrNotRunnerOwner
+	}
+	if existing, err := s.existing(certificateModel.KindCause, runner.ID); existing != nil || err != nil {
+		return existing, err
+	}
+
+	if !runner.Status.Counts() || runner.DistanceCovered <= 0 ||
+		(runner.DistanceToCover > 0 && runner.DistanceCovered < runner.DistanceToCover) {
+		return nil, certificateModel.ErrNotCompleted
+	}
+
+	cause, err := s.causeRepo.GetByID(runner.CauseID)
+	if err != nil {
+		return nil, err
+	}
+	sponsorships, err := s.causeSponsorRepo.GetByCauseID(cause.ID)
+	if err != nil {
+		return nil, err
+	}
+	brandImages := make([]string, 0, len(sponsorships))
+	for _, sponsorship := range sponsorships {
+		brandImages = append(brandImages, sponsorship.BrandImg)
+	}
+
+	return s.issue(ctx, &certificateModel.Certificate{
+		Kind:        certificateModel.KindCause,
+		SubjectID:   cause.ID,
+		SubjectName: cause.Name,
+		RunnerID:    runner.ID,
+		UserID:      runner.OwnerID,
+		Distance:    runner.DistanceCovered,
+		Duration:    runner.Duration,
+	}, brandImages)
+}
+
+// GetCertificate returns a certificate by ID, or model.ErrCertificateNotFound.
+func (s *CertificateService) GetCertificate(certificateID string) (*certificateModel.Certificate, error) {
+	return s.certificateRepo.GetByID(certificateID)
+}
+
+// ListUserCertificates returns a page of the certificates issued to a user, newest first.
+func (s *CertificateService) ListUserCertificates(userID string, limit, offset int) ([]*certificateModel.Certificate, error) {
+	return s.certificateRepo.ListByUser(userID, limit, offset)
+}
+
+// existing returns the certificate already issued for a run, or nil if there is none.
+func (s *CertificateService) existing(kind certificateModel.Kind, runnerID string) (*certificateModel.Certificate, error) {
+	certificate, err := s.certificateRepo.GetByRunner(kind, runnerID)
+	if errors.Is(err, certificateModel.ErrCertificateNotFound) {
+		return nil, nil
+	}
+	return certificate, err
+}
+
+// issue renders the certificate, stores the PDF and records it.
+func (s *CertificateService) issue(ctx context.Context, certificate *certificateModel.Certificate, brandImages []string) (*certificateModel.Certificate, error) {
+	now := time.Now()
+	certificate.Base = model.Base{ID: id.New(), CreatedAt: now, UpdatedAt: now}
+	certificate.RunnerName = s.runnerName(certificate.UserID)
+
+	data, err := render(certificate, s.loadLogos(ctx, brandImages), s.VerifyURL(certificate.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	certificate.FileKey = "certificates/" + certificate.ID + ".pdf"
+	certificate.FileURL, err = s.storage.Save(ctx, certificate.FileKey, bytes.NewReader(data), int64(len(data)), "application/pdf")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.certificateRepo.Create(certificate); err != nil {
+		_ = s.storage.Delete(ctx, certificate.FileKey)
+		// A concurrent request for the same run may have won the unique index.
+		if existing, _ := s.existing(certificate.Kind, certificate.RunnerID); existing != nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+	return certificate, nil
+}
+
+// runnerName is the name printed on the certificate: the user's full name, or their
+// username when they have not set one.
+func (s *CertificateService) runnerName(userID string) string {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return userID
+	}
+	if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+		return name
+	}
+	return user.Username
+}
+
+// loadLogos fetches the distinct sponsor brand images, skipping any that cannot be loaded so
+// a broken logo never blocks a certificate.
+func (s *CertificateService) loadLogos(ctx context.Context, brandImages []string) [][]byte {
+	if s.logos == nil {
+		return nil
+	}
+	var logos [][]byte
+	seen := make(map[string]bool)
+	for _, url := range brandImages {
+		if url == "" || seen[url] || len(logos) == maxLogos {
+			continue
+		}
+		seen[url] = true
+		data, err := s.logos.Load(ctx, url)
+		if err != nil {
+			slog.Warn("sponsor logo skipped on certificate", "url", url, "err", err)
+			continue
+		}
+		logos = append(logos, data)
+	}
+	return logos
+}
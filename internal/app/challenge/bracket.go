@@ -0,0 +1,180 @@
+package challenge
+
+import (
+	"log/slog"
+	"time"
+
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/challenge/repo"
+)
+
+// bracketMemberPage is how many members are read at a time when a bracket is drawn.
+const bracketMemberPage = 500
+
+// WithBrackets enables knockout brackets, in which a challenge's members are drawn into pairs
+// that race each round and the one who logs more distance advances.
+func WithBrackets(bracketRepo repo.BracketRepository) Option {
+	return func(s *ChallengeService) {
+		s.bracketRepo = bracketRepo
+	}
+}
+
+// CreateBracket seeds the challenge's members and draws a knockout bracket for them, with
+// rounds of roundLength (a week when zero) from startsAt. Distance seeding ranks members by the
+// counted distance they had logged by the draw. A challenge has at most one bracket, and
+// closed or cancelled challenges get none.
+func (s *ChallengeService) CreateBracket(challengeID string, seeding challengeModel.Seeding, startsAt time.Time, roundLength time.Duration, now time.Time) (*challengeModel.Bracket, []*challengeModel.BracketMatch, error) {
+	if s.bracketRepo == nil {
+		return nil, nil, challengeModel.ErrBracketsNotEnabled
+	}
+	bracket := &challengeModel.Bracket{
+		ChallengeID: challengeID,
+		Seeding:     seeding,
+		StartsAt:    startsAt,
+		RoundLength: roundLength,
+	}
+	if err := bracket.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	challenge, err := s.challengeRepo.GetByID(challengeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge.ClosedAt != nil || challenge.CancelledAt != nil {
+		return nil, nil, challengeModel.ErrChallengeFinished
+	}
+	existing, err := s.bracketRepo.GetChallengeBracket(challenge.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing != nil {
+		return nil, nil, challengeModel.ErrBracketExists
+	}
+
+	members, err := s.allChallengeMembers(challenge.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var pastDistance map[string]float64
+	if seeding == challengeModel.SeedDistance {
+		pastDistance = make(map[string]float64, len(members))
+		for _, userID := range members {
+			if pastDistance[userID], err = s.causeRunnerRepo.SumDistanceSince(userID, time.Time{}); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	seeds, err := challengeModel.SeedEntrants(members, seeding, pastDistance, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	matches, err := bracket.Draw(seeds, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.bracketRepo.CreateBracket(bracket, matches); err != nil {
+		// A concurrent draw may have won the unique index.
+		if existing, _ := s.bracketRepo.GetChallengeBracket(challenge.ID); existing != nil {
+			return nil, nil, challengeModel.ErrBracketExists
+		}
+		return nil, nil, err
+	}
+	return bracket, matches, nil
+}
+
+// allChallengeMembers returns the user IDs of every member of the challenge, earliest first.
+func (s *ChallengeService) allChallengeMembers(challengeID string) ([]string, error) {
+	var userIDs []string
+	for offset := 0; ; offset += bracketMemberPage {
+		page, total, err := s.challengeRepo.ListMembers(challengeID, bracketMemberPage, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range page {
+			userIDs = append(userIDs, member.UserID)
+		}
+		if len(page) < bracketMemberPage || int64(offset+len(page)) >= total {
+			return userIDs, nil
+		}
+	}
+}
+
+// GetBracket returns the challenge's bracket with its matches by round and slot.
+func (s *ChallengeService) GetBracket(challengeID string) (*challengeModel.Bracket, []*challengeModel.BracketMatch, error) {
+	if s.bracketRepo == nil {
+		return nil, nil, challengeModel.ErrBracketsNotEnabled
+	}
+	bracket, err := s.bracketRepo.GetChallengeBracket(challengeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bracket == nil {
+		return nil, nil, challengeModel.ErrNoBracket
+	}
+	matches, err := s.bracketRepo.ListMatches(bracket.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bracket, matches, nil
+}
+
+// DecideDueMatches decides every bracket match whose round has ended and returns how many it
+// decided. A failure on one match is logged and does not stop the others.
+func (s *ChallengeService) DecideDueMatches(now time.Time) (int, error) {
+	if s.bracketRepo == nil {
+		return 0, nil
+	}
+
+	due, err := s.bracketRepo.ListDueMatches(now)
+	if err != nil {
+		return 0, err
+	}
+	decided := 0
+	for _, match := range due {
+		ok, err := s.DecideMatch(match.BracketID, match.ID, now)
+		if err != nil {
+			slog.Error("bracket match decision failed", "match_id", match.ID, "err", err)
+			continue
+		}
+		if ok {
+			decided++
+		}
+	}
+	return decided, nil
+}
+
+// DecideMatch settles the match from the distance its pair logged in the challenge's causes
+// during the round and advances the winner. It runs at most once per match and reports whether
+// this call decided it; a match still waiting on a feeder is left for a later call.
+func (s *ChallengeService) DecideMatch(bracketID, matchID string, now time.Time) (bool, error) {
+	if s.bracketRepo == nil {
+		return false, challengeModel.ErrBracketsNotEnabled
+	}
+
+	bracket, err := s.bracketRepo.GetBracket(bracketID)
+	if err != nil {
+		return false, err
+	}
+	matches, err := s.bracketRepo.ListMatches(bracket.ID)
+	if err != nil {
+		return false, err
+	}
+	var match *challengeModel.BracketMatch
+	for _, m := range matches {
+		if m.ID == matchID {
+			match = m
+		}
+	}
+	if match == nil || match.DecidedAt != nil || !match.Ready() {
+		return false, nil
+	}
+
+	runners, err := s.challengeRunners(bracket.ChallengeID)
+	if err != nil {
+		return false, err
+	}
+	match.Decide(runners, now)
+	next := bracket.Advance(matches, match)
+	return s.bracketRepo.SaveDecision(bracket, match, next)
+}
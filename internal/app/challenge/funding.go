@@ -0,0 +1,93 @@
+package challenge
+
+import (
+	"time"
+
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/challenge/repo"
+)
+
+// WithFunding enables sponsor settlement: when a challenge closes, each of its causes' funding
+// ledgers is settled into one statement per sponsor of what they owe.
+func WithFunding(statementRepo repo.SponsorStatementRepository) Option {
+	return func(s *ChallengeService) {
+		s.statementRepo = statementRepo
+	}
+}
+
+// GetCauseFunding returns the cause's funding ledger. Until the cause is settled each sponsor's
+// earnings are recomputed from the distance the cause has covered; afterwards the ledger is
+// built from the settlement statements, so it matches what sponsors were billed.
+func (s *ChallengeService) GetCauseFunding(causeID string) (*challengeModel.CauseFunding, error) {
+	cause, err := s.causeRepo.GetByID(causeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.statementRepo != nil {
+		statements, err := s.statementRepo.ListByCause(cause.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(statements) > 0 {
+			return challengeModel.SettledCauseFunding(cause, statements), nil
+		}
+	}
+
+	sponsorships, err := s.sponsorCauseRepo.GetByCauseID(cause.ID)
+	if err != nil {
+		return nil, err
+	}
+	return challengeModel.NewCauseFunding(cause, sponsorships), nil
+}
+
+// settleCause writes the cause's sponsor statements through r.
+func settleCause(r repo.Repositories, causeID string, now time.Time) (bool, error) {
+	cause, err := r.Causes.GetByID(causeID)
+	if err != nil {
+		return false, err
+	}
+	sponsorships, err := r.SponsorCauses.GetByCauseID(cause.ID)
+	if err != nil {
+		return false, err
+	}
+	if len(sponsorships) == 0 {
+		return false, nil
+	}
+	statements := challengeModel.NewCauseFunding(cause, sponsorships).Statements(now)
+	return r.Statements.SaveStatements(cause.ID, statements)
+}
+
+// settleChallengeCauses settles every cause of a closing challenge through r, so the
+// statements commit with the close.
+func (s *ChallengeService) settleChallengeCauses(r repo.Repositories, challenge *challengeModel.Challenge, now time.Time) error {
+	if s.statementRepo == nil {
+		return nil
+	}
+	causes, err := r.Causes.GetByChallengeID(challenge.ID)
+	if err != nil {
+		return err
+	}
+	for _, cause := range causes {
+		if _, err := settleCause(r, cause.ID, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListCauseStatements returns the settled cause's sponsor statements, largest first.
+func (s *ChallengeService) ListCauseStatements(causeID string) ([]*challengeModel.SponsorStatement, error) {
+	if s.statementRepo == nil {
+		return nil, challengeModel.ErrFundingNotEnabled
+	}
+	return s.statementRepo.ListByCause(causeID)
+}
+
+// ListSponsorStatements returns the sponsor's statements across causes, latest first.
+func (s *ChallengeService) ListSponsorStatements(sponsorID string) ([]*challengeModel.SponsorStatement, error) {
+	if s.statementRepo == nil {
+		return nil, challengeModel.ErrFundingNotEnabled
+	}
+	return s.statementRepo.ListBySponsor(sponsorID)
+}
@@ -0,0 +1,32 @@
+package challenge
+
+import (
+	"context"
+	"log/slog"
+
+	mediaModel "gopi.com/internal/domain/media/model"
+)
+
+// GalleryRemover deletes the image and video gallery of a subject that is being deleted.
+type GalleryRemover interface {
+	DeleteGallery(ctx context.Context, kind mediaModel.SubjectKind, subjectID string) error
+}
+
+// WithGalleries removes the galleries of deleted challenges and causes. Without it, their
+// gallery items are left behind.
+func WithGalleries(galleries GalleryRemover) Option {
+	return func(s *ChallengeService) {
+		s.galleries = galleries
+	}
+}
+
+// deleteGallery removes the gallery of a subject that has been deleted. The subject is gone
+// whatever happens here, so a failure is logged rather than returned.
+func (s *ChallengeService) deleteGallery(kind mediaModel.SubjectKind, subjectID string) {
+	if s.galleries == nil {
+		return
+	}
+	if err := s.galleries.DeleteGallery(context.Background(), kind, subjectID); err != nil {
+		slog.Error("gallery delete failed", "subject_kind", kind, "subject_id", subjectID, "err", err)
+	}
+}
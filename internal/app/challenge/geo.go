@@ -0,0 +1,27 @@
+package challenge
+
+import (
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/geo"
+)
+
+// WithGeocoder positions challenges and causes created with a location but no coordinates.
+// Without it, they only have a position when one is supplied.
+func WithGeocoder(geocoder geo.Geocoder) Option {
+	return func(s *ChallengeService) {
+		s.geocoder = geocoder
+	}
+}
+
+// ListChallengesNear returns a page of the challenges within near, nearest first, with the
+// total number of matches.
+func (s *ChallengeService) ListChallengesNear(near model.Near, limit, offset int) ([]*challengeModel.Challenge, int64, error) {
+	return s.challengeRepo.ListNear(near, limit, offset)
+}
+
+// ListCausesNear returns a page of the causes within near, nearest first, with the total
+// number of matches.
+func (s *ChallengeService) ListCausesNear(near model.Near, limit, offset int) ([]*challengeModel.Cause, int64, error) {
+	return s.causeRepo.ListNear(near, limit, offset)
+}
@@ -0,0 +1,347 @@
+package challenge
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/challenge/repo"
+)
+
+// WithJudging enables judging rounds, in which staff-appointed judges score a challenge's
+// causes against a rubric and members vote for them. Rounds are tallied when they close, and a
+// challenge's final round awards its CausePrice.
+func WithJudging(judgingRepo repo.JudgingRepository) Option {
+	return func(s *ChallengeService) {
+		s.judgingRepo = judgingRepo
+	}
+}
+
+// CreateJudgingRound validates the round and adds it to its challenge. A challenge has at
+// most one final round, which a unique index enforces against concurrent creates.
+func (s *ChallengeService) CreateJudgingRound(round *challengeModel.JudgingRound) error {
+	if s.judgingRepo == nil {
+		return challengeModel.ErrJudgingNotEnabled
+	}
+	if err := round.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.challengeRepo.GetByID(round.ChallengeID); err != nil {
+		return err
+	}
+	if round.Final {
+		exists, err := s.hasFinalRound(round.ChallengeID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return challengeModel.ErrFinalRoundExists
+		}
+	}
+	if err := s.judgingRepo.CreateRound(round); err != nil {
+		// A concurrent create may have won the unique index.
+		if exists, _ := s.hasFinalRound(round.ChallengeID); round.Final && exists {
+			return challengeModel.ErrFinalRoundExists
+		}
+		return err
+	}
+	return nil
+}
+
+// hasFinalRound reports whether the challenge already has a final round.
+func (s *ChallengeService) hasFinalRound(challengeID string) (bool, error) {
+	rounds, err := s.judgingRepo.ListRounds(challengeID)
+	if err != nil {
+		return false, err
+	}
+	for _, existing := range rounds {
+		if existing.Final {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ListJudgingRounds returns the challenge's rounds in the order they open.
+func (s *ChallengeService) ListJudgingRounds(challengeID string) ([]*challengeModel.JudgingRound, error) {
+	if s.judgingRepo == nil {
+		return nil, challengeModel.ErrJudgingNotEnabled
+	}
+	return s.judgingRepo.ListRounds(challengeID)
+}
+
+func (s *ChallengeService) GetJudgingRound(id string) (*challengeModel.JudgingRound, error) {
+	if s.judgingRepo == nil {
+		return nil, challengeModel.ErrJudgingNotEnabled
+	}
+	return s.judgingRepo.GetRound(id)
+}
+
+// ListJudges returns the round's judges in the order they were appointed.
+func (s *ChallengeService) ListJudges(roundID string) ([]*challengeModel.Judge, error) {
+	if s.judgingRepo == nil {
+		return nil, challengeModel.ErrJudgingNotEnabled
+	}
+	return s.judgingRepo.ListJudges(roundID)
+}
+
+// AppointJudge makes the user a judge of the round until its results are tallied. A zero
+// weight counts the judge's scores once.
+func (s *ChallengeService) AppointJudge(roundID, userID string, weight float64, staffID string) (*challengeModel.Judge, error) {
+	if s.judgingRepo == nil {
+		return nil, challengeModel.ErrJudgingNotEnabled
+	}
+	if weight < 0 {
+		return nil, challengeModel.ErrInvalidJudgeWeight
+	}
+	if weight == 0 {
+		weight = 1
+	}
+	round, err := s.judgingRepo.GetRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round.ClosedAt != nil {
+		return nil, challengeModel.ErrRoundClosed
+	}
+
+	existing, err := s.judgingRepo.GetJudge(round.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, challengeModel.ErrAlreadyJudge
+	}
+	judge := &challengeModel.Judge{RoundID: round.ID, UserID: userID, Weight: weight, AppointedBy: staffID}
+	if err := s.judgingRepo.AddJudge(judge); err != nil {
+		// A concurrent appointment may have won the unique index.
+		if existing, _ := s.judgingRepo.GetJudge(round.ID, userID); existing != nil {
+			return nil, challengeModel.ErrAlreadyJudge
+		}
+		return nil, err
+	}
+	return judge, nil
+}
+
+// RemoveJudge withdraws the user from judging the round. Scores they already gave stop
+// counting.
+func (s *ChallengeService) RemoveJudge(roundID, userID string) error {
+	if s.judgingRepo == nil {
+		return challengeModel.ErrJudgingNotEnabled
+	}
+	round, err := s.judgingRepo.GetRound(roundID)
+	if err != nil {
+		return err
+	}
+	if round.ClosedAt != nil {
+		return challengeModel.ErrRoundClosed
+	}
+	removed, err := s.judgingRepo.RemoveJudge(round.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return challengeModel.ErrNotJudge
+	}
+	return nil
+}
+
+// ScoreCause records a judge's rubric scores for a cause while the round is open, replacing
+// any score they gave it before.
+func (s *ChallengeService) ScoreCause(roundID, judgeID, causeID string, scores map[string]float64, comment string, now time.Time) (*challengeModel.JudgeScore, error) {
+	if s.judgingRepo == nil {
+		return nil, challengeModel.ErrJudgingNotEnabled
+	}
+	round, err := s.judgingRepo.GetRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round.Status(now) != challengeModel.RoundOpen {
+		return nil, challengeModel.ErrRoundNotOpen
+	}
+	judge, err := s.judgingRepo.GetJudge(round.ID, judgeID)
+	if err != nil {
+		return nil, err
+	}
+	if judge == nil {
+		return nil, challengeModel.ErrNotJudge
+	}
+	if err := s.checkRoundCause(round, causeID); err != nil {
+		return nil, err
+	}
+	total, err := round.Score(scores)
+	if err != nil {
+		return nil, err
+	}
+
+	score := &challengeModel.JudgeScore{
+		RoundID: round.ID,
+		CauseID: causeID,
+		JudgeID: judgeID,
+		Scores:  scores,
+		Total:   total,
+		Comment: strings.TrimSpace(comment),
+	}
+	if err := s.judgingRepo.SaveScore(score); err != nil {
+		return nil, err
+	}
+	return score, nil
+}
+
+// ListJudgeScores returns every judge's scores in the round, grouped by cause.
+func (s *ChallengeService) ListJudgeScores(roundID string) ([]*challengeModel.JudgeScore, error) {
+	if s.judgingRepo == nil {
+		return nil, challengeModel.ErrJudgingNotEnabled
+	}
+	return s.judgingRepo.ListScores(roundID)
+}
+
+// CastCauseVote records a challenge member's community vote for a cause while the round is
+// open. Each member votes once per round.
+func (s *ChallengeService) CastCauseVote(roundID, userID, causeID string, now time.Time) (*challengeModel.Vote, error) {
+	if s.judgingRepo == nil {
+		return nil, challengeModel.ErrJudgingNotEnabled
+	}
+	round, err := s.judgingRepo.GetRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round.Status(now) != challengeModel.RoundOpen {
+		return nil, challengeModel.ErrRoundNotOpen
+	}
+	isMember, err := s.challengeRepo.IsMember(round.ChallengeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, challengeModel.ErrNotMember
+	}
+	if err := s.checkRoundCause(round, causeID); err != nil {
+		return nil, err
+	}
+
+	vote := &challengeModel.Vote{RoundID: round.ID, CauseID: causeID, UserID: userID}
+	cast, err := s.judgingRepo.CastVote(vote)
+	if err != nil {
+		return nil, err
+	}
+	if !cast {
+		return nil, challengeModel.ErrAlreadyVoted
+	}
+	return vote, nil
+}
+
+// checkRoundCause checks the cause belongs to the round's challenge.
+func (s *ChallengeService) checkRoundCause(round *challengeModel.JudgingRound, causeID string) error {
+	cause, err := s.causeRepo.GetByID(causeID)
+	if err != nil {
+		return err
+	}
+	if cause.ChallengeID != round.ChallengeID {
+		return challengeModel.ErrCauseNotInChallenge
+	}
+	return nil
+}
+
+// CloseDueJudgingRounds tallies every round whose closing time has passed and returns how
+// many it closed. A failure on one round is logged and does not stop the others.
+func (s *ChallengeService) CloseDueJudgingRounds(now time.Time) (int, error) {
+	if s.judgingRepo == nil {
+		return 0, nil
+	}
+
+	due, err := s.judgingRepo.ListDueRounds(now)
+	if err != nil {
+		return 0, err
+	}
+	closed := 0
+	for _, round := range due {
+		ok, err := s.CloseJudgingRound(round, now)
+		if err != nil {
+			slog.Error("judging round tally failed", "round_id", round.ID, "err", err)
+			continue
+		}
+		if ok {
+			closed++
+		}
+	}
+	return closed, nil
+}
+
+// CloseJudgingRound tallies the round's judge scores and votes into its results. The close and
+// its results are written in one unit of work, so a failed tally leaves the round open for the
+// next tick. It runs at most once per round and reports whether this call closed it.
+func (s *ChallengeService) CloseJudgingRound(round *challengeModel.JudgingRound, now time.Time) (bool, error) {
+	if s.judgingRepo == nil {
+		return false, challengeModel.ErrJudgingNotEnabled
+	}
+
+	closed := false
+	err := s.uow.Do(func(r repo.Repositories) error {
+		ok, err := r.Judging.MarkRoundClosed(round.ID, now)
+		if err != nil || !ok {
+			return err
+		}
+		challenge, err := r.Challenges.GetByID(round.ChallengeID)
+		if err != nil {
+			return err
+		}
+		judges, err := r.Judging.ListJudges(round.ID)
+		if err != nil {
+			return err
+		}
+		scores, err := r.Judging.ListScores(round.ID)
+		if err != nil {
+			return err
+		}
+		votes, err := r.Judging.CountVotes(round.ID)
+		if err != nil {
+			return err
+		}
+		if err := r.Judging.SaveResults(round.ID, round.Tally(judges, scores, votes, challenge)); err != nil {
+			return err
+		}
+		closed = true
+		return nil
+	})
+	if err != nil || !closed {
+		return false, err
+	}
+	round.ClosedAt = &now
+	return true, nil
+}
+
+// GetJudgingResults returns a closed round's results by rank.
+func (s *ChallengeService) GetJudgingResults(roundID string) ([]*challengeModel.RoundResult, error) {
+	if s.judgingRepo == nil {
+		return nil, challengeModel.ErrJudgingNotEnabled
+	}
+	round, err := s.judgingRepo.GetRound(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round.ClosedAt == nil {
+		return nil, challengeModel.ErrRoundNotClosed
+	}
+	return s.judgingRepo.GetResults(round.ID)
+}
+
+// GetCauseAwards returns the challenge's final round and its results, whose places carry the
+// challenge's CausePrice. Both are nil until the final round closes.
+func (s *ChallengeService) GetCauseAwards(challengeID string) (*challengeModel.JudgingRound, []*challengeModel.RoundResult, error) {
+	if s.judgingRepo == nil {
+		return nil, nil, challengeModel.ErrJudgingNotEnabled
+	}
+	rounds, err := s.judgingRepo.ListRounds(challengeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, round := range rounds {
+		if round.Final && round.ClosedAt != nil {
+			results, err := s.judgingRepo.GetResults(round.ID)
+			return round, results, err
+		}
+	}
+	return nil, nil, nil
+}
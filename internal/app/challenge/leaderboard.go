@@ -0,0 +1,83 @@
+package challenge
+
+import (
+	"time"
+
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/leaderboard"
+)
+
+// WithLeaderboards serves challenge and cause leaderboards from l, which may keep them in
+// Redis. Without it every query ranks straight from the database.
+func WithLeaderboards(l *leaderboard.Leaderboards) Option {
+	return func(s *ChallengeService) {
+		s.leaderboards = l
+	}
+}
+
+// ChallengeLeaderboard ranks users by the counted runs they logged across every cause of the
+// challenge.
+func (s *ChallengeService) ChallengeLeaderboard(challengeID string, q leaderboard.Query) (*leaderboard.Standings, error) {
+	scope := leaderboard.Scope{Kind: leaderboard.ScopeChallenge, ID: challengeID}
+	return s.leaderboards.Standings(scope, q, func(since time.Time, activity string) ([]*leaderboard.Run, error) {
+		causes, err := s.causeRepo.GetByChallengeID(challengeID)
+		if err != nil {
+			return nil, err
+		}
+		causeIDs := make([]string, 0, len(causes))
+		for _, cause := range causes {
+			causeIDs = append(causeIDs, cause.ID)
+		}
+		return s.leaderboardRuns(causeIDs, since, activity)
+	})
+}
+
+// CauseLeaderboard ranks users by the counted runs they logged in the cause.
+func (s *ChallengeService) CauseLeaderboard(causeID string, q leaderboard.Query) (*leaderboard.Standings, error) {
+	scope := leaderboard.Scope{Kind: leaderboard.ScopeCause, ID: causeID}
+	return s.leaderboards.Standings(scope, q, func(since time.Time, activity string) ([]*leaderboard.Run, error) {
+		return s.leaderboardRuns([]string{causeID}, since, activity)
+	})
+}
+
+func (s *ChallengeService) leaderboardRuns(causeIDs []string, since time.Time, activity string) ([]*leaderboard.Run, error) {
+	runners, err := s.causeRunnerRepo.ListForLeaderboard(causeIDs, since, activity)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]*leaderboard.Run, 0, len(runners))
+	for _, runner := range runners {
+		runs = append(runs, &leaderboard.Run{
+			UserID:      runner.OwnerID,
+			Activity:    runner.Activity,
+			Distance:    runner.DistanceCovered,
+			Duration:    runner.Duration,
+			MoneyRaised: runner.MoneyRaised,
+			CoverImage:  runner.CoverImage,
+		})
+	}
+	return runs, nil
+}
+
+// refreshCauseBoards drops the stored leaderboards a cause's runs count towards. The cause is
+// only loaded when boards are stored.
+func (s *ChallengeService) refreshCauseBoards(causeID string) {
+	if !s.leaderboards.Cached() {
+		return
+	}
+	cause, err := s.causeRepo.GetByID(causeID)
+	if err != nil {
+		cause = &challengeModel.Cause{Base: model.Base{ID: causeID}}
+	}
+	s.leaderboards.Invalidate(causeScopes(cause)...)
+}
+
+// causeScopes are the leaderboards a cause's runs count towards.
+func causeScopes(cause *challengeModel.Cause) []leaderboard.Scope {
+	scopes := []leaderboard.Scope{{Kind: leaderboard.ScopeCause, ID: cause.ID}}
+	if cause.ChallengeID != "" {
+		scopes = append(scopes, leaderboard.Scope{Kind: leaderboard.ScopeChallenge, ID: cause.ChallengeID})
+	}
+	return scopes
+}
@@ -0,0 +1,175 @@
+package challenge
+
+import (
+	"context"
+	"time"
+
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	mediaModel "gopi.com/internal/domain/media/model"
+	"gopi.com/internal/lib/geo"
+)
+
+// UpdateChallenge saves edits to a challenge that has neither closed nor been cancelled. A
+// challenge without coordinates is re-geocoded from its location.
+func (s *ChallengeService) UpdateChallenge(challenge *challengeModel.Challenge) error {
+	if challenge.Finished() {
+		return challengeModel.ErrChallengeFinished
+	}
+	if !challenge.RankingCriterion.Valid() {
+		return challengeModel.ErrInvalidRankingCriterion
+	}
+	challenge.RankingCriterion = challenge.RankingCriterion.OrDefault()
+	var err error
+	if challenge.WinningPrice, err = challengeModel.NormalizePrizes(challenge.WinningPrice); err != nil {
+		return err
+	}
+	if challenge.CausePrice, err = challengeModel.NormalizePrizes(challenge.CausePrice); err != nil {
+		return err
+	}
+	if challenge.Coordinates == nil {
+		challenge.Coordinates = geo.Locate(context.Background(), s.geocoder, challenge.Location)
+	}
+	challenge.UpdatedAt = time.Now()
+	return s.challengeRepo.Update(challenge)
+}
+
+// CancelChallenge calls off a challenge that has neither closed nor been cancelled. It is never
+// closed out, so no winners are chosen and its sponsors are not settled.
+func (s *ChallengeService) CancelChallenge(id string, now time.Time) (*challengeModel.Challenge, error) {
+	ok, err := s.challengeRepo.MarkCancelled(id, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, challengeModel.ErrChallengeFinished
+	}
+	return s.challengeRepo.GetByID(id)
+}
+
+// DeleteChallenge removes the challenge with its causes, runners, sponsorships, members and
+// galleries. Unless forced, it refuses while sponsors of the challenge or its causes are still owed a
+// settlement; it always refuses once a cause's sponsors have been settled.
+func (s *ChallengeService) DeleteChallenge(id string, force bool) error {
+	challenge, err := s.challengeRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	causes, err := s.causeRepo.GetByChallengeID(id)
+	if err != nil {
+		return err
+	}
+	for _, cause := range causes {
+		if err := s.checkCauseDelete(challenge, cause.ID, force); err != nil {
+			return err
+		}
+	}
+	// Challenge pledges are owed until it closes.
+	if !force && challenge.ClosedAt == nil {
+		sponsorships, err := s.sponsorRepo.GetByChallengeID(id)
+		if err != nil {
+			return err
+		}
+		if len(sponsorships) > 0 {
+			return challengeModel.ErrOutstandingSponsorships
+		}
+	}
+	if err := s.challengeRepo.Delete(id); err != nil {
+		return err
+	}
+	s.deleteGallery(mediaModel.SubjectChallenge, id)
+	for _, cause := range causes {
+		s.deleteGallery(mediaModel.SubjectCause, cause.ID)
+	}
+	return nil
+}
+
+// TransferChallenge hands the challenge from its owner, fromID, to toID.
+func (s *ChallengeService) TransferChallenge(id, fromID, toID string) (*challengeModel.Challenge, error) {
+	if fromID == toID {
+		return nil, challengeModel.ErrAlreadyOwner
+	}
+	ok, err := s.challengeRepo.TransferOwner(id, fromID, toID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, challengeModel.ErrOwnerChanged
+	}
+	return s.challengeRepo.GetByID(id)
+}
+
+// UpdateCause saves edits to a cause. A cause without coordinates is re-geocoded from its
+// location.
+func (s *ChallengeService) UpdateCause(cause *challengeModel.Cause) error {
+	if cause.Coordinates == nil {
+		cause.Coordinates = geo.Locate(context.Background(), s.geocoder, cause.Location)
+	}
+	cause.UpdatedAt = time.Now()
+	return s.causeRepo.Update(cause)
+}
+
+// DeleteCause removes the cause with its runners, sponsorships, orders, members and gallery. Unless
+// forced, it refuses while its sponsors are still owed a settlement; it always refuses once
+// they have been settled.
+func (s *ChallengeService) DeleteCause(id string, force bool) error {
+	cause, err := s.causeRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	challenge, err := s.challengeRepo.GetByID(cause.ChallengeID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkCauseDelete(challenge, cause.ID, force); err != nil {
+		return err
+	}
+	if err := s.causeRepo.Delete(id); err != nil {
+		return err
+	}
+	s.deleteGallery(mediaModel.SubjectCause, id)
+	return nil
+}
+
+// TransferCause hands the cause from its owner, fromID, to toID.
+func (s *ChallengeService) TransferCause(id, fromID, toID string) (*challengeModel.Cause, error) {
+	if fromID == toID {
+		return nil, challengeModel.ErrAlreadyOwner
+	}
+	ok, err := s.causeRepo.TransferOwner(id, fromID, toID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, challengeModel.ErrOwnerChanged
+	}
+	return s.causeRepo.GetByID(id)
+}
+
+// checkCauseDelete returns ErrSponsorsSettled if the cause has sponsor statements, which a
+// delete would lose, and unless forced, ErrOutstandingSponsorships if its sponsors are still
+// owed a settlement. Without funding a cause counts as settled once its challenge closes.
+func (s *ChallengeService) checkCauseDelete(challenge *challengeModel.Challenge, causeID string, force bool) error {
+	settled := challenge.ClosedAt != nil
+	if s.statementRepo != nil {
+		statements, err := s.statementRepo.ListByCause(causeID)
+		if err != nil {
+			return err
+		}
+		if len(statements) > 0 {
+			return challengeModel.ErrSponsorsSettled
+		}
+		settled = false
+	}
+	if force || settled {
+		return nil
+	}
+
+	sponsorships, err := s.sponsorCauseRepo.GetByCauseID(causeID)
+	if err != nil {
+		return err
+	}
+	if len(sponsorships) > 0 {
+		return challengeModel.ErrOutstandingSponsorships
+	}
+	return nil
+}
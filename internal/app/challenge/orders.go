@@ -0,0 +1,238 @@
+package challenge
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"strings"
+	"time"
+
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/challenge/repo"
+	userRepo "gopi.com/internal/domain/user/repo"
+	"gopi.com/internal/lib/email"
+)
+
+// WithCauseOrders enables ordering units of commercial causes. Orders are priced from the
+// cause's UnitPrice, limited by its stock once paid, and the buyer is emailed a receipt when
+// they pay and a notice when they are refunded.
+func WithCauseOrders(orderRepo repo.CauseOrderRepository, userRepo userRepo.UserRepository, emailService email.EmailServiceInterface) Option {
+	return func(s *ChallengeService) {
+		s.orderRepo = orderRepo
+		s.userRepo = userRepo
+		s.emailService = emailService
+	}
+}
+
+// PlaceCauseOrder opens a pending order for quantity units of the cause. The amount the buyer
+// was shown must match the quantity at the cause's current unit price. Stock is only taken
+// when the order is paid, so an unpaid order holds none.
+func (s *ChallengeService) PlaceCauseOrder(causeID, buyerID string, quantity int, amount float64) (*challengeModel.CauseOrder, error) {
+	if s.orderRepo == nil {
+		return nil, challengeModel.ErrOrdersNotEnabled
+	}
+	cause, err := s.causeRepo.GetByID(causeID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := cause.NewOrder(buyerID, quantity, amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.orderRepo.Create(order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (s *ChallengeService) GetCauseOrder(id string) (*challengeModel.CauseOrder, error) {
+	if s.orderRepo == nil {
+		return nil, challengeModel.ErrOrdersNotEnabled
+	}
+	return s.orderRepo.GetByID(id)
+}
+
+// ListBuyerOrders returns a page of the buyer's orders, newest first, and their total count.
+func (s *ChallengeService) ListBuyerOrders(buyerID string, limit, offset int) ([]*challengeModel.CauseOrder, int64, error) {
+	if s.orderRepo == nil {
+		return nil, 0, challengeModel.ErrOrdersNotEnabled
+	}
+	return s.orderRepo.ListByBuyer(buyerID, limit, offset)
+}
+
+// ListCauseOrders returns a page of the cause's orders, newest first, and their total count.
+func (s *ChallengeService) ListCauseOrders(causeID string, limit, offset int) ([]*challengeModel.CauseOrder, int64, error) {
+	if s.orderRepo == nil {
+		return nil, 0, challengeModel.ErrOrdersNotEnabled
+	}
+	return s.orderRepo.ListByCause(causeID, limit, offset)
+}
+
+// PayCauseOrder confirms payment of a pending order, selling its units, and emails the buyer a
+// receipt. It fails with ErrOutOfStock when the units sold out after the order was placed.
+func (s *ChallengeService) PayCauseOrder(id, paymentRef string, now time.Time) (*challengeModel.CauseOrder, error) {
+	order, err := s.GetCauseOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	order.PaymentRef = strings.TrimSpace(paymentRef)
+	if err := s.transitionOrder(order, challengeModel.OrderPaid, now, order.Quantity); err != nil {
+		return nil, err
+	}
+	s.emailOrder(order, orderReceiptEmail)
+	return order, nil
+}
+
+// FulfilCauseOrder records that a paid order was delivered.
+func (s *ChallengeService) FulfilCauseOrder(id string, now time.Time) (*challengeModel.CauseOrder, error) {
+	order, err := s.GetCauseOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.transitionOrder(order, challengeModel.OrderFulfilled, now, 0); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// RefundCauseOrder pays back a paid or fulfilled order, returning its units to stock, and
+// emails the buyer a refund notice.
+func (s *ChallengeService) RefundCauseOrder(id string, now time.Time) (*challengeModel.CauseOrder, error) {
+	order, err := s.GetCauseOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	soldDelta := 0
+	if order.Status.Sold() {
+		soldDelta = -order.Quantity
+	}
+	if err := s.transitionOrder(order, challengeModel.OrderRefunded, now, soldDelta); err != nil {
+		return nil, err
+	}
+	s.emailOrder(order, orderRefundEmail)
+	return order, nil
+}
+
+// transitionOrder moves the order to the next status if its lifecycle allows it, shifting its
+// cause's units sold by soldDelta.
+func (s *ChallengeService) transitionOrder(order *challengeModel.CauseOrder, to challengeModel.OrderStatus, now time.Time, soldDelta int) error {
+	from := order.Status
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: cannot move from %s to %s", challengeModel.ErrInvalidOrderState, from, to)
+	}
+	moved, err := s.orderRepo.Transition(order, to, now, soldDelta)
+	if err != nil {
+		return err
+	}
+	if !moved {
+		// Another request moved the order first.
+		return fmt.Errorf("%w: order is no longer %s", challengeModel.ErrInvalidOrderState, from)
+	}
+	return nil
+}
+
+// SetCauseStock limits how many units of the cause can be sold in all, or lifts the limit when
+// stock is nil. The limit cannot go below the units already sold.
+func (s *ChallengeService) SetCauseStock(causeID string, stock *int) (*challengeModel.Cause, error) {
+	if s.orderRepo == nil {
+		return nil, challengeModel.ErrOrdersNotEnabled
+	}
+	if stock != nil && *stock < 0 {
+		return nil, challengeModel.ErrInvalidStock
+	}
+	cause, err := s.causeRepo.GetByID(causeID)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.orderRepo.SetStock(cause.ID, stock)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, challengeModel.ErrInvalidStock
+	}
+	return s.causeRepo.GetByID(cause.ID)
+}
+
+// emailOrder sends the buyer the order email. Failures are logged rather than returned because
+// the order has already moved on.
+func (s *ChallengeService) emailOrder(order *challengeModel.CauseOrder, tmpl *orderEmail) {
+	if s.emailService == nil || s.userRepo == nil {
+		return
+	}
+	user, err := s.userRepo.GetByID(order.BuyerID)
+	if err != nil || user == nil || user.Email == "" {
+		return
+	}
+	data := orderEmailData{Order: order, CauseName: order.CauseID}
+	if cause, err := s.causeRepo.GetByID(order.CauseID); err == nil && cause != nil {
+		data.CauseName = cause.Name
+	}
+
+	subject := fmt.Sprintf(tmpl.subject, data.CauseName)
+	var buf bytes.Buffer
+	err = tmpl.body.Execute(&buf, data)
+	if err == nil {
+		err = s.emailService.SendBulkEmail([]string{user.Email}, subject, buf.String())
+	}
+	if err != nil {
+		slog.Error("cause order email failed", "order_id", order.ID, "status", order.Status, "err", err)
+	}
+}
+
+type orderEmail struct {
+	subject string // format taking the cause name
+	body    *template.Template
+}
+
+type orderEmailData struct {
+	Order     *challengeModel.CauseOrder
+	CauseName string
+}
+
+var orderReceiptEmail = &orderEmail{
+	subject: "Your receipt for %s",
+	body: template.Must(template.New("order_receipt").Parse(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background-color: #28a745; color: white; padding: 20px; text-align: center;">
+		<h1>Thank you for your order</h1>
+	</div>
+	<div style="padding: 20px;">
+		<p>Your payment for {{.CauseName}} has been received.</p>
+		<p><strong>Order:</strong> {{.Order.ID}}</p>
+		<p><strong>Quantity:</strong> {{.Order.Quantity}} x {{printf "%.2f" .Order.UnitPrice}}</p>
+		<p><strong>Total paid:</strong> {{printf "%.2f" .Order.Amount}}</p>
+		{{if .Order.PaymentRef}}<p><strong>Payment reference:</strong> {{.Order.PaymentRef}}</p>{{end}}
+		{{if .Order.PaidAt}}<p><strong>Paid on:</strong> {{.Order.PaidAt.Format "2 Jan 2006 15:04 MST"}}</p>{{end}}
+	</div>
+	<div style="background-color: #f8f9fa; padding: 20px; text-align: center; color: #6c757d;">
+		<p>&copy; 2024 GoPadi. All rights reserved.</p>
+	</div>
+</body>
+</html>
+`)),
+}
+
+var orderRefundEmail = &orderEmail{
+	subject: "Your order for %s has been refunded",
+	body: template.Must(template.New("order_refund").Parse(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background-color: #6c757d; color: white; padding: 20px; text-align: center;">
+		<h1>Your order has been refunded</h1>
+	</div>
+	<div style="padding: 20px;">
+		<p>Your order for {{.CauseName}} has been refunded.</p>
+		<p><strong>Order:</strong> {{.Order.ID}}</p>
+		<p><strong>Quantity:</strong> {{.Order.Quantity}}</p>
+		<p><strong>Amount refunded:</strong> {{printf "%.2f" .Order.Amount}}</p>
+	</div>
+	<div style="background-color: #f8f9fa; padding: 20px; text-align: center; color: #6c757d;">
+		<p>&copy; 2024 GoPadi. All rights reserved.</p>
+	</div>
+</body>
+</html>
+`)),
+}
@@ -0,0 +1,84 @@
+package challenge
+
+import (
+	"time"
+
+	activityModel "gopi.com/internal/domain/activity/model"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/challenge/repo"
+	trackModel "gopi.com/internal/domain/track/model"
+)
+
+// WithAntiCheat checks every cause activity against rules. Activities that break a rule are
+// saved as flagged and left out of the cause total and leaderboard until staff approve them.
+func WithAntiCheat(rules *activityModel.Rules) Option {
+	return func(s *ChallengeService) {
+		s.rules = rules
+	}
+}
+
+// checkCauseRun returns the rules runner breaks. replaced is distance already counted today
+// that this activity supersedes. It is a no-op when anti-cheat is disabled.
+func (s *ChallengeService) checkCauseRun(runner *challengeModel.CauseRunner, track *trackModel.Track, replaced float64) ([]activityModel.Violation, error) {
+	if s.rules == nil || runner.DistanceCovered <= 0 {
+		return nil, nil
+	}
+
+	today, err := s.causeRunnerRepo.SumDistanceSince(runner.OwnerID, activityModel.StartOfDay(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	return s.rules.Check(activityModel.Run{
+		Activity:      runner.Activity,
+		Distance:      runner.DistanceCovered,
+		Duration:      runner.Duration,
+		DistanceToday: today - replaced,
+		Track:         track,
+	}), nil
+}
+
+// ListFlaggedCauseRunners returns the cause activity review queue, oldest first.
+func (s *ChallengeService) ListFlaggedCauseRunners(limit, offset int) ([]*challengeModel.CauseRunner, error) {
+	return s.causeRunnerRepo.ListByStatus(activityModel.ReviewStatusFlagged, limit, offset)
+}
+
+// ReviewCauseRunner approves or rejects a flagged cause activity. Approval adds its distance
+// to the cause total.
+func (s *ChallengeService) ReviewCauseRunner(runnerID, reviewerID string, approve bool) (*challengeModel.CauseRunner, error) {
+	runner, err := s.causeRunnerRepo.GetByID(runnerID)
+	if err != nil {
+		return nil, err
+	}
+	if runner.Status != activityModel.ReviewStatusFlagged {
+		return nil, activityModel.ErrNotFlagged
+	}
+
+	now := time.Now()
+	status := activityModel.ReviewStatusRejected
+	if approve {
+		status = activityModel.ReviewStatusApproved
+	}
+
+	err = s.uow.Do(func(r repo.Repositories) error {
+		ok, err := r.CauseRunners.Review(runnerID, status, reviewerID, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return activityModel.ErrNotFlagged // reviewed concurrently
+		}
+		if !approve {
+			return nil
+		}
+		return r.Causes.IncrementDistance(runner.CauseID, runner.DistanceCovered)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	runner.Status, runner.ReviewedBy, runner.ReviewedAt = status, reviewerID, &now
+	if approve {
+		s.refreshCauseBoards(runner.CauseID)
+	}
+	return runner, nil
+}
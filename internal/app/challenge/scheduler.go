@@ -0,0 +1,64 @@
+package challenge
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Scheduler periodically closes out the challenges that have ended, tallies the judging rounds
+// that have closed and decides the bracket matches whose round has ended.
+type Scheduler struct {
+	service  *ChallengeService
+	interval time.Duration
+}
+
+func NewScheduler(service *ChallengeService, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{service: service, interval: interval}
+}
+
+// Start runs the scheduler in the background until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	go s.run(ctx)
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.tick(time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.tick(now)
+		}
+	}
+}
+
+func (s *Scheduler) tick(now time.Time) {
+	closed, err := s.service.CloseDueChallenges(now)
+	if err != nil {
+		slog.Error("challenge close-out tick failed", "err", err)
+	} else if closed > 0 {
+		slog.Info("challenges closed out", "challenges", closed)
+	}
+
+	rounds, err := s.service.CloseDueJudgingRounds(now)
+	if err != nil {
+		slog.Error("judging round tally tick failed", "err", err)
+	} else if rounds > 0 {
+		slog.Info("judging rounds tallied", "rounds", rounds)
+	}
+
+	matches, err := s.service.DecideDueMatches(now)
+	if err != nil {
+		slog.Error("bracket match tick failed", "err", err)
+	} else if matches > 0 {
+		slog.Info("bracket matches decided", "matches", matches)
+	}
+}
@@ -1,12 +1,20 @@
 package challenge
 
 import (
+	"context"
 	"time"
 
+	activityModel "gopi.com/internal/domain/activity/model"
 	challengeModel "gopi.com/internal/domain/challenge/model"
 	"gopi.com/internal/domain/challenge/repo"
 	"gopi.com/internal/domain/model"
+	notificationRepo "gopi.com/internal/domain/notification/repo"
+	trackModel "gopi.com/internal/domain/track/model"
+	userRepo "gopi.com/internal/domain/user/repo"
+	"gopi.com/internal/lib/email"
+	"gopi.com/internal/lib/geo"
 	"gopi.com/internal/lib/id"
+	"gopi.com/internal/lib/leaderboard"
 )
 
 type ChallengeService struct {
@@ -16,6 +24,21 @@ type ChallengeService struct {
 	sponsorRepo       repo.SponsorChallengeRepository
 	sponsorCauseRepo  repo.SponsorCauseRepository
 	causeBuyerRepo    repo.CauseBuyerRepository
+	uow               repo.UnitOfWork
+	rules             *activityModel.Rules            // anti-cheat, set by WithAntiCheat
+	geocoder          geo.Geocoder                    // set by WithGeocoder
+	leaderboards      *leaderboard.Leaderboards       // set by WithLeaderboards
+	judgingRepo       repo.JudgingRepository          // set by WithJudging
+	orderRepo         repo.CauseOrderRepository       // set by WithCauseOrders
+	statementRepo     repo.SponsorStatementRepository // set by WithFunding
+	bracketRepo       repo.BracketRepository          // set by WithBrackets
+	galleries         GalleryRemover                  // set by WithGalleries
+
+	// close-out collaborators, set by WithWinners; WithCauseOrders also sets userRepo and emailService
+	winnerRepo       repo.ChallengeWinnerRepository
+	notificationRepo notificationRepo.NotificationRepository
+	userRepo         userRepo.UserRepository
+	emailService     email.EmailServiceInterface
 }
 
 func NewChallengeService(
@@ -25,8 +48,9 @@ func NewChallengeService(
 	sponsorRepo repo.SponsorChallengeRepository,
 	sponsorCauseRepo repo.SponsorCauseRepository,
 	causeBuyerRepo repo.CauseBuyerRepository,
+	opts ...Option,
 ) *ChallengeService {
-	return &ChallengeService{
+	s := &ChallengeService{
 		challengeRepo:    challengeRepo,
 		causeRepo:        causeRepo,
 		causeRunnerRepo:  causeRunnerRepo,
@@ -34,6 +58,46 @@ func NewChallengeService(
 		sponsorCauseRepo: sponsorCauseRepo,
 		causeBuyerRepo:   causeBuyerRepo,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.leaderboards == nil {
+		s.leaderboards = leaderboard.New(nil, 0)
+	}
+	if s.uow == nil {
+		s.uow = directUnitOfWork{repos: repo.Repositories{
+			Challenges:    challengeRepo,
+			Causes:        causeRepo,
+			CauseRunners:  causeRunnerRepo,
+			Sponsors:      sponsorRepo,
+			SponsorCauses: sponsorCauseRepo,
+			CauseBuyers:   causeBuyerRepo,
+			Winners:       s.winnerRepo,
+			Statements:    s.statementRepo,
+			Judging:       s.judgingRepo,
+		}}
+	}
+	return s
+}
+
+// Option configures optional ChallengeService collaborators.
+type Option func(*ChallengeService)
+
+// WithUnitOfWork makes cause runner and cause total writes commit atomically.
+func WithUnitOfWork(uow repo.UnitOfWork) Option {
+	return func(s *ChallengeService) {
+		s.uow = uow
+	}
+}
+
+// directUnitOfWork runs against the service's own repositories without a transaction.
+// It is used when no UnitOfWork is configured; totals are still incremented atomically.
+type directUnitOfWork struct {
+	repos repo.Repositories
+}
+
+func (u directUnitOfWork) Do(fn func(repos repo.Repositories) error) error {
+	return fn(u.repos)
 }
 
 func (s *ChallengeService) CreateChallenge(
@@ -42,7 +106,22 @@ func (s *ChallengeService) CreateChallenge(
 	distanceToCover, targetAmount, targetAmountPerKm float64,
 	startDuration, endDuration string,
 	noOfWinner int,
+	ranking challengeModel.RankingCriterion,
+	winningPrice, causePrice []challengeModel.Prize,
+	coordinates *model.GeoPoint,
 ) (*challengeModel.Challenge, error) {
+	if !ranking.Valid() {
+		return nil, challengeModel.ErrInvalidRankingCriterion
+	}
+	winningPrice, err := challengeModel.NormalizePrizes(winningPrice)
+	if err != nil {
+		return nil, err
+	}
+	causePrice, err = challengeModel.NormalizePrizes(causePrice)
+	if err != nil {
+		return nil, err
+	}
+
 	challenge := &challengeModel.Challenge{
 		Base: model.Base{
 			ID:        id.New(),
@@ -56,20 +135,27 @@ func (s *ChallengeService) CreateChallenge(
 		Condition:         condition,
 		Goal:              goal,
 		Location:          location,
+		Coordinates:       coordinates,
 		DistanceToCover:   distanceToCover,
 		TargetAmount:      targetAmount,
 		TargetAmountPerKm: targetAmountPerKm,
 		StartDuration:     startDuration,
-		EndDuration:       endDuration,
 		NoOfWinner:        noOfWinner,
-		WinningPrice:      []interface{}{},
-		CausePrice:        []interface{}{},
+		WinningPrice:      winningPrice,
+		RankingCriterion:  ranking.OrDefault(),
+		CausePrice:        causePrice,
 		Members:           []interface{}{}, // Initialize empty
 		Sponsors:          []interface{}{}, // Initialize empty
 		Slug:              generateSlug(name),
 	}
+	if err := challenge.SetEnd(endDuration); err != nil {
+		return nil, err
+	}
+	if challenge.Coordinates == nil {
+		challenge.Coordinates = geo.Locate(context.Background(), s.geocoder, location)
+	}
 
-	err := s.challengeRepo.Create(challenge)
+	err = s.challengeRepo.Create(challenge)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +169,7 @@ func (s *ChallengeService) CreateCause(
 	location, description string,
 	isCommercial bool,
 	amountPerPiece, fundAmount, willingAmount, unitPrice float64,
+	coordinates *model.GeoPoint,
 ) (*challengeModel.Cause, error) {
 	cause := &challengeModel.Cause{
 		Base: model.Base{
@@ -97,6 +184,7 @@ func (s *ChallengeService) CreateCause(
 		ProductDescription: productDescription,
 		Activity:           activity,
 		Location:           location,
+		Coordinates:        coordinates,
 		Description:        description,
 		IsCommercial:       isCommercial,
 		OwnerID:            ownerID,
@@ -108,6 +196,9 @@ func (s *ChallengeService) CreateCause(
 		Sponsors:           []interface{}{}, // Initialize empty
 		Slug:               generateSlug(name),
 	}
+	if cause.Coordinates == nil {
+		cause.Coordinates = geo.Locate(context.Background(), s.geocoder, location)
+	}
 
 	err := s.causeRepo.Create(cause)
 	if err != nil {
@@ -133,35 +224,110 @@ func (s *ChallengeService) GetCausesByChallenge(challengeID string) ([]*challeng
 	return s.causeRepo.GetByChallengeID(challengeID)
 }
 
+// JoinChallenge adds the user to the challenge's members. A cancelled challenge cannot be
+// joined.
 func (s *ChallengeService) JoinChallenge(challengeID, userID string) error {
-	// In the new implementation, we should use the junction table
-	// For now, we'll implement a basic version that works with the GORM junction tables
 	challenge, err := s.challengeRepo.GetByID(challengeID)
 	if err != nil {
 		return err
 	}
+	if challenge.CancelledAt != nil {
+		return challengeModel.ErrChallengeCancelled
+	}
 
-	// In production, you'd create an entry in the ChallengeMember junction table
-	// For now, we'll just update the challenge's updated timestamp
-	challenge.UpdatedAt = time.Now()
-	return s.challengeRepo.Update(challenge)
+	isMember, err := s.challengeRepo.IsMember(challengeID, userID)
+	if err != nil {
+		return err
+	}
+	if isMember {
+		return challengeModel.ErrAlreadyMember
+	}
+	if err := s.challengeRepo.AddMember(challengeID, userID); err != nil {
+		// A concurrent join may have won the unique index.
+		if isMember, _ := s.challengeRepo.IsMember(challengeID, userID); isMember {
+			return challengeModel.ErrAlreadyMember
+		}
+		return err
+	}
+	return nil
 }
 
+// LeaveChallenge removes the user from the challenge's members. The owner cannot leave.
+func (s *ChallengeService) LeaveChallenge(challengeID, userID string) error {
+	challenge, err := s.challengeRepo.GetByID(challengeID)
+	if err != nil {
+		return err
+	}
+	if challenge.OwnerID == userID {
+		return challengeModel.ErrOwnerCannotLeave
+	}
+
+	removed, err := s.challengeRepo.RemoveMember(challengeID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return challengeModel.ErrNotMember
+	}
+	return nil
+}
+
+// ListChallengeMembers returns a page of the challenge's members, earliest first, and how
+// many there are in total.
+func (s *ChallengeService) ListChallengeMembers(challengeID string, limit, offset int) ([]*challengeModel.Membership, int64, error) {
+	return s.challengeRepo.ListMembers(challengeID, limit, offset)
+}
+
+// JoinCause adds the user to the cause's members.
 func (s *ChallengeService) JoinCause(causeID, userID string) error {
-	// In the new implementation, we should use the junction table  
-	// For now, we'll implement a basic version that works with the GORM junction tables
+	if _, err := s.causeRepo.GetByID(causeID); err != nil {
+		return err
+	}
+
+	isMember, err := s.causeRepo.IsMember(causeID, userID)
+	if err != nil {
+		return err
+	}
+	if isMember {
+		return challengeModel.ErrAlreadyMember
+	}
+	if err := s.causeRepo.AddMember(causeID, userID); err != nil {
+		// A concurrent join may have won the unique index.
+		if isMember, _ := s.causeRepo.IsMember(causeID, userID); isMember {
+			return challengeModel.ErrAlreadyMember
+		}
+		return err
+	}
+	return nil
+}
+
+// LeaveCause removes the user from the cause's members. The owner cannot leave.
+func (s *ChallengeService) LeaveCause(causeID, userID string) error {
 	cause, err := s.causeRepo.GetByID(causeID)
 	if err != nil {
 		return err
 	}
+	if cause.OwnerID == userID {
+		return challengeModel.ErrOwnerCannotLeave
+	}
 
-	// In production, you'd create an entry in the CauseMember junction table
-	// For now, we'll just update the cause's updated timestamp
-	cause.UpdatedAt = time.Now()
-	return s.causeRepo.Update(cause)
+	removed, err := s.causeRepo.RemoveMember(causeID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return challengeModel.ErrNotMember
+	}
+	return nil
+}
+
+// ListCauseMembers returns a page of the cause's members, earliest first, and how many there
+// are in total.
+func (s *ChallengeService) ListCauseMembers(causeID string, limit, offset int) ([]*challengeModel.Membership, int64, error) {
+	return s.causeRepo.ListMembers(causeID, limit, offset)
 }
 
-func (s *ChallengeService) RecordCauseActivity(causeID, userID string, distanceToCover, distanceCovered float64, duration, activity string) error {
+func (s *ChallengeService) RecordCauseActivity(causeID, userID string, distanceToCover, distanceCovered float64, duration time.Duration, activity string) error {
 	causeRunner := &challengeModel.CauseRunner{
 		Base: model.Base{
 			ID:        id.New(),
@@ -176,21 +342,128 @@ func (s *ChallengeService) RecordCauseActivity(causeID, userID string, distanceT
 		OwnerID:         userID,
 	}
 
-	err := s.causeRunnerRepo.Create(causeRunner)
+	cause, err := s.causeRepo.GetByID(causeID)
 	if err != nil {
 		return err
 	}
 
-	// Update cause's total distance covered
-	cause, err := s.causeRepo.GetByID(causeID)
+	violations, err := s.checkCauseRun(causeRunner, nil, 0)
 	if err != nil {
 		return err
 	}
+	if len(violations) > 0 {
+		causeRunner.Status = activityModel.ReviewStatusFlagged
+		causeRunner.Violations = violations
+	} else if s.rules != nil {
+		causeRunner.Status = activityModel.ReviewStatusAccepted
+	}
+
+	err = s.uow.Do(func(r repo.Repositories) error {
+		if err := r.CauseRunners.Create(causeRunner); err != nil {
+			return err
+		}
+		// A flagged activity adds nothing to the cause until it is approved.
+		if len(violations) > 0 {
+			return nil
+		}
+		return r.Causes.IncrementDistance(causeID, distanceCovered)
+	})
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &activityModel.HeldForReviewError{Violations: violations}
+	}
 
-	cause.DistanceCovered += distanceCovered
-	cause.UpdatedAt = time.Now()
+	if s.leaderboards.Cached() {
+		s.leaderboards.Invalidate(causeScopes(cause)...)
+	}
+	return nil
+}
+
+func (s *ChallengeService) GetCauseRunnerByID(runnerID string) (*challengeModel.CauseRunner, error) {
+	return s.causeRunnerRepo.GetByID(runnerID)
+}
 
-	return s.causeRepo.Update(cause)
+// trackAttempts is how many times a track is recorded against a runner that keeps changing
+// underneath it before giving up.
+const trackAttempts = 3
+
+// RecordCauseRunTrack replaces a cause runner's reported figures with those computed from an
+// uploaded GPS track and moves the cause total by the difference. A track that breaks the
+// anti-cheat rules takes the runner's distance back out of the cause until it is reviewed.
+// The runner is only written while it still has the distance and status the difference was
+// worked out from, so a concurrent change cannot make the cause total drift.
+func (s *ChallengeService) RecordCauseRunTrack(runnerID string, track *trackModel.Track, summary trackModel.Summary, trackURL string) error {
+	for attempt := 0; attempt < trackAttempts; attempt++ {
+		runner, violations, attached, err := s.attachCauseRunTrack(runnerID, track, summary, trackURL)
+		if err != nil {
+			return err
+		}
+		if !attached {
+			continue
+		}
+
+		if !runner.Status.Counts() {
+			return &activityModel.HeldForReviewError{Violations: runner.Violations}
+		}
+		s.refreshCauseBoards(runner.CauseID)
+		if len(violations) > 0 {
+			return &activityModel.HeldForReviewError{Violations: violations}
+		}
+		return nil
+	}
+	return challengeModel.ErrRunnerChanged
+}
+
+// attachCauseRunTrack makes one attempt at RecordCauseRunTrack and reports false when the
+// runner changed after it was read.
+func (s *ChallengeService) attachCauseRunTrack(runnerID string, track *trackModel.Track, summary trackModel.Summary, trackURL string) (*challengeModel.CauseRunner, []activityModel.Violation, bool, error) {
+	runner, err := s.causeRunnerRepo.GetByID(runnerID)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	counted := runner.Status.Counts()
+	updated := *runner
+	updated.DistanceCovered = summary.Distance
+	updated.Duration = summary.MovingTime.Truncate(time.Second)
+
+	var violations []activityModel.Violation
+	if counted {
+		// The runner's reported distance is already in today's total; it is being replaced.
+		var replaced float64
+		if !runner.DateJoined.Before(activityModel.StartOfDay(time.Now())) {
+			replaced = runner.DistanceCovered
+		}
+		if violations, err = s.checkCauseRun(&updated, track, replaced); err != nil {
+			return nil, nil, false, err
+		}
+	}
+
+	attached := false
+	err = s.uow.Do(func(r repo.Repositories) error {
+		ok, err := r.CauseRunners.AttachTrack(runner, updated.DistanceCovered, updated.Duration, trackURL, summary.ElevationGain)
+		if err != nil || !ok {
+			return err
+		}
+		attached = true
+		switch {
+		case !counted:
+			return nil // still held (or rejected); nothing is in the cause total
+		case len(violations) > 0:
+			if err := r.CauseRunners.Flag(runnerID, violations); err != nil {
+				return err
+			}
+			return r.Causes.IncrementDistance(runner.CauseID, -runner.DistanceCovered)
+		default:
+			return r.Causes.IncrementDistance(runner.CauseID, summary.Distance-runner.DistanceCovered)
+		}
+	})
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return runner, violations, attached, nil
 }
 
 func (s *ChallengeService) SponsorChallenge(challengeID, sponsorID string, distance, amountPerKm float64) error {
@@ -231,21 +504,6 @@ func (s *ChallengeService) SponsorCause(causeID, sponsorID string, distance, amo
 	return s.sponsorCauseRepo.Create(sponsor)
 }
 
-func (s *ChallengeService) BuyCause(causeID, buyerID string, amount float64) error {
-	buyer := &challengeModel.CauseBuyer{
-		Base: model.Base{
-			ID:        id.New(),
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		},
-		BuyerID: buyerID,
-		CauseID: causeID,
-		Amount:  amount,
-	}
-
-	return s.causeBuyerRepo.Create(buyer)
-}
-
 func (s *ChallengeService) GetLeaderboard() ([]*challengeModel.CauseRunner, error) {
 	return s.causeRunnerRepo.GetLeaderboard()
 }
@@ -0,0 +1,285 @@
+package challenge
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"strings"
+	"time"
+
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/challenge/repo"
+	notificationModel "gopi.com/internal/domain/notification/model"
+	notificationRepo "gopi.com/internal/domain/notification/repo"
+	userRepo "gopi.com/internal/domain/user/repo"
+	"gopi.com/internal/lib/email"
+)
+
+// WithWinners enables challenge close-out: when a challenge ends its participants are ranked
+// by its criterion, the top NoOfWinner are stored with their prizes and told in the app and by
+// email. Staff can then override the result. Without it, challenges never close.
+func WithWinners(winnerRepo repo.ChallengeWinnerRepository, notifications notificationRepo.NotificationRepository, userRepo userRepo.UserRepository, emailService email.EmailServiceInterface) Option {
+	return func(s *ChallengeService) {
+		s.winnerRepo = winnerRepo
+		s.notificationRepo = notifications
+		s.userRepo = userRepo
+		s.emailService = emailService
+	}
+}
+
+// CloseDueChallenges closes out every challenge whose end has passed and returns how many it
+// closed. A failure on one challenge is logged and does not stop the others.
+func (s *ChallengeService) CloseDueChallenges(now time.Time) (int, error) {
+	if s.winnerRepo == nil {
+		return 0, nil
+	}
+
+	due, err := s.challengeRepo.ListDueForCloseout(now)
+	if err != nil {
+		return 0, err
+	}
+	closed := 0
+	for _, challenge := range due {
+		ok, err := s.CloseOutChallenge(challenge, now)
+		if err != nil {
+			slog.Error("challenge close-out failed", "challenge_id", challenge.ID, "err", err)
+			continue
+		}
+		if ok {
+			closed++
+		}
+	}
+	return closed, nil
+}
+
+// CloseOutChallenge ranks the challenge's participants, stores its winners and settles its
+// causes' sponsors, then notifies the winners. The close and its results are written in one
+// unit of work, so a failed close leaves the challenge open for the next tick. It runs at most
+// once per challenge and reports whether this call closed it.
+func (s *ChallengeService) CloseOutChallenge(challenge *challengeModel.Challenge, now time.Time) (bool, error) {
+	if s.winnerRepo == nil {
+		return false, challengeModel.ErrWinnersNotEnabled
+	}
+
+	var (
+		closed  bool
+		winners []*challengeModel.ChallengeWinner
+	)
+	err := s.uow.Do(func(r repo.Repositories) error {
+		ok, err := r.Challenges.MarkClosed(challenge.ID, now)
+		if err != nil || !ok {
+			return err
+		}
+		runners, err := causeRunners(r.Causes, r.CauseRunners, challenge.ID)
+		if err != nil {
+			return err
+		}
+		winners = challenge.SelectWinners(challengeModel.Participants(runners))
+		if err := r.Winners.SaveWinners(challenge.ID, winners, nil); err != nil {
+			return err
+		}
+		if err := s.settleChallengeCauses(r, challenge, now); err != nil {
+			return err
+		}
+		closed = true
+		return nil
+	})
+	if err != nil || !closed {
+		return false, err
+	}
+	challenge.ClosedAt = &now
+
+	s.notifyWinners(challenge, winners)
+	return true, nil
+}
+
+// GetChallengeWinners returns the challenge's winners by rank. It is empty until the
+// challenge has closed.
+func (s *ChallengeService) GetChallengeWinners(challengeID string) ([]*challengeModel.ChallengeWinner, error) {
+	if s.winnerRepo == nil {
+		return nil, challengeModel.ErrWinnersNotEnabled
+	}
+	return s.winnerRepo.GetWinners(challengeID)
+}
+
+// OverrideChallengeWinners replaces a closed challenge's winners with userIDs, in rank order,
+// and records who changed them and why. Users who were not already winners are notified.
+func (s *ChallengeService) OverrideChallengeWinners(challengeID, staffID, reason string, userIDs []string) ([]*challengeModel.ChallengeWinner, error) {
+	if s.winnerRepo == nil {
+		return nil, challengeModel.ErrWinnersNotEnabled
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, challengeModel.ErrOverrideReasonRequired
+	}
+
+	challenge, err := s.challengeRepo.GetByID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.ClosedAt == nil {
+		return nil, challengeModel.ErrChallengeNotClosed
+	}
+	participants, err := s.participants(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	winners, err := challenge.OverrideWinners(userIDs, participants)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.winnerRepo.GetWinners(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	audit := &challengeModel.WinnerAudit{
+		ChallengeID: challenge.ID,
+		ActorID:     staffID,
+		Reason:      reason,
+		Previous:    challengeModel.WinnerIDs(previous),
+		Winners:     challengeModel.WinnerIDs(winners),
+	}
+	if err := s.winnerRepo.SaveWinners(challenge.ID, winners, audit); err != nil {
+		return nil, err
+	}
+
+	wasWinner := make(map[string]bool, len(previous))
+	for _, winner := range previous {
+		wasWinner[winner.UserID] = true
+	}
+	var added []*challengeModel.ChallengeWinner
+	for _, winner := range winners {
+		if !wasWinner[winner.UserID] {
+			added = append(added, winner)
+		}
+	}
+	s.notifyWinners(challenge, added)
+	return winners, nil
+}
+
+// ListWinnerAudits returns the staff overrides of a challenge's winners, oldest first.
+func (s *ChallengeService) ListWinnerAudits(challengeID string) ([]*challengeModel.WinnerAudit, error) {
+	if s.winnerRepo == nil {
+		return nil, challengeModel.ErrWinnersNotEnabled
+	}
+	return s.winnerRepo.ListAudits(challengeID)
+}
+
+// participants totals the counted runs in every cause of the challenge per user.
+func (s *ChallengeService) participants(challengeID string) ([]*challengeModel.Participant, error) {
+	runners, err := s.challengeRunners(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	return challengeModel.Participants(runners), nil
+}
+
+// challengeRunners returns the runners of every cause in the challenge.
+func (s *ChallengeService) challengeRunners(challengeID string) ([]*challengeModel.CauseRunner, error) {
+	return causeRunners(s.causeRepo, s.causeRunnerRepo, challengeID)
+}
+
+// causeRunners returns the runners of every cause in the challenge from the given repositories.
+func causeRunners(causeRepo repo.CauseRepository, causeRunnerRepo repo.CauseRunnerRepository, challengeID string) ([]*challengeModel.CauseRunner, error) {
+	causes, err := causeRepo.GetByChallengeID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	var runners []*challengeModel.CauseRunner
+	for _, cause := range causes {
+		found, err := causeRunnerRepo.GetByCauseID(cause.ID)
+		if err != nil {
+			return nil, err
+		}
+		runners = append(runners, found...)
+	}
+	return runners, nil
+}
+
+// notifyWinners tells each winner their placing in the app and by email. Failures are logged
+// rather than returned because the winners have already been saved.
+func (s *ChallengeService) notifyWinners(challenge *challengeModel.Challenge, winners []*challengeModel.ChallengeWinner) {
+	if len(winners) == 0 {
+		return
+	}
+
+	if s.notificationRepo != nil {
+		notifications := make([]*notificationModel.Notification, 0, len(winners))
+		for _, winner := range winners {
+			notifications = append(notifications, &notificationModel.Notification{
+				UserID:   winner.UserID,
+				Kind:     notificationModel.KindChallengeWinner,
+				SourceID: challenge.ID,
+				Title:    winnerTitle(challenge, winner),
+				Body:     winnerBody(challenge, winner),
+				Link:     "/challenges/" + challenge.Slug,
+			})
+		}
+		if err := s.notificationRepo.CreateBatch(notifications); err != nil {
+			slog.Error("challenge winner notifications failed", "challenge_id", challenge.ID, "err", err)
+		}
+	}
+
+	if s.emailService == nil || s.userRepo == nil {
+		return
+	}
+	for _, winner := range winners {
+		user, err := s.userRepo.GetByID(winner.UserID)
+		if err != nil || user == nil || user.Email == "" {
+			continue
+		}
+		html, err := renderWinnerEmail(winnerEmailData{Challenge: challenge, Winner: winner, Body: winnerBody(challenge, winner)})
+		if err == nil {
+			err = s.emailService.SendBulkEmail([]string{user.Email}, winnerTitle(challenge, winner), html)
+		}
+		if err != nil {
+			slog.Error("challenge winner email failed", "challenge_id", challenge.ID, "user_id", winner.UserID, "err", err)
+		}
+	}
+}
+
+func winnerTitle(challenge *challengeModel.Challenge, winner *challengeModel.ChallengeWinner) string {
+	return fmt.Sprintf("You placed #%d in %s", winner.Rank, challenge.Name)
+}
+
+func winnerBody(challenge *challengeModel.Challenge, winner *challengeModel.ChallengeWinner) string {
+	body := fmt.Sprintf("Congratulations! You finished #%d in %s with %.2f km.", winner.Rank, challenge.Name, winner.Distance)
+	if winner.Prize != nil {
+		body += fmt.Sprintf(" Your prize: %s.", winner.Prize)
+	}
+	return body
+}
+
+type winnerEmailData struct {
+	Challenge *challengeModel.Challenge
+	Winner    *challengeModel.ChallengeWinner
+	Body      string
+}
+
+var winnerEmailTemplate = template.Must(template.New("winner").Parse(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background-color: #28a745; color: white; padding: 20px; text-align: center;">
+		<h1>You placed #{{.Winner.Rank}} in {{.Challenge.Name}}</h1>
+	</div>
+	<div style="padding: 20px;">
+		<p>{{.Body}}</p>
+		<p><strong>Distance covered:</strong> {{printf "%.2f" .Winner.Distance}} km</p>
+		<p><strong>Money raised:</strong> {{printf "%.2f" .Winner.MoneyRaised}}</p>
+	</div>
+	<div style="background-color: #f8f9fa; padding: 20px; text-align: center; color: #6c757d;">
+		<p>&copy; 2024 GoPadi. All rights reserved.</p>
+	</div>
+</body>
+</html>
+`))
+
+func renderWinnerEmail(data winnerEmailData) (string, error) {
+	var buf bytes.Buffer
+	if err := winnerEmailTemplate.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,285 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+	mediaModel "gopi.com/internal/domain/media/model"
+	mediaRepo "gopi.com/internal/domain/media/repo"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gopi.com/internal/lib/oembed"
+	"gopi.com/internal/lib/storage"
+)
+
+// MediaService manages the ordered image and video galleries of challenges, causes and
+// campaigns. Anyone who may see a subject may view its gallery; only the owner of the subject
+// may change it.
+type MediaService struct {
+	mediaRepo mediaRepo.MediaRepository
+	storage   storage.Storage
+
+	// set by WithChallenges
+	challengeRepo challengeRepo.ChallengeRepository
+	causeRepo     challengeRepo.CauseRepository
+
+	// set by WithCampaigns
+	campaignRepo campaignRepo.CampaignRepository
+}
+
+// NewMediaService returns a service that stores uploaded images through st.
+func NewMediaService(mediaRepo mediaRepo.MediaRepository, st storage.Storage, opts ...Option) *MediaService {
+	s := &MediaService{
+		mediaRepo: mediaRepo,
+		storage:   st,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Option configures optional MediaService collaborators.
+type Option func(*MediaService)
+
+// WithChallenges enables galleries on challenges and causes.
+func WithChallenges(challenges challengeRepo.ChallengeRepository, causes challengeRepo.CauseRepository) Option {
+	return func(s *MediaService) {
+		s.challengeRepo = challenges
+		s.causeRepo = causes
+	}
+}
+
+// WithCampaigns enables galleries on campaigns.
+func WithCampaigns(campaigns campaignRepo.CampaignRepository) Option {
+	return func(s *MediaService) {
+		s.campaignRepo = campaigns
+	}
+}
+
+// ListGallery returns the subject's gallery in order. The gallery of a private campaign is
+// only shown to its owner, its members and staff, and is ErrSubjectNotFound to anyone else;
+// viewerID is empty for anonymous viewers.
+func (s *MediaService) ListGallery(kind mediaModel.SubjectKind, subjectID, viewerID string, isStaff bool) ([]*mediaModel.Item, error) {
+	if kind == mediaModel.SubjectCampaign {
+		if s.campaignRepo == nil {
+			return nil, mediaModel.ErrKindNotEnabled
+		}
+		campaign, err := s.campaignRepo.GetByID(subjectID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", mediaModel.ErrSubjectNotFound, err)
+		}
+		if !campaign.VisibleTo(viewerID) && !isStaff {
+			return nil, mediaModel.ErrSubjectNotFound
+		}
+	} else if _, err := s.ownerOf(kind, subjectID); err != nil {
+		return nil, err
+	}
+	return s.mediaRepo.ListGallery(kind, subjectID)
+}
+
+// AddImage stores an uploaded image and appends it to the subject's gallery. ext is the file
+// extension, with its dot, that matches contentType.
+func (s *MediaService) AddImage(ctx context.Context, kind mediaModel.SubjectKind, subjectID, userID, caption string,
+	r io.Reader, size int64, contentType, ext string) (*mediaModel.Item, error) {
+	if err := s.checkOwner(kind, subjectID, userID); err != nil {
+		return nil, err
+	}
+	if err := validateCaption(caption); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	item := &mediaModel.Item{
+		Base:        model.Base{ID: id.New(), CreatedAt: now, UpdatedAt: now},
+		SubjectKind: kind,
+		SubjectID:   subjectID,
+		Type:        mediaModel.TypeImage,
+		Caption:     caption,
+		UploaderID:  userID,
+		ContentType: contentType,
+		Size:        size,
+	}
+	item.FileKey = fmt.Sprintf("media/%ss/%s/%s%s", kind, subjectID, item.ID, ext)
+	url, err := s.storage.Save(ctx, item.FileKey, r, size, contentType)
+	if err != nil {
+		return nil, err
+	}
+	item.URL = url
+
+	added, err := s.mediaRepo.Append(item, mediaModel.MaxGalleryItems)
+	if err != nil || !added {
+		_ = s.storage.Delete(ctx, item.FileKey)
+		if err == nil {
+			err = mediaModel.ErrGalleryFull
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// AddVideo appends a link to a YouTube, Vimeo or Dailymotion video to the subject's gallery,
+// with its embed details derived from the link.
+func (s *MediaService) AddVideo(kind mediaModel.SubjectKind, subjectID, userID, videoURL, caption string) (*mediaModel.Item, error) {
+	if err := s.checkOwner(kind, subjectID, userID); err != nil {
+		return nil, err
+	}
+	if err := validateCaption(caption); err != nil {
+		return nil, err
+	}
+	video, err := oembed.Parse(videoURL)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &mediaModel.Item{
+		SubjectKind:  kind,
+		SubjectID:    subjectID,
+		Type:         mediaModel.TypeVideo,
+		Caption:      caption,
+		URL:          video.URL,
+		UploaderID:   userID,
+		Provider:     video.ProviderName,
+		ProviderURL:  video.ProviderURL,
+		VideoID:      video.VideoID,
+		EmbedURL:     video.EmbedURL,
+		ThumbnailURL: video.ThumbnailURL,
+		Width:        video.Width,
+		Height:       video.Height,
+		EmbedHTML:    video.HTML,
+	}
+	added, err := s.mediaRepo.Append(item, mediaModel.MaxGalleryItems)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, mediaModel.ErrGalleryFull
+	}
+	return item, nil
+}
+
+// UpdateCaption replaces an item's caption.
+func (s *MediaService) UpdateCaption(itemID, userID, caption string) (*mediaModel.Item, error) {
+	item, err := s.ownedItem(itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCaption(caption); err != nil {
+		return nil, err
+	}
+	if err := s.mediaRepo.UpdateCaption(item.ID, caption); err != nil {
+		return nil, err
+	}
+	item.Caption = caption
+	return item, nil
+}
+
+// ReorderGallery puts the subject's gallery in the order of itemIDs, which must list every
+// item exactly once.
+func (s *MediaService) ReorderGallery(kind mediaModel.SubjectKind, subjectID, userID string, itemIDs []string) ([]*mediaModel.Item, error) {
+	if err := s.checkOwner(kind, subjectID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.mediaRepo.Reorder(kind, subjectID, itemIDs); err != nil {
+		return nil, err
+	}
+	return s.mediaRepo.ListGallery(kind, subjectID)
+}
+
+// DeleteItem removes an item from its gallery, along with the stored file of an image.
+func (s *MediaService) DeleteItem(ctx context.Context, itemID, userID string) error {
+	item, err := s.ownedItem(itemID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.mediaRepo.Delete(item.ID); err != nil {
+		return err
+	}
+	if item.FileKey != "" {
+		_ = s.storage.Delete(ctx, item.FileKey)
+	}
+	return nil
+}
+
+// DeleteGallery removes every item in the subject's gallery along with the stored images, for
+// when the subject itself is deleted.
+func (s *MediaService) DeleteGallery(ctx context.Context, kind mediaModel.SubjectKind, subjectID string) error {
+	items, err := s.mediaRepo.DeleteGallery(kind, subjectID)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.FileKey != "" {
+			_ = s.storage.Delete(ctx, item.FileKey)
+		}
+	}
+	return nil
+}
+
+func (s *MediaService) ownedItem(itemID, userID string) (*mediaModel.Item, error) {
+	item, err := s.mediaRepo.GetByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwner(item.SubjectKind, item.SubjectID, userID); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *MediaService) checkOwner(kind mediaModel.SubjectKind, subjectID, userID string) error {
+	ownerID, err := s.ownerOf(kind, subjectID)
+	if err != nil {
+		return err
+	}
+	if ownerID != userID {
+		return mediaModel.ErrNotSubjectOwner
+	}
+	return nil
+}
+
+// ownerOf returns the ID of the user who owns the gallery's subject.
+func (s *MediaService) ownerOf(kind mediaModel.SubjectKind, subjectID string) (string, error) {
+	switch kind {
+	case mediaModel.SubjectChallenge:
+		if s.challengeRepo == nil {
+			return "", mediaModel.ErrKindNotEnabled
+		}
+		challenge, err := s.challengeRepo.GetByID(subjectID)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", mediaModel.ErrSubjectNotFound, err)
+		}
+		return challenge.OwnerID, nil
+	case mediaModel.SubjectCause:
+		if s.causeRepo == nil {
+			return "", mediaModel.ErrKindNotEnabled
+		}
+		cause, err := s.causeRepo.GetByID(subjectID)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", mediaModel.ErrSubjectNotFound, err)
+		}
+		return cause.OwnerID, nil
+	case mediaModel.SubjectCampaign:
+		if s.campaignRepo == nil {
+			return "", mediaModel.ErrKindNotEnabled
+		}
+		campaign, err := s.campaignRepo.GetByID(subjectID)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", mediaModel.ErrSubjectNotFound, err)
+		}
+		return campaign.OwnerID, nil
+	}
+	return "", mediaModel.ErrKindNotEnabled
+}
+
+func validateCaption(caption string) error {
+	if utf8.RuneCountInString(caption) > mediaModel.MaxCaptionLength {
+		return mediaModel.ErrCaptionTooLong
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"time"
+
+	notificationModel "gopi.com/internal/domain/notification/model"
+	notificationRepo "gopi.com/internal/domain/notification/repo"
+)
+
+// NotificationService serves a user's in-app notifications. Other services deliver them
+// through the repository directly.
+type NotificationService struct {
+	notificationRepo notificationRepo.NotificationRepository
+}
+
+func NewNotificationService(notificationRepo notificationRepo.NotificationRepository) *NotificationService {
+	return &NotificationService{notificationRepo: notificationRepo}
+}
+
+// ListNotifications returns a page of the user's notifications, newest first, and how many
+// of all their notifications are unread.
+func (s *NotificationService) ListNotifications(userID string, unreadOnly bool, limit, offset int) ([]*notificationModel.Notification, int64, error) {
+	notifications, err := s.notificationRepo.ListByUser(userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	unread, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, unread, nil
+}
+
+// MarkRead marks one of the user's notifications as read. Marking it again is a no-op.
+func (s *NotificationService) MarkRead(userID, notificationID string) error {
+	return s.notificationRepo.MarkRead(notificationID, userID, time.Now())
+}
+
+// MarkAllRead marks all of the user's notifications as read and returns how many were unread.
+func (s *NotificationService) MarkAllRead(userID string) (int64, error) {
+	return s.notificationRepo.MarkAllRead(userID, time.Now())
+}
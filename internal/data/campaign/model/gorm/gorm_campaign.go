@@ -2,6 +2,7 @@ package gorm
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	userGorm "gopi.com/internal/data/user/model/gorm"
@@ -18,16 +19,24 @@ type Campaign struct {
 	Condition         string `gorm:"type:text"`
 	Mode              string `gorm:"type:varchar(50);index"`
 	Goal              string
-	Activity          string  `gorm:"type:varchar(50);index"`
-	AcceptTac         bool    `gorm:"default:false"`
-	Location          string  `gorm:"index"`
-	MoneyRaised       float64 `gorm:"default:0;index"`
-	TargetAmount      float64 `gorm:"default:0"`
-	TargetAmountPerKm float64 `gorm:"default:0"`
-	DistanceToCover   float64 `gorm:"default:0"`
-	DistanceCovered   float64 `gorm:"default:0;index"`
+	Activity          string   `gorm:"type:varchar(50);index"`
+	AcceptTac         bool     `gorm:"default:false"`
+	Location          string   `gorm:"index"`
+	Latitude          *float64 `gorm:"index:idx_campaign_coordinates"`
+	Longitude         *float64 `gorm:"index:idx_campaign_coordinates"`
+	MoneyRaised       float64  `gorm:"default:0;index"`
+	TargetAmount      float64  `gorm:"default:0"`
+	TargetAmountPerKm float64  `gorm:"default:0"`
+	DistanceToCover   float64  `gorm:"default:0"`
+	DistanceCovered   float64  `gorm:"default:0;index"`
 	StartDuration     string
 	EndDuration       string
+	StartsAt          *time.Time `gorm:"index"`
+	EndsAt            *time.Time `gorm:"index"`
+	Status            string     `gorm:"type:varchar(20);index"`
+	AchievedAt        *time.Time
+	ClosedAt          *time.Time
+	Visibility        string `gorm:"type:varchar(20);index"`
 	OwnerID           string `gorm:"not null;index"`
 	Slug              string `gorm:"unique;not null;index"`
 	WorkoutImg        string
@@ -82,12 +91,14 @@ type CampaignRunner struct {
 	ID              string  `gorm:"type:varchar(255);primary_key"`
 	CampaignID      string  `gorm:"not null;index"`
 	DistanceCovered float64 `gorm:"default:0;index"`
-	Duration        string
+	DurationSeconds int64   `gorm:"column:duration_seconds;default:0;index"`
 	MoneyRaised     float64 `gorm:"default:0;index"`
 	CoverImage      string
 	Activity        string    `gorm:"type:varchar(50);index"`
 	OwnerID         string    `gorm:"not null;index"`
 	DateJoined      time.Time `gorm:"index;column:date_joined"`
+	TrackURL        string
+	ElevationGain   float64   `gorm:"default:0"`
 	CreatedAt       time.Time `gorm:"index"`
 	UpdatedAt       time.Time `gorm:"column:date_updated"`
 
@@ -102,13 +113,14 @@ func (CampaignRunner) TableName() string {
 
 type SponsorCampaign struct {
 	ID          string  `gorm:"type:varchar(255);primary_key"`
-	Sponsors    string  `gorm:"type:text"` // JSON array stored as text for multiple sponsors
+	Sponsors    string  `gorm:"type:text"` // JSON array of sponsor summaries, IDs only
 	CampaignID  string  `gorm:"not null;index"`
 	Distance    float64 `gorm:"default:0;index"`
 	AmountPerKm float64 `gorm:"default:0"`
 	TotalAmount float64 `gorm:"default:0;index"`
 	BrandImg    string
 	VideoUrl    string
+	Status      string    `gorm:"type:varchar(20);index"` // pending for unconfirmed template slots; empty means confirmed
 	CreatedAt   time.Time `gorm:"index;column:date_created"`
 	UpdatedAt   time.Time
 
@@ -167,6 +179,8 @@ func FromDomainCampaign(c *campaignModel.Campaign) *Campaign {
 		Activity:          string(c.Activity),
 		AcceptTac:         c.AcceptTac,
 		Location:          c.Location,
+		Latitude:          model.Latitude(c.Coordinates),
+		Longitude:         model.Longitude(c.Coordinates),
 		MoneyRaised:       c.MoneyRaised,
 		TargetAmount:      c.TargetAmount,
 		TargetAmountPerKm: c.TargetAmountPerKm,
@@ -174,6 +188,12 @@ func FromDomainCampaign(c *campaignModel.Campaign) *Campaign {
 		DistanceCovered:   c.DistanceCovered,
 		StartDuration:     c.StartDuration,
 		EndDuration:       c.EndDuration,
+		StartsAt:          c.StartsAt,
+		EndsAt:            c.EndsAt,
+		Status:            string(c.Status),
+		AchievedAt:        c.AchievedAt,
+		ClosedAt:          c.ClosedAt,
+		Visibility:        string(c.Visibility),
 		OwnerID:           c.OwnerID,
 		Slug:              c.Slug,
 		WorkoutImg:        c.WorkoutImg,
@@ -185,18 +205,14 @@ func FromDomainCampaign(c *campaignModel.Campaign) *Campaign {
 
 // Convert from GORM Campaign to domain Campaign
 func ToDomainCampaign(c *Campaign) *campaignModel.Campaign {
-	// Convert GORM member and sponsor relationships to interface{} slices
-	var members []interface{}
-	var sponsors []interface{}
-
-	// Convert Members relationship to interface{} slice (extract UserID)
+	// Convert the junction rows to user summaries; names are set when User was preloaded
+	members := []campaignModel.UserSummary{}
 	for _, member := range c.Members {
-		members = append(members, member.UserID)
+		members = append(members, userSummary(member.UserID, &member.User))
 	}
-
-	// Convert Sponsors relationship to interface{} slice (extract UserID)
+	sponsors := []campaignModel.UserSummary{}
 	for _, sponsor := range c.Sponsors {
-		sponsors = append(sponsors, sponsor.UserID)
+		sponsors = append(sponsors, userSummary(sponsor.UserID, &sponsor.User))
 	}
 
 	return &campaignModel.Campaign{
@@ -213,6 +229,7 @@ func ToDomainCampaign(c *Campaign) *campaignModel.Campaign {
 		Activity:          campaignModel.Activity(c.Activity),
 		AcceptTac:         c.AcceptTac,
 		Location:          c.Location,
+		Coordinates:       model.FromLatLon(c.Latitude, c.Longitude),
 		MoneyRaised:       c.MoneyRaised,
 		TargetAmount:      c.TargetAmount,
 		TargetAmountPerKm: c.TargetAmountPerKm,
@@ -220,6 +237,12 @@ func ToDomainCampaign(c *Campaign) *campaignModel.Campaign {
 		DistanceCovered:   c.DistanceCovered,
 		StartDuration:     c.StartDuration,
 		EndDuration:       c.EndDuration,
+		StartsAt:          c.StartsAt,
+		EndsAt:            c.EndsAt,
+		Status:            campaignModel.CampaignStatus(c.Status),
+		AchievedAt:        c.AchievedAt,
+		ClosedAt:          c.ClosedAt,
+		Visibility:        campaignModel.CampaignVisibility(c.Visibility),
 		Members:           members,
 		Sponsors:          sponsors,
 		OwnerID:           c.OwnerID,
@@ -234,12 +257,14 @@ func FromDomainCampaignRunner(cr *campaignModel.CampaignRunner) *CampaignRunner
 		ID:              cr.ID,
 		CampaignID:      cr.CampaignID,
 		DistanceCovered: cr.DistanceCovered,
-		Duration:        cr.Duration,
+		DurationSeconds: model.Seconds(cr.Duration),
 		MoneyRaised:     cr.MoneyRaised,
 		CoverImage:      cr.CoverImage,
 		Activity:        cr.Activity,
 		OwnerID:         cr.OwnerID,
 		DateJoined:      cr.DateJoined,
+		TrackURL:        cr.TrackURL,
+		ElevationGain:   cr.ElevationGain,
 		CreatedAt:       cr.CreatedAt,
 		UpdatedAt:       cr.UpdatedAt,
 	}
@@ -255,34 +280,29 @@ func ToDomainCampaignRunner(cr *CampaignRunner) *campaignModel.CampaignRunner {
 		},
 		CampaignID:      cr.CampaignID,
 		DistanceCovered: cr.DistanceCovered,
-		Duration:        cr.Duration,
+		Duration:        model.FromSeconds(cr.DurationSeconds),
 		MoneyRaised:     cr.MoneyRaised,
 		CoverImage:      cr.CoverImage,
 		Activity:        cr.Activity,
 		OwnerID:         cr.OwnerID,
 		DateJoined:      cr.DateJoined,
+		TrackURL:        cr.TrackURL,
+		ElevationGain:   cr.ElevationGain,
 	}
 }
 
 // Convert from domain SponsorCampaign to GORM SponsorCampaign
 func FromDomainSponsorCampaign(sc *campaignModel.SponsorCampaign) *SponsorCampaign {
-	sponsors := ""
-	// Convert Sponsors slice to JSON string
-	if len(sc.Sponsors) > 0 {
-		if data, err := json.Marshal(sc.Sponsors); err == nil {
-			sponsors = string(data)
-		}
-	}
-
 	return &SponsorCampaign{
 		ID:          sc.ID,
-		Sponsors:    sponsors,
+		Sponsors:    MarshalUsers(sc.Sponsors),
 		CampaignID:  sc.CampaignID,
 		Distance:    sc.Distance,
 		AmountPerKm: sc.AmountPerKm,
 		TotalAmount: sc.TotalAmount,
 		BrandImg:    sc.BrandImg,
 		VideoUrl:    sc.VideoUrl,
+		Status:      string(sc.Status),
 		CreatedAt:   sc.CreatedAt,
 		UpdatedAt:   sc.UpdatedAt,
 	}
@@ -290,25 +310,91 @@ func FromDomainSponsorCampaign(sc *campaignModel.SponsorCampaign) *SponsorCampai
 
 // Convert from GORM SponsorCampaign to domain SponsorCampaign
 func ToDomainSponsorCampaign(sc *SponsorCampaign) *campaignModel.SponsorCampaign {
-	var sponsors []interface{}
-
-	// Parse JSON string back to interface{} slice
-	if sc.Sponsors != "" {
-		json.Unmarshal([]byte(sc.Sponsors), &sponsors)
-	}
-
 	return &campaignModel.SponsorCampaign{
 		Base: model.Base{
 			ID:        sc.ID,
 			CreatedAt: sc.CreatedAt,
 			UpdatedAt: sc.UpdatedAt,
 		},
-		Sponsors:    sponsors,
+		Sponsors:    UnmarshalUsers(sc.Sponsors),
 		CampaignID:  sc.CampaignID,
 		Distance:    sc.Distance,
 		AmountPerKm: sc.AmountPerKm,
 		TotalAmount: sc.TotalAmount,
 		BrandImg:    sc.BrandImg,
 		VideoUrl:    sc.VideoUrl,
+		Status:      campaignModel.SponsorshipStatus(sc.Status),
+	}
+}
+
+// userSummary names a member or sponsor row's user; user is the zero value when not preloaded.
+func userSummary(userID string, user *userGorm.UserGORM) campaignModel.UserSummary {
+	summary := campaignModel.UserSummary{ID: userID}
+	if user.ID == userID {
+		summary.Username = user.Username
+		summary.FullName = strings.TrimSpace(user.FirstName + " " + user.LastName)
+	}
+	return summary
+}
+
+// MarshalUsers stores a user list as a JSON array of IDs-only summaries, "" when it is empty.
+// Names are not stored; they are looked up when the list is read.
+func MarshalUsers(users []campaignModel.UserSummary) string {
+	if len(users) == 0 {
+		return ""
+	}
+	ids := make([]campaignModel.UserSummary, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, campaignModel.UserSummary{ID: u.ID})
+	}
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// UnmarshalUsers reads a stored user list. Entries that are neither a user ID nor a summary
+// with one, as older releases sometimes stored, are skipped.
+func UnmarshalUsers(data string) []campaignModel.UserSummary {
+	users := []campaignModel.UserSummary{}
+	var entries []json.RawMessage
+	if data == "" || json.Unmarshal([]byte(data), &entries) != nil {
+		return users
+	}
+	for _, entry := range entries {
+		var u campaignModel.UserSummary
+		if json.Unmarshal(entry, &u) == nil && u.ID != "" {
+			users = append(users, campaignModel.UserSummary{ID: u.ID})
+		}
+	}
+	return users
+}
+
+// FillUserNames sets the username and full name of every summary in lists from the users
+// table, with one query. Users that no longer exist keep only their ID.
+func FillUserNames(db *gorm.DB, lists ...[]campaignModel.UserSummary) error {
+	var ids []string
+	for _, users := range lists {
+		ids = append(ids, campaignModel.UserIDs(users)...)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	var rows []userGorm.UserGORM
+	if err := db.Select("id", "username", "first_name", "last_name").Where("id IN ?", ids).Find(&rows).Error; err != nil {
+		return err
+	}
+	byID := make(map[string]*userGorm.UserGORM, len(rows))
+	for i := range rows {
+		byID[rows[i].ID] = &rows[i]
+	}
+	for _, users := range lists {
+		for i, u := range users {
+			if user, ok := byID[u.ID]; ok {
+				users[i] = userSummary(u.ID, user)
+			}
+		}
 	}
+	return nil
 }
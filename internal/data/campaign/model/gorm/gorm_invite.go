@@ -0,0 +1,131 @@
+package gorm
+
+import (
+	"time"
+
+	userGorm "gopi.com/internal/data/user/model/gorm"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// CampaignInvite is an invite link or email invitation to a campaign.
+type CampaignInvite struct {
+	ID         string `gorm:"type:varchar(255);primary_key"`
+	CampaignID string `gorm:"not null;index"`
+	CreatedBy  string `gorm:"not null"`
+	Email      string `gorm:"index"`
+	MaxUses    int    `gorm:"default:0"`
+	Uses       int    `gorm:"default:0"`
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time `gorm:"index"`
+	UpdatedAt  time.Time `gorm:"column:date_updated"`
+
+	Campaign Campaign `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE"`
+}
+
+func (CampaignInvite) TableName() string {
+	return "campaign_invites"
+}
+
+// CampaignJoinRequest is a user's request to join a private campaign.
+type CampaignJoinRequest struct {
+	ID         string `gorm:"type:varchar(255);primary_key"`
+	CampaignID string `gorm:"not null;index:idx_campaign_join_request"`
+	UserID     string `gorm:"not null;index:idx_campaign_join_request"`
+	Message    string `gorm:"type:text"`
+	Status     string `gorm:"type:varchar(20);index"`
+	DecidedBy  string
+	DecidedAt  *time.Time
+	CreatedAt  time.Time `gorm:"index"`
+	UpdatedAt  time.Time `gorm:"column:date_updated"`
+
+	Campaign Campaign          `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE"`
+	User     userGorm.UserGORM `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (CampaignJoinRequest) TableName() string {
+	return "campaign_join_requests"
+}
+
+func (ci *CampaignInvite) BeforeCreate(tx *gorm.DB) (err error) {
+	if ci.ID == "" {
+		ci.ID = id.New()
+	}
+	return
+}
+
+func (jr *CampaignJoinRequest) BeforeCreate(tx *gorm.DB) (err error) {
+	if jr.ID == "" {
+		jr.ID = id.New()
+	}
+	return
+}
+
+// Convert from domain CampaignInvite to GORM CampaignInvite
+func FromDomainCampaignInvite(ci *campaignModel.CampaignInvite) *CampaignInvite {
+	return &CampaignInvite{
+		ID:         ci.ID,
+		CampaignID: ci.CampaignID,
+		CreatedBy:  ci.CreatedBy,
+		Email:      ci.Email,
+		MaxUses:    ci.MaxUses,
+		Uses:       ci.Uses,
+		ExpiresAt:  ci.ExpiresAt,
+		RevokedAt:  ci.RevokedAt,
+		CreatedAt:  ci.CreatedAt,
+		UpdatedAt:  ci.UpdatedAt,
+	}
+}
+
+// Convert from GORM CampaignInvite to domain CampaignInvite
+func ToDomainCampaignInvite(ci *CampaignInvite) *campaignModel.CampaignInvite {
+	return &campaignModel.CampaignInvite{
+		Base: model.Base{
+			ID:        ci.ID,
+			CreatedAt: ci.CreatedAt,
+			UpdatedAt: ci.UpdatedAt,
+		},
+		CampaignID: ci.CampaignID,
+		CreatedBy:  ci.CreatedBy,
+		Email:      ci.Email,
+		MaxUses:    ci.MaxUses,
+		Uses:       ci.Uses,
+		ExpiresAt:  ci.ExpiresAt,
+		RevokedAt:  ci.RevokedAt,
+	}
+}
+
+// Convert from domain CampaignJoinRequest to GORM CampaignJoinRequest
+func FromDomainCampaignJoinRequest(jr *campaignModel.CampaignJoinRequest) *CampaignJoinRequest {
+	return &CampaignJoinRequest{
+		ID:         jr.ID,
+		CampaignID: jr.CampaignID,
+		UserID:     jr.UserID,
+		Message:    jr.Message,
+		Status:     string(jr.Status),
+		DecidedBy:  jr.DecidedBy,
+		DecidedAt:  jr.DecidedAt,
+		CreatedAt:  jr.CreatedAt,
+		UpdatedAt:  jr.UpdatedAt,
+	}
+}
+
+// Convert from GORM CampaignJoinRequest to domain CampaignJoinRequest
+func ToDomainCampaignJoinRequest(jr *CampaignJoinRequest) *campaignModel.CampaignJoinRequest {
+	return &campaignModel.CampaignJoinRequest{
+		Base: model.Base{
+			ID:        jr.ID,
+			CreatedAt: jr.CreatedAt,
+			UpdatedAt: jr.UpdatedAt,
+		},
+		CampaignID: jr.CampaignID,
+		UserID:     jr.UserID,
+		Message:    jr.Message,
+		Status:     campaignModel.JoinRequestStatus(jr.Status),
+		DecidedBy:  jr.DecidedBy,
+		DecidedAt:  jr.DecidedAt,
+	}
+}
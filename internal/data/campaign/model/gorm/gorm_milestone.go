@@ -0,0 +1,65 @@
+package gorm
+
+import (
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// CampaignMilestone is a progress mark configured for a campaign.
+type CampaignMilestone struct {
+	ID         string     `gorm:"type:varchar(255);primary_key"`
+	CampaignID string     `gorm:"not null;index"`
+	Metric     string     `gorm:"type:varchar(20);not null"`
+	Percent    float64    `gorm:"default:0"`
+	Value      float64    `gorm:"default:0"`
+	ReachedAt  *time.Time `gorm:"index"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time `gorm:"column:date_updated"`
+
+	Campaign Campaign `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE"`
+}
+
+func (CampaignMilestone) TableName() string {
+	return "campaign_milestones"
+}
+
+func (cm *CampaignMilestone) BeforeCreate(tx *gorm.DB) (err error) {
+	if cm.ID == "" {
+		cm.ID = id.New()
+	}
+	return
+}
+
+// Convert from domain CampaignMilestone to GORM CampaignMilestone
+func FromDomainCampaignMilestone(cm *campaignModel.CampaignMilestone) *CampaignMilestone {
+	return &CampaignMilestone{
+		ID:         cm.ID,
+		CampaignID: cm.CampaignID,
+		Metric:     string(cm.Metric),
+		Percent:    cm.Percent,
+		Value:      cm.Value,
+		ReachedAt:  cm.ReachedAt,
+		CreatedAt:  cm.CreatedAt,
+		UpdatedAt:  cm.UpdatedAt,
+	}
+}
+
+// Convert from GORM CampaignMilestone to domain CampaignMilestone
+func ToDomainCampaignMilestone(cm *CampaignMilestone) *campaignModel.CampaignMilestone {
+	return &campaignModel.CampaignMilestone{
+		Base: model.Base{
+			ID:        cm.ID,
+			CreatedAt: cm.CreatedAt,
+			UpdatedAt: cm.UpdatedAt,
+		},
+		CampaignID: cm.CampaignID,
+		Metric:     campaignModel.MilestoneMetric(cm.Metric),
+		Percent:    cm.Percent,
+		Value:      cm.Value,
+		ReachedAt:  cm.ReachedAt,
+	}
+}
@@ -0,0 +1,125 @@
+package gorm
+
+import (
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// CampaignStanding is a leaderboard row frozen at close-out.
+type CampaignStanding struct {
+	ID              string  `gorm:"type:varchar(255);primary_key"`
+	CampaignID      string  `gorm:"not null;index;uniqueIndex:idx_campaign_standing_user"`
+	Rank            int     `gorm:"column:position;not null"`
+	UserID          string  `gorm:"not null;index;uniqueIndex:idx_campaign_standing_user"`
+	DistanceCovered float64 `gorm:"default:0"`
+	MoneyRaised     float64 `gorm:"default:0"`
+	Activity        string  `gorm:"type:varchar(50)"`
+	CreatedAt       time.Time
+
+	Campaign Campaign `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE"`
+}
+
+func (CampaignStanding) TableName() string {
+	return "campaign_standings"
+}
+
+// SponsorObligation is the amount a sponsorship owes, computed at close-out.
+type SponsorObligation struct {
+	ID                string  `gorm:"type:varchar(255);primary_key"`
+	CampaignID        string  `gorm:"not null;index"`
+	SponsorCampaignID string  `gorm:"not null;uniqueIndex"`
+	Sponsors          string  `gorm:"type:text"` // JSON array of sponsor summaries, IDs only
+	PledgedDistance   float64 `gorm:"default:0"`
+	CreditedDistance  float64 `gorm:"default:0"`
+	AmountPerKm       float64 `gorm:"default:0"`
+	Amount            float64 `gorm:"default:0"`
+	CreatedAt         time.Time
+
+	Campaign Campaign `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE"`
+}
+
+func (SponsorObligation) TableName() string {
+	return "campaign_sponsor_obligations"
+}
+
+func (cs *CampaignStanding) BeforeCreate(tx *gorm.DB) (err error) {
+	if cs.ID == "" {
+		cs.ID = id.New()
+	}
+	return
+}
+
+func (so *SponsorObligation) BeforeCreate(tx *gorm.DB) (err error) {
+	if so.ID == "" {
+		so.ID = id.New()
+	}
+	return
+}
+
+// Convert from domain CampaignStanding to GORM CampaignStanding
+func FromDomainCampaignStanding(cs *campaignModel.CampaignStanding) *CampaignStanding {
+	return &CampaignStanding{
+		ID:              cs.ID,
+		CampaignID:      cs.CampaignID,
+		Rank:            cs.Rank,
+		UserID:          cs.UserID,
+		DistanceCovered: cs.DistanceCovered,
+		MoneyRaised:     cs.MoneyRaised,
+		Activity:        cs.Activity,
+		CreatedAt:       cs.CreatedAt,
+	}
+}
+
+// Convert from GORM CampaignStanding to domain CampaignStanding
+func ToDomainCampaignStanding(cs *CampaignStanding) *campaignModel.CampaignStanding {
+	return &campaignModel.CampaignStanding{
+		Base: model.Base{
+			ID:        cs.ID,
+			CreatedAt: cs.CreatedAt,
+			UpdatedAt: cs.CreatedAt,
+		},
+		CampaignID:      cs.CampaignID,
+		Rank:            cs.Rank,
+		UserID:          cs.UserID,
+		DistanceCovered: cs.DistanceCovered,
+		MoneyRaised:     cs.MoneyRaised,
+		Activity:        cs.Activity,
+	}
+}
+
+// Convert from domain SponsorObligation to GORM SponsorObligation
+func FromDomainSponsorObligation(so *campaignModel.SponsorObligation) *SponsorObligation {
+	return &SponsorObligation{
+		ID:                so.ID,
+		CampaignID:        so.CampaignID,
+		SponsorCampaignID: so.SponsorCampaignID,
+		Sponsors:          MarshalUsers(so.Sponsors),
+		PledgedDistance:   so.PledgedDistance,
+		CreditedDistance:  so.CreditedDistance,
+		AmountPerKm:       so.AmountPerKm,
+		Amount:            so.Amount,
+		CreatedAt:         so.CreatedAt,
+	}
+}
+
+// Convert from GORM SponsorObligation to domain SponsorObligation
+func ToDomainSponsorObligation(so *SponsorObligation) *campaignModel.SponsorObligation {
+	return &campaignModel.SponsorObligation{
+		Base: model.Base{
+			ID:        so.ID,
+			CreatedAt: so.CreatedAt,
+			UpdatedAt: so.CreatedAt,
+		},
+		CampaignID:        so.CampaignID,
+		SponsorCampaignID: so.SponsorCampaignID,
+		Sponsors:          UnmarshalUsers(so.Sponsors),
+		PledgedDistance:   so.PledgedDistance,
+		CreditedDistance:  so.CreditedDistance,
+		AmountPerKm:       so.AmountPerKm,
+		Amount:            so.Amount,
+	}
+}
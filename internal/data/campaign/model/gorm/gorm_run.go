@@ -0,0 +1,103 @@
+package gorm
+
+import (
+	"encoding/json"
+	"time"
+
+	activityModel "gopi.com/internal/domain/activity/model"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// CampaignRun is one recorded activity, kept for daily limits and the review queue.
+type CampaignRun struct {
+	ID              string  `gorm:"type:varchar(255);primary_key"`
+	CampaignID      string  `gorm:"not null;index"`
+	RunnerID        string  `gorm:"not null;index"`
+	OwnerID         string  `gorm:"not null;index:idx_campaign_run_owner_day"`
+	Activity        string  `gorm:"type:varchar(50)"`
+	Distance        float64 `gorm:"default:0"`
+	DurationSeconds int64   `gorm:"column:duration_seconds;default:0"`
+	MoneyRaised     float64 `gorm:"default:0"`
+	TrackURL        string
+	ElevationGain   float64 `gorm:"default:0"`
+	Status          string  `gorm:"type:varchar(20);index"`
+	Violations      string  `gorm:"type:text"` // JSON array of violations
+	ReviewedBy      string
+	ReviewedAt      *time.Time
+	CreatedAt       time.Time `gorm:"index:idx_campaign_run_owner_day"`
+	UpdatedAt       time.Time `gorm:"column:date_updated"`
+
+	Campaign Campaign `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE"`
+}
+
+func (CampaignRun) TableName() string {
+	return "campaign_runs"
+}
+
+func (cr *CampaignRun) BeforeCreate(tx *gorm.DB) (err error) {
+	if cr.ID == "" {
+		cr.ID = id.New()
+	}
+	return
+}
+
+// Convert from domain CampaignRun to GORM CampaignRun
+func FromDomainCampaignRun(cr *campaignModel.CampaignRun) *CampaignRun {
+	violations := ""
+	if len(cr.Violations) > 0 {
+		if data, err := json.Marshal(cr.Violations); err == nil {
+			violations = string(data)
+		}
+	}
+
+	return &CampaignRun{
+		ID:              cr.ID,
+		CampaignID:      cr.CampaignID,
+		RunnerID:        cr.RunnerID,
+		OwnerID:         cr.OwnerID,
+		Activity:        cr.Activity,
+		Distance:        cr.Distance,
+		DurationSeconds: model.Seconds(cr.Duration),
+		MoneyRaised:     cr.MoneyRaised,
+		TrackURL:        cr.TrackURL,
+		ElevationGain:   cr.ElevationGain,
+		Status:          string(cr.Status),
+		Violations:      violations,
+		ReviewedBy:      cr.ReviewedBy,
+		ReviewedAt:      cr.ReviewedAt,
+		CreatedAt:       cr.CreatedAt,
+		UpdatedAt:       cr.UpdatedAt,
+	}
+}
+
+// Convert from GORM CampaignRun to domain CampaignRun
+func ToDomainCampaignRun(cr *CampaignRun) *campaignModel.CampaignRun {
+	var violations []activityModel.Violation
+	if cr.Violations != "" {
+		json.Unmarshal([]byte(cr.Violations), &violations)
+	}
+
+	return &campaignModel.CampaignRun{
+		Base: model.Base{
+			ID:        cr.ID,
+			CreatedAt: cr.CreatedAt,
+			UpdatedAt: cr.UpdatedAt,
+		},
+		CampaignID:    cr.CampaignID,
+		RunnerID:      cr.RunnerID,
+		OwnerID:       cr.OwnerID,
+		Activity:      cr.Activity,
+		Distance:      cr.Distance,
+		Duration:      model.FromSeconds(cr.DurationSeconds),
+		MoneyRaised:   cr.MoneyRaised,
+		TrackURL:      cr.TrackURL,
+		ElevationGain: cr.ElevationGain,
+		Status:        activityModel.ReviewStatus(cr.Status),
+		Violations:    violations,
+		ReviewedBy:    cr.ReviewedBy,
+		ReviewedAt:    cr.ReviewedAt,
+	}
+}
@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"time"
+
+	userGorm "gopi.com/internal/data/user/model/gorm"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// CampaignTeam is a group of members competing together within a campaign.
+type CampaignTeam struct {
+	ID         string `gorm:"type:varchar(255);primary_key"`
+	CampaignID string `gorm:"not null;index;uniqueIndex:idx_campaign_team_name;uniqueIndex:idx_campaign_team_invite"`
+	Name       string `gorm:"not null;uniqueIndex:idx_campaign_team_name"`
+	CaptainID  string `gorm:"not null;index"`
+	InviteCode string `gorm:"type:varchar(32);not null;uniqueIndex:idx_campaign_team_invite"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time `gorm:"column:date_updated"`
+
+	Campaign Campaign             `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE"`
+	Members  []CampaignTeamMember `gorm:"foreignKey:TeamID;constraint:OnDelete:CASCADE"`
+}
+
+func (CampaignTeam) TableName() string {
+	return "campaign_teams"
+}
+
+// CampaignTeamMember places a user in a team. The unique campaign/user index keeps each user
+// in at most one team per campaign.
+type CampaignTeamMember struct {
+	ID         string    `gorm:"type:varchar(255);primary_key"`
+	TeamID     string    `gorm:"not null;index"`
+	CampaignID string    `gorm:"not null;uniqueIndex:idx_campaign_team_member"`
+	UserID     string    `gorm:"not null;uniqueIndex:idx_campaign_team_member"`
+	JoinedAt   time.Time `gorm:"index"`
+
+	User userGorm.UserGORM `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (CampaignTeamMember) TableName() string {
+	return "campaign_team_members"
+}
+
+func (ct *CampaignTeam) BeforeCreate(tx *gorm.DB) (err error) {
+	if ct.ID == "" {
+		ct.ID = id.New()
+	}
+	return
+}
+
+func (tm *CampaignTeamMember) BeforeCreate(tx *gorm.DB) (err error) {
+	if tm.ID == "" {
+		tm.ID = id.New()
+	}
+	if tm.JoinedAt.IsZero() {
+		tm.JoinedAt = time.Now()
+	}
+	return
+}
+
+// Convert from domain CampaignTeam to GORM CampaignTeam
+func FromDomainCampaignTeam(ct *campaignModel.CampaignTeam) *CampaignTeam {
+	return &CampaignTeam{
+		ID:         ct.ID,
+		CampaignID: ct.CampaignID,
+		Name:       ct.Name,
+		CaptainID:  ct.CaptainID,
+		InviteCode: ct.InviteCode,
+		CreatedAt:  ct.CreatedAt,
+		UpdatedAt:  ct.UpdatedAt,
+		// Note: Members are handled separately
+	}
+}
+
+// Convert from GORM CampaignTeam to domain CampaignTeam
+func ToDomainCampaignTeam(ct *CampaignTeam) *campaignModel.CampaignTeam {
+	members := []string{}
+	for _, member := range ct.Members {
+		members = append(members, member.UserID)
+	}
+
+	return &campaignModel.CampaignTeam{
+		Base: model.Base{
+			ID:        ct.ID,
+			CreatedAt: ct.CreatedAt,
+			UpdatedAt: ct.UpdatedAt,
+		},
+		CampaignID: ct.CampaignID,
+		Name:       ct.Name,
+		CaptainID:  ct.CaptainID,
+		InviteCode: ct.InviteCode,
+		Members:    members,
+	}
+}
+
+// Convert from domain CampaignTeamMember to GORM CampaignTeamMember
+func FromDomainCampaignTeamMember(tm *campaignModel.CampaignTeamMember) *CampaignTeamMember {
+	return &CampaignTeamMember{
+		TeamID:     tm.TeamID,
+		CampaignID: tm.CampaignID,
+		UserID:     tm.UserID,
+		JoinedAt:   tm.JoinedAt,
+	}
+}
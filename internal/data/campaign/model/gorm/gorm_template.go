@@ -0,0 +1,137 @@
+package gorm
+
+import (
+	"encoding/json"
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// CampaignTemplate is a reusable campaign blueprint and its recurring schedule.
+type CampaignTemplate struct {
+	ID                string `gorm:"type:varchar(255);primary_key"`
+	OwnerID           string `gorm:"not null;index"`
+	SourceCampaignID  string `gorm:"index"`
+	Name              string `gorm:"not null"`
+	Description       string `gorm:"type:text"`
+	Condition         string `gorm:"type:text"`
+	Mode              string
+	Goal              string `gorm:"type:text"`
+	Activity          string
+	Location          string
+	Latitude          *float64
+	Longitude         *float64
+	Visibility        string `gorm:"type:varchar(20)"`
+	TargetAmount      float64
+	TargetAmountPerKm float64
+	DistanceToCover   float64
+	WorkoutImg        string
+	StartsAt          *time.Time
+	LengthSeconds     int64      `gorm:"column:length_seconds;default:0"`
+	SponsorSlots      string     `gorm:"type:text"` // JSON array of slots
+	Milestones        string     `gorm:"type:text"` // JSON array of milestone specs
+	Recurrence        string     `gorm:"type:varchar(20)"`
+	NextStartsAt      *time.Time `gorm:"index"`
+	LeadSeconds       int64      `gorm:"column:lead_seconds;default:0"`
+	Editions          int        `gorm:"default:0"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time `gorm:"column:date_updated"`
+}
+
+func (CampaignTemplate) TableName() string {
+	return "campaign_templates"
+}
+
+func (ct *CampaignTemplate) BeforeCreate(tx *gorm.DB) (err error) {
+	if ct.ID == "" {
+		ct.ID = id.New()
+	}
+	return
+}
+
+// Convert from domain CampaignTemplate to GORM CampaignTemplate
+func FromDomainCampaignTemplate(ct *campaignModel.CampaignTemplate) *CampaignTemplate {
+	slots, milestones := "[]", "[]"
+	if data, err := json.Marshal(ct.SponsorSlots); err == nil && ct.SponsorSlots != nil {
+		slots = string(data)
+	}
+	if data, err := json.Marshal(ct.Milestones); err == nil && ct.Milestones != nil {
+		milestones = string(data)
+	}
+
+	return &CampaignTemplate{
+		ID:                ct.ID,
+		OwnerID:           ct.OwnerID,
+		SourceCampaignID:  ct.SourceCampaignID,
+		Name:              ct.Name,
+		Description:       ct.Description,
+		Condition:         ct.Condition,
+		Mode:              string(ct.Mode),
+		Goal:              ct.Goal,
+		Activity:          string(ct.Activity),
+		Location:          ct.Location,
+		Latitude:          model.Latitude(ct.Coordinates),
+		Longitude:         model.Longitude(ct.Coordinates),
+		Visibility:        string(ct.Visibility),
+		TargetAmount:      ct.TargetAmount,
+		TargetAmountPerKm: ct.TargetAmountPerKm,
+		DistanceToCover:   ct.DistanceToCover,
+		WorkoutImg:        ct.WorkoutImg,
+		StartsAt:          ct.StartsAt,
+		LengthSeconds:     model.Seconds(ct.Length),
+		SponsorSlots:      slots,
+		Milestones:        milestones,
+		Recurrence:        string(ct.Recurrence),
+		NextStartsAt:      ct.NextStartsAt,
+		LeadSeconds:       model.Seconds(ct.Lead),
+		Editions:          ct.Editions,
+		CreatedAt:         ct.CreatedAt,
+		UpdatedAt:         ct.UpdatedAt,
+	}
+}
+
+// Convert from GORM CampaignTemplate to domain CampaignTemplate
+func ToDomainCampaignTemplate(ct *CampaignTemplate) *campaignModel.CampaignTemplate {
+	slots := []campaignModel.TemplateSponsorSlot{}
+	if ct.SponsorSlots != "" {
+		json.Unmarshal([]byte(ct.SponsorSlots), &slots)
+	}
+	milestones := []campaignModel.TemplateMilestone{}
+	if ct.Milestones != "" {
+		json.Unmarshal([]byte(ct.Milestones), &milestones)
+	}
+
+	return &campaignModel.CampaignTemplate{
+		Base: model.Base{
+			ID:        ct.ID,
+			CreatedAt: ct.CreatedAt,
+			UpdatedAt: ct.UpdatedAt,
+		},
+		OwnerID:           ct.OwnerID,
+		SourceCampaignID:  ct.SourceCampaignID,
+		Name:              ct.Name,
+		Description:       ct.Description,
+		Condition:         ct.Condition,
+		Mode:              campaignModel.CampaignMode(ct.Mode),
+		Goal:              ct.Goal,
+		Activity:          campaignModel.Activity(ct.Activity),
+		Location:          ct.Location,
+		Coordinates:       model.FromLatLon(ct.Latitude, ct.Longitude),
+		Visibility:        campaignModel.CampaignVisibility(ct.Visibility),
+		TargetAmount:      ct.TargetAmount,
+		TargetAmountPerKm: ct.TargetAmountPerKm,
+		DistanceToCover:   ct.DistanceToCover,
+		WorkoutImg:        ct.WorkoutImg,
+		StartsAt:          ct.StartsAt,
+		Length:            model.FromSeconds(ct.LengthSeconds),
+		SponsorSlots:      slots,
+		Milestones:        milestones,
+		Recurrence:        campaignModel.Recurrence(ct.Recurrence),
+		NextStartsAt:      ct.NextStartsAt,
+		Lead:              model.FromSeconds(ct.LeadSeconds),
+		Editions:          ct.Editions,
+	}
+}
@@ -0,0 +1,192 @@
+package gorm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gorm.io/gorm"
+)
+
+// MigrateCampaignLifecycle backfills starts_at/ends_at from the legacy start_duration/end_duration
+// strings and assigns an initial status to campaigns created before the lifecycle existed.
+// Only rows without a status are touched, so it is safe to run on every start-up.
+// Strings that cannot be parsed are left as-is and the campaign is treated as open-ended.
+func MigrateCampaignLifecycle(db *gorm.DB) error {
+	now := time.Now()
+	var rows []Campaign
+	return db.Select("id", "start_duration", "end_duration", "starts_at", "ends_at").
+		Where("status = '' OR status IS NULL").
+		FindInBatches(&rows, 200, func(tx *gorm.DB, batch int) error {
+			for _, row := range rows {
+				c := &campaignModel.Campaign{StartsAt: row.StartsAt, EndsAt: row.EndsAt}
+				if c.StartsAt == nil {
+					if t, err := campaignModel.ParseCampaignTime(row.StartDuration); err == nil {
+						c.StartsAt = t
+					} else {
+						slog.Warn("campaign start not parseable", "campaign_id", row.ID, "value", row.StartDuration)
+					}
+				}
+				if c.EndsAt == nil {
+					if t, err := campaignModel.ParseCampaignTime(row.EndDuration); err == nil {
+						c.EndsAt = t
+					} else {
+						slog.Warn("campaign end not parseable", "campaign_id", row.ID, "value", row.EndDuration)
+					}
+				}
+
+				if err := db.Model(&Campaign{}).Where("id = ?", row.ID).Updates(map[string]interface{}{
+					"starts_at": c.StartsAt,
+					"ends_at":   c.EndsAt,
+					"status":    string(c.ScheduledStatus(now)),
+				}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+// MigrateSponsorLists rewrites the sponsor lists that older releases stored as free-form JSON,
+// usually bare user IDs, as typed user summaries: on sponsorships, on sponsor obligations and on
+// template sponsor slots. Entries without a user ID are dropped. Rows already typed are left
+// alone, so it is safe to run on every start-up.
+func MigrateSponsorLists(db *gorm.DB) error {
+	for _, table := range []interface{}{&SponsorCampaign{}, &SponsorObligation{}} {
+		if err := migrateUserColumn(db, table, "sponsors", func(data string) string {
+			return MarshalUsers(UnmarshalUsers(data))
+		}); err != nil {
+			return err
+		}
+	}
+	return migrateUserColumn(db, &CampaignTemplate{}, "sponsor_slots", migrateSlotSponsors)
+}
+
+// migrateUserColumn rewrites column on every row of table where typed returns something else.
+func migrateUserColumn(db *gorm.DB, table interface{}, column string, typed func(string) string) error {
+	type jsonRow struct {
+		ID   string
+		Data string
+	}
+
+	var lastID string
+	for {
+		var rows []jsonRow
+		if err := db.Model(table).Select("id", column+" AS data").
+			Where("id > ? AND "+column+" IS NOT NULL AND "+column+" <> ''", lastID).
+			Order("id").Limit(200).Scan(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		for _, row := range rows {
+			lastID = row.ID
+			data := typed(row.Data)
+			if data == row.Data {
+				continue
+			}
+			if err := db.Model(table).Where("id = ?", row.ID).Update(column, data).Error; err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// migrateSlotSponsors types the sponsors of each slot in a template's sponsor_slots JSON. Slots
+// that cannot be parsed are logged and left in place.
+func migrateSlotSponsors(data string) string {
+	var slots []map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &slots); err != nil {
+		slog.Warn("template sponsor slots not parseable", "value", data)
+		return data
+	}
+	changed := false
+	for _, slot := range slots {
+		raw, ok := slot["sponsors"]
+		if !ok {
+			continue
+		}
+		sponsors := MarshalUsers(UnmarshalUsers(string(raw)))
+		if sponsors == "" {
+			sponsors = "[]"
+		}
+		if sponsors != string(raw) {
+			slot["sponsors"] = json.RawMessage(sponsors)
+			changed = true
+		}
+	}
+	if !changed {
+		return data
+	}
+	typed, err := json.Marshal(slots)
+	if err != nil {
+		return data
+	}
+	return string(typed)
+}
+
+// CampaignSearchTable is the SQLite FTS5 index over campaign names, descriptions and locations.
+const CampaignSearchTable = "campaign_campaign_fts"
+
+// CampaignFulltextIndex is the MySQL FULLTEXT index over the same columns.
+const CampaignFulltextIndex = "idx_campaign_fulltext"
+
+var errNoFTS5 = errors.New("sqlite built without fts5")
+
+// MigrateCampaignSearch creates the full-text index campaign search uses: an FTS5 table kept in
+// sync by triggers on SQLite, or a FULLTEXT index on MySQL. Other databases, and SQLite builds
+// without the sqlite_fts5 tag, fall back to LIKE matching. It is safe to run on every start-up.
+func MigrateCampaignSearch(db *gorm.DB) error {
+	switch db.Dialector.Name() {
+	case "sqlite":
+		if db.Migrator().HasTable(CampaignSearchTable) {
+			return nil
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(`CREATE VIRTUAL TABLE ` + CampaignSearchTable + ` USING fts5(
+				name, description, location, content='campaign_campaign', content_rowid='rowid')`).Error; err != nil {
+				return fmt.Errorf("%w: %v", errNoFTS5, err)
+			}
+			for _, stmt := range []string{
+				`CREATE TRIGGER IF NOT EXISTS campaign_campaign_fts_ai AFTER INSERT ON campaign_campaign BEGIN
+					INSERT INTO ` + CampaignSearchTable + `(rowid, name, description, location)
+					VALUES (new.rowid, new.name, new.description, new.location);
+				END`,
+				`CREATE TRIGGER IF NOT EXISTS campaign_campaign_fts_ad AFTER DELETE ON campaign_campaign BEGIN
+					INSERT INTO ` + CampaignSearchTable + `(` + CampaignSearchTable + `, rowid, name, description, location)
+					VALUES ('delete', old.rowid, old.name, old.description, old.location);
+				END`,
+				`CREATE TRIGGER IF NOT EXISTS campaign_campaign_fts_au AFTER UPDATE ON campaign_campaign BEGIN
+					INSERT INTO ` + CampaignSearchTable + `(` + CampaignSearchTable + `, rowid, name, description, location)
+					VALUES ('delete', old.rowid, old.name, old.description, old.location);
+					INSERT INTO ` + CampaignSearchTable + `(rowid, name, description, location)
+					VALUES (new.rowid, new.name, new.description, new.location);
+				END`,
+				// Index the campaigns created before the table existed.
+				`INSERT INTO ` + CampaignSearchTable + `(` + CampaignSearchTable + `) VALUES ('rebuild')`,
+			} {
+				if err := tx.Exec(stmt).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if errors.Is(err, errNoFTS5) {
+			slog.Warn("campaign full-text search unavailable, falling back to LIKE", "err", err)
+			return nil
+		}
+		return err
+	case "mysql":
+		if db.Migrator().HasIndex(&Campaign{}, CampaignFulltextIndex) {
+			return nil
+		}
+		return db.Exec("CREATE FULLTEXT INDEX " + CampaignFulltextIndex + " ON campaign_campaign (name, description, location)").Error
+	default:
+		return nil
+	}
+}
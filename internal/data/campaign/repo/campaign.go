@@ -3,10 +3,13 @@ package repo
 import (
 	"errors"
 	"strings"
+	"time"
+	"unicode"
 
 	"gorm.io/gorm"
 
 	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/db"
 	campaignModel "gopi.com/internal/domain/campaign/model"
 	campaignRepo "gopi.com/internal/domain/campaign/repo"
 )
@@ -26,20 +29,16 @@ func (r *GormCampaignRepository) Create(campaign *campaignModel.Campaign) error
 	}
 
 	// Handle Members relationships - add each member to the junction table
-	for _, memberID := range campaign.Members {
-		if userID, ok := memberID.(string); ok {
-			if err := r.AddMember(dbCampaign.ID, userID); err != nil {
-				return err
-			}
+	for _, member := range campaign.Members {
+		if err := r.AddMember(dbCampaign.ID, member.ID); err != nil {
+			return err
 		}
 	}
 
 	// Handle Sponsors relationships - add each sponsor to the junction table
-	for _, sponsorID := range campaign.Sponsors {
-		if userID, ok := sponsorID.(string); ok {
-			if err := r.AddSponsor(dbCampaign.ID, userID); err != nil {
-				return err
-			}
+	for _, sponsor := range campaign.Sponsors {
+		if err := r.AddSponsor(dbCampaign.ID, sponsor.ID); err != nil {
+			return err
 		}
 	}
 
@@ -49,7 +48,7 @@ func (r *GormCampaignRepository) Create(campaign *campaignModel.Campaign) error
 
 func (r *GormCampaignRepository) GetByID(id string) (*campaignModel.Campaign, error) {
 	var c gormmodel.Campaign
-	if err := r.db.Preload("Members").Preload("Sponsors").First(&c, "id = ?", id).Error; err != nil {
+	if err := r.db.Preload("Members.User").Preload("Sponsors.User").First(&c, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
@@ -61,7 +60,7 @@ func (r *GormCampaignRepository) GetByID(id string) (*campaignModel.Campaign, er
 
 func (r *GormCampaignRepository) GetBySlug(slug string) (*campaignModel.Campaign, error) {
 	var c gormmodel.Campaign
-	if err := r.db.Preload("Members").Preload("Sponsors").Where("slug = ?", slug).First(&c).Error; err != nil {
+	if err := r.db.Preload("Members.User").Preload("Sponsors.User").Where("slug = ?", slug).First(&c).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
@@ -73,7 +72,7 @@ func (r *GormCampaignRepository) GetBySlug(slug string) (*campaignModel.Campaign
 
 func (r *GormCampaignRepository) GetByOwnerID(ownerID string) ([]*campaignModel.Campaign, error) {
 	var campaigns []gormmodel.Campaign
-	if err := r.db.Preload("Members").Preload("Sponsors").Where("owner_id = ?", ownerID).Find(&campaigns).Error; err != nil {
+	if err := r.db.Preload("Members.User").Preload("Sponsors.User").Where("owner_id = ?", ownerID).Find(&campaigns).Error; err != nil {
 		return nil, err
 	}
 
@@ -99,7 +98,7 @@ func (r *GormCampaignRepository) Delete(id string) error {
 
 func (r *GormCampaignRepository) List(limit, offset int) ([]*campaignModel.Campaign, error) {
 	var campaigns []gormmodel.Campaign
-	if err := r.db.Preload("Members").Preload("Sponsors").Limit(limit).Offset(offset).Order("date_created DESC").Find(&campaigns).Error; err != nil {
+	if err := r.db.Preload("Members.User").Preload("Sponsors.User").Limit(limit).Offset(offset).Order("date_created DESC").Find(&campaigns).Error; err != nil {
 		return nil, err
 	}
 
@@ -111,21 +110,179 @@ func (r *GormCampaignRepository) List(limit, offset int) ([]*campaignModel.Campa
 }
 
 func (r *GormCampaignRepository) Search(query string, limit, offset int) ([]*campaignModel.Campaign, error) {
-	var campaigns []gormmodel.Campaign
-	searchPattern := "%" + strings.ToLower(query) + "%"
+	campaigns, _, err := r.Find(campaignModel.CampaignFilter{Query: query, Limit: limit, Offset: offset})
+	return campaigns, err
+}
 
-	if err := r.db.Preload("Members").Preload("Sponsors").
-		Where("LOWER(name) LIKE ? OR LOWER(description) LIKE ? OR LOWER(location) LIKE ?",
-			searchPattern, searchPattern, searchPattern).
-		Limit(limit).Offset(offset).Order("date_created DESC").Find(&campaigns).Error; err != nil {
-		return nil, err
+func (r *GormCampaignRepository) Find(filter campaignModel.CampaignFilter) ([]*campaignModel.Campaign, int64, error) {
+	if filter.Near != nil {
+		return r.findNear(filter)
+	}
+
+	var total int64
+	if err := r.filtered(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	q := ordered(r.filtered(filter).Preload("Members.User").Preload("Sponsors.User"), filter.Sort)
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit).Offset(filter.Offset)
+	}
+
+	var campaigns []gormmodel.Campaign
+	if err := q.Find(&campaigns).Error; err != nil {
+		return nil, 0, err
 	}
 
 	var result []*campaignModel.Campaign
 	for _, c := range campaigns {
 		result = append(result, gormmodel.ToDomainCampaign(&c))
 	}
-	return result, nil
+	return result, total, nil
+}
+
+// findNear is Find for a radius search: the distance check runs in Go, so matching ids are
+// collected first and only the requested page is loaded.
+func (r *GormCampaignRepository) findNear(filter campaignModel.CampaignFilter) ([]*campaignModel.Campaign, int64, error) {
+	ids, err := db.SelectWithinRadius(ordered(r.filtered(filter), filter.Sort), *filter.Near,
+		filter.Sort == campaignModel.CampaignSortNearest)
+	if err != nil {
+		return nil, 0, err
+	}
+	page := db.PageIDs(ids, filter.Limit, filter.Offset)
+	if len(page) == 0 {
+		return nil, int64(len(ids)), nil
+	}
+
+	var campaigns []gormmodel.Campaign
+	if err := r.db.Preload("Members.User").Preload("Sponsors.User").Where("id IN ?", page).Find(&campaigns).Error; err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[string]*campaignModel.Campaign, len(campaigns))
+	for _, c := range campaigns {
+		byID[c.ID] = gormmodel.ToDomainCampaign(&c)
+	}
+
+	var result []*campaignModel.Campaign
+	for _, id := range page {
+		if c, ok := byID[id]; ok {
+			result = append(result, c)
+		}
+	}
+	return result, int64(len(ids)), nil
+}
+
+// ordered applies a search sort order. Nearest is applied after the distance check, so here
+// it falls back to newest first.
+func ordered(q *gorm.DB, sort campaignModel.CampaignSort) *gorm.DB {
+	switch sort {
+	case campaignModel.CampaignSortMostFunded:
+		q = q.Order("money_raised DESC")
+	case campaignModel.CampaignSortClosestToGoal:
+		// Open campaigns first, by the further along of their distance and funding goals;
+		// campaigns without a goal come last.
+		q = q.Order("CASE WHEN achieved_at IS NULL THEN 0 ELSE 1 END").Order(`CASE
+			WHEN distance_to_cover > 0 AND (target_amount <= 0
+				OR distance_covered / NULLIF(distance_to_cover, 0) >= money_raised / NULLIF(target_amount, 0))
+				THEN distance_covered / NULLIF(distance_to_cover, 0)
+			WHEN target_amount > 0 THEN money_raised / NULLIF(target_amount, 0)
+			ELSE -1 END DESC`)
+	}
+	return q.Order("date_created DESC").Order("id")
+}
+
+// filtered applies every condition in filter except sorting and paging.
+func (r *GormCampaignRepository) filtered(filter campaignModel.CampaignFilter) *gorm.DB {
+	q := r.db.Model(&gormmodel.Campaign{})
+
+	if terms := searchTerms(filter.Query); len(terms) > 0 {
+		q = r.matchText(q, terms)
+	}
+	if filter.Activity != "" {
+		q = q.Where("activity = ?", string(filter.Activity))
+	}
+	if filter.Mode != "" {
+		q = q.Where("mode = ?", string(filter.Mode))
+	}
+	if filter.Location != "" {
+		q = q.Where("LOWER(location) LIKE ?", "%"+strings.ToLower(filter.Location)+"%")
+	}
+	if filter.Status == campaignModel.CampaignStatusActive {
+		q = q.Where("(status = ? OR status = '' OR status IS NULL)", string(filter.Status))
+	} else if filter.Status != "" {
+		q = q.Where("status = ?", string(filter.Status))
+	}
+	if filter.OwnerID != "" {
+		q = q.Where("owner_id = ?", filter.OwnerID)
+	}
+	if filter.ExcludeOwnerID != "" {
+		q = q.Where("owner_id <> ?", filter.ExcludeOwnerID)
+	}
+	if filter.From != nil {
+		q = q.Where("(ends_at IS NULL OR ends_at >= ?)", *filter.From)
+	}
+	if filter.To != nil {
+		q = q.Where("(starts_at IS NULL OR starts_at <= ?)", *filter.To)
+	}
+	if filter.MinTarget != nil {
+		q = q.Where("target_amount >= ?", *filter.MinTarget)
+	}
+	if filter.MaxTarget != nil {
+		q = q.Where("target_amount <= ?", *filter.MaxTarget)
+	}
+	if filter.Listed {
+		q = listedFor(q, filter.ViewerID)
+	}
+	return q
+}
+
+// listedFor keeps public campaigns, and unlisted or private ones viewerID owns or belongs to.
+// Campaigns created before visibility existed are public.
+func listedFor(q *gorm.DB, viewerID string) *gorm.DB {
+	public := "(visibility = ? OR visibility = '' OR visibility IS NULL)"
+	if viewerID == "" {
+		return q.Where(public, string(campaignModel.CampaignVisibilityPublic))
+	}
+	return q.Where("("+public+" OR owner_id = ? OR id IN (SELECT campaign_id FROM campaign_members WHERE user_id = ?))",
+		string(campaignModel.CampaignVisibilityPublic), viewerID, viewerID)
+}
+
+// matchText requires every term to appear in the name, description or location, using the
+// full-text index created by MigrateCampaignSearch when there is one.
+func (r *GormCampaignRepository) matchText(q *gorm.DB, terms []string) *gorm.DB {
+	switch r.db.Dialector.Name() {
+	case "sqlite":
+		if r.db.Migrator().HasTable(gormmodel.CampaignSearchTable) {
+			var match []string
+			for _, term := range terms {
+				match = append(match, `"`+term+`"*`)
+			}
+			return q.Where("rowid IN (SELECT rowid FROM "+gormmodel.CampaignSearchTable+" WHERE "+
+				gormmodel.CampaignSearchTable+" MATCH ?)", strings.Join(match, " "))
+		}
+	case "mysql":
+		if r.db.Migrator().HasIndex(&gormmodel.Campaign{}, gormmodel.CampaignFulltextIndex) {
+			var match []string
+			for _, term := range terms {
+				match = append(match, "+"+term+"*")
+			}
+			return q.Where("MATCH(name, description, location) AGAINST (? IN BOOLEAN MODE)", strings.Join(match, " "))
+		}
+	}
+
+	for _, term := range terms {
+		pattern := "%" + term + "%"
+		q = q.Where("(LOWER(name) LIKE ? OR LOWER(description) LIKE ? OR LOWER(location) LIKE ?)", pattern, pattern, pattern)
+	}
+	return q
+}
+
+// searchTerms splits a query into lower-case words, dropping punctuation so user input cannot
+// inject full-text operators.
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
 }
 
 // Many-to-many relationship methods
@@ -170,3 +327,59 @@ func (r *GormCampaignRepository) IsSponsor(campaignID, userID string) (bool, err
 		Count(&count).Error
 	return count > 0, err
 }
+
+// Lifecycle methods
+func (r *GormCampaignRepository) ListDueForTransition(now time.Time) ([]*campaignModel.Campaign, error) {
+	var campaigns []gormmodel.Campaign
+	if err := r.db.
+		Where("(status = ? AND starts_at <= ?) OR ((status = ? OR status = '' OR status IS NULL) AND ends_at <= ?)",
+			campaignModel.CampaignStatusScheduled, now, campaignModel.CampaignStatusActive, now).
+		Order("date_created ASC").Find(&campaigns).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.Campaign
+	for _, c := range campaigns {
+		result = append(result, gormmodel.ToDomainCampaign(&c))
+	}
+	return result, nil
+}
+
+func (r *GormCampaignRepository) UpdateStatus(id string, from, to campaignModel.CampaignStatus) (bool, error) {
+	q := r.db.Model(&gormmodel.Campaign{}).Where("id = ?", id)
+	if from == "" {
+		q = q.Where("(status = '' OR status IS NULL)")
+	} else {
+		q = q.Where("status = ?", from)
+	}
+	res := q.Updates(map[string]interface{}{"status": string(to), "date_updated": time.Now()})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *GormCampaignRepository) IncrementTotals(id string, distance, money float64) error {
+	return r.db.Model(&gormmodel.Campaign{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"distance_covered": gorm.Expr("distance_covered + ?", distance),
+		"money_raised":     gorm.Expr("money_raised + ?", money),
+		"date_updated":     time.Now(),
+	}).Error
+}
+
+// Close-out methods
+func (r *GormCampaignRepository) MarkAchieved(id string, at time.Time) (bool, error) {
+	res := r.db.Model(&gormmodel.Campaign{}).
+		Where("id = ? AND achieved_at IS NULL AND (status = ? OR status = '' OR status IS NULL)",
+			id, campaignModel.CampaignStatusActive).
+		Updates(map[string]interface{}{
+			"achieved_at":  at,
+			"status":       string(campaignModel.CampaignStatusCompleted),
+			"date_updated": time.Now(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *GormCampaignRepository) MarkClosed(id string, at time.Time) (bool, error) {
+	res := r.db.Model(&gormmodel.Campaign{}).
+		Where("id = ? AND closed_at IS NULL", id).
+		Updates(map[string]interface{}{"closed_at": at, "date_updated": time.Now()})
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+)
+
+type GormCampaignInviteRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCampaignInviteRepository(db *gorm.DB) campaignRepo.CampaignInviteRepository {
+	return &GormCampaignInviteRepository{db: db}
+}
+
+func (r *GormCampaignInviteRepository) Create(invite *campaignModel.CampaignInvite) error {
+	dbInvite := gormmodel.FromDomainCampaignInvite(invite)
+	if err := r.db.Create(dbInvite).Error; err != nil {
+		return err
+	}
+	*invite = *gormmodel.ToDomainCampaignInvite(dbInvite)
+	return nil
+}
+
+func (r *GormCampaignInviteRepository) GetByID(id string) (*campaignModel.CampaignInvite, error) {
+	var i gormmodel.CampaignInvite
+	err := r.db.First(&i, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, campaignModel.ErrInvalidInvite
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainCampaignInvite(&i), nil
+}
+
+func (r *GormCampaignInviteRepository) ListByCampaign(campaignID string) ([]*campaignModel.CampaignInvite, error) {
+	var invites []gormmodel.CampaignInvite
+	if err := r.db.Where("campaign_id = ?", campaignID).Order("created_at DESC").Find(&invites).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.CampaignInvite
+	for _, i := range invites {
+		result = append(result, gormmodel.ToDomainCampaignInvite(&i))
+	}
+	return result, nil
+}
+
+func (r *GormCampaignInviteRepository) Redeem(id string, now time.Time) (bool, error) {
+	result := r.db.Model(&gormmodel.CampaignInvite{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ? AND (max_uses = 0 OR uses < max_uses)", id, now).
+		Updates(map[string]interface{}{
+			"uses":         gorm.Expr("uses + 1"),
+			"date_updated": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *GormCampaignInviteRepository) Revoke(id string, at time.Time) error {
+	return r.db.Model(&gormmodel.CampaignInvite{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at":   at,
+			"date_updated": at,
+		}).Error
+}
+
+type GormCampaignJoinRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCampaignJoinRequestRepository(db *gorm.DB) campaignRepo.CampaignJoinRequestRepository {
+	return &GormCampaignJoinRequestRepository{db: db}
+}
+
+func (r *GormCampaignJoinRequestRepository) Create(request *campaignModel.CampaignJoinRequest) error {
+	dbRequest := gormmodel.FromDomainCampaignJoinRequest(request)
+	if err := r.db.Create(dbRequest).Error; err != nil {
+		return err
+	}
+	*request = *gormmodel.ToDomainCampaignJoinRequest(dbRequest)
+	return nil
+}
+
+func (r *GormCampaignJoinRequestRepository) GetByID(id string) (*campaignModel.CampaignJoinRequest, error) {
+	var jr gormmodel.CampaignJoinRequest
+	err := r.db.First(&jr, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, campaignModel.ErrJoinRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainCampaignJoinRequest(&jr), nil
+}
+
+func (r *GormCampaignJoinRequestRepository) GetPending(campaignID, userID string) (*campaignModel.CampaignJoinRequest, error) {
+	var jr gormmodel.CampaignJoinRequest
+	err := r.db.Where("campaign_id = ? AND user_id = ? AND status = ?",
+		campaignID, userID, string(campaignModel.JoinRequestPending)).First(&jr).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainCampaignJoinRequest(&jr), nil
+}
+
+func (r *GormCampaignJoinRequestRepository) ListByCampaign(campaignID string, status campaignModel.JoinRequestStatus) ([]*campaignModel.CampaignJoinRequest, error) {
+	q := r.db.Where("campaign_id = ?", campaignID)
+	if status != "" {
+		q = q.Where("status = ?", string(status))
+	}
+
+	var requests []gormmodel.CampaignJoinRequest
+	if err := q.Order("created_at ASC").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.CampaignJoinRequest
+	for _, jr := range requests {
+		result = append(result, gormmodel.ToDomainCampaignJoinRequest(&jr))
+	}
+	return result, nil
+}
+
+func (r *GormCampaignJoinRequestRepository) Decide(id string, status campaignModel.JoinRequestStatus, deciderID string, at time.Time) (bool, error) {
+	result := r.db.Model(&gormmodel.CampaignJoinRequest{}).
+		Where("id = ? AND status = ?", id, string(campaignModel.JoinRequestPending)).
+		Updates(map[string]interface{}{
+			"status":       string(status),
+			"decided_by":   deciderID,
+			"decided_at":   at,
+			"date_updated": at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,56 @@
+package repo
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+)
+
+type GormCampaignMilestoneRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCampaignMilestoneRepository(db *gorm.DB) campaignRepo.CampaignMilestoneRepository {
+	return &GormCampaignMilestoneRepository{db: db}
+}
+
+func (r *GormCampaignMilestoneRepository) ListByCampaign(campaignID string) ([]*campaignModel.CampaignMilestone, error) {
+	var milestones []gormmodel.CampaignMilestone
+	if err := r.db.Where("campaign_id = ?", campaignID).Order("created_at ASC").Find(&milestones).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.CampaignMilestone
+	for _, cm := range milestones {
+		result = append(result, gormmodel.ToDomainCampaignMilestone(&cm))
+	}
+	return result, nil
+}
+
+func (r *GormCampaignMilestoneRepository) ReplacePending(campaignID string, milestones []*campaignModel.CampaignMilestone) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("campaign_id = ? AND reached_at IS NULL", campaignID).
+			Delete(&gormmodel.CampaignMilestone{}).Error; err != nil {
+			return err
+		}
+		for _, milestone := range milestones {
+			dbMilestone := gormmodel.FromDomainCampaignMilestone(milestone)
+			if err := tx.Create(dbMilestone).Error; err != nil {
+				return err
+			}
+			*milestone = *gormmodel.ToDomainCampaignMilestone(dbMilestone)
+		}
+		return nil
+	})
+}
+
+func (r *GormCampaignMilestoneRepository) MarkReached(id string, at time.Time) (bool, error) {
+	res := r.db.Model(&gormmodel.CampaignMilestone{}).
+		Where("id = ? AND reached_at IS NULL", id).
+		Updates(map[string]interface{}{"reached_at": at, "date_updated": at})
+	return res.RowsAffected > 0, res.Error
+}
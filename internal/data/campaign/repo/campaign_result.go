@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+)
+
+type GormCampaignResultRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCampaignResultRepository(db *gorm.DB) campaignRepo.CampaignResultRepository {
+	return &GormCampaignResultRepository{db: db}
+}
+
+// SaveStandings replaces any standings already stored for the campaign.
+func (r *GormCampaignResultRepository) SaveStandings(campaignID string, standings []*campaignModel.CampaignStanding) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("campaign_id = ?", campaignID).Delete(&gormmodel.CampaignStanding{}).Error; err != nil {
+			return err
+		}
+		for _, standing := range standings {
+			dbStanding := gormmodel.FromDomainCampaignStanding(standing)
+			if err := tx.Create(dbStanding).Error; err != nil {
+				return err
+			}
+			*standing = *gormmodel.ToDomainCampaignStanding(dbStanding)
+		}
+		return nil
+	})
+}
+
+func (r *GormCampaignResultRepository) GetStandings(campaignID string) ([]*campaignModel.CampaignStanding, error) {
+	var standings []gormmodel.CampaignStanding
+	if err := r.db.Where("campaign_id = ?", campaignID).Order("position ASC").Find(&standings).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.CampaignStanding
+	for _, s := range standings {
+		result = append(result, gormmodel.ToDomainCampaignStanding(&s))
+	}
+	return result, nil
+}
+
+// SaveObligations replaces any obligations already stored for the campaign.
+func (r *GormCampaignResultRepository) SaveObligations(campaignID string, obligations []*campaignModel.SponsorObligation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("campaign_id = ?", campaignID).Delete(&gormmodel.SponsorObligation{}).Error; err != nil {
+			return err
+		}
+		for _, obligation := range obligations {
+			dbObligation := gormmodel.FromDomainSponsorObligation(obligation)
+			if err := tx.Create(dbObligation).Error; err != nil {
+				return err
+			}
+			*obligation = *gormmodel.ToDomainSponsorObligation(dbObligation)
+		}
+		return nil
+	})
+}
+
+func (r *GormCampaignResultRepository) GetObligations(campaignID string) ([]*campaignModel.SponsorObligation, error) {
+	var obligations []gormmodel.SponsorObligation
+	if err := r.db.Where("campaign_id = ?", campaignID).Order("amount DESC").Find(&obligations).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.SponsorObligation
+	var names [][]campaignModel.UserSummary
+	for _, o := range obligations {
+		obligation := gormmodel.ToDomainSponsorObligation(&o)
+		result = append(result, obligation)
+		names = append(names, obligation.Sponsors)
+	}
+	if err := gormmodel.FillUserNames(r.db, names...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,93 @@
+package repo
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	activityModel "gopi.com/internal/domain/activity/model"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+)
+
+type GormCampaignRunRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCampaignRunRepository(db *gorm.DB) campaignRepo.CampaignRunRepository {
+	return &GormCampaignRunRepository{db: db}
+}
+
+func (r *GormCampaignRunRepository) Create(run *campaignModel.CampaignRun) error {
+	dbRun := gormmodel.FromDomainCampaignRun(run)
+	if err := r.db.Create(dbRun).Error; err != nil {
+		return err
+	}
+	*run = *gormmodel.ToDomainCampaignRun(dbRun)
+	return nil
+}
+
+func (r *GormCampaignRunRepository) GetByID(id string) (*campaignModel.CampaignRun, error) {
+	var run gormmodel.CampaignRun
+	if err := r.db.First(&run, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainCampaignRun(&run), nil
+}
+
+func (r *GormCampaignRunRepository) ListByStatus(status activityModel.ReviewStatus, limit, offset int) ([]*campaignModel.CampaignRun, error) {
+	var runs []gormmodel.CampaignRun
+	if err := r.db.Where("status = ?", string(status)).Order("created_at ASC").Limit(limit).Offset(offset).Find(&runs).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.CampaignRun
+	for _, run := range runs {
+		result = append(result, gormmodel.ToDomainCampaignRun(&run))
+	}
+	return result, nil
+}
+
+func (r *GormCampaignRunRepository) SumDistanceSince(ownerID string, since time.Time) (float64, error) {
+	var total float64
+	err := r.db.Model(&gormmodel.CampaignRun{}).
+		Where("owner_id = ? AND created_at >= ? AND status IN ?", ownerID, since,
+			[]string{string(activityModel.ReviewStatusAccepted), string(activityModel.ReviewStatusApproved)}).
+		Select("COALESCE(SUM(distance), 0)").Scan(&total).Error
+	return total, err
+}
+
+func (r *GormCampaignRunRepository) ListForLeaderboard(campaignID string, since time.Time, activity string) ([]*campaignModel.CampaignRun, error) {
+	query := r.db.Where("campaign_id = ? AND created_at >= ? AND status IN ?", campaignID, since,
+		[]string{string(activityModel.ReviewStatusAccepted), string(activityModel.ReviewStatusApproved)})
+	if activity != "" {
+		query = query.Where("LOWER(activity) = ?", strings.ToLower(activity))
+	}
+	var runs []gormmodel.CampaignRun
+	if err := query.Find(&runs).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.CampaignRun
+	for _, run := range runs {
+		result = append(result, gormmodel.ToDomainCampaignRun(&run))
+	}
+	return result, nil
+}
+
+func (r *GormCampaignRunRepository) Review(id string, status activityModel.ReviewStatus, reviewerID string, at time.Time) (bool, error) {
+	result := r.db.Model(&gormmodel.CampaignRun{}).
+		Where("id = ? AND status = ?", id, string(activityModel.ReviewStatusFlagged)).
+		Updates(map[string]interface{}{
+			"status":       string(status),
+			"reviewed_by":  reviewerID,
+			"reviewed_at":  at,
+			"date_updated": at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
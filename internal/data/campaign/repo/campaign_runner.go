@@ -2,12 +2,14 @@ package repo
 
 import (
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
 	gormmodel "gopi.com/internal/data/campaign/model/gorm"
 	campaignModel "gopi.com/internal/domain/campaign/model"
 	campaignRepo "gopi.com/internal/domain/campaign/repo"
+	"gopi.com/internal/domain/model"
 )
 
 type GormCampaignRunnerRepository struct {
@@ -77,3 +79,20 @@ func (r *GormCampaignRunnerRepository) Update(runner *campaignModel.CampaignRunn
 func (r *GormCampaignRunnerRepository) Delete(id string) error {
 	return r.db.Delete(&gormmodel.CampaignRunner{}, "id = ?", id).Error
 }
+
+func (r *GormCampaignRunnerRepository) IncrementProgress(id string, distance, money float64, duration time.Duration) error {
+	return r.db.Model(&gormmodel.CampaignRunner{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"distance_covered": gorm.Expr("distance_covered + ?", distance),
+		"money_raised":     gorm.Expr("money_raised + ?", money),
+		"duration_seconds": gorm.Expr("duration_seconds + ?", model.Seconds(duration)),
+		"date_updated":     time.Now(),
+	}).Error
+}
+
+func (r *GormCampaignRunnerRepository) AttachTrack(id, trackURL string, elevationGain float64) error {
+	return r.db.Model(&gormmodel.CampaignRunner{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"track_url":      trackURL,
+		"elevation_gain": gorm.Expr("elevation_gain + ?", elevationGain),
+		"date_updated":   time.Now(),
+	}).Error
+}
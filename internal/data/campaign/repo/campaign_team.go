@@ -0,0 +1,157 @@
+package repo
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+	"gopi.com/internal/domain/model"
+)
+
+type GormCampaignTeamRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCampaignTeamRepository(db *gorm.DB) campaignRepo.CampaignTeamRepository {
+	return &GormCampaignTeamRepository{db: db}
+}
+
+// preloadMembers loads member rows longest-standing first, which is the order captaincy
+// passes down in.
+func preloadMembers(db *gorm.DB) *gorm.DB {
+	return db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("joined_at ASC, id ASC")
+	})
+}
+
+func (r *GormCampaignTeamRepository) Create(team *campaignModel.CampaignTeam) error {
+	dbTeam := gormmodel.FromDomainCampaignTeam(team)
+	if err := r.db.Create(dbTeam).Error; err != nil {
+		return err
+	}
+	*team = *gormmodel.ToDomainCampaignTeam(dbTeam)
+	return nil
+}
+
+func (r *GormCampaignTeamRepository) GetByID(id string) (*campaignModel.CampaignTeam, error) {
+	var t gormmodel.CampaignTeam
+	if err := preloadMembers(r.db).First(&t, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainCampaignTeam(&t), nil
+}
+
+func (r *GormCampaignTeamRepository) GetByInviteCode(campaignID, code string) (*campaignModel.CampaignTeam, error) {
+	var t gormmodel.CampaignTeam
+	err := preloadMembers(r.db).Where("campaign_id = ? AND invite_code = ?", campaignID, code).First(&t).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, campaignModel.ErrInvalidInviteCode
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainCampaignTeam(&t), nil
+}
+
+func (r *GormCampaignTeamRepository) ListByCampaign(campaignID string) ([]*campaignModel.CampaignTeam, error) {
+	var teams []gormmodel.CampaignTeam
+	if err := preloadMembers(r.db).Where("campaign_id = ?", campaignID).Order("name ASC").Find(&teams).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.CampaignTeam
+	for _, t := range teams {
+		result = append(result, gormmodel.ToDomainCampaignTeam(&t))
+	}
+	return result, nil
+}
+
+func (r *GormCampaignTeamRepository) Update(team *campaignModel.CampaignTeam) error {
+	dbTeam := gormmodel.FromDomainCampaignTeam(team)
+	if err := r.db.Omit("Members").Save(dbTeam).Error; err != nil {
+		return err
+	}
+	members := team.Members
+	*team = *gormmodel.ToDomainCampaignTeam(dbTeam)
+	team.Members = members
+	return nil
+}
+
+func (r *GormCampaignTeamRepository) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("team_id = ?", id).Delete(&gormmodel.CampaignTeamMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&gormmodel.CampaignTeam{}, "id = ?", id).Error
+	})
+}
+
+func (r *GormCampaignTeamRepository) AddMember(member *campaignModel.CampaignTeamMember) error {
+	dbMember := gormmodel.FromDomainCampaignTeamMember(member)
+	if err := r.db.Create(dbMember).Error; err != nil {
+		return err
+	}
+	member.JoinedAt = dbMember.JoinedAt
+	return nil
+}
+
+func (r *GormCampaignTeamRepository) RemoveMember(teamID, userID string) error {
+	return r.db.Where("team_id = ? AND user_id = ?", teamID, userID).
+		Delete(&gormmodel.CampaignTeamMember{}).Error
+}
+
+func (r *GormCampaignTeamRepository) TeamOf(campaignID, userID string) (*campaignModel.CampaignTeam, error) {
+	var member gormmodel.CampaignTeamMember
+	err := r.db.Where("campaign_id = ? AND user_id = ?", campaignID, userID).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(member.TeamID)
+}
+
+func (r *GormCampaignTeamRepository) Standings(campaignID string) ([]*campaignModel.TeamStanding, error) {
+	var rows []struct {
+		TeamID          string
+		Name            string
+		CaptainID       string
+		MemberCount     int
+		DistanceCovered float64
+		MoneyRaised     float64
+		DurationSeconds int64
+	}
+	// Members are counted separately so users with several runner rows count once.
+	err := r.db.Table("campaign_teams AS t").
+		Select(`t.id AS team_id, t.name, t.captain_id,
+			COUNT(DISTINCT m.user_id) AS member_count,
+			COALESCE(SUM(cr.distance_covered), 0) AS distance_covered,
+			COALESCE(SUM(cr.money_raised), 0) AS money_raised,
+			COALESCE(SUM(cr.duration_seconds), 0) AS duration_seconds`).
+		Joins("LEFT JOIN campaign_team_members AS m ON m.team_id = t.id").
+		Joins("LEFT JOIN campaign_campaignrunner AS cr ON cr.owner_id = m.user_id AND cr.campaign_id = t.campaign_id").
+		Where("t.campaign_id = ?", campaignID).
+		Group("t.id, t.name, t.captain_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.TeamStanding
+	for _, row := range rows {
+		result = append(result, &campaignModel.TeamStanding{
+			TeamID:          row.TeamID,
+			Name:            row.Name,
+			CaptainID:       row.CaptainID,
+			MemberCount:     row.MemberCount,
+			DistanceCovered: row.DistanceCovered,
+			MoneyRaised:     row.MoneyRaised,
+			Duration:        model.FromSeconds(row.DurationSeconds),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,95 @@
+package repo
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+)
+
+type GormCampaignTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCampaignTemplateRepository(db *gorm.DB) campaignRepo.CampaignTemplateRepository {
+	return &GormCampaignTemplateRepository{db: db}
+}
+
+func (r *GormCampaignTemplateRepository) Create(template *campaignModel.CampaignTemplate) error {
+	dbTemplate := gormmodel.FromDomainCampaignTemplate(template)
+	if err := r.db.Create(dbTemplate).Error; err != nil {
+		return err
+	}
+	*template = *gormmodel.ToDomainCampaignTemplate(dbTemplate)
+	return nil
+}
+
+func (r *GormCampaignTemplateRepository) GetByID(id string) (*campaignModel.CampaignTemplate, error) {
+	var ct gormmodel.CampaignTemplate
+	if err := r.db.Where("id = ?", id).First(&ct).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, campaignModel.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+	return gormmodel.ToDomainCampaignTemplate(&ct), nil
+}
+
+func (r *GormCampaignTemplateRepository) ListByOwner(ownerID string) ([]*campaignModel.CampaignTemplate, error) {
+	var templates []gormmodel.CampaignTemplate
+	if err := r.db.Where("owner_id = ?", ownerID).Order("created_at DESC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.CampaignTemplate
+	for _, ct := range templates {
+		result = append(result, gormmodel.ToDomainCampaignTemplate(&ct))
+	}
+	return result, nil
+}
+
+func (r *GormCampaignTemplateRepository) Update(template *campaignModel.CampaignTemplate) error {
+	template.UpdatedAt = time.Now()
+	dbTemplate := gormmodel.FromDomainCampaignTemplate(template)
+	return r.db.Save(dbTemplate).Error
+}
+
+func (r *GormCampaignTemplateRepository) Delete(id string) error {
+	return r.db.Delete(&gormmodel.CampaignTemplate{}, "id = ?", id).Error
+}
+
+func (r *GormCampaignTemplateRepository) ListRecurring() ([]*campaignModel.CampaignTemplate, error) {
+	var templates []gormmodel.CampaignTemplate
+	if err := r.db.Where("recurrence <> '' AND next_starts_at IS NOT NULL").
+		Order("next_starts_at ASC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*campaignModel.CampaignTemplate
+	for _, ct := range templates {
+		result = append(result, gormmodel.ToDomainCampaignTemplate(&ct))
+	}
+	return result, nil
+}
+
+func (r *GormCampaignTemplateRepository) AdvanceSchedule(id string, from, next time.Time) (bool, error) {
+	res := r.db.Model(&gormmodel.CampaignTemplate{}).
+		Where("id = ? AND next_starts_at = ?", id, from).
+		Updates(map[string]interface{}{
+			"next_starts_at": next,
+			"date_updated":   time.Now(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *GormCampaignTemplateRepository) CountEdition(id string) error {
+	return r.db.Model(&gormmodel.CampaignTemplate{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"editions":     gorm.Expr("editions + 1"),
+			"date_updated": time.Now(),
+		}).Error
+}
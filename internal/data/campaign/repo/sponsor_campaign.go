@@ -2,6 +2,7 @@ package repo
 
 import (
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -36,6 +37,9 @@ func (r *GormSponsorCampaignRepository) GetByID(id string) (*campaignModel.Spons
 		return nil, err
 	}
 	dsc := gormmodel.ToDomainSponsorCampaign(&sc)
+	if err := gormmodel.FillUserNames(r.db, dsc.Sponsors); err != nil {
+		return nil, err
+	}
 	return dsc, nil
 }
 
@@ -46,8 +50,14 @@ func (r *GormSponsorCampaignRepository) GetByCampaignID(campaignID string) ([]*c
 	}
 
 	var result []*campaignModel.SponsorCampaign
+	var names [][]campaignModel.UserSummary
 	for _, sc := range sponsors {
-		result = append(result, gormmodel.ToDomainSponsorCampaign(&sc))
+		dsc := gormmodel.ToDomainSponsorCampaign(&sc)
+		result = append(result, dsc)
+		names = append(names, dsc.Sponsors)
+	}
+	if err := gormmodel.FillUserNames(r.db, names...); err != nil {
+		return nil, err
 	}
 	return result, nil
 }
@@ -64,3 +74,14 @@ func (r *GormSponsorCampaignRepository) Update(sponsor *campaignModel.SponsorCam
 func (r *GormSponsorCampaignRepository) Delete(id string) error {
 	return r.db.Delete(&gormmodel.SponsorCampaign{}, "id = ?", id).Error
 }
+
+func (r *GormSponsorCampaignRepository) Confirm(id string, sponsors []campaignModel.UserSummary) (bool, error) {
+	res := r.db.Model(&gormmodel.SponsorCampaign{}).
+		Where("id = ? AND status = ?", id, campaignModel.SponsorshipPending).
+		Updates(map[string]interface{}{
+			"status":     campaignModel.SponsorshipConfirmed,
+			"sponsors":   gormmodel.MarshalUsers(sponsors),
+			"updated_at": time.Now(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,31 @@
+package repo
+
+import (
+	"gorm.io/gorm"
+
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+)
+
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewGormUnitOfWork(db *gorm.DB) campaignRepo.UnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+// Do runs fn inside a database transaction with repositories bound to it.
+func (u *GormUnitOfWork) Do(fn func(repos campaignRepo.Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(campaignRepo.Repositories{
+			Campaigns: NewGormCampaignRepository(tx),
+			Runners:   NewGormCampaignRunnerRepository(tx),
+			Sponsors:  NewGormSponsorCampaignRepository(tx),
+			Runs:      NewGormCampaignRunRepository(tx),
+			Teams:     NewGormCampaignTeamRepository(tx),
+			Invites:   NewGormCampaignInviteRepository(tx),
+			Requests:  NewGormCampaignJoinRequestRepository(tx),
+			Results:   NewGormCampaignResultRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package gorm
+
+import (
+	"time"
+
+	certificateModel "gopi.com/internal/domain/certificate/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// Certificate is a completion certificate. A run gets at most one, so issuing again returns
+// the stored one.
+type Certificate struct {
+	ID              string  `gorm:"type:varchar(255);primary_key"`
+	Kind            string  `gorm:"uniqueIndex:idx_certificate_runner,priority:1;size:20;not null"`
+	RunnerID        string  `gorm:"uniqueIndex:idx_certificate_runner,priority:2;size:255;not null"`
+	SubjectID       string  `gorm:"index;not null"`
+	SubjectName     string  `gorm:"not null"`
+	UserID          string  `gorm:"index;not null"`
+	RunnerName      string  `gorm:"not null"`
+	Distance        float64 `gorm:"not null"`
+	DurationSeconds int64   `gorm:"column:duration_seconds;default:0"`
+	FileKey         string
+	FileURL         string
+	CreatedAt       time.Time `gorm:"index"`
+	UpdatedAt       time.Time
+}
+
+func (Certificate) TableName() string {
+	return "certificates"
+}
+
+func (c *Certificate) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == "" {
+		c.ID = id.New()
+	}
+	return
+}
+
+// Converters
+
+func FromDomainCertificate(c *certificateModel.Certificate) *Certificate {
+	return &Certificate{
+		ID:              c.ID,
+		Kind:            string(c.Kind),
+		RunnerID:        c.RunnerID,
+		SubjectID:       c.SubjectID,
+		SubjectName:     c.SubjectName,
+		UserID:          c.UserID,
+		RunnerName:      c.RunnerName,
+		Distance:        c.Distance,
+		DurationSeconds: model.Seconds(c.Duration),
+		FileKey:         c.FileKey,
+		FileURL:         c.FileURL,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+func ToDomainCertificate(c *Certificate) *certificateModel.Certificate {
+	return &certificateModel.Certificate{
+		Base: model.Base{
+			ID:        c.ID,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		},
+		Kind:        certificateModel.Kind(c.Kind),
+		RunnerID:    c.RunnerID,
+		SubjectID:   c.SubjectID,
+		SubjectName: c.SubjectName,
+		UserID:      c.UserID,
+		RunnerName:  c.RunnerName,
+		Distance:    c.Distance,
+		Duration:    model.FromSeconds(c.DurationSeconds),
+		FileKey:     c.FileKey,
+		FileURL:     c.FileURL,
+	}
+}
@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/certificate/model/gorm"
+	certificateModel "gopi.com/internal/domain/certificate/model"
+	certificateRepo "gopi.com/internal/domain/certificate/repo"
+)
+
+type GormCertificateRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCertificateRepository(db *gorm.DB) certificateRepo.CertificateRepository {
+	return &GormCertificateRepository{db: db}
+}
+
+func (r *GormCertificateRepository) Create(certificate *certificateModel.Certificate) error {
+	dbCertificate := gormmodel.FromDomainCertificate(certificate)
+	if err := r.db.Create(dbCertificate).Error; err != nil {
+		return err
+	}
+	*certificate = *gormmodel.ToDomainCertificate(dbCertificate)
+	return nil
+}
+
+func (r *GormCertificateRepository) GetByID(id string) (*certificateModel.Certificate, error) {
+	var c gormmodel.Certificate
+	err := r.db.First(&c, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, certificateModel.ErrCertificateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainCertificate(&c), nil
+}
+
+func (r *GormCertificateRepository) GetByRunner(kind certificateModel.Kind, runnerID string) (*certificateModel.Certificate, error) {
+	var c gormmodel.Certificate
+	err := r.db.Where("kind = ? AND runner_id = ?", string(kind), runnerID).First(&c).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, certificateModel.ErrCertificateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainCertificate(&c), nil
+}
+
+func (r *GormCertificateRepository) ListByUser(userID string, limit, offset int) ([]*certificateModel.Certificate, error) {
+	var certificates []gormmodel.Certificate
+	if err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).Find(&certificates).Error; err != nil {
+		return nil, err
+	}
+	result := make([]*certificateModel.Certificate, 0, len(certificates))
+	for i := range certificates {
+		result = append(result, gormmodel.ToDomainCertificate(&certificates[i]))
+	}
+	return result, nil
+}
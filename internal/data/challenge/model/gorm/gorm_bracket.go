@@ -0,0 +1,159 @@
+package gorm
+
+import (
+	"time"
+
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// ChallengeBracket is a challenge's knockout draw; the unique index allows one per challenge.
+type ChallengeBracket struct {
+	ID                 string    `gorm:"type:varchar(255);primary_key"`
+	ChallengeID        string    `gorm:"not null;uniqueIndex"`
+	Seeding            string    `gorm:"type:varchar(20);not null"`
+	StartsAt           time.Time `gorm:"not null"`
+	RoundLengthSeconds int64     `gorm:"not null"`
+	Entrants           int       `gorm:"not null"`
+	Size               int       `gorm:"not null"`
+	Rounds             int       `gorm:"not null"`
+	ChampionID         string
+	CompletedAt        *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+
+	Challenge Challenge `gorm:"foreignKey:ChallengeID;constraint:OnDelete:CASCADE"`
+}
+
+func (ChallengeBracket) TableName() string {
+	return "challenge_brackets"
+}
+
+// BracketMatch pairs two entrants in one round of a bracket.
+type BracketMatch struct {
+	ID           string    `gorm:"type:varchar(255);primary_key"`
+	BracketID    string    `gorm:"not null;uniqueIndex:idx_bracket_match_place"`
+	Round        int       `gorm:"not null;uniqueIndex:idx_bracket_match_place"`
+	Slot         int       `gorm:"not null;uniqueIndex:idx_bracket_match_place"`
+	HomeID       string    `gorm:"index"`
+	HomeSeed     int       `gorm:"default:0"`
+	AwayID       string    `gorm:"index"`
+	AwaySeed     int       `gorm:"default:0"`
+	HomeDistance float64   `gorm:"default:0"`
+	AwayDistance float64   `gorm:"default:0"`
+	OpensAt      time.Time `gorm:"not null"`
+	ClosesAt     time.Time `gorm:"not null;index"`
+	Outcome      string    `gorm:"type:varchar(20);not null"`
+	WinnerID     string
+	DecidedAt    *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	Bracket ChallengeBracket `gorm:"foreignKey:BracketID;constraint:OnDelete:CASCADE"`
+}
+
+func (BracketMatch) TableName() string {
+	return "bracket_matches"
+}
+
+func (cb *ChallengeBracket) BeforeCreate(tx *gorm.DB) (err error) {
+	if cb.ID == "" {
+		cb.ID = id.New()
+	}
+	return
+}
+
+func (bm *BracketMatch) BeforeCreate(tx *gorm.DB) (err error) {
+	if bm.ID == "" {
+		bm.ID = id.New()
+	}
+	return
+}
+
+// Convert from domain Bracket to GORM ChallengeBracket
+func FromDomainBracket(b *challengeModel.Bracket) *ChallengeBracket {
+	return &ChallengeBracket{
+		ID:                 b.ID,
+		ChallengeID:        b.ChallengeID,
+		Seeding:            string(b.Seeding),
+		StartsAt:           b.StartsAt,
+		RoundLengthSeconds: model.Seconds(b.RoundLength),
+		Entrants:           b.Entrants,
+		Size:               b.Size,
+		Rounds:             b.Rounds,
+		ChampionID:         b.ChampionID,
+		CompletedAt:        b.CompletedAt,
+		CreatedAt:          b.CreatedAt,
+		UpdatedAt:          b.UpdatedAt,
+	}
+}
+
+// Convert from GORM ChallengeBracket to domain Bracket
+func ToDomainBracket(b *ChallengeBracket) *challengeModel.Bracket {
+	return &challengeModel.Bracket{
+		Base: model.Base{
+			ID:        b.ID,
+			CreatedAt: b.CreatedAt,
+			UpdatedAt: b.UpdatedAt,
+		},
+		ChallengeID: b.ChallengeID,
+		Seeding:     challengeModel.Seeding(b.Seeding),
+		StartsAt:    b.StartsAt,
+		RoundLength: model.FromSeconds(b.RoundLengthSeconds),
+		Entrants:    b.Entrants,
+		Size:        b.Size,
+		Rounds:      b.Rounds,
+		ChampionID:  b.ChampionID,
+		CompletedAt: b.CompletedAt,
+	}
+}
+
+// Convert from domain BracketMatch to GORM BracketMatch
+func FromDomainBracketMatch(m *challengeModel.BracketMatch) *BracketMatch {
+	return &BracketMatch{
+		ID:           m.ID,
+		BracketID:    m.BracketID,
+		Round:        m.Round,
+		Slot:         m.Slot,
+		HomeID:       m.HomeID,
+		HomeSeed:     m.HomeSeed,
+		AwayID:       m.AwayID,
+		AwaySeed:     m.AwaySeed,
+		HomeDistance: m.HomeDistance,
+		AwayDistance: m.AwayDistance,
+		OpensAt:      m.OpensAt,
+		ClosesAt:     m.ClosesAt,
+		Outcome:      string(m.Outcome),
+		WinnerID:     m.WinnerID,
+		DecidedAt:    m.DecidedAt,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// Convert from GORM BracketMatch to domain BracketMatch
+func ToDomainBracketMatch(m *BracketMatch) *challengeModel.BracketMatch {
+	return &challengeModel.BracketMatch{
+		Base: model.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+		BracketID:    m.BracketID,
+		Round:        m.Round,
+		Slot:         m.Slot,
+		HomeID:       m.HomeID,
+		HomeSeed:     m.HomeSeed,
+		AwayID:       m.AwayID,
+		AwaySeed:     m.AwaySeed,
+		HomeDistance: m.HomeDistance,
+		AwayDistance: m.AwayDistance,
+		OpensAt:      m.OpensAt,
+		ClosesAt:     m.ClosesAt,
+		Outcome:      challengeModel.MatchOutcome(m.Outcome),
+		WinnerID:     m.WinnerID,
+		DecidedAt:    m.DecidedAt,
+	}
+}
@@ -1,9 +1,11 @@
 package gorm
 
 import (
+	"encoding/json"
 	"time"
 
 	userGorm "gopi.com/internal/data/user/model/gorm"
+	activityModel "gopi.com/internal/domain/activity/model"
 	challengeModel "gopi.com/internal/domain/challenge/model"
 	"gopi.com/internal/domain/model"
 	"gopi.com/internal/lib/id"
@@ -19,14 +21,20 @@ type Challenge struct {
 	Condition         string `gorm:"type:text"`
 	Goal              string
 	Location          string `gorm:"index"`
+	Latitude          *float64 `gorm:"index:idx_challenge_coordinates"`
+	Longitude         *float64 `gorm:"index:idx_challenge_coordinates"`
 	DistanceToCover   float64 `gorm:"default:0"`
 	TargetAmount      float64 `gorm:"default:0"`
 	TargetAmountPerKm float64 `gorm:"default:0"`
 	StartDuration     string
 	EndDuration       string
+	EndsAt            *time.Time `gorm:"index"`
 	NoOfWinner        int    `gorm:"default:3"`
 	WinningPrice      string `gorm:"type:json"` // JSON stored as text
 	CausePrice        string `gorm:"type:json"` // JSON stored as text
+	RankingCriterion  string `gorm:"type:varchar(20)"`
+	ClosedAt          *time.Time
+	CancelledAt       *time.Time
 	CoverImage        string
 	VideoUrl          string
 	Slug              string `gorm:"unique;index"`
@@ -34,7 +42,8 @@ type Challenge struct {
 	UpdatedAt         time.Time
 	
 	// Database relationships
-	Owner userGorm.UserGORM `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE"`
+	Owner   userGorm.UserGORM `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE"`
+	Members []ChallengeMember `gorm:"foreignKey:ChallengeID;constraint:OnDelete:CASCADE"`
 }
 
 func (Challenge) TableName() string {
@@ -82,17 +91,21 @@ type Cause struct {
 	ProductDescription string `gorm:"type:text"`
 	Activity           string `gorm:"type:varchar(50);index"`
 	Location           string
+	Latitude           *float64 `gorm:"index:idx_cause_coordinates"`
+	Longitude          *float64 `gorm:"index:idx_cause_coordinates"`
 	Description        string
 	IsCommercial       bool `gorm:"default:false"`
 	WhoIdeaImpact      string
 	BuyerUser          string
 	DistanceCovered    float64 `gorm:"default:0;index"`
 	AmountPerPiece     float64 `gorm:"default:0"`
-	Duration           string
+	DurationSeconds    int64   `gorm:"column:duration_seconds;default:0"`
 	FundCause          bool    `gorm:"default:false"`
 	FundAmount         float64 `gorm:"default:0"`
 	WillingAmount      float64 `gorm:"default:0"`
 	UnitPrice          float64 `gorm:"default:0"`
+	Stock              *int    // units for sale, NULL when unlimited
+	UnitsSold          int     `gorm:"default:0"`
 	CostToLaunch       string
 	BenefitDesc        string
 	OwnerID            string `gorm:"not null;index"`
@@ -105,6 +118,7 @@ type Cause struct {
 	// Database relationships
 	Challenge Challenge         `gorm:"foreignKey:ChallengeID;constraint:OnDelete:CASCADE"`
 	Owner     userGorm.UserGORM `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE"`
+	Members   []CauseMember     `gorm:"foreignKey:CauseID;constraint:OnDelete:CASCADE"`
 }
 
 func (Cause) TableName() string {
@@ -148,12 +162,18 @@ type CauseRunner struct {
 	CauseID         string `gorm:"not null;index"`
 	DistanceToCover float64 `gorm:"default:0"`
 	DistanceCovered float64 `gorm:"default:0;index"`
-	Duration        string
+	DurationSeconds int64   `gorm:"column:duration_seconds;default:0;index"`
 	MoneyRaised     float64 `gorm:"default:0;index"`
 	CoverImage      string
 	Activity        string `gorm:"type:varchar(50);index"`
 	OwnerID         string `gorm:"not null;index"`
 	DateJoined      time.Time `gorm:"index;column:date_joined;autoCreateTime"`
+	TrackURL        string
+	ElevationGain   float64   `gorm:"default:0"`
+	Status          string    `gorm:"type:varchar(20);index"`
+	Violations      string    `gorm:"type:text"` // JSON array of anti-cheat violations
+	ReviewedBy      string
+	ReviewedAt      *time.Time
 	CreatedAt       time.Time `gorm:"index;column:date_joined"`
 	UpdatedAt       time.Time `gorm:"column:date_updated"`
 	
@@ -241,6 +261,20 @@ func (c *Cause) BeforeCreate(tx *gorm.DB) (err error) {
 	return
 }
 
+func (cm *ChallengeMember) BeforeCreate(tx *gorm.DB) (err error) {
+	if cm.ID == "" {
+		cm.ID = id.New()
+	}
+	return
+}
+
+func (cm *CauseMember) BeforeCreate(tx *gorm.DB) (err error) {
+	if cm.ID == "" {
+		cm.ID = id.New()
+	}
+	return
+}
+
 func (cr *CauseRunner) BeforeCreate(tx *gorm.DB) (err error) {
 	if cr.ID == "" {
 		cr.ID = id.New()
@@ -288,8 +322,8 @@ func (sc *SponsorCause) BeforeSave(tx *gorm.DB) (err error) {
 // Conversion functions
 func FromDomainChallenge(c *challengeModel.Challenge) *Challenge {
 	// Convert JSON fields to strings
-	winningPrice := ""
-	causePrice := ""
+	winningPrice := marshalPrizes(c.WinningPrice)
+	causePrice := marshalPrizes(c.CausePrice)
 
 	return &Challenge{
 		ID:                c.ID,
@@ -300,14 +334,20 @@ func FromDomainChallenge(c *challengeModel.Challenge) *Challenge {
 		Condition:         c.Condition,
 		Goal:              c.Goal,
 		Location:          c.Location,
+		Latitude:          model.Latitude(c.Coordinates),
+		Longitude:         model.Longitude(c.Coordinates),
 		DistanceToCover:   c.DistanceToCover,
 		TargetAmount:      c.TargetAmount,
 		TargetAmountPerKm: c.TargetAmountPerKm,
 		StartDuration:     c.StartDuration,
 		EndDuration:       c.EndDuration,
+		EndsAt:            c.EndsAt,
 		NoOfWinner:        c.NoOfWinner,
 		WinningPrice:      winningPrice,
 		CausePrice:        causePrice,
+		RankingCriterion:  string(c.RankingCriterion),
+		ClosedAt:          c.ClosedAt,
+		CancelledAt:       c.CancelledAt,
 		CoverImage:        c.CoverImage,
 		VideoUrl:          c.VideoUrl,
 		Slug:              c.Slug,
@@ -317,9 +357,14 @@ func FromDomainChallenge(c *challengeModel.Challenge) *Challenge {
 }
 
 func ToDomainChallenge(c *Challenge) *challengeModel.Challenge {
-	// In real implementation, you'd properly unmarshal JSON fields
-	var winningPrice []interface{}
-	var causePrice []interface{}
+	winningPrice := unmarshalPrizes(c.WinningPrice)
+	causePrice := unmarshalPrizes(c.CausePrice)
+
+	// Members holds the user IDs loaded from the junction table
+	members := []interface{}{}
+	for _, member := range c.Members {
+		members = append(members, member.UserID)
+	}
 
 	return &challengeModel.Challenge{
 		Base: model.Base{
@@ -334,16 +379,21 @@ func ToDomainChallenge(c *Challenge) *challengeModel.Challenge {
 		Condition:         c.Condition,
 		Goal:              c.Goal,
 		Location:          c.Location,
+		Coordinates:       model.FromLatLon(c.Latitude, c.Longitude),
 		DistanceToCover:   c.DistanceToCover,
 		TargetAmount:      c.TargetAmount,
 		TargetAmountPerKm: c.TargetAmountPerKm,
 		StartDuration:     c.StartDuration,
 		EndDuration:       c.EndDuration,
+		EndsAt:            c.EndsAt,
 		NoOfWinner:        c.NoOfWinner,
 		WinningPrice:      winningPrice,
 		CausePrice:        causePrice,
+		RankingCriterion:  challengeModel.RankingCriterion(c.RankingCriterion),
+		ClosedAt:          c.ClosedAt,
+		CancelledAt:       c.CancelledAt,
 		CoverImage:        c.CoverImage,
-		Members:           []interface{}{}, // Will be populated by repository when needed
+		Members:           members,
 		Sponsors:          []interface{}{}, // Will be populated by repository when needed
 		VideoUrl:          c.VideoUrl,
 		Slug:              c.Slug,
@@ -360,17 +410,21 @@ func FromDomainCause(c *challengeModel.Cause) *Cause {
 		ProductDescription: c.ProductDescription,
 		Activity:           string(c.Activity),
 		Location:           c.Location,
+		Latitude:           model.Latitude(c.Coordinates),
+		Longitude:          model.Longitude(c.Coordinates),
 		Description:        c.Description,
 		IsCommercial:       c.IsCommercial,
 		WhoIdeaImpact:      c.WhoIdeaImpact,
 		BuyerUser:          c.BuyerUser,
 		DistanceCovered:    c.DistanceCovered,
 		AmountPerPiece:     c.AmountPerPiece,
-		Duration:           c.Duration,
+		DurationSeconds:    model.Seconds(c.Duration),
 		FundCause:          c.FundCause,
 		FundAmount:         c.FundAmount,
 		WillingAmount:      c.WillingAmount,
 		UnitPrice:          c.UnitPrice,
+		Stock:              c.Stock,
+		UnitsSold:          c.UnitsSold,
 		CostToLaunch:       c.CostToLaunch,
 		BenefitDesc:        c.BenefitDesc,
 		OwnerID:            c.OwnerID,
@@ -383,6 +437,12 @@ func FromDomainCause(c *challengeModel.Cause) *Cause {
 }
 
 func ToDomainCause(c *Cause) *challengeModel.Cause {
+	// Members holds the user IDs loaded from the junction table
+	members := []interface{}{}
+	for _, member := range c.Members {
+		members = append(members, member.UserID)
+	}
+
 	return &challengeModel.Cause{
 		Base: model.Base{
 			ID:        c.ID,
@@ -396,21 +456,24 @@ func ToDomainCause(c *Cause) *challengeModel.Cause {
 		ProductDescription: c.ProductDescription,
 		Activity:           challengeModel.Activity(c.Activity),
 		Location:           c.Location,
+		Coordinates:        model.FromLatLon(c.Latitude, c.Longitude),
 		Description:        c.Description,
 		IsCommercial:       c.IsCommercial,
 		WhoIdeaImpact:      c.WhoIdeaImpact,
 		BuyerUser:          c.BuyerUser,
 		DistanceCovered:    c.DistanceCovered,
 		AmountPerPiece:     c.AmountPerPiece,
-		Duration:           c.Duration,
+		Duration:           model.FromSeconds(c.DurationSeconds),
 		FundCause:          c.FundCause,
 		FundAmount:         c.FundAmount,
 		WillingAmount:      c.WillingAmount,
 		UnitPrice:          c.UnitPrice,
+		Stock:              c.Stock,
+		UnitsSold:          c.UnitsSold,
 		CostToLaunch:       c.CostToLaunch,
 		BenefitDesc:        c.BenefitDesc,
 		OwnerID:            c.OwnerID,
-		Members:            []interface{}{}, // Will be populated by repository when needed
+		Members:            members,
 		Sponsors:           []interface{}{}, // Will be populated by repository when needed
 		WorkoutImg:         c.WorkoutImg,
 		VideoUrl:           c.VideoUrl,
@@ -420,23 +483,41 @@ func ToDomainCause(c *Cause) *challengeModel.Cause {
 
 // Additional conversion functions for other models
 func FromDomainCauseRunner(cr *challengeModel.CauseRunner) *CauseRunner {
+	violations := ""
+	if len(cr.Violations) > 0 {
+		if data, err := json.Marshal(cr.Violations); err == nil {
+			violations = string(data)
+		}
+	}
+
 	return &CauseRunner{
 		ID:              cr.ID,
 		CauseID:         cr.CauseID,
 		DistanceToCover: cr.DistanceToCover,
 		DistanceCovered: cr.DistanceCovered,
-		Duration:        cr.Duration,
+		DurationSeconds: model.Seconds(cr.Duration),
 		MoneyRaised:     cr.MoneyRaised,
 		CoverImage:      cr.CoverImage,
 		Activity:        cr.Activity,
 		OwnerID:         cr.OwnerID,
 		DateJoined:      cr.DateJoined,
+		TrackURL:        cr.TrackURL,
+		ElevationGain:   cr.ElevationGain,
+		Status:          string(cr.Status),
+		Violations:      violations,
+		ReviewedBy:      cr.ReviewedBy,
+		ReviewedAt:      cr.ReviewedAt,
 		CreatedAt:       cr.CreatedAt,
 		UpdatedAt:       cr.UpdatedAt,
 	}
 }
 
 func ToDomainCauseRunner(cr *CauseRunner) *challengeModel.CauseRunner {
+	var violations []activityModel.Violation
+	if cr.Violations != "" {
+		json.Unmarshal([]byte(cr.Violations), &violations)
+	}
+
 	return &challengeModel.CauseRunner{
 		Base: model.Base{
 			ID:        cr.ID,
@@ -446,12 +527,18 @@ func ToDomainCauseRunner(cr *CauseRunner) *challengeModel.CauseRunner {
 		CauseID:         cr.CauseID,
 		DistanceToCover: cr.DistanceToCover,
 		DistanceCovered: cr.DistanceCovered,
-		Duration:        cr.Duration,
+		Duration:        model.FromSeconds(cr.DurationSeconds),
 		MoneyRaised:     cr.MoneyRaised,
 		CoverImage:      cr.CoverImage,
 		Activity:        cr.Activity,
 		OwnerID:         cr.OwnerID,
 		DateJoined:      cr.DateJoined,
+		TrackURL:        cr.TrackURL,
+		ElevationGain:   cr.ElevationGain,
+		Status:          activityModel.ReviewStatus(cr.Status),
+		Violations:      violations,
+		ReviewedBy:      cr.ReviewedBy,
+		ReviewedAt:      cr.ReviewedAt,
 	}
 }
 
@@ -544,3 +631,46 @@ func ToDomainCauseBuyer(cb *CauseBuyer) *challengeModel.CauseBuyer {
 		DateBought: cb.DateBought,
 	}
 }
+
+// marshalPrizes stores a prize list as text, "[]" when it is empty.
+func marshalPrizes(prizes []challengeModel.Prize) string {
+	if len(prizes) == 0 {
+		return "[]"
+	}
+	data, err := json.Marshal(prizes)
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
+
+func unmarshalPrizes(data string) []challengeModel.Prize {
+	prizes := []challengeModel.Prize{}
+	if data != "" {
+		json.Unmarshal([]byte(data), &prizes)
+	}
+	return prizes
+}
+
+// marshalPrize stores an awarded prize as text, "" when there is none.
+func marshalPrize(prize *challengeModel.Prize) string {
+	if prize == nil {
+		return ""
+	}
+	data, err := json.Marshal(prize)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func unmarshalPrize(data string) *challengeModel.Prize {
+	if data == "" {
+		return nil
+	}
+	var prize challengeModel.Prize
+	if err := json.Unmarshal([]byte(data), &prize); err != nil {
+		return nil
+	}
+	return &prize
+}
@@ -0,0 +1,71 @@
+package gorm
+
+import (
+	"time"
+
+	userGorm "gopi.com/internal/data/user/model/gorm"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// SponsorStatement is what a sponsor owes a cause once it is settled; one per sponsor and cause.
+type SponsorStatement struct {
+	ID              string    `gorm:"type:varchar(255);primary_key"`
+	CauseID         string    `gorm:"not null;uniqueIndex:idx_sponsor_statement_cause"`
+	SponsorID       string    `gorm:"not null;index;uniqueIndex:idx_sponsor_statement_cause"`
+	Sponsorships    int       `gorm:"default:0"`
+	DistanceCovered float64   `gorm:"default:0"`
+	Pledged         float64   `gorm:"type:decimal(19,2);default:0"`
+	AmountDue       float64   `gorm:"type:decimal(19,2);default:0"`
+	SettledAt       time.Time `gorm:"not null;index"`
+	CreatedAt       time.Time
+
+	Cause   Cause             `gorm:"foreignKey:CauseID;constraint:OnDelete:CASCADE"`
+	Sponsor userGorm.UserGORM `gorm:"foreignKey:SponsorID;constraint:OnDelete:CASCADE"`
+}
+
+func (SponsorStatement) TableName() string {
+	return "challenge_sponsorstatement"
+}
+
+func (ss *SponsorStatement) BeforeCreate(tx *gorm.DB) (err error) {
+	if ss.ID == "" {
+		ss.ID = id.New()
+	}
+	return
+}
+
+// Convert from domain SponsorStatement to GORM SponsorStatement
+func FromDomainSponsorStatement(s *challengeModel.SponsorStatement) *SponsorStatement {
+	return &SponsorStatement{
+		ID:              s.ID,
+		CauseID:         s.CauseID,
+		SponsorID:       s.SponsorID,
+		Sponsorships:    s.Sponsorships,
+		DistanceCovered: s.DistanceCovered,
+		Pledged:         s.Pledged,
+		AmountDue:       s.AmountDue,
+		SettledAt:       s.SettledAt,
+		CreatedAt:       s.CreatedAt,
+	}
+}
+
+// Convert from GORM SponsorStatement to domain SponsorStatement
+func ToDomainSponsorStatement(s *SponsorStatement) *challengeModel.SponsorStatement {
+	return &challengeModel.SponsorStatement{
+		Base: model.Base{
+			ID:        s.ID,
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.CreatedAt,
+		},
+		CauseID:         s.CauseID,
+		SponsorID:       s.SponsorID,
+		Sponsorships:    s.Sponsorships,
+		DistanceCovered: s.DistanceCovered,
+		Pledged:         s.Pledged,
+		AmountDue:       s.AmountDue,
+		SettledAt:       s.SettledAt,
+	}
+}
@@ -0,0 +1,312 @@
+package gorm
+
+import (
+	"encoding/json"
+	"time"
+
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// JudgingRound is a window in which judges score and members vote on a challenge's causes.
+type JudgingRound struct {
+	ID          string    `gorm:"type:varchar(255);primary_key"`
+	ChallengeID string    `gorm:"not null;index"`
+	Name        string    `gorm:"not null"`
+	OpensAt     time.Time `gorm:"not null;index"`
+	ClosesAt    time.Time `gorm:"not null;index"`
+	Rubric      string    `gorm:"type:text"` // JSON array of criteria
+	VoteShare   float64   `gorm:"default:0"`
+	Final       bool      `gorm:"default:false"`
+	FinalFor    *string   `gorm:"type:varchar(255);uniqueIndex"` // ChallengeID on the final round, NULL otherwise, so a challenge has one final
+	ClosedAt    *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Challenge Challenge `gorm:"foreignKey:ChallengeID;constraint:OnDelete:CASCADE"`
+}
+
+func (JudgingRound) TableName() string {
+	return "judging_rounds"
+}
+
+// JudgingJudge appoints a user to score a round.
+type JudgingJudge struct {
+	ID          string  `gorm:"type:varchar(255);primary_key"`
+	RoundID     string  `gorm:"not null;uniqueIndex:idx_judging_judge_user"`
+	UserID      string  `gorm:"not null;index;uniqueIndex:idx_judging_judge_user"`
+	Weight      float64 `gorm:"default:1"`
+	AppointedBy string
+	CreatedAt   time.Time
+
+	Round JudgingRound `gorm:"foreignKey:RoundID;constraint:OnDelete:CASCADE"`
+}
+
+func (JudgingJudge) TableName() string {
+	return "judging_judges"
+}
+
+// JudgingScore is a judge's rating of a cause, one per judge and cause in a round.
+type JudgingScore struct {
+	ID        string  `gorm:"type:varchar(255);primary_key"`
+	RoundID   string  `gorm:"not null;uniqueIndex:idx_judging_score_cause"`
+	JudgeID   string  `gorm:"not null;uniqueIndex:idx_judging_score_cause"`
+	CauseID   string  `gorm:"not null;index;uniqueIndex:idx_judging_score_cause"`
+	Scores    string  `gorm:"type:text"` // JSON object of rating per criterion
+	Total     float64 `gorm:"default:0"`
+	Comment   string  `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Round JudgingRound `gorm:"foreignKey:RoundID;constraint:OnDelete:CASCADE"`
+	Cause Cause        `gorm:"foreignKey:CauseID;constraint:OnDelete:CASCADE"`
+}
+
+func (JudgingScore) TableName() string {
+	return "judging_scores"
+}
+
+// JudgingVote is a member's vote in a round; the unique index allows one per member.
+type JudgingVote struct {
+	ID        string `gorm:"type:varchar(255);primary_key"`
+	RoundID   string `gorm:"not null;uniqueIndex:idx_judging_vote_user"`
+	UserID    string `gorm:"not null;uniqueIndex:idx_judging_vote_user"`
+	CauseID   string `gorm:"not null;index"`
+	CreatedAt time.Time
+
+	Round JudgingRound `gorm:"foreignKey:RoundID;constraint:OnDelete:CASCADE"`
+	Cause Cause        `gorm:"foreignKey:CauseID;constraint:OnDelete:CASCADE"`
+}
+
+func (JudgingVote) TableName() string {
+	return "judging_votes"
+}
+
+// JudgingResult is a cause's placing in a closed round.
+type JudgingResult struct {
+	ID         string  `gorm:"type:varchar(255);primary_key"`
+	RoundID    string  `gorm:"not null;index"`
+	CauseID    string  `gorm:"not null;index"`
+	Rank       int     `gorm:"column:position;not null"`
+	JudgeScore float64 `gorm:"default:0"`
+	Votes      int     `gorm:"default:0"`
+	Score      float64 `gorm:"default:0"`
+	Prize      string  `gorm:"type:text"` // JSON of the CausePrice prize
+	CreatedAt  time.Time
+
+	Round JudgingRound `gorm:"foreignKey:RoundID;constraint:OnDelete:CASCADE"`
+}
+
+func (JudgingResult) TableName() string {
+	return "judging_results"
+}
+
+func (jr *JudgingRound) BeforeCreate(tx *gorm.DB) (err error) {
+	if jr.ID == "" {
+		jr.ID = id.New()
+	}
+	return
+}
+
+func (jj *JudgingJudge) BeforeCreate(tx *gorm.DB) (err error) {
+	if jj.ID == "" {
+		jj.ID = id.New()
+	}
+	return
+}
+
+func (js *JudgingScore) BeforeCreate(tx *gorm.DB) (err error) {
+	if js.ID == "" {
+		js.ID = id.New()
+	}
+	return
+}
+
+func (jv *JudgingVote) BeforeCreate(tx *gorm.DB) (err error) {
+	if jv.ID == "" {
+		jv.ID = id.New()
+	}
+	return
+}
+
+func (jr *JudgingResult) BeforeCreate(tx *gorm.DB) (err error) {
+	if jr.ID == "" {
+		jr.ID = id.New()
+	}
+	return
+}
+
+// Convert from domain JudgingRound to GORM JudgingRound
+func FromDomainJudgingRound(r *challengeModel.JudgingRound) *JudgingRound {
+	rubric, _ := json.Marshal(r.Rubric)
+
+	return &JudgingRound{
+		ID:          r.ID,
+		ChallengeID: r.ChallengeID,
+		Name:        r.Name,
+		OpensAt:     r.OpensAt,
+		ClosesAt:    r.ClosesAt,
+		Rubric:      string(rubric),
+		VoteShare:   r.VoteShare,
+		Final:       r.Final,
+		FinalFor:    finalFor(r),
+		ClosedAt:    r.ClosedAt,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+// finalFor returns the FinalFor key of a round.
+func finalFor(r *challengeModel.JudgingRound) *string {
+	if !r.Final {
+		return nil
+	}
+	challengeID := r.ChallengeID
+	return &challengeID
+}
+
+// Convert from GORM JudgingRound to domain JudgingRound
+func ToDomainJudgingRound(r *JudgingRound) *challengeModel.JudgingRound {
+	rubric := []challengeModel.Criterion{}
+	json.Unmarshal([]byte(r.Rubric), &rubric)
+
+	return &challengeModel.JudgingRound{
+		Base: model.Base{
+			ID:        r.ID,
+			CreatedAt: r.CreatedAt,
+			UpdatedAt: r.UpdatedAt,
+		},
+		ChallengeID: r.ChallengeID,
+		Name:        r.Name,
+		OpensAt:     r.OpensAt,
+		ClosesAt:    r.ClosesAt,
+		Rubric:      rubric,
+		VoteShare:   r.VoteShare,
+		Final:       r.Final,
+		ClosedAt:    r.ClosedAt,
+	}
+}
+
+// Convert from domain Judge to GORM JudgingJudge
+func FromDomainJudge(j *challengeModel.Judge) *JudgingJudge {
+	return &JudgingJudge{
+		ID:          j.ID,
+		RoundID:     j.RoundID,
+		UserID:      j.UserID,
+		Weight:      j.Weight,
+		AppointedBy: j.AppointedBy,
+		CreatedAt:   j.CreatedAt,
+	}
+}
+
+// Convert from GORM JudgingJudge to domain Judge
+func ToDomainJudge(j *JudgingJudge) *challengeModel.Judge {
+	return &challengeModel.Judge{
+		Base: model.Base{
+			ID:        j.ID,
+			CreatedAt: j.CreatedAt,
+			UpdatedAt: j.CreatedAt,
+		},
+		RoundID:     j.RoundID,
+		UserID:      j.UserID,
+		Weight:      j.Weight,
+		AppointedBy: j.AppointedBy,
+	}
+}
+
+// Convert from domain JudgeScore to GORM JudgingScore
+func FromDomainJudgeScore(s *challengeModel.JudgeScore) *JudgingScore {
+	scores, _ := json.Marshal(s.Scores)
+
+	return &JudgingScore{
+		ID:        s.ID,
+		RoundID:   s.RoundID,
+		JudgeID:   s.JudgeID,
+		CauseID:   s.CauseID,
+		Scores:    string(scores),
+		Total:     s.Total,
+		Comment:   s.Comment,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+// Convert from GORM JudgingScore to domain JudgeScore
+func ToDomainJudgeScore(s *JudgingScore) *challengeModel.JudgeScore {
+	scores := map[string]float64{}
+	json.Unmarshal([]byte(s.Scores), &scores)
+
+	return &challengeModel.JudgeScore{
+		Base: model.Base{
+			ID:        s.ID,
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.UpdatedAt,
+		},
+		RoundID: s.RoundID,
+		CauseID: s.CauseID,
+		JudgeID: s.JudgeID,
+		Scores:  scores,
+		Total:   s.Total,
+		Comment: s.Comment,
+	}
+}
+
+// Convert from domain Vote to GORM JudgingVote
+func FromDomainVote(v *challengeModel.Vote) *JudgingVote {
+	return &JudgingVote{
+		ID:        v.ID,
+		RoundID:   v.RoundID,
+		UserID:    v.UserID,
+		CauseID:   v.CauseID,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+// Convert from GORM JudgingVote to domain Vote
+func ToDomainVote(v *JudgingVote) *challengeModel.Vote {
+	return &challengeModel.Vote{
+		Base: model.Base{
+			ID:        v.ID,
+			CreatedAt: v.CreatedAt,
+			UpdatedAt: v.CreatedAt,
+		},
+		RoundID: v.RoundID,
+		CauseID: v.CauseID,
+		UserID:  v.UserID,
+	}
+}
+
+// Convert from domain RoundResult to GORM JudgingResult
+func FromDomainRoundResult(r *challengeModel.RoundResult) *JudgingResult {
+	return &JudgingResult{
+		ID:         r.ID,
+		RoundID:    r.RoundID,
+		CauseID:    r.CauseID,
+		Rank:       r.Rank,
+		JudgeScore: r.JudgeScore,
+		Votes:      r.Votes,
+		Score:      r.Score,
+		Prize:      marshalPrize(r.Prize),
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+// Convert from GORM JudgingResult to domain RoundResult
+func ToDomainRoundResult(r *JudgingResult) *challengeModel.RoundResult {
+	return &challengeModel.RoundResult{
+		Base: model.Base{
+			ID:        r.ID,
+			CreatedAt: r.CreatedAt,
+			UpdatedAt: r.CreatedAt,
+		},
+		RoundID:    r.RoundID,
+		CauseID:    r.CauseID,
+		Rank:       r.Rank,
+		JudgeScore: r.JudgeScore,
+		Votes:      r.Votes,
+		Score:      r.Score,
+		Prize:      unmarshalPrize(r.Prize),
+	}
+}
@@ -0,0 +1,82 @@
+package gorm
+
+import (
+	"time"
+
+	userGorm "gopi.com/internal/data/user/model/gorm"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// CauseOrder is an order of units of a commercial cause.
+type CauseOrder struct {
+	ID          string  `gorm:"type:varchar(255);primary_key"`
+	CauseID     string  `gorm:"not null;index"`
+	BuyerID     string  `gorm:"not null;index"`
+	Quantity    int     `gorm:"not null"`
+	UnitPrice   float64 `gorm:"type:decimal(19,2);default:0"`
+	Amount      float64 `gorm:"type:decimal(19,2);default:0"`
+	Status      string  `gorm:"type:varchar(20);not null;index"`
+	PaymentRef  string
+	PaidAt      *time.Time
+	FulfilledAt *time.Time
+	RefundedAt  *time.Time
+	CreatedAt   time.Time `gorm:"index"`
+	UpdatedAt   time.Time
+
+	Cause Cause             `gorm:"foreignKey:CauseID;constraint:OnDelete:CASCADE"`
+	Buyer userGorm.UserGORM `gorm:"foreignKey:BuyerID;constraint:OnDelete:CASCADE"`
+}
+
+func (CauseOrder) TableName() string {
+	return "challenge_causeorder"
+}
+
+func (co *CauseOrder) BeforeCreate(tx *gorm.DB) (err error) {
+	if co.ID == "" {
+		co.ID = id.New()
+	}
+	return
+}
+
+// Convert from domain CauseOrder to GORM CauseOrder
+func FromDomainCauseOrder(o *challengeModel.CauseOrder) *CauseOrder {
+	return &CauseOrder{
+		ID:          o.ID,
+		CauseID:     o.CauseID,
+		BuyerID:     o.BuyerID,
+		Quantity:    o.Quantity,
+		UnitPrice:   o.UnitPrice,
+		Amount:      o.Amount,
+		Status:      string(o.Status),
+		PaymentRef:  o.PaymentRef,
+		PaidAt:      o.PaidAt,
+		FulfilledAt: o.FulfilledAt,
+		RefundedAt:  o.RefundedAt,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
+	}
+}
+
+// Convert from GORM CauseOrder to domain CauseOrder
+func ToDomainCauseOrder(o *CauseOrder) *challengeModel.CauseOrder {
+	return &challengeModel.CauseOrder{
+		Base: model.Base{
+			ID:        o.ID,
+			CreatedAt: o.CreatedAt,
+			UpdatedAt: o.UpdatedAt,
+		},
+		CauseID:     o.CauseID,
+		BuyerID:     o.BuyerID,
+		Quantity:    o.Quantity,
+		UnitPrice:   o.UnitPrice,
+		Amount:      o.Amount,
+		Status:      challengeModel.OrderStatus(o.Status),
+		PaymentRef:  o.PaymentRef,
+		PaidAt:      o.PaidAt,
+		FulfilledAt: o.FulfilledAt,
+		RefundedAt:  o.RefundedAt,
+	}
+}
@@ -0,0 +1,133 @@
+package gorm
+
+import (
+	"encoding/json"
+	"time"
+
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// ChallengeWinner is a placing awarded at close-out or set by staff.
+type ChallengeWinner struct {
+	ID              string  `gorm:"type:varchar(255);primary_key"`
+	ChallengeID     string  `gorm:"not null;index;uniqueIndex:idx_challenge_winner_user"`
+	Rank            int     `gorm:"column:position;not null"`
+	UserID          string  `gorm:"not null;index;uniqueIndex:idx_challenge_winner_user"`
+	Distance        float64 `gorm:"default:0"`
+	DurationSeconds int64   `gorm:"default:0"`
+	MoneyRaised     float64 `gorm:"default:0"`
+	Prize           string  `gorm:"type:text"` // JSON of the WinningPrice prize
+	Overridden      bool    `gorm:"default:false"`
+	CreatedAt       time.Time
+
+	Challenge Challenge `gorm:"foreignKey:ChallengeID;constraint:OnDelete:CASCADE"`
+}
+
+func (ChallengeWinner) TableName() string {
+	return "challenge_winners"
+}
+
+// ChallengeWinnerAudit records a staff override of a challenge's winners.
+type ChallengeWinnerAudit struct {
+	ID          string    `gorm:"type:varchar(255);primary_key"`
+	ChallengeID string    `gorm:"not null;index"`
+	ActorID     string    `gorm:"not null"`
+	Reason      string    `gorm:"type:text"`
+	Previous    string    `gorm:"type:text"` // JSON array of user IDs by rank
+	Winners     string    `gorm:"type:text"` // JSON array of user IDs by rank
+	CreatedAt   time.Time `gorm:"index"`
+
+	Challenge Challenge `gorm:"foreignKey:ChallengeID;constraint:OnDelete:CASCADE"`
+}
+
+func (ChallengeWinnerAudit) TableName() string {
+	return "challenge_winner_audits"
+}
+
+func (cw *ChallengeWinner) BeforeCreate(tx *gorm.DB) (err error) {
+	if cw.ID == "" {
+		cw.ID = id.New()
+	}
+	return
+}
+
+func (ca *ChallengeWinnerAudit) BeforeCreate(tx *gorm.DB) (err error) {
+	if ca.ID == "" {
+		ca.ID = id.New()
+	}
+	return
+}
+
+// Convert from domain ChallengeWinner to GORM ChallengeWinner
+func FromDomainChallengeWinner(cw *challengeModel.ChallengeWinner) *ChallengeWinner {
+	return &ChallengeWinner{
+		ID:              cw.ID,
+		ChallengeID:     cw.ChallengeID,
+		Rank:            cw.Rank,
+		UserID:          cw.UserID,
+		Distance:        cw.Distance,
+		DurationSeconds: model.Seconds(cw.Duration),
+		MoneyRaised:     cw.MoneyRaised,
+		Prize:           marshalPrize(cw.Prize),
+		Overridden:      cw.Overridden,
+		CreatedAt:       cw.CreatedAt,
+	}
+}
+
+// Convert from GORM ChallengeWinner to domain ChallengeWinner
+func ToDomainChallengeWinner(cw *ChallengeWinner) *challengeModel.ChallengeWinner {
+	return &challengeModel.ChallengeWinner{
+		Base: model.Base{
+			ID:        cw.ID,
+			CreatedAt: cw.CreatedAt,
+			UpdatedAt: cw.CreatedAt,
+		},
+		ChallengeID: cw.ChallengeID,
+		Rank:        cw.Rank,
+		UserID:      cw.UserID,
+		Distance:    cw.Distance,
+		Duration:    model.FromSeconds(cw.DurationSeconds),
+		MoneyRaised: cw.MoneyRaised,
+		Prize:       unmarshalPrize(cw.Prize),
+		Overridden:  cw.Overridden,
+	}
+}
+
+// Convert from domain WinnerAudit to GORM ChallengeWinnerAudit
+func FromDomainWinnerAudit(wa *challengeModel.WinnerAudit) *ChallengeWinnerAudit {
+	previous, _ := json.Marshal(wa.Previous)
+	winners, _ := json.Marshal(wa.Winners)
+
+	return &ChallengeWinnerAudit{
+		ID:          wa.ID,
+		ChallengeID: wa.ChallengeID,
+		ActorID:     wa.ActorID,
+		Reason:      wa.Reason,
+		Previous:    string(previous),
+		Winners:     string(winners),
+		CreatedAt:   wa.CreatedAt,
+	}
+}
+
+// Convert from GORM ChallengeWinnerAudit to domain WinnerAudit
+func ToDomainWinnerAudit(ca *ChallengeWinnerAudit) *challengeModel.WinnerAudit {
+	previous, winners := []string{}, []string{}
+	json.Unmarshal([]byte(ca.Previous), &previous)
+	json.Unmarshal([]byte(ca.Winners), &winners)
+
+	return &challengeModel.WinnerAudit{
+		Base: model.Base{
+			ID:        ca.ID,
+			CreatedAt: ca.CreatedAt,
+			UpdatedAt: ca.CreatedAt,
+		},
+		ChallengeID: ca.ChallengeID,
+		ActorID:     ca.ActorID,
+		Reason:      ca.Reason,
+		Previous:    previous,
+		Winners:     winners,
+	}
+}
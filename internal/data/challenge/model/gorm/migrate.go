@@ -0,0 +1,229 @@
+package gorm
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gorm.io/gorm"
+)
+
+// MigrateChallengeEnds backfills ends_at from the legacy end_duration string so challenges
+// created before close-out existed are closed when they end. Only unclosed rows without an
+// ends_at are touched, so it is safe to run on every start-up. Strings that cannot be parsed
+// are left as-is and the challenge is treated as open-ended.
+func MigrateChallengeEnds(db *gorm.DB) error {
+	var rows []Challenge
+	return db.Select("id", "end_duration").
+		Where("ends_at IS NULL AND closed_at IS NULL AND end_duration <> ''").
+		FindInBatches(&rows, 200, func(tx *gorm.DB, batch int) error {
+			for _, row := range rows {
+				c := &challengeModel.Challenge{}
+				if err := c.SetEnd(row.EndDuration); err != nil {
+					slog.Warn("challenge end not parseable", "challenge_id", row.ID, "value", row.EndDuration)
+					continue
+				}
+				if err := db.Model(&Challenge{}).Where("id = ?", row.ID).Update("ends_at", c.EndsAt).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+// MigrateFinalRounds sets final_for on final rounds created before it existed, so the unique
+// index covers them. Where a challenge already has several finals only the earliest is keyed.
+// Keyed rows are skipped, so it is safe to run on every start-up.
+func MigrateFinalRounds(db *gorm.DB) error {
+	var rows []JudgingRound
+	if err := db.Select("id", "challenge_id", "final_for").
+		Where("final = ?", true).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return err
+	}
+	keyed := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if row.FinalFor != nil {
+			keyed[row.ChallengeID] = true
+		}
+	}
+	for _, row := range rows {
+		if keyed[row.ChallengeID] {
+			continue
+		}
+		if err := db.Model(&JudgingRound{}).Where("id = ?", row.ID).Update("final_for", row.ChallengeID).Error; err != nil {
+			return err
+		}
+		keyed[row.ChallengeID] = true
+	}
+	return nil
+}
+
+// MigrateChallengePrizes rewrites prizes stored by older releases as free-form JSON, one
+// number, string or object per rank, in the typed shape: a challenge's winning and cause
+// prizes, and the prizes already awarded to winners and judged causes. Numbers become amounts,
+// strings descriptions, and objects keep their rank, amount, currency and description under
+// the names older clients used. Rows already typed are left alone, so it is safe to run on
+// every start-up.
+func MigrateChallengePrizes(db *gorm.DB) error {
+	var rows []Challenge
+	err := db.Select("id", "winning_price", "cause_price").
+		FindInBatches(&rows, 200, func(tx *gorm.DB, batch int) error {
+			for _, row := range rows {
+				updates := map[string]interface{}{}
+				if prizes, changed := migratePrizeList(row.WinningPrice); changed {
+					updates["winning_price"] = prizes
+				}
+				if prizes, changed := migratePrizeList(row.CausePrice); changed {
+					updates["cause_price"] = prizes
+				}
+				if len(updates) == 0 {
+					continue
+				}
+				if err := db.Model(&Challenge{}).Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return err
+	}
+	if err := migrateAwardedPrizes(db, &ChallengeWinner{}); err != nil {
+		return err
+	}
+	return migrateAwardedPrizes(db, &JudgingResult{})
+}
+
+// migrateAwardedPrizes rewrites the prize column of a winners or results table, taking a
+// legacy prize's rank from the row's position.
+func migrateAwardedPrizes(db *gorm.DB, table interface{}) error {
+	type awardRow struct {
+		ID      string
+		Placing int
+		Prize   string
+	}
+
+	var lastID string
+	for {
+		var rows []awardRow
+		if err := db.Model(table).Select("id", "position AS placing", "prize").
+			Where("id > ? AND prize IS NOT NULL AND prize <> ''", lastID).
+			Order("id").Limit(200).Scan(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		for _, row := range rows {
+			lastID = row.ID
+			if isTypedPrizes("["+row.Prize+"]", row.Placing) {
+				continue
+			}
+			prize := ""
+			var legacy interface{}
+			if err := json.Unmarshal([]byte(row.Prize), &legacy); err != nil {
+				slog.Warn("prize not parseable", "id", row.ID, "value", row.Prize)
+				continue
+			}
+			if p, ok := legacyPrize(legacy, row.Placing); ok {
+				prize = marshalPrize(&p)
+			}
+			if err := db.Model(table).Where("id = ?", row.ID).Update("prize", prize).Error; err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// migratePrizeList returns the typed form of a stored prize list and whether it differs from
+// what is stored. Lists that cannot be parsed are logged and left in place.
+func migratePrizeList(data string) (string, bool) {
+	if data == "" || isTypedPrizes(data, 0) {
+		return data, false
+	}
+	var legacy []interface{}
+	if err := json.Unmarshal([]byte(data), &legacy); err != nil {
+		slog.Warn("prize list not parseable", "value", data)
+		return data, false
+	}
+
+	prizes := make([]challengeModel.Prize, 0, len(legacy))
+	seen := make(map[int]bool, len(legacy))
+	for i, entry := range legacy {
+		if p, ok := legacyPrize(entry, i+1); ok && !seen[p.Rank] {
+			seen[p.Rank] = true
+			prizes = append(prizes, p)
+		}
+	}
+	sort.Slice(prizes, func(i, j int) bool { return prizes[i].Rank < prizes[j].Rank })
+	return marshalPrizes(prizes), true
+}
+
+// isTypedPrizes reports whether data is a list of prizes that already have ranks and no
+// fields beyond the typed ones. A single prize is typed only if it has the given rank.
+func isTypedPrizes(data string, rank int) bool {
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.DisallowUnknownFields()
+	var prizes []challengeModel.Prize
+	if err := dec.Decode(&prizes); err != nil {
+		return false
+	}
+	for _, p := range prizes {
+		if p.Rank < 1 || rank > 0 && p.Rank != rank {
+			return false
+		}
+	}
+	return true
+}
+
+// legacyPrize converts one free-form prize. It reports false for entries that say nothing
+// about a prize, such as null or an empty string.
+func legacyPrize(entry interface{}, rank int) (challengeModel.Prize, bool) {
+	p := challengeModel.Prize{Rank: rank}
+	switch v := entry.(type) {
+	case float64:
+		p.Amount = v
+	case string:
+		p.Description = strings.TrimSpace(v)
+	case map[string]interface{}:
+		if r, ok := v["rank"].(float64); ok && r >= 1 {
+			p.Rank = int(r)
+		} else if r, ok := v["position"].(float64); ok && r >= 1 {
+			p.Rank = int(r)
+		}
+		for _, key := range []string{"amount", "value", "prize"} {
+			if amount, ok := legacyAmount(v[key]); ok {
+				p.Amount = amount
+				break
+			}
+		}
+		if currency, ok := v["currency"].(string); ok {
+			p.Currency = strings.ToUpper(strings.TrimSpace(currency))
+		}
+		for _, key := range []string{"description", "name", "title", "prize"} {
+			if description, ok := v[key].(string); ok && strings.TrimSpace(description) != "" {
+				if _, isAmount := legacyAmount(description); !isAmount {
+					p.Description = strings.TrimSpace(description)
+					break
+				}
+			}
+		}
+	}
+	return p, p.Amount > 0 || p.Description != ""
+}
+
+// legacyAmount reads an amount stored as a number or as a numeric string.
+func legacyAmount(v interface{}) (float64, bool) {
+	switch amount := v.(type) {
+	case float64:
+		return amount, amount > 0
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+		return f, err == nil && f > 0
+	}
+	return 0, false
+}
@@ -0,0 +1,124 @@
+package repo
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+)
+
+type GormBracketRepository struct {
+	db *gorm.DB
+}
+
+func NewGormBracketRepository(db *gorm.DB) challengeRepo.BracketRepository {
+	return &GormBracketRepository{db: db}
+}
+
+func (r *GormBracketRepository) CreateBracket(bracket *challengeModel.Bracket, matches []*challengeModel.BracketMatch) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		dbBracket := gormmodel.FromDomainBracket(bracket)
+		if err := tx.Create(dbBracket).Error; err != nil {
+			return err
+		}
+		*bracket = *gormmodel.ToDomainBracket(dbBracket)
+
+		for _, match := range matches {
+			match.BracketID = bracket.ID
+			dbMatch := gormmodel.FromDomainBracketMatch(match)
+			if err := tx.Create(dbMatch).Error; err != nil {
+				return err
+			}
+			*match = *gormmodel.ToDomainBracketMatch(dbMatch)
+		}
+		return nil
+	})
+}
+
+func (r *GormBracketRepository) GetBracket(id string) (*challengeModel.Bracket, error) {
+	var bracket gormmodel.ChallengeBracket
+	if err := r.db.First(&bracket, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainBracket(&bracket), nil
+}
+
+func (r *GormBracketRepository) GetChallengeBracket(challengeID string) (*challengeModel.Bracket, error) {
+	var bracket gormmodel.ChallengeBracket
+	if err := r.db.First(&bracket, "challenge_id = ?", challengeID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return gormmodel.ToDomainBracket(&bracket), nil
+}
+
+func (r *GormBracketRepository) ListMatches(bracketID string) ([]*challengeModel.BracketMatch, error) {
+	var matches []gormmodel.BracketMatch
+	if err := r.db.Where("bracket_id = ?", bracketID).Order("round ASC, slot ASC").Find(&matches).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.BracketMatch
+	for _, match := range matches {
+		result = append(result, gormmodel.ToDomainBracketMatch(&match))
+	}
+	return result, nil
+}
+
+func (r *GormBracketRepository) ListDueMatches(now time.Time) ([]*challengeModel.BracketMatch, error) {
+	var matches []gormmodel.BracketMatch
+	if err := r.db.Where("decided_at IS NULL AND closes_at <= ?", now).
+		Order("closes_at ASC, round ASC, slot ASC").Find(&matches).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.BracketMatch
+	for _, match := range matches {
+		result = append(result, gormmodel.ToDomainBracketMatch(&match))
+	}
+	return result, nil
+}
+
+func (r *GormBracketRepository) SaveDecision(bracket *challengeModel.Bracket, match, next *challengeModel.BracketMatch) (bool, error) {
+	decided := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Model(&gormmodel.BracketMatch{}).
+			Where("id = ? AND decided_at IS NULL", match.ID).
+			Updates(map[string]interface{}{
+				"home_distance": match.HomeDistance,
+				"away_distance": match.AwayDistance,
+				"outcome":       string(match.Outcome),
+				"winner_id":     match.WinnerID,
+				"decided_at":    match.DecidedAt,
+				"updated_at":    now,
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		decided = true
+
+		if next == nil {
+			return tx.Model(&gormmodel.ChallengeBracket{}).
+				Where("id = ?", bracket.ID).
+				Updates(map[string]interface{}{
+					"champion_id":  bracket.ChampionID,
+					"completed_at": bracket.CompletedAt,
+					"updated_at":   now,
+				}).Error
+		}
+		// Each side of a match has one feeder, so only the winner's side is written.
+		side := map[string]interface{}{"home_id": next.HomeID, "home_seed": next.HomeSeed, "updated_at": now}
+		if match.Slot%2 == 1 {
+			side = map[string]interface{}{"away_id": next.AwayID, "away_seed": next.AwaySeed, "updated_at": now}
+		}
+		return tx.Model(&gormmodel.BracketMatch{}).Where("id = ?", next.ID).Updates(side).Error
+	})
+	return decided, err
+}
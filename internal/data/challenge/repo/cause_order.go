@@ -0,0 +1,121 @@
+package repo
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+)
+
+type GormCauseOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCauseOrderRepository(db *gorm.DB) challengeRepo.CauseOrderRepository {
+	return &GormCauseOrderRepository{db: db}
+}
+
+func (r *GormCauseOrderRepository) Create(order *challengeModel.CauseOrder) error {
+	dbOrder := gormmodel.FromDomainCauseOrder(order)
+	if err := r.db.Create(dbOrder).Error; err != nil {
+		return err
+	}
+	*order = *gormmodel.ToDomainCauseOrder(dbOrder)
+	return nil
+}
+
+func (r *GormCauseOrderRepository) GetByID(id string) (*challengeModel.CauseOrder, error) {
+	var order gormmodel.CauseOrder
+	if err := r.db.First(&order, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainCauseOrder(&order), nil
+}
+
+func (r *GormCauseOrderRepository) ListByBuyer(buyerID string, limit, offset int) ([]*challengeModel.CauseOrder, int64, error) {
+	return r.list(r.db.Model(&gormmodel.CauseOrder{}).Where("buyer_id = ?", buyerID), limit, offset)
+}
+
+func (r *GormCauseOrderRepository) ListByCause(causeID string, limit, offset int) ([]*challengeModel.CauseOrder, int64, error) {
+	return r.list(r.db.Model(&gormmodel.CauseOrder{}).Where("cause_id = ?", causeID), limit, offset)
+}
+
+// list returns a page of the query's orders, newest first, and how many there are in all.
+func (r *GormCauseOrderRepository) list(q *gorm.DB, limit, offset int) ([]*challengeModel.CauseOrder, int64, error) {
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var orders []gormmodel.CauseOrder
+	if err := q.Order("created_at DESC").Limit(limit).Offset(offset).Find(&orders).Error; err != nil {
+		return nil, 0, err
+	}
+	result := make([]*challengeModel.CauseOrder, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, gormmodel.ToDomainCauseOrder(&order))
+	}
+	return result, total, nil
+}
+
+// Transition moves the order from its current status to the next one, stamping when it did, and
+// shifts its cause's units sold by soldDelta in the same transaction. It reports false when the
+// order is no longer in the status it was read in, and fails with ErrOutOfStock when the sale
+// would take the cause past its stock.
+func (r *GormCauseOrderRepository) Transition(order *challengeModel.CauseOrder, to challengeModel.OrderStatus, at time.Time, soldDelta int) (bool, error) {
+	updates := map[string]interface{}{"status": string(to), "updated_at": time.Now()}
+	switch to {
+	case challengeModel.OrderPaid:
+		updates["paid_at"] = at
+		updates["payment_ref"] = order.PaymentRef
+	case challengeModel.OrderFulfilled:
+		updates["fulfilled_at"] = at
+	case challengeModel.OrderRefunded:
+		updates["refunded_at"] = at
+	}
+
+	moved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&gormmodel.CauseOrder{}).
+			Where("id = ? AND status = ?", order.ID, string(order.Status)).
+			Updates(updates)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+
+		if soldDelta != 0 {
+			res = tx.Model(&gormmodel.Cause{}).
+				Where("id = ? AND (stock IS NULL OR units_sold + ? <= stock)", order.CauseID, soldDelta).
+				UpdateColumn("units_sold", gorm.Expr("units_sold + ?", soldDelta))
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return challengeModel.ErrOutOfStock
+			}
+		}
+
+		var saved gormmodel.CauseOrder
+		if err := tx.First(&saved, "id = ?", order.ID).Error; err != nil {
+			return err
+		}
+		*order = *gormmodel.ToDomainCauseOrder(&saved)
+		moved = true
+		return nil
+	})
+	return moved, err
+}
+
+// SetStock changes how many units of the cause are for sale, or makes them unlimited when stock
+// is nil. It reports false when the cause has already sold more than stock.
+func (r *GormCauseOrderRepository) SetStock(causeID string, stock *int) (bool, error) {
+	q := r.db.Model(&gormmodel.Cause{}).Where("id = ?", causeID)
+	if stock != nil {
+		q = q.Where("units_sold <= ?", *stock)
+	}
+	res := q.Updates(map[string]interface{}{"stock": stock, "updated_at": time.Now()})
+	return res.RowsAffected > 0, res.Error
+}
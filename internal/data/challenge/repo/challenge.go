@@ -1,13 +1,19 @@
 package repo
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
 	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	"gopi.com/internal/db"
+	activityModel "gopi.com/internal/domain/activity/model"
 	challengeModel "gopi.com/internal/domain/challenge/model"
 	challengeRepo "gopi.com/internal/domain/challenge/repo"
+	"gopi.com/internal/domain/model"
 )
 
 type GormChallengeRepository struct {
@@ -29,7 +35,7 @@ func (r *GormChallengeRepository) Create(challenge *challengeModel.Challenge) er
 
 func (r *GormChallengeRepository) GetByID(id string) (*challengeModel.Challenge, error) {
 	var c gormmodel.Challenge
-	if err := r.db.First(&c, "id = ?", id).Error; err != nil {
+	if err := r.db.Preload("Members").First(&c, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
@@ -41,7 +47,7 @@ func (r *GormChallengeRepository) GetByID(id string) (*challengeModel.Challenge,
 
 func (r *GormChallengeRepository) GetBySlug(slug string) (*challengeModel.Challenge, error) {
 	var c gormmodel.Challenge
-	if err := r.db.Where("slug = ?", slug).First(&c).Error; err != nil {
+	if err := r.db.Preload("Members").Where("slug = ?", slug).First(&c).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
@@ -53,7 +59,7 @@ func (r *GormChallengeRepository) GetBySlug(slug string) (*challengeModel.Challe
 
 func (r *GormChallengeRepository) GetByOwnerID(ownerID string) ([]*challengeModel.Challenge, error) {
 	var challenges []gormmodel.Challenge
-	if err := r.db.Where("owner_id = ?", ownerID).Find(&challenges).Error; err != nil {
+	if err := r.db.Preload("Members").Where("owner_id = ?", ownerID).Find(&challenges).Error; err != nil {
 		return nil, err
 	}
 
@@ -66,20 +72,107 @@ func (r *GormChallengeRepository) GetByOwnerID(ownerID string) ([]*challengeMode
 
 func (r *GormChallengeRepository) Update(challenge *challengeModel.Challenge) error {
 	dbChallenge := gormmodel.FromDomainChallenge(challenge)
-	if err := r.db.Save(&dbChallenge).Error; err != nil {
+	// Ownership, close-out and cancellation change through their own methods, so a stale copy
+	// cannot overwrite them.
+	if err := r.db.Omit("owner_id", "closed_at", "cancelled_at").Save(&dbChallenge).Error; err != nil {
 		return err
 	}
+	members := challenge.Members
 	*challenge = *gormmodel.ToDomainChallenge(dbChallenge)
+	challenge.Members = members
 	return nil
 }
 
 func (r *GormChallengeRepository) Delete(id string) error {
-	return r.db.Delete(&gormmodel.Challenge{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var causeIDs, roundIDs []string
+		if err := tx.Model(&gormmodel.Cause{}).Where("challenge_id = ?", id).Pluck("id", &causeIDs).Error; err != nil {
+			return err
+		}
+		for _, causeID := range causeIDs {
+			if err := deleteCause(tx, causeID); err != nil {
+				return err
+			}
+		}
+
+		var bracketIDs []string
+		if err := tx.Model(&gormmodel.ChallengeBracket{}).Where("challenge_id = ?", id).Pluck("id", &bracketIDs).Error; err != nil {
+			return err
+		}
+		if len(bracketIDs) > 0 {
+			if err := tx.Where("bracket_id IN ?", bracketIDs).Delete(&gormmodel.BracketMatch{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&gormmodel.JudgingRound{}).Where("challenge_id = ?", id).Pluck("id", &roundIDs).Error; err != nil {
+			return err
+		}
+		if len(roundIDs) > 0 {
+			for _, table := range []interface{}{&gormmodel.JudgingJudge{}, &gormmodel.JudgingScore{}, &gormmodel.JudgingVote{}, &gormmodel.JudgingResult{}} {
+				if err := tx.Where("round_id IN ?", roundIDs).Delete(table).Error; err != nil {
+					return err
+				}
+			}
+		}
+		for _, table := range []interface{}{
+			&gormmodel.ChallengeBracket{}, &gormmodel.JudgingRound{}, &gormmodel.ChallengeWinner{}, &gormmodel.ChallengeWinnerAudit{},
+			&gormmodel.SponsorChallenge{}, &gormmodel.ChallengeSponsor{}, &gormmodel.ChallengeMember{},
+		} {
+			if err := tx.Where("challenge_id = ?", id).Delete(table).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&gormmodel.Challenge{}, "id = ?", id).Error
+	})
 }
 
 func (r *GormChallengeRepository) List(limit, offset int) ([]*challengeModel.Challenge, error) {
 	var challenges []gormmodel.Challenge
-	if err := r.db.Limit(limit).Offset(offset).Order("created_at DESC").Find(&challenges).Error; err != nil {
+	if err := r.db.Preload("Members").Limit(limit).Offset(offset).Order("created_at DESC").Find(&challenges).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.Challenge
+	for _, c := range challenges {
+		result = append(result, gormmodel.ToDomainChallenge(&c))
+	}
+	return result, nil
+}
+
+func (r *GormChallengeRepository) ListNear(near model.Near, limit, offset int) ([]*challengeModel.Challenge, int64, error) {
+	ids, err := db.SelectWithinRadius(r.db.Model(&gormmodel.Challenge{}), near, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	page := db.PageIDs(ids, limit, offset)
+	if len(page) == 0 {
+		return nil, int64(len(ids)), nil
+	}
+
+	var challenges []gormmodel.Challenge
+	if err := r.db.Preload("Members").Where("id IN ?", page).Find(&challenges).Error; err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[string]*challengeModel.Challenge, len(challenges))
+	for _, c := range challenges {
+		byID[c.ID] = gormmodel.ToDomainChallenge(&c)
+	}
+
+	var result []*challengeModel.Challenge
+	for _, id := range page {
+		if c, ok := byID[id]; ok {
+			result = append(result, c)
+		}
+	}
+	return result, int64(len(ids)), nil
+}
+
+func (r *GormChallengeRepository) ListDueForCloseout(now time.Time) ([]*challengeModel.Challenge, error) {
+	var challenges []gormmodel.Challenge
+	if err := r.db.Preload("Members").
+		Where("closed_at IS NULL AND cancelled_at IS NULL AND ends_at <= ?", now).
+		Order("ends_at ASC").Find(&challenges).Error; err != nil {
 		return nil, err
 	}
 
@@ -90,6 +183,69 @@ func (r *GormChallengeRepository) List(limit, offset int) ([]*challengeModel.Cha
 	return result, nil
 }
 
+func (r *GormChallengeRepository) MarkClosed(id string, at time.Time) (bool, error) {
+	res := r.db.Model(&gormmodel.Challenge{}).
+		Where("id = ? AND closed_at IS NULL AND cancelled_at IS NULL", id).
+		Updates(map[string]interface{}{"closed_at": at, "updated_at": time.Now()})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *GormChallengeRepository) MarkCancelled(id string, at time.Time) (bool, error) {
+	res := r.db.Model(&gormmodel.Challenge{}).
+		Where("id = ? AND closed_at IS NULL AND cancelled_at IS NULL", id).
+		Updates(map[string]interface{}{"cancelled_at": at, "updated_at": time.Now()})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *GormChallengeRepository) TransferOwner(id, fromID, toID string) (bool, error) {
+	res := r.db.Model(&gormmodel.Challenge{}).
+		Where("id = ? AND owner_id = ?", id, fromID).
+		Updates(map[string]interface{}{"owner_id": toID, "updated_at": time.Now()})
+	return res.RowsAffected > 0, res.Error
+}
+
+// Many-to-many relationship methods
+func (r *GormChallengeRepository) AddMember(challengeID, userID string) error {
+	member := &gormmodel.ChallengeMember{
+		ChallengeID: challengeID,
+		UserID:      userID,
+		JoinedAt:    time.Now(),
+	}
+	return r.db.Create(member).Error
+}
+
+func (r *GormChallengeRepository) RemoveMember(challengeID, userID string) (bool, error) {
+	res := r.db.Where("challenge_id = ? AND user_id = ?", challengeID, userID).
+		Delete(&gormmodel.ChallengeMember{})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *GormChallengeRepository) IsMember(challengeID, userID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&gormmodel.ChallengeMember{}).
+		Where("challenge_id = ? AND user_id = ?", challengeID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *GormChallengeRepository) ListMembers(challengeID string, limit, offset int) ([]*challengeModel.Membership, int64, error) {
+	q := r.db.Model(&gormmodel.ChallengeMember{}).Where("challenge_id = ?", challengeID)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var members []gormmodel.ChallengeMember
+	if err := q.Order("joined_at ASC").Limit(limit).Offset(offset).Find(&members).Error; err != nil {
+		return nil, 0, err
+	}
+	result := make([]*challengeModel.Membership, 0, len(members))
+	for _, m := range members {
+		result = append(result, &challengeModel.Membership{UserID: m.UserID, JoinedAt: m.JoinedAt})
+	}
+	return result, total, nil
+}
+
 // Cause Repository
 type GormCauseRepository struct {
 	db *gorm.DB
@@ -110,7 +266,7 @@ func (r *GormCauseRepository) Create(cause *challengeModel.Cause) error {
 
 func (r *GormCauseRepository) GetByID(id string) (*challengeModel.Cause, error) {
 	var c gormmodel.Cause
-	if err := r.db.First(&c, "id = ?", id).Error; err != nil {
+	if err := r.db.Preload("Members").First(&c, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
@@ -122,7 +278,7 @@ func (r *GormCauseRepository) GetByID(id string) (*challengeModel.Cause, error)
 
 func (r *GormCauseRepository) GetBySlug(slug string) (*challengeModel.Cause, error) {
 	var c gormmodel.Cause
-	if err := r.db.Where("slug = ?", slug).First(&c).Error; err != nil {
+	if err := r.db.Preload("Members").Where("slug = ?", slug).First(&c).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
@@ -134,7 +290,7 @@ func (r *GormCauseRepository) GetBySlug(slug string) (*challengeModel.Cause, err
 
 func (r *GormCauseRepository) GetByChallengeID(challengeID string) ([]*challengeModel.Cause, error) {
 	var causes []gormmodel.Cause
-	if err := r.db.Where("challenge_id = ?", challengeID).Find(&causes).Error; err != nil {
+	if err := r.db.Preload("Members").Where("challenge_id = ?", challengeID).Find(&causes).Error; err != nil {
 		return nil, err
 	}
 
@@ -147,7 +303,7 @@ func (r *GormCauseRepository) GetByChallengeID(challengeID string) ([]*challenge
 
 func (r *GormCauseRepository) GetByOwnerID(ownerID string) ([]*challengeModel.Cause, error) {
 	var causes []gormmodel.Cause
-	if err := r.db.Where("owner_id = ?", ownerID).Find(&causes).Error; err != nil {
+	if err := r.db.Preload("Members").Where("owner_id = ?", ownerID).Find(&causes).Error; err != nil {
 		return nil, err
 	}
 
@@ -160,15 +316,117 @@ func (r *GormCauseRepository) GetByOwnerID(ownerID string) ([]*challengeModel.Ca
 
 func (r *GormCauseRepository) Update(cause *challengeModel.Cause) error {
 	dbCause := gormmodel.FromDomainCause(cause)
-	if err := r.db.Save(&dbCause).Error; err != nil {
+	// Ownership, covered distance and units sold change through their own methods, so a stale
+	// copy cannot overwrite them.
+	if err := r.db.Omit("owner_id", "distance_covered", "units_sold").Save(&dbCause).Error; err != nil {
 		return err
 	}
+	members := cause.Members
 	*cause = *gormmodel.ToDomainCause(dbCause)
+	cause.Members = members
 	return nil
 }
 
 func (r *GormCauseRepository) Delete(id string) error {
-	return r.db.Delete(&gormmodel.Cause{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteCause(tx, id)
+	})
+}
+
+// deleteCause removes the cause and the rows that reference it within tx.
+func deleteCause(tx *gorm.DB, id string) error {
+	for _, table := range []interface{}{
+		&gormmodel.JudgingScore{}, &gormmodel.JudgingVote{}, &gormmodel.JudgingResult{},
+		&gormmodel.SponsorStatement{}, &gormmodel.CauseOrder{}, &gormmodel.CauseBuyer{},
+		&gormmodel.SponsorCause{}, &gormmodel.CauseRunner{}, &gormmodel.CauseSponsorMember{}, &gormmodel.CauseMember{},
+	} {
+		if err := tx.Where("cause_id = ?", id).Delete(table).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Delete(&gormmodel.Cause{}, "id = ?", id).Error
+}
+
+func (r *GormCauseRepository) TransferOwner(id, fromID, toID string) (bool, error) {
+	res := r.db.Model(&gormmodel.Cause{}).
+		Where("id = ? AND owner_id = ?", id, fromID).
+		Updates(map[string]interface{}{"owner_id": toID, "updated_at": time.Now()})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *GormCauseRepository) IncrementDistance(id string, distance float64) error {
+	return r.db.Model(&gormmodel.Cause{}).Where("id = ?", id).
+		Update("distance_covered", gorm.Expr("distance_covered + ?", distance)).Error
+}
+
+func (r *GormCauseRepository) ListNear(near model.Near, limit, offset int) ([]*challengeModel.Cause, int64, error) {
+	ids, err := db.SelectWithinRadius(r.db.Model(&gormmodel.Cause{}), near, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	page := db.PageIDs(ids, limit, offset)
+	if len(page) == 0 {
+		return nil, int64(len(ids)), nil
+	}
+
+	var causes []gormmodel.Cause
+	if err := r.db.Preload("Members").Where("id IN ?", page).Find(&causes).Error; err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[string]*challengeModel.Cause, len(causes))
+	for _, c := range causes {
+		byID[c.ID] = gormmodel.ToDomainCause(&c)
+	}
+
+	var result []*challengeModel.Cause
+	for _, id := range page {
+		if c, ok := byID[id]; ok {
+			result = append(result, c)
+		}
+	}
+	return result, int64(len(ids)), nil
+}
+
+// Many-to-many relationship methods
+func (r *GormCauseRepository) AddMember(causeID, userID string) error {
+	member := &gormmodel.CauseMember{
+		CauseID:  causeID,
+		UserID:   userID,
+		JoinedAt: time.Now(),
+	}
+	return r.db.Create(member).Error
+}
+
+func (r *GormCauseRepository) RemoveMember(causeID, userID string) (bool, error) {
+	res := r.db.Where("cause_id = ? AND user_id = ?", causeID, userID).
+		Delete(&gormmodel.CauseMember{})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *GormCauseRepository) IsMember(causeID, userID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&gormmodel.CauseMember{}).
+		Where("cause_id = ? AND user_id = ?", causeID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *GormCauseRepository) ListMembers(causeID string, limit, offset int) ([]*challengeModel.Membership, int64, error) {
+	q := r.db.Model(&gormmodel.CauseMember{}).Where("cause_id = ?", causeID)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var members []gormmodel.CauseMember
+	if err := q.Order("joined_at ASC").Limit(limit).Offset(offset).Find(&members).Error; err != nil {
+		return nil, 0, err
+	}
+	result := make([]*challengeModel.Membership, 0, len(members))
+	for _, m := range members {
+		result = append(result, &challengeModel.Membership{UserID: m.UserID, JoinedAt: m.JoinedAt})
+	}
+	return result, total, nil
 }
 
 // CauseRunner Repository
@@ -242,7 +500,7 @@ func (r *GormCauseRunnerRepository) Delete(id string) error {
 
 func (r *GormCauseRunnerRepository) GetLeaderboard() ([]*challengeModel.CauseRunner, error) {
 	var runners []gormmodel.CauseRunner
-	if err := r.db.Order("distance_covered DESC").Limit(10).Find(&runners).Error; err != nil {
+	if err := r.db.Scopes(countedCauseRunners).Order("distance_covered DESC").Limit(10).Find(&runners).Error; err != nil {
 		return nil, err
 	}
 
@@ -253,6 +511,97 @@ func (r *GormCauseRunnerRepository) GetLeaderboard() ([]*challengeModel.CauseRun
 	return result, nil
 }
 
+func (r *GormCauseRunnerRepository) ListForLeaderboard(causeIDs []string, since time.Time, activity string) ([]*challengeModel.CauseRunner, error) {
+	if len(causeIDs) == 0 {
+		return nil, nil
+	}
+	query := r.db.Scopes(countedCauseRunners).Where("cause_id IN ?", causeIDs)
+	if !since.IsZero() {
+		query = query.Where("date_joined >= ?", since)
+	}
+	if activity != "" {
+		query = query.Where("LOWER(activity) = ?", strings.ToLower(activity))
+	}
+	var runners []gormmodel.CauseRunner
+	if err := query.Find(&runners).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.CauseRunner
+	for _, cr := range runners {
+		result = append(result, gormmodel.ToDomainCauseRunner(&cr))
+	}
+	return result, nil
+}
+
+func (r *GormCauseRunnerRepository) AttachTrack(runner *challengeModel.CauseRunner, distance float64, duration time.Duration, trackURL string, elevationGain float64) (bool, error) {
+	res := r.db.Model(&gormmodel.CauseRunner{}).
+		Where("id = ? AND distance_covered = ? AND COALESCE(status, '') = ?", runner.ID, runner.DistanceCovered, string(runner.Status)).
+		Updates(map[string]interface{}{
+			"distance_covered": distance,
+			"duration_seconds": model.Seconds(duration),
+			"track_url":        trackURL,
+			"elevation_gain":   elevationGain,
+			"date_updated":     time.Now(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+// countedCauseRunners excludes runs held for, or rejected in, anti-cheat review.
+func countedCauseRunners(db *gorm.DB) *gorm.DB {
+	return db.Where("(status IS NULL OR status NOT IN ?)",
+		[]string{string(activityModel.ReviewStatusFlagged), string(activityModel.ReviewStatusRejected)})
+}
+
+func (r *GormCauseRunnerRepository) SumDistanceSince(ownerID string, since time.Time) (float64, error) {
+	var total float64
+	err := r.db.Model(&gormmodel.CauseRunner{}).
+		Where("owner_id = ? AND date_joined >= ?", ownerID, since).
+		Scopes(countedCauseRunners).
+		Select("COALESCE(SUM(distance_covered), 0)").Scan(&total).Error
+	return total, err
+}
+
+func (r *GormCauseRunnerRepository) ListByStatus(status activityModel.ReviewStatus, limit, offset int) ([]*challengeModel.CauseRunner, error) {
+	var runners []gormmodel.CauseRunner
+	if err := r.db.Where("status = ?", string(status)).Order("date_joined ASC").Limit(limit).Offset(offset).Find(&runners).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.CauseRunner
+	for _, cr := range runners {
+		result = append(result, gormmodel.ToDomainCauseRunner(&cr))
+	}
+	return result, nil
+}
+
+func (r *GormCauseRunnerRepository) Flag(id string, violations []activityModel.Violation) error {
+	data, err := json.Marshal(violations)
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&gormmodel.CauseRunner{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       string(activityModel.ReviewStatusFlagged),
+		"violations":   string(data),
+		"date_updated": time.Now(),
+	}).Error
+}
+
+func (r *GormCauseRunnerRepository) Review(id string, status activityModel.ReviewStatus, reviewerID string, at time.Time) (bool, error) {
+	result := r.db.Model(&gormmodel.CauseRunner{}).
+		Where("id = ? AND status = ?", id, string(activityModel.ReviewStatusFlagged)).
+		Updates(map[string]interface{}{
+			"status":       string(status),
+			"reviewed_by":  reviewerID,
+			"reviewed_at":  at,
+			"date_updated": at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // SponsorChallenge Repository
 type GormSponsorChallengeRepository struct {
 	db *gorm.DB
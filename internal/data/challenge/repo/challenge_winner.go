@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+)
+
+type GormChallengeWinnerRepository struct {
+	db *gorm.DB
+}
+
+func NewGormChallengeWinnerRepository(db *gorm.DB) challengeRepo.ChallengeWinnerRepository {
+	return &GormChallengeWinnerRepository{db: db}
+}
+
+func (r *GormChallengeWinnerRepository) SaveWinners(challengeID string, winners []*challengeModel.ChallengeWinner, audit *challengeModel.WinnerAudit) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("challenge_id = ?", challengeID).Delete(&gormmodel.ChallengeWinner{}).Error; err != nil {
+			return err
+		}
+		for _, winner := range winners {
+			dbWinner := gormmodel.FromDomainChallengeWinner(winner)
+			if err := tx.Create(dbWinner).Error; err != nil {
+				return err
+			}
+			*winner = *gormmodel.ToDomainChallengeWinner(dbWinner)
+		}
+		if audit == nil {
+			return nil
+		}
+		dbAudit := gormmodel.FromDomainWinnerAudit(audit)
+		if err := tx.Create(dbAudit).Error; err != nil {
+			return err
+		}
+		*audit = *gormmodel.ToDomainWinnerAudit(dbAudit)
+		return nil
+	})
+}
+
+func (r *GormChallengeWinnerRepository) GetWinners(challengeID string) ([]*challengeModel.ChallengeWinner, error) {
+	var winners []gormmodel.ChallengeWinner
+	if err := r.db.Where("challenge_id = ?", challengeID).Order("position ASC").Find(&winners).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.ChallengeWinner
+	for _, w := range winners {
+		result = append(result, gormmodel.ToDomainChallengeWinner(&w))
+	}
+	return result, nil
+}
+
+func (r *GormChallengeWinnerRepository) ListAudits(challengeID string) ([]*challengeModel.WinnerAudit, error) {
+	var audits []gormmodel.ChallengeWinnerAudit
+	if err := r.db.Where("challenge_id = ?", challengeID).Order("created_at ASC").Find(&audits).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.WinnerAudit
+	for _, a := range audits {
+		result = append(result, gormmodel.ToDomainWinnerAudit(&a))
+	}
+	return result, nil
+}
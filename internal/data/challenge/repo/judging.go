@@ -0,0 +1,199 @@
+package repo
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+)
+
+type GormJudgingRepository struct {
+	db *gorm.DB
+}
+
+func NewGormJudgingRepository(db *gorm.DB) challengeRepo.JudgingRepository {
+	return &GormJudgingRepository{db: db}
+}
+
+func (r *GormJudgingRepository) CreateRound(round *challengeModel.JudgingRound) error {
+	dbRound := gormmodel.FromDomainJudgingRound(round)
+	if err := r.db.Create(dbRound).Error; err != nil {
+		return err
+	}
+	*round = *gormmodel.ToDomainJudgingRound(dbRound)
+	return nil
+}
+
+func (r *GormJudgingRepository) GetRound(id string) (*challengeModel.JudgingRound, error) {
+	var round gormmodel.JudgingRound
+	if err := r.db.First(&round, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainJudgingRound(&round), nil
+}
+
+func (r *GormJudgingRepository) ListRounds(challengeID string) ([]*challengeModel.JudgingRound, error) {
+	var rounds []gormmodel.JudgingRound
+	if err := r.db.Where("challenge_id = ?", challengeID).Order("opens_at ASC").Find(&rounds).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.JudgingRound
+	for _, round := range rounds {
+		result = append(result, gormmodel.ToDomainJudgingRound(&round))
+	}
+	return result, nil
+}
+
+func (r *GormJudgingRepository) ListDueRounds(now time.Time) ([]*challengeModel.JudgingRound, error) {
+	var rounds []gormmodel.JudgingRound
+	if err := r.db.Where("closed_at IS NULL AND closes_at <= ?", now).Order("closes_at ASC").Find(&rounds).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.JudgingRound
+	for _, round := range rounds {
+		result = append(result, gormmodel.ToDomainJudgingRound(&round))
+	}
+	return result, nil
+}
+
+func (r *GormJudgingRepository) MarkRoundClosed(id string, at time.Time) (bool, error) {
+	res := r.db.Model(&gormmodel.JudgingRound{}).
+		Where("id = ? AND closed_at IS NULL", id).
+		Updates(map[string]interface{}{"closed_at": at, "updated_at": time.Now()})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *GormJudgingRepository) AddJudge(judge *challengeModel.Judge) error {
+	dbJudge := gormmodel.FromDomainJudge(judge)
+	if err := r.db.Create(dbJudge).Error; err != nil {
+		return err
+	}
+	*judge = *gormmodel.ToDomainJudge(dbJudge)
+	return nil
+}
+
+func (r *GormJudgingRepository) GetJudge(roundID, userID string) (*challengeModel.Judge, error) {
+	var judge gormmodel.JudgingJudge
+	if err := r.db.First(&judge, "round_id = ? AND user_id = ?", roundID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return gormmodel.ToDomainJudge(&judge), nil
+}
+
+func (r *GormJudgingRepository) ListJudges(roundID string) ([]*challengeModel.Judge, error) {
+	var judges []gormmodel.JudgingJudge
+	if err := r.db.Where("round_id = ?", roundID).Order("created_at ASC").Find(&judges).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.Judge
+	for _, judge := range judges {
+		result = append(result, gormmodel.ToDomainJudge(&judge))
+	}
+	return result, nil
+}
+
+func (r *GormJudgingRepository) RemoveJudge(roundID, userID string) (bool, error) {
+	res := r.db.Where("round_id = ? AND user_id = ?", roundID, userID).Delete(&gormmodel.JudgingJudge{})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *GormJudgingRepository) SaveScore(score *challengeModel.JudgeScore) error {
+	dbScore := gormmodel.FromDomainJudgeScore(score)
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "round_id"}, {Name: "judge_id"}, {Name: "cause_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"scores", "total", "comment", "updated_at"}),
+	}).Create(dbScore).Error
+	if err != nil {
+		return err
+	}
+	// The insert's ID is discarded when an earlier score is replaced, so read back the row.
+	var saved gormmodel.JudgingScore
+	if err := r.db.First(&saved, "round_id = ? AND judge_id = ? AND cause_id = ?", score.RoundID, score.JudgeID, score.CauseID).Error; err != nil {
+		return err
+	}
+	*score = *gormmodel.ToDomainJudgeScore(&saved)
+	return nil
+}
+
+func (r *GormJudgingRepository) ListScores(roundID string) ([]*challengeModel.JudgeScore, error) {
+	var scores []gormmodel.JudgingScore
+	if err := r.db.Where("round_id = ?", roundID).Order("cause_id ASC, created_at ASC").Find(&scores).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.JudgeScore
+	for _, score := range scores {
+		result = append(result, gormmodel.ToDomainJudgeScore(&score))
+	}
+	return result, nil
+}
+
+func (r *GormJudgingRepository) CastVote(vote *challengeModel.Vote) (bool, error) {
+	dbVote := gormmodel.FromDomainVote(vote)
+	res := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(dbVote)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+	*vote = *gormmodel.ToDomainVote(dbVote)
+	return true, nil
+}
+
+func (r *GormJudgingRepository) CountVotes(roundID string) (map[string]int, error) {
+	var rows []struct {
+		CauseID string
+		Votes   int
+	}
+	if err := r.db.Model(&gormmodel.JudgingVote{}).
+		Select("cause_id, COUNT(*) AS votes").
+		Where("round_id = ?", roundID).
+		Group("cause_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.CauseID] = row.Votes
+	}
+	return counts, nil
+}
+
+func (r *GormJudgingRepository) SaveResults(roundID string, results []*challengeModel.RoundResult) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("round_id = ?", roundID).Delete(&gormmodel.JudgingResult{}).Error; err != nil {
+			return err
+		}
+		for _, result := range results {
+			dbResult := gormmodel.FromDomainRoundResult(result)
+			if err := tx.Create(dbResult).Error; err != nil {
+				return err
+			}
+			*result = *gormmodel.ToDomainRoundResult(dbResult)
+		}
+		return nil
+	})
+}
+
+func (r *GormJudgingRepository) GetResults(roundID string) ([]*challengeModel.RoundResult, error) {
+	var results []gormmodel.JudgingResult
+	if err := r.db.Where("round_id = ?", roundID).Order("position ASC").Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.RoundResult
+	for _, res := range results {
+		result = append(result, gormmodel.ToDomainRoundResult(&res))
+	}
+	return result, nil
+}
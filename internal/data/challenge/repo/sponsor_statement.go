@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+)
+
+type GormSponsorStatementRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSponsorStatementRepository(db *gorm.DB) challengeRepo.SponsorStatementRepository {
+	return &GormSponsorStatementRepository{db: db}
+}
+
+func (r *GormSponsorStatementRepository) SaveStatements(causeID string, statements []*challengeModel.SponsorStatement) (bool, error) {
+	saved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&gormmodel.SponsorStatement{}).Where("cause_id = ?", causeID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return nil
+		}
+		for _, statement := range statements {
+			dbStatement := gormmodel.FromDomainSponsorStatement(statement)
+			if err := tx.Create(dbStatement).Error; err != nil {
+				return err
+			}
+			*statement = *gormmodel.ToDomainSponsorStatement(dbStatement)
+		}
+		saved = true
+		return nil
+	})
+	return saved, err
+}
+
+func (r *GormSponsorStatementRepository) ListByCause(causeID string) ([]*challengeModel.SponsorStatement, error) {
+	var statements []gormmodel.SponsorStatement
+	if err := r.db.Where("cause_id = ?", causeID).Order("amount_due DESC, sponsor_id ASC").Find(&statements).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.SponsorStatement
+	for _, statement := range statements {
+		result = append(result, gormmodel.ToDomainSponsorStatement(&statement))
+	}
+	return result, nil
+}
+
+func (r *GormSponsorStatementRepository) ListBySponsor(sponsorID string) ([]*challengeModel.SponsorStatement, error) {
+	var statements []gormmodel.SponsorStatement
+	if err := r.db.Where("sponsor_id = ?", sponsorID).Order("settled_at DESC").Find(&statements).Error; err != nil {
+		return nil, err
+	}
+
+	var result []*challengeModel.SponsorStatement
+	for _, statement := range statements {
+		result = append(result, gormmodel.ToDomainSponsorStatement(&statement))
+	}
+	return result, nil
+}
@@ -0,0 +1,32 @@
+package repo
+
+import (
+	"gorm.io/gorm"
+
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+)
+
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewGormUnitOfWork(db *gorm.DB) challengeRepo.UnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+// Do runs fn inside a database transaction with repositories bound to it.
+func (u *GormUnitOfWork) Do(fn func(repos challengeRepo.Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(challengeRepo.Repositories{
+			Challenges:    NewGormChallengeRepository(tx),
+			Causes:        NewGormCauseRepository(tx),
+			CauseRunners:  NewGormCauseRunnerRepository(tx),
+			Sponsors:      NewGormSponsorChallengeRepository(tx),
+			SponsorCauses: NewGormSponsorCauseRepository(tx),
+			CauseBuyers:   NewGormCauseBuyerRepository(tx),
+			Winners:       NewGormChallengeWinnerRepository(tx),
+			Statements:    NewGormSponsorStatementRepository(tx),
+			Judging:       NewGormJudgingRepository(tx),
+		})
+	})
+}
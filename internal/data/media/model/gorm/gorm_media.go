@@ -0,0 +1,102 @@
+package gorm
+
+import (
+	"time"
+
+	mediaModel "gopi.com/internal/domain/media/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// MediaItem is one image or video in a challenge, cause or campaign gallery.
+type MediaItem struct {
+	ID           string `gorm:"type:varchar(255);primary_key"`
+	SubjectKind  string `gorm:"index:idx_media_gallery,priority:1;size:20;not null"`
+	SubjectID    string `gorm:"index:idx_media_gallery,priority:2;size:255;not null"`
+	Position     int    `gorm:"index:idx_media_gallery,priority:3;not null"`
+	Type         string `gorm:"size:20;not null"`
+	Caption      string `gorm:"size:500"`
+	URL          string `gorm:"not null"`
+	UploaderID   string `gorm:"index;not null"`
+	FileKey      string
+	ContentType  string
+	Size         int64
+	Provider     string
+	ProviderURL  string
+	VideoID      string
+	EmbedURL     string
+	ThumbnailURL string
+	Width        int
+	Height       int
+	EmbedHTML    string    `gorm:"type:text"`
+	CreatedAt    time.Time `gorm:"index"`
+	UpdatedAt    time.Time
+}
+
+func (MediaItem) TableName() string {
+	return "media_items"
+}
+
+func (m *MediaItem) BeforeCreate(tx *gorm.DB) (err error) {
+	if m.ID == "" {
+		m.ID = id.New()
+	}
+	return
+}
+
+// Converters
+
+func FromDomainMediaItem(m *mediaModel.Item) *MediaItem {
+	return &MediaItem{
+		ID:           m.ID,
+		SubjectKind:  string(m.SubjectKind),
+		SubjectID:    m.SubjectID,
+		Position:     m.Position,
+		Type:         string(m.Type),
+		Caption:      m.Caption,
+		URL:          m.URL,
+		UploaderID:   m.UploaderID,
+		FileKey:      m.FileKey,
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		Provider:     m.Provider,
+		ProviderURL:  m.ProviderURL,
+		VideoID:      m.VideoID,
+		EmbedURL:     m.EmbedURL,
+		ThumbnailURL: m.ThumbnailURL,
+		Width:        m.Width,
+		Height:       m.Height,
+		EmbedHTML:    m.EmbedHTML,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func ToDomainMediaItem(m *MediaItem) *mediaModel.Item {
+	return &mediaModel.Item{
+		Base: model.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+		SubjectKind:  mediaModel.SubjectKind(m.SubjectKind),
+		SubjectID:    m.SubjectID,
+		Position:     m.Position,
+		Type:         mediaModel.Type(m.Type),
+		Caption:      m.Caption,
+		URL:          m.URL,
+		UploaderID:   m.UploaderID,
+		FileKey:      m.FileKey,
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		Provider:     m.Provider,
+		ProviderURL:  m.ProviderURL,
+		VideoID:      m.VideoID,
+		EmbedURL:     m.EmbedURL,
+		ThumbnailURL: m.ThumbnailURL,
+		Width:        m.Width,
+		Height:       m.Height,
+		EmbedHTML:    m.EmbedHTML,
+	}
+}
@@ -0,0 +1,155 @@
+package repo
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	gormmodel "gopi.com/internal/data/media/model/gorm"
+	mediaModel "gopi.com/internal/domain/media/model"
+	mediaRepo "gopi.com/internal/domain/media/repo"
+)
+
+type GormMediaRepository struct {
+	db *gorm.DB
+}
+
+func NewGormMediaRepository(db *gorm.DB) mediaRepo.MediaRepository {
+	return &GormMediaRepository{db: db}
+}
+
+func (r *GormMediaRepository) Append(item *mediaModel.Item, max int) (bool, error) {
+	dbItem := gormmodel.FromDomainMediaItem(item)
+	added := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var stats struct {
+			Count int64
+			Last  int
+		}
+		if err := tx.Model(&gormmodel.MediaItem{}).
+			Select("COUNT(*) AS count, COALESCE(MAX(position), 0) AS last").
+			Where("subject_kind = ? AND subject_id = ?", dbItem.SubjectKind, dbItem.SubjectID).
+			Scan(&stats).Error; err != nil {
+			return err
+		}
+		if stats.Count >= int64(max) {
+			return nil
+		}
+		dbItem.Position = stats.Last + 1
+		if err := tx.Create(dbItem).Error; err != nil {
+			return err
+		}
+		added = true
+		return nil
+	})
+	if err != nil || !added {
+		return false, err
+	}
+	*item = *gormmodel.ToDomainMediaItem(dbItem)
+	return true, nil
+}
+
+func (r *GormMediaRepository) GetByID(id string) (*mediaModel.Item, error) {
+	var m gormmodel.MediaItem
+	err := r.db.First(&m, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, mediaModel.ErrItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gormmodel.ToDomainMediaItem(&m), nil
+}
+
+func (r *GormMediaRepository) ListGallery(kind mediaModel.SubjectKind, subjectID string) ([]*mediaModel.Item, error) {
+	var items []gormmodel.MediaItem
+	if err := r.db.Where("subject_kind = ? AND subject_id = ?", string(kind), subjectID).
+		Order("position ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	result := make([]*mediaModel.Item, 0, len(items))
+	for i := range items {
+		result = append(result, gormmodel.ToDomainMediaItem(&items[i]))
+	}
+	return result, nil
+}
+
+func (r *GormMediaRepository) UpdateCaption(id, caption string) error {
+	res := r.db.Model(&gormmodel.MediaItem{}).Where("id = ?", id).Update("caption", caption)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return mediaModel.ErrItemNotFound
+	}
+	return nil
+}
+
+func (r *GormMediaRepository) Reorder(kind mediaModel.SubjectKind, subjectID string, ids []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current []string
+		if err := tx.Model(&gormmodel.MediaItem{}).
+			Where("subject_kind = ? AND subject_id = ?", string(kind), subjectID).
+			Pluck("id", &current).Error; err != nil {
+			return err
+		}
+		if len(ids) != len(current) {
+			return mediaModel.ErrInvalidOrder
+		}
+		remaining := make(map[string]bool, len(current))
+		for _, itemID := range current {
+			remaining[itemID] = true
+		}
+		for _, itemID := range ids {
+			if !remaining[itemID] {
+				return mediaModel.ErrInvalidOrder
+			}
+			delete(remaining, itemID)
+		}
+
+		for i, itemID := range ids {
+			if err := tx.Model(&gormmodel.MediaItem{}).Where("id = ?", itemID).
+				Update("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *GormMediaRepository) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var m gormmodel.MediaItem
+		err := tx.First(&m, "id = ?", id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mediaModel.ErrItemNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&gormmodel.MediaItem{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Model(&gormmodel.MediaItem{}).
+			Where("subject_kind = ? AND subject_id = ? AND position > ?", m.SubjectKind, m.SubjectID, m.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+}
+
+func (r *GormMediaRepository) DeleteGallery(kind mediaModel.SubjectKind, subjectID string) ([]*mediaModel.Item, error) {
+	var items []gormmodel.MediaItem
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subject_kind = ? AND subject_id = ?", string(kind), subjectID).Find(&items).Error; err != nil {
+			return err
+		}
+		return tx.Where("subject_kind = ? AND subject_id = ?", string(kind), subjectID).Delete(&gormmodel.MediaItem{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*mediaModel.Item, 0, len(items))
+	for i := range items {
+		result = append(result, gormmodel.ToDomainMediaItem(&items[i]))
+	}
+	return result, nil
+}
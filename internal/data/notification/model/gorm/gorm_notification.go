@@ -0,0 +1,70 @@
+package gorm
+
+import (
+	"time"
+
+	"gopi.com/internal/domain/model"
+	notificationModel "gopi.com/internal/domain/notification/model"
+	"gopi.com/internal/lib/id"
+	"gorm.io/gorm"
+)
+
+// Notification is an in-app message. The unique index makes delivering the same event to a
+// user twice a no-op.
+type Notification struct {
+	ID        string `gorm:"type:varchar(255);primary_key"`
+	UserID    string `gorm:"uniqueIndex:idx_notification_source,priority:1;size:255;not null"`
+	Kind      string `gorm:"uniqueIndex:idx_notification_source,priority:2;size:40;not null"`
+	SourceID  string `gorm:"uniqueIndex:idx_notification_source,priority:3;size:255;not null"`
+	Title     string `gorm:"not null"`
+	Body      string `gorm:"type:text"`
+	Link      string
+	ReadAt    *time.Time `gorm:"index"`
+	CreatedAt time.Time  `gorm:"index"`
+	UpdatedAt time.Time
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) (err error) {
+	if n.ID == "" {
+		n.ID = id.New()
+	}
+	return
+}
+
+// Convert from domain Notification to GORM Notification
+func FromDomainNotification(n *notificationModel.Notification) *Notification {
+	return &Notification{
+		ID:        n.ID,
+		UserID:    n.UserID,
+		Kind:      string(n.Kind),
+		SourceID:  n.SourceID,
+		Title:     n.Title,
+		Body:      n.Body,
+		Link:      n.Link,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}
+}
+
+// Convert from GORM Notification to domain Notification
+func ToDomainNotification(n *Notification) *notificationModel.Notification {
+	return &notificationModel.Notification{
+		Base: model.Base{
+			ID:        n.ID,
+			CreatedAt: n.CreatedAt,
+			UpdatedAt: n.UpdatedAt,
+		},
+		UserID:   n.UserID,
+		Kind:     notificationModel.Kind(n.Kind),
+		SourceID: n.SourceID,
+		Title:    n.Title,
+		Body:     n.Body,
+		Link:     n.Link,
+		ReadAt:   n.ReadAt,
+	}
+}
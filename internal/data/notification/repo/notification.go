@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	gormmodel "gopi.com/internal/data/notification/model/gorm"
+	notificationModel "gopi.com/internal/domain/notification/model"
+	notificationRepo "gopi.com/internal/domain/notification/repo"
+)
+
+type GormNotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewGormNotificationRepository(db *gorm.DB) notificationRepo.NotificationRepository {
+	return &GormNotificationRepository{db: db}
+}
+
+func (r *GormNotificationRepository) CreateBatch(notifications []*notificationModel.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	rows := make([]*gormmodel.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		rows = append(rows, gormmodel.FromDomainNotification(n))
+	}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(rows, 200).Error; err != nil {
+		return err
+	}
+	for i, row := range rows {
+		*notifications[i] = *gormmodel.ToDomainNotification(row)
+	}
+	return nil
+}
+
+func (r *GormNotificationRepository) ListByUser(userID string, unreadOnly bool, limit, offset int) ([]*notificationModel.Notification, error) {
+	q := r.db.Where("user_id = ?", userID)
+	if unreadOnly {
+		q = q.Where("read_at IS NULL")
+	}
+
+	var notifications []gormmodel.Notification
+	if err := q.Order("created_at DESC").Limit(limit).Offset(offset).Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	result := make([]*notificationModel.Notification, 0, len(notifications))
+	for i := range notifications {
+		result = append(result, gormmodel.ToDomainNotification(&notifications[i]))
+	}
+	return result, nil
+}
+
+func (r *GormNotificationRepository) CountUnread(userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&gormmodel.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *GormNotificationRepository) MarkRead(id, userID string, at time.Time) error {
+	var n gormmodel.Notification
+	if err := r.db.Select("id", "read_at").Where("id = ? AND user_id = ?", id, userID).Take(&n).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notificationModel.ErrNotificationNotFound
+		}
+		return err
+	}
+	if n.ReadAt != nil {
+		return nil
+	}
+	return r.db.Model(&gormmodel.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Updates(map[string]interface{}{"read_at": at, "updated_at": at}).Error
+}
+
+func (r *GormNotificationRepository) MarkAllRead(userID string, at time.Time) (int64, error) {
+	result := r.db.Model(&gormmodel.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Updates(map[string]interface{}{"read_at": at, "updated_at": at})
+	return result.RowsAffected, result.Error
+}
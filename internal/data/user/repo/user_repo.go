@@ -37,6 +37,23 @@ func (r *UserRepositoryGORM) GetByID(id string) (*userModel.User, error) {
 	return userGORMModel.ToUserModel(), nil
 }
 
+func (r *UserRepositoryGORM) GetByIDs(ids []string) ([]*userModel.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var usersGORM []userGORM.UserGORM
+	err := r.db.Where("id IN ?", ids).Find(&usersGORM).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*userModel.User, len(usersGORM))
+	for i, userGORMModel := range usersGORM {
+		users[i] = userGORMModel.ToUserModel()
+	}
+	return users, nil
+}
+
 func (r *UserRepositoryGORM) GetByEmail(email string) (*userModel.User, error) {
 	var userGORMModel userGORM.UserGORM
 	err := r.db.Where("email = ?", email).First(&userGORMModel).Error
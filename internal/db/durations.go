@@ -0,0 +1,64 @@
+package db
+
+import (
+	"log/slog"
+
+	"gorm.io/gorm"
+
+	"gopi.com/internal/domain/model"
+)
+
+// MigrateLegacyDurations moves the free-form duration strings older releases stored in a
+// "duration" column into duration_seconds, for each model's table. Converted rows have the
+// legacy value cleared, so it is safe to run on every start-up. Values that cannot be parsed
+// are logged and left in place for manual correction.
+func MigrateLegacyDurations(gdb *gorm.DB, models ...interface{}) error {
+	for _, m := range models {
+		if !gdb.Migrator().HasColumn(m, "duration") {
+			continue
+		}
+		stmt := &gorm.Statement{DB: gdb}
+		if err := stmt.Parse(m); err != nil {
+			return err
+		}
+		if err := migrateLegacyDurations(gdb, stmt.Schema.Table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migrateLegacyDurations(gdb *gorm.DB, table string) error {
+	type legacyRow struct {
+		ID       string
+		Duration string
+	}
+
+	var lastID string
+	for {
+		var rows []legacyRow
+		if err := gdb.Table(table).Select("id", "duration").
+			Where("id > ? AND duration IS NOT NULL AND duration <> ''", lastID).
+			Order("id").Limit(200).Scan(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		for _, row := range rows {
+			lastID = row.ID
+			d, err := model.ParseDuration(row.Duration)
+			if err != nil {
+				slog.Warn("duration not parseable", "table", table, "id", row.ID, "value", row.Duration)
+				continue
+			}
+			if err := gdb.Table(table).Where("id = ?", row.ID).Updates(map[string]interface{}{
+				"duration_seconds": model.Seconds(d),
+				"duration":         "",
+			}).Error; err != nil {
+				return err
+			}
+		}
+	}
+}
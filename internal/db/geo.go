@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+
+	"gorm.io/gorm"
+
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/geo"
+)
+
+// WithinBoundingBox restricts q to rows whose latitude and longitude columns fall inside box;
+// rows without coordinates never match. Plain range comparisons keep the coordinate indexes
+// usable on SQLite and MySQL alike. Callers still check the exact distance of each match.
+func WithinBoundingBox(q *gorm.DB, box model.BoundingBox) *gorm.DB {
+	q = q.Where("latitude BETWEEN ? AND ?", box.MinLat, box.MaxLat)
+	if box.CrossesAntimeridian() {
+		return q.Where("(longitude >= ? OR longitude <= ?)", box.MinLon, box.MaxLon)
+	}
+	return q.Where("longitude BETWEEN ? AND ?", box.MinLon, box.MaxLon)
+}
+
+// GeocodeMissingCoordinates fills in latitude and longitude for rows of each model that have
+// a location but no position, such as rows written before positions were stored or while
+// geocoding was disabled. Locations the geocoder does not know are left without one.
+func GeocodeMissingCoordinates(ctx context.Context, gdb *gorm.DB, g geo.Geocoder, models ...interface{}) error {
+	if g == nil {
+		return nil
+	}
+	for _, m := range models {
+		stmt := &gorm.Statement{DB: gdb}
+		if err := stmt.Parse(m); err != nil {
+			return err
+		}
+		if err := geocodeMissing(ctx, gdb, g, stmt.Schema.Table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func geocodeMissing(ctx context.Context, gdb *gorm.DB, g geo.Geocoder, table string) error {
+	var rows []struct {
+		ID       string
+		Location string
+	}
+	err := gdb.Table(table).Select("id, location").
+		Where("latitude IS NULL AND location IS NOT NULL AND location <> ''").
+		Find(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	located := 0
+	for _, row := range rows {
+		p, err := g.Geocode(ctx, row.Location)
+		if errors.Is(err, geo.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = gdb.Table(table).Where("id = ?", row.ID).
+			Updates(map[string]interface{}{"latitude": p.Lat, "longitude": p.Lon}).Error
+		if err != nil {
+			return err
+		}
+		located++
+	}
+	if len(rows) > 0 {
+		slog.Info("geocoded legacy locations", "table", table, "located", located, "unknown", len(rows)-located)
+	}
+	return nil
+}
+
+// SelectWithinRadius returns the ids of the rows of q positioned within near, keeping q's
+// order or, with nearestFirst, closest first. Rows are prefiltered on the bounding box in
+// SQL and then checked with the haversine distance, so q should be selective enough for its
+// candidates to be read in one go.
+func SelectWithinRadius(q *gorm.DB, near model.Near, nearestFirst bool) ([]string, error) {
+	var rows []struct {
+		ID        string
+		Latitude  *float64
+		Longitude *float64
+	}
+	err := WithinBoundingBox(q, near.Center.BoundingBox(near.RadiusKm)).
+		Select("id, latitude, longitude").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		id       string
+		distance float64
+	}
+	var matches []match
+	for _, row := range rows {
+		p := model.FromLatLon(row.Latitude, row.Longitude)
+		if near.Contains(p) {
+			matches = append(matches, match{id: row.ID, distance: near.Center.DistanceKm(*p)})
+		}
+	}
+	if nearestFirst {
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].distance < matches[j].distance })
+	}
+
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.id
+	}
+	return ids, nil
+}
+
+// PageIDs returns the ids on the page starting at offset; a limit of zero or less means all.
+func PageIDs(ids []string, limit, offset int) []string {
+	if offset >= len(ids) {
+		return nil
+	}
+	ids = ids[offset:]
+	if limit > 0 && limit < len(ids) {
+		ids = ids[:limit]
+	}
+	return ids
+}
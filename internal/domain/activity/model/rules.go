@@ -0,0 +1,191 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopi.com/internal/domain/model"
+	trackModel "gopi.com/internal/domain/track/model"
+)
+
+// ErrHeldForReview is matched (via errors.Is) by HeldForReviewError.
+var ErrHeldForReview = errors.New("activity held for review")
+
+// ErrNotFlagged is returned when reviewing an activity that is not awaiting review.
+var ErrNotFlagged = errors.New("activity is not awaiting review")
+
+// ReviewStatus tracks whether an activity counts towards aggregates.
+type ReviewStatus string
+
+const (
+	ReviewStatusAccepted ReviewStatus = "accepted" // passed every rule
+	ReviewStatusFlagged  ReviewStatus = "flagged"  // held out of aggregates until reviewed
+	ReviewStatusApproved ReviewStatus = "approved" // flagged, then approved by staff
+	ReviewStatusRejected ReviewStatus = "rejected" // flagged, then rejected by staff
+)
+
+// Counts reports whether an activity with this status is included in totals and leaderboards.
+// Activities recorded before review existed have no status and count.
+func (s ReviewStatus) Counts() bool {
+	return s == "" || s == ReviewStatusAccepted || s == ReviewStatusApproved
+}
+
+// Rule names reported in violations.
+const (
+	RuleMaxSpeed      = "max_speed"
+	RuleDailyDistance = "daily_distance"
+	RuleDuration      = "duration_mismatch"
+	RuleTeleport      = "gps_teleport"
+)
+
+// Violation describes one rule an activity broke.
+type Violation struct {
+	Rule   string `json:"rule"`
+	Detail string `json:"detail"`
+}
+
+// HeldForReviewError is returned when an activity was saved but held out of aggregates.
+type HeldForReviewError struct {
+	Violations []Violation
+}
+
+func (e *HeldForReviewError) Error() string {
+	rules := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		rules = append(rules, v.Rule)
+	}
+	return fmt.Sprintf("%s: %s", ErrHeldForReview, strings.Join(rules, ", "))
+}
+
+func (e *HeldForReviewError) Is(target error) bool {
+	return target == ErrHeldForReview
+}
+
+// Limits are the plausibility bounds for one activity type.
+type Limits struct {
+	MaxSpeed         float64 // km/h, averaged over the activity
+	MinSpeed         float64 // km/h; slower averages mean the duration does not fit the distance
+	MaxDailyDistance float64 // km per user per UTC day
+	MaxJumpSpeed     float64 // km/h between consecutive GPS points
+}
+
+// DefaultLimits are keyed by activity name ("Walking", "Running", "Cycling").
+var DefaultLimits = map[string]Limits{
+	"Walking": {MaxSpeed: 9, MinSpeed: 1, MaxDailyDistance: 60, MaxJumpSpeed: 30},
+	"Running": {MaxSpeed: 25, MinSpeed: 3, MaxDailyDistance: 100, MaxJumpSpeed: 60},
+	"Cycling": {MaxSpeed: 60, MinSpeed: 5, MaxDailyDistance: 400, MaxJumpSpeed: 120},
+}
+
+// fallbackActivity supplies limits for activities without their own entry.
+const fallbackActivity = "Running"
+
+// minJumpMeters ignores short hops so ordinary GPS jitter between close samples is not a teleport.
+const minJumpMeters = 100.0
+
+// Run is the activity being checked.
+type Run struct {
+	Activity      string
+	Distance      float64           // km
+	Duration      time.Duration     // as reported, or moving time from the track
+	DistanceToday float64           // km already counted for the same user today
+	Track         *trackModel.Track // nil when no GPS file was uploaded
+}
+
+// Rules checks activities against per-activity limits.
+type Rules struct {
+	limits map[string]Limits
+}
+
+// NewRules returns a rule set using limits, or DefaultLimits when limits is nil.
+func NewRules(limits map[string]Limits) *Rules {
+	if limits == nil {
+		limits = DefaultLimits
+	}
+	return &Rules{limits: limits}
+}
+
+// LimitsFor returns the limits applied to activity.
+func (r *Rules) LimitsFor(activity string) Limits {
+	for name, l := range r.limits {
+		if strings.EqualFold(name, activity) {
+			return l
+		}
+	}
+	return r.limits[fallbackActivity]
+}
+
+// Check returns every rule run breaks; an empty result means the run is plausible.
+func (r *Rules) Check(run Run) []Violation {
+	if run.Distance <= 0 {
+		return nil
+	}
+	limits := r.LimitsFor(run.Activity)
+
+	var violations []Violation
+	if limits.MaxDailyDistance > 0 && run.DistanceToday+run.Distance > limits.MaxDailyDistance {
+		violations = append(violations, Violation{
+			Rule:   RuleDailyDistance,
+			Detail: fmt.Sprintf("%.2f km today exceeds the %.0f km daily limit", run.DistanceToday+run.Distance, limits.MaxDailyDistance),
+		})
+	}
+
+	if run.Duration <= 0 {
+		violations = append(violations, Violation{Rule: RuleDuration, Detail: fmt.Sprintf("%.2f km reported without a duration", run.Distance)})
+	} else {
+		speed := run.Distance / run.Duration.Hours()
+		if limits.MaxSpeed > 0 && speed > limits.MaxSpeed {
+			violations = append(violations, Violation{
+				Rule:   RuleMaxSpeed,
+				Detail: fmt.Sprintf("average %.1f km/h exceeds %.0f km/h", speed, limits.MaxSpeed),
+			})
+		}
+		if limits.MinSpeed > 0 && speed < limits.MinSpeed {
+			violations = append(violations, Violation{
+				Rule:   RuleDuration,
+				Detail: fmt.Sprintf("average %.2f km/h is too slow for %.2f km in %s", speed, run.Distance, model.FormatDuration(run.Duration)),
+			})
+		}
+	}
+
+	if run.Track != nil {
+		if jumps := teleports(run.Track, limits.MaxJumpSpeed); jumps > 0 {
+			violations = append(violations, Violation{
+				Rule:   RuleTeleport,
+				Detail: fmt.Sprintf("%d GPS jump(s) faster than %.0f km/h", jumps, limits.MaxJumpSpeed),
+			})
+		}
+	}
+	return violations
+}
+
+// teleports counts consecutive points whose implied speed is impossible for the activity.
+// Points without timestamps only count when they are more than a kilometre apart.
+func teleports(track *trackModel.Track, maxJumpSpeed float64) int {
+	var jumps int
+	for i := 1; i < len(track.Points); i++ {
+		prev, cur := track.Points[i-1], track.Points[i]
+		meters := trackModel.Haversine(prev, cur)
+		if meters < minJumpMeters {
+			continue
+		}
+		if prev.Time.IsZero() || cur.Time.IsZero() {
+			if meters > 1000 {
+				jumps++
+			}
+			continue
+		}
+		dt := cur.Time.Sub(prev.Time)
+		if dt <= 0 || (maxJumpSpeed > 0 && meters/1000/dt.Hours() > maxJumpSpeed) {
+			jumps++
+		}
+	}
+	return jumps
+}
+
+// StartOfDay returns midnight UTC of t's day, the window used for daily limits.
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
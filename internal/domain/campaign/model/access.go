@@ -0,0 +1,130 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+var (
+	// ErrInviteRequired is returned when a user tries to join a private campaign without an
+	// invite or an approved join request.
+	ErrInviteRequired = errors.New("this campaign is invite-only")
+	// ErrInvalidInvite is returned for invite tokens that are malformed, forged or revoked.
+	ErrInvalidInvite = errors.New("invalid campaign invite")
+	// ErrInviteExpired is returned when an invite is used after its expiry time.
+	ErrInviteExpired = errors.New("campaign invite has expired")
+	// ErrInviteUsedUp is returned when an invite has already been used its maximum number of times.
+	ErrInviteUsedUp = errors.New("campaign invite has no uses left")
+	// ErrInviteEmailMismatch is returned when an email invitation is redeemed by another address.
+	ErrInviteEmailMismatch = errors.New("this invite was sent to a different email address")
+	// ErrAlreadyMember is returned when a user who is already a member joins or asks to join.
+	ErrAlreadyMember = errors.New("user is already a member of this campaign")
+	// ErrJoinRequestNotNeeded is returned when a join request is made for a campaign anyone can join.
+	ErrJoinRequestNotNeeded = errors.New("this campaign can be joined directly")
+	// ErrJoinRequestPending is returned when the user already has a request awaiting a decision.
+	ErrJoinRequestPending = errors.New("a join request is already pending for this campaign")
+	// ErrJoinRequestNotFound is returned when a join request does not exist in the campaign.
+	ErrJoinRequestNotFound = errors.New("join request not found")
+	// ErrJoinRequestDecided is returned when an approved or rejected request is decided again.
+	ErrJoinRequestDecided = errors.New("join request has already been decided")
+	// ErrInvitesNotEnabled is returned by invite and join request operations when the service
+	// has no invite store.
+	ErrInvitesNotEnabled = errors.New("campaign invites are not enabled")
+)
+
+// CampaignVisibility controls who can find and join a campaign.
+type CampaignVisibility string
+
+const (
+	CampaignVisibilityPublic   CampaignVisibility = "public"   // listed, and anyone can join
+	CampaignVisibilityUnlisted CampaignVisibility = "unlisted" // anyone with the link can join, but left out of listings
+	CampaignVisibilityPrivate  CampaignVisibility = "private"  // invite-only and hidden from non-members
+)
+
+// Valid reports whether v is a known visibility.
+func (v CampaignVisibility) Valid() bool {
+	switch v {
+	case CampaignVisibilityPublic, CampaignVisibilityUnlisted, CampaignVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// EffectiveVisibility treats campaigns created before visibility existed as public.
+func (c *Campaign) EffectiveVisibility() CampaignVisibility {
+	if c.Visibility == "" {
+		return CampaignVisibilityPublic
+	}
+	return c.Visibility
+}
+
+// HasMember reports whether userID is one of the campaign's members.
+func (c *Campaign) HasMember(userID string) bool {
+	return HasUser(c.Members, userID)
+}
+
+// VisibleTo reports whether userID may see the campaign. Private campaigns are only visible to
+// their owner and members; userID is empty for anonymous viewers.
+func (c *Campaign) VisibleTo(userID string) bool {
+	if c.EffectiveVisibility() != CampaignVisibilityPrivate {
+		return true
+	}
+	return userID != "" && (c.OwnerID == userID || c.HasMember(userID))
+}
+
+// CanJoinWithoutInvite checks that userID may join directly, which private campaigns only
+// allow their owner to do.
+func (c *Campaign) CanJoinWithoutInvite(userID string) error {
+	if c.EffectiveVisibility() == CampaignVisibilityPrivate && c.OwnerID != userID {
+		return ErrInviteRequired
+	}
+	return nil
+}
+
+// CampaignInvite lets people join a campaign through a signed link. Link invites can be shared
+// and used up to MaxUses times; email invites are bound to Email and used once.
+type CampaignInvite struct {
+	model.Base
+	CampaignID string     `json:"campaign_id"`
+	CreatedBy  string     `json:"created_by"`
+	Email      string     `json:"email,omitempty"` // only this address can redeem the invite when set
+	MaxUses    int        `json:"max_uses"`        // 0 allows unlimited uses
+	Uses       int        `json:"uses"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Usable checks that the invite can still be redeemed at now.
+func (i *CampaignInvite) Usable(now time.Time) error {
+	switch {
+	case i.RevokedAt != nil:
+		return ErrInvalidInvite
+	case !now.Before(i.ExpiresAt):
+		return ErrInviteExpired
+	case i.MaxUses > 0 && i.Uses >= i.MaxUses:
+		return ErrInviteUsedUp
+	}
+	return nil
+}
+
+// JoinRequestStatus is where a join request is in the owner's review.
+type JoinRequestStatus string
+
+const (
+	JoinRequestPending  JoinRequestStatus = "pending"
+	JoinRequestApproved JoinRequestStatus = "approved"
+	JoinRequestRejected JoinRequestStatus = "rejected"
+)
+
+// CampaignJoinRequest asks the owner of a private campaign to let a user in.
+type CampaignJoinRequest struct {
+	model.Base
+	CampaignID string            `json:"campaign_id"`
+	UserID     string            `json:"user_id"`
+	Message    string            `json:"message"`
+	Status     JoinRequestStatus `json:"status"`
+	DecidedBy  string            `json:"decided_by,omitempty"`
+	DecidedAt  *time.Time        `json:"decided_at,omitempty"`
+}
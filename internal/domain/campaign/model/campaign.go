@@ -23,51 +23,76 @@ const (
 
 type Campaign struct {
 	model.Base
-	Name               string       `json:"name"`                 // name
-	Description        string       `json:"description"`          // description
-	Condition          string       `json:"condition"`            // condition
-	Mode               CampaignMode `json:"mode"`                 // mode
-	Goal               string       `json:"goal"`                 // goal
-	Activity           Activity     `json:"activity"`             // activity
-	AcceptTac          bool         `json:"accept_tac"`           // accept_tac
-	Location           string       `json:"location"`             // location
-	MoneyRaised        float64      `json:"money_raised"`         // money_raised
-	TargetAmount       float64      `json:"target_amount"`        // target_amount
-	TargetAmountPerKm  float64      `json:"target_amount_per_km"` // target_amount_per_km
-	DistanceToCover    float64      `json:"distance_to_cover"`    // distance_to_cover
-	DistanceCovered    float64      `json:"distance_covered"`     // distance_covered
-	StartDuration      string       `json:"start_duration"`       // start_duration
-	EndDuration        string       `json:"end_duration"`         // end_duration
-	OwnerID            string       `json:"owner_id"`             // owner
-	Slug               string       `json:"slug"`                 // slug
-	WorkoutImg         string       `json:"workout_img"`          // workout_img
-	// ManyToMany relationships - actual objects like Django
-	Members  []interface{} `json:"members"`  // members (User objects)
-	Sponsors []interface{} `json:"sponsors"` // sponsors (User objects)
+	Name              string             `json:"name"`                  // name
+	Description       string             `json:"description"`           // description
+	Condition         string             `json:"condition"`             // condition
+	Mode              CampaignMode       `json:"mode"`                  // mode
+	Goal              string             `json:"goal"`                  // goal
+	Activity          Activity           `json:"activity"`              // activity
+	AcceptTac         bool               `json:"accept_tac"`            // accept_tac
+	Location          string             `json:"location"`              // location
+	Coordinates       *model.GeoPoint    `json:"coordinates,omitempty"` // position of Location, nil when unknown
+	MoneyRaised       float64            `json:"money_raised"`          // money_raised
+	TargetAmount      float64            `json:"target_amount"`         // target_amount
+	TargetAmountPerKm float64            `json:"target_amount_per_km"`  // target_amount_per_km
+	DistanceToCover   float64            `json:"distance_to_cover"`     // distance_to_cover
+	DistanceCovered   float64            `json:"distance_covered"`      // distance_covered
+	StartDuration     string             `json:"start_duration"`        // start_duration (legacy free-form)
+	EndDuration       string             `json:"end_duration"`          // end_duration (legacy free-form)
+	StartsAt          *time.Time         `json:"starts_at,omitempty"`   // parsed start of the campaign window
+	EndsAt            *time.Time         `json:"ends_at,omitempty"`     // parsed end of the campaign window
+	Status            CampaignStatus     `json:"status"`                // lifecycle state
+	AchievedAt        *time.Time         `json:"achieved_at,omitempty"` // when the distance or funding goal was met
+	ClosedAt          *time.Time         `json:"closed_at,omitempty"`   // when standings and sponsor obligations were frozen
+	Visibility        CampaignVisibility `json:"visibility"`            // who can find and join; empty means public
+	OwnerID           string             `json:"owner_id"`              // owner
+	Slug              string             `json:"slug"`                  // slug
+	WorkoutImg        string             `json:"workout_img"`           // workout_img
+	// ManyToMany relationships, loaded from the junction tables
+	Members  []UserSummary `json:"members"`  // members
+	Sponsors []UserSummary `json:"sponsors"` // sponsors
 }
 
 type CampaignRunner struct {
 	model.Base
-	CampaignID      string    `json:"campaign_id"`      // campaign
-	DistanceCovered float64   `json:"distance_covered"` // distance_covered
-	Duration        string    `json:"duration"`         // duration
-	MoneyRaised     float64   `json:"money_raised"`     // money_raised
-	CoverImage      string    `json:"cover_image"`      // cover_image
-	Activity        string    `json:"activity"`         // activity
-	OwnerID         string    `json:"owner_id"`         // owner
-	DateJoined      time.Time `json:"date_joined"`      // date_joined
+	CampaignID      string        `json:"campaign_id"`      // campaign
+	DistanceCovered float64       `json:"distance_covered"` // distance_covered
+	Duration        time.Duration `json:"duration"`         // duration, stored as whole seconds
+	MoneyRaised     float64       `json:"money_raised"`     // money_raised
+	CoverImage      string        `json:"cover_image"`      // cover_image
+	Activity        string        `json:"activity"`         // activity
+	OwnerID         string        `json:"owner_id"`         // owner
+	DateJoined      time.Time     `json:"date_joined"`      // date_joined
+	TrackURL        string        `json:"track_url"`        // latest uploaded GPS track (GeoJSON)
+	ElevationGain   float64       `json:"elevation_gain"`   // meters climbed across uploaded tracks
 }
 
 type SponsorCampaign struct {
 	model.Base
-	CampaignID    string  `json:"campaign_id"`    // campaign
-	Distance      float64 `json:"distance"`       // distance
-	AmountPerKm   float64 `json:"amount_per_km"`  // amount_per_km
-	TotalAmount   float64 `json:"total_amount"`   // total_amount
-	BrandImg      string  `json:"brand_img"`      // brand_img
-	VideoUrl      string  `json:"video_url"`      // video_url
-	// ManyToMany relationship - actual objects like Django
-	Sponsors []interface{} `json:"sponsors"` // sponsor (User objects via ManyToManyField)
+	CampaignID  string  `json:"campaign_id"`   // campaign
+	Distance    float64 `json:"distance"`      // distance
+	AmountPerKm float64 `json:"amount_per_km"` // amount_per_km
+	TotalAmount float64 `json:"total_amount"`  // total_amount
+	BrandImg    string  `json:"brand_img"`     // brand_img
+	VideoUrl    string  `json:"video_url"`     // video_url
+	// Status is pending for slots copied from a template until a sponsor confirms them; empty
+	// means confirmed.
+	Status   SponsorshipStatus `json:"status"`
+	Sponsors []UserSummary     `json:"sponsors"` // the users paying for the sponsorship
+}
+
+// SponsorshipStatus says whether a sponsorship counts towards the campaign.
+type SponsorshipStatus string
+
+const (
+	SponsorshipPending   SponsorshipStatus = "pending"   // a slot waiting for its sponsor; not in money raised
+	SponsorshipConfirmed SponsorshipStatus = "confirmed" // pledged and counted in money raised
+)
+
+// Confirmed reports whether the sponsorship counts. Sponsorships created before slots existed
+// have no status and are confirmed.
+func (sc *SponsorCampaign) Confirmed() bool {
+	return sc.Status != SponsorshipPending
 }
 
 // CalculateTotalAmount calculates the total amount based on distance and amount per km
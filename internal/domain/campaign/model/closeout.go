@@ -0,0 +1,86 @@
+package model
+
+import (
+	"math"
+	"sort"
+
+	"gopi.com/internal/domain/model"
+)
+
+// CampaignStanding is a runner's position on the leaderboard frozen when the campaign closed.
+type CampaignStanding struct {
+	model.Base
+	CampaignID      string  `json:"campaign_id"`
+	Rank            int     `json:"rank"`
+	UserID          string  `json:"user_id"`
+	DistanceCovered float64 `json:"distance_covered"`
+	MoneyRaised     float64 `json:"money_raised"`
+	Activity        string  `json:"activity"`
+}
+
+// SponsorObligation is what a sponsorship owes once the campaign closes: AmountPerKm for every
+// kilometre the campaign actually covered, up to the pledged Distance.
+type SponsorObligation struct {
+	model.Base
+	CampaignID        string        `json:"campaign_id"`
+	SponsorCampaignID string        `json:"sponsor_campaign_id"`
+	PledgedDistance   float64       `json:"pledged_distance"`
+	CreditedDistance  float64       `json:"credited_distance"`
+	AmountPerKm       float64       `json:"amount_per_km"`
+	Amount            float64       `json:"amount"`
+	Sponsors          []UserSummary `json:"sponsors"` // copied from the SponsorCampaign
+}
+
+// GoalReached reports whether the distance or the funding target has been met.
+// A target of zero means the campaign has no goal of that kind.
+func (c *Campaign) GoalReached() bool {
+	if c.DistanceToCover > 0 && c.DistanceCovered >= c.DistanceToCover {
+		return true
+	}
+	return c.TargetAmount > 0 && c.MoneyRaised >= c.TargetAmount
+}
+
+// Obligation works out what this sponsorship owes for distanceCovered kilometres.
+func (sc *SponsorCampaign) Obligation(distanceCovered float64) *SponsorObligation {
+	credited := math.Max(0, math.Min(distanceCovered, sc.Distance))
+	return &SponsorObligation{
+		CampaignID:        sc.CampaignID,
+		SponsorCampaignID: sc.ID,
+		PledgedDistance:   sc.Distance,
+		CreditedDistance:  credited,
+		AmountPerKm:       sc.AmountPerKm,
+		Amount:            math.Round(credited*sc.AmountPerKm*100) / 100,
+		Sponsors:          sc.Sponsors,
+	}
+}
+
+// BuildStandings totals runs per user and ranks them by distance, then money raised.
+func BuildStandings(campaignID string, runners []*CampaignRunner) []*CampaignStanding {
+	byUser := make(map[string]*CampaignStanding)
+	var standings []*CampaignStanding
+	for _, runner := range runners {
+		st, ok := byUser[runner.OwnerID]
+		if !ok {
+			st = &CampaignStanding{CampaignID: campaignID, UserID: runner.OwnerID, Activity: runner.Activity}
+			byUser[runner.OwnerID] = st
+			standings = append(standings, st)
+		}
+		st.DistanceCovered += runner.DistanceCovered
+		st.MoneyRaised += runner.MoneyRaised
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.DistanceCovered != b.DistanceCovered {
+			return a.DistanceCovered > b.DistanceCovered
+		}
+		if a.MoneyRaised != b.MoneyRaised {
+			return a.MoneyRaised > b.MoneyRaised
+		}
+		return a.UserID < b.UserID
+	})
+	for i, st := range standings {
+		st.Rank = i + 1
+	}
+	return standings
+}
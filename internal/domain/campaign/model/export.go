@@ -0,0 +1,19 @@
+package model
+
+import "errors"
+
+// ErrInvalidExportFormat is returned when an export is requested in a format we cannot write.
+var ErrInvalidExportFormat = errors.New("export format must be csv or xlsx")
+
+// ExportFormat is the file format of a campaign results export.
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+// Valid reports whether f is a supported export format.
+func (f ExportFormat) Valid() bool {
+	return f == ExportFormatCSV || f == ExportFormatXLSX
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type CampaignStatus string
+
+const (
+	CampaignStatusDraft     CampaignStatus = "draft"
+	CampaignStatusScheduled CampaignStatus = "scheduled"
+	CampaignStatusActive    CampaignStatus = "active"
+	CampaignStatusCompleted CampaignStatus = "completed"
+	CampaignStatusCancelled CampaignStatus = "cancelled"
+)
+
+var (
+	// ErrInvalidCampaignState is returned when an operation does not fit the campaign's current state.
+	ErrInvalidCampaignState = errors.New("campaign state does not allow this operation")
+	// ErrInvalidCampaignSchedule is returned for unparseable or inconsistent start/end times.
+	ErrInvalidCampaignSchedule = errors.New("invalid campaign schedule")
+)
+
+// campaignTransitions lists the allowed state changes. Completed and cancelled are terminal.
+var campaignTransitions = map[CampaignStatus][]CampaignStatus{
+	CampaignStatusDraft:     {CampaignStatusScheduled, CampaignStatusActive, CampaignStatusCancelled},
+	CampaignStatusScheduled: {CampaignStatusActive, CampaignStatusCancelled},
+	CampaignStatusActive:    {CampaignStatusCompleted, CampaignStatusCancelled},
+}
+
+// CanTransitionTo reports whether a campaign may move from s to next.
+func (s CampaignStatus) CanTransitionTo(next CampaignStatus) bool {
+	for _, allowed := range campaignTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal reports whether no further transitions are possible.
+func (s CampaignStatus) IsTerminal() bool {
+	return s == CampaignStatusCompleted || s == CampaignStatusCancelled
+}
+
+// ScheduledStatus returns the state implied by the start/end window at now.
+// Campaigns without a start are active immediately; campaigns without an end never complete by time.
+func (c *Campaign) ScheduledStatus(now time.Time) CampaignStatus {
+	if c.EndsAt != nil && !now.Before(*c.EndsAt) {
+		return CampaignStatusCompleted
+	}
+	if c.StartsAt != nil && now.Before(*c.StartsAt) {
+		return CampaignStatusScheduled
+	}
+	return CampaignStatusActive
+}
+
+// StatusAt returns the effective state at now. Draft and terminal states are sticky; scheduled and
+// active campaigns follow their window even if the scheduler has not persisted the change yet.
+// Rows created before the lifecycle existed have an empty status and are treated the same way.
+func (c *Campaign) StatusAt(now time.Time) CampaignStatus {
+	switch c.Status {
+	case CampaignStatusDraft, CampaignStatusCompleted, CampaignStatusCancelled:
+		return c.Status
+	}
+	return c.ScheduledStatus(now)
+}
+
+// CanJoin checks that members may still sign up: before the start or while running.
+func (c *Campaign) CanJoin(now time.Time) error {
+	switch st := c.StatusAt(now); st {
+	case CampaignStatusScheduled, CampaignStatusActive:
+		return nil
+	default:
+		return stateError("join", st)
+	}
+}
+
+// CanRecordActivity checks that runs may be started or logged, which is only while active.
+func (c *Campaign) CanRecordActivity(now time.Time) error {
+	if st := c.StatusAt(now); st != CampaignStatusActive {
+		return stateError("record activity on", st)
+	}
+	return nil
+}
+
+func stateError(action string, st CampaignStatus) error {
+	return fmt.Errorf("%w: cannot %s a %s campaign", ErrInvalidCampaignState, action, st)
+}
+
+// campaignTimeLayouts are the formats accepted for start/end values, including those seen in
+// legacy free-form StartDuration/EndDuration strings.
+var campaignTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"02/01/2006",
+	"2 Jan 2006",
+	"Jan 2, 2006",
+	"January 2, 2006",
+}
+
+// ParseCampaignTime parses a start/end value. Empty strings yield nil. Date-only values are
+// interpreted in UTC at midnight.
+func ParseCampaignTime(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range campaignTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unrecognised time %q", ErrInvalidCampaignSchedule, value)
+}
+
+// SetSchedule parses start and end into StartsAt/EndsAt, keeping the raw strings for clients
+// that still read start_duration/end_duration.
+func (c *Campaign) SetSchedule(start, end string) error {
+	startsAt, err := ParseCampaignTime(start)
+	if err != nil {
+		return err
+	}
+	endsAt, err := ParseCampaignTime(end)
+	if err != nil {
+		return err
+	}
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return fmt.Errorf("%w: end must be after start", ErrInvalidCampaignSchedule)
+	}
+	c.StartDuration, c.EndDuration = start, end
+	c.StartsAt, c.EndsAt = startsAt, endsAt
+	return nil
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+// MaxMilestones caps how many milestones a campaign can have.
+const MaxMilestones = 20
+
+var (
+	// ErrInvalidMilestone is returned for milestones with an unknown metric, or without exactly
+	// one of a percentage (above 0, up to 100) or a positive mark.
+	ErrInvalidMilestone = errors.New("invalid campaign milestone")
+	// ErrTooManyMilestones is returned when more than MaxMilestones are configured.
+	ErrTooManyMilestones = fmt.Errorf("a campaign can have at most %d milestones", MaxMilestones)
+	// ErrMilestonesNotEnabled is returned by milestone operations when the service has no
+	// milestone store.
+	ErrMilestonesNotEnabled = errors.New("campaign milestones are not enabled")
+)
+
+// MilestoneMetric is the campaign total a milestone watches.
+type MilestoneMetric string
+
+const (
+	MilestoneMetricDistance MilestoneMetric = "distance" // kilometres covered, against DistanceToCover
+	MilestoneMetricMoney    MilestoneMetric = "money"    // money raised, against TargetAmount
+)
+
+// Valid reports whether m is a known metric.
+func (m MilestoneMetric) Valid() bool {
+	return m == MilestoneMetricDistance || m == MilestoneMetricMoney
+}
+
+// CampaignMilestone is a mark on the way to a campaign's goal. It is either a share of the
+// campaign's target (Percent) or a fixed amount (Value), and is reached once, the first time
+// the campaign total gets there.
+type CampaignMilestone struct {
+	model.Base
+	CampaignID string          `json:"campaign_id"`
+	Metric     MilestoneMetric `json:"metric"`
+	Percent    float64         `json:"percent,omitempty"` // share of the target, 0 for a fixed mark
+	Value      float64         `json:"value,omitempty"`   // km or amount, when Percent is 0
+	ReachedAt  *time.Time      `json:"reached_at,omitempty"`
+}
+
+// Validate checks the metric and that exactly one of Percent and Value is set.
+func (m *CampaignMilestone) Validate() error {
+	switch {
+	case !m.Metric.Valid():
+		return fmt.Errorf("%w: metric must be %q or %q", ErrInvalidMilestone, MilestoneMetricDistance, MilestoneMetricMoney)
+	case m.Percent < 0 || m.Percent > 100 || m.Value < 0:
+		return fmt.Errorf("%w: percent must be between 0 and 100 and value positive", ErrInvalidMilestone)
+	case (m.Percent > 0) == (m.Value > 0):
+		return fmt.Errorf("%w: set either percent or value", ErrInvalidMilestone)
+	}
+	return nil
+}
+
+// Same reports whether o is configured like m.
+func (m *CampaignMilestone) Same(o *CampaignMilestone) bool {
+	return m.Metric == o.Metric && m.Percent == o.Percent && m.Value == o.Value
+}
+
+// Threshold is the campaign total at which the milestone is reached. Percentage milestones
+// follow the current target and have no threshold while the campaign has none.
+func (m *CampaignMilestone) Threshold(c *Campaign) float64 {
+	if m.Percent == 0 {
+		return m.Value
+	}
+	target := c.DistanceToCover
+	if m.Metric == MilestoneMetricMoney {
+		target = c.TargetAmount
+	}
+	return target * m.Percent / 100
+}
+
+// Progress is the campaign total the milestone watches.
+func (m *CampaignMilestone) Progress(c *Campaign) float64 {
+	if m.Metric == MilestoneMetricMoney {
+		return c.MoneyRaised
+	}
+	return c.DistanceCovered
+}
+
+// ReachedBy reports whether the campaign's totals have got to the milestone.
+func (m *CampaignMilestone) ReachedBy(c *Campaign) bool {
+	threshold := m.Threshold(c)
+	return threshold > 0 && m.Progress(c) >= threshold
+}
+
+// Label describes the milestone, e.g. "50% of the distance goal" or "100 km covered".
+func (m *CampaignMilestone) Label() string {
+	if m.Percent > 0 {
+		goal := "distance goal"
+		if m.Metric == MilestoneMetricMoney {
+			goal = "fundraising target"
+		}
+		return strconv.FormatFloat(m.Percent, 'f', -1, 64) + "% of the " + goal
+	}
+	if m.Metric == MilestoneMetricMoney {
+		return strconv.FormatFloat(m.Value, 'f', 2, 64) + " raised"
+	}
+	return strconv.FormatFloat(m.Value, 'f', -1, 64) + " km covered"
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	activityModel "gopi.com/internal/domain/activity/model"
+	"gopi.com/internal/domain/model"
+)
+
+// CampaignRun is one activity recorded against a campaign runner. Runs that pass the anti-cheat
+// rules are added to the runner and campaign totals straight away; flagged runs wait for staff
+// review and are only added once approved.
+type CampaignRun struct {
+	model.Base
+	CampaignID    string                     `json:"campaign_id"`
+	RunnerID      string                     `json:"runner_id"`
+	OwnerID       string                     `json:"owner_id"`
+	Activity      string                     `json:"activity"`
+	Distance      float64                    `json:"distance"` // km
+	Duration      time.Duration              `json:"duration"`
+	MoneyRaised   float64                    `json:"money_raised"`
+	TrackURL      string                     `json:"track_url"`
+	ElevationGain float64                    `json:"elevation_gain"`
+	Status        activityModel.ReviewStatus `json:"status"`
+	Violations    []activityModel.Violation  `json:"violations"`
+	ReviewedBy    string                     `json:"reviewed_by"`
+	ReviewedAt    *time.Time                 `json:"reviewed_at"`
+}
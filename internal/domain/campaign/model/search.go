@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+// CampaignSort is the order campaign search results are returned in.
+type CampaignSort string
+
+const (
+	CampaignSortNewest        CampaignSort = "newest"
+	CampaignSortMostFunded    CampaignSort = "most_funded"
+	CampaignSortClosestToGoal CampaignSort = "closest_to_goal" // furthest along towards their goal first
+	CampaignSortNearest       CampaignSort = "nearest"         // closest to Near.Center first; requires Near
+)
+
+// CampaignFilter selects a page of campaigns. Zero-valued fields do not filter.
+type CampaignFilter struct {
+	Query          string // words matched against name, description and location
+	Activity       Activity
+	Mode           CampaignMode
+	Location       string         // substring of the location, case-insensitive
+	Status         CampaignStatus // campaigns created before the lifecycle count as active
+	OwnerID        string
+	ExcludeOwnerID string
+	From           *time.Time // campaigns whose window ends at or after From
+	To             *time.Time // campaigns whose window starts at or before To
+	MinTarget      *float64   // target amount bounds, inclusive
+	MaxTarget      *float64
+	Near           *model.Near  // campaigns positioned within the radius; others are excluded
+	Listed         bool         // only public campaigns, plus others ViewerID owns or is a member of
+	ViewerID       string       // the user listings are shown to; empty for anonymous viewers
+	Sort           CampaignSort // defaults to newest
+	Limit          int
+	Offset         int
+}
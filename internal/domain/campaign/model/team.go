@@ -0,0 +1,96 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+var (
+	// ErrAlreadyInTeam is returned when a user joins or creates a team while already in one
+	// for the same campaign.
+	ErrAlreadyInTeam = errors.New("user is already in a team for this campaign")
+	// ErrNotTeamMember is returned when a team operation needs the user to be in the team.
+	ErrNotTeamMember = errors.New("user is not a member of this team")
+	// ErrNotTeamCaptain is returned when a captain-only operation is attempted by another member.
+	ErrNotTeamCaptain = errors.New("only the team captain can do this")
+	// ErrInvalidInviteCode is returned when no team in the campaign has the given invite code.
+	ErrInvalidInviteCode = errors.New("invalid team invite code")
+	// ErrTeamNameTaken is returned when another team in the campaign already has the name.
+	ErrTeamNameTaken = errors.New("a team with this name already exists in this campaign")
+	// ErrTeamsNotEnabled is returned by team operations when the service has no team store.
+	ErrTeamsNotEnabled = errors.New("teams are not enabled")
+)
+
+// CanChangeTeams checks that teams may be created, joined or left, which is only until the
+// campaign completes, so the team leaderboard stays fixed once it ends.
+func (c *Campaign) CanChangeTeams(now time.Time) error {
+	switch st := c.StatusAt(now); st {
+	case CampaignStatusScheduled, CampaignStatusActive:
+		return nil
+	default:
+		return stateError("change teams in", st)
+	}
+}
+
+// CampaignTeam groups campaign members who compete together. The captain manages the team
+// and its invite code; anyone with the code can join.
+type CampaignTeam struct {
+	model.Base
+	CampaignID string   `json:"campaign_id"`
+	Name       string   `json:"name"`
+	CaptainID  string   `json:"captain_id"`
+	InviteCode string   `json:"invite_code"`
+	Members    []string `json:"members"` // member user IDs, longest-standing first
+}
+
+// HasMember reports whether userID is in the team.
+func (t *CampaignTeam) HasMember(userID string) bool {
+	for _, member := range t.Members {
+		if member == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// CampaignTeamMember is a user's place in a team. A user can be in at most one team per campaign.
+type CampaignTeamMember struct {
+	TeamID     string    `json:"team_id"`
+	CampaignID string    `json:"campaign_id"`
+	UserID     string    `json:"user_id"`
+	JoinedAt   time.Time `json:"joined_at"`
+}
+
+// TeamStanding is a team's position on the team leaderboard, totalled from its members'
+// campaign runners.
+type TeamStanding struct {
+	TeamID          string        `json:"team_id"`
+	Name            string        `json:"name"`
+	CaptainID       string        `json:"captain_id"`
+	Rank            int           `json:"rank"`
+	MemberCount     int           `json:"member_count"`
+	DistanceCovered float64       `json:"distance_covered"`
+	MoneyRaised     float64       `json:"money_raised"`
+	Duration        time.Duration `json:"duration"`
+}
+
+// RankTeamStandings orders teams by distance, then money raised, and numbers them from 1.
+func RankTeamStandings(standings []*TeamStanding) []*TeamStanding {
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.DistanceCovered != b.DistanceCovered {
+			return a.DistanceCovered > b.DistanceCovered
+		}
+		if a.MoneyRaised != b.MoneyRaised {
+			return a.MoneyRaised > b.MoneyRaised
+		}
+		return a.Name < b.Name
+	})
+	for i, st := range standings {
+		st.Rank = i + 1
+	}
+	return standings
+}
@@ -0,0 +1,205 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+var (
+	// ErrTemplateNotFound is returned when a campaign template does not exist.
+	ErrTemplateNotFound = errors.New("campaign template not found")
+	// ErrInvalidRecurrence is returned for unknown recurrence intervals or schedules that cannot
+	// work out when the next edition starts.
+	ErrInvalidRecurrence = errors.New("invalid campaign recurrence")
+	// ErrTemplatesNotEnabled is returned by template operations when the service has no template
+	// store.
+	ErrTemplatesNotEnabled = errors.New("campaign templates are not enabled")
+	// ErrSponsorshipNotFound is returned when a sponsorship does not exist in the campaign.
+	ErrSponsorshipNotFound = errors.New("sponsorship not found")
+	// ErrSponsorshipNotPending is returned when a sponsorship that is already confirmed is
+	// confirmed again.
+	ErrSponsorshipNotPending = errors.New("sponsorship is not awaiting confirmation")
+	// ErrNotSlotSponsor is returned when someone other than the sponsors named on a slot
+	// confirms it.
+	ErrNotSlotSponsor = errors.New("this sponsorship slot is reserved for another sponsor")
+)
+
+// Recurrence is how often a template's schedule creates a new edition.
+type Recurrence string
+
+const (
+	RecurrenceNone    Recurrence = ""
+	RecurrenceWeekly  Recurrence = "weekly"
+	RecurrenceMonthly Recurrence = "monthly"
+)
+
+// Valid reports whether r is a known recurrence.
+func (r Recurrence) Valid() bool {
+	switch r {
+	case RecurrenceNone, RecurrenceWeekly, RecurrenceMonthly:
+		return true
+	}
+	return false
+}
+
+// Next returns the start of the edition after one starting at t.
+func (r Recurrence) Next(t time.Time) time.Time {
+	switch r {
+	case RecurrenceWeekly:
+		return t.AddDate(0, 0, 7)
+	case RecurrenceMonthly:
+		return t.AddDate(0, 1, 0)
+	}
+	return t
+}
+
+// DefaultRecurrenceLead is how long before its start a recurring edition is created when the
+// template does not say.
+const DefaultRecurrenceLead = 7 * 24 * time.Hour
+
+// CampaignTemplate is a reusable campaign blueprint. New campaigns are created from it with
+// their schedule shifted to a new start, and a recurring template creates the next edition on
+// its own.
+type CampaignTemplate struct {
+	model.Base
+	OwnerID          string `json:"owner_id"`
+	SourceCampaignID string `json:"source_campaign_id,omitempty"` // the campaign it was saved from
+
+	// Campaign fields copied to every edition
+	Name              string             `json:"name"`
+	Description       string             `json:"description"`
+	Condition         string             `json:"condition"`
+	Mode              CampaignMode       `json:"mode"`
+	Goal              string             `json:"goal"`
+	Activity          Activity           `json:"activity"`
+	Location          string             `json:"location"`
+	Coordinates       *model.GeoPoint    `json:"coordinates,omitempty"`
+	Visibility        CampaignVisibility `json:"visibility"`
+	TargetAmount      float64            `json:"target_amount"`
+	TargetAmountPerKm float64            `json:"target_amount_per_km"`
+	DistanceToCover   float64            `json:"distance_to_cover"`
+	WorkoutImg        string             `json:"workout_img"`
+
+	// StartsAt is the start of the source campaign; date shifts are measured from it.
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	// Length is the time from start to end of each edition, 0 for open-ended campaigns.
+	Length time.Duration `json:"length"`
+
+	SponsorSlots []TemplateSponsorSlot `json:"sponsor_slots"`
+	Milestones   []TemplateMilestone   `json:"milestones"`
+
+	// Recurring schedule
+	Recurrence   Recurrence    `json:"recurrence"`
+	NextStartsAt *time.Time    `json:"next_starts_at,omitempty"` // start of the next edition the schedule creates
+	Lead         time.Duration `json:"lead"`                     // how long before NextStartsAt it is created
+	Editions     int           `json:"editions"`                 // campaigns created from the template so far
+}
+
+// TemplateSponsorSlot is a sponsorship copied to each edition as a pending slot.
+type TemplateSponsorSlot struct {
+	Distance    float64       `json:"distance"`
+	AmountPerKm float64       `json:"amount_per_km"`
+	BrandImg    string        `json:"brand_img,omitempty"`
+	VideoUrl    string        `json:"video_url,omitempty"`
+	Sponsors    []UserSummary `json:"sponsors,omitempty"` // sponsors invited to fill the slot; empty lets anyone
+}
+
+// TemplateMilestone is a milestone copied to each edition.
+type TemplateMilestone struct {
+	Metric  MilestoneMetric `json:"metric"`
+	Percent float64         `json:"percent,omitempty"`
+	Value   float64         `json:"value,omitempty"`
+}
+
+// NewTemplateFromCampaign copies the reusable parts of c: its settings, schedule length,
+// sponsorships as slots and milestone configuration. Progress, members and dates are not copied.
+func NewTemplateFromCampaign(c *Campaign, sponsorships []*SponsorCampaign, milestones []*CampaignMilestone) *CampaignTemplate {
+	t := &CampaignTemplate{
+		OwnerID:           c.OwnerID,
+		SourceCampaignID:  c.ID,
+		Name:              c.Name,
+		Description:       c.Description,
+		Condition:         c.Condition,
+		Mode:              c.Mode,
+		Goal:              c.Goal,
+		Activity:          c.Activity,
+		Location:          c.Location,
+		Coordinates:       c.Coordinates,
+		Visibility:        c.Visibility,
+		TargetAmount:      c.TargetAmount,
+		TargetAmountPerKm: c.TargetAmountPerKm,
+		DistanceToCover:   c.DistanceToCover,
+		WorkoutImg:        c.WorkoutImg,
+		StartsAt:          c.StartsAt,
+		SponsorSlots:      []TemplateSponsorSlot{},
+		Milestones:        []TemplateMilestone{},
+	}
+	if c.StartsAt != nil && c.EndsAt != nil {
+		t.Length = c.EndsAt.Sub(*c.StartsAt)
+	}
+	for _, sc := range sponsorships {
+		t.SponsorSlots = append(t.SponsorSlots, TemplateSponsorSlot{
+			Distance:    sc.Distance,
+			AmountPerKm: sc.AmountPerKm,
+			BrandImg:    sc.BrandImg,
+			VideoUrl:    sc.VideoUrl,
+			Sponsors:    sc.Sponsors,
+		})
+	}
+	for _, m := range milestones {
+		t.Milestones = append(t.Milestones, TemplateMilestone{Metric: m.Metric, Percent: m.Percent, Value: m.Value})
+	}
+	return t
+}
+
+// Schedule returns the start and end of an edition starting at start. The end is nil for
+// open-ended templates.
+func (t *CampaignTemplate) Schedule(start time.Time) (time.Time, *time.Time) {
+	start = start.UTC()
+	if t.Length <= 0 {
+		return start, nil
+	}
+	end := start.Add(t.Length)
+	return start, &end
+}
+
+// Shifted returns the source campaign's start moved by days, or an error when the template
+// has no start to shift.
+func (t *CampaignTemplate) Shifted(days int) (time.Time, error) {
+	if t.StartsAt == nil {
+		return time.Time{}, fmt.Errorf("%w: the template has no start date to shift, give a start instead", ErrInvalidCampaignSchedule)
+	}
+	return t.StartsAt.AddDate(0, 0, days), nil
+}
+
+// SetRecurrence schedules the template to create an edition every interval, the first starting
+// at first and each created lead before it starts. RecurrenceNone stops the schedule.
+func (t *CampaignTemplate) SetRecurrence(r Recurrence, first *time.Time, lead time.Duration) error {
+	if !r.Valid() {
+		return fmt.Errorf("%w: recurrence must be %q or %q", ErrInvalidRecurrence, RecurrenceWeekly, RecurrenceMonthly)
+	}
+	if r == RecurrenceNone {
+		t.Recurrence, t.NextStartsAt, t.Lead = r, nil, 0
+		return nil
+	}
+	if first == nil {
+		return fmt.Errorf("%w: a start for the first edition is required", ErrInvalidRecurrence)
+	}
+	if lead <= 0 {
+		lead = DefaultRecurrenceLead
+	}
+	if t.Length > 0 && lead >= r.Next(*first).Sub(*first) {
+		return fmt.Errorf("%w: editions must be created less than one interval ahead", ErrInvalidRecurrence)
+	}
+	next := first.UTC()
+	t.Recurrence, t.NextStartsAt, t.Lead = r, &next, lead
+	return nil
+}
+
+// DueAt reports whether the schedule should create its next edition at now.
+func (t *CampaignTemplate) DueAt(now time.Time) bool {
+	return t.Recurrence != RecurrenceNone && t.NextStartsAt != nil && !now.Before(t.NextStartsAt.Add(-t.Lead))
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// UserSummary names a member or sponsor. Only ID identifies the user; Username and FullName
+// are filled in when the user is loaded alongside and may be empty.
+type UserSummary struct {
+	ID       string `json:"id"`
+	Username string `json:"username,omitempty"`
+	FullName string `json:"full_name,omitempty"`
+}
+
+// UnmarshalJSON also accepts a bare user ID, the shape older releases stored.
+func (u *UserSummary) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*u = UserSummary{ID: strings.TrimSpace(id)}
+		return nil
+	}
+	type summary UserSummary
+	var s summary
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*u = UserSummary(s)
+	return nil
+}
+
+// UsersByID returns a summary carrying only the ID for each user ID, skipping empty ones.
+func UsersByID(ids ...string) []UserSummary {
+	users := make([]UserSummary, 0, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			users = append(users, UserSummary{ID: id})
+		}
+	}
+	return users
+}
+
+// UserIDs returns the IDs of users, in order.
+func UserIDs(users []UserSummary) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
+
+// HasUser reports whether userID is one of users.
+func HasUser(users []UserSummary, userID string) bool {
+	for _, u := range users {
+		if u.ID == userID {
+			return true
+		}
+	}
+	return false
+}
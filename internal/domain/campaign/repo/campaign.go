@@ -1,6 +1,11 @@
 package repo
 
-import "gopi.com/internal/domain/campaign/model"
+import (
+	"time"
+
+	activityModel "gopi.com/internal/domain/activity/model"
+	"gopi.com/internal/domain/campaign/model"
+)
 
 type CampaignRepository interface {
 	Create(campaign *model.Campaign) error
@@ -11,6 +16,8 @@ type CampaignRepository interface {
 	Delete(id string) error
 	List(limit, offset int) ([]*model.Campaign, error)
 	Search(query string, limit, offset int) ([]*model.Campaign, error)
+	// Find returns one page of campaigns matching filter and the total number of matches.
+	Find(filter model.CampaignFilter) ([]*model.Campaign, int64, error)
 	
 	// Many-to-many relationship methods
 	AddMember(campaignID, userID string) error
@@ -19,6 +26,25 @@ type CampaignRepository interface {
 	AddSponsor(campaignID, userID string) error
 	RemoveSponsor(campaignID, userID string) error
 	IsSponsor(campaignID, userID string) (bool, error)
+
+	// Lifecycle methods
+	// ListDueForTransition returns scheduled campaigns whose start has passed and active
+	// (or legacy status-less) campaigns whose end has passed.
+	ListDueForTransition(now time.Time) ([]*model.Campaign, error)
+	// UpdateStatus moves a campaign from one status to another only if it is still in from.
+	// It reports whether the row was changed.
+	UpdateStatus(id string, from, to model.CampaignStatus) (bool, error)
+
+	// IncrementTotals atomically adds to distance_covered and money_raised, so concurrent
+	// writers never overwrite each other's progress.
+	IncrementTotals(id string, distance, money float64) error
+
+	// Close-out methods
+	// MarkAchieved records that the goal was met and completes the campaign, only if it is still
+	// active and not already achieved. It reports whether the row was changed.
+	MarkAchieved(id string, at time.Time) (bool, error)
+	// MarkClosed stamps closed_at once; it reports false if the campaign was already closed.
+	MarkClosed(id string, at time.Time) (bool, error)
 }
 
 type CampaignRunnerRepository interface {
@@ -28,6 +54,18 @@ type CampaignRunnerRepository interface {
 	GetByOwnerID(ownerID string) ([]*model.CampaignRunner, error)
 	Update(runner *model.CampaignRunner) error
 	Delete(id string) error
+	// IncrementProgress atomically adds distance, money and time to a runner.
+	IncrementProgress(id string, distance, money float64, duration time.Duration) error
+	// AttachTrack records the latest uploaded track and adds its elevation gain to the runner.
+	AttachTrack(id, trackURL string, elevationGain float64) error
+}
+
+// CampaignResultRepository stores the standings and sponsor obligations frozen at close-out.
+type CampaignResultRepository interface {
+	SaveStandings(campaignID string, standings []*model.CampaignStanding) error
+	GetStandings(campaignID string) ([]*model.CampaignStanding, error)
+	SaveObligations(campaignID string, obligations []*model.SponsorObligation) error
+	GetObligations(campaignID string) ([]*model.SponsorObligation, error)
 }
 
 type SponsorCampaignRepository interface {
@@ -36,4 +74,114 @@ type SponsorCampaignRepository interface {
 	GetByCampaignID(campaignID string) ([]*model.SponsorCampaign, error)
 	Update(sponsor *model.SponsorCampaign) error
 	Delete(id string) error
+	// Confirm marks a pending sponsorship confirmed with its final sponsors. It reports false if
+	// the sponsorship was no longer pending.
+	Confirm(id string, sponsors []model.UserSummary) (bool, error)
+}
+
+// CampaignRunRepository stores individual runs for daily limits and the review queue.
+type CampaignRunRepository interface {
+	Create(run *model.CampaignRun) error
+	GetByID(id string) (*model.CampaignRun, error)
+	ListByStatus(status activityModel.ReviewStatus, limit, offset int) ([]*model.CampaignRun, error)
+	// SumDistanceSince totals the counted (accepted or approved) runs of a user since a time.
+	SumDistanceSince(ownerID string, since time.Time) (float64, error)
+	// ListForLeaderboard returns the counted runs of a campaign since a time, of one activity
+	// when activity is not empty.
+	ListForLeaderboard(campaignID string, since time.Time, activity string) ([]*model.CampaignRun, error)
+	// Review moves a flagged run to status and reports false if it was no longer flagged.
+	Review(id string, status activityModel.ReviewStatus, reviewerID string, at time.Time) (bool, error)
+}
+
+// CampaignTeamRepository stores teams and their members.
+type CampaignTeamRepository interface {
+	Create(team *model.CampaignTeam) error
+	GetByID(id string) (*model.CampaignTeam, error)
+	// GetByInviteCode finds the team in a campaign that uses code, or returns
+	// model.ErrInvalidInviteCode if none does.
+	GetByInviteCode(campaignID, code string) (*model.CampaignTeam, error)
+	ListByCampaign(campaignID string) ([]*model.CampaignTeam, error)
+	Update(team *model.CampaignTeam) error
+	Delete(id string) error
+
+	// AddMember fails if the user is already in a team for the campaign.
+	AddMember(member *model.CampaignTeamMember) error
+	RemoveMember(teamID, userID string) error
+	// TeamOf returns the user's team in a campaign, or nil if they are not in one.
+	TeamOf(campaignID, userID string) (*model.CampaignTeam, error)
+
+	// Standings totals each team's members' campaign runners. Teams are returned unranked.
+	Standings(campaignID string) ([]*model.TeamStanding, error)
+}
+
+// CampaignInviteRepository stores invite links and email invitations.
+type CampaignInviteRepository interface {
+	Create(invite *model.CampaignInvite) error
+	// GetByID returns model.ErrInvalidInvite if there is no such invite.
+	GetByID(id string) (*model.CampaignInvite, error)
+	ListByCampaign(campaignID string) ([]*model.CampaignInvite, error)
+	// Redeem counts one use of the invite only if it is unrevoked, unexpired at now and has
+	// uses left. It reports whether the use was counted.
+	Redeem(id string, now time.Time) (bool, error)
+	Revoke(id string, at time.Time) error
+}
+
+// CampaignJoinRequestRepository stores requests to join private campaigns.
+type CampaignJoinRequestRepository interface {
+	Create(request *model.CampaignJoinRequest) error
+	// GetByID returns model.ErrJoinRequestNotFound if there is no such request.
+	GetByID(id string) (*model.CampaignJoinRequest, error)
+	// GetPending returns the user's pending request for a campaign, or nil if there is none.
+	GetPending(campaignID, userID string) (*model.CampaignJoinRequest, error)
+	// ListByCampaign returns the campaign's requests oldest first, all of them when status is empty.
+	ListByCampaign(campaignID string, status model.JoinRequestStatus) ([]*model.CampaignJoinRequest, error)
+	// Decide moves a pending request to status and reports false if it was no longer pending.
+	Decide(id string, status model.JoinRequestStatus, deciderID string, at time.Time) (bool, error)
+}
+
+// CampaignMilestoneRepository stores the progress milestones configured for campaigns.
+type CampaignMilestoneRepository interface {
+	// ListByCampaign returns the campaign's milestones, oldest first.
+	ListByCampaign(campaignID string) ([]*model.CampaignMilestone, error)
+	// ReplacePending deletes the campaign's unreached milestones and stores milestones in their
+	// place. Reached milestones are kept.
+	ReplacePending(campaignID string, milestones []*model.CampaignMilestone) error
+	// MarkReached stamps reached_at once; it reports false if the milestone was already reached.
+	MarkReached(id string, at time.Time) (bool, error)
+}
+
+// CampaignTemplateRepository stores campaign templates and their recurring schedules.
+type CampaignTemplateRepository interface {
+	Create(template *model.CampaignTemplate) error
+	// GetByID returns model.ErrTemplateNotFound if there is no such template.
+	GetByID(id string) (*model.CampaignTemplate, error)
+	// ListByOwner returns the owner's templates, newest first.
+	ListByOwner(ownerID string) ([]*model.CampaignTemplate, error)
+	Update(template *model.CampaignTemplate) error
+	Delete(id string) error
+	// ListRecurring returns the templates with a scheduled next edition, soonest first.
+	ListRecurring() ([]*model.CampaignTemplate, error)
+	// AdvanceSchedule moves next_starts_at from from to next only if it is still from. It
+	// reports whether the row was changed, so exactly one caller creates each edition.
+	AdvanceSchedule(id string, from, next time.Time) (bool, error)
+	// CountEdition adds one to the number of campaigns created from the template.
+	CountEdition(id string) error
+}
+
+// Repositories groups the campaign repositories bound to a single unit of work.
+type Repositories struct {
+	Campaigns CampaignRepository
+	Runners   CampaignRunnerRepository
+	Sponsors  SponsorCampaignRepository
+	Runs      CampaignRunRepository
+	Teams     CampaignTeamRepository
+	Invites   CampaignInviteRepository
+	Requests  CampaignJoinRequestRepository
+	Results   CampaignResultRepository
+}
+
+// UnitOfWork runs fn in one transaction: every write made through the repositories passed
+// to fn is committed together, or rolled back if fn returns an error.
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
 }
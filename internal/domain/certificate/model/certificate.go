@@ -0,0 +1,48 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+var (
+	// ErrCertificateNotFound is returned when no certificate has the given ID.
+	ErrCertificateNotFound = errors.New("certificate not found")
+	// ErrRunnerNotFound is returned when the campaign or cause runner does not exist.
+	ErrRunnerNotFound = errors.New("runner not found")
+	// ErrNotRunnerOwner is returned when a user asks for a certificate for someone else's run.
+	ErrNotRunnerOwner = errors.New("runner belongs to another user")
+	// ErrNotCompleted is returned while a run does not yet qualify for a certificate: the
+	// campaign has not finished, no distance was covered, the cause distance was not reached
+	// or the run is held for review.
+	ErrNotCompleted = errors.New("run has not been completed")
+	// ErrKindNotEnabled is returned for campaign or cause certificates when the service was
+	// built without the repositories they need.
+	ErrKindNotEnabled = errors.New("certificates are not enabled for this kind of run")
+)
+
+// Kind is what a certificate was issued for.
+type Kind string
+
+const (
+	KindCampaign Kind = "campaign" // a runner's part in a finished campaign
+	KindCause    Kind = "cause"    // a completed cause run
+)
+
+// Certificate records a completion certificate. The figures are copied from the run when it
+// is issued so verification shows exactly what was printed.
+type Certificate struct {
+	model.Base
+	Kind        Kind          `json:"kind"`
+	SubjectID   string        `json:"subject_id"`   // campaign or cause ID
+	SubjectName string        `json:"subject_name"` // campaign or cause name
+	RunnerID    string        `json:"runner_id"`    // campaign or cause runner ID
+	UserID      string        `json:"user_id"`
+	RunnerName  string        `json:"runner_name"`
+	Distance    float64       `json:"distance"` // kilometres
+	Duration    time.Duration `json:"duration"` // stored as whole seconds
+	FileKey     string        `json:"file_key"` // storage key of the PDF
+	FileURL     string        `json:"file_url"`
+}
@@ -0,0 +1,14 @@
+package repo
+
+import certificateModel "gopi.com/internal/domain/certificate/model"
+
+// CertificateRepository abstracts persistence for completion certificates.
+type CertificateRepository interface {
+	Create(certificate *certificateModel.Certificate) error
+	// GetByID returns model.ErrCertificateNotFound if there is no such certificate.
+	GetByID(id string) (*certificateModel.Certificate, error)
+	// GetByRunner returns the certificate already issued for a run, or
+	// model.ErrCertificateNotFound.
+	GetByRunner(kind certificateModel.Kind, runnerID string) (*certificateModel.Certificate, error)
+	ListByUser(userID string, limit, offset int) ([]*certificateModel.Certificate, error)
+}
@@ -0,0 +1,317 @@
+package model
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+var (
+	// ErrBracketsNotEnabled is returned when knockout brackets are not configured.
+	ErrBracketsNotEnabled = errors.New("challenge brackets are not enabled")
+	// ErrInvalidSeeding is returned for a seeding other than random or distance.
+	ErrInvalidSeeding = errors.New("seeding must be random or distance")
+	// ErrInvalidBracket is returned for a bracket without a start or with a round length that
+	// is not positive.
+	ErrInvalidBracket = errors.New("a bracket needs a start time and a positive round length")
+	// ErrTooFewEntrants is returned when a bracket is drawn for fewer than two members.
+	ErrTooFewEntrants = errors.New("a bracket needs at least two challenge members")
+	// ErrBracketExists is returned when a challenge that already has a bracket is given another.
+	ErrBracketExists = errors.New("challenge already has a bracket")
+	// ErrNoBracket is returned when the bracket of a challenge without one is asked for.
+	ErrNoBracket = errors.New("challenge has no bracket")
+)
+
+// DefaultRoundLength is how long each bracket round lasts when none is given.
+const DefaultRoundLength = 7 * 24 * time.Hour
+
+// Seeding is how a bracket's entrants are ordered before they are drawn.
+type Seeding string
+
+const (
+	SeedRandom   Seeding = "random"   // shuffled
+	SeedDistance Seeding = "distance" // most counted distance logged before the draw first
+)
+
+// Valid reports whether s is a known seeding.
+func (s Seeding) Valid() bool {
+	return s == SeedRandom || s == SeedDistance
+}
+
+// MatchOutcome is how a bracket match was decided.
+type MatchOutcome string
+
+const (
+	MatchPending  MatchOutcome = "pending"  // not decided yet
+	MatchRaced    MatchOutcome = "raced"    // both sides logged distance; the longer won
+	MatchBye      MatchOutcome = "bye"      // one side was empty in the draw
+	MatchWalkover MatchOutcome = "walkover" // a side logged nothing in the round's window
+)
+
+// Bracket is a knockout draw for a challenge's members. Each round lasts RoundLength, starting
+// at StartsAt; pairs race in each match and the one who logs more distance advances.
+type Bracket struct {
+	model.Base
+	ChallengeID string        `json:"challenge_id"`
+	Seeding     Seeding       `json:"seeding"`
+	StartsAt    time.Time     `json:"starts_at"`
+	RoundLength time.Duration `json:"round_length"`
+	Entrants    int           `json:"entrants"`
+	Size        int           `json:"size"`   // first-round places, a power of two; the places past Entrants are byes
+	Rounds      int           `json:"rounds"` // the last one is the final
+	ChampionID  string        `json:"champion_id,omitempty"`
+	CompletedAt *time.Time    `json:"completed_at,omitempty"` // when the final was decided
+}
+
+// BracketMatch pairs two entrants in one round. Home comes from the even-numbered feeder
+// match of the round before, away from the odd one; in the first round both come from the
+// draw. Seeds are 1 for the top seed and 0 for an empty side.
+type BracketMatch struct {
+	model.Base
+	BracketID    string       `json:"bracket_id"`
+	Round        int          `json:"round"` // 1 for the first round
+	Slot         int          `json:"slot"`  // position in the round from 0
+	HomeID       string       `json:"home_id,omitempty"`
+	HomeSeed     int          `json:"home_seed,omitempty"`
+	AwayID       string       `json:"away_id,omitempty"`
+	AwaySeed     int          `json:"away_seed,omitempty"`
+	HomeDistance float64      `json:"home_distance"` // counted distance logged in the window
+	AwayDistance float64      `json:"away_distance"`
+	OpensAt      time.Time    `json:"opens_at"`
+	ClosesAt     time.Time    `json:"closes_at"`
+	Outcome      MatchOutcome `json:"outcome"`
+	WinnerID     string       `json:"winner_id,omitempty"`
+	DecidedAt    *time.Time   `json:"decided_at,omitempty"`
+}
+
+// Validate checks the bracket's seeding and schedule, defaulting RoundLength to a week.
+func (b *Bracket) Validate() error {
+	if !b.Seeding.Valid() {
+		return ErrInvalidSeeding
+	}
+	if b.RoundLength == 0 {
+		b.RoundLength = DefaultRoundLength
+	}
+	if b.StartsAt.IsZero() || b.RoundLength < 0 {
+		return ErrInvalidBracket
+	}
+	return nil
+}
+
+// RoundWindow returns when the round opens and closes. Rounds follow one another without a
+// gap.
+func (b *Bracket) RoundWindow(round int) (time.Time, time.Time) {
+	opens := b.StartsAt.Add(time.Duration(round-1) * b.RoundLength)
+	return opens, opens.Add(b.RoundLength)
+}
+
+// SeedEntrants orders the users from top seed down. Random seeding shuffles them with rng, or
+// the global source when rng is nil; distance seeding puts the most past distance first, ties
+// going to the lower user ID. Repeated and empty user IDs are dropped.
+func SeedEntrants(userIDs []string, seeding Seeding, pastDistance map[string]float64, rng *rand.Rand) ([]string, error) {
+	if !seeding.Valid() {
+		return nil, ErrInvalidSeeding
+	}
+	seen := make(map[string]bool, len(userIDs))
+	seeds := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID != "" && !seen[userID] {
+			seen[userID] = true
+			seeds = append(seeds, userID)
+		}
+	}
+
+	sort.Strings(seeds)
+	if seeding == SeedDistance {
+		sort.SliceStable(seeds, func(i, j int) bool {
+			return pastDistance[seeds[i]] > pastDistance[seeds[j]]
+		})
+		return seeds, nil
+	}
+	swap := func(i, j int) { seeds[i], seeds[j] = seeds[j], seeds[i] }
+	if rng != nil {
+		rng.Shuffle(len(seeds), swap)
+	} else {
+		rand.Shuffle(len(seeds), swap)
+	}
+	return seeds, nil
+}
+
+// Draw sizes the bracket for the seeded entrants and generates every match of every round.
+// First-round matches pair seeds so the top seeds meet last (1 plays the lowest seed, and 1
+// and 2 can only meet in the final). When the entrants do not fill a power of two the top
+// seeds get byes, which are decided at now and advance at once. Later-round matches are
+// filled in as their feeders are decided.
+func (b *Bracket) Draw(seeds []string, now time.Time) ([]*BracketMatch, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	if len(seeds) < 2 {
+		return nil, ErrTooFewEntrants
+	}
+	b.Entrants = len(seeds)
+	b.Size, b.Rounds = 2, 1
+	for b.Size < len(seeds) {
+		b.Size *= 2
+		b.Rounds++
+	}
+
+	var matches []*BracketMatch
+	for round, slots := 1, b.Size/2; round <= b.Rounds; round, slots = round+1, slots/2 {
+		opens, closes := b.RoundWindow(round)
+		for slot := 0; slot < slots; slot++ {
+			matches = append(matches, &BracketMatch{
+				Round:    round,
+				Slot:     slot,
+				OpensAt:  opens,
+				ClosesAt: closes,
+				Outcome:  MatchPending,
+			})
+		}
+	}
+
+	order := seedOrder(b.Size)
+	entrant := func(seed int) (string, int) {
+		if seed > len(seeds) {
+			return "", 0
+		}
+		return seeds[seed-1], seed
+	}
+	for slot := 0; slot < b.Size/2; slot++ {
+		match := matches[slot]
+		match.HomeID, match.HomeSeed = entrant(order[2*slot])
+		match.AwayID, match.AwaySeed = entrant(order[2*slot+1])
+		if match.AwayID == "" {
+			match.Outcome = MatchBye
+			match.WinnerID = match.HomeID
+			match.DecidedAt = &now
+			b.Advance(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// seedOrder returns the seeds of a bracket of size places in first-round order, so that each
+// pair of neighbours sums to size+1.
+func seedOrder(size int) []int {
+	order := []int{1}
+	for n := 2; n <= size; n *= 2 {
+		next := make([]int, 0, n)
+		for _, seed := range order {
+			next = append(next, seed, n+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// Ready reports whether both sides of the match are known.
+func (m *BracketMatch) Ready() bool {
+	return m.HomeID != "" && m.AwayID != ""
+}
+
+// Decide settles a ready match from the runners' counted distance logged in its window. Runs
+// held or rejected by anti-cheat review, and runs by anyone else, are left out. The side with
+// more distance wins and the match counts as raced; when only one side logged anything it
+// wins by walkover, and when neither did, or on a tie, the higher seed goes through.
+func (m *BracketMatch) Decide(runners []*CauseRunner, now time.Time) {
+	m.HomeDistance, m.AwayDistance = 0, 0
+	for _, runner := range runners {
+		if !runner.Status.Counts() {
+			continue
+		}
+		logged := runner.DateJoined
+		if logged.IsZero() {
+			logged = runner.CreatedAt
+		}
+		if logged.Before(m.OpensAt) || !logged.Before(m.ClosesAt) {
+			continue
+		}
+		switch runner.OwnerID {
+		case m.HomeID:
+			m.HomeDistance += runner.DistanceCovered
+		case m.AwayID:
+			m.AwayDistance += runner.DistanceCovered
+		}
+	}
+
+	homeWins := m.HomeDistance > m.AwayDistance ||
+		(m.HomeDistance == m.AwayDistance && m.HomeSeed <= m.AwaySeed)
+	m.WinnerID = m.AwayID
+	if homeWins {
+		m.WinnerID = m.HomeID
+	}
+	m.Outcome = MatchRaced
+	if m.HomeDistance == 0 || m.AwayDistance == 0 {
+		m.Outcome = MatchWalkover
+	}
+	m.DecidedAt = &now
+}
+
+// WinnerSeed returns the seed of the match's winner.
+func (m *BracketMatch) WinnerSeed() int {
+	if m.WinnerID == m.AwayID {
+		return m.AwaySeed
+	}
+	return m.HomeSeed
+}
+
+// Advance moves the decided match's winner into their match in the next round, which it
+// returns. After the final it crowns the winner champion and returns nil.
+func (b *Bracket) Advance(matches []*BracketMatch, decided *BracketMatch) *BracketMatch {
+	if decided.Round >= b.Rounds {
+		b.ChampionID = decided.WinnerID
+		b.CompletedAt = decided.DecidedAt
+		return nil
+	}
+	for _, next := range matches {
+		if next.Round != decided.Round+1 || next.Slot != decided.Slot/2 {
+			continue
+		}
+		if decided.Slot%2 == 0 {
+			next.HomeID, next.HomeSeed = decided.WinnerID, decided.WinnerSeed()
+		} else {
+			next.AwayID, next.AwaySeed = decided.WinnerID, decided.WinnerSeed()
+		}
+		return next
+	}
+	return nil
+}
+
+// BracketNode is a match in the bracket tree with the two matches that feed it, home first.
+// First-round matches have no feeders.
+type BracketNode struct {
+	Match   *BracketMatch  `json:"match"`
+	Feeders []*BracketNode `json:"feeders,omitempty"`
+}
+
+// BracketTree arranges the bracket's matches as a tree rooted at the final. It is nil when the
+// final is missing.
+func BracketTree(bracket *Bracket, matches []*BracketMatch) *BracketNode {
+	type key struct{ round, slot int }
+	byPlace := make(map[key]*BracketMatch, len(matches))
+	for _, match := range matches {
+		byPlace[key{match.Round, match.Slot}] = match
+	}
+
+	var node func(round, slot int) *BracketNode
+	node = func(round, slot int) *BracketNode {
+		match, ok := byPlace[key{round, slot}]
+		if !ok {
+			return nil
+		}
+		n := &BracketNode{Match: match}
+		if round > 1 {
+			for _, feeder := range []*BracketNode{node(round-1, 2*slot), node(round-1, 2*slot+1)} {
+				if feeder != nil {
+					n.Feeders = append(n.Feeders, feeder)
+				}
+			}
+		}
+		return n
+	}
+	return node(bracket.Rounds, 0)
+}
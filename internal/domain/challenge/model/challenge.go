@@ -1,8 +1,11 @@
 package model
 
 import (
+	"errors"
+	"sort"
 	"time"
 
+	activityModel "gopi.com/internal/domain/activity/model"
 	"gopi.com/internal/domain/model"
 )
 
@@ -30,14 +33,19 @@ type Challenge struct {
 	Condition          string        `json:"condition"`           // condition
 	Goal               string        `json:"goal"`                // goal
 	Location           string        `json:"location"`            // location
+	Coordinates        *model.GeoPoint `json:"coordinates,omitempty"` // position of Location, nil when unknown
 	DistanceToCover    float64       `json:"distance_to_cover"`   // distance_to_cover
 	TargetAmount       float64       `json:"target_amount"`       // target_amount
 	TargetAmountPerKm  float64       `json:"target_amount_per_km"` // target_amount_per_km
 	StartDuration      string        `json:"start_duration"`      // start_duration
 	EndDuration        string        `json:"end_duration"`        // end_duration
+	EndsAt             *time.Time    `json:"ends_at,omitempty"`   // parsed end, nil when open-ended
 	NoOfWinner         int           `json:"no_of_winner"`        // no_of_winner
-	WinningPrice       []interface{} `json:"winning_price"`       // winning_price (JSONField)
-	CausePrice         []interface{} `json:"cause_price"`         // cause_price (JSONField)
+	WinningPrice       []Prize       `json:"winning_price"`       // winning_price (JSONField), sorted by rank
+	RankingCriterion   RankingCriterion `json:"ranking_criterion"` // how winners are ranked at close-out
+	ClosedAt           *time.Time    `json:"closed_at,omitempty"` // when winners were selected
+	CancelledAt        *time.Time    `json:"cancelled_at,omitempty"` // when the owner or staff called it off
+	CausePrice         []Prize       `json:"cause_price"`         // cause_price (JSONField), awarded to the final judging round by rank
 	CoverImage         string        `json:"cover_image"`         // cover_image
 	VideoUrl           string        `json:"video_url"`           // video_url
 	Slug               string        `json:"slug"`                // slug
@@ -55,17 +63,20 @@ type Cause struct {
 	ProductDescription string   `json:"product_description"` // product_description
 	Activity           Activity `json:"activity"`            // activity
 	Location           string   `json:"location"`            // location
+	Coordinates        *model.GeoPoint `json:"coordinates,omitempty"` // position of Location, nil when unknown
 	Description        string   `json:"description"`         // description
 	IsCommercial       bool     `json:"is_commercial"`       // is_commercial
 	WhoIdeaImpact      string   `json:"who_idea_impact"`     // who_idea_impact
 	BuyerUser          string   `json:"buyer_user"`          // buyer_user
 	DistanceCovered    float64  `json:"distance_covered"`    // distance_covered
 	AmountPerPiece     float64  `json:"amount_per_piece"`    // amount_per_piece
-	Duration           string   `json:"duration"`            // duration
+	Duration           time.Duration `json:"duration"`       // duration, stored as whole seconds
 	FundCause          bool     `json:"fund_cause"`          // fund_cause
 	FundAmount         float64  `json:"fund_amount"`         // fund_amount
 	WillingAmount      float64  `json:"willing_amount"`      // willing_amount
 	UnitPrice          float64  `json:"unit_price"`          // unit_price
+	Stock              *int     `json:"stock,omitempty"`     // units for sale, nil when unlimited
+	UnitsSold          int      `json:"units_sold"`          // units in paid orders, less refunds
 	CostToLaunch       string   `json:"cost_to_launch"`      // cost_to_launch
 	BenefitDesc        string   `json:"benefit_desc"`        // benefit_desc
 	OwnerID            string   `json:"owner_id"`            // owner
@@ -77,17 +88,27 @@ type Cause struct {
 	Sponsors []interface{} `json:"sponsors"` // sponsors (User objects)
 }
 
+// ErrRunnerChanged is returned when a cause runner keeps changing while a track is attached.
+var ErrRunnerChanged = errors.New("the run changed while its track was being recorded; try again")
+
 type CauseRunner struct {
 	model.Base
 	CauseID         string  `json:"cause_id"`         // cause
 	DistanceToCover float64 `json:"distance_to_cover"` // distance_to_cover
 	DistanceCovered float64 `json:"distance_covered"` // distance_covered
-	Duration        string  `json:"duration"`         // duration
+	Duration        time.Duration `json:"duration"`   // duration, stored as whole seconds
 	MoneyRaised     float64 `json:"money_raised"`     // money_raised
 	CoverImage      string  `json:"cover_image"`      // cover_image
 	Activity        string  `json:"activity"`         // activity
 	OwnerID         string  `json:"owner_id"`         // owner
 	DateJoined      time.Time `json:"date_joined"`    // date_joined
+	TrackURL        string    `json:"track_url"`      // uploaded GPS track (GeoJSON)
+	ElevationGain   float64   `json:"elevation_gain"` // meters climbed, from the uploaded track
+
+	Status     activityModel.ReviewStatus `json:"status"`      // anti-cheat review status
+	Violations []activityModel.Violation  `json:"violations"`  // rules broken, when flagged
+	ReviewedBy string                     `json:"reviewed_by"` // staff reviewer
+	ReviewedAt *time.Time                 `json:"reviewed_at"` // review time
 }
 
 type SponsorChallenge struct {
@@ -112,6 +133,8 @@ type SponsorCause struct {
 	VideoUrl    string  `json:"video_url"`    // video_url
 }
 
+// CauseBuyer is a one-off purchase recorded before cause orders existed. Sales of commercial
+// causes are CauseOrders.
 type CauseBuyer struct {
 	model.Base
 	BuyerID    string    `json:"buyer_id"`    // buyer (SET_NULL)
@@ -135,22 +158,16 @@ func GetRunnerList(runners []*CauseRunner) []*CauseRunner {
 	// Sort by distance covered descending
 	sortedRunners := make([]*CauseRunner, len(runners))
 	copy(sortedRunners, runners)
-	
-	// Simple sort implementation - in production use sort.Slice
-	for i := 0; i < len(sortedRunners)-1; i++ {
-		for j := i + 1; j < len(sortedRunners); j++ {
-			if sortedRunners[i].DistanceCovered < sortedRunners[j].DistanceCovered {
-				sortedRunners[i], sortedRunners[j] = sortedRunners[j], sortedRunners[i]
-			}
-		}
-	}
+	sort.SliceStable(sortedRunners, func(i, j int) bool {
+		return sortedRunners[i].DistanceCovered > sortedRunners[j].DistanceCovered
+	})
 	
 	// Filter unique owners (similar to Django logic)
 	unique := []*CauseRunner{}
 	seen := make(map[string]bool)
 	
 	for _, runner := range sortedRunners {
-		if runner.Duration != "" && !seen[runner.OwnerID] {
+		if runner.Duration > 0 && !seen[runner.OwnerID] {
 			unique = append(unique, runner)
 			seen[runner.OwnerID] = true
 		}
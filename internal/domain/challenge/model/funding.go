@@ -0,0 +1,137 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+// ErrFundingNotEnabled is returned when sponsor settlement statements are not configured.
+var ErrFundingNotEnabled = errors.New("cause funding settlements are not enabled")
+
+// EarnedFor returns what the sponsorship owes once its cause has covered distance km:
+// AmountPerKm for every km up to the Distance the sponsor pledged, to the cent.
+func (sc *SponsorCause) EarnedFor(distance float64) float64 {
+	covered := math.Max(distance, 0)
+	if sc.Distance > 0 {
+		covered = math.Min(covered, sc.Distance)
+	}
+	return roundCents(sc.AmountPerKm * covered)
+}
+
+// SponsorObligation is what one sponsor has pledged to a cause and earned so far, across all
+// of their sponsorships of it.
+type SponsorObligation struct {
+	SponsorID    string  `json:"sponsor_id"`
+	Sponsorships int     `json:"sponsorships"`
+	Pledged      float64 `json:"pledged"` // owed if the cause covers every pledged km
+	Earned       float64 `json:"earned"`  // owed for the km covered so far
+}
+
+// CauseFunding is a cause's funding ledger: what its sponsors have earned from the distance its
+// runners have covered, against the cause's FundAmount goal.
+type CauseFunding struct {
+	CauseID         string               `json:"cause_id"`
+	Goal            float64              `json:"goal"`
+	DistanceCovered float64              `json:"distance_covered"`
+	Pledged         float64              `json:"pledged"`
+	Earned          float64              `json:"earned"`
+	Sponsors        []*SponsorObligation `json:"sponsors"` // largest earner first
+	SettledAt       *time.Time           `json:"settled_at,omitempty"`
+}
+
+// NewCauseFunding totals the cause's sponsorships at the distance the cause has covered.
+func NewCauseFunding(cause *Cause, sponsorships []*SponsorCause) *CauseFunding {
+	funding := &CauseFunding{
+		CauseID:         cause.ID,
+		Goal:            cause.FundAmount,
+		DistanceCovered: cause.DistanceCovered,
+	}
+	bySponsor := make(map[string]*SponsorObligation)
+	for _, sponsorship := range sponsorships {
+		obligation, ok := bySponsor[sponsorship.SponsorID]
+		if !ok {
+			obligation = &SponsorObligation{SponsorID: sponsorship.SponsorID}
+			bySponsor[sponsorship.SponsorID] = obligation
+			funding.Sponsors = append(funding.Sponsors, obligation)
+		}
+		obligation.Sponsorships++
+		obligation.Pledged = roundCents(obligation.Pledged + sponsorship.TotalAmount)
+		obligation.Earned = roundCents(obligation.Earned + sponsorship.EarnedFor(cause.DistanceCovered))
+	}
+	for _, obligation := range funding.Sponsors {
+		funding.Pledged = roundCents(funding.Pledged + obligation.Pledged)
+		funding.Earned = roundCents(funding.Earned + obligation.Earned)
+	}
+	sort.SliceStable(funding.Sponsors, func(i, j int) bool {
+		return funding.Sponsors[i].Earned > funding.Sponsors[j].Earned
+	})
+	return funding
+}
+
+// SettledCauseFunding rebuilds the cause's ledger from its settlement statements, so a settled
+// cause shows what its sponsors were billed rather than what later distance would earn.
+func SettledCauseFunding(cause *Cause, statements []*SponsorStatement) *CauseFunding {
+	funding := &CauseFunding{CauseID: cause.ID, Goal: cause.FundAmount}
+	for _, statement := range statements {
+		funding.DistanceCovered = statement.DistanceCovered
+		funding.SettledAt = &statement.SettledAt
+		funding.Sponsors = append(funding.Sponsors, &SponsorObligation{
+			SponsorID:    statement.SponsorID,
+			Sponsorships: statement.Sponsorships,
+			Pledged:      statement.Pledged,
+			Earned:       statement.AmountDue,
+		})
+		funding.Pledged = roundCents(funding.Pledged + statement.Pledged)
+		funding.Earned = roundCents(funding.Earned + statement.AmountDue)
+	}
+	sort.SliceStable(funding.Sponsors, func(i, j int) bool {
+		return funding.Sponsors[i].Earned > funding.Sponsors[j].Earned
+	})
+	return funding
+}
+
+// Progress returns the percentage of the goal earned so far, which may pass 100. It is 0 for a
+// cause without a goal.
+func (f *CauseFunding) Progress() float64 {
+	if f.Goal <= 0 {
+		return 0
+	}
+	return math.Round(f.Earned/f.Goal*10000) / 100
+}
+
+// Statements settles the ledger into one statement per sponsor of what they owe.
+func (f *CauseFunding) Statements(at time.Time) []*SponsorStatement {
+	statements := make([]*SponsorStatement, 0, len(f.Sponsors))
+	for _, obligation := range f.Sponsors {
+		statements = append(statements, &SponsorStatement{
+			CauseID:         f.CauseID,
+			SponsorID:       obligation.SponsorID,
+			Sponsorships:    obligation.Sponsorships,
+			DistanceCovered: f.DistanceCovered,
+			Pledged:         obligation.Pledged,
+			AmountDue:       obligation.Earned,
+			SettledAt:       at,
+		})
+	}
+	return statements
+}
+
+// SponsorStatement is what a sponsor owes a cause, settled when the cause's challenge closed.
+type SponsorStatement struct {
+	model.Base
+	CauseID         string    `json:"cause_id"`
+	SponsorID       string    `json:"sponsor_id"`
+	Sponsorships    int       `json:"sponsorships"`
+	DistanceCovered float64   `json:"distance_covered"` // the cause's distance when settled
+	Pledged         float64   `json:"pledged"`
+	AmountDue       float64   `json:"amount_due"`
+	SettledAt       time.Time `json:"settled_at"`
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,256 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+var (
+	// ErrJudgingNotEnabled is returned when cause judging is not configured.
+	ErrJudgingNotEnabled = errors.New("cause judging is not enabled")
+	// ErrInvalidRound is returned for a round without a name or that closes before it opens.
+	ErrInvalidRound = errors.New("a judging round needs a name and must close after it opens")
+	// ErrInvalidRubric is returned for a rubric that is empty, repeats a criterion or has a
+	// criterion without a positive weight and maximum score.
+	ErrInvalidRubric = errors.New("rubric criteria need distinct names, a positive weight and a positive maximum score")
+	// ErrInvalidVoteShare is returned for a vote share outside 0 to 1.
+	ErrInvalidVoteShare = errors.New("vote share must be between 0 and 1")
+	// ErrFinalRoundExists is returned when a challenge is given a second final round.
+	ErrFinalRoundExists = errors.New("challenge already has a final judging round")
+	// ErrRoundNotOpen is returned when judges score or members vote outside the round's times.
+	ErrRoundNotOpen = errors.New("judging round is not open")
+	// ErrRoundClosed is returned when judges are changed after the round's results were tallied.
+	ErrRoundClosed = errors.New("judging round has closed")
+	// ErrRoundNotClosed is returned when results are asked for before the round closed.
+	ErrRoundNotClosed = errors.New("judging round has not closed yet")
+	// ErrInvalidJudgeWeight is returned for a negative judge weight.
+	ErrInvalidJudgeWeight = errors.New("judge weight must be positive")
+	// ErrAlreadyJudge is returned when a user is appointed to a round they already judge.
+	ErrAlreadyJudge = errors.New("user is already a judge in this round")
+	// ErrNotJudge is returned when a user who does not judge the round scores a cause.
+	ErrNotJudge = errors.New("user is not a judge in this round")
+	// ErrInvalidScore is returned for scores that miss a rubric criterion, name an unknown one
+	// or fall outside 0 and the criterion's maximum.
+	ErrInvalidScore = errors.New("scores must rate every rubric criterion between 0 and its maximum score")
+	// ErrCauseNotInChallenge is returned when a cause outside the round's challenge is scored
+	// or voted for.
+	ErrCauseNotInChallenge = errors.New("cause is not part of the round's challenge")
+	// ErrAlreadyVoted is returned when a member votes a second time in a round.
+	ErrAlreadyVoted = errors.New("user has already voted in this round")
+)
+
+// RoundStatus is where a judging round is in its schedule.
+type RoundStatus string
+
+const (
+	RoundScheduled RoundStatus = "scheduled" // before OpensAt
+	RoundOpen      RoundStatus = "open"      // judges score and members vote
+	RoundClosed    RoundStatus = "closed"    // after ClosesAt; results are tallied
+)
+
+// Criterion is one line of a judging rubric. A judge rates it from 0 to MaxScore, and Weight
+// says how much it counts against the other criteria.
+type Criterion struct {
+	Name     string  `json:"name"`
+	Weight   float64 `json:"weight"`
+	MaxScore float64 `json:"max_score"`
+}
+
+// JudgingRound is a window in which appointed judges score a challenge's causes against a
+// rubric and members cast community votes.
+type JudgingRound struct {
+	model.Base
+	ChallengeID string      `json:"challenge_id"`
+	Name        string      `json:"name"`
+	OpensAt     time.Time   `json:"opens_at"`
+	ClosesAt    time.Time   `json:"closes_at"`
+	Rubric      []Criterion `json:"rubric"`
+	VoteShare   float64     `json:"vote_share"`          // part of a cause's score from votes, the rest from judges
+	Final       bool        `json:"final"`               // its results award the challenge's CausePrice
+	ClosedAt    *time.Time  `json:"closed_at,omitempty"` // when the results were tallied
+}
+
+// Validate checks the round's name, schedule, rubric and vote share.
+func (r *JudgingRound) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" || r.OpensAt.IsZero() || !r.ClosesAt.After(r.OpensAt) {
+		return ErrInvalidRound
+	}
+	if r.VoteShare < 0 || r.VoteShare > 1 {
+		return ErrInvalidVoteShare
+	}
+	if len(r.Rubric) == 0 {
+		return ErrInvalidRubric
+	}
+	seen := make(map[string]bool, len(r.Rubric))
+	for i := range r.Rubric {
+		criterion := &r.Rubric[i]
+		criterion.Name = strings.TrimSpace(criterion.Name)
+		key := strings.ToLower(criterion.Name)
+		if criterion.Name == "" || seen[key] || criterion.Weight <= 0 || criterion.MaxScore <= 0 {
+			return ErrInvalidRubric
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// Status reports where the round is at now. A round is closed once ClosesAt has passed, even
+// before its results are tallied.
+func (r *JudgingRound) Status(now time.Time) RoundStatus {
+	switch {
+	case r.ClosedAt != nil || !now.Before(r.ClosesAt):
+		return RoundClosed
+	case now.Before(r.OpensAt):
+		return RoundScheduled
+	}
+	return RoundOpen
+}
+
+// Score rates a cause against the rubric, returning the weighted score out of 100. Every
+// criterion must be rated, case-insensitively by name, between 0 and its maximum.
+func (r *JudgingRound) Score(scores map[string]float64) (float64, error) {
+	if len(scores) != len(r.Rubric) {
+		return 0, ErrInvalidScore
+	}
+	byName := make(map[string]float64, len(scores))
+	for name, score := range scores {
+		byName[strings.ToLower(strings.TrimSpace(name))] = score
+	}
+
+	var total, weights float64
+	for _, criterion := range r.Rubric {
+		score, ok := byName[strings.ToLower(criterion.Name)]
+		if !ok || score < 0 || score > criterion.MaxScore {
+			return 0, ErrInvalidScore
+		}
+		total += criterion.Weight * score / criterion.MaxScore
+		weights += criterion.Weight
+	}
+	return 100 * total / weights, nil
+}
+
+// Judge is a user appointed by staff to score a round. Weight says how much their scores
+// count against the other judges'.
+type Judge struct {
+	model.Base
+	RoundID     string  `json:"round_id"`
+	UserID      string  `json:"user_id"`
+	Weight      float64 `json:"weight"`
+	AppointedBy string  `json:"appointed_by"` // staff member who appointed them
+}
+
+// JudgeScore is one judge's rating of one cause in a round. A judge may rescore a cause while
+// the round is open; the latest rating counts.
+type JudgeScore struct {
+	model.Base
+	RoundID string             `json:"round_id"`
+	CauseID string             `json:"cause_id"`
+	JudgeID string             `json:"judge_id"` // the judge's user ID
+	Scores  map[string]float64 `json:"scores"`   // rating per rubric criterion
+	Total   float64            `json:"total"`    // weighted score out of 100
+	Comment string             `json:"comment"`
+}
+
+// Vote is a member's community vote for a cause. Each member has one vote per round.
+type Vote struct {
+	model.Base
+	RoundID string `json:"round_id"`
+	CauseID string `json:"cause_id"`
+	UserID  string `json:"user_id"`
+}
+
+// RoundResult is one cause's placing in a closed round.
+type RoundResult struct {
+	model.Base
+	RoundID    string      `json:"round_id"`
+	CauseID    string      `json:"cause_id"`
+	Rank       int         `json:"rank"`
+	JudgeScore float64     `json:"judge_score"`     // judge-weighted average of the judges' totals, out of 100
+	Votes      int         `json:"votes"`           // community votes received
+	Score      float64     `json:"score"`           // judge and vote scores blended by VoteShare, out of 100
+	Prize      *Prize      `json:"prize,omitempty"` // the CausePrice prize for Rank, final rounds only
+}
+
+// Tally ranks the causes that were scored or voted for. A cause's judge score is the average
+// of its judges' totals weighted by judge; scores from users no longer judging are dropped.
+// Its vote score is its votes against the most any cause received. The two are blended by
+// VoteShare. Ties go to the higher judge score, then to more votes, then to the lower cause ID.
+// In a final round each place is awarded the challenge's CausePrice prize for its rank.
+func (r *JudgingRound) Tally(judges []*Judge, scores []*JudgeScore, votes map[string]int, challenge *Challenge) []*RoundResult {
+	weights := make(map[string]float64, len(judges))
+	for _, judge := range judges {
+		weights[judge.UserID] = judge.Weight
+	}
+
+	byCause := make(map[string]*RoundResult)
+	weighted := make(map[string]float64)
+	result := func(causeID string) *RoundResult {
+		res, ok := byCause[causeID]
+		if !ok {
+			res = &RoundResult{RoundID: r.ID, CauseID: causeID}
+			byCause[causeID] = res
+		}
+		return res
+	}
+	for _, score := range scores {
+		weight, ok := weights[score.JudgeID]
+		if !ok || weight <= 0 {
+			continue
+		}
+		res := result(score.CauseID)
+		res.JudgeScore += weight * score.Total
+		weighted[score.CauseID] += weight
+	}
+	mostVotes := 0
+	for causeID, count := range votes {
+		if count <= 0 {
+			continue
+		}
+		result(causeID).Votes = count
+		mostVotes = max(mostVotes, count)
+	}
+
+	results := make([]*RoundResult, 0, len(byCause))
+	for causeID, res := range byCause {
+		if weighted[causeID] > 0 {
+			res.JudgeScore /= weighted[causeID]
+		}
+		voteScore := 0.0
+		if mostVotes > 0 {
+			voteScore = 100 * float64(res.Votes) / float64(mostVotes)
+		}
+		res.Score = (1-r.VoteShare)*res.JudgeScore + r.VoteShare*voteScore
+		results = append(results, res)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.JudgeScore != b.JudgeScore {
+			return a.JudgeScore > b.JudgeScore
+		}
+		if a.Votes != b.Votes {
+			return a.Votes > b.Votes
+		}
+		return a.CauseID < b.CauseID
+	})
+	for i, res := range results {
+		res.Rank = i + 1
+		if r.Final && challenge != nil {
+			res.Prize = challenge.CausePrizeFor(res.Rank)
+		}
+	}
+	return results
+}
+
+// CausePrizeFor returns the CausePrice prize for a 1-based cause rank, or nil when there is none.
+func (c *Challenge) CausePrizeFor(rank int) *Prize {
+	return prizeFor(c.CausePrice, rank)
+}
@@ -0,0 +1,27 @@
+package model
+
+import "errors"
+
+var (
+	// ErrChallengeCancelled is returned when a user joins a cancelled challenge.
+	ErrChallengeCancelled = errors.New("challenge has been cancelled")
+	// ErrChallengeFinished is returned when a challenge that has closed or been cancelled is
+	// updated or cancelled.
+	ErrChallengeFinished = errors.New("challenge has already closed or been cancelled")
+	// ErrOutstandingSponsorships is returned when a challenge or cause whose sponsors are still
+	// owed a settlement is deleted without force.
+	ErrOutstandingSponsorships = errors.New("sponsors have outstanding obligations; only staff can force the delete")
+	// ErrSponsorsSettled is returned when a cause whose sponsors have been settled is deleted,
+	// which would lose their statements.
+	ErrSponsorsSettled = errors.New("sponsors have been settled; deleting would lose their statements")
+	// ErrAlreadyOwner is returned when ownership is transferred to the current owner.
+	ErrAlreadyOwner = errors.New("user already owns it")
+	// ErrOwnerChanged is returned when ownership changed hands while being transferred.
+	ErrOwnerChanged = errors.New("ownership changed while being transferred")
+)
+
+// Finished reports whether the challenge has closed or been cancelled. Its details are then
+// fixed.
+func (c *Challenge) Finished() bool {
+	return c.ClosedAt != nil || c.CancelledAt != nil
+}
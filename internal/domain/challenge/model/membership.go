@@ -0,0 +1,21 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrAlreadyMember is returned when a user joins a challenge or cause they already belong to.
+	ErrAlreadyMember = errors.New("user is already a member")
+	// ErrNotMember is returned when a user leaves a challenge or cause they do not belong to.
+	ErrNotMember = errors.New("user is not a member")
+	// ErrOwnerCannotLeave is returned when the owner of a challenge or cause tries to leave it.
+	ErrOwnerCannotLeave = errors.New("the owner cannot leave")
+)
+
+// Membership is one user's membership of a challenge or cause.
+type Membership struct {
+	UserID   string    `json:"user_id"`
+	JoinedAt time.Time `json:"joined_at"`
+}
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+var (
+	// ErrOrdersNotEnabled is returned when cause orders are not configured.
+	ErrOrdersNotEnabled = errors.New("cause orders are not enabled")
+	// ErrCauseNotForSale is returned when a cause that is not commercial, or has no unit price,
+	// is ordered.
+	ErrCauseNotForSale = errors.New("cause is not for sale")
+	// ErrInvalidQuantity is returned for an order of fewer than one unit.
+	ErrInvalidQuantity = errors.New("quantity must be at least 1")
+	// ErrAmountMismatch is returned when the amount a buyer was shown is not the quantity times
+	// the cause's current unit price.
+	ErrAmountMismatch = errors.New("amount does not match quantity times unit price")
+	// ErrOutOfStock is returned when a cause has fewer units left than ordered.
+	ErrOutOfStock = errors.New("not enough stock left")
+	// ErrInvalidStock is returned for a negative stock or one below the units already sold.
+	ErrInvalidStock = errors.New("stock cannot be negative or below the units already sold")
+	// ErrInvalidOrderState is returned when an order cannot move to the requested status.
+	ErrInvalidOrderState = errors.New("order status does not allow this operation")
+)
+
+// OrderStatus is where a cause order is in its life.
+type OrderStatus string
+
+const (
+	OrderPending   OrderStatus = "pending"   // placed, awaiting payment
+	OrderPaid      OrderStatus = "paid"      // payment confirmed; its units are sold
+	OrderFulfilled OrderStatus = "fulfilled" // delivered to the buyer
+	OrderRefunded  OrderStatus = "refunded"  // paid back; its units return to stock
+)
+
+// orderTransitions lists the allowed status changes. Refunded is terminal.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderPending:   {OrderPaid},
+	OrderPaid:      {OrderFulfilled, OrderRefunded},
+	OrderFulfilled: {OrderRefunded},
+}
+
+// CanTransitionTo reports whether an order may move from s to next.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Sold reports whether the order's units count as sold.
+func (s OrderStatus) Sold() bool {
+	return s == OrderPaid || s == OrderFulfilled
+}
+
+// CauseOrder is a buyer's order of units of a commercial cause.
+type CauseOrder struct {
+	model.Base
+	CauseID     string      `json:"cause_id"`
+	BuyerID     string      `json:"buyer_id"`
+	Quantity    int         `json:"quantity"`
+	UnitPrice   float64     `json:"unit_price"` // the cause's unit price when ordered
+	Amount      float64     `json:"amount"`     // Quantity times UnitPrice
+	Status      OrderStatus `json:"status"`
+	PaymentRef  string      `json:"payment_ref,omitempty"` // reference of the confirmed payment
+	PaidAt      *time.Time  `json:"paid_at,omitempty"`
+	FulfilledAt *time.Time  `json:"fulfilled_at,omitempty"`
+	RefundedAt  *time.Time  `json:"refunded_at,omitempty"`
+}
+
+// Available returns how many units are left to sell, or -1 when stock is unlimited.
+func (c *Cause) Available() int {
+	if c.Stock == nil {
+		return -1
+	}
+	return max(*c.Stock-c.UnitsSold, 0)
+}
+
+// NewOrder prices an order of quantity units at the cause's unit price, rounded to the cent,
+// and checks it against the amount the buyer was shown and the units left.
+func (c *Cause) NewOrder(buyerID string, quantity int, amount float64) (*CauseOrder, error) {
+	if !c.IsCommercial || c.UnitPrice <= 0 {
+		return nil, ErrCauseNotForSale
+	}
+	if quantity < 1 {
+		return nil, ErrInvalidQuantity
+	}
+	total := roundCents(c.UnitPrice * float64(quantity))
+	if math.Abs(total-amount) >= 0.005 {
+		return nil, fmt.Errorf("%w: %d x %.2f is %.2f", ErrAmountMismatch, quantity, c.UnitPrice, total)
+	}
+	if available := c.Available(); available >= 0 && quantity > available {
+		return nil, fmt.Errorf("%w: %d units left", ErrOutOfStock, available)
+	}
+	return &CauseOrder{
+		CauseID:   c.ID,
+		BuyerID:   buyerID,
+		Quantity:  quantity,
+		UnitPrice: c.UnitPrice,
+		Amount:    total,
+		Status:    OrderPending,
+	}, nil
+}
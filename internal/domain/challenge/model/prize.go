@@ -0,0 +1,67 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidPrizes is returned for a prize list that NormalizePrizes rejects.
+var ErrInvalidPrizes = errors.New("each prize needs a unique rank and an amount with its currency, a description, or both")
+
+// Prize is what one place wins: an amount of money, a description of what is awarded, or both.
+type Prize struct {
+	Rank        int     `json:"rank"`                  // 1-based place
+	Amount      float64 `json:"amount,omitempty"`      // money, in Currency
+	Currency    string  `json:"currency,omitempty"`    // ISO 4217 code, set with Amount
+	Description string  `json:"description,omitempty"` // e.g. "Gold medal and race kit"
+}
+
+// String describes the prize for notifications, e.g. "500.00 USD, Gold medal".
+func (p Prize) String() string {
+	var parts []string
+	if p.Amount > 0 {
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("%.2f %s", p.Amount, p.Currency)))
+	}
+	if p.Description != "" {
+		parts = append(parts, p.Description)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// NormalizePrizes checks a prize list and returns it sorted by rank. Prizes without a rank
+// take their position in the list, so a plain list runs first place first. Currencies are
+// upper-cased. Every prize needs an amount with a three-letter currency, a description, or
+// both, and no two prizes may share a rank.
+func NormalizePrizes(prizes []Prize) ([]Prize, error) {
+	normalized := make([]Prize, 0, len(prizes))
+	seen := make(map[int]bool, len(prizes))
+	for i, p := range prizes {
+		if p.Rank == 0 {
+			p.Rank = i + 1
+		}
+		p.Currency = strings.ToUpper(strings.TrimSpace(p.Currency))
+		p.Description = strings.TrimSpace(p.Description)
+		switch {
+		case p.Rank < 0 || seen[p.Rank]:
+			return nil, fmt.Errorf("%w: rank %d", ErrInvalidPrizes, p.Rank)
+		case p.Amount < 0, p.Amount > 0 && len(p.Currency) != 3, p.Amount == 0 && p.Description == "":
+			return nil, fmt.Errorf("%w: rank %d", ErrInvalidPrizes, p.Rank)
+		}
+		seen[p.Rank] = true
+		normalized = append(normalized, p)
+	}
+	sort.Slice(normalized, func(i, j int) bool { return normalized[i].Rank < normalized[j].Rank })
+	return normalized, nil
+}
+
+// prizeFor returns a copy of the prize for a 1-based rank, or nil when there is none.
+func prizeFor(prizes []Prize, rank int) *Prize {
+	for _, p := range prizes {
+		if p.Rank == rank {
+			return &p
+		}
+	}
+	return nil
+}
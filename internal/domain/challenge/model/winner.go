@@ -0,0 +1,265 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+)
+
+var (
+	// ErrInvalidChallengeSchedule is returned for an end time that cannot be parsed.
+	ErrInvalidChallengeSchedule = errors.New("invalid challenge schedule")
+	// ErrInvalidRankingCriterion is returned for an unknown ranking criterion.
+	ErrInvalidRankingCriterion = errors.New("ranking criterion must be distance, fastest or money")
+	// ErrWinnersNotEnabled is returned when winner selection is not configured.
+	ErrWinnersNotEnabled = errors.New("challenge winners are not enabled")
+	// ErrChallengeNotClosed is returned when winners are overridden before the challenge closed.
+	ErrChallengeNotClosed = errors.New("challenge has not closed yet")
+	// ErrInvalidWinners is returned for an override that repeats a user or exceeds NoOfWinner.
+	ErrInvalidWinners = errors.New("winners must be distinct and no more than the challenge's number of winners")
+	// ErrNotParticipant is returned when an override names a user who did not run in the challenge.
+	ErrNotParticipant = errors.New("user did not take part in the challenge")
+	// ErrOverrideReasonRequired is returned when staff override winners without saying why.
+	ErrOverrideReasonRequired = errors.New("a reason is required to override winners")
+)
+
+// RankingCriterion decides how participants are ranked when a challenge closes.
+type RankingCriterion string
+
+const (
+	RankByDistance RankingCriterion = "distance" // most distance covered
+	RankByFastest  RankingCriterion = "fastest"  // least time to cover DistanceToCover
+	RankByMoney    RankingCriterion = "money"    // most money raised
+)
+
+// Valid reports whether c is a known criterion. Empty means RankByDistance.
+func (c RankingCriterion) Valid() bool {
+	switch c {
+	case "", RankByDistance, RankByFastest, RankByMoney:
+		return true
+	}
+	return false
+}
+
+// OrDefault returns c, or RankByDistance when c is empty.
+func (c RankingCriterion) OrDefault() RankingCriterion {
+	if c == "" {
+		return RankByDistance
+	}
+	return c
+}
+
+// SetEnd parses end into EndsAt, keeping the raw string for clients that still read
+// end_duration. An empty end leaves the challenge open-ended.
+func (c *Challenge) SetEnd(end string) error {
+	endsAt, err := campaignModel.ParseCampaignTime(end)
+	if err != nil {
+		return fmt.Errorf("%w: unrecognised end %q", ErrInvalidChallengeSchedule, end)
+	}
+	c.EndDuration, c.EndsAt = end, endsAt
+	return nil
+}
+
+// Participant is one user's totals across the runs they counted in a challenge's causes.
+type Participant struct {
+	UserID      string
+	Distance    float64
+	Duration    time.Duration
+	MoneyRaised float64
+	JoinedAt    time.Time // earliest run, used to break ties
+}
+
+// Pace is the participant's average time per kilometre, or zero before they have run.
+func (p *Participant) Pace() time.Duration {
+	if p.Distance <= 0 {
+		return 0
+	}
+	return time.Duration(float64(p.Duration) / p.Distance)
+}
+
+// Participants totals counted runners per user. Runs held or rejected by anti-cheat review are
+// left out.
+func Participants(runners []*CauseRunner) []*Participant {
+	byUser := make(map[string]*Participant)
+	var participants []*Participant
+	for _, runner := range runners {
+		if !runner.Status.Counts() {
+			continue
+		}
+		joined := runner.DateJoined
+		if joined.IsZero() {
+			joined = runner.CreatedAt
+		}
+		p, ok := byUser[runner.OwnerID]
+		if !ok {
+			p = &Participant{UserID: runner.OwnerID, JoinedAt: joined}
+			byUser[runner.OwnerID] = p
+			participants = append(participants, p)
+		}
+		p.Distance += runner.DistanceCovered
+		p.Duration += runner.Duration
+		p.MoneyRaised += runner.MoneyRaised
+		if joined.Before(p.JoinedAt) {
+			p.JoinedAt = joined
+		}
+	}
+	return participants
+}
+
+// Rank orders the participants eligible under the challenge's criterion, best first:
+//   - distance: most distance, then least time;
+//   - fastest: only those who covered DistanceToCover (or any distance when none is set),
+//     least time to cover DistanceToCover at their average pace, then most distance;
+//   - money: most money raised, then most distance.
+//
+// Remaining ties go to whoever ran first, then to the lower user ID, so the order never
+// depends on how the runs were loaded.
+func (c *Challenge) Rank(participants []*Participant) []*Participant {
+	criterion := c.RankingCriterion.OrDefault()
+	var ranked []*Participant
+	for _, p := range participants {
+		switch criterion {
+		case RankByFastest:
+			if p.Duration > 0 && p.Distance > 0 && p.Distance >= c.DistanceToCover {
+				ranked = append(ranked, p)
+			}
+		case RankByMoney:
+			if p.MoneyRaised > 0 {
+				ranked = append(ranked, p)
+			}
+		default:
+			if p.Distance > 0 {
+				ranked = append(ranked, p)
+			}
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		switch criterion {
+		case RankByFastest:
+			// Everyone is timed over the same distance, so the lower pace is the faster time
+			// however far past DistanceToCover each ran.
+			if pa, pb := a.Pace(), b.Pace(); pa != pb {
+				return pa < pb
+			}
+			if a.Distance != b.Distance {
+				return a.Distance > b.Distance
+			}
+		case RankByMoney:
+			if a.MoneyRaised != b.MoneyRaised {
+				return a.MoneyRaised > b.MoneyRaised
+			}
+			if a.Distance != b.Distance {
+				return a.Distance > b.Distance
+			}
+		default:
+			if a.Distance != b.Distance {
+				return a.Distance > b.Distance
+			}
+			if a.Duration != b.Duration {
+				return a.Duration < b.Duration
+			}
+		}
+		if !a.JoinedAt.Equal(b.JoinedAt) {
+			return a.JoinedAt.Before(b.JoinedAt)
+		}
+		return a.UserID < b.UserID
+	})
+	return ranked
+}
+
+// PrizeFor returns the WinningPrice prize for a 1-based rank, or nil when there is none.
+func (c *Challenge) PrizeFor(rank int) *Prize {
+	return prizeFor(c.WinningPrice, rank)
+}
+
+// ChallengeWinner is a placing awarded when a challenge closes, or set by staff.
+type ChallengeWinner struct {
+	model.Base
+	ChallengeID string        `json:"challenge_id"`
+	Rank        int           `json:"rank"`
+	UserID      string        `json:"user_id"`
+	Distance    float64       `json:"distance"`
+	Duration    time.Duration `json:"duration"`
+	MoneyRaised float64       `json:"money_raised"`
+	Prize       *Prize        `json:"prize,omitempty"` // the WinningPrice prize for Rank
+	Overridden  bool          `json:"overridden"`      // placed by staff rather than by ranking
+}
+
+// SelectWinners ranks the participants and awards the top NoOfWinner places their prizes.
+func (c *Challenge) SelectWinners(participants []*Participant) []*ChallengeWinner {
+	ranked := c.Rank(participants)
+	if len(ranked) > c.NoOfWinner {
+		ranked = ranked[:max(c.NoOfWinner, 0)]
+	}
+	winners := make([]*ChallengeWinner, 0, len(ranked))
+	for i, p := range ranked {
+		winners = append(winners, c.winner(i+1, p))
+	}
+	return winners
+}
+
+// OverrideWinners places the given users in order, with the totals they ran, replacing the
+// ranked result. Every user must be a participant.
+func (c *Challenge) OverrideWinners(userIDs []string, participants []*Participant) ([]*ChallengeWinner, error) {
+	if len(userIDs) > c.NoOfWinner {
+		return nil, ErrInvalidWinners
+	}
+	byUser := make(map[string]*Participant, len(participants))
+	for _, p := range participants {
+		byUser[p.UserID] = p
+	}
+
+	seen := make(map[string]bool, len(userIDs))
+	winners := make([]*ChallengeWinner, 0, len(userIDs))
+	for i, userID := range userIDs {
+		if userID == "" || seen[userID] {
+			return nil, ErrInvalidWinners
+		}
+		seen[userID] = true
+		p, ok := byUser[userID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotParticipant, userID)
+		}
+		winner := c.winner(i+1, p)
+		winner.Overridden = true
+		winners = append(winners, winner)
+	}
+	return winners, nil
+}
+
+func (c *Challenge) winner(rank int, p *Participant) *ChallengeWinner {
+	return &ChallengeWinner{
+		ChallengeID: c.ID,
+		Rank:        rank,
+		UserID:      p.UserID,
+		Distance:    p.Distance,
+		Duration:    p.Duration,
+		MoneyRaised: p.MoneyRaised,
+		Prize:       c.PrizeFor(rank),
+	}
+}
+
+// WinnerAudit records a staff override of a challenge's winners.
+type WinnerAudit struct {
+	model.Base
+	ChallengeID string   `json:"challenge_id"`
+	ActorID     string   `json:"actor_id"` // staff member who made the change
+	Reason      string   `json:"reason"`
+	Previous    []string `json:"previous"` // winner user IDs by rank before the change
+	Winners     []string `json:"winners"`  // winner user IDs by rank after the change
+}
+
+// WinnerIDs returns the winners' user IDs in rank order.
+func WinnerIDs(winners []*ChallengeWinner) []string {
+	ids := make([]string, 0, len(winners))
+	for _, winner := range winners {
+		ids = append(ids, winner.UserID)
+	}
+	return ids
+}
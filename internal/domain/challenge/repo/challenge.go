@@ -1,15 +1,52 @@
 package repo
 
-import "gopi.com/internal/domain/challenge/model"
+import (
+	"time"
+
+	activityModel "gopi.com/internal/domain/activity/model"
+	"gopi.com/internal/domain/challenge/model"
+	domainModel "gopi.com/internal/domain/model"
+)
 
 type ChallengeRepository interface {
 	Create(challenge *model.Challenge) error
 	GetByID(id string) (*model.Challenge, error)
 	GetBySlug(slug string) (*model.Challenge, error)
 	GetByOwnerID(ownerID string) ([]*model.Challenge, error)
+	// Update saves the challenge's details. Its owner and closed and cancelled stamps are left
+	// alone.
 	Update(challenge *model.Challenge) error
+	// Delete removes the challenge with its causes and everything recorded against them: members,
+	// runners, sponsorships, orders, statements, judging and winners.
 	Delete(id string) error
 	List(limit, offset int) ([]*model.Challenge, error)
+	// ListNear returns a page of the challenges positioned within near, nearest first, and
+	// how many there are in total.
+	ListNear(near domainModel.Near, limit, offset int) ([]*model.Challenge, int64, error)
+
+	// Close-out methods
+	// ListDueForCloseout returns the unclosed, uncancelled challenges whose end has passed,
+	// earliest first.
+	ListDueForCloseout(now time.Time) ([]*model.Challenge, error)
+	// MarkClosed stamps closed_at once; it reports false if the challenge was already closed or
+	// has been cancelled.
+	MarkClosed(id string, at time.Time) (bool, error)
+
+	// Management methods
+	// MarkCancelled stamps cancelled_at; it reports false if the challenge has already closed or
+	// been cancelled.
+	MarkCancelled(id string, at time.Time) (bool, error)
+	// TransferOwner hands the challenge to toID and reports false if fromID no longer owns it.
+	TransferOwner(id, fromID, toID string) (bool, error)
+
+	// Many-to-many relationship methods
+	AddMember(challengeID, userID string) error
+	// RemoveMember reports false if the user was not a member.
+	RemoveMember(challengeID, userID string) (bool, error)
+	IsMember(challengeID, userID string) (bool, error)
+	// ListMembers returns a page of the challenge's members, earliest first, and how many there
+	// are in total.
+	ListMembers(challengeID string, limit, offset int) ([]*model.Membership, int64, error)
 }
 
 type CauseRepository interface {
@@ -18,8 +55,27 @@ type CauseRepository interface {
 	GetBySlug(slug string) (*model.Cause, error)
 	GetByChallengeID(challengeID string) ([]*model.Cause, error)
 	GetByOwnerID(ownerID string) ([]*model.Cause, error)
+	// Update saves the cause's details. Its owner, distance covered and units sold are left alone.
 	Update(cause *model.Cause) error
+	// Delete removes the cause with everything recorded against it: members, runners,
+	// sponsorships, buyers, orders, statements and judging scores, votes and results.
 	Delete(id string) error
+	// TransferOwner hands the cause to toID and reports false if fromID no longer owns it.
+	TransferOwner(id, fromID, toID string) (bool, error)
+	// IncrementDistance atomically adds to distance_covered.
+	IncrementDistance(id string, distance float64) error
+	// ListNear returns a page of the causes positioned within near, nearest first, and how
+	// many there are in total.
+	ListNear(near domainModel.Near, limit, offset int) ([]*model.Cause, int64, error)
+
+	// Many-to-many relationship methods
+	AddMember(causeID, userID string) error
+	// RemoveMember reports false if the user was not a member.
+	RemoveMember(causeID, userID string) (bool, error)
+	IsMember(causeID, userID string) (bool, error)
+	// ListMembers returns a page of the cause's members, earliest first, and how many there
+	// are in total.
+	ListMembers(causeID string, limit, offset int) ([]*model.Membership, int64, error)
 }
 
 type CauseRunnerRepository interface {
@@ -30,6 +86,78 @@ type CauseRunnerRepository interface {
 	Update(runner *model.CauseRunner) error
 	Delete(id string) error
 	GetLeaderboard() ([]*model.CauseRunner, error)
+	// ListForLeaderboard returns the counted runners of the causes who joined since a time (zero
+	// for all time), of one activity when activity is not empty.
+	ListForLeaderboard(causeIDs []string, since time.Time, activity string) ([]*model.CauseRunner, error)
+	// AttachTrack replaces the runner's distance, duration and elevation gain with the figures
+	// computed from an uploaded track. It only writes while the runner still has the distance
+	// and status it was read with, and reports false when it has since changed.
+	AttachTrack(runner *model.CauseRunner, distance float64, duration time.Duration, trackURL string, elevationGain float64) (bool, error)
+	// SumDistanceSince totals the counted (not flagged or rejected) runs of a user since a time.
+	SumDistanceSince(ownerID string, since time.Time) (float64, error)
+	ListByStatus(status activityModel.ReviewStatus, limit, offset int) ([]*model.CauseRunner, error)
+	// Flag holds the runner out of leaderboards until it is reviewed.
+	Flag(id string, violations []activityModel.Violation) error
+	// Review moves a flagged runner to status and reports false if it was no longer flagged.
+	Review(id string, status activityModel.ReviewStatus, reviewerID string, at time.Time) (bool, error)
+}
+
+// ChallengeWinnerRepository stores the winners chosen at close-out and staff overrides of them.
+type ChallengeWinnerRepository interface {
+	// SaveWinners replaces the challenge's winners. A non-nil audit is recorded in the same
+	// transaction.
+	SaveWinners(challengeID string, winners []*model.ChallengeWinner, audit *model.WinnerAudit) error
+	// GetWinners returns the challenge's winners by rank.
+	GetWinners(challengeID string) ([]*model.ChallengeWinner, error)
+	// ListAudits returns the challenge's winner overrides, oldest first.
+	ListAudits(challengeID string) ([]*model.WinnerAudit, error)
+}
+
+// JudgingRepository stores judging rounds with their judges, scores, votes and results.
+type JudgingRepository interface {
+	CreateRound(round *model.JudgingRound) error
+	GetRound(id string) (*model.JudgingRound, error)
+	// ListRounds returns the challenge's rounds in the order they open.
+	ListRounds(challengeID string) ([]*model.JudgingRound, error)
+	// ListDueRounds returns the untallied rounds that closed by now.
+	ListDueRounds(now time.Time) ([]*model.JudgingRound, error)
+	// MarkRoundClosed sets the round's closed time and reports false if it was already set.
+	MarkRoundClosed(id string, at time.Time) (bool, error)
+	AddJudge(judge *model.Judge) error
+	// GetJudge returns the user's appointment to the round, or nil when they do not judge it.
+	GetJudge(roundID, userID string) (*model.Judge, error)
+	ListJudges(roundID string) ([]*model.Judge, error)
+	// RemoveJudge reports false if the user did not judge the round.
+	RemoveJudge(roundID, userID string) (bool, error)
+	// SaveScore records the judge's score for the cause, replacing any earlier one.
+	SaveScore(score *model.JudgeScore) error
+	ListScores(roundID string) ([]*model.JudgeScore, error)
+	// CastVote records the vote and reports false if the user already voted in the round.
+	CastVote(vote *model.Vote) (bool, error)
+	// CountVotes returns the round's votes per cause ID.
+	CountVotes(roundID string) (map[string]int, error)
+	// SaveResults replaces the round's results.
+	SaveResults(roundID string, results []*model.RoundResult) error
+	// GetResults returns the round's results by rank.
+	GetResults(roundID string) ([]*model.RoundResult, error)
+}
+
+// BracketRepository stores knockout brackets with their matches.
+type BracketRepository interface {
+	// CreateBracket stores the bracket with all its matches in one transaction.
+	CreateBracket(bracket *model.Bracket, matches []*model.BracketMatch) error
+	GetBracket(id string) (*model.Bracket, error)
+	// GetChallengeBracket returns the challenge's bracket, or nil when it has none.
+	GetChallengeBracket(challengeID string) (*model.Bracket, error)
+	// ListMatches returns the bracket's matches by round and slot.
+	ListMatches(bracketID string) ([]*model.BracketMatch, error)
+	// ListDueMatches returns the undecided matches whose window closed by now, earliest round
+	// first.
+	ListDueMatches(now time.Time) ([]*model.BracketMatch, error)
+	// SaveDecision records the decided match and reports false if it was already decided. In
+	// the same transaction it stores the winner's side of next, or the bracket's champion when
+	// next is nil.
+	SaveDecision(bracket *model.Bracket, match, next *model.BracketMatch) (bool, error)
 }
 
 type SponsorChallengeRepository interface {
@@ -58,3 +186,41 @@ type CauseBuyerRepository interface {
 	Update(buyer *model.CauseBuyer) error
 	Delete(id string) error
 }
+
+// CauseOrderRepository stores orders of commercial causes. Transition moves an order on and
+// adjusts its cause's units sold together, refusing a sale beyond the cause's stock.
+type CauseOrderRepository interface {
+	Create(order *model.CauseOrder) error
+	GetByID(id string) (*model.CauseOrder, error)
+	ListByBuyer(buyerID string, limit, offset int) ([]*model.CauseOrder, int64, error)
+	ListByCause(causeID string, limit, offset int) ([]*model.CauseOrder, int64, error)
+	Transition(order *model.CauseOrder, to model.OrderStatus, at time.Time, soldDelta int) (bool, error)
+	SetStock(causeID string, stock *int) (bool, error)
+}
+
+// SponsorStatementRepository stores the settlement statements of causes' sponsors. SaveStatements
+// settles a cause once: it reports false, saving nothing, when the cause is already settled.
+type SponsorStatementRepository interface {
+	SaveStatements(causeID string, statements []*model.SponsorStatement) (bool, error)
+	ListByCause(causeID string) ([]*model.SponsorStatement, error)
+	ListBySponsor(sponsorID string) ([]*model.SponsorStatement, error)
+}
+
+// Repositories groups the challenge repositories bound to a single unit of work.
+type Repositories struct {
+	Challenges    ChallengeRepository
+	Causes        CauseRepository
+	CauseRunners  CauseRunnerRepository
+	Sponsors      SponsorChallengeRepository
+	SponsorCauses SponsorCauseRepository
+	CauseBuyers   CauseBuyerRepository
+	Winners       ChallengeWinnerRepository
+	Statements    SponsorStatementRepository
+	Judging       JudgingRepository
+}
+
+// UnitOfWork runs fn in one transaction: every write made through the repositories passed
+// to fn is committed together, or rolled back if fn returns an error.
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"errors"
+
+	"gopi.com/internal/domain/model"
+)
+
+// Gallery limits.
+const (
+	MaxGalleryItems  = 50
+	MaxCaptionLength = 500 // characters
+)
+
+var (
+	// ErrItemNotFound is returned when no gallery item has the given ID.
+	ErrItemNotFound = errors.New("media item not found")
+	// ErrSubjectNotFound is returned when the challenge, cause or campaign does not exist.
+	ErrSubjectNotFound = errors.New("gallery not found")
+	// ErrNotSubjectOwner is returned when someone other than the owner manages a gallery.
+	ErrNotSubjectOwner = errors.New("only the owner can manage this gallery")
+	// ErrCaptionTooLong is returned for captions over MaxCaptionLength characters.
+	ErrCaptionTooLong = errors.New("caption must be at most 500 characters")
+	// ErrGalleryFull is returned when a gallery already holds MaxGalleryItems items.
+	ErrGalleryFull = errors.New("gallery already holds 50 items")
+	// ErrInvalidOrder is returned when a new gallery order does not list every item exactly once.
+	ErrInvalidOrder = errors.New("order must list every item in the gallery exactly once")
+	// ErrKindNotEnabled is returned for challenge, cause or campaign galleries when the service
+	// was built without the repositories they need.
+	ErrKindNotEnabled = errors.New("galleries are not enabled for this kind of subject")
+)
+
+// SubjectKind is what a gallery is attached to.
+type SubjectKind string
+
+const (
+	SubjectChallenge SubjectKind = "challenge"
+	SubjectCause     SubjectKind = "cause"
+	SubjectCampaign  SubjectKind = "campaign"
+)
+
+// Type is what a gallery item holds.
+type Type string
+
+const (
+	TypeImage Type = "image" // an uploaded image
+	TypeVideo Type = "video" // a link to a video on a known provider
+)
+
+// Item is one image or video in a challenge, cause or campaign gallery. Videos carry
+// oEmbed-style metadata derived from their link.
+type Item struct {
+	model.Base
+	SubjectKind SubjectKind `json:"subject_kind"`
+	SubjectID   string      `json:"subject_id"`
+	Type        Type        `json:"type"`
+	Position    int         `json:"position"` // 1-based place in the gallery
+	Caption     string      `json:"caption"`
+	URL         string      `json:"url"` // the image, or the video's page
+	UploaderID  string      `json:"uploader_id"`
+
+	// images
+	FileKey     string `json:"file_key,omitempty"` // storage key of the upload
+	ContentType string `json:"content_type,omitempty"`
+	Size        int64  `json:"size,omitempty"` // bytes
+
+	// videos
+	Provider     string `json:"provider,omitempty"`
+	ProviderURL  string `json:"provider_url,omitempty"`
+	VideoID      string `json:"video_id,omitempty"`
+	EmbedURL     string `json:"embed_url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	Width        int    `json:"width,omitempty"`
+	Height       int    `json:"height,omitempty"`
+	EmbedHTML    string `json:"embed_html,omitempty"`
+}
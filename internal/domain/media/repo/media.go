@@ -0,0 +1,22 @@
+package repo
+
+import mediaModel "gopi.com/internal/domain/media/model"
+
+// MediaRepository abstracts persistence for challenge, cause and campaign galleries.
+type MediaRepository interface {
+	// Append adds the item at the end of its gallery and sets its position. It reports false,
+	// adding nothing, when the gallery already holds max items.
+	Append(item *mediaModel.Item, max int) (bool, error)
+	// GetByID returns model.ErrItemNotFound if there is no such item.
+	GetByID(id string) (*mediaModel.Item, error)
+	// ListGallery returns the subject's items in gallery order.
+	ListGallery(kind mediaModel.SubjectKind, subjectID string) ([]*mediaModel.Item, error)
+	UpdateCaption(id, caption string) error
+	// Reorder puts the gallery in the order of ids, first first. It returns
+	// model.ErrInvalidOrder unless ids lists every item in the gallery exactly once.
+	Reorder(kind mediaModel.SubjectKind, subjectID string, ids []string) error
+	// Delete removes the item and closes the gap it leaves in the gallery order.
+	Delete(id string) error
+	// DeleteGallery removes every item in the subject's gallery and returns what it removed.
+	DeleteGallery(kind mediaModel.SubjectKind, subjectID string) ([]*mediaModel.Item, error)
+}
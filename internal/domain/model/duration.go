@@ -0,0 +1,170 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrInvalidDuration is returned by ParseDuration for input it cannot read.
+var ErrInvalidDuration = errors.New("invalid duration")
+
+// ParseDuration reads an activity duration in any of the forms clients have sent over time:
+//
+//	"45", "25:30", "1:02:03"      seconds, MM:SS and H:MM:SS
+//	"45 min", "1h 30m", "2 hours" numbers with units
+//	"PT1H2M3S", "P1DT2H"          ISO-8601 durations
+//
+// An empty string is zero. The result is truncated to whole seconds.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	var (
+		d   time.Duration
+		err error
+	)
+	switch {
+	case strings.Contains(s, ":"):
+		d, err = parseClock(s)
+	case s[0] == 'P' || s[0] == 'p':
+		d, err = parseISO8601(s)
+	default:
+		d, err = parseUnits(s)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w %q", ErrInvalidDuration, s)
+	}
+	return d.Truncate(time.Second), nil
+}
+
+// FormatDuration renders d as "MM:SS", or "H:MM:SS" from one hour.
+func FormatDuration(d time.Duration) string {
+	total := int64(d.Round(time.Second).Seconds())
+	h, m, s := total/3600, total/60%60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
+
+// Seconds converts a duration to the whole seconds stored in the database.
+func Seconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
+
+// FromSeconds converts stored seconds back to a duration.
+func FromSeconds(seconds int64) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
+
+func parseClock(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, ErrInvalidDuration
+	}
+	var total int64
+	for _, part := range parts {
+		n, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil || n < 0 {
+			return 0, ErrInvalidDuration
+		}
+		total = total*60 + n
+	}
+	return FromSeconds(total), nil
+}
+
+// parseISO8601 reads P[nD][T[nH][nM][nS]]; weeks, months and years are not activity lengths.
+func parseISO8601(s string) (time.Duration, error) {
+	s = strings.ToUpper(s[1:])
+	date, clock, hasTime := strings.Cut(s, "T")
+	if s == "" || (hasTime && clock == "") {
+		return 0, ErrInvalidDuration
+	}
+
+	var total float64
+	for _, f := range []struct {
+		part  string
+		units map[byte]float64
+	}{
+		{date, map[byte]float64{'D': 86400}},
+		{clock, map[byte]float64{'H': 3600, 'M': 60, 'S': 1}},
+	} {
+		rest := f.part
+		for rest != "" {
+			i := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' && r != ',' })
+			if i <= 0 {
+				return 0, ErrInvalidDuration
+			}
+			unit, ok := f.units[rest[i]]
+			if !ok {
+				return 0, ErrInvalidDuration
+			}
+			n, err := strconv.ParseFloat(strings.Replace(rest[:i], ",", ".", 1), 64)
+			if err != nil {
+				return 0, ErrInvalidDuration
+			}
+			total += n * unit
+			rest = rest[i+1:]
+		}
+	}
+	return time.Duration(total * float64(time.Second)), nil
+}
+
+var durationUnits = map[string]time.Duration{
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+}
+
+// parseUnits reads numbers followed by units ("1h 30m", "45 min", "1 hour and 5 minutes").
+// A bare number is seconds.
+func parseUnits(s string) (time.Duration, error) {
+	s = strings.ToLower(s)
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		if n < 0 {
+			return 0, ErrInvalidDuration
+		}
+		return time.Duration(n * float64(time.Second)), nil
+	}
+
+	var total time.Duration
+	var matched bool
+	rest := s
+	for {
+		rest = strings.TrimLeft(rest, " ,")
+		rest = strings.TrimPrefix(rest, "and ")
+		if rest == "" {
+			break
+		}
+		i := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' })
+		if i <= 0 {
+			return 0, ErrInvalidDuration
+		}
+		n, err := strconv.ParseFloat(rest[:i], 64)
+		if err != nil {
+			return 0, ErrInvalidDuration
+		}
+		rest = strings.TrimLeft(rest[i:], " ")
+		j := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsLetter(r) })
+		if j < 0 {
+			j = len(rest)
+		}
+		unit, ok := durationUnits[rest[:j]]
+		if !ok {
+			return 0, ErrInvalidDuration
+		}
+		total += time.Duration(n * float64(unit))
+		rest = rest[j:]
+		matched = true
+	}
+	if !matched {
+		return 0, ErrInvalidDuration
+	}
+	return total, nil
+}
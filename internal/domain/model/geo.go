@@ -0,0 +1,135 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidCoordinates is returned for latitudes outside [-90, 90], longitudes outside
+// [-180, 180] or text that is not a "lat,lon" pair.
+var ErrInvalidCoordinates = errors.New("invalid coordinates")
+
+const earthRadiusKm = 6371.0088
+
+// GeoPoint is a WGS84 position in decimal degrees.
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// NewGeoPoint validates lat and lon and returns them as a point.
+func NewGeoPoint(lat, lon float64) (GeoPoint, error) {
+	if math.IsNaN(lat) || math.IsNaN(lon) || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return GeoPoint{}, fmt.Errorf("%w: %g,%g", ErrInvalidCoordinates, lat, lon)
+	}
+	return GeoPoint{Lat: lat, Lon: lon}, nil
+}
+
+// ParseGeoPoint reads a "lat,lon" pair such as "6.5244,3.3792".
+func ParseGeoPoint(s string) (GeoPoint, error) {
+	latText, lonText, ok := strings.Cut(s, ",")
+	if !ok {
+		return GeoPoint{}, fmt.Errorf("%w: %q", ErrInvalidCoordinates, s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latText), 64)
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("%w: %q", ErrInvalidCoordinates, s)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(lonText), 64)
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("%w: %q", ErrInvalidCoordinates, s)
+	}
+	return NewGeoPoint(lat, lon)
+}
+
+// DistanceKm returns the great-circle (haversine) distance between two points.
+func (p GeoPoint) DistanceKm(q GeoPoint) float64 {
+	lat1 := p.Lat * math.Pi / 180
+	lat2 := q.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (q.Lon - p.Lon) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox is a latitude/longitude rectangle. When it crosses the antimeridian MinLon is
+// greater than MaxLon and the box covers MinLon..180 and -180..MaxLon.
+type BoundingBox struct {
+	MinLat, MaxLat float64
+	MinLon, MaxLon float64
+}
+
+// CrossesAntimeridian reports whether the box wraps around longitude ±180.
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLon > b.MaxLon
+}
+
+// BoundingBox returns a box containing every point within radiusKm of p. It is a cheap
+// prefilter: corners of the box are further than radiusKm away, so matches still need
+// checking with DistanceKm.
+func (p GeoPoint) BoundingBox(radiusKm float64) BoundingBox {
+	dLat := radiusKm / earthRadiusKm * 180 / math.Pi
+	box := BoundingBox{MinLat: p.Lat - dLat, MaxLat: p.Lat + dLat, MinLon: -180, MaxLon: 180}
+
+	// Boxes reaching a pole span every longitude.
+	if box.MinLat <= -90 || box.MaxLat >= 90 {
+		box.MinLat = math.Max(box.MinLat, -90)
+		box.MaxLat = math.Min(box.MaxLat, 90)
+		return box
+	}
+
+	ratio := math.Sin(radiusKm/earthRadiusKm) / math.Cos(p.Lat*math.Pi/180)
+	if ratio >= 1 {
+		return box
+	}
+	dLon := math.Asin(ratio) * 180 / math.Pi
+	box.MinLon = p.Lon - dLon
+	box.MaxLon = p.Lon + dLon
+	if box.MinLon < -180 {
+		box.MinLon += 360
+	}
+	if box.MaxLon > 180 {
+		box.MaxLon -= 360
+	}
+	return box
+}
+
+// Near selects positions within RadiusKm of Center.
+type Near struct {
+	Center   GeoPoint
+	RadiusKm float64
+}
+
+// Contains reports whether p lies within the radius. A nil p (no known position) never does.
+func (n Near) Contains(p *GeoPoint) bool {
+	return p != nil && n.Center.DistanceKm(*p) <= n.RadiusKm
+}
+
+// Latitude and Longitude split an optional point into nullable columns; FromLatLon joins
+// them again and returns nil unless both are set.
+func Latitude(p *GeoPoint) *float64 {
+	if p == nil {
+		return nil
+	}
+	lat := p.Lat
+	return &lat
+}
+
+func Longitude(p *GeoPoint) *float64 {
+	if p == nil {
+		return nil
+	}
+	lon := p.Lon
+	return &lon
+}
+
+func FromLatLon(lat, lon *float64) *GeoPoint {
+	if lat == nil || lon == nil {
+		return nil
+	}
+	return &GeoPoint{Lat: *lat, Lon: *lon}
+}
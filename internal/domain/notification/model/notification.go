@@ -0,0 +1,33 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"gopi.com/internal/domain/model"
+)
+
+// ErrNotificationNotFound is returned when a notification does not exist or belongs to
+// another user.
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// Kind says what a notification is about.
+type Kind string
+
+const (
+	KindCampaignMilestone Kind = "campaign_milestone"
+	KindChallengeWinner   Kind = "challenge_winner"
+)
+
+// Notification is an in-app message for one user. SourceID names the event that produced it,
+// so the same event never notifies a user twice.
+type Notification struct {
+	model.Base
+	UserID   string     `json:"user_id"`
+	Kind     Kind       `json:"kind"`
+	SourceID string     `json:"source_id"`
+	Title    string     `json:"title"`
+	Body     string     `json:"body"`
+	Link     string     `json:"link,omitempty"` // app path to open, e.g. /campaigns/<slug>
+	ReadAt   *time.Time `json:"read_at,omitempty"`
+}
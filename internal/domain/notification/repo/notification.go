@@ -0,0 +1,19 @@
+package repo
+
+import (
+	"time"
+
+	"gopi.com/internal/domain/notification/model"
+)
+
+type NotificationRepository interface {
+	// CreateBatch stores notifications, skipping any whose user, kind and source already have one.
+	CreateBatch(notifications []*model.Notification) error
+	// ListByUser returns a page of the user's notifications, newest first.
+	ListByUser(userID string, unreadOnly bool, limit, offset int) ([]*model.Notification, error)
+	CountUnread(userID string) (int64, error)
+	// MarkRead stamps a notification of userID as read, or returns model.ErrNotificationNotFound.
+	MarkRead(id, userID string, at time.Time) error
+	// MarkAllRead stamps every unread notification of userID and returns how many changed.
+	MarkAllRead(userID string, at time.Time) (int64, error)
+}
@@ -0,0 +1,245 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	// ErrEmptyTrack is returned when a track has fewer than two positioned points.
+	ErrEmptyTrack = errors.New("track has no usable points")
+)
+
+const (
+	earthRadiusMeters = 6371008.8
+
+	// MinMovingSpeed is the speed (m/s) below which a segment counts as stopped rather than moving.
+	MinMovingSpeed = 0.5
+	// elevationNoise is the climb (m) that must accumulate before it counts towards the gain,
+	// so GPS altitude jitter on flat ground is not reported as climbing.
+	elevationNoise = 2.0
+	// splitDistance is the length of one split in meters.
+	splitDistance = 1000.0
+)
+
+// Point is a single recorded position. Time is zero when the source carried no timestamps.
+type Point struct {
+	Lat       float64
+	Lon       float64
+	Elevation float64
+	HasEle    bool
+	Time      time.Time
+}
+
+// Track is an ordered list of points recorded during one activity.
+type Track struct {
+	Points []Point
+}
+
+// Split summarises one kilometre of the track; the last split may be shorter.
+type Split struct {
+	Index         int     `json:"index"`
+	Distance      float64 `json:"distance"`       // km
+	Seconds       float64 `json:"seconds"`        // 0 when the track has no timestamps
+	ElevationGain float64 `json:"elevation_gain"` // m
+}
+
+// Summary holds the figures computed from a track.
+type Summary struct {
+	Distance      float64       // km
+	MovingTime    time.Duration // time spent above MinMovingSpeed
+	ElapsedTime   time.Duration // first to last timestamp
+	ElevationGain float64       // m
+	Splits        []Split
+}
+
+// Haversine returns the great-circle distance in meters between two points.
+func Haversine(a, b Point) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Summarize computes distance, moving time, elevation gain and kilometre splits.
+func (t *Track) Summarize() (Summary, error) {
+	if len(t.Points) < 2 {
+		return Summary{}, ErrEmptyTrack
+	}
+
+	var (
+		summary   Summary
+		meters    float64
+		gain      float64
+		ref       = t.Points[0].Elevation
+		split     = Split{Index: 1}
+		splitFrom = 0.0 // meters at which the current split started
+		splitTime = t.Points[0].Time
+	)
+
+	for i := 1; i < len(t.Points); i++ {
+		prev, cur := t.Points[i-1], t.Points[i]
+		d := Haversine(prev, cur)
+
+		var dt time.Duration
+		if !prev.Time.IsZero() && !cur.Time.IsZero() && cur.Time.After(prev.Time) {
+			dt = cur.Time.Sub(prev.Time)
+			if d/dt.Seconds() >= MinMovingSpeed {
+				summary.MovingTime += dt
+			}
+		}
+
+		var climb float64
+		if prev.HasEle && cur.HasEle {
+			switch {
+			case cur.Elevation-ref >= elevationNoise:
+				climb = cur.Elevation - ref
+				ref = cur.Elevation
+			case cur.Elevation < ref:
+				ref = cur.Elevation
+			}
+		} else if cur.HasEle {
+			ref = cur.Elevation
+		}
+		gain += climb
+		split.ElevationGain += climb
+
+		// Close every split boundary this segment crosses, interpolating the crossing time.
+		for d > 0 && meters+d >= splitFrom+splitDistance {
+			frac := (splitFrom + splitDistance - meters) / d
+			at := prev.Time
+			if dt > 0 {
+				at = prev.Time.Add(time.Duration(frac * float64(dt)))
+			}
+			split.Distance = splitDistance / 1000
+			if !splitTime.IsZero() && !at.IsZero() {
+				split.Seconds = at.Sub(splitTime).Seconds()
+			}
+			summary.Splits = append(summary.Splits, split.finish())
+			split = Split{Index: split.Index + 1}
+			splitFrom += splitDistance
+			splitTime = at
+		}
+		meters += d
+	}
+
+	if rest := meters - splitFrom; rest > 1 {
+		split.Distance = rest / 1000
+		last := t.Points[len(t.Points)-1].Time
+		if !splitTime.IsZero() && !last.IsZero() {
+			split.Seconds = last.Sub(splitTime).Seconds()
+		}
+		summary.Splits = append(summary.Splits, split.finish())
+	}
+
+	first, last := t.Points[0].Time, t.Points[len(t.Points)-1].Time
+	if !first.IsZero() && !last.IsZero() && last.After(first) {
+		summary.ElapsedTime = last.Sub(first)
+	}
+	summary.Distance = meters / 1000
+	summary.ElevationGain = math.Round(gain*10) / 10
+	return summary, nil
+}
+
+func (s Split) finish() Split {
+	s.Distance = math.Round(s.Distance*1000) / 1000
+	s.Seconds = math.Round(s.Seconds)
+	s.ElevationGain = math.Round(s.ElevationGain*10) / 10
+	return s
+}
+
+// Simplify returns a copy of the track reduced with the Douglas-Peucker algorithm: points that
+// lie within tolerance meters of the line between their neighbours are dropped. The first and
+// last points are always kept.
+func (t *Track) Simplify(tolerance float64) *Track {
+	n := len(t.Points)
+	if n < 3 {
+		return &Track{Points: append([]Point(nil), t.Points...)}
+	}
+
+	keep := make([]bool, n)
+	keep[0], keep[n-1] = true, true
+
+	// An explicit stack keeps long tracks from recursing thousands of frames deep.
+	type span struct{ from, to int }
+	stack := []span{{0, n - 1}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		maxDist, index := 0.0, -1
+		for i := s.from + 1; i < s.to; i++ {
+			if d := crossTrackDistance(t.Points[i], t.Points[s.from], t.Points[s.to]); d > maxDist {
+				maxDist, index = d, i
+			}
+		}
+		if index >= 0 && maxDist > tolerance {
+			keep[index] = true
+			stack = append(stack, span{s.from, index}, span{index, s.to})
+		}
+	}
+
+	simplified := &Track{}
+	for i, p := range t.Points {
+		if keep[i] {
+			simplified.Points = append(simplified.Points, p)
+		}
+	}
+	return simplified
+}
+
+// crossTrackDistance returns the distance in meters from p to the segment a-b, using an
+// equirectangular projection that is accurate enough at GPS sampling distances.
+func crossTrackDistance(p, a, b Point) float64 {
+	cosLat := math.Cos(a.Lat * math.Pi / 180)
+	project := func(q Point) (float64, float64) {
+		return (q.Lon - a.Lon) * math.Pi / 180 * cosLat * earthRadiusMeters,
+			(q.Lat - a.Lat) * math.Pi / 180 * earthRadiusMeters
+	}
+	px, py := project(p)
+	bx, by := project(b)
+
+	lengthSq := bx*bx + by*by
+	if lengthSq == 0 {
+		return math.Hypot(px, py)
+	}
+	u := math.Max(0, math.Min(1, (px*bx+py*by)/lengthSq))
+	return math.Hypot(px-u*bx, py-u*by)
+}
+
+// GeoJSONGeometry is a GeoJSON LineString; coordinates are [lon, lat] or [lon, lat, ele].
+type GeoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+// GeoJSONFeature is a GeoJSON Feature wrapping the route geometry.
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   GeoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// GeoJSON returns the track as a LineString feature suitable for map rendering.
+func (t *Track) GeoJSON(properties map[string]interface{}) GeoJSONFeature {
+	coords := make([][]float64, 0, len(t.Points))
+	for _, p := range t.Points {
+		if p.HasEle {
+			coords = append(coords, []float64{p.Lon, p.Lat, p.Elevation})
+		} else {
+			coords = append(coords, []float64{p.Lon, p.Lat})
+		}
+	}
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	return GeoJSONFeature{
+		Type:       "Feature",
+		Geometry:   GeoJSONGeometry{Type: "LineString", Coordinates: coords},
+		Properties: properties,
+	}
+}
@@ -6,6 +6,7 @@ type UserRepository interface {
 	// Basic CRUD operations
 	Create(user *model.User) error
 	GetByID(id string) (*model.User, error)
+	GetByIDs(ids []string) ([]*model.User, error) // unknown ids are skipped
 	GetByEmail(email string) (*model.User, error)
 	GetByUsername(username string) (*model.User, error)
 	Update(user *model.User) error
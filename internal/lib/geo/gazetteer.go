@@ -0,0 +1,100 @@
+package geo
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopi.com/internal/domain/model"
+)
+
+// Gazetteer geocodes offline against a fixed list of places. Names are matched ignoring case
+// and punctuation, so "Ikeja, Lagos" and "ikeja lagos" are the same place.
+type Gazetteer struct {
+	places map[string]model.GeoPoint
+}
+
+// LoadGazetteer reads a gazetteer CSV file; see ReadGazetteer for the format.
+func LoadGazetteer(path string) (*Gazetteer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open gazetteer: %w", err)
+	}
+	defer f.Close()
+	return ReadGazetteer(f)
+}
+
+// ReadGazetteer reads "name,latitude,longitude" rows. Lines starting with # are comments and
+// a header row is skipped. A later row with the same name replaces an earlier one.
+func ReadGazetteer(r io.Reader) (*Gazetteer, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+
+	g := &Gazetteer{places: make(map[string]model.GeoPoint)}
+	for record := 1; ; record++ {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read gazetteer: %w", err)
+		}
+
+		lat, latErr := strconv.ParseFloat(row[1], 64)
+		lon, lonErr := strconv.ParseFloat(row[2], 64)
+		if latErr != nil || lonErr != nil {
+			if record == 1 {
+				continue // header
+			}
+			pos, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("read gazetteer: line %d: %w", pos, model.ErrInvalidCoordinates)
+		}
+		point, err := model.NewGeoPoint(lat, lon)
+		if err != nil {
+			pos, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("read gazetteer: line %d: %w", pos, err)
+		}
+		if key := placeKey(row[0]); key != "" {
+			g.places[key] = point
+		}
+	}
+	return g, nil
+}
+
+// Len returns the number of places in the gazetteer.
+func (g *Gazetteer) Len() int {
+	return len(g.places)
+}
+
+// Geocode looks up the whole query first, then drops comma-separated parts from the end
+// ("Ikeja, Lagos, Nigeria" tries "Ikeja, Lagos" then "Ikeja"), and finally tries the
+// remaining parts on their own, so the most specific known place wins.
+func (g *Gazetteer) Geocode(ctx context.Context, query string) (model.GeoPoint, error) {
+	parts := strings.Split(query, ",")
+	for n := len(parts); n > 0; n-- {
+		if p, ok := g.places[placeKey(strings.Join(parts[:n], " "))]; ok {
+			return p, nil
+		}
+	}
+	for _, part := range parts[1:] {
+		if p, ok := g.places[placeKey(part)]; ok {
+			return p, nil
+		}
+	}
+	return model.GeoPoint{}, fmt.Errorf("%w: %q", ErrNotFound, query)
+}
+
+// placeKey lower-cases s and reduces every run of non-alphanumeric characters to one space.
+func placeKey(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
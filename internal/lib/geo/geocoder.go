@@ -0,0 +1,64 @@
+// Package geo turns free-form place names into coordinates.
+package geo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"gopi.com/internal/domain/model"
+)
+
+// ErrNotFound is returned when a geocoder has no position for a query.
+var ErrNotFound = errors.New("location not found")
+
+// Geocoder resolves a place name such as "Ikeja, Lagos" to a position.
+// Implementations must be safe for concurrent use.
+type Geocoder interface {
+	// Geocode returns ErrNotFound when the query matches no known place.
+	Geocode(ctx context.Context, query string) (model.GeoPoint, error)
+}
+
+// Config selects and configures a geocoder.
+type Config struct {
+	// Backend: "gazetteer" or "none"
+	Backend string
+
+	// GazetteerPath is the CSV file read by the gazetteer backend.
+	GazetteerPath string
+}
+
+// New builds the geocoder named by cfg.Backend. It returns nil, nil for "none", which
+// leaves positions to be supplied explicitly.
+func New(cfg Config) (Geocoder, error) {
+	switch cfg.Backend {
+	case "none":
+		return nil, nil
+	case "", "gazetteer":
+		g, err := LoadGazetteer(cfg.GazetteerPath)
+		if err != nil {
+			return nil, err
+		}
+		return g, nil
+	default:
+		return nil, errors.New("unknown geocoder backend: " + cfg.Backend)
+	}
+}
+
+// Locate returns the position of location, or nil when there is no geocoder, the location is
+// blank or unknown, or the lookup fails. Failures are logged rather than returned so a
+// missing position never blocks saving the record it belongs to.
+func Locate(ctx context.Context, g Geocoder, location string) *model.GeoPoint {
+	if g == nil || strings.TrimSpace(location) == "" {
+		return nil
+	}
+	p, err := g.Geocode(ctx, location)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			slog.Warn("geocoding failed", "location", location, "err", err)
+		}
+		return nil
+	}
+	return &p
+}
@@ -0,0 +1,180 @@
+// Package gpstrack reads GPS activity files (GPX and TCX) into track points.
+package gpstrack
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	trackModel "gopi.com/internal/domain/track/model"
+)
+
+// ErrUnsupportedFormat is returned for files that are neither GPX nor TCX.
+var ErrUnsupportedFormat = errors.New("unsupported track format: expected GPX or TCX")
+
+// Format identifies the file type of an uploaded track.
+type Format string
+
+const (
+	FormatGPX Format = "gpx"
+	FormatTCX Format = "tcx"
+)
+
+// Parse reads a GPX or TCX document, detected from its root element, and returns its points
+// in recorded order. Points without a position (e.g. TCX heart-rate-only samples) are skipped.
+func Parse(r io.Reader) (*trackModel.Track, Format, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	format, err := detect(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var points []trackModel.Point
+	switch format {
+	case FormatGPX:
+		points, err = parseGPX(data)
+	case FormatTCX:
+		points, err = parseTCX(data)
+	}
+	if err != nil {
+		return nil, format, err
+	}
+	if len(points) < 2 {
+		return nil, format, trackModel.ErrEmptyTrack
+	}
+	return &trackModel.Track{Points: points}, format, nil
+}
+
+// detect returns the format named by the document's root element.
+func detect(data []byte) (Format, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", ErrUnsupportedFormat
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			switch strings.ToLower(start.Name.Local) {
+			case "gpx":
+				return FormatGPX, nil
+			case "trainingcenterdatabase":
+				return FormatTCX, nil
+			}
+			return "", ErrUnsupportedFormat
+		}
+	}
+}
+
+type gpxDocument struct {
+	Tracks []struct {
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+	Routes []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+}
+
+type gpxPoint struct {
+	Lat  float64  `xml:"lat,attr"`
+	Lon  float64  `xml:"lon,attr"`
+	Ele  *float64 `xml:"ele"`
+	Time string   `xml:"time"`
+}
+
+func parseGPX(data []byte) ([]trackModel.Point, error) {
+	var doc gpxDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var points []trackModel.Point
+	add := func(p gpxPoint) {
+		point := trackModel.Point{Lat: p.Lat, Lon: p.Lon, Time: parseTime(p.Time)}
+		if p.Ele != nil {
+			point.Elevation, point.HasEle = *p.Ele, true
+		}
+		points = append(points, point)
+	}
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				add(p)
+			}
+		}
+	}
+	// Planned routes carry no timestamps; only fall back to them when there is no recording.
+	if len(points) == 0 {
+		for _, rte := range doc.Routes {
+			for _, p := range rte.Points {
+				add(p)
+			}
+		}
+	}
+	return points, nil
+}
+
+type tcxDocument struct {
+	Activities []struct {
+		Laps []struct {
+			Tracks []struct {
+				Points []tcxPoint `xml:"Trackpoint"`
+			} `xml:"Track"`
+		} `xml:"Lap"`
+	} `xml:"Activities>Activity"`
+}
+
+type tcxPoint struct {
+	Time     string `xml:"Time"`
+	Position *struct {
+		Lat float64 `xml:"LatitudeDegrees"`
+		Lon float64 `xml:"LongitudeDegrees"`
+	} `xml:"Position"`
+	Altitude *float64 `xml:"AltitudeMeters"`
+}
+
+func parseTCX(data []byte) ([]trackModel.Point, error) {
+	var doc tcxDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var points []trackModel.Point
+	for _, activity := range doc.Activities {
+		for _, lap := range activity.Laps {
+			for _, trk := range lap.Tracks {
+				for _, p := range trk.Points {
+					if p.Position == nil {
+						continue
+					}
+					point := trackModel.Point{Lat: p.Position.Lat, Lon: p.Position.Lon, Time: parseTime(p.Time)}
+					if p.Altitude != nil {
+						point.Elevation, point.HasEle = *p.Altitude, true
+					}
+					points = append(points, point)
+				}
+			}
+		}
+	}
+	return points, nil
+}
+
+func parseTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
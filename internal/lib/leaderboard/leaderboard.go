@@ -0,0 +1,329 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrInvalidWindow is returned for a window other than daily, weekly, monthly or all_time.
+	ErrInvalidWindow = errors.New("window must be daily, weekly, monthly or all_time")
+	// ErrWindowUnavailable is returned when a scope cannot be ranked over a time window because
+	// its runs are not logged individually.
+	ErrWindowUnavailable = errors.New("time-windowed leaderboards are not available for this scope")
+)
+
+// ScopeKind is what a leaderboard ranks the runners of.
+type ScopeKind string
+
+const (
+	ScopeChallenge ScopeKind = "challenge" // runs across every cause of a challenge
+	ScopeCause     ScopeKind = "cause"
+	ScopeCampaign  ScopeKind = "campaign"
+)
+
+// Scope identifies the challenge, cause or campaign a leaderboard ranks.
+type Scope struct {
+	Kind ScopeKind
+	ID   string
+}
+
+func (s Scope) String() string {
+	return string(s.Kind) + ":" + s.ID
+}
+
+// Window limits a leaderboard to runs logged since the start of the current day, week or month.
+type Window string
+
+const (
+	WindowDaily   Window = "daily"
+	WindowWeekly  Window = "weekly" // weeks start on Monday
+	WindowMonthly Window = "monthly"
+	WindowAllTime Window = "all_time"
+)
+
+// ParseWindow parses a window query value. Empty means WindowAllTime.
+func ParseWindow(s string) (Window, error) {
+	switch w := Window(strings.ToLower(strings.TrimSpace(s))); w {
+	case "":
+		return WindowAllTime, nil
+	case WindowDaily, WindowWeekly, WindowMonthly, WindowAllTime:
+		return w, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidWindow, s)
+}
+
+// Since returns the start of the window containing now, in UTC, or the zero time for
+// WindowAllTime.
+func (w Window) Since(now time.Time) time.Time {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch w {
+	case WindowDaily:
+		return day
+	case WindowWeekly:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case WindowMonthly:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Time{}
+}
+
+// Board is one ranked list: a scope over a window, optionally of a single activity.
+type Board struct {
+	Scope    Scope
+	Window   Window
+	Since    time.Time // start of the window, zero for all time
+	Activity string    // lower-cased; empty for every activity
+}
+
+// Key names the board within its scope. It changes when the window rolls over, so a daily
+// board is never served the day after.
+func (b Board) Key() string {
+	activity := b.Activity
+	if activity == "" {
+		activity = "*"
+	}
+	return fmt.Sprintf("%s:%d:%s", b.Window, b.Since.Unix(), activity)
+}
+
+// Run is one counted activity, or a runner's totals, loaded from the database.
+type Run struct {
+	UserID      string
+	Activity    string
+	Distance    float64
+	Duration    time.Duration
+	MoneyRaised float64
+	CoverImage  string
+}
+
+// Entry is one user's totals and place on a board.
+type Entry struct {
+	Rank        int           `json:"rank"`
+	UserID      string        `json:"user_id"`
+	Distance    float64       `json:"distance"`
+	Duration    time.Duration `json:"duration"`
+	MoneyRaised float64       `json:"money_raised"`
+	Activity    string        `json:"activity"` // empty when the user logged more than one activity
+	CoverImage  string        `json:"cover_image"`
+	Runs        int           `json:"runs"`
+}
+
+// Rank totals runs per user and orders users by most distance, then least time, then user ID
+// so equal totals always come out in the same order. Users with no distance are left off.
+func Rank(runs []*Run) []*Entry {
+	byUser := make(map[string]*Entry)
+	var entries []*Entry
+	for _, run := range runs {
+		e, ok := byUser[run.UserID]
+		if !ok {
+			e = &Entry{UserID: run.UserID, Activity: run.Activity}
+			byUser[run.UserID] = e
+			entries = append(entries, e)
+		}
+		e.Distance += run.Distance
+		e.Duration += run.Duration
+		e.MoneyRaised += run.MoneyRaised
+		e.Runs++
+		if !strings.EqualFold(e.Activity, run.Activity) {
+			e.Activity = ""
+		}
+		if run.CoverImage != "" {
+			e.CoverImage = run.CoverImage
+		}
+	}
+
+	ranked := entries[:0]
+	for _, e := range entries {
+		if e.Distance > 0 {
+			ranked = append(ranked, e)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Distance != b.Distance {
+			return a.Distance > b.Distance
+		}
+		if a.Duration != b.Duration {
+			return a.Duration < b.Duration
+		}
+		return a.UserID < b.UserID
+	})
+	for i, e := range ranked {
+		e.Rank = i + 1
+	}
+	return ranked
+}
+
+// Query selects a page of a board and, when UserID is set, that user's place with up to
+// Neighbours users either side of them.
+type Query struct {
+	Window     Window
+	Activity   string
+	Limit      int
+	Offset     int
+	UserID     string
+	Neighbours int
+}
+
+// Standings is the answer to a Query.
+type Standings struct {
+	Board      Board
+	Entries    []*Entry
+	Total      int      // users on the whole board
+	Me         *Entry   // the querying user, nil when they are not ranked
+	Neighbours []*Entry // the users around Me, Me included
+}
+
+// Loader loads the runs of a scope logged since a time (zero for all time), of one activity
+// when activity is not empty.
+type Loader func(since time.Time, activity string) ([]*Run, error)
+
+// Store keeps ranked boards so repeat queries skip the database.
+type Store interface {
+	// Range returns up to limit entries after the first offset, best first, and the board's
+	// size. ok is false when the board is not stored.
+	Range(ctx context.Context, board Board, offset, limit int) (entries []*Entry, total int, ok bool, err error)
+	// Find returns the user's entry on a stored board, or nil when they are not on it.
+	Find(ctx context.Context, board Board, userID string) (*Entry, error)
+	// Save replaces the board with ranked entries for ttl.
+	Save(ctx context.Context, board Board, entries []*Entry, ttl time.Duration) error
+	// Invalidate drops every stored board of the scope.
+	Invalidate(ctx context.Context, scope Scope) error
+}
+
+// Leaderboards answers leaderboard queries from a Store when one is configured, and otherwise
+// ranks the runs straight from the database. A failing store is logged and bypassed.
+type Leaderboards struct {
+	store Store
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// New returns leaderboards cached in store for ttl. A nil store ranks from the database on
+// every query.
+func New(store Store, ttl time.Duration) *Leaderboards {
+	return &Leaderboards{store: store, ttl: ttl, now: time.Now}
+}
+
+// NewLeaderboardsFactory returns leaderboards kept in Redis sorted sets when client is not
+// nil, and ranked from the database otherwise.
+func NewLeaderboardsFactory(client *redis.Client, ttl time.Duration) *Leaderboards {
+	if client == nil {
+		return New(nil, ttl)
+	}
+	return New(NewRedisStore(client, ""), ttl)
+}
+
+// Cached reports whether boards are stored, so writers know whether to invalidate them.
+func (l *Leaderboards) Cached() bool {
+	return l.store != nil
+}
+
+// Standings answers q for scope, calling load when the board is not stored.
+func (l *Leaderboards) Standings(scope Scope, q Query, load Loader) (*Standings, error) {
+	window := q.Window
+	if window == "" {
+		window = WindowAllTime
+	}
+	board := Board{
+		Scope:    scope,
+		Window:   window,
+		Since:    window.Since(l.now()),
+		Activity: strings.ToLower(strings.TrimSpace(q.Activity)),
+	}
+
+	if l.store != nil {
+		standings, ok, err := l.fromStore(board, q)
+		if err == nil && ok {
+			return standings, nil
+		}
+		if err != nil {
+			slog.Warn("leaderboard store unavailable, ranking from database", "board", scope.String(), "err", err)
+		}
+	}
+
+	runs, err := load(board.Since, board.Activity)
+	if err != nil {
+		return nil, err
+	}
+	ranked := Rank(runs)
+	if l.store != nil {
+		if err := l.store.Save(context.Background(), board, ranked, l.ttl); err != nil {
+			slog.Warn("leaderboard store save failed", "board", scope.String(), "err", err)
+		}
+	}
+	return page(board, ranked, q), nil
+}
+
+// Invalidate drops the stored boards of scopes after their runs change.
+func (l *Leaderboards) Invalidate(scopes ...Scope) {
+	if l.store == nil {
+		return
+	}
+	for _, scope := range scopes {
+		if err := l.store.Invalidate(context.Background(), scope); err != nil {
+			slog.Warn("leaderboard invalidation failed", "board", scope.String(), "err", err)
+		}
+	}
+}
+
+func (l *Leaderboards) fromStore(board Board, q Query) (*Standings, bool, error) {
+	ctx := context.Background()
+	entries, total, ok, err := l.store.Range(ctx, board, q.Offset, q.Limit)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	standings := &Standings{Board: board, Entries: entries, Total: total}
+	if q.UserID == "" {
+		return standings, true, nil
+	}
+
+	me, err := l.store.Find(ctx, board, q.UserID)
+	if err != nil || me == nil {
+		return standings, true, err
+	}
+	standings.Me = me
+	first := max(me.Rank-1-q.Neighbours, 0)
+	neighbours, _, ok, err := l.store.Range(ctx, board, first, me.Rank-first+q.Neighbours)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	standings.Neighbours = neighbours
+	return standings, true, nil
+}
+
+// page cuts a query's page and the user's neighbours out of a ranked board.
+func page(board Board, ranked []*Entry, q Query) *Standings {
+	standings := &Standings{Board: board, Entries: rangeOf(ranked, q.Offset, q.Limit), Total: len(ranked)}
+	if q.UserID == "" {
+		return standings
+	}
+	for _, e := range ranked {
+		if e.UserID == q.UserID {
+			standings.Me = e
+			first := max(e.Rank-1-q.Neighbours, 0)
+			standings.Neighbours = rangeOf(ranked, first, e.Rank-first+q.Neighbours)
+			break
+		}
+	}
+	return standings
+}
+
+func rangeOf(ranked []*Entry, offset, limit int) []*Entry {
+	if offset >= len(ranked) {
+		return []*Entry{}
+	}
+	end := len(ranked)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return ranked[offset:end]
+}
@@ -0,0 +1,155 @@
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	totalField  = "#total"
+	entryPrefix = "u:"
+)
+
+// RedisStore keeps each board in two keys: a sorted set of user IDs scored by rank, for paging
+// and neighbour lookups, and a hash of the users' entries. Scoring by rank rather than distance
+// keeps the database's tie-breaks. Invalidating a scope bumps its generation, which every board
+// key includes, so stale boards are never read again and expire on their own.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore creates a Redis-backed board store.
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = "leaderboard:"
+	}
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) Range(ctx context.Context, board Board, offset, limit int) ([]*Entry, int, bool, error) {
+	key, err := s.boardKey(ctx, board)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	stop := int64(-1)
+	if limit > 0 {
+		stop = int64(offset + limit - 1)
+	}
+	pipe := s.client.Pipeline()
+	totalCmd := pipe.HGet(ctx, key+":entries", totalField)
+	membersCmd := pipe.ZRange(ctx, key+":ranks", int64(offset), stop)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, 0, false, err
+	}
+	total, err := totalCmd.Int()
+	if errors.Is(err, redis.Nil) {
+		return nil, 0, false, nil
+	}
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	members := membersCmd.Val()
+	entries := make([]*Entry, 0, len(members))
+	if len(members) == 0 {
+		return entries, total, true, nil
+	}
+	fields := make([]string, len(members))
+	for i, userID := range members {
+		fields[i] = entryPrefix + userID
+	}
+	values, err := s.client.HMGet(ctx, key+":entries", fields...).Result()
+	if err != nil {
+		return nil, 0, false, err
+	}
+	for _, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+			return nil, 0, false, err
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, total, true, nil
+}
+
+func (s *RedisStore) Find(ctx context.Context, board Board, userID string) (*Entry, error) {
+	key, err := s.boardKey(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := s.client.HGet(ctx, key+":entries", entryPrefix+userID).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entry Entry
+	if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *RedisStore) Save(ctx context.Context, board Board, entries []*Entry, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = time.Minute
+	}
+	key, err := s.boardKey(ctx, board)
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[string]interface{}, len(entries)+1)
+	fields[totalField] = len(entries)
+	members := make([]redis.Z, 0, len(entries))
+	for _, entry := range entries {
+		raw, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		fields[entryPrefix+entry.UserID] = raw
+		members = append(members, redis.Z{Score: float64(entry.Rank), Member: entry.UserID})
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key+":ranks", key+":entries")
+		if len(members) > 0 {
+			pipe.ZAdd(ctx, key+":ranks", members...)
+			pipe.Expire(ctx, key+":ranks", ttl)
+		}
+		pipe.HSet(ctx, key+":entries", fields)
+		pipe.Expire(ctx, key+":entries", ttl)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) Invalidate(ctx context.Context, scope Scope) error {
+	return s.client.Incr(ctx, s.generationKey(scope)).Err()
+}
+
+// boardKey prefixes the board's key with its scope's current generation.
+func (s *RedisStore) boardKey(ctx context.Context, board Board) (string, error) {
+	generation, err := s.client.Get(ctx, s.generationKey(board.Scope)).Result()
+	if errors.Is(err, redis.Nil) {
+		generation = "0"
+	} else if err != nil {
+		return "", err
+	}
+	return s.prefix + board.Scope.String() + ":" + generation + ":" + board.Key(), nil
+}
+
+func (s *RedisStore) generationKey(scope Scope) string {
+	return s.prefix + scope.String() + ":generation"
+}
@@ -0,0 +1,151 @@
+// Package oembed describes video links the way an oEmbed "video" response would, worked out
+// offline from the link alone. No provider is ever called, so titles and authors are not
+// known.
+package oembed
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ErrUnsupportedURL is returned for links that are not to a video on a known provider.
+var ErrUnsupportedURL = errors.New("not a supported video link; use YouTube, Vimeo or Dailymotion")
+
+// Video is the oEmbed-style description of a video link.
+type Video struct {
+	ProviderName string // e.g. "YouTube"
+	ProviderURL  string
+	VideoID      string
+	URL          string // canonical page of the video
+	EmbedURL     string // player to load in an iframe
+	ThumbnailURL string // empty when it cannot be derived from the link
+	Width        int
+	Height       int
+	HTML         string // iframe markup for EmbedURL at Width x Height
+}
+
+// Player sizes; YouTube Shorts are portrait.
+const (
+	landscapeWidth  = 640
+	landscapeHeight = 360
+)
+
+var (
+	youTubeID     = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoID       = regexp.MustCompile(`^[0-9]+$`)
+	dailymotionID = regexp.MustCompile(`^x[0-9a-z]+$`)
+)
+
+// Parse recognises YouTube, Vimeo and Dailymotion links, including short links, embed
+// players and YouTube Shorts, and returns ErrUnsupportedURL for anything else.
+func Parse(rawURL string) (*Video, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedURL, rawURL)
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	host = strings.TrimPrefix(host, "m.")
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+
+	var video *Video
+	switch host {
+	case "youtube.com", "youtube-nocookie.com":
+		video = parseYouTube(u, segments)
+	case "youtu.be":
+		if len(segments) == 1 {
+			video = youTube(segments[0], false)
+		}
+	case "vimeo.com", "player.vimeo.com":
+		video = parseVimeo(segments)
+	case "dailymotion.com":
+		if len(segments) >= 2 && (segments[0] == "video" || segments[0] == "embed" && len(segments) == 3 && segments[1] == "video") {
+			video = dailymotion(segments[len(segments)-1])
+		}
+	case "dai.ly":
+		if len(segments) == 1 {
+			video = dailymotion(segments[0])
+		}
+	}
+	if video == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedURL, rawURL)
+	}
+	video.HTML = fmt.Sprintf(`<iframe src="%s" width="%d" height="%d" frameborder="0" allow="autoplay; fullscreen; picture-in-picture" allowfullscreen></iframe>`,
+		html.EscapeString(video.EmbedURL), video.Width, video.Height)
+	return video, nil
+}
+
+func parseYouTube(u *url.URL, segments []string) *Video {
+	switch {
+	case len(segments) == 1 && segments[0] == "watch":
+		return youTube(u.Query().Get("v"), false)
+	case len(segments) == 2 && (segments[0] == "embed" || segments[0] == "live" || segments[0] == "v"):
+		return youTube(segments[1], false)
+	case len(segments) == 2 && segments[0] == "shorts":
+		return youTube(segments[1], true)
+	}
+	return nil
+}
+
+func youTube(id string, short bool) *Video {
+	if !youTubeID.MatchString(id) {
+		return nil
+	}
+	video := &Video{
+		ProviderName: "YouTube",
+		ProviderURL:  "https://www.youtube.com/",
+		VideoID:      id,
+		URL:          "https://www.youtube.com/watch?v=" + id,
+		EmbedURL:     "https://www.youtube.com/embed/" + id,
+		ThumbnailURL: "https://i.ytimg.com/vi/" + id + "/hqdefault.jpg",
+		Width:        landscapeWidth,
+		Height:       landscapeHeight,
+	}
+	if short {
+		video.URL = "https://www.youtube.com/shorts/" + id
+		video.Width, video.Height = landscapeHeight, landscapeWidth
+	}
+	return video
+}
+
+// parseVimeo takes the video ID from the last path segment, which covers vimeo.com/<id>,
+// vimeo.com/channels/<name>/<id> and player.vimeo.com/video/<id>.
+func parseVimeo(segments []string) *Video {
+	if len(segments) == 0 {
+		return nil
+	}
+	id := segments[len(segments)-1]
+	if !vimeoID.MatchString(id) {
+		return nil
+	}
+	return &Video{
+		ProviderName: "Vimeo",
+		ProviderURL:  "https://vimeo.com/",
+		VideoID:      id,
+		URL:          "https://vimeo.com/" + id,
+		EmbedURL:     "https://player.vimeo.com/video/" + id,
+		Width:        landscapeWidth,
+		Height:       landscapeHeight,
+	}
+}
+
+func dailymotion(id string) *Video {
+	// Links to a video page may carry a slug after the ID: /video/x8abc12_some-title.
+	id, _, _ = strings.Cut(id, "_")
+	if !dailymotionID.MatchString(id) {
+		return nil
+	}
+	return &Video{
+		ProviderName: "Dailymotion",
+		ProviderURL:  "https://www.dailymotion.com/",
+		VideoID:      id,
+		URL:          "https://www.dailymotion.com/video/" + id,
+		EmbedURL:     "https://www.dailymotion.com/embed/video/" + id,
+		ThumbnailURL: "https://www.dailymotion.com/thumbnail/video/" + id,
+		Width:        landscapeWidth,
+		Height:       landscapeHeight,
+	}
+}
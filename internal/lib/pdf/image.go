@@ -0,0 +1,94 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// Formats AddImage can decode besides JPEG.
+	_ "image/gif"
+	_ "image/png"
+)
+
+// ErrUnsupportedImage is returned for images that are not JPEG, PNG or GIF.
+var ErrUnsupportedImage = errors.New("pdf: unsupported image format")
+
+// Image is a raster image added to a Document. It can be drawn any number of times on any
+// of the document's pages while being stored once.
+type Image struct {
+	id            int
+	width, height int
+	colorSpace    string
+	filter        string
+	data          []byte
+}
+
+// Width is the image width in pixels.
+func (img *Image) Width() int {
+	return img.width
+}
+
+// Height is the image height in pixels.
+func (img *Image) Height() int {
+	return img.height
+}
+
+// AddImage adds a JPEG, PNG or GIF image to the document. JPEGs are stored as they are;
+// other formats are decoded and stored compressed, with any transparency flattened onto
+// white.
+func (d *Document) AddImage(data []byte) (*Image, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, ErrUnsupportedImage
+	}
+
+	img := &Image{id: len(d.images) + 1, width: cfg.Width, height: cfg.Height}
+	if format == "jpeg" {
+		img.filter = "DCTDecode"
+		img.data = data
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			img.colorSpace = "DeviceGray"
+		case color.CMYKModel:
+			img.colorSpace = "DeviceCMYK"
+		default:
+			img.colorSpace = "DeviceRGB"
+		}
+		// Reject files that only have a valid header rather than fail in the reader.
+		if _, err := jpeg.Decode(bytes.NewReader(data)); err != nil {
+			return nil, ErrUnsupportedImage
+		}
+	} else {
+		decoded, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, ErrUnsupportedImage
+		}
+		img.filter = "FlateDecode"
+		img.colorSpace = "DeviceRGB"
+		img.data = deflate(flattenRGB(decoded))
+	}
+
+	d.images = append(d.images, img)
+	return img, nil
+}
+
+// flattenRGB returns the pixels of img as 8-bit RGB triples, blending transparent pixels
+// with a white background.
+func flattenRGB(img image.Image) []byte {
+	bounds := img.Bounds()
+	out := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			// RGBA is alpha-premultiplied, so adding the uncovered share of white blends.
+			r, g, b, a := img.At(x, y).RGBA()
+			white := 0xffff - a
+			out = append(out, byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8))
+		}
+	}
+	return out
+}
@@ -0,0 +1,276 @@
+// Package pdf writes simple PDF documents: filled and outlined rectangles, single-line text
+// in the standard Helvetica fonts and raster images. Nothing is embedded except images, so
+// the output stays small and needs no font files.
+//
+// Coordinates are in points (1/72 inch) measured from the top-left corner of the page.
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Page sizes in points.
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Font is one of the standard fonts every PDF reader provides.
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+var fontNames = [...]string{Helvetica: "Helvetica", HelveticaBold: "Helvetica-Bold"}
+
+// Document is a PDF being built in memory. Pages and images are added to it and the whole
+// file is written by WriteTo.
+type Document struct {
+	width, height float64
+	title         string
+	pages         []*Page
+	images        []*Image
+}
+
+// NewDocument returns an empty document whose pages are width by height points.
+func NewDocument(width, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+// SetTitle sets the title readers show instead of the file name.
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// Page is a page of a Document. Drawing operations are appended in order, so later ones
+// paint over earlier ones.
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+	images  []*Image
+}
+
+// AddPage appends a blank page to the document.
+func (d *Document) AddPage() *Page {
+	p := &Page{doc: d}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// FillRect paints a rectangle with its top-left corner at x, y.
+func (p *Page) FillRect(x, y, w, h float64, c color.Color) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n", rgb(c), num(x), num(p.doc.height-y-h), num(w), num(h))
+}
+
+// StrokeRect outlines a rectangle with its top-left corner at x, y. The line is centred on
+// the rectangle's edges.
+func (p *Page) StrokeRect(x, y, w, h, lineWidth float64, c color.Color) {
+	fmt.Fprintf(&p.content, "%s RG %s w %s %s %s %s re S\n", rgb(c), num(lineWidth),
+		num(x), num(p.doc.height-y-h), num(w), num(h))
+}
+
+// Line draws a straight line from x1, y1 to x2, y2.
+func (p *Page) Line(x1, y1, x2, y2, lineWidth float64, c color.Color) {
+	fmt.Fprintf(&p.content, "%s RG %s w %s %s m %s %s l S\n", rgb(c), num(lineWidth),
+		num(x1), num(p.doc.height-y1), num(x2), num(p.doc.height-y2))
+}
+
+// Text draws s with its baseline starting at x, y. Characters outside the Windows-1252
+// range are shown as "?".
+func (p *Page) Text(x, y float64, font Font, size float64, c color.Color, s string) {
+	fmt.Fprintf(&p.content, "BT %s rg /F%d %s Tf %s %s Td %s Tj ET\n", rgb(c), int(font)+1, num(size),
+		num(x), num(p.doc.height-y), literal(encodeWinAnsi(s)))
+}
+
+// TextCentered draws s centred horizontally on x with its baseline at y.
+func (p *Page) TextCentered(x, y float64, font Font, size float64, c color.Color, s string) {
+	p.Text(x-TextWidth(font, size, s)/2, y, font, size, c, s)
+}
+
+// Image draws img scaled to fill the w by h box whose top-left corner is at x, y.
+func (p *Page) Image(img *Image, x, y, w, h float64) {
+	p.images = appendUnique(p.images, img)
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /Im%d Do Q\n", num(w), num(h), num(x), num(p.doc.height-y-h), img.id)
+}
+
+func appendUnique(images []*Image, img *Image) []*Image {
+	for _, existing := range images {
+		if existing == img {
+			return images
+		}
+	}
+	return append(images, img)
+}
+
+// WriteTo writes the finished document to w.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	pw := &writer{w: bufio.NewWriter(w)}
+	pw.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are fixed, images follow and each page takes two objects: the page
+	// dictionary and its content stream.
+	const (
+		catalogObj = 1
+		pagesObj   = 2
+		fontObj    = 3
+	)
+	imageObj := func(img *Image) int { return fontObj + len(fontNames) + img.id - 1 }
+	pageObj := func(i int) int { return fontObj + len(fontNames) + len(d.images) + 2*i }
+
+	pw.object(catalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageObj(i))
+	}
+	pw.object(pagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %s %s] >>",
+		strings.Join(kids, " "), len(d.pages), num(d.width), num(d.height)))
+
+	for i, name := range fontNames {
+		pw.object(fontObj+i, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+	}
+
+	for _, img := range d.images {
+		dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s",
+			img.width, img.height, img.colorSpace, img.filter)
+		if img.colorSpace == "DeviceCMYK" && img.filter == "DCTDecode" {
+			dict += " /Decode [1 0 1 0 1 0 1 0]" // Adobe JPEGs store CMYK inverted
+		}
+		pw.stream(imageObj(img), dict, img.data)
+	}
+
+	fonts := make([]string, len(fontNames))
+	for i := range fontNames {
+		fonts[i] = fmt.Sprintf("/F%d %d 0 R", i+1, fontObj+i)
+	}
+	for i, page := range d.pages {
+		var xobjects []string
+		for _, img := range page.images {
+			xobjects = append(xobjects, fmt.Sprintf("/Im%d %d 0 R", img.id, imageObj(img)))
+		}
+		resources := fmt.Sprintf("/Font << %s >>", strings.Join(fonts, " "))
+		if len(xobjects) > 0 {
+			resources += fmt.Sprintf(" /XObject << %s >>", strings.Join(xobjects, " "))
+		}
+		pw.object(pageObj(i), fmt.Sprintf("<< /Type /Page /Parent %d 0 R /Resources << %s >> /Contents %d 0 R >>",
+			pagesObj, resources, pageObj(i)+1))
+		pw.stream(pageObj(i)+1, "", page.content.Bytes())
+	}
+
+	infoObj := pageObj(len(d.pages))
+	info := "<< /Producer (gopi) >>"
+	if d.title != "" {
+		info = fmt.Sprintf("<< /Title %s /Producer (gopi) >>", literal(encodeWinAnsi(d.title)))
+	}
+	pw.object(infoObj, info)
+
+	xref := pw.n
+	pw.printf("xref\n0 %d\n0000000000 65535 f \n", infoObj+1)
+	for obj := 1; obj <= infoObj; obj++ {
+		pw.printf("%010d 00000 n \n", pw.offsets[obj])
+	}
+	pw.printf("trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		infoObj+1, catalogObj, infoObj, xref)
+
+	if pw.err != nil {
+		return pw.n, pw.err
+	}
+	return pw.n, pw.w.Flush()
+}
+
+// writer tracks the byte offset of every object for the cross-reference table and keeps
+// the first error so WriteTo can check once at the end.
+type writer struct {
+	w       *bufio.Writer
+	n       int64
+	offsets map[int]int64
+	err     error
+}
+
+func (w *writer) write(b []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.Write(b)
+	w.n += int64(n)
+	w.err = err
+}
+
+func (w *writer) printf(format string, args ...interface{}) {
+	w.write([]byte(fmt.Sprintf(format, args...)))
+}
+
+func (w *writer) object(id int, body string) {
+	if w.offsets == nil {
+		w.offsets = make(map[int]int64)
+	}
+	w.offsets[id] = w.n
+	w.printf("%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+func (w *writer) stream(id int, dict string, data []byte) {
+	if w.offsets == nil {
+		w.offsets = make(map[int]int64)
+	}
+	w.offsets[id] = w.n
+	if dict != "" {
+		dict += " "
+	}
+	w.printf("%d 0 obj\n<< %s/Length %d >>\nstream\n", id, dict, len(data))
+	w.write(data)
+	w.printf("\nendstream\nendobj\n")
+}
+
+func deflate(data []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	return buf.Bytes()
+}
+
+// num formats a coordinate with at most two decimals, which is finer than any printer.
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func rgb(c color.Color) string {
+	if c == nil {
+		c = color.Black
+	}
+	r, g, b, _ := c.RGBA()
+	channel := func(v uint32) string {
+		return strconv.FormatFloat(float64(v)/0xffff, 'f', 3, 64)
+	}
+	return channel(r) + " " + channel(g) + " " + channel(b)
+}
+
+// literal quotes s as a PDF string, escaping delimiters and writing bytes outside
+// printable ASCII as octal.
+func literal(s []byte) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, c := range s {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 32 || c > 126:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
@@ -0,0 +1,465 @@
+// Package qr encodes short texts such as verification links as QR codes (ISO/IEC 18004)
+// using byte mode and error correction level M, which survives printing and a little wear.
+package qr
+
+import "errors"
+
+// ErrTooLong is returned for texts that do not fit in the largest supported version.
+var ErrTooLong = errors.New("qr: text too long")
+
+// Code is an encoded QR symbol. Module (0, 0) is the top-left corner.
+type Code struct {
+	version  int
+	size     int
+	modules  []bool
+	function []bool // finder, timing, alignment, format and version modules, never masked
+}
+
+// Size is the number of modules along each side, without the quiet zone.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Version is the symbol version, from 1 (21x21) to 10 (57x57).
+func (c *Code) Version() int {
+	return c.version
+}
+
+// Dark reports whether the module at column x and row y is dark. Modules outside the
+// symbol, such as the quiet zone, are light.
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.size || y >= c.size {
+		return false
+	}
+	return c.modules[y*c.size+x]
+}
+
+// Encode encodes text in the smallest version that holds it, choosing the mask with the
+// lowest penalty as the standard recommends.
+func Encode(text string) (*Code, error) {
+	return encode([]byte(text), -1)
+}
+
+// EncodeWithMask is Encode with a fixed mask pattern (0-7), for comparing output with
+// other encoders.
+func EncodeWithMask(text string, mask int) (*Code, error) {
+	if mask < 0 || mask > 7 {
+		return nil, errors.New("qr: mask must be between 0 and 7")
+	}
+	return encode([]byte(text), mask)
+}
+
+// levelM lists, per version, the error correction codewords per block, the number of
+// blocks and the total number of codewords.
+var levelM = [...]struct{ ecc, blocks, total int }{
+	1: {10, 1, 26}, 2: {16, 1, 44}, 3: {26, 1, 70}, 4: {18, 2, 100}, 5: {24, 2, 134},
+	6: {16, 4, 172}, 7: {18, 4, 196}, 8: {22, 4, 242}, 9: {22, 5, 292}, 10: {26, 5, 346},
+}
+
+var alignmentCenters = [...][]int{
+	1: nil, 2: {6, 18}, 3: {6, 22}, 4: {6, 26}, 5: {6, 30},
+	6: {6, 34}, 7: {6, 22, 38}, 8: {6, 24, 42}, 9: {6, 26, 46}, 10: {6, 28, 50},
+}
+
+const maxVersion = 10
+
+func dataCodewords(version int) int {
+	v := levelM[version]
+	return v.total - v.ecc*v.blocks
+}
+
+func encode(data []byte, mask int) (*Code, error) {
+	version := 0
+	for v := 1; v <= maxVersion; v++ {
+		if 4+countBits(v)+8*len(data) <= dataCodewords(v)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	c := &Code{version: version, size: version*4 + 17}
+	c.modules = make([]bool, c.size*c.size)
+	c.function = make([]bool, c.size*c.size)
+	c.drawFunctionPatterns()
+	c.drawCodewords(addErrorCorrection(version, dataBits(version, data)))
+
+	if mask < 0 {
+		best := 0
+		for m := 0; m < 8; m++ {
+			c.applyMask(m)
+			c.drawFormatBits(m)
+			if p := c.penalty(); m == 0 || p < best {
+				best, mask = p, m
+			}
+			c.applyMask(m) // masking twice undoes it
+		}
+	}
+	c.applyMask(mask)
+	c.drawFormatBits(mask)
+	return c, nil
+}
+
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+// dataBits builds the byte mode segment, terminator and padding for version.
+func dataBits(version int, data []byte) []byte {
+	var bb bitBuffer
+	bb.append(0x4, 4) // byte mode
+	bb.append(len(data), countBits(version))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+
+	capacity := dataCodewords(version) * 8
+	if n := capacity - bb.len; n > 4 {
+		bb.append(0, 4)
+	} else {
+		bb.append(0, n)
+	}
+	bb.append(0, (8-bb.len%8)%8)
+	for pad := 0xEC; bb.len < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+	return bb.bytes
+}
+
+type bitBuffer struct {
+	bytes []byte
+	len   int
+}
+
+func (b *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if b.len%8 == 0 {
+			b.bytes = append(b.bytes, 0)
+		}
+		if value>>uint(i)&1 == 1 {
+			b.bytes[b.len/8] |= 0x80 >> uint(b.len%8)
+		}
+		b.len++
+	}
+}
+
+// addErrorCorrection splits data into blocks, appends each block's Reed-Solomon codewords
+// and interleaves the result.
+func addErrorCorrection(version int, data []byte) []byte {
+	v := levelM[version]
+	shortBlocks := v.blocks - v.total%v.blocks
+	shortLen := v.total / v.blocks
+	divisor := rsDivisor(v.ecc)
+
+	blocks := make([][]byte, v.blocks)
+	k := 0
+	for i := range blocks {
+		n := shortLen - v.ecc
+		if i >= shortBlocks {
+			n++
+		}
+		block := append([]byte(nil), data[k:k+n]...)
+		k += n
+		ecc := rsRemainder(block, divisor)
+		if i < shortBlocks {
+			block = append(block, 0) // placeholder so every block has the same length
+		}
+		blocks[i] = append(block, ecc...)
+	}
+
+	var result []byte
+	for i := 0; i < len(blocks[0]); i++ {
+		for j, block := range blocks {
+			if i != shortLen-v.ecc || j >= shortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+	return result
+}
+
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= gfMul(d, factor)
+		}
+	}
+	return result
+}
+
+// gfMul multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMul(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int(y>>uint(i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+func (c *Code) set(x, y int, dark bool) {
+	c.modules[y*c.size+x] = dark
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.set(x, y, dark)
+	c.function[y*c.size+x] = true
+}
+
+func (c *Code) isFunction(x, y int) bool {
+	return c.function[y*c.size+x]
+}
+
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.size-4, 3)
+	c.drawFinder(3, c.size-4)
+
+	centers := alignmentCenters[c.version]
+	last := len(centers) - 1
+	for i, cy := range centers {
+		for j, cx := range centers {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue // overlaps a finder pattern
+			}
+			c.drawAlignment(cx, cy)
+		}
+	}
+
+	c.drawFormatBits(0) // reserve the format areas; overwritten once the mask is chosen
+	c.drawVersion()
+}
+
+func (c *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= c.size || yy >= c.size {
+				continue
+			}
+			d := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, d != 2 && d != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits writes the error correction level and mask, BCH protected, in both
+// copies of the format area.
+func (c *Code) drawFormatBits(mask int) {
+	const levelMBits = 0
+	data := levelMBits<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>uint(i)&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.size-15+i, bit(i))
+	}
+	c.setFunction(8, c.size-8, true) // always dark
+}
+
+func (c *Code) drawVersion() {
+	if c.version < 7 {
+		return
+	}
+	rem := c.version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := c.version<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := bits>>uint(i)&1 == 1
+		a, b := c.size-11+i%3, i/3
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the data in the zigzag order, two columns at a time from the
+// bottom-right corner, skipping function modules.
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vert := 0; vert < c.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.size - 1 - vert // upward
+				}
+				if !c.isFunction(x, y) && i < len(data)*8 {
+					c.set(x, y, data[i>>3]>>uint(7-i&7)&1 == 1)
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if c.isFunction(x, y) {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				c.modules[y*c.size+x] = !c.modules[y*c.size+x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol with the four rules of the standard: long runs, 2x2 blocks,
+// finder-like patterns and an unbalanced dark ratio.
+func (c *Code) penalty() int {
+	score := 0
+	finderLike := []bool{true, false, true, true, true, false, true}
+
+	line := make([]bool, c.size)
+	for pass := 0; pass < 2; pass++ {
+		for a := 0; a < c.size; a++ {
+			for b := 0; b < c.size; b++ {
+				if pass == 0 {
+					line[b] = c.Dark(b, a)
+				} else {
+					line[b] = c.Dark(a, b)
+				}
+			}
+
+			run := 1
+			for b := 1; b <= c.size; b++ {
+				if b < c.size && line[b] == line[b-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+
+			for b := 0; b+len(finderLike) <= c.size; b++ {
+				match := true
+				for k, dark := range finderLike {
+					if line[b+k] != dark {
+						match = false
+						break
+					}
+				}
+				if match && (lightRun(line, b-4, b) || lightRun(line, b+7, b+11)) {
+					score += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			d := c.Dark(x, y)
+			if d {
+				dark++
+			}
+			if x+1 < c.size && y+1 < c.size && d == c.Dark(x+1, y) && d == c.Dark(x, y+1) && d == c.Dark(x+1, y+1) {
+				score += 3
+			}
+		}
+	}
+	total := c.size * c.size
+	score += abs(dark*20-total*10) / total * 10
+	return score
+}
+
+// lightRun reports whether line[from:to] is all light, counting modules outside the
+// symbol as light.
+func lightRun(line []bool, from, to int) bool {
+	for i := from; i < to; i++ {
+		if i >= 0 && i < len(line) && line[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
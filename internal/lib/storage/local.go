@@ -8,21 +8,76 @@ import (
     "os"
     "path/filepath"
     "strings"
+    "time"
 )
 
 type LocalStorage struct {
     baseDir       string
     publicBaseURL string // e.g. /uploads
+
+    // Private objects live outside baseDir so the static file server never sees them.
+    privateDir      string
+    signedBaseURL   string // e.g. /api/files
+    privatePrefixes []string
+    signer          *Signer
 }
 
 func NewLocalStorage(baseDir, publicBaseURL string) *LocalStorage {
     return &LocalStorage{baseDir: baseDir, publicBaseURL: strings.TrimRight(publicBaseURL, "/")}
 }
 
+// NewLocalStorageFromConfig builds a LocalStorage with private prefix and signed URL support.
+// It returns ErrNoPrivateStore when private prefixes are set without a private dir outside
+// the public one.
+func NewLocalStorageFromConfig(cfg Config) (*LocalStorage, error) {
+    if len(cfg.PrivatePrefixes) > 0 && !outside(cfg.LocalPrivateDir, cfg.LocalBaseDir) {
+        return nil, ErrNoPrivateStore
+    }
+    s := NewLocalStorage(cfg.LocalBaseDir, cfg.LocalPublicBaseURL)
+    s.privateDir = cfg.LocalPrivateDir
+    s.signedBaseURL = strings.TrimRight(cfg.LocalSignedBaseURL, "/")
+    s.privatePrefixes = cfg.PrivatePrefixes
+    if cfg.SigningSecret != "" {
+        s.signer = NewSigner(cfg.SigningSecret)
+    }
+    return s, nil
+}
+
+// outside reports whether dir is set and lies outside base, so the static file server for base
+// never reaches it.
+func outside(dir, base string) bool {
+    if dir == "" {
+        return false
+    }
+    absDir, err := filepath.Abs(dir)
+    if err != nil {
+        return false
+    }
+    absBase, err := filepath.Abs(base)
+    if err != nil {
+        return false
+    }
+    rel, err := filepath.Rel(absBase, absDir)
+    return err == nil && (rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+func (s *LocalStorage) isPrivate(key string) bool {
+    return s.privateDir != "" && hasPrivatePrefix(s.privatePrefixes, key)
+}
+
+// path resolves key to its location on disk, choosing the private dir where applicable.
+func (s *LocalStorage) path(key string) string {
+    dir := s.baseDir
+    if s.isPrivate(key) {
+        dir = s.privateDir
+    }
+    return filepath.Join(dir, filepath.FromSlash(cleanKey(key)))
+}
+
 func (s *LocalStorage) Save(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
     // Clean and ensure directories exist
-    cleanKey := filepath.ToSlash(filepath.Clean(key))
-    absPath := filepath.Join(s.baseDir, filepath.FromSlash(cleanKey))
+    cleanKey := cleanKey(key)
+    absPath := s.path(cleanKey)
 
     if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
         return "", fmt.Errorf("prepare dir: %w", err)
@@ -39,14 +94,18 @@ func (s *LocalStorage) Save(ctx context.Context, key string, r io.Reader, size i
         return "", fmt.Errorf("write file: %w", err)
     }
 
+    // Private objects resolve to the (unsigned) download endpoint
+    if s.isPrivate(cleanKey) {
+        return s.signedBaseURL + "/" + escapeKey(cleanKey), nil
+    }
+
     // Construct public URL
     public := s.publicBaseURL + "/" + cleanKey
     return public, nil
 }
 
 func (s *LocalStorage) Delete(ctx context.Context, key string) error {
-    cleanKey := filepath.ToSlash(filepath.Clean(key))
-    absPath := filepath.Join(s.baseDir, filepath.FromSlash(cleanKey))
+    absPath := s.path(key)
     if err := os.Remove(absPath); err != nil {
         if errors.Is(err, os.ErrNotExist) {
             return nil
@@ -55,3 +114,41 @@ func (s *LocalStorage) Delete(ctx context.Context, key string) error {
     }
     return nil
 }
+
+func (s *LocalStorage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+    if !s.isPrivate(key) {
+        return s.publicBaseURL + "/" + cleanKey(key), nil
+    }
+    if s.signer == nil {
+        return "", ErrSigningDisabled
+    }
+    return s.signer.URL(s.signedBaseURL, key, ttl), nil
+}
+
+func (s *LocalStorage) Verify(key string, expires int64, signature string) error {
+    if s.signer == nil {
+        return ErrSigningDisabled
+    }
+    return s.signer.Verify(key, expires, signature)
+}
+
+// Open only serves private objects; public ones are already reachable through publicBaseURL.
+func (s *LocalStorage) Open(ctx context.Context, key string) (io.ReadSeekCloser, time.Time, error) {
+    if !s.isPrivate(key) {
+        return nil, time.Time{}, os.ErrNotExist
+    }
+    f, err := os.Open(s.path(key))
+    if err != nil {
+        return nil, time.Time{}, err
+    }
+    info, err := f.Stat()
+    if err != nil {
+        f.Close()
+        return nil, time.Time{}, err
+    }
+    if info.IsDir() {
+        f.Close()
+        return nil, time.Time{}, os.ErrNotExist
+    }
+    return f, info.ModTime(), nil
+}
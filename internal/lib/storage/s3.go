@@ -6,19 +6,28 @@ import (
     "io"
     "net/url"
     "strings"
+    "time"
 
     minio "github.com/minio/minio-go/v7"
     "github.com/minio/minio-go/v7/pkg/credentials"
 )
 
 type S3Storage struct {
-    client         *minio.Client
-    bucket         string
-    region         string
-    publicBaseURL  string // optional CDN or custom domain; if set, used for public URLs
+    client          *minio.Client
+    bucket          string
+    privateBucket   string // bucket for private prefixes; objects there are never given public URLs
+    region          string
+    publicBaseURL   string // optional CDN or custom domain; if set, used for public URLs
+    privatePrefixes []string
 }
 
+// NewS3Storage connects to the configured bucket. It returns ErrNoPrivateStore when private
+// prefixes are set without a private bucket of their own.
 func NewS3Storage(cfg Config) (*S3Storage, error) {
+    if len(cfg.PrivatePrefixes) > 0 && (cfg.S3PrivateBucket == "" || cfg.S3PrivateBucket == cfg.S3Bucket) {
+        return nil, ErrNoPrivateStore
+    }
+
     // Endpoint may be empty for AWS; minio requires an endpoint. For AWS, you can use s3.<region>.amazonaws.com
     endpoint := cfg.S3Endpoint
     if endpoint == "" {
@@ -41,20 +50,39 @@ func NewS3Storage(cfg Config) (*S3Storage, error) {
     }
 
     return &S3Storage{
-        client:        cli,
-        bucket:        cfg.S3Bucket,
-        region:        cfg.S3Region,
-        publicBaseURL: strings.TrimRight(cfg.S3PublicBaseURL, "/"),
+        client:          cli,
+        bucket:          cfg.S3Bucket,
+        privateBucket:   cfg.S3PrivateBucket,
+        region:          cfg.S3Region,
+        publicBaseURL:   strings.TrimRight(cfg.S3PublicBaseURL, "/"),
+        privatePrefixes: cfg.PrivatePrefixes,
     }, nil
 }
 
+func (s *S3Storage) isPrivate(key string) bool {
+    return hasPrivatePrefix(s.privatePrefixes, key)
+}
+
+// bucketFor routes private prefixes to the private bucket.
+func (s *S3Storage) bucketFor(key string) string {
+    if s.isPrivate(key) {
+        return s.privateBucket
+    }
+    return s.bucket
+}
+
 func (s *S3Storage) Save(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
     opts := minio.PutObjectOptions{ContentType: contentType}
-    _, err := s.client.PutObject(ctx, s.bucket, key, r, size, opts)
+    _, err := s.client.PutObject(ctx, s.bucketFor(key), key, r, size, opts)
     if err != nil {
         return "", err
     }
 
+    // Private objects have no public URL; callers must use SignedURL
+    if s.isPrivate(key) {
+        return fmt.Sprintf("s3://%s/%s", s.privateBucket, strings.TrimLeft(key, "/")), nil
+    }
+
     // Build public URL
     if s.publicBaseURL != "" {
         u, _ := url.Parse(s.publicBaseURL)
@@ -69,5 +97,14 @@ func (s *S3Storage) Save(ctx context.Context, key string, r io.Reader, size int6
 }
 
 func (s *S3Storage) Delete(ctx context.Context, key string) error {
-    return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+    return s.client.RemoveObject(ctx, s.bucketFor(key), key, minio.RemoveObjectOptions{})
+}
+
+// SignedURL returns a presigned GET URL generated by the S3 client.
+func (s *S3Storage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+    u, err := s.client.PresignedGetObject(ctx, s.bucketFor(key), key, ttl, url.Values{})
+    if err != nil {
+        return "", err
+    }
+    return u.String(), nil
 }
@@ -2,16 +2,38 @@ package storage
 
 import (
     "context"
+    "errors"
     "io"
+    "path/filepath"
+    "strings"
+    "time"
 )
 
+// ErrNoPrivateStore is returned when private prefixes are configured without a private bucket
+// or directory apart from the public one, which would expose private objects.
+var ErrNoPrivateStore = errors.New("storage: private prefixes need a private bucket or directory separate from the public one")
+
 // Storage abstracts upload/delete operations and returns a public URL for saved objects.
 // Implementations must be safe for concurrent use.
 type Storage interface {
     // Save stores content at the provided key (e.g., "profile/filename.jpg") and returns a public URL.
+    // Keys under a private prefix are not publicly reachable; the returned URL must not be shared
+    // as-is and callers should hand out SignedURL results instead.
     Save(ctx context.Context, key string, r io.Reader, size int64, contentType string) (publicURL string, err error)
     // Delete removes an object at key. Should be idempotent.
     Delete(ctx context.Context, key string) error
+    // SignedURL returns a time-limited URL granting read access to key.
+    // Public keys may be returned as their plain public URL.
+    SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// Downloader is implemented by backends whose private objects are streamed through the API
+// (e.g. local disk) rather than served directly by the storage provider.
+type Downloader interface {
+    // Verify checks a signature produced by SignedURL for key and expires (unix seconds).
+    Verify(key string, expires int64, signature string) error
+    // Open returns a seekable reader for key along with its modification time.
+    Open(ctx context.Context, key string) (io.ReadSeekCloser, time.Time, error)
 }
 
 // Config is a generic storage configuration. Concrete backends may use a subset.
@@ -19,17 +41,42 @@ type Config struct {
     // Backend: "local" or "s3"
     Backend string
 
+    // Private objects: keys starting with any of these prefixes (e.g. "exports/") are never
+    // exposed publicly and are only reachable through signed URLs.
+    PrivatePrefixes []string
+    SigningSecret   string // HMAC secret for local signed URLs
+
     // Local settings
     LocalBaseDir          string // filesystem base dir, e.g. ./uploads
     LocalPublicBaseURL    string // public base URL/prefix served by the API, e.g. /uploads
+    LocalPrivateDir       string // filesystem dir for private objects, required with PrivatePrefixes and kept outside LocalBaseDir, e.g. ./private
+    LocalSignedBaseURL    string // download endpoint that verifies signatures, e.g. /api/files
 
     // S3 settings (also compatible with MinIO)
     S3Endpoint        string // optional; if empty uses AWS SDK endpoint resolution or MinIO endpoint
     S3Region          string
     S3Bucket          string
+    S3PrivateBucket   string // bucket for private prefixes; required with PrivatePrefixes and distinct from S3Bucket
     S3AccessKeyID     string
     S3SecretAccessKey string
     S3UseSSL          bool
     S3ForcePathStyle  bool
     S3PublicBaseURL   string // optional CDN/CloudFront domain; if set, used to construct public URL
 }
+
+// cleanKey normalises a key to a slash-separated relative path.
+func cleanKey(key string) string {
+    return strings.TrimLeft(filepath.ToSlash(filepath.Clean("/"+key)), "/")
+}
+
+// hasPrivatePrefix reports whether key falls under one of the private prefixes.
+func hasPrivatePrefix(prefixes []string, key string) bool {
+    key = cleanKey(key)
+    for _, p := range prefixes {
+        p = strings.TrimLeft(p, "/")
+        if p != "" && strings.HasPrefix(key, p) {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,131 @@
+package activity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	activityModel "gopi.com/internal/domain/activity/model"
+	trackModel "gopi.com/internal/domain/track/model"
+)
+
+const metersPerDegreeLat = 111195.08
+
+func rulesOf(violations []activityModel.Violation) []string {
+	rules := make([]string, 0, len(violations))
+	for _, v := range violations {
+		rules = append(rules, v.Rule)
+	}
+	return rules
+}
+
+func TestRules_Check(t *testing.T) {
+	rules := activityModel.NewRules(nil)
+
+	tests := []struct {
+		name     string
+		run      activityModel.Run
+		expected []string
+	}{
+		{
+			name:     "plausible run",
+			run:      activityModel.Run{Activity: "Running", Distance: 10, Duration: 55 * time.Minute},
+			expected: []string{},
+		},
+		{
+			name:     "too fast for a run",
+			run:      activityModel.Run{Activity: "Running", Distance: 10, Duration: 15 * time.Minute},
+			expected: []string{activityModel.RuleMaxSpeed},
+		},
+		{
+			name:     "same pace is fine on a bike",
+			run:      activityModel.Run{Activity: "cycling", Distance: 10, Duration: 15 * time.Minute},
+			expected: []string{},
+		},
+		{
+			name:     "duration far too long for the distance",
+			run:      activityModel.Run{Activity: "Walking", Distance: 1, Duration: 5 * time.Hour},
+			expected: []string{activityModel.RuleDuration},
+		},
+		{
+			name:     "missing duration",
+			run:      activityModel.Run{Activity: "Running", Distance: 5, Duration: 0},
+			expected: []string{activityModel.RuleDuration},
+		},
+		{
+			name:     "daily limit counts earlier runs",
+			run:      activityModel.Run{Activity: "Walking", Distance: 10, Duration: 2 * time.Hour, DistanceToday: 55},
+			expected: []string{activityModel.RuleDailyDistance},
+		},
+		{
+			name:     "unknown activity uses running limits",
+			run:      activityModel.Run{Activity: "Swimming", Distance: 10, Duration: 15 * time.Minute},
+			expected: []string{activityModel.RuleMaxSpeed},
+		},
+		{
+			name:     "no distance is never flagged",
+			run:      activityModel.Run{Activity: "Running", Distance: 0, Duration: 0},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rulesOf(rules.Check(tt.run)))
+		})
+	}
+}
+
+func TestRules_Check_Teleport(t *testing.T) {
+	rules := activityModel.NewRules(nil)
+	start := time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)
+
+	// 1km north every 5 minutes (12 km/h), then a 6km hop (72 km/h) in the next 5 minutes.
+	track := &trackModel.Track{}
+	for i, lat := range []float64{0, 1000, 2000, 8000, 9000} {
+		track.Points = append(track.Points, trackModel.Point{
+			Lat:  lat / metersPerDegreeLat,
+			Time: start.Add(time.Duration(i) * 5 * time.Minute),
+		})
+	}
+
+	violations := rules.Check(activityModel.Run{Activity: "Running", Distance: 9, Duration: 45 * time.Minute, Track: track})
+	assert.Equal(t, []string{activityModel.RuleTeleport}, rulesOf(violations))
+
+	// The same hop is possible on a bike.
+	violations = rules.Check(activityModel.Run{Activity: "Cycling", Distance: 9, Duration: 45 * time.Minute, Track: track})
+	assert.Empty(t, violations)
+}
+
+func TestRules_CustomLimits(t *testing.T) {
+	rules := activityModel.NewRules(map[string]activityModel.Limits{
+		"Running": {MaxSpeed: 12},
+	})
+
+	assert.Empty(t, rules.Check(activityModel.Run{Activity: "Running", Distance: 500, Duration: 48 * time.Hour}))
+	assert.Equal(t, []string{activityModel.RuleMaxSpeed},
+		rulesOf(rules.Check(activityModel.Run{Activity: "Running", Distance: 10, Duration: 45 * time.Minute})))
+}
+
+func TestHeldForReviewError(t *testing.T) {
+	var err error = &activityModel.HeldForReviewError{Violations: []activityModel.Violation{
+		{Rule: activityModel.RuleMaxSpeed}, {Rule: activityModel.RuleTeleport},
+	}}
+
+	assert.True(t, errors.Is(err, activityModel.ErrHeldForReview))
+	assert.Equal(t, "activity held for review: max_speed, gps_teleport", err.Error())
+
+	var held *activityModel.HeldForReviewError
+	require.True(t, errors.As(err, &held))
+	assert.Len(t, held.Violations, 2)
+}
+
+func TestReviewStatus_Counts(t *testing.T) {
+	assert.True(t, activityModel.ReviewStatus("").Counts())
+	assert.True(t, activityModel.ReviewStatusAccepted.Counts())
+	assert.True(t, activityModel.ReviewStatusApproved.Counts())
+	assert.False(t, activityModel.ReviewStatusFlagged.Counts())
+	assert.False(t, activityModel.ReviewStatusRejected.Counts())
+}
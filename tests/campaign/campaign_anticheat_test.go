@@ -0,0 +1,169 @@
+package campaign_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	campaign "gopi.com/internal/app/campaign"
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/data/campaign/repo"
+	activityModel "gopi.com/internal/domain/activity/model"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/tests/testdb"
+	"gorm.io/gorm"
+)
+
+func setupAntiCheatService(t *testing.T) (*campaign.CampaignService, *campaignModel.Campaign) {
+	service, c, _ := setupAntiCheatDB(t)
+	return service, c
+}
+
+func setupAntiCheatDB(t *testing.T) (*campaign.CampaignService, *campaignModel.Campaign, *gorm.DB) {
+	db := testdb.Open(t, &gormmodel.Campaign{}, &gormmodel.CampaignRunner{}, &gormmodel.SponsorCampaign{},
+		&gormmodel.CampaignMember{}, &gormmodel.CampaignSponsor{}, &gormmodel.CampaignRun{})
+
+	campaignRepo := repo.NewGormCampaignRepository(db)
+	service := campaign.NewCampaignService(campaignRepo, repo.NewGormCampaignRunnerRepository(db), repo.NewGormSponsorCampaignRepository(db),
+		campaign.WithUnitOfWork(repo.NewGormUnitOfWork(db)),
+		campaign.WithAntiCheat(repo.NewGormCampaignRunRepository(db), activityModel.NewRules(nil)))
+
+	c := &campaignModel.Campaign{
+		Base:    model.Base{ID: "anticheat-campaign"},
+		Name:    "anti-cheat",
+		OwnerID: "owner",
+		Slug:    "anti-cheat",
+		Status:  campaignModel.CampaignStatusActive,
+	}
+	require.NoError(t, campaignRepo.Create(c))
+	return service, c, db
+}
+
+func TestCampaignService_AntiCheat_HoldAndApprove(t *testing.T) {
+	service, c := setupAntiCheatService(t)
+
+	// A plausible run is credited straight away.
+	require.NoError(t, service.RecordActivity(c.ID, "user1", 5, 30*time.Minute, "Running"))
+
+	// 10km in 15 minutes is not a run; it is saved but held out of the totals.
+	err := service.RecordActivity(c.ID, "user2", 10, 15*time.Minute, "Running")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, activityModel.ErrHeldForReview))
+	var held *activityModel.HeldForReviewError
+	require.True(t, errors.As(err, &held))
+	assert.Equal(t, activityModel.RuleMaxSpeed, held.Violations[0].Rule)
+
+	got, err := service.GetCampaignByID(c.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 5, got.DistanceCovered, 1e-9)
+
+	flagged, err := service.ListFlaggedRuns(10, 0)
+	require.NoError(t, err)
+	require.Len(t, flagged, 1)
+	run := flagged[0]
+	assert.Equal(t, "user2", run.OwnerID)
+	assert.Equal(t, activityModel.ReviewStatusFlagged, run.Status)
+	assert.Equal(t, activityModel.RuleMaxSpeed, run.Violations[0].Rule)
+
+	runner, err := service.GetRunnerByID(run.RunnerID)
+	require.NoError(t, err)
+	assert.Zero(t, runner.DistanceCovered)
+
+	// Approval credits the run exactly once.
+	approved, err := service.ReviewRun(run.ID, "staff1", true)
+	require.NoError(t, err)
+	assert.Equal(t, activityModel.ReviewStatusApproved, approved.Status)
+	assert.Equal(t, "staff1", approved.ReviewedBy)
+	assert.NotNil(t, approved.ReviewedAt)
+
+	_, err = service.ReviewRun(run.ID, "staff1", true)
+	assert.True(t, errors.Is(err, activityModel.ErrNotFlagged))
+
+	got, err = service.GetCampaignByID(c.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 15, got.DistanceCovered, 1e-9)
+	runner, err = service.GetRunnerByID(run.RunnerID)
+	require.NoError(t, err)
+	assert.InDelta(t, 10, runner.DistanceCovered, 1e-9)
+
+	flagged, err = service.ListFlaggedRuns(10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, flagged)
+}
+
+func TestCampaignService_AntiCheat_RejectAndDailyLimit(t *testing.T) {
+	service, c := setupAntiCheatService(t)
+
+	require.NoError(t, service.RecordActivity(c.ID, "walker", 40, 8*time.Hour, "Walking"))
+	runners, err := service.GetRunnersByUser("walker")
+	require.NoError(t, err)
+	require.Len(t, runners, 1)
+
+	// Another 30km the same day takes the walker past the 60km daily limit.
+	err = service.FinishActivity(runners[0].ID, 30, 6*time.Hour, 0)
+	var held *activityModel.HeldForReviewError
+	require.True(t, errors.As(err, &held))
+	assert.Equal(t, activityModel.RuleDailyDistance, held.Violations[0].Rule)
+
+	flagged, err := service.ListFlaggedRuns(10, 0)
+	require.NoError(t, err)
+	require.Len(t, flagged, 1)
+
+	rejected, err := service.ReviewRun(flagged[0].ID, "staff1", false)
+	require.NoError(t, err)
+	assert.Equal(t, activityModel.ReviewStatusRejected, rejected.Status)
+
+	got, err := service.GetCampaignByID(c.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 40, got.DistanceCovered, 1e-9)
+	runner, err := service.GetRunnerByID(runners[0].ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 40, runner.DistanceCovered, 1e-9)
+}
+
+func TestCampaignService_AntiCheat_HoldRollsBackRunner(t *testing.T) {
+	service, c, db := setupAntiCheatDB(t)
+
+	// Fail the flagged run's insert; the zero-distance runner must not be left behind.
+	require.NoError(t, db.Callback().Create().Before("gorm:create").Register("fail_runs", func(tx *gorm.DB) {
+		if tx.Statement.Table == "campaign_runs" {
+			_ = tx.AddError(errors.New("runs unavailable"))
+		}
+	}))
+
+	err := service.RecordActivity(c.ID, "user2", 10, 15*time.Minute, "Running")
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, activityModel.ErrHeldForReview))
+
+	runners, err := service.GetRunnersByUser("user2")
+	require.NoError(t, err)
+	assert.Empty(t, runners)
+}
+
+func TestCampaignService_AntiCheat_NoApprovalAfterCloseOut(t *testing.T) {
+	service, c, db := setupAntiCheatDB(t)
+
+	err := service.RecordActivity(c.ID, "user2", 10, 15*time.Minute, "Running")
+	require.True(t, errors.Is(err, activityModel.ErrHeldForReview))
+	flagged, err := service.ListFlaggedRuns(10, 0)
+	require.NoError(t, err)
+	require.Len(t, flagged, 1)
+
+	ok, err := repo.NewGormCampaignRepository(db).MarkClosed(c.ID, time.Now())
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = service.ReviewRun(flagged[0].ID, "staff1", true)
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidCampaignState)
+	got, err := service.GetCampaignByID(c.ID)
+	require.NoError(t, err)
+	assert.Zero(t, got.DistanceCovered)
+
+	// The run stays in the queue and can still be rejected.
+	rejected, err := service.ReviewRun(flagged[0].ID, "staff1", false)
+	require.NoError(t, err)
+	assert.Equal(t, activityModel.ReviewStatusRejected, rejected.Status)
+}
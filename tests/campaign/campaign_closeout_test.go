@@ -0,0 +1,342 @@
+package campaign_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	campaign "gopi.com/internal/app/campaign"
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/data/campaign/repo"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	campaignMocks "gopi.com/tests/mocks/campaign"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+// recordingEmailService captures bulk emails sent during close-out.
+type recordingEmailService struct {
+	mu   sync.Mutex
+	sent []sentEmail
+}
+
+type sentEmail struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+func (r *recordingEmailService) SendOTPEmail(email, firstName, otp string) error      { return nil }
+func (r *recordingEmailService) SendWelcomeEmail(email, firstName string) error       { return nil }
+func (r *recordingEmailService) SendPasswordResetEmail(email, resetLink string) error { return nil }
+func (r *recordingEmailService) SendApologyEmail(email, username string) error        { return nil }
+func (r *recordingEmailService) TestEmailConnection() error                           { return nil }
+func (r *recordingEmailService) GetQueueLength() int                                  { return 0 }
+
+func (r *recordingEmailService) SendBulkEmail(emails []string, subject, htmlContent string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, sentEmail{To: emails, Subject: subject, Body: htmlContent})
+	return nil
+}
+
+func TestCampaign_GoalReached(t *testing.T) {
+	tests := []struct {
+		name     string
+		campaign campaignModel.Campaign
+		want     bool
+	}{
+		{"no goals", campaignModel.Campaign{DistanceCovered: 100, MoneyRaised: 100}, false},
+		{"distance short", campaignModel.Campaign{DistanceToCover: 10, DistanceCovered: 9.9}, false},
+		{"distance met", campaignModel.Campaign{DistanceToCover: 10, DistanceCovered: 10}, true},
+		{"money met", campaignModel.Campaign{DistanceToCover: 10, TargetAmount: 500, MoneyRaised: 500}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.campaign.GoalReached())
+		})
+	}
+}
+
+func TestSponsorCampaign_Obligation(t *testing.T) {
+	sc := &campaignModel.SponsorCampaign{
+		Base:        model.Base{ID: "sc-1"},
+		CampaignID:  "c-1",
+		Distance:    8,
+		AmountPerKm: 2.5,
+		Sponsors:    campaignModel.UsersByID("s-1"),
+	}
+
+	under := sc.Obligation(3.2)
+	assert.Equal(t, 3.2, under.CreditedDistance)
+	assert.Equal(t, 8.0, under.Amount)
+
+	over := sc.Obligation(12)
+	assert.Equal(t, 8.0, over.CreditedDistance, "credited distance is capped at the pledge")
+	assert.Equal(t, 20.0, over.Amount)
+	assert.Equal(t, "sc-1", over.SponsorCampaignID)
+	assert.Equal(t, campaignModel.UsersByID("s-1"), over.Sponsors)
+}
+
+func TestBuildStandings(t *testing.T) {
+	runners := []*campaignModel.CampaignRunner{
+		{OwnerID: "a", DistanceCovered: 3, Activity: "Running"},
+		{OwnerID: "b", DistanceCovered: 5, Activity: "Walking"},
+		{OwnerID: "a", DistanceCovered: 4, MoneyRaised: 10},
+		{OwnerID: "c", DistanceCovered: 5, MoneyRaised: 20},
+	}
+
+	standings := campaignModel.BuildStandings("c-1", runners)
+	require.Len(t, standings, 3)
+	assert.Equal(t, "a", standings[0].UserID)
+	assert.Equal(t, 7.0, standings[0].DistanceCovered)
+	assert.Equal(t, "Running", standings[0].Activity)
+	assert.Equal(t, "c", standings[1].UserID, "ties on distance are broken by money raised")
+	assert.Equal(t, "b", standings[2].UserID)
+	for i, st := range standings {
+		assert.Equal(t, i+1, st.Rank)
+		assert.Equal(t, "c-1", st.CampaignID)
+	}
+}
+
+func TestCampaignService_GoalAchievedClosesOut(t *testing.T) {
+	db := setupTestDB(t)
+	campaignRepo := repo.NewGormCampaignRepository(db)
+	runnerRepo := repo.NewGormCampaignRunnerRepository(db)
+	sponsorRepo := repo.NewGormSponsorCampaignRepository(db)
+	resultRepo := repo.NewGormCampaignResultRepository(db)
+
+	users := &userMocks.MockUserRepository{}
+	for _, u := range []*userModel.User{
+		{Base: model.Base{ID: "owner"}, Email: "owner@example.com", Username: "owner"},
+		{Base: model.Base{ID: "r1"}, Email: "r1@example.com", Username: "runner1"},
+		{Base: model.Base{ID: "r2"}, Email: "r2@example.com", Username: "runner2"},
+		{Base: model.Base{ID: "s1"}, Email: "s1@example.com", Username: "sponsor1"},
+		{Base: model.Base{ID: "s2"}, Email: "s2@example.com", Username: "sponsor2"},
+	} {
+		users.On("GetByID", u.ID).Return(u, nil).Maybe()
+	}
+	emails := &recordingEmailService{}
+
+	service := campaign.NewCampaignService(campaignRepo, runnerRepo, sponsorRepo,
+		campaign.WithCloseout(resultRepo, users, emails))
+
+	c := &campaignModel.Campaign{
+		Base:            model.Base{ID: "goal"},
+		Name:            "Ten K",
+		OwnerID:         "owner",
+		Slug:            "goal",
+		Status:          campaignModel.CampaignStatusActive,
+		DistanceToCover: 10,
+		Members:         campaignModel.UsersByID("r1", "r2"),
+	}
+	require.NoError(t, campaignRepo.Create(c))
+	require.NoError(t, service.SponsorCampaign("goal", campaignModel.UsersByID("s1"), 8, 2))
+	require.NoError(t, service.SponsorCampaign("goal", campaignModel.UsersByID("s2"), 20, 1))
+
+	require.NoError(t, service.RecordActivity("goal", "r1", 6, 30*time.Minute, "Running"))
+	got, err := campaignRepo.GetByID("goal")
+	require.NoError(t, err)
+	assert.Nil(t, got.AchievedAt)
+	assert.Empty(t, emails.sent)
+
+	require.NoError(t, service.RecordActivity("goal", "r2", 5, 25*time.Minute, "Running"))
+
+	got, err = campaignRepo.GetByID("goal")
+	require.NoError(t, err)
+	assert.Equal(t, campaignModel.CampaignStatusCompleted, got.Status)
+	require.NotNil(t, got.AchievedAt)
+	require.NotNil(t, got.ClosedAt)
+
+	standings, obligations, err := service.GetCampaignResults("goal")
+	require.NoError(t, err)
+	require.Len(t, standings, 2)
+	assert.Equal(t, "r1", standings[0].UserID)
+	assert.Equal(t, 1, standings[0].Rank)
+
+	owed := map[float64]float64{}
+	for _, o := range obligations {
+		owed[o.PledgedDistance] = o.Amount
+	}
+	assert.Equal(t, map[float64]float64{8: 16, 20: 11}, owed)
+
+	require.Len(t, emails.sent, 3)
+	assert.ElementsMatch(t, []string{"owner@example.com", "r1@example.com", "r2@example.com"}, emails.sent[0].To)
+	assert.Contains(t, emails.sent[0].Body, "runner1")
+	assert.NotContains(t, emails.sent[0].Body, "Amount due")
+	for _, sent := range emails.sent[1:] {
+		require.Len(t, sent.To, 1)
+		assert.True(t, strings.HasPrefix(sent.To[0], "s"))
+		assert.Contains(t, sent.Body, "Amount due")
+	}
+
+	// The leaderboard is frozen: no more runs, and closing again does nothing.
+	err = service.RecordActivity("goal", "r1", 1, 5*time.Minute, "Running")
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidCampaignState)
+	require.NoError(t, service.CloseOut(got))
+	assert.Len(t, emails.sent, 3)
+}
+
+func TestCampaignService_CheckGoalWithoutCloseout(t *testing.T) {
+	mockCampaignRepo := &campaignMocks.MockCampaignRepository{}
+	mockRunnerRepo := &campaignMocks.MockCampaignRunnerRepository{}
+	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, &campaignMocks.MockSponsorCampaignRepository{})
+
+	before := &campaignModel.Campaign{
+		Base:            model.Base{ID: "c-1"},
+		Status:          campaignModel.CampaignStatusActive,
+		DistanceToCover: 5,
+		DistanceCovered: 4,
+	}
+	c := &campaignModel.Campaign{
+		Base:            model.Base{ID: "c-1"},
+		Status:          campaignModel.CampaignStatusActive,
+		DistanceToCover: 5,
+		DistanceCovered: 6,
+	}
+	mockCampaignRepo.On("GetByID", "c-1").Return(before, nil).Once()
+	mockRunnerRepo.On("Create", mock.Anything).Return(nil)
+	mockCampaignRepo.On("IncrementTotals", "c-1", 2.0, 0.0).Return(nil)
+	mockCampaignRepo.On("GetByID", "c-1").Return(c, nil).Once()
+	mockCampaignRepo.On("MarkAchieved", "c-1", mock.AnythingOfType("time.Time")).Return(true, nil)
+
+	require.NoError(t, service.RecordActivity("c-1", "u-1", 2, 10*time.Minute, "Running"))
+
+	assert.Equal(t, campaignModel.CampaignStatusCompleted, c.Status)
+	assert.NotNil(t, c.AchievedAt)
+	mockCampaignRepo.AssertNotCalled(t, "MarkClosed", mock.Anything, mock.Anything)
+	mockCampaignRepo.AssertExpectations(t)
+}
+
+func TestCampaignService_AdvanceLifecycleClosesOutEndedCampaigns(t *testing.T) {
+	db := setupTestDB(t)
+	campaignRepo := repo.NewGormCampaignRepository(db)
+	runnerRepo := repo.NewGormCampaignRunnerRepository(db)
+	sponsorRepo := repo.NewGormSponsorCampaignRepository(db)
+	resultRepo := repo.NewGormCampaignResultRepository(db)
+	service := campaign.NewCampaignService(campaignRepo, runnerRepo, sponsorRepo,
+		campaign.WithCloseout(resultRepo, nil, nil))
+
+	past := time.Now().Add(-time.Hour)
+	c := &campaignModel.Campaign{
+		Base:            model.Base{ID: "ended"},
+		Name:            "ended",
+		OwnerID:         "owner",
+		Slug:            "ended",
+		Status:          campaignModel.CampaignStatusActive,
+		DistanceToCover: 100,
+		DistanceCovered: 4,
+		EndsAt:          &past,
+	}
+	require.NoError(t, campaignRepo.Create(c))
+	require.NoError(t, sponsorRepo.Create(&campaignModel.SponsorCampaign{CampaignID: "ended", Distance: 50, AmountPerKm: 3}))
+
+	changed, err := service.AdvanceLifecycle(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+
+	got, err := campaignRepo.GetByID("ended")
+	require.NoError(t, err)
+	assert.Nil(t, got.AchievedAt, "ending by time does not mean the goal was achieved")
+	assert.NotNil(t, got.ClosedAt)
+
+	_, obligations, err := service.GetCampaignResults("ended")
+	require.NoError(t, err)
+	require.Len(t, obligations, 1)
+	assert.Equal(t, 12.0, obligations[0].Amount)
+}
+
+func TestGormCampaignRepository_MarkAchievedOnce(t *testing.T) {
+	db := setupTestDB(t)
+	campaignRepo := repo.NewGormCampaignRepository(db)
+
+	require.NoError(t, campaignRepo.Create(&campaignModel.Campaign{Base: model.Base{ID: "a"}, Name: "a", OwnerID: "o", Slug: "a", Status: campaignModel.CampaignStatusActive}))
+	require.NoError(t, campaignRepo.Create(&campaignModel.Campaign{Base: model.Base{ID: "d"}, Name: "d", OwnerID: "o", Slug: "d", Status: campaignModel.CampaignStatusDraft}))
+
+	ok, err := campaignRepo.MarkAchieved("a", time.Now())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = campaignRepo.MarkAchieved("a", time.Now())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = campaignRepo.MarkAchieved("d", time.Now())
+	require.NoError(t, err)
+	assert.False(t, ok, "drafts cannot be achieved")
+
+	ok, err = campaignRepo.MarkClosed("a", time.Now())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = campaignRepo.MarkClosed("a", time.Now())
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestCampaignService_CloseOutRollsBackOnFailure(t *testing.T) {
+	db := testdb.Open(t, &gormmodel.Campaign{}, &gormmodel.CampaignRunner{}, &gormmodel.SponsorCampaign{},
+		&gormmodel.CampaignMember{}, &gormmodel.CampaignSponsor{}, &gormmodel.CampaignStanding{})
+
+	campaignRepo := repo.NewGormCampaignRepository(db)
+	sponsorRepo := repo.NewGormSponsorCampaignRepository(db)
+	service := campaign.NewCampaignService(campaignRepo, repo.NewGormCampaignRunnerRepository(db), sponsorRepo,
+		campaign.WithUnitOfWork(repo.NewGormUnitOfWork(db)),
+		campaign.WithCloseout(repo.NewGormCampaignResultRepository(db), nil, nil))
+
+	c := &campaignModel.Campaign{Base: model.Base{ID: "c"}, Name: "c", OwnerID: "owner", Slug: "c", Status: campaignModel.CampaignStatusActive}
+	require.NoError(t, campaignRepo.Create(c))
+	require.NoError(t, sponsorRepo.Create(&campaignModel.SponsorCampaign{CampaignID: "c", Distance: 5, AmountPerKm: 2}))
+
+	// Without an obligations table the close fails, and the campaign is left open.
+	assert.Error(t, service.CloseOut(c))
+	got, err := campaignRepo.GetByID("c")
+	require.NoError(t, err)
+	assert.Nil(t, got.ClosedAt)
+
+	require.NoError(t, db.AutoMigrate(&gormmodel.SponsorObligation{}))
+	require.NoError(t, service.CloseOut(c))
+	got, err = campaignRepo.GetByID("c")
+	require.NoError(t, err)
+	assert.NotNil(t, got.ClosedAt)
+	_, obligations, err := service.GetCampaignResults("c")
+	require.NoError(t, err)
+	assert.Len(t, obligations, 1)
+}
+
+func TestCampaignService_AdvanceLifecycleRetriesFailedCloseOut(t *testing.T) {
+	db := testdb.Open(t, &gormmodel.Campaign{}, &gormmodel.CampaignRunner{}, &gormmodel.SponsorCampaign{},
+		&gormmodel.CampaignMember{}, &gormmodel.CampaignSponsor{}, &gormmodel.CampaignStanding{})
+
+	campaignRepo := repo.NewGormCampaignRepository(db)
+	sponsorRepo := repo.NewGormSponsorCampaignRepository(db)
+	service := campaign.NewCampaignService(campaignRepo, repo.NewGormCampaignRunnerRepository(db), sponsorRepo,
+		campaign.WithUnitOfWork(repo.NewGormUnitOfWork(db)),
+		campaign.WithCloseout(repo.NewGormCampaignResultRepository(db), nil, nil))
+
+	past := time.Now().Add(-time.Hour)
+	c := &campaignModel.Campaign{Base: model.Base{ID: "c"}, Name: "c", OwnerID: "owner", Slug: "c", Status: campaignModel.CampaignStatusActive, EndsAt: &past}
+	require.NoError(t, campaignRepo.Create(c))
+	require.NoError(t, sponsorRepo.Create(&campaignModel.SponsorCampaign{CampaignID: "c", Distance: 5, AmountPerKm: 2}))
+
+	// The failed close-out also undoes the completion, so the campaign is still due.
+	_, err := service.AdvanceLifecycle(time.Now())
+	assert.Error(t, err)
+	got, err := campaignRepo.GetByID("c")
+	require.NoError(t, err)
+	assert.Equal(t, campaignModel.CampaignStatusActive, got.Status)
+	assert.Nil(t, got.ClosedAt)
+
+	require.NoError(t, db.AutoMigrate(&gormmodel.SponsorObligation{}))
+	changed, err := service.AdvanceLifecycle(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+	got, err = campaignRepo.GetByID("c")
+	require.NoError(t, err)
+	assert.Equal(t, campaignModel.CampaignStatusCompleted, got.Status)
+	assert.NotNil(t, got.ClosedAt)
+}
@@ -0,0 +1,108 @@
+package campaign_test
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	campaign "gopi.com/internal/app/campaign"
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/data/campaign/repo"
+	userGorm "gopi.com/internal/data/user/model/gorm"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/id"
+	"gopi.com/tests/testdb"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestCampaignService_ConcurrentUpdates_SQLite(t *testing.T) {
+	db := testdb.Open(t)
+
+	runConcurrentCampaignUpdates(t, db)
+}
+
+// TestCampaignService_ConcurrentUpdates_MySQL runs against the database in TEST_MYSQL_DSN,
+// e.g. "user:pass@tcp(localhost:3306)/gopi_test?parseTime=true".
+func TestCampaignService_ConcurrentUpdates_MySQL(t *testing.T) {
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSN not set")
+	}
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	runConcurrentCampaignUpdates(t, db)
+}
+
+func runConcurrentCampaignUpdates(t *testing.T, db *gorm.DB) {
+	require.NoError(t, db.AutoMigrate(&userGorm.UserGORM{}, &gormmodel.Campaign{}, &gormmodel.CampaignRunner{},
+		&gormmodel.SponsorCampaign{}, &gormmodel.CampaignMember{}, &gormmodel.CampaignSponsor{}))
+
+	suffix := id.New()
+	owner := &userGorm.UserGORM{ID: suffix[:26], Username: "owner-" + suffix, Email: suffix + "@example.com", Password: "x"}
+	require.NoError(t, db.Create(owner).Error)
+	t.Cleanup(func() { db.Delete(owner) })
+
+	campaignRepo := repo.NewGormCampaignRepository(db)
+	runnerRepo := repo.NewGormCampaignRunnerRepository(db)
+	service := campaign.NewCampaignService(campaignRepo, runnerRepo, repo.NewGormSponsorCampaignRepository(db),
+		campaign.WithUnitOfWork(repo.NewGormUnitOfWork(db)))
+
+	c := &campaignModel.Campaign{
+		Base:    model.Base{ID: "concurrent-" + suffix},
+		Name:    "concurrent",
+		OwnerID: owner.ID,
+		Slug:    "concurrent-" + suffix,
+		Status:  campaignModel.CampaignStatusActive,
+	}
+	require.NoError(t, campaignRepo.Create(c))
+	t.Cleanup(func() { db.Delete(&gormmodel.Campaign{}, "id = ?", c.ID) })
+
+	shared := &campaignModel.CampaignRunner{CampaignID: c.ID, OwnerID: owner.ID, Activity: "Running"}
+	require.NoError(t, runnerRepo.Create(shared))
+
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*3)
+	for i := 0; i < workers; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			errs <- service.RecordActivity(c.ID, owner.ID, 1, 5*time.Minute, "Running")
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- service.FinishActivity(shared.ID, 0.5, 2*time.Minute+30*time.Second, 2)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := service.CreateSponsorCampaign(c.ID, campaignModel.UsersByID(owner.ID), 1, 3, "", "")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	got, err := campaignRepo.GetByID(c.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, workers*1+workers*0.5, got.DistanceCovered, 1e-9)
+	assert.InDelta(t, workers*2+workers*3, got.MoneyRaised, 1e-9)
+
+	runner, err := runnerRepo.GetByID(shared.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, workers*0.5, runner.DistanceCovered, 1e-9)
+	assert.InDelta(t, workers*2, runner.MoneyRaised, 1e-9)
+
+	runners, err := runnerRepo.GetByCampaignID(c.ID)
+	require.NoError(t, err)
+	assert.Len(t, runners, workers+1)
+}
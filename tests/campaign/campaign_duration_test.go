@@ -0,0 +1,67 @@
+package campaign_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/data/campaign/repo"
+	"gopi.com/internal/db"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+)
+
+func TestMigrateLegacyDurations(t *testing.T) {
+	gdb := setupCampaignRunnerTestDB(t)
+	// Databases created before durations were stored as seconds still have the text column.
+	require.NoError(t, gdb.Exec("ALTER TABLE campaign_campaignrunner ADD COLUMN duration text").Error)
+
+	legacy := map[string]string{
+		"clock":   "1:02:03",
+		"minutes": "45 min",
+		"iso":     "PT25M30S",
+		"garbage": "a while",
+		"empty":   "",
+	}
+	for id, value := range legacy {
+		require.NoError(t, gdb.Create(&gormmodel.CampaignRunner{ID: id, CampaignID: "c", OwnerID: "o"}).Error)
+		require.NoError(t, gdb.Exec("UPDATE campaign_campaignrunner SET duration = ? WHERE id = ?", value, id).Error)
+	}
+
+	require.NoError(t, db.MigrateLegacyDurations(gdb, &gormmodel.CampaignRunner{}, &gormmodel.CampaignRun{}))
+	// Running again is a no-op
+	require.NoError(t, db.MigrateLegacyDurations(gdb, &gormmodel.CampaignRunner{}))
+
+	type row struct {
+		Duration        string
+		DurationSeconds int64
+	}
+	load := func(id string) row {
+		var r row
+		require.NoError(t, gdb.Table("campaign_campaignrunner").Select("duration", "duration_seconds").Where("id = ?", id).Scan(&r).Error)
+		return r
+	}
+
+	assert.Equal(t, row{Duration: "", DurationSeconds: 3723}, load("clock"))
+	assert.Equal(t, row{Duration: "", DurationSeconds: 2700}, load("minutes"))
+	assert.Equal(t, row{Duration: "", DurationSeconds: 1530}, load("iso"))
+	assert.Equal(t, row{Duration: "a while", DurationSeconds: 0}, load("garbage"))
+	assert.Equal(t, row{Duration: "", DurationSeconds: 0}, load("empty"))
+}
+
+func TestGormCampaignRunnerRepository_IncrementProgress_TotalsDuration(t *testing.T) {
+	gdb := setupCampaignRunnerTestDB(t)
+	runnerRepo := repo.NewGormCampaignRunnerRepository(gdb)
+
+	runner := &campaignModel.CampaignRunner{CampaignID: "c", OwnerID: "o", Activity: "Running", Duration: 10 * time.Minute}
+	require.NoError(t, runnerRepo.Create(runner))
+
+	require.NoError(t, runnerRepo.IncrementProgress(runner.ID, 5, 0, 25*time.Minute+30*time.Second))
+	require.NoError(t, runnerRepo.IncrementProgress(runner.ID, 3, 0, 14*time.Minute+45*time.Second))
+
+	got, err := runnerRepo.GetByID(runner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 50*time.Minute+15*time.Second, got.Duration)
+	assert.InDelta(t, 8, got.DistanceCovered, 1e-9)
+}
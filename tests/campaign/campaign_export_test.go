@@ -0,0 +1,207 @@
+package campaign_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/handler"
+	campaign "gopi.com/internal/app/campaign"
+	"gopi.com/internal/app/user"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	"gopi.com/internal/lib/xlsx"
+	campaignMocks "gopi.com/tests/mocks/campaign"
+	userMocks "gopi.com/tests/mocks/user"
+)
+
+func setupCampaignExportTest(t *testing.T, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	exportCampaign := &campaignModel.Campaign{Base: model.Base{ID: "c1"}, Slug: "city-run", OwnerID: "owner", DistanceCovered: 12}
+
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	mockRunnerRepo := new(campaignMocks.MockCampaignRunnerRepository)
+	mockSponsorRepo := new(campaignMocks.MockSponsorCampaignRepository)
+	mockUserRepo := new(userMocks.MockUserRepository)
+
+	mockCampaignRepo.On("GetBySlug", "city-run").Return(exportCampaign, nil)
+	mockCampaignRepo.On("GetByID", "c1").Return(exportCampaign, nil)
+	mockRunnerRepo.On("GetByCampaignID", "c1").Return([]*campaignModel.CampaignRunner{
+		{Base: model.Base{ID: "r1"}, CampaignID: "c1", OwnerID: "ada", Activity: "running", DistanceCovered: 10.5,
+			Duration: 61 * time.Minute, MoneyRaised: 21, DateJoined: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)},
+		{Base: model.Base{ID: "r2"}, CampaignID: "c1", OwnerID: "ghost", Activity: "walking", DistanceCovered: 1.5,
+			Duration: 20 * time.Minute},
+	}, nil)
+	mockSponsorRepo.On("GetByCampaignID", "c1").Return([]*campaignModel.SponsorCampaign{
+		{Base: model.Base{ID: "s1"}, CampaignID: "c1", Distance: 20, AmountPerKm: 2.5, Sponsors: campaignModel.UsersByID("acme")},
+		{Base: model.Base{ID: "s2"}, CampaignID: "c1", Distance: 5, AmountPerKm: 10},
+	}, nil)
+	// One lookup for the whole export; ghost has no user and is left blank.
+	mockUserRepo.On("GetByIDs", []string{"ada", "ghost", "acme"}).Return([]*userModel.User{
+		{Base: model.Base{ID: "ada"}, Username: "=ada", FirstName: "Ada", LastName: "Lovelace"},
+		{Base: model.Base{ID: "acme"}, Username: "acme"},
+	}, nil).Once()
+
+	campaignService := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo,
+		campaign.WithCloseout(nil, mockUserRepo, nil))
+	campaignHandler := handler.NewCampaignHandler(campaignService, user.NewUserService(mockUserRepo, nil))
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+	protected := router.Group("/campaigns")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Next()
+	})
+	protected.GET("/:slug/export", campaignHandler.ExportCampaign)
+	return router
+}
+
+func TestCampaignHandler_ExportCampaign_CSV(t *testing.T) {
+	router := setupCampaignExportTest(t, "owner")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/campaigns/city-run/export", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `filename=city-run-results.csv`)
+
+	r := csv.NewReader(w.Body)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 6) // roster header, 2 runners, ledger header, 2 sponsorships
+
+	assert.Equal(t, "runner_id", records[0][0])
+	// Formula-like usernames are neutralised.
+	assert.Equal(t, []string{"r1", "ada", "'=ada", "Ada Lovelace", "running", "2026-03-01T09:00:00Z",
+		"10.5", "1:01:00", "3660", "21", "0"}, records[1])
+	assert.Equal(t, []string{"r2", "ghost", "", "", "walking", "", "1.5", "20:00", "1200", "0", "0"}, records[2])
+
+	assert.Equal(t, "sponsorship_id", records[3][0])
+	// s1 owes 12 km x 2.5; s2 is capped at its 5 km pledge.
+	assert.Equal(t, []string{"s1", "acme", "20", "2.5", "50", "12", "30"}, records[4])
+	assert.Equal(t, []string{"s2", "", "5", "10", "50", "5", "50"}, records[5])
+}
+
+func TestCampaignHandler_ExportCampaign_XLSX(t *testing.T) {
+	router := setupCampaignExportTest(t, "owner")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/campaigns/city-run/export?format=xlsx", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, xlsx.ContentType, w.Header().Get("Content-Type"))
+
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	require.NoError(t, err)
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		parts[f.Name] = string(data)
+	}
+
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Runners"`)
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Sponsor ledger"`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], "Ada Lovelace")
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<c r="G2" s="0"><v>10.5</v></c>`)
+	assert.Contains(t, parts["xl/worksheets/sheet2.xml"], `<c r="G2" s="0"><v>30</v></c>`)
+}
+
+func TestCampaignHandler_ExportCampaign_Rejected(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		query          string
+		expectedStatus int
+	}{
+		{name: "not the owner", userID: "stranger", expectedStatus: http.StatusForbidden},
+		{name: "unknown format", userID: "owner", query: "?format=pdf", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupCampaignExportTest(t, tt.userID)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/campaigns/city-run/export"+tt.query, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "application/json"))
+		})
+	}
+}
+
+func TestCampaignService_ExportCampaign_Closed(t *testing.T) {
+	closedAt := time.Now()
+	closed := &campaignModel.Campaign{Base: model.Base{ID: "c1"}, OwnerID: "owner", DistanceCovered: 12, ClosedAt: &closedAt}
+
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	mockRunnerRepo := new(campaignMocks.MockCampaignRunnerRepository)
+	mockSponsorRepo := new(campaignMocks.MockSponsorCampaignRepository)
+	mockResultRepo := new(campaignMocks.MockCampaignResultRepository)
+	mockUserRepo := new(userMocks.MockUserRepository)
+
+	mockCampaignRepo.On("GetByID", "c1").Return(closed, nil)
+	mockRunnerRepo.On("GetByCampaignID", "c1").Return([]*campaignModel.CampaignRunner{}, nil)
+	mockSponsorRepo.On("GetByCampaignID", "c1").Return([]*campaignModel.SponsorCampaign{
+		{Base: model.Base{ID: "s1"}, CampaignID: "c1", Distance: 20, AmountPerKm: 2.5, Sponsors: campaignModel.UsersByID("acme")},
+	}, nil)
+	// Frozen at 10 km, before a later approval moved the live total to 12 km.
+	mockResultRepo.On("GetObligations", "c1").Return([]*campaignModel.SponsorObligation{
+		{CampaignID: "c1", SponsorCampaignID: "s1", PledgedDistance: 20, CreditedDistance: 10, AmountPerKm: 2.5, Amount: 25},
+	}, nil)
+	mockUserRepo.On("GetByIDs", []string{"acme"}).Return([]*userModel.User{{Base: model.Base{ID: "acme"}, Username: "acme"}}, nil)
+
+	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo,
+		campaign.WithCloseout(mockResultRepo, mockUserRepo, nil))
+
+	export, err := service.ExportCampaign("c1")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteCSV(&buf))
+
+	r := csv.NewReader(&buf)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"s1", "acme", "20", "2.5", "50", "10", "25"}, records[2])
+}
+
+func TestCampaignService_ExportCampaign_UserLookupFails(t *testing.T) {
+	c := &campaignModel.Campaign{Base: model.Base{ID: "c1"}, OwnerID: "owner"}
+
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	mockRunnerRepo := new(campaignMocks.MockCampaignRunnerRepository)
+	mockSponsorRepo := new(campaignMocks.MockSponsorCampaignRepository)
+	mockUserRepo := new(userMocks.MockUserRepository)
+
+	mockCampaignRepo.On("GetByID", "c1").Return(c, nil)
+	mockRunnerRepo.On("GetByCampaignID", "c1").Return([]*campaignModel.CampaignRunner{{CampaignID: "c1", OwnerID: "ada"}}, nil)
+	mockSponsorRepo.On("GetByCampaignID", "c1").Return([]*campaignModel.SponsorCampaign{}, nil)
+	mockUserRepo.On("GetByIDs", []string{"ada"}).Return(nil, errors.New("connection refused"))
+
+	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo,
+		campaign.WithCloseout(nil, mockUserRepo, nil))
+
+	_, err := service.ExportCampaign("c1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,278 @@
+package campaign_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/handler"
+	"gopi.com/internal/app/campaign"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/data/campaign/repo"
+	"gopi.com/internal/db"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	"gopi.com/internal/lib/geo"
+	campaignMocks "gopi.com/tests/mocks/campaign"
+	userMocks "gopi.com/tests/mocks/user"
+)
+
+var lagos = model.GeoPoint{Lat: 6.5244, Lon: 3.3792}
+
+func testGeocoder(t *testing.T) geo.Geocoder {
+	g, err := geo.ReadGazetteer(strings.NewReader("Lagos,6.5244,3.3792\nIkeja Lagos,6.6018,3.3515\nAbuja,9.0765,7.3986\n"))
+	require.NoError(t, err)
+	return g
+}
+
+func TestGormCampaignRepository_Find_Near(t *testing.T) {
+	gdb := setupTestDB(t)
+	campaignRepo := repo.NewGormCampaignRepository(gdb)
+
+	base := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	for i, c := range []struct {
+		id       string
+		activity campaignModel.Activity
+		at       *model.GeoPoint
+	}{
+		{"lekki", campaignModel.ActivityRunning, &model.GeoPoint{Lat: 6.4698, Lon: 3.5852}}, // ~24 km
+		{"ikeja", campaignModel.ActivityWalking, &model.GeoPoint{Lat: 6.6018, Lon: 3.3515}}, // ~9 km
+		{"abuja", campaignModel.ActivityRunning, &model.GeoPoint{Lat: 9.0765, Lon: 7.3986}}, // ~526 km
+		{"unplaced", campaignModel.ActivityRunning, nil},
+		{"island", campaignModel.ActivityRunning, &model.GeoPoint{Lat: 6.4281, Lon: 3.4219}}, // ~12 km
+		// Inside the bounding box of a 25 km radius but further away than 25 km.
+		{"corner", campaignModel.ActivityRunning, &model.GeoPoint{Lat: 6.70, Lon: 3.55}},
+	} {
+		require.NoError(t, campaignRepo.Create(&campaignModel.Campaign{
+			Base:     model.Base{ID: c.id, CreatedAt: base.Add(time.Duration(i) * time.Hour)},
+			Name:     c.id,
+			Activity: c.activity, Coordinates: c.at, OwnerID: "owner1", Slug: c.id,
+		}))
+	}
+
+	near := &model.Near{Center: lagos, RadiusKm: 25}
+	tests := []struct {
+		name          string
+		filter        campaignModel.CampaignFilter
+		expectedIDs   []string
+		expectedTotal int64
+	}{
+		{
+			name:          "within the radius, newest first",
+			filter:        campaignModel.CampaignFilter{Near: near},
+			expectedIDs:   []string{"island", "ikeja", "lekki"},
+			expectedTotal: 3,
+		},
+		{
+			name:          "nearest first",
+			filter:        campaignModel.CampaignFilter{Near: near, Sort: campaignModel.CampaignSortNearest},
+			expectedIDs:   []string{"ikeja", "island", "lekki"},
+			expectedTotal: 3,
+		},
+		{
+			name:          "combined with other filters",
+			filter:        campaignModel.CampaignFilter{Near: near, Activity: campaignModel.ActivityRunning},
+			expectedIDs:   []string{"island", "lekki"},
+			expectedTotal: 2,
+		},
+		{
+			name:          "paged with the full total",
+			filter:        campaignModel.CampaignFilter{Near: near, Sort: campaignModel.CampaignSortNearest, Limit: 2, Offset: 2},
+			expectedIDs:   []string{"lekki"},
+			expectedTotal: 3,
+		},
+		{
+			name:          "page past the end",
+			filter:        campaignModel.CampaignFilter{Near: near, Limit: 2, Offset: 4},
+			expectedIDs:   []string{},
+			expectedTotal: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaigns, total, err := campaignRepo.Find(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedIDs, ids(campaigns))
+			assert.Equal(t, tt.expectedTotal, total)
+		})
+	}
+
+	found, err := campaignRepo.GetByID("ikeja")
+	require.NoError(t, err)
+	require.NotNil(t, found.Coordinates)
+	assert.Equal(t, 6.6018, found.Coordinates.Lat)
+}
+
+func TestCampaignService_Geocoding(t *testing.T) {
+	gdb := setupTestDB(t)
+	campaignRepo := repo.NewGormCampaignRepository(gdb)
+	service := campaign.NewCampaignService(campaignRepo, repo.NewGormCampaignRunnerRepository(gdb),
+		repo.NewGormSponsorCampaignRepository(gdb), campaign.WithGeocoder(testGeocoder(t)))
+
+	create := func(name, location string, at *model.GeoPoint) *campaignModel.Campaign {
+		c, err := service.CreateCampaign("owner1", "owner", name, "", "", "", location,
+			campaignModel.CampaignModeFree, campaignModel.ActivityRunning, 0, 0, 10, "", "", at, "")
+		require.NoError(t, err)
+		stored, err := campaignRepo.GetByID(c.ID)
+		require.NoError(t, err)
+		return stored
+	}
+
+	located := create("Located", "Ikeja, Lagos", nil)
+	require.NotNil(t, located.Coordinates)
+	assert.Equal(t, model.GeoPoint{Lat: 6.6018, Lon: 3.3515}, *located.Coordinates)
+
+	explicit := create("Explicit", "Lagos", &model.GeoPoint{Lat: 6.45, Lon: 3.40})
+	assert.Equal(t, model.GeoPoint{Lat: 6.45, Lon: 3.40}, *explicit.Coordinates)
+
+	unknown := create("Unknown", "Somewhere Else", nil)
+	assert.Nil(t, unknown.Coordinates)
+
+	// A campaign moved to a new location without coordinates is positioned again.
+	located.Location = "Abuja"
+	located.Coordinates = nil
+	require.NoError(t, service.UpdateCampaign(located))
+	moved, err := campaignRepo.GetByID(located.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.GeoPoint{Lat: 9.0765, Lon: 7.3986}, *moved.Coordinates)
+}
+
+func TestGeocodeMissingCoordinates(t *testing.T) {
+	gdb := setupTestDB(t)
+	campaignRepo := repo.NewGormCampaignRepository(gdb)
+	for _, c := range []*campaignModel.Campaign{
+		{Base: model.Base{ID: "old"}, Name: "Old", Location: "Lagos", OwnerID: "owner1", Slug: "old"},
+		{Base: model.Base{ID: "unknown"}, Name: "Unknown", Location: "Atlantis", OwnerID: "owner1", Slug: "unknown"},
+		{Base: model.Base{ID: "blank"}, Name: "Blank", OwnerID: "owner1", Slug: "blank"},
+		{Base: model.Base{ID: "placed"}, Name: "Placed", Location: "Lagos", Coordinates: &model.GeoPoint{Lat: 1, Lon: 1}, OwnerID: "owner1", Slug: "placed"},
+	} {
+		require.NoError(t, campaignRepo.Create(c))
+	}
+
+	require.NoError(t, db.GeocodeMissingCoordinates(context.Background(), gdb, testGeocoder(t), &gormmodel.Campaign{}))
+	require.NoError(t, db.GeocodeMissingCoordinates(context.Background(), gdb, nil, &gormmodel.Campaign{}))
+
+	coordinates := func(id string) *model.GeoPoint {
+		c, err := campaignRepo.GetByID(id)
+		require.NoError(t, err)
+		return c.Coordinates
+	}
+	assert.Equal(t, &lagos, coordinates("old"))
+	assert.Nil(t, coordinates("unknown"))
+	assert.Nil(t, coordinates("blank"))
+	assert.Equal(t, &model.GeoPoint{Lat: 1, Lon: 1}, coordinates("placed"))
+}
+
+func TestCampaignHandler_Geo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		setupMocks     func(*campaignMocks.MockCampaignRepository, *userMocks.MockUserRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "search near a position",
+			method: http.MethodGet,
+			target: "/campaigns/search?near=6.5244,3.3792&radius_km=10&sort=nearest",
+			setupMocks: func(m *campaignMocks.MockCampaignRepository, _ *userMocks.MockUserRepository) {
+				m.On("Find", mock.MatchedBy(func(f campaignModel.CampaignFilter) bool {
+					return f.Near != nil && f.Near.Center == lagos && f.Near.RadiusKm == 10 &&
+						f.Sort == campaignModel.CampaignSortNearest
+				})).Return([]*campaignModel.Campaign{}, int64(0), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "radius defaults to 25 km",
+			method: http.MethodGet,
+			target: "/campaigns/search?near=6.5244,3.3792",
+			setupMocks: func(m *campaignMocks.MockCampaignRepository, _ *userMocks.MockUserRepository) {
+				m.On("Find", mock.MatchedBy(func(f campaignModel.CampaignFilter) bool {
+					return f.Near != nil && f.Near.RadiusKm == 25
+				})).Return([]*campaignModel.Campaign{}, int64(0), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "nearest needs a position",
+			method:         http.MethodGet,
+			target:         "/campaigns/search?sort=nearest",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "malformed near",
+			method:         http.MethodGet,
+			target:         "/campaigns/search?near=lagos",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "radius out of range",
+			method:         http.MethodGet,
+			target:         "/campaigns/search?near=6.5,3.3&radius_km=5000",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "create with coordinates",
+			method: http.MethodPost,
+			target: "/campaigns",
+			body:   `{"name":"Placed Run","location":"Lagos","latitude":6.45,"longitude":3.4}`,
+			setupMocks: func(m *campaignMocks.MockCampaignRepository, u *userMocks.MockUserRepository) {
+				u.On("GetByID", "user123").Return(&userModel.User{Base: model.Base{ID: "user123"}, Username: "runner"}, nil)
+				m.On("Create", mock.MatchedBy(func(c *campaignModel.Campaign) bool {
+					return c.Coordinates != nil && *c.Coordinates == model.GeoPoint{Lat: 6.45, Lon: 3.4}
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create with half a position",
+			method:         http.MethodPost,
+			target:         "/campaigns",
+			body:           `{"name":"Half Placed","latitude":6.45}`,
+			setupMocks:     func(_ *campaignMocks.MockCampaignRepository, u *userMocks.MockUserRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+			mockUserRepo := new(userMocks.MockUserRepository)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockCampaignRepo, mockUserRepo)
+			}
+			campaignService := campaign.NewCampaignService(mockCampaignRepo, new(campaignMocks.MockCampaignRunnerRepository),
+				new(campaignMocks.MockSponsorCampaignRepository))
+			campaignHandler := handler.NewCampaignHandler(campaignService, user.NewUserService(mockUserRepo, nil))
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", "user123")
+				c.Next()
+			})
+			router.GET("/campaigns/search", campaignHandler.SearchCampaigns)
+			router.POST("/campaigns", campaignHandler.CreateCampaign)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.body)))
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			mockCampaignRepo.AssertExpectations(t)
+		})
+	}
+}
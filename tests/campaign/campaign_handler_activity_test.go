@@ -11,7 +11,6 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"gopi.com/api/http/dto"
 	"gopi.com/api/http/handler"
 	"gopi.com/internal/app/campaign"
@@ -94,7 +93,7 @@ func TestCampaignHandler_GetFinishCampaignDetails(t *testing.T) {
 					},
 					CampaignID:      "campaign123",
 					DistanceCovered: 5.0,
-					Duration:        "25:00",
+					Duration:        25 * time.Minute,
 					MoneyRaised:     10.0,
 					Activity:        "Running",
 					OwnerID:         "test-user-id",
@@ -173,7 +172,7 @@ func TestCampaignHandler_GetFinishCampaignDetails(t *testing.T) {
 					},
 					CampaignID:      "campaign123",
 					DistanceCovered: 3.0,
-					Duration:        "15:00",
+					Duration:        15 * time.Minute,
 					MoneyRaised:     5.0,
 					Activity:        "Walking",
 					OwnerID:         "different-user", // Different from test-user-id
@@ -250,7 +249,7 @@ func TestCampaignHandler_FinishCampaignRun(t *testing.T) {
 					},
 					CampaignID:      "campaign123",
 					DistanceCovered: 5.0,
-					Duration:        "25:00",
+					Duration:        25 * time.Minute,
 					MoneyRaised:     10.0,
 					Activity:        "Running",
 					OwnerID:         "test-user-id",
@@ -260,17 +259,9 @@ func TestCampaignHandler_FinishCampaignRun(t *testing.T) {
 				mockCampaignRepo.On("GetBySlug", "test-campaign-slug").Return(expectedCampaign, nil)
 				mockCampaignRepo.On("GetByID", "campaign123").Return(expectedCampaign, nil)
 				mockRunnerRepo.On("GetByID", "runner123").Return(expectedRunner, nil)
-				mockRunnerRepo.On("Update", mock.MatchedBy(func(r *campaignModel.CampaignRunner) bool {
-					return r.ID == "runner123" &&
-						r.DistanceCovered == 15.5 && // 5.0 (existing) + 10.5 (new) = 15.5
-						r.Duration == "35:20" &&
-						r.MoneyRaised == 25.0 // 10.0 (existing) + 15.0 (new) = 25.0
-				})).Return(nil)
-				mockCampaignRepo.On("Update", mock.MatchedBy(func(c *campaignModel.Campaign) bool {
-					return c.ID == "campaign123" &&
-						c.DistanceCovered == 35.5 &&
-						c.MoneyRaised == 65.0
-				})).Return(nil)
+				// Runner and campaign totals are incremented atomically by the new distance and money
+				mockRunnerRepo.On("IncrementProgress", "runner123", 10.5, 15.0, 35*time.Minute+20*time.Second).Return(nil)
+				mockCampaignRepo.On("IncrementTotals", "campaign123", 10.5, 15.0).Return(nil)
 
 				// Mock user service for response
 				runnerOwner := &userModel.User{
@@ -318,7 +309,7 @@ func TestCampaignHandler_FinishCampaignRun(t *testing.T) {
 					},
 					CampaignID:      "campaign123",
 					DistanceCovered: 3.0,
-					Duration:        "12:00",
+					Duration:        12 * time.Minute,
 					MoneyRaised:     0,
 					Activity:        "Walking",
 					OwnerID:         "test-user-id",
@@ -328,17 +319,9 @@ func TestCampaignHandler_FinishCampaignRun(t *testing.T) {
 				mockCampaignRepo.On("GetBySlug", "test-campaign-slug").Return(expectedCampaign, nil)
 				mockCampaignRepo.On("GetByID", "campaign123").Return(expectedCampaign, nil)
 				mockRunnerRepo.On("GetByID", "runner123").Return(expectedRunner, nil)
-				mockRunnerRepo.On("Update", mock.MatchedBy(func(r *campaignModel.CampaignRunner) bool {
-					return r.ID == "runner123" &&
-						r.DistanceCovered == 11.0 && // 3.0 (existing) + 8.0 (new) = 11.0
-						r.Duration == "28:15" &&
-						r.MoneyRaised == 0 // 0 (existing) + 0 (new) = 0
-				})).Return(nil)
-				mockCampaignRepo.On("Update", mock.MatchedBy(func(c *campaignModel.Campaign) bool {
-					return c.ID == "campaign123" &&
-						c.DistanceCovered == 23.0 &&
-						c.MoneyRaised == 30.0 // No change since MoneyRaised is 0
-				})).Return(nil)
+				// Runner and campaign totals are incremented atomically by the new distance
+				mockRunnerRepo.On("IncrementProgress", "runner123", 8.0, 0.0, 28*time.Minute+15*time.Second).Return(nil)
+				mockCampaignRepo.On("IncrementTotals", "campaign123", 8.0, 0.0).Return(nil)
 
 				// Mock user service for response
 				runnerOwner := &userModel.User{
@@ -382,6 +365,51 @@ func TestCampaignHandler_FinishCampaignRun(t *testing.T) {
 				// No mocks needed - validation happens before service calls
 			},
 		},
+		{
+			name:     "invalid request - unparseable duration",
+			slug:     "test-campaign-slug",
+			runnerID: "runner123",
+			requestBody: dto.FinishActivityRequest{
+				DistanceCovered: 5.0,
+				Duration:        "half an hour",
+				MoneyRaised:     10.0,
+			},
+			expectedStatus: http.StatusBadRequest,
+			mockSetup: func() {
+				// No mocks needed - validation happens before service calls
+			},
+		},
+		{
+			name:     "duration sent as seconds",
+			slug:     "test-campaign-slug",
+			runnerID: "runner789",
+			requestBody: dto.FinishActivityRequest{
+				DistanceCovered: 6.0,
+				Duration:        "ignored when seconds are sent",
+				DurationSeconds: func() *int64 { v := int64(1950); return &v }(),
+			},
+			expectedStatus: http.StatusOK,
+			mockSetup: func() {
+				expectedCampaign := &campaignModel.Campaign{
+					Base: model.Base{ID: "campaign123"},
+					Name: "Test Campaign",
+					Slug: "test-campaign-slug",
+				}
+				expectedRunner := &campaignModel.CampaignRunner{
+					Base:       model.Base{ID: "runner789"},
+					CampaignID: "campaign123",
+					Activity:   "Running",
+					OwnerID:    "test-user-id",
+				}
+
+				mockCampaignRepo.On("GetBySlug", "test-campaign-slug").Return(expectedCampaign, nil)
+				mockCampaignRepo.On("GetByID", "campaign123").Return(expectedCampaign, nil)
+				mockRunnerRepo.On("GetByID", "runner789").Return(expectedRunner, nil)
+				mockRunnerRepo.On("IncrementProgress", "runner789", 6.0, 0.0, 32*time.Minute+30*time.Second).Return(nil)
+				mockCampaignRepo.On("IncrementTotals", "campaign123", 6.0, 0.0).Return(nil)
+				mockUserRepo.On("GetByID", "test-user-id").Return(&userModel.User{Base: model.Base{ID: "test-user-id"}}, nil)
+			},
+		},
 		{
 			name:     "campaign not found",
 			slug:     "nonexistent-slug",
@@ -452,7 +480,7 @@ func TestCampaignHandler_FinishCampaignRun(t *testing.T) {
 					},
 					CampaignID:      "campaign123",
 					DistanceCovered: 3.0,
-					Duration:        "15:00",
+					Duration:        15 * time.Minute,
 					MoneyRaised:     5.0,
 					Activity:        "Walking",
 					OwnerID:         "different-user", // Different from test-user-id
@@ -493,7 +521,7 @@ func TestCampaignHandler_FinishCampaignRun(t *testing.T) {
 					},
 					CampaignID:      "campaign123",
 					DistanceCovered: 5.0,
-					Duration:        "25:00",
+					Duration:        25 * time.Minute,
 					MoneyRaised:     10.0,
 					Activity:        "Running",
 					OwnerID:         "test-user-id",
@@ -501,8 +529,9 @@ func TestCampaignHandler_FinishCampaignRun(t *testing.T) {
 				}
 
 				mockCampaignRepo.On("GetBySlug", "test-campaign-slug").Return(expectedCampaign, nil)
+				mockCampaignRepo.On("GetByID", "campaign123").Return(expectedCampaign, nil)
 				mockRunnerRepo.On("GetByID", "runner123").Return(expectedRunner, nil)
-				mockRunnerRepo.On("Update", mock.Anything).Return(errors.New("repository error"))
+				mockRunnerRepo.On("IncrementProgress", "runner123", 12.0, 20.0, 40*time.Minute).Return(errors.New("repository error"))
 			},
 		},
 	}
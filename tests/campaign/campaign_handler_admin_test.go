@@ -126,22 +126,15 @@ func TestCampaignAdminHandler_CreateCampaignRunner(t *testing.T) {
 						r.OwnerID == "user123" &&
 						r.Activity == "Running" &&
 						r.DistanceCovered == 0 &&
-						r.Duration == "" &&
+						r.Duration == 0 &&
 						r.MoneyRaised == 0
 				})).Return(nil)
 
-				// Mock FinishActivity call (updates runner with additional details)
-				mockRunnerRepo.On("Update", mock.MatchedBy(func(r *campaignModel.CampaignRunner) bool {
-					return r.DistanceCovered == 10.5 &&
-						r.Duration == "45:30" &&
-						r.MoneyRaised == 25.0
-				})).Return(nil)
+				// Mock FinishActivity call (increments runner with additional details)
+				mockRunnerRepo.On("IncrementProgress", mock.Anything, 10.5, 25.0, 45*time.Minute+30*time.Second).Return(nil)
 
-				// Mock campaign update for FinishActivity
-				mockCampaignRepo.On("Update", mock.MatchedBy(func(c *campaignModel.Campaign) bool {
-					return c.DistanceCovered == 10.5 &&
-						c.MoneyRaised == 25.0
-				})).Return(nil)
+				// Mock campaign totals increment for FinishActivity
+				mockCampaignRepo.On("IncrementTotals", "campaign123", 10.5, 25.0).Return(nil)
 
 				// Mock getting runner for FinishActivity
 				mockRunnerRepo.On("GetByID", mock.Anything).Return(&campaignModel.CampaignRunner{
@@ -154,7 +147,7 @@ func TestCampaignAdminHandler_CreateCampaignRunner(t *testing.T) {
 					OwnerID:         "user123",
 					Activity:        "Running",
 					DistanceCovered: 0,
-					Duration:        "",
+					Duration:        0,
 					MoneyRaised:     0,
 					DateJoined:      time.Now(),
 				}, nil).Once()
@@ -170,7 +163,7 @@ func TestCampaignAdminHandler_CreateCampaignRunner(t *testing.T) {
 					OwnerID:         "user123",
 					Activity:        "Running",
 					DistanceCovered: 10.5,
-					Duration:        "45:30",
+					Duration:        45*time.Minute + 30*time.Second,
 					MoneyRaised:     25.0,
 					DateJoined:      time.Now(),
 				}
@@ -329,7 +322,7 @@ func TestCampaignAdminHandler_GetCampaignRunners(t *testing.T) {
 						OwnerID:         "user1",
 						Activity:        "Running",
 						DistanceCovered: 10.5,
-						Duration:        "45:30",
+						Duration:        45*time.Minute + 30*time.Second,
 						MoneyRaised:     25.0,
 						DateJoined:      time.Now(),
 					},
@@ -343,7 +336,7 @@ func TestCampaignAdminHandler_GetCampaignRunners(t *testing.T) {
 						OwnerID:         "user2",
 						Activity:        "Walking",
 						DistanceCovered: 5.0,
-						Duration:        "30:00",
+						Duration:        30 * time.Minute,
 						MoneyRaised:     10.0,
 						DateJoined:      time.Now(),
 					},
@@ -410,7 +403,7 @@ func TestCampaignAdminHandler_GetCampaignRunners(t *testing.T) {
 						OwnerID:         "user3",
 						Activity:        "Cycling",
 						DistanceCovered: 15.0,
-						Duration:        "60:00",
+						Duration:        time.Hour,
 						MoneyRaised:     30.0,
 						DateJoined:      time.Now(),
 					},
@@ -505,7 +498,7 @@ func TestCampaignAdminHandler_GetCampaignRunnerByID(t *testing.T) {
 					OwnerID:         "user123",
 					Activity:        "Running",
 					DistanceCovered: 10.5,
-					Duration:        "45:30",
+					Duration:        45*time.Minute + 30*time.Second,
 					MoneyRaised:     25.0,
 					DateJoined:      time.Now(),
 				}
@@ -596,7 +589,7 @@ func TestCampaignAdminHandler_UpdateCampaignRunner(t *testing.T) {
 					OwnerID:         "user123",
 					Activity:        "Running",
 					DistanceCovered: 10.5,
-					Duration:        "45:30",
+					Duration:        45*time.Minute + 30*time.Second,
 					MoneyRaised:     25.0,
 					CoverImage:      "old-cover.jpg",
 				}
@@ -606,7 +599,7 @@ func TestCampaignAdminHandler_UpdateCampaignRunner(t *testing.T) {
 					return r.ID == "runner123" &&
 						r.Activity == "Walking" &&
 						r.DistanceCovered == 15.0 &&
-						r.Duration == "60:00" &&
+						r.Duration == time.Hour &&
 						r.MoneyRaised == 30.0 &&
 						r.CoverImage == "new-cover.jpg"
 				})).Return(nil)
@@ -622,7 +615,7 @@ func TestCampaignAdminHandler_UpdateCampaignRunner(t *testing.T) {
 					OwnerID:         "user123",
 					Activity:        "Walking",
 					DistanceCovered: 15.0,
-					Duration:        "60:00",
+					Duration:        time.Hour,
 					MoneyRaised:     30.0,
 					CoverImage:      "new-cover.jpg",
 				}
@@ -660,7 +653,7 @@ func TestCampaignAdminHandler_UpdateCampaignRunner(t *testing.T) {
 					OwnerID:         "user123",
 					Activity:        "Running",
 					DistanceCovered: 10.5,
-					Duration:        "45:30",
+					Duration:        45*time.Minute + 30*time.Second,
 					MoneyRaised:     25.0,
 				}
 
@@ -669,7 +662,7 @@ func TestCampaignAdminHandler_UpdateCampaignRunner(t *testing.T) {
 					return r.ID == "runner123" &&
 						r.Activity == "Cycling" &&
 						r.DistanceCovered == 10.5 && // unchanged
-						r.Duration == "45:30" && // unchanged
+						r.Duration == 45*time.Minute+30*time.Second && // unchanged
 						r.MoneyRaised == 25.0 // unchanged
 				})).Return(nil)
 
@@ -880,13 +873,11 @@ func TestCampaignAdminHandler_CreateSponsorCampaign(t *testing.T) {
 						s.VideoUrl == "video.mp4"
 				})).Return(nil)
 
-				// Mock second GetByID call in CreateSponsorCampaign for updating campaign
+				// Mock second GetByID call in CreateSponsorCampaign for the updated totals
 				mockCampaignRepo.On("GetByID", "campaign123").Return(expectedCampaign, nil)
 
-				// Mock campaign update in CreateSponsorCampaign
-				mockCampaignRepo.On("Update", mock.MatchedBy(func(c *campaignModel.Campaign) bool {
-					return c.ID == "campaign123" && c.MoneyRaised == 50.0
-				})).Return(nil)
+				// Mock money raised increment in CreateSponsorCampaign
+				mockCampaignRepo.On("IncrementTotals", "campaign123", 0.0, 50.0).Return(nil)
 
 				// Mock AddSponsor call
 				mockCampaignRepo.On("AddSponsor", "campaign123", "sponsor123").Return(nil)
@@ -936,13 +927,11 @@ func TestCampaignAdminHandler_CreateSponsorCampaign(t *testing.T) {
 						s.VideoUrl == ""
 				})).Return(nil)
 
-				// Mock second GetByID call in CreateSponsorCampaign for updating campaign
+				// Mock second GetByID call in CreateSponsorCampaign for the updated totals
 				mockCampaignRepo.On("GetByID", "campaign123").Return(expectedCampaign, nil)
 
-				// Mock campaign update in CreateSponsorCampaign
-				mockCampaignRepo.On("Update", mock.MatchedBy(func(c *campaignModel.Campaign) bool {
-					return c.ID == "campaign123" && c.MoneyRaised == 10.0
-				})).Return(nil)
+				// Mock money raised increment in CreateSponsorCampaign
+				mockCampaignRepo.On("IncrementTotals", "campaign123", 0.0, 10.0).Return(nil)
 
 				// Mock AddSponsor call
 				mockCampaignRepo.On("AddSponsor", "campaign123", "sponsor123").Return(nil)
@@ -268,7 +268,8 @@ func TestCampaignHandler_GetCampaigns(t *testing.T) {
 		},
 	}
 
-	mockCampaignRepo.On("List", 10, 0).Return(expectedCampaigns, nil)
+	mockCampaignRepo.On("Find", campaignModel.CampaignFilter{Listed: true, ViewerID: "test-user-id", Limit: 10, Offset: 0}).
+		Return(expectedCampaigns, int64(2), nil)
 
 	// Mock user lookups for each campaign owner
 	owner1 := &userModel.User{
@@ -9,12 +9,14 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"gopi.com/api/http/dto"
 	"gopi.com/api/http/handler"
 	"gopi.com/internal/app/campaign"
 	"gopi.com/internal/app/user"
 	campaignModel "gopi.com/internal/domain/campaign/model"
 	"gopi.com/internal/domain/model"
 	userModel "gopi.com/internal/domain/user/model"
+	"gopi.com/internal/lib/leaderboard"
 	campaignMocks "gopi.com/tests/mocks/campaign"
 	userMocks "gopi.com/tests/mocks/user"
 )
@@ -90,7 +92,7 @@ func TestCampaignHandler_GetCampaignLeaderboard(t *testing.T) {
 						},
 						CampaignID:      "campaign123",
 						DistanceCovered: 15.5,
-						Duration:        "45:30",
+						Duration:        45*time.Minute + 30*time.Second,
 						MoneyRaised:     25.0,
 						Activity:        "Running",
 						OwnerID:         "user1",
@@ -105,7 +107,7 @@ func TestCampaignHandler_GetCampaignLeaderboard(t *testing.T) {
 						},
 						CampaignID:      "campaign123",
 						DistanceCovered: 12.0,
-						Duration:        "38:15",
+						Duration:        38*time.Minute + 15*time.Second,
 						MoneyRaised:     18.0,
 						Activity:        "Walking",
 						OwnerID:         "user2",
@@ -120,7 +122,7 @@ func TestCampaignHandler_GetCampaignLeaderboard(t *testing.T) {
 						},
 						CampaignID:      "campaign123",
 						DistanceCovered: 8.5,
-						Duration:        "28:45",
+						Duration:        28*time.Minute + 45*time.Second,
 						MoneyRaised:     12.0,
 						Activity:        "Cycling",
 						OwnerID:         "user3",
@@ -152,7 +154,7 @@ func TestCampaignHandler_GetCampaignLeaderboard(t *testing.T) {
 		{
 			name:           "campaign not found",
 			slug:           "nonexistent-slug",
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusNotFound,
 			expectedCount:  0,
 			mockSetup: func() {
 				mockCampaignRepo.On("GetBySlug", "nonexistent-slug").Return(nil, assert.AnError)
@@ -237,3 +239,68 @@ func TestCampaignHandler_GetCampaignLeaderboard(t *testing.T) {
 		})
 	}
 }
+
+func TestCampaignHandler_GetCampaignLeaderboard_Filters(t *testing.T) {
+	router, mockCampaignRepo, mockRunnerRepo, _, mockUserRepo := setupCampaignLeaderboardTest(t)
+
+	expectedCampaign := &campaignModel.Campaign{Base: model.Base{ID: "campaign123"}, Slug: "filtered"}
+	mockCampaignRepo.On("GetBySlug", "filtered").Return(expectedCampaign, nil)
+	mockRunnerRepo.On("GetByCampaignID", "campaign123").Return([]*campaignModel.CampaignRunner{
+		{CampaignID: "campaign123", OwnerID: "user1", Activity: "Running", DistanceCovered: 15},
+		{CampaignID: "campaign123", OwnerID: "user2", Activity: "Walking", DistanceCovered: 12},
+		{CampaignID: "campaign123", OwnerID: "test-user-id", Activity: "Walking", DistanceCovered: 3},
+	}, nil)
+	for _, id := range []string{"user2", "test-user-id"} {
+		mockUserRepo.On("GetByID", id).Return(&userModel.User{Base: model.Base{ID: id}, Username: id}, nil)
+	}
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/campaigns/filtered/leaderboard"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("?activity=walking")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dto.CampaignLeaderboardResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "all_time", response.Window)
+	assert.Equal(t, 2, response.Total)
+	if assert.Len(t, response.Leaderboard, 2) {
+		assert.Equal(t, "user2", response.Leaderboard[0].UserID)
+		assert.Equal(t, "Walking", response.Leaderboard[0].Activity)
+	}
+	if assert.NotNil(t, response.Me) {
+		assert.Equal(t, 2, response.Me.Rank)
+		assert.Equal(t, "test-user-id", response.Me.Username)
+	}
+
+	// Windowed boards need the run log kept by anti-cheat.
+	assert.Equal(t, http.StatusServiceUnavailable, get("?window=weekly").Code)
+	assert.Equal(t, http.StatusBadRequest, get("?window=yearly").Code)
+}
+
+func TestCampaignService_Leaderboard_WindowUsesRunLog(t *testing.T) {
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	mockRunRepo := new(campaignMocks.MockCampaignRunRepository)
+	service := campaign.NewCampaignService(mockCampaignRepo, new(campaignMocks.MockCampaignRunnerRepository),
+		new(campaignMocks.MockSponsorCampaignRepository), campaign.WithAntiCheat(mockRunRepo, nil))
+
+	mockCampaignRepo.On("GetBySlug", "windowed").Return(&campaignModel.Campaign{Base: model.Base{ID: "campaign123"}}, nil)
+	since := leaderboard.WindowDaily.Since(time.Now())
+	mockRunRepo.On("ListForLeaderboard", "campaign123", since, "run").Return([]*campaignModel.CampaignRun{
+		{OwnerID: "user1", Activity: "Run", Distance: 2, Duration: 12 * time.Minute},
+		{OwnerID: "user2", Activity: "Run", Distance: 5, Duration: 30 * time.Minute},
+		{OwnerID: "user1", Activity: "Run", Distance: 4, Duration: 24 * time.Minute},
+	}, nil)
+
+	standings, err := service.Leaderboard("windowed", leaderboard.Query{Window: leaderboard.WindowDaily, Activity: "Run"})
+	assert.NoError(t, err)
+	if assert.Len(t, standings.Entries, 2) {
+		assert.Equal(t, "user1", standings.Entries[0].UserID)
+		assert.Equal(t, 6.0, standings.Entries[0].Distance)
+		assert.Equal(t, 2, standings.Entries[0].Runs)
+	}
+	mockRunRepo.AssertExpectations(t)
+}
@@ -0,0 +1,463 @@
+package campaign_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	campaign "gopi.com/internal/app/campaign"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/data/campaign/repo"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+	"gopi.com/internal/domain/model"
+	campaignMocks "gopi.com/tests/mocks/campaign"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+	"gorm.io/gorm"
+)
+
+type inviteFixture struct {
+	service    *campaign.CampaignService
+	campaigns  campaignRepo.CampaignRepository
+	invites    campaignRepo.CampaignInviteRepository
+	db         *gorm.DB
+	private    *campaignModel.Campaign
+	signingKey string
+}
+
+func setupInviteService(t *testing.T) *inviteFixture {
+	db := testdb.Open(t, &gormmodel.Campaign{}, &gormmodel.CampaignRunner{}, &gormmodel.SponsorCampaign{},
+		&gormmodel.CampaignMember{}, &gormmodel.CampaignSponsor{}, &gormmodel.CampaignInvite{}, &gormmodel.CampaignJoinRequest{})
+
+	f := &inviteFixture{
+		campaigns:  repo.NewGormCampaignRepository(db),
+		invites:    repo.NewGormCampaignInviteRepository(db),
+		db:         db,
+		signingKey: "test-invite-key",
+	}
+	f.service = campaign.NewCampaignService(f.campaigns, repo.NewGormCampaignRunnerRepository(db), repo.NewGormSponsorCampaignRepository(db),
+		campaign.WithUnitOfWork(repo.NewGormUnitOfWork(db)),
+		campaign.WithInvites(f.invites, repo.NewGormCampaignJoinRequestRepository(db), f.signingKey, "https://example.com/campaigns/invite"))
+
+	f.private = &campaignModel.Campaign{
+		Base:       model.Base{ID: "private-campaign"},
+		Name:       "Secret Run",
+		OwnerID:    "owner",
+		Slug:       "secret-run",
+		Status:     campaignModel.CampaignStatusActive,
+		Visibility: campaignModel.CampaignVisibilityPrivate,
+	}
+	require.NoError(t, f.campaigns.Create(f.private))
+	return f
+}
+
+func TestCampaignVisibility(t *testing.T) {
+	private := &campaignModel.Campaign{OwnerID: "owner", Members: campaignModel.UsersByID("member"), Visibility: campaignModel.CampaignVisibilityPrivate}
+	assert.True(t, private.VisibleTo("owner"))
+	assert.True(t, private.VisibleTo("member"))
+	assert.False(t, private.VisibleTo("stranger"))
+	assert.False(t, private.VisibleTo(""))
+	assert.NoError(t, private.CanJoinWithoutInvite("owner"))
+	assert.ErrorIs(t, private.CanJoinWithoutInvite("stranger"), campaignModel.ErrInviteRequired)
+
+	legacy := &campaignModel.Campaign{OwnerID: "owner"}
+	assert.Equal(t, campaignModel.CampaignVisibilityPublic, legacy.EffectiveVisibility())
+	assert.True(t, legacy.VisibleTo(""))
+	assert.NoError(t, legacy.CanJoinWithoutInvite("stranger"))
+
+	unlisted := &campaignModel.Campaign{OwnerID: "owner", Visibility: campaignModel.CampaignVisibilityUnlisted}
+	assert.True(t, unlisted.VisibleTo(""))
+	assert.NoError(t, unlisted.CanJoinWithoutInvite("stranger"))
+}
+
+func TestCampaignService_InviteLinkJoinsPrivateCampaign(t *testing.T) {
+	f := setupInviteService(t)
+
+	assert.ErrorIs(t, f.service.JoinCampaign(f.private.ID, "u1"), campaignModel.ErrInviteRequired)
+
+	invite, err := f.service.CreateInvite(f.private.ID, "owner", 2, 0)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(campaign.DefaultInviteTTL), invite.ExpiresAt, time.Minute)
+
+	link := f.service.InviteLink(invite)
+	assert.True(t, strings.HasPrefix(link, "https://example.com/campaigns/invite?token="+invite.ID+"."))
+	token := f.service.InviteToken(invite)
+
+	preview, _, err := f.service.PreviewInvite(token)
+	require.NoError(t, err)
+	assert.Equal(t, f.private.ID, preview.ID)
+
+	joined, err := f.service.AcceptInvite(token, "u1", "u1@example.com")
+	require.NoError(t, err)
+	assert.True(t, joined.HasMember("u1"))
+
+	_, err = f.service.AcceptInvite(token, "u1", "u1@example.com")
+	assert.ErrorIs(t, err, campaignModel.ErrAlreadyMember)
+
+	_, err = f.service.AcceptInvite(token, "u2", "u2@example.com")
+	require.NoError(t, err)
+
+	_, err = f.service.AcceptInvite(token, "u3", "u3@example.com")
+	assert.ErrorIs(t, err, campaignModel.ErrInviteUsedUp)
+
+	stored, err := f.invites.GetByID(invite.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Uses)
+
+	isMember, err := f.service.IsMember(f.private.ID, "u3")
+	require.NoError(t, err)
+	assert.False(t, isMember)
+}
+
+func TestCampaignService_InviteTokenChecks(t *testing.T) {
+	f := setupInviteService(t)
+
+	invite, err := f.service.CreateInvite(f.private.ID, "owner", 0, time.Hour)
+	require.NoError(t, err)
+	token := f.service.InviteToken(invite)
+
+	tampered := token[:len(token)-1] + "0"
+	if tampered == token {
+		tampered = token[:len(token)-1] + "1"
+	}
+	for _, bad := range []string{"", "nope", invite.ID + ".1.abc", tampered} {
+		_, err = f.service.AcceptInvite(bad, "u1", "")
+		assert.ErrorIs(t, err, campaignModel.ErrInvalidInvite, bad)
+	}
+
+	// A token signed with another key is rejected.
+	other := campaign.NewCampaignService(f.campaigns, nil, nil,
+		campaign.WithInvites(f.invites, nil, "another-key", ""))
+	_, err = f.service.AcceptInvite(other.InviteToken(invite), "u1", "")
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidInvite)
+
+	// Expired invites are refused even though the signature is good.
+	expired := &campaignModel.CampaignInvite{
+		Base:       model.Base{ID: "expired-invite"},
+		CampaignID: f.private.ID,
+		CreatedBy:  "owner",
+		ExpiresAt:  time.Now().Add(-time.Hour).Truncate(time.Second),
+	}
+	require.NoError(t, f.invites.Create(expired))
+	_, err = f.service.AcceptInvite(f.service.InviteToken(expired), "u1", "")
+	assert.ErrorIs(t, err, campaignModel.ErrInviteExpired)
+
+	// Revoked invites stop working.
+	require.NoError(t, f.service.RevokeInvite(f.private.ID, invite.ID))
+	_, err = f.service.AcceptInvite(token, "u1", "")
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidInvite)
+	assert.ErrorIs(t, f.service.RevokeInvite("another-campaign", invite.ID), campaignModel.ErrInvalidInvite)
+}
+
+func TestCampaignService_InviteByEmail(t *testing.T) {
+	f := setupInviteService(t)
+
+	invites, err := f.service.InviteByEmail(f.private.ID, "owner", []string{"Ann@Example.com", " ann@example.com ", "bob@example.com", ""}, 0)
+	require.NoError(t, err)
+	require.Len(t, invites, 2)
+	assert.Equal(t, "ann@example.com", invites[0].Email)
+	assert.Equal(t, 1, invites[0].MaxUses)
+
+	token := f.service.InviteToken(invites[0])
+	_, err = f.service.AcceptInvite(token, "bob", "bob@example.com")
+	assert.ErrorIs(t, err, campaignModel.ErrInviteEmailMismatch)
+
+	_, err = f.service.AcceptInvite(token, "ann", "ANN@example.com")
+	require.NoError(t, err)
+
+	_, err = f.service.AcceptInvite(token, "ann2", "ann@example.com")
+	assert.ErrorIs(t, err, campaignModel.ErrInviteUsedUp)
+}
+
+func TestCampaignService_JoinRequests(t *testing.T) {
+	f := setupInviteService(t)
+
+	request, err := f.service.RequestToJoin(f.private.ID, "u1", "  let me in ")
+	require.NoError(t, err)
+	assert.Equal(t, campaignModel.JoinRequestPending, request.Status)
+	assert.Equal(t, "let me in", request.Message)
+
+	_, err = f.service.RequestToJoin(f.private.ID, "u1", "")
+	assert.ErrorIs(t, err, campaignModel.ErrJoinRequestPending)
+
+	approved, err := f.service.ApproveJoinRequest(f.private.ID, request.ID, "owner")
+	require.NoError(t, err)
+	assert.Equal(t, campaignModel.JoinRequestApproved, approved.Status)
+	assert.Equal(t, "owner", approved.DecidedBy)
+	require.NotNil(t, approved.DecidedAt)
+
+	isMember, err := f.service.IsMember(f.private.ID, "u1")
+	require.NoError(t, err)
+	assert.True(t, isMember)
+
+	_, err = f.service.RejectJoinRequest(f.private.ID, request.ID, "owner")
+	assert.ErrorIs(t, err, campaignModel.ErrJoinRequestDecided)
+	_, err = f.service.RequestToJoin(f.private.ID, "u1", "")
+	assert.ErrorIs(t, err, campaignModel.ErrAlreadyMember)
+
+	other, err := f.service.RequestToJoin(f.private.ID, "u2", "")
+	require.NoError(t, err)
+	_, err = f.service.ApproveJoinRequest("another-campaign", other.ID, "owner")
+	assert.ErrorIs(t, err, campaignModel.ErrJoinRequestNotFound)
+	rejected, err := f.service.RejectJoinRequest(f.private.ID, other.ID, "owner")
+	require.NoError(t, err)
+	assert.Equal(t, campaignModel.JoinRequestRejected, rejected.Status)
+
+	pending, err := f.service.ListJoinRequests(f.private.ID, campaignModel.JoinRequestPending)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	all, err := f.service.ListJoinRequests(f.private.ID, "")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	public := &campaignModel.Campaign{Base: model.Base{ID: "public-campaign"}, Name: "Open", OwnerID: "owner", Slug: "open",
+		Status: campaignModel.CampaignStatusActive}
+	require.NoError(t, f.campaigns.Create(public))
+	_, err = f.service.RequestToJoin(public.ID, "u3", "")
+	assert.ErrorIs(t, err, campaignModel.ErrJoinRequestNotNeeded)
+}
+
+func TestCampaignService_ListCampaignsHidesPrivate(t *testing.T) {
+	f := setupInviteService(t)
+
+	for _, c := range []*campaignModel.Campaign{
+		{Base: model.Base{ID: "public"}, Name: "Public", OwnerID: "owner", Slug: "public", Visibility: campaignModel.CampaignVisibilityPublic},
+		{Base: model.Base{ID: "unlisted"}, Name: "Unlisted", OwnerID: "owner", Slug: "unlisted", Visibility: campaignModel.CampaignVisibilityUnlisted},
+		{Base: model.Base{ID: "legacy"}, Name: "Legacy", OwnerID: "owner", Slug: "legacy"},
+	} {
+		require.NoError(t, f.campaigns.Create(c))
+	}
+	require.NoError(t, f.db.Model(&gormmodel.Campaign{}).Where("id = ?", "legacy").Update("visibility", "").Error)
+	require.NoError(t, f.campaigns.AddMember(f.private.ID, "member"))
+
+	ids := func(viewerID string) []string {
+		campaigns, err := f.service.ListCampaigns(viewerID, 10, 0)
+		require.NoError(t, err)
+		var result []string
+		for _, c := range campaigns {
+			result = append(result, c.ID)
+		}
+		return result
+	}
+
+	assert.ElementsMatch(t, []string{"public", "legacy"}, ids(""))
+	assert.ElementsMatch(t, []string{"public", "legacy"}, ids("stranger"))
+	assert.ElementsMatch(t, []string{"public", "legacy", "private-campaign"}, ids("member"))
+	assert.ElementsMatch(t, []string{"public", "unlisted", "legacy", "private-campaign"}, ids("owner"))
+
+	all, err := f.service.ListAllCampaigns(10, 0)
+	require.NoError(t, err)
+	assert.Len(t, all, 4)
+}
+
+func TestCampaignService_InvitesNotEnabled(t *testing.T) {
+	service := campaign.NewCampaignService(new(campaignMocks.MockCampaignRepository),
+		new(campaignMocks.MockCampaignRunnerRepository), new(campaignMocks.MockSponsorCampaignRepository))
+
+	_, err := service.CreateInvite("c", "u", 0, 0)
+	assert.True(t, errors.Is(err, campaignModel.ErrInvitesNotEnabled))
+	_, err = service.RequestToJoin("c", "u", "")
+	assert.True(t, errors.Is(err, campaignModel.ErrInvitesNotEnabled))
+}
+
+func setupCampaignInviteHandlerTest(t *testing.T, userID string) (*gin.Engine, *campaignMocks.MockCampaignRepository, *campaignMocks.MockCampaignInviteRepository, *campaignMocks.MockCampaignJoinRequestRepository, *userMocks.MockUserRepository) {
+	gin.SetMode(gin.TestMode)
+
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	mockInviteRepo := new(campaignMocks.MockCampaignInviteRepository)
+	mockRequestRepo := new(campaignMocks.MockCampaignJoinRequestRepository)
+	mockUserRepo := new(userMocks.MockUserRepository)
+
+	campaignService := campaign.NewCampaignService(mockCampaignRepo, new(campaignMocks.MockCampaignRunnerRepository),
+		new(campaignMocks.MockSponsorCampaignRepository),
+		campaign.WithInvites(mockInviteRepo, mockRequestRepo, "test-invite-key", "https://example.com/campaigns/invite"))
+	userService := user.NewUserService(mockUserRepo, nil)
+	campaignHandler := handler.NewCampaignHandler(campaignService, userService)
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	protected := router.Group("/campaigns")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Next()
+	})
+	protected.POST("/invites/:token/accept", campaignHandler.AcceptCampaignInvite)
+	protected.POST("/:slug/invites", campaignHandler.CreateCampaignInvite)
+	protected.POST("/:slug/join_requests", campaignHandler.RequestToJoinCampaign)
+	protected.GET("/:slug/join_requests", campaignHandler.ListCampaignJoinRequests)
+
+	return router, mockCampaignRepo, mockInviteRepo, mockRequestRepo, mockUserRepo
+}
+
+func TestCampaignHandler_CreateCampaignInvite(t *testing.T) {
+	private := &campaignModel.Campaign{Base: model.Base{ID: "c1"}, Slug: "secret", OwnerID: "owner",
+		Status: campaignModel.CampaignStatusActive, Visibility: campaignModel.CampaignVisibilityPrivate}
+
+	tests := []struct {
+		name           string
+		userID         string
+		body           string
+		setupMocks     func(*campaignMocks.MockCampaignRepository, *campaignMocks.MockCampaignInviteRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "owner creates invite",
+			userID: "owner",
+			body:   `{"max_uses":5,"expires_in_hours":24}`,
+			setupMocks: func(cr *campaignMocks.MockCampaignRepository, ir *campaignMocks.MockCampaignInviteRepository) {
+				cr.On("GetBySlug", "secret").Return(private, nil)
+				cr.On("GetByID", "c1").Return(private, nil)
+				ir.On("Create", mock.MatchedBy(func(i *campaignModel.CampaignInvite) bool {
+					return i.CampaignID == "c1" && i.MaxUses == 5 && i.CreatedBy == "owner" &&
+						i.ExpiresAt.After(time.Now().Add(23*time.Hour)) && i.ExpiresAt.Before(time.Now().Add(25*time.Hour))
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "owner creates invite without a body",
+			userID: "owner",
+			setupMocks: func(cr *campaignMocks.MockCampaignRepository, ir *campaignMocks.MockCampaignInviteRepository) {
+				cr.On("GetBySlug", "secret").Return(private, nil)
+				cr.On("GetByID", "c1").Return(private, nil)
+				ir.On("Create", mock.MatchedBy(func(i *campaignModel.CampaignInvite) bool {
+					return i.MaxUses == 0
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "not the owner",
+			userID: "stranger",
+			body:   `{}`,
+			setupMocks: func(cr *campaignMocks.MockCampaignRepository, ir *campaignMocks.MockCampaignInviteRepository) {
+				cr.On("GetBySlug", "secret").Return(private, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "negative usage cap",
+			userID: "owner",
+			body:   `{"max_uses":-1}`,
+			setupMocks: func(cr *campaignMocks.MockCampaignRepository, ir *campaignMocks.MockCampaignInviteRepository) {
+				cr.On("GetBySlug", "secret").Return(private, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockCampaignRepo, mockInviteRepo, _, _ := setupCampaignInviteHandlerTest(t, tt.userID)
+			tt.setupMocks(mockCampaignRepo, mockInviteRepo)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/campaigns/secret/invites", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var response dto.InviteResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "c1", response.CampaignID)
+				assert.NotEmpty(t, response.Token)
+				assert.Equal(t, "https://example.com/campaigns/invite?token="+response.Token, response.Link)
+			}
+			mockInviteRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCampaignHandler_AcceptCampaignInvite_BadToken(t *testing.T) {
+	router, _, _, _, _ := setupCampaignInviteHandlerTest(t, "u1")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/campaigns/invites/not-a-token/accept", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, campaignModel.ErrInvalidInvite.Error(), response.Message)
+}
+
+func TestCampaignHandler_RequestToJoinCampaign(t *testing.T) {
+	private := &campaignModel.Campaign{Base: model.Base{ID: "c1"}, Slug: "secret", OwnerID: "owner",
+		Status: campaignModel.CampaignStatusActive, Visibility: campaignModel.CampaignVisibilityPrivate,
+		Members: campaignModel.UsersByID("member")}
+
+	tests := []struct {
+		name           string
+		userID         string
+		setupMocks     func(*campaignMocks.MockCampaignJoinRequestRepository, *userMocks.MockUserRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "stranger asks to join",
+			userID: "u1",
+			setupMocks: func(rr *campaignMocks.MockCampaignJoinRequestRepository, ur *userMocks.MockUserRepository) {
+				rr.On("GetPending", "c1", "u1").Return(nil, nil)
+				rr.On("Create", mock.MatchedBy(func(r *campaignModel.CampaignJoinRequest) bool {
+					return r.UserID == "u1" && r.Message == "hello" && r.Status == campaignModel.JoinRequestPending
+				})).Return(nil)
+				ur.On("GetByID", "u1").Return(nil, errors.New("not found"))
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "already pending",
+			userID: "u1",
+			setupMocks: func(rr *campaignMocks.MockCampaignJoinRequestRepository, ur *userMocks.MockUserRepository) {
+				rr.On("GetPending", "c1", "u1").Return(&campaignModel.CampaignJoinRequest{Status: campaignModel.JoinRequestPending}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "already a member",
+			userID:         "member",
+			setupMocks:     func(rr *campaignMocks.MockCampaignJoinRequestRepository, ur *userMocks.MockUserRepository) {},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockCampaignRepo, _, mockRequestRepo, mockUserRepo := setupCampaignInviteHandlerTest(t, tt.userID)
+			mockCampaignRepo.On("GetBySlug", "secret").Return(private, nil)
+			mockCampaignRepo.On("GetByID", "c1").Return(private, nil)
+			tt.setupMocks(mockRequestRepo, mockUserRepo)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/campaigns/secret/join_requests", bytes.NewBufferString(`{"message":"hello"}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockRequestRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCampaignHandler_ListCampaignJoinRequests_BadStatus(t *testing.T) {
+	router, mockCampaignRepo, _, _, _ := setupCampaignInviteHandlerTest(t, "owner")
+	mockCampaignRepo.On("GetBySlug", "secret").Return(&campaignModel.Campaign{Base: model.Base{ID: "c1"}, Slug: "secret", OwnerID: "owner"}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/campaigns/secret/join_requests?status=maybe", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
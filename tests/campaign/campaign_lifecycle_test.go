@@ -0,0 +1,293 @@
+package campaign_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	campaign "gopi.com/internal/app/campaign"
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/data/campaign/repo"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	campaignMocks "gopi.com/tests/mocks/campaign"
+)
+
+func TestParseCampaignTime(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantNil bool
+		wantErr bool
+	}{
+		{input: "", wantNil: true},
+		{input: "2024-01-31", want: "2024-01-31T00:00:00Z"},
+		{input: "2024-01-31 18:30", want: "2024-01-31T18:30:00Z"},
+		{input: "2024-01-31T18:30:00+01:00", want: "2024-01-31T17:30:00Z"},
+		{input: "31/01/2024", want: "2024-01-31T00:00:00Z"},
+		{input: "Jan 31, 2024", want: "2024-01-31T00:00:00Z"},
+		{input: "2 weeks", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := campaignModel.ParseCampaignTime(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, campaignModel.ErrInvalidCampaignSchedule)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.want, got.Format(time.RFC3339))
+		})
+	}
+}
+
+func TestCampaign_StatusAt(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		campaign campaignModel.Campaign
+		want     campaignModel.CampaignStatus
+	}{
+		{"legacy without window", campaignModel.Campaign{}, campaignModel.CampaignStatusActive},
+		{"scheduled before start", campaignModel.Campaign{Status: campaignModel.CampaignStatusScheduled, StartsAt: &future}, campaignModel.CampaignStatusScheduled},
+		{"scheduled after start", campaignModel.Campaign{Status: campaignModel.CampaignStatusScheduled, StartsAt: &past}, campaignModel.CampaignStatusActive},
+		{"active after end", campaignModel.Campaign{Status: campaignModel.CampaignStatusActive, EndsAt: &past}, campaignModel.CampaignStatusCompleted},
+		{"draft is sticky", campaignModel.Campaign{Status: campaignModel.CampaignStatusDraft, StartsAt: &past}, campaignModel.CampaignStatusDraft},
+		{"cancelled is sticky", campaignModel.Campaign{Status: campaignModel.CampaignStatusCancelled, EndsAt: &future}, campaignModel.CampaignStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.campaign.StatusAt(now))
+		})
+	}
+}
+
+func TestCampaignStatus_CanTransitionTo(t *testing.T) {
+	assert.True(t, campaignModel.CampaignStatusDraft.CanTransitionTo(campaignModel.CampaignStatusScheduled))
+	assert.True(t, campaignModel.CampaignStatusScheduled.CanTransitionTo(campaignModel.CampaignStatusActive))
+	assert.True(t, campaignModel.CampaignStatusActive.CanTransitionTo(campaignModel.CampaignStatusCompleted))
+	assert.True(t, campaignModel.CampaignStatusActive.CanTransitionTo(campaignModel.CampaignStatusCancelled))
+	assert.False(t, campaignModel.CampaignStatusCompleted.CanTransitionTo(campaignModel.CampaignStatusActive))
+	assert.False(t, campaignModel.CampaignStatusCancelled.CanTransitionTo(campaignModel.CampaignStatusActive))
+	assert.False(t, campaignModel.CampaignStatusScheduled.CanTransitionTo(campaignModel.CampaignStatusCompleted))
+}
+
+func TestCampaignService_CreateCampaign_Schedule(t *testing.T) {
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	service := campaign.NewCampaignService(mockCampaignRepo, new(campaignMocks.MockCampaignRunnerRepository), new(campaignMocks.MockSponsorCampaignRepository))
+
+	start := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	end := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+	mockCampaignRepo.On("Create", mock.MatchedBy(func(c *campaignModel.Campaign) bool {
+		return c.Status == campaignModel.CampaignStatusScheduled && c.StartsAt != nil && c.EndsAt != nil
+	})).Return(nil)
+
+	result, err := service.CreateCampaign("owner123", "owner", "Future Walk", "", "", "", "",
+		campaignModel.CampaignModeFree, campaignModel.ActivityWalking, 0, 0, 10, start, end, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, campaignModel.CampaignStatusScheduled, result.Status)
+
+	_, err = service.CreateCampaign("owner123", "owner", "Backwards", "", "", "", "",
+		campaignModel.CampaignModeFree, campaignModel.ActivityWalking, 0, 0, 10, end, start, nil, "")
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidCampaignSchedule)
+
+	mockCampaignRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestCampaignService_RecordActivity_RejectsInactiveCampaign(t *testing.T) {
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	mockRunnerRepo := new(campaignMocks.MockCampaignRunnerRepository)
+	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, new(campaignMocks.MockSponsorCampaignRepository))
+
+	future := time.Now().Add(time.Hour)
+	mockCampaignRepo.On("GetByID", "campaign123").Return(&campaignModel.Campaign{
+		Base:     model.Base{ID: "campaign123"},
+		Status:   campaignModel.CampaignStatusScheduled,
+		StartsAt: &future,
+	}, nil)
+
+	err := service.RecordActivity("campaign123", "user123", 5, 30*time.Minute, "Walking")
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidCampaignState)
+	mockRunnerRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCampaignService_FinishActivity_RejectsCompletedCampaign(t *testing.T) {
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	mockRunnerRepo := new(campaignMocks.MockCampaignRunnerRepository)
+	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, new(campaignMocks.MockSponsorCampaignRepository))
+
+	mockRunnerRepo.On("GetByID", "runner123").Return(&campaignModel.CampaignRunner{
+		Base:       model.Base{ID: "runner123"},
+		CampaignID: "campaign123",
+	}, nil)
+	mockCampaignRepo.On("GetByID", "campaign123").Return(&campaignModel.Campaign{
+		Base:   model.Base{ID: "campaign123"},
+		Status: campaignModel.CampaignStatusCompleted,
+	}, nil)
+
+	err := service.FinishActivity("runner123", 5, 30*time.Minute, 0)
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidCampaignState)
+	mockRunnerRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestCampaignService_ParticipateCampaign_RejectsCancelledCampaign(t *testing.T) {
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	mockRunnerRepo := new(campaignMocks.MockCampaignRunnerRepository)
+	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, new(campaignMocks.MockSponsorCampaignRepository))
+
+	mockCampaignRepo.On("GetBySlug", "test-campaign").Return(&campaignModel.Campaign{
+		Base:   model.Base{ID: "campaign123"},
+		Status: campaignModel.CampaignStatusCancelled,
+	}, nil)
+
+	_, err := service.ParticipateCampaign("test-campaign", "user123", "Walking")
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidCampaignState)
+	mockCampaignRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+}
+
+func TestCampaignService_PublishAndCancel(t *testing.T) {
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	service := campaign.NewCampaignService(mockCampaignRepo, new(campaignMocks.MockCampaignRunnerRepository), new(campaignMocks.MockSponsorCampaignRepository))
+
+	future := time.Now().Add(time.Hour)
+	mockCampaignRepo.On("GetByID", "draft1").Return(&campaignModel.Campaign{
+		Base:     model.Base{ID: "draft1"},
+		Status:   campaignModel.CampaignStatusDraft,
+		StartsAt: &future,
+	}, nil)
+	mockCampaignRepo.On("UpdateStatus", "draft1", campaignModel.CampaignStatusDraft, campaignModel.CampaignStatusScheduled).Return(true, nil)
+
+	published, err := service.PublishCampaign("draft1")
+	require.NoError(t, err)
+	assert.Equal(t, campaignModel.CampaignStatusScheduled, published.Status)
+
+	mockCampaignRepo.On("GetByID", "done1").Return(&campaignModel.Campaign{
+		Base:   model.Base{ID: "done1"},
+		Status: campaignModel.CampaignStatusCompleted,
+	}, nil)
+
+	_, err = service.CancelCampaign("done1")
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidCampaignState)
+
+	_, err = service.PublishCampaign("done1")
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidCampaignState)
+}
+
+func TestCampaignService_AdvanceLifecycle(t *testing.T) {
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	service := campaign.NewCampaignService(mockCampaignRepo, new(campaignMocks.MockCampaignRunnerRepository), new(campaignMocks.MockSponsorCampaignRepository))
+
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	longAgo := now.Add(-time.Hour)
+	due := []*campaignModel.Campaign{
+		{Base: model.Base{ID: "starting"}, Status: campaignModel.CampaignStatusScheduled, StartsAt: &past},
+		{Base: model.Base{ID: "ending"}, Status: campaignModel.CampaignStatusActive, EndsAt: &past},
+		{Base: model.Base{ID: "legacy"}, EndsAt: &past},
+		{Base: model.Base{ID: "raced"}, Status: campaignModel.CampaignStatusActive, EndsAt: &past},
+		{Base: model.Base{ID: "lapsed"}, Status: campaignModel.CampaignStatusScheduled, StartsAt: &longAgo, EndsAt: &past},
+	}
+	mockCampaignRepo.On("ListDueForTransition", now).Return(due, nil)
+	mockCampaignRepo.On("UpdateStatus", "starting", campaignModel.CampaignStatusScheduled, campaignModel.CampaignStatusActive).Return(true, nil)
+	mockCampaignRepo.On("UpdateStatus", "ending", campaignModel.CampaignStatusActive, campaignModel.CampaignStatusCompleted).Return(true, nil)
+	mockCampaignRepo.On("UpdateStatus", "legacy", campaignModel.CampaignStatus(""), campaignModel.CampaignStatusCompleted).Return(true, nil)
+	mockCampaignRepo.On("UpdateStatus", "raced", campaignModel.CampaignStatusActive, campaignModel.CampaignStatusCompleted).Return(false, nil)
+	// A campaign whose whole window passed between ticks starts and then completes.
+	mockCampaignRepo.On("UpdateStatus", "lapsed", campaignModel.CampaignStatusScheduled, campaignModel.CampaignStatusActive).Return(true, nil)
+	mockCampaignRepo.On("UpdateStatus", "lapsed", campaignModel.CampaignStatusActive, campaignModel.CampaignStatusCompleted).Return(true, nil)
+
+	changed, err := service.AdvanceLifecycle(now)
+	require.NoError(t, err)
+	assert.Equal(t, 4, changed)
+	assert.Equal(t, campaignModel.CampaignStatusCompleted, due[4].Status)
+	mockCampaignRepo.AssertExpectations(t)
+}
+
+func TestGormCampaignRepository_Lifecycle(t *testing.T) {
+	db := setupTestDB(t)
+	campaignRepo := repo.NewGormCampaignRepository(db)
+
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	seed := []*campaignModel.Campaign{
+		{Base: model.Base{ID: "c-start"}, Name: "start", OwnerID: "o", Slug: "c-start", Status: campaignModel.CampaignStatusScheduled, StartsAt: &past},
+		{Base: model.Base{ID: "c-wait"}, Name: "wait", OwnerID: "o", Slug: "c-wait", Status: campaignModel.CampaignStatusScheduled, StartsAt: &future},
+		{Base: model.Base{ID: "c-end"}, Name: "end", OwnerID: "o", Slug: "c-end", Status: campaignModel.CampaignStatusActive, EndsAt: &past},
+		{Base: model.Base{ID: "c-open"}, Name: "open", OwnerID: "o", Slug: "c-open", Status: campaignModel.CampaignStatusActive},
+		{Base: model.Base{ID: "c-draft"}, Name: "draft", OwnerID: "o", Slug: "c-draft", Status: campaignModel.CampaignStatusDraft, EndsAt: &past},
+	}
+	for _, c := range seed {
+		require.NoError(t, campaignRepo.Create(c))
+	}
+
+	due, err := campaignRepo.ListDueForTransition(now)
+	require.NoError(t, err)
+	var ids []string
+	for _, c := range due {
+		ids = append(ids, c.ID)
+	}
+	assert.ElementsMatch(t, []string{"c-start", "c-end"}, ids)
+
+	ok, err := campaignRepo.UpdateStatus("c-start", campaignModel.CampaignStatusScheduled, campaignModel.CampaignStatusActive)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Second writer loses because the row is no longer scheduled
+	ok, err = campaignRepo.UpdateStatus("c-start", campaignModel.CampaignStatusScheduled, campaignModel.CampaignStatusCancelled)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	got, err := campaignRepo.GetByID("c-start")
+	require.NoError(t, err)
+	assert.Equal(t, campaignModel.CampaignStatusActive, got.Status)
+}
+
+func TestMigrateCampaignLifecycle(t *testing.T) {
+	db := setupTestDB(t)
+
+	rows := []gormmodel.Campaign{
+		{ID: "legacy-past", Name: "a", OwnerID: "o", Slug: "a", StartDuration: "2024-01-01", EndDuration: "2024-01-31"},
+		{ID: "legacy-future", Name: "b", OwnerID: "o", Slug: "b", StartDuration: time.Now().Add(48 * time.Hour).Format("2006-01-02")},
+		{ID: "legacy-garbage", Name: "c", OwnerID: "o", Slug: "c", StartDuration: "next week"},
+		{ID: "already", Name: "d", OwnerID: "o", Slug: "d", Status: string(campaignModel.CampaignStatusCancelled), StartDuration: "2024-01-01"},
+	}
+	require.NoError(t, db.Create(&rows).Error)
+
+	require.NoError(t, gormmodel.MigrateCampaignLifecycle(db))
+	// Running again is a no-op
+	require.NoError(t, gormmodel.MigrateCampaignLifecycle(db))
+
+	load := func(id string) gormmodel.Campaign {
+		var c gormmodel.Campaign
+		require.NoError(t, db.First(&c, "id = ?", id).Error)
+		return c
+	}
+
+	past := load("legacy-past")
+	assert.Equal(t, string(campaignModel.CampaignStatusCompleted), past.Status)
+	require.NotNil(t, past.StartsAt)
+	require.NotNil(t, past.EndsAt)
+	assert.Equal(t, "2024-01-31", past.EndsAt.UTC().Format("2006-01-02"))
+
+	assert.Equal(t, string(campaignModel.CampaignStatusScheduled), load("legacy-future").Status)
+
+	garbage := load("legacy-garbage")
+	assert.Equal(t, string(campaignModel.CampaignStatusActive), garbage.Status)
+	assert.Nil(t, garbage.StartsAt)
+
+	already := load("already")
+	assert.Equal(t, string(campaignModel.CampaignStatusCancelled), already.Status)
+	assert.Nil(t, already.StartsAt)
+}
@@ -0,0 +1,280 @@
+package campaign_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	campaign "gopi.com/internal/app/campaign"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/data/campaign/repo"
+	notificationGorm "gopi.com/internal/data/notification/model/gorm"
+	notificationDataRepo "gopi.com/internal/data/notification/repo"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	notificationRepo "gopi.com/internal/domain/notification/repo"
+	userModel "gopi.com/internal/domain/user/model"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+	"gorm.io/gorm"
+)
+
+func TestCampaignMilestone_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		milestone campaignModel.CampaignMilestone
+		valid     bool
+	}{
+		{"percent", campaignModel.CampaignMilestone{Metric: campaignModel.MilestoneMetricDistance, Percent: 25}, true},
+		{"value", campaignModel.CampaignMilestone{Metric: campaignModel.MilestoneMetricMoney, Value: 500}, true},
+		{"unknown metric", campaignModel.CampaignMilestone{Metric: "steps", Percent: 25}, false},
+		{"neither", campaignModel.CampaignMilestone{Metric: campaignModel.MilestoneMetricDistance}, false},
+		{"both", campaignModel.CampaignMilestone{Metric: campaignModel.MilestoneMetricDistance, Percent: 50, Value: 10}, false},
+		{"over 100 percent", campaignModel.CampaignMilestone{Metric: campaignModel.MilestoneMetricDistance, Percent: 150}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.milestone.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, campaignModel.ErrInvalidMilestone)
+			}
+		})
+	}
+}
+
+func TestCampaignMilestone_Threshold(t *testing.T) {
+	c := &campaignModel.Campaign{DistanceToCover: 40, TargetAmount: 1000, DistanceCovered: 20, MoneyRaised: 100}
+
+	half := &campaignModel.CampaignMilestone{Metric: campaignModel.MilestoneMetricDistance, Percent: 50}
+	assert.Equal(t, 20.0, half.Threshold(c))
+	assert.True(t, half.ReachedBy(c))
+	assert.Equal(t, "50% of the distance goal", half.Label())
+
+	money := &campaignModel.CampaignMilestone{Metric: campaignModel.MilestoneMetricMoney, Value: 250}
+	assert.Equal(t, 250.0, money.Threshold(c))
+	assert.False(t, money.ReachedBy(c))
+	assert.Equal(t, "250.00 raised", money.Label())
+
+	km := &campaignModel.CampaignMilestone{Metric: campaignModel.MilestoneMetricDistance, Value: 12.5}
+	assert.Equal(t, "12.5 km covered", km.Label())
+
+	// Percentage milestones never fire for a campaign without that kind of target.
+	noTarget := &campaignModel.Campaign{DistanceCovered: 1000}
+	assert.False(t, half.ReachedBy(noTarget))
+}
+
+type milestoneFixture struct {
+	service       *campaign.CampaignService
+	campaign      *campaignModel.Campaign
+	notifications notificationRepo.NotificationRepository
+	emails        *recordingEmailService
+	db            *gorm.DB
+}
+
+// setupMilestoneService creates an active 100 km campaign with two members and a sponsor.
+func setupMilestoneService(t *testing.T) *milestoneFixture {
+	db := testdb.Open(t, &gormmodel.Campaign{}, &gormmodel.CampaignRunner{}, &gormmodel.SponsorCampaign{},
+		&gormmodel.CampaignMember{}, &gormmodel.CampaignSponsor{}, &gormmodel.CampaignMilestone{}, &notificationGorm.Notification{})
+
+	users := new(userMocks.MockUserRepository)
+	for _, id := range []string{"owner", "member-1", "member-2", "sponsor"} {
+		users.On("GetByID", id).Return(&userModel.User{Base: model.Base{ID: id}, Username: id, Email: id + "@example.com"}, nil).Maybe()
+	}
+
+	f := &milestoneFixture{
+		notifications: notificationDataRepo.NewGormNotificationRepository(db),
+		emails:        &recordingEmailService{},
+		db:            db,
+	}
+	campaigns := repo.NewGormCampaignRepository(db)
+	sponsors := repo.NewGormSponsorCampaignRepository(db)
+	f.service = campaign.NewCampaignService(campaigns, repo.NewGormCampaignRunnerRepository(db), sponsors,
+		campaign.WithUnitOfWork(repo.NewGormUnitOfWork(db)),
+		campaign.WithMilestones(repo.NewGormCampaignMilestoneRepository(db), f.notifications, users, f.emails))
+
+	f.campaign = &campaignModel.Campaign{
+		Base:            model.Base{ID: "milestone-campaign"},
+		Name:            "Coast Run",
+		OwnerID:         "owner",
+		Slug:            "coast-run",
+		Status:          campaignModel.CampaignStatusActive,
+		DistanceToCover: 100,
+		TargetAmount:    1000,
+	}
+	require.NoError(t, campaigns.Create(f.campaign))
+	require.NoError(t, campaigns.AddMember(f.campaign.ID, "member-1"))
+	require.NoError(t, campaigns.AddMember(f.campaign.ID, "member-2"))
+	require.NoError(t, sponsors.Create(&campaignModel.SponsorCampaign{Base: model.Base{ID: "pledge"}, CampaignID: f.campaign.ID,
+		Distance: 10, AmountPerKm: 1, Sponsors: campaignModel.UsersByID("sponsor")}))
+	return f
+}
+
+func (f *milestoneFixture) unread(t *testing.T, userID string) int64 {
+	count, err := f.notifications.CountUnread(userID)
+	require.NoError(t, err)
+	return count
+}
+
+func TestSetMilestones(t *testing.T) {
+	f := setupMilestoneService(t)
+	require.NoError(t, f.service.RecordActivity(f.campaign.ID, "member-1", 30, 0, "Running"))
+
+	saved, err := f.service.SetMilestones(f.campaign.ID, []*campaignModel.CampaignMilestone{
+		{Metric: campaignModel.MilestoneMetricDistance, Percent: 50},
+		{Metric: campaignModel.MilestoneMetricDistance, Percent: 25},
+		{Metric: campaignModel.MilestoneMetricDistance, Percent: 25},
+		{Metric: campaignModel.MilestoneMetricMoney, Value: 500},
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 3, "duplicates are dropped")
+	assert.Equal(t, campaignModel.MilestoneMetricDistance, saved[0].Metric)
+	assert.Equal(t, 25.0, saved[0].Percent)
+	assert.NotNil(t, saved[0].ReachedAt, "a milestone already passed is recorded as reached")
+	assert.Nil(t, saved[1].ReachedAt)
+	assert.Equal(t, campaignModel.MilestoneMetricMoney, saved[2].Metric)
+	assert.Zero(t, f.unread(t, "member-1"), "passing a milestone before it existed notifies nobody")
+
+	// Replacing keeps reached milestones and swaps the pending ones.
+	saved, err = f.service.SetMilestones(f.campaign.ID, []*campaignModel.CampaignMilestone{
+		{Metric: campaignModel.MilestoneMetricDistance, Value: 75},
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Equal(t, 25.0, saved[0].Percent)
+	assert.Equal(t, 75.0, saved[1].Value)
+
+	_, err = f.service.SetMilestones(f.campaign.ID, []*campaignModel.CampaignMilestone{{Metric: "steps", Value: 1}})
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidMilestone)
+
+	service := campaign.NewCampaignService(nil, nil, nil)
+	_, err = service.SetMilestones(f.campaign.ID, nil)
+	assert.ErrorIs(t, err, campaignModel.ErrMilestonesNotEnabled)
+}
+
+func TestMilestones_NotifyOnce(t *testing.T) {
+	f := setupMilestoneService(t)
+	_, err := f.service.SetMilestones(f.campaign.ID, []*campaignModel.CampaignMilestone{
+		{Metric: campaignModel.MilestoneMetricDistance, Percent: 25},
+		{Metric: campaignModel.MilestoneMetricDistance, Percent: 50},
+		{Metric: campaignModel.MilestoneMetricMoney, Value: 10},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, f.service.RecordActivity(f.campaign.ID, "member-1", 20, 0, "Running"))
+	assert.Empty(t, f.emails.sent)
+
+	require.NoError(t, f.service.RecordActivity(f.campaign.ID, "member-2", 10, 0, "Running"))
+	require.Len(t, f.emails.sent, 1)
+	assert.Equal(t, "Coast Run reached 25% of the distance goal", f.emails.sent[0].Subject)
+	assert.ElementsMatch(t, []string{"owner@example.com", "member-1@example.com", "member-2@example.com", "sponsor@example.com"},
+		f.emails.sent[0].To)
+	for _, userID := range []string{"owner", "member-1", "member-2", "sponsor"} {
+		assert.Equal(t, int64(1), f.unread(t, userID), userID)
+	}
+
+	notifications, err := f.notifications.ListByUser("member-1", false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "Coast Run reached 25% of the distance goal", notifications[0].Title)
+	assert.Equal(t, "/campaigns/coast-run", notifications[0].Link)
+
+	// More progress below the next milestone sends nothing new.
+	require.NoError(t, f.service.RecordActivity(f.campaign.ID, "member-1", 5, 0, "Running"))
+	assert.Len(t, f.emails.sent, 1)
+
+	// Sponsorships move money milestones.
+	require.NoError(t, f.service.SponsorCampaign(f.campaign.ID, campaignModel.UsersByID("sponsor"), 10, 2))
+	require.Len(t, f.emails.sent, 2)
+	assert.Equal(t, "Coast Run reached 10.00 raised", f.emails.sent[1].Subject)
+	assert.Equal(t, int64(2), f.unread(t, "owner"))
+}
+
+func TestMilestones_ConcurrentActivities(t *testing.T) {
+	f := setupMilestoneService(t)
+	_, err := f.service.SetMilestones(f.campaign.ID, []*campaignModel.CampaignMilestone{
+		{Metric: campaignModel.MilestoneMetricDistance, Percent: 25},
+		{Metric: campaignModel.MilestoneMetricDistance, Percent: 50},
+		{Metric: campaignModel.MilestoneMetricDistance, Percent: 100},
+	})
+	require.NoError(t, err)
+
+	// Twenty 5 km runs land together and take the campaign to exactly 100 km.
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- f.service.RecordActivity(f.campaign.ID, "member-1", 5, 0, "Running")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, f.emails.sent, 3, "each milestone is emailed once")
+	for _, userID := range []string{"owner", "member-1", "member-2", "sponsor"} {
+		assert.Equal(t, int64(3), f.unread(t, userID), userID)
+	}
+	milestones, err := f.service.ListMilestones(f.campaign)
+	require.NoError(t, err)
+	for _, milestone := range milestones {
+		assert.NotNil(t, milestone.ReachedAt, milestone.Label())
+	}
+}
+
+func TestCampaignHandler_Milestones(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := setupMilestoneService(t)
+	require.NoError(t, f.service.RecordActivity(f.campaign.ID, "member-1", 40, 0, "Running"))
+	campaignHandler := handler.NewCampaignHandler(f.service, user.NewUserService(new(userMocks.MockUserRepository), nil))
+
+	serve := func(userID, method, body string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+			c.Next()
+		})
+		router.GET("/campaigns/:slug/milestones", campaignHandler.GetCampaignMilestones)
+		router.PUT("/campaigns/:slug/milestones", campaignHandler.SetCampaignMilestones)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/campaigns/coast-run/milestones", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	body := `{"milestones":[{"metric":"distance","percent":25},{"metric":"distance","percent":50},{"metric":"money","value":250}]}`
+	assert.Equal(t, http.StatusForbidden, serve("member-1", http.MethodPut, body).Code)
+	assert.Equal(t, http.StatusBadRequest, serve("owner", http.MethodPut, `{"milestones":[{"metric":"steps","value":1}]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve("owner", http.MethodPut, `{"milestones":[{"metric":"distance","percent":25,"value":5}]}`).Code)
+
+	w := serve("owner", http.MethodPut, body)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serve("member-2", http.MethodGet, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dto.MilestoneListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 40.0, response.DistanceCovered)
+	require.Len(t, response.Milestones, 3)
+	assert.Equal(t, "25% of the distance goal", response.Milestones[0].Label)
+	assert.Equal(t, 25.0, response.Milestones[0].Threshold)
+	assert.True(t, response.Milestones[0].Reached)
+	assert.False(t, response.Milestones[1].Reached)
+	assert.Equal(t, "money", response.Milestones[2].Metric)
+}
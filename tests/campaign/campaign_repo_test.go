@@ -23,7 +23,8 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	}
 
 	// Auto-migrate the schema - include all related models for proper relationship handling
-	err = db.AutoMigrate(&gormmodel.Campaign{}, &gormmodel.CampaignRunner{}, &gormmodel.SponsorCampaign{}, &gormmodel.CampaignMember{}, &gormmodel.CampaignSponsor{})
+	err = db.AutoMigrate(&gormmodel.Campaign{}, &gormmodel.CampaignRunner{}, &gormmodel.SponsorCampaign{}, &gormmodel.CampaignMember{}, &gormmodel.CampaignSponsor{},
+		&gormmodel.CampaignStanding{}, &gormmodel.SponsorObligation{})
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
@@ -64,8 +65,8 @@ func TestGormCampaignRepository_Create(t *testing.T) {
 				AcceptTac:         true,
 				MoneyRaised:       0,
 				DistanceCovered:   0,
-				Members:           []interface{}{},
-				Sponsors:          []interface{}{},
+				Members:           []campaignModel.UserSummary{},
+				Sponsors:          []campaignModel.UserSummary{},
 				Slug:              "test-campaign-slug",
 			},
 			expectedErr: nil,
@@ -584,7 +585,7 @@ func TestGormCampaignRepository_AddMember(t *testing.T) {
 		Name:    "Test Campaign",
 		OwnerID: "owner123",
 		Slug:    "test-campaign-slug",
-		Members: []interface{}{},
+		Members: []campaignModel.UserSummary{},
 	}
 	err := repo.Create(testCampaign)
 	assert.NoError(t, err)
@@ -596,7 +597,7 @@ func TestGormCampaignRepository_AddMember(t *testing.T) {
 	// Verify the member was added
 	updatedCampaign, err := repo.GetByID("test-campaign-id")
 	assert.NoError(t, err)
-	assert.Contains(t, updatedCampaign.Members, "user123")
+	assert.Contains(t, campaignModel.UserIDs(updatedCampaign.Members), "user123")
 }
 
 func TestGormCampaignRepository_RemoveMember(t *testing.T) {
@@ -613,7 +614,7 @@ func TestGormCampaignRepository_RemoveMember(t *testing.T) {
 		Name:    "Test Campaign",
 		OwnerID: "owner123",
 		Slug:    "test-campaign-slug",
-		Members: []interface{}{"user123"},
+		Members: campaignModel.UsersByID("user123"),
 	}
 	err := repo.Create(testCampaign)
 	assert.NoError(t, err)
@@ -625,7 +626,7 @@ func TestGormCampaignRepository_RemoveMember(t *testing.T) {
 	// Verify the member was removed
 	updatedCampaign, err := repo.GetByID("test-campaign-id")
 	assert.NoError(t, err)
-	assert.NotContains(t, updatedCampaign.Members, "user123")
+	assert.NotContains(t, campaignModel.UserIDs(updatedCampaign.Members), "user123")
 }
 
 func TestGormCampaignRepository_IsMember(t *testing.T) {
@@ -642,7 +643,7 @@ func TestGormCampaignRepository_IsMember(t *testing.T) {
 		Name:    "Test Campaign",
 		OwnerID: "owner123",
 		Slug:    "test-campaign-slug",
-		Members: []interface{}{"user123", "user456"},
+		Members: campaignModel.UsersByID("user123", "user456"),
 	}
 	err := repo.Create(testCampaign)
 	assert.NoError(t, err)
@@ -690,7 +691,7 @@ func TestGormCampaignRepository_AddSponsor(t *testing.T) {
 		Name:     "Test Campaign",
 		OwnerID:  "owner123",
 		Slug:     "test-campaign-slug",
-		Sponsors: []interface{}{},
+		Sponsors: []campaignModel.UserSummary{},
 	}
 	err := repo.Create(testCampaign)
 	assert.NoError(t, err)
@@ -702,7 +703,7 @@ func TestGormCampaignRepository_AddSponsor(t *testing.T) {
 	// Verify the sponsor was added
 	updatedCampaign, err := repo.GetByID("test-campaign-id")
 	assert.NoError(t, err)
-	assert.Contains(t, updatedCampaign.Sponsors, "user123")
+	assert.Contains(t, campaignModel.UserIDs(updatedCampaign.Sponsors), "user123")
 }
 
 func TestGormCampaignRepository_RemoveSponsor(t *testing.T) {
@@ -719,7 +720,7 @@ func TestGormCampaignRepository_RemoveSponsor(t *testing.T) {
 		Name:     "Test Campaign",
 		OwnerID:  "owner123",
 		Slug:     "test-campaign-slug",
-		Sponsors: []interface{}{"user123"},
+		Sponsors: campaignModel.UsersByID("user123"),
 	}
 	err := repo.Create(testCampaign)
 	assert.NoError(t, err)
@@ -731,7 +732,7 @@ func TestGormCampaignRepository_RemoveSponsor(t *testing.T) {
 	// Verify the sponsor was removed
 	updatedCampaign, err := repo.GetByID("test-campaign-id")
 	assert.NoError(t, err)
-	assert.NotContains(t, updatedCampaign.Sponsors, "user123")
+	assert.NotContains(t, campaignModel.UserIDs(updatedCampaign.Sponsors), "user123")
 }
 
 func TestGormCampaignRepository_IsSponsor(t *testing.T) {
@@ -748,7 +749,7 @@ func TestGormCampaignRepository_IsSponsor(t *testing.T) {
 		Name:     "Test Campaign",
 		OwnerID:  "owner123",
 		Slug:     "test-campaign-slug",
-		Sponsors: []interface{}{"user123", "user456"},
+		Sponsors: campaignModel.UsersByID("user123", "user456"),
 	}
 	err := repo.Create(testCampaign)
 	assert.NoError(t, err)
@@ -819,8 +820,8 @@ func TestGormCampaignRepository_CampaignCreationWithRelationships(t *testing.T)
 				AcceptTac:         true,
 				MoneyRaised:       0,
 				DistanceCovered:   0,
-				Members:           []interface{}{"member1", "member2", "member3"},
-				Sponsors:          []interface{}{"sponsor1", "sponsor2"},
+				Members:           campaignModel.UsersByID("member1", "member2", "member3"),
+				Sponsors:          campaignModel.UsersByID("sponsor1", "sponsor2"),
 				Slug:              "relationship-test-campaign",
 			},
 			expectedMembers:  3,
@@ -850,8 +851,8 @@ func TestGormCampaignRepository_CampaignCreationWithRelationships(t *testing.T)
 				AcceptTac:         true,
 				MoneyRaised:       0,
 				DistanceCovered:   0,
-				Members:           []interface{}{},
-				Sponsors:          []interface{}{},
+				Members:           []campaignModel.UserSummary{},
+				Sponsors:          []campaignModel.UserSummary{},
 				Slug:              "empty-relationships-campaign",
 			},
 			expectedMembers:  0,
@@ -936,8 +937,8 @@ func TestGormCampaignRepository_RelationshipPreloading(t *testing.T) {
 		AcceptTac:         true,
 		MoneyRaised:       0,
 		DistanceCovered:   0,
-		Members:           []interface{}{"member1", "member2"},
-		Sponsors:          []interface{}{"sponsor1", "sponsor2"},
+		Members:           campaignModel.UsersByID("member1", "member2"),
+		Sponsors:          campaignModel.UsersByID("sponsor1", "sponsor2"),
 		Slug:              "preload-test-campaign",
 	}
 
@@ -979,10 +980,10 @@ func TestGormCampaignRepository_RelationshipPreloading(t *testing.T) {
 			// Verify relationships are properly loaded
 			assert.Len(t, retrieved.Members, 2)
 			assert.Len(t, retrieved.Sponsors, 2)
-			assert.Contains(t, retrieved.Members, "member1")
-			assert.Contains(t, retrieved.Members, "member2")
-			assert.Contains(t, retrieved.Sponsors, "sponsor1")
-			assert.Contains(t, retrieved.Sponsors, "sponsor2")
+			assert.Contains(t, campaignModel.UserIDs(retrieved.Members), "member1")
+			assert.Contains(t, campaignModel.UserIDs(retrieved.Members), "member2")
+			assert.Contains(t, campaignModel.UserIDs(retrieved.Sponsors), "sponsor1")
+			assert.Contains(t, campaignModel.UserIDs(retrieved.Sponsors), "sponsor2")
 		})
 	}
 }
@@ -1014,8 +1015,8 @@ func TestGormCampaignRepository_RelationshipIntegrity(t *testing.T) {
 		AcceptTac:         true,
 		MoneyRaised:       0,
 		DistanceCovered:   0,
-		Members:           []interface{}{"member1", "member2"},
-		Sponsors:          []interface{}{"sponsor1", "sponsor2"},
+		Members:           campaignModel.UsersByID("member1", "member2"),
+		Sponsors:          campaignModel.UsersByID("sponsor1", "sponsor2"),
 		Slug:              "integrity-test-campaign",
 	}
 
@@ -1046,8 +1047,8 @@ func TestGormCampaignRepository_RelationshipIntegrity(t *testing.T) {
 			AcceptTac:         true,
 			MoneyRaised:       0,
 			DistanceCovered:   0,
-			Members:           []interface{}{"member1", "member2"},   // Same members
-			Sponsors:          []interface{}{"sponsor1", "sponsor2"}, // Same sponsors
+			Members:           campaignModel.UsersByID("member1", "member2"),   // Same members
+			Sponsors:          campaignModel.UsersByID("sponsor1", "sponsor2"), // Same sponsors
 			Slug:              "integrity-test-campaign",
 		}
 
@@ -1071,10 +1072,10 @@ func TestGormCampaignRepository_RelationshipIntegrity(t *testing.T) {
 
 		assert.Len(t, retrieved.Members, 2)
 		assert.Len(t, retrieved.Sponsors, 2)
-		assert.Contains(t, retrieved.Members, "member1")
-		assert.Contains(t, retrieved.Members, "member2")
-		assert.Contains(t, retrieved.Sponsors, "sponsor1")
-		assert.Contains(t, retrieved.Sponsors, "sponsor2")
+		assert.Contains(t, campaignModel.UserIDs(retrieved.Members), "member1")
+		assert.Contains(t, campaignModel.UserIDs(retrieved.Members), "member2")
+		assert.Contains(t, campaignModel.UserIDs(retrieved.Sponsors), "sponsor1")
+		assert.Contains(t, campaignModel.UserIDs(retrieved.Sponsors), "sponsor2")
 
 		// Verify database-level relationships
 		var members []gormmodel.CampaignMember
@@ -1114,8 +1115,8 @@ func TestGormCampaignRepository_CascadeDeleteRelationships(t *testing.T) {
 		AcceptTac:         true,
 		MoneyRaised:       0,
 		DistanceCovered:   0,
-		Members:           []interface{}{"member1", "member2", "member3"},
-		Sponsors:          []interface{}{"sponsor1", "sponsor2", "sponsor3"},
+		Members:           campaignModel.UsersByID("member1", "member2", "member3"),
+		Sponsors:          campaignModel.UsersByID("sponsor1", "sponsor2", "sponsor3"),
 		Slug:              "cascade-delete-test-campaign",
 	}
 
@@ -1174,8 +1175,8 @@ func TestGormCampaignRepository_RelationshipOperations(t *testing.T) {
 		AcceptTac:         true,
 		MoneyRaised:       0,
 		DistanceCovered:   0,
-		Members:           []interface{}{"initial-member"},
-		Sponsors:          []interface{}{"initial-sponsor"},
+		Members:           campaignModel.UsersByID("initial-member"),
+		Sponsors:          campaignModel.UsersByID("initial-sponsor"),
 		Slug:              "relationship-ops-test-campaign",
 	}
 
@@ -1287,8 +1288,8 @@ func TestGormCampaignRepository_ListWithRelationships(t *testing.T) {
 			AcceptTac:         true,
 			MoneyRaised:       0,
 			DistanceCovered:   0,
-			Members:           []interface{}{"member1", "member2"},
-			Sponsors:          []interface{}{"sponsor1"},
+			Members:           campaignModel.UsersByID("member1", "member2"),
+			Sponsors:          campaignModel.UsersByID("sponsor1"),
 			Slug:              "list-test-campaign-1",
 		},
 		{
@@ -1313,8 +1314,8 @@ func TestGormCampaignRepository_ListWithRelationships(t *testing.T) {
 			AcceptTac:         true,
 			MoneyRaised:       0,
 			DistanceCovered:   0,
-			Members:           []interface{}{"member3", "member4", "member5"},
-			Sponsors:          []interface{}{"sponsor2", "sponsor3"},
+			Members:           campaignModel.UsersByID("member3", "member4", "member5"),
+			Sponsors:          campaignModel.UsersByID("sponsor2", "sponsor3"),
 			Slug:              "list-test-campaign-2",
 		},
 	}
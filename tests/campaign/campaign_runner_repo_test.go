@@ -62,7 +62,7 @@ func TestGormCampaignRunnerRepository_Create(t *testing.T) {
 				},
 				CampaignID:      "test-campaign-id",
 				DistanceCovered: 10.5,
-				Duration:        "45:30",
+				Duration:        45*time.Minute + 30*time.Second,
 				MoneyRaised:     25.0,
 				CoverImage:      "runner.jpg",
 				Activity:        "Running",
@@ -131,7 +131,7 @@ func TestGormCampaignRunnerRepository_GetByID(t *testing.T) {
 		ID:              "test-runner-id",
 		CampaignID:      "test-campaign-id",
 		DistanceCovered: 10.5,
-		Duration:        "45:30",
+		DurationSeconds: 2730,
 		MoneyRaised:     25.0,
 		CoverImage:      "runner.jpg",
 		Activity:        "Running",
@@ -212,7 +212,7 @@ func TestGormCampaignRunnerRepository_GetByCampaignID(t *testing.T) {
 			ID:              "runner1",
 			CampaignID:      "campaign1",
 			DistanceCovered: 10.5,
-			Duration:        "45:30",
+			DurationSeconds: 2730,
 			MoneyRaised:     25.0,
 			Activity:        "Running",
 			OwnerID:         "user1",
@@ -224,7 +224,7 @@ func TestGormCampaignRunnerRepository_GetByCampaignID(t *testing.T) {
 			ID:              "runner2",
 			CampaignID:      "campaign1",
 			DistanceCovered: 5.0,
-			Duration:        "30:00",
+			DurationSeconds: 1800,
 			MoneyRaised:     10.0,
 			Activity:        "Walking",
 			OwnerID:         "user2",
@@ -236,7 +236,7 @@ func TestGormCampaignRunnerRepository_GetByCampaignID(t *testing.T) {
 			ID:              "runner3",
 			CampaignID:      "campaign2",
 			DistanceCovered: 15.0,
-			Duration:        "60:00",
+			DurationSeconds: 3600,
 			MoneyRaised:     30.0,
 			Activity:        "Cycling",
 			OwnerID:         "user3",
@@ -326,7 +326,7 @@ func TestGormCampaignRunnerRepository_GetByOwnerID(t *testing.T) {
 			ID:              "runner1",
 			CampaignID:      "test-campaign-id",
 			DistanceCovered: 10.5,
-			Duration:        "45:30",
+			DurationSeconds: 2730,
 			MoneyRaised:     25.0,
 			Activity:        "Running",
 			OwnerID:         "user1",
@@ -338,7 +338,7 @@ func TestGormCampaignRunnerRepository_GetByOwnerID(t *testing.T) {
 			ID:              "runner2",
 			CampaignID:      "test-campaign-id",
 			DistanceCovered: 5.0,
-			Duration:        "30:00",
+			DurationSeconds: 1800,
 			MoneyRaised:     10.0,
 			Activity:        "Walking",
 			OwnerID:         "user1",
@@ -350,7 +350,7 @@ func TestGormCampaignRunnerRepository_GetByOwnerID(t *testing.T) {
 			ID:              "runner3",
 			CampaignID:      "test-campaign-id",
 			DistanceCovered: 15.0,
-			Duration:        "60:00",
+			DurationSeconds: 3600,
 			MoneyRaised:     30.0,
 			Activity:        "Cycling",
 			OwnerID:         "user2",
@@ -438,7 +438,7 @@ func TestGormCampaignRunnerRepository_Update(t *testing.T) {
 		ID:              "test-runner-id",
 		CampaignID:      "test-campaign-id",
 		DistanceCovered: 10.5,
-		Duration:        "45:30",
+		DurationSeconds: 2730,
 		MoneyRaised:     25.0,
 		CoverImage:      "runner.jpg",
 		Activity:        "Running",
@@ -467,7 +467,7 @@ func TestGormCampaignRunnerRepository_Update(t *testing.T) {
 				},
 				CampaignID:      "test-campaign-id",
 				DistanceCovered: 15.0,
-				Duration:        "60:00",
+				Duration:        time.Hour,
 				MoneyRaised:     30.0,
 				CoverImage:      "updated-runner.jpg",
 				Activity:        "Walking",
@@ -487,7 +487,7 @@ func TestGormCampaignRunnerRepository_Update(t *testing.T) {
 				},
 				CampaignID:      "test-campaign-id",
 				DistanceCovered: 20.0,
-				Duration:        "90:00",
+				Duration:        time.Hour + 30*time.Minute,
 				MoneyRaised:     40.0,
 				CoverImage:      "partially-updated.jpg",
 				Activity:        "Cycling",
@@ -513,7 +513,7 @@ func TestGormCampaignRunnerRepository_Update(t *testing.T) {
 				result := db.First(&dbRunner, "id = ?", tt.runnerID)
 				assert.NoError(t, result.Error)
 				assert.Equal(t, tt.updateData.DistanceCovered, dbRunner.DistanceCovered)
-				assert.Equal(t, tt.updateData.Duration, dbRunner.Duration)
+				assert.Equal(t, tt.updateData.Duration, time.Duration(dbRunner.DurationSeconds)*time.Second)
 				assert.Equal(t, tt.updateData.MoneyRaised, dbRunner.MoneyRaised)
 				assert.Equal(t, tt.updateData.CoverImage, dbRunner.CoverImage)
 				assert.Equal(t, tt.updateData.Activity, dbRunner.Activity)
@@ -545,7 +545,7 @@ func TestGormCampaignRunnerRepository_Delete(t *testing.T) {
 		ID:              "test-runner-id",
 		CampaignID:      "test-campaign-id",
 		DistanceCovered: 10.5,
-		Duration:        "45:30",
+		DurationSeconds: 2730,
 		MoneyRaised:     25.0,
 		CoverImage:      "runner.jpg",
 		Activity:        "Running",
@@ -0,0 +1,293 @@
+package campaign_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	"gopi.com/internal/app/campaign"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/data/campaign/repo"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	campaignMocks "gopi.com/tests/mocks/campaign"
+	userMocks "gopi.com/tests/mocks/user"
+)
+
+func ids(campaigns []*campaignModel.Campaign) []string {
+	result := []string{}
+	for _, c := range campaigns {
+		result = append(result, c.ID)
+	}
+	return result
+}
+
+func seedSearchCampaigns(t *testing.T, r interface {
+	Create(*campaignModel.Campaign) error
+}) {
+	day := func(d int) *time.Time {
+		at := time.Date(2026, 5, d, 0, 0, 0, 0, time.UTC)
+		return &at
+	}
+	base := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	campaigns := []*campaignModel.Campaign{
+		{
+			Base: model.Base{ID: "lagos-run", CreatedAt: base.Add(1 * time.Hour)},
+			Name: "Lagos Marathon Fundraiser", Description: "Running for clean water", Location: "Lagos, Nigeria",
+			Activity: campaignModel.ActivityRunning, Mode: campaignModel.CampaignModeFree, Status: campaignModel.CampaignStatusActive,
+			TargetAmount: 1000, MoneyRaised: 900, DistanceToCover: 100, DistanceCovered: 20,
+			StartsAt: day(1), EndsAt: day(10), OwnerID: "owner1", Slug: "lagos-run",
+		},
+		{
+			Base: model.Base{ID: "abuja-walk", CreatedAt: base.Add(2 * time.Hour)},
+			Name: "Abuja Charity Walk", Description: "Walking for school books", Location: "Abuja, Nigeria",
+			Activity: campaignModel.ActivityWalking, Mode: campaignModel.CampaignModePaid, Status: campaignModel.CampaignStatusScheduled,
+			TargetAmount: 5000, MoneyRaised: 100,
+			StartsAt: day(20), EndsAt: day(30), OwnerID: "owner2", Slug: "abuja-walk",
+		},
+		{
+			// Created before the lifecycle existed: no status, no window.
+			Base: model.Base{ID: "legacy-ride", CreatedAt: base.Add(3 * time.Hour)},
+			Name: "Legacy Ride", Description: "Cycling for clean water", Location: "Accra, Ghana",
+			Activity: campaignModel.ActivityCycling, Mode: campaignModel.CampaignModeFree,
+			DistanceToCover: 50, DistanceCovered: 40, MoneyRaised: 50,
+			OwnerID: "owner1", Slug: "legacy-ride",
+		},
+		{
+			Base: model.Base{ID: "done-run", CreatedAt: base.Add(4 * time.Hour)},
+			Name: "Finished Run", Description: "Running, already achieved", Location: "Lagos, Nigeria",
+			Activity: campaignModel.ActivityRunning, Mode: campaignModel.CampaignModeFree, Status: campaignModel.CampaignStatusCompleted,
+			TargetAmount: 100, MoneyRaised: 150, AchievedAt: day(2),
+			StartsAt: day(1), EndsAt: day(3), OwnerID: "owner3", Slug: "done-run",
+		},
+	}
+	for _, c := range campaigns {
+		require.NoError(t, r.Create(c))
+	}
+}
+
+func TestGormCampaignRepository_Find(t *testing.T) {
+	db := setupTestDB(t)
+	campaignRepo := repo.NewGormCampaignRepository(db)
+	seedSearchCampaigns(t, campaignRepo)
+
+	float := func(f float64) *float64 { return &f }
+	at := func(d int) *time.Time {
+		t := time.Date(2026, 5, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		name          string
+		filter        campaignModel.CampaignFilter
+		expectedIDs   []string
+		expectedTotal int64
+	}{
+		{
+			name:          "no filter returns newest first",
+			filter:        campaignModel.CampaignFilter{},
+			expectedIDs:   []string{"done-run", "legacy-ride", "abuja-walk", "lagos-run"},
+			expectedTotal: 4,
+		},
+		{
+			name:          "every query word must match",
+			filter:        campaignModel.CampaignFilter{Query: "clean water"},
+			expectedIDs:   []string{"legacy-ride", "lagos-run"},
+			expectedTotal: 2,
+		},
+		{
+			name:          "query matches location and ignores punctuation",
+			filter:        campaignModel.CampaignFilter{Query: `ghana" OR *`},
+			expectedIDs:   []string{"legacy-ride"},
+			expectedTotal: 1,
+		},
+		{
+			name:          "activity and mode",
+			filter:        campaignModel.CampaignFilter{Activity: campaignModel.ActivityRunning, Mode: campaignModel.CampaignModeFree},
+			expectedIDs:   []string{"done-run", "lagos-run"},
+			expectedTotal: 2,
+		},
+		{
+			name:          "location",
+			filter:        campaignModel.CampaignFilter{Location: "lagos"},
+			expectedIDs:   []string{"done-run", "lagos-run"},
+			expectedTotal: 2,
+		},
+		{
+			name:          "active includes legacy campaigns without a status",
+			filter:        campaignModel.CampaignFilter{Status: campaignModel.CampaignStatusActive},
+			expectedIDs:   []string{"legacy-ride", "lagos-run"},
+			expectedTotal: 2,
+		},
+		{
+			name:          "date range overlaps the campaign window",
+			filter:        campaignModel.CampaignFilter{From: at(5), To: at(15)},
+			expectedIDs:   []string{"legacy-ride", "lagos-run"},
+			expectedTotal: 2,
+		},
+		{
+			name:          "target range",
+			filter:        campaignModel.CampaignFilter{MinTarget: float(500), MaxTarget: float(2000)},
+			expectedIDs:   []string{"lagos-run"},
+			expectedTotal: 1,
+		},
+		{
+			name:          "exclude owner",
+			filter:        campaignModel.CampaignFilter{ExcludeOwnerID: "owner1"},
+			expectedIDs:   []string{"done-run", "abuja-walk"},
+			expectedTotal: 2,
+		},
+		{
+			name:          "page past the first with the full total",
+			filter:        campaignModel.CampaignFilter{Limit: 2, Offset: 2},
+			expectedIDs:   []string{"abuja-walk", "lagos-run"},
+			expectedTotal: 4,
+		},
+		{
+			name:          "most funded",
+			filter:        campaignModel.CampaignFilter{Sort: campaignModel.CampaignSortMostFunded},
+			expectedIDs:   []string{"lagos-run", "done-run", "abuja-walk", "legacy-ride"},
+			expectedTotal: 4,
+		},
+		{
+			// lagos-run is 90% funded, legacy-ride 80% of its distance, abuja-walk 2% funded;
+			// achieved campaigns come after those still going.
+			name:          "closest to goal",
+			filter:        campaignModel.CampaignFilter{Sort: campaignModel.CampaignSortClosestToGoal},
+			expectedIDs:   []string{"lagos-run", "legacy-ride", "abuja-walk", "done-run"},
+			expectedTotal: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaigns, total, err := campaignRepo.Find(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedIDs, ids(campaigns))
+			assert.Equal(t, tt.expectedTotal, total)
+		})
+	}
+}
+
+// The full-text index is only used when the binary is built with the sqlite_fts5 tag; either
+// way the results must match.
+func TestGormCampaignRepository_Find_FullTextIndex(t *testing.T) {
+	db := setupTestDB(t)
+	campaignRepo := repo.NewGormCampaignRepository(db)
+
+	// Campaigns created before the index exist are indexed by the migration.
+	seedSearchCampaigns(t, campaignRepo)
+	require.NoError(t, gormmodel.MigrateCampaignSearch(db))
+	require.NoError(t, gormmodel.MigrateCampaignSearch(db)) // idempotent
+
+	campaigns, _, err := campaignRepo.Find(campaignModel.CampaignFilter{Query: "water"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"lagos-run", "legacy-ride"}, ids(campaigns))
+
+	// Words match by prefix.
+	campaigns, _, err = campaignRepo.Find(campaignModel.CampaignFilter{Query: "chari"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abuja-walk"}, ids(campaigns))
+
+	// Later inserts, updates and deletes are kept in sync.
+	require.NoError(t, campaignRepo.Create(&campaignModel.Campaign{
+		Base: model.Base{ID: "new-swim"}, Name: "Harbour Swim", Description: "Swimming for water", OwnerID: "owner4", Slug: "new-swim",
+	}))
+	moved, err := campaignRepo.GetByID("legacy-ride")
+	require.NoError(t, err)
+	moved.Description = "Cycling for libraries"
+	require.NoError(t, campaignRepo.Update(moved))
+	require.NoError(t, campaignRepo.Delete("lagos-run"))
+
+	campaigns, total, err := campaignRepo.Find(campaignModel.CampaignFilter{Query: "water"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new-swim"}, ids(campaigns))
+	assert.Equal(t, int64(1), total)
+}
+
+func TestCampaignHandler_SearchCampaigns(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMocks     func(*campaignMocks.MockCampaignRepository)
+		expectedStatus int
+		expectedTotal  int
+	}{
+		{
+			name:  "filters are passed to the repository",
+			query: "q=water&activity=Running&status=active&to=2026-05-10&min_target=100&sort=closest_to_goal&page=3&limit=5",
+			setupMocks: func(m *campaignMocks.MockCampaignRepository) {
+				m.On("Find", mock.MatchedBy(func(f campaignModel.CampaignFilter) bool {
+					endOfDay := time.Date(2026, 5, 10, 23, 59, 59, 999999999, time.UTC)
+					return f.Query == "water" &&
+						f.Activity == campaignModel.ActivityRunning &&
+						f.Status == campaignModel.CampaignStatusActive &&
+						f.To != nil && f.To.Equal(endOfDay) && f.From == nil &&
+						f.MinTarget != nil && *f.MinTarget == 100 && f.MaxTarget == nil &&
+						f.Sort == campaignModel.CampaignSortClosestToGoal &&
+						f.Limit == 5 && f.Offset == 10
+				})).Return([]*campaignModel.Campaign{}, int64(11), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTotal:  11,
+		},
+		{
+			name:  "defaults to newest",
+			query: "",
+			setupMocks: func(m *campaignMocks.MockCampaignRepository) {
+				m.On("Find", campaignModel.CampaignFilter{Listed: true, Sort: campaignModel.CampaignSortNewest, Limit: 10}).
+					Return([]*campaignModel.Campaign{}, int64(0), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown sort",
+			query:          "sort=cheapest",
+			setupMocks:     func(m *campaignMocks.MockCampaignRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "bad date",
+			query:          "from=last-week",
+			setupMocks:     func(m *campaignMocks.MockCampaignRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+			tt.setupMocks(mockCampaignRepo)
+			campaignService := campaign.NewCampaignService(mockCampaignRepo, new(campaignMocks.MockCampaignRunnerRepository),
+				new(campaignMocks.MockSponsorCampaignRepository))
+			campaignHandler := handler.NewCampaignHandler(campaignService, user.NewUserService(new(userMocks.MockUserRepository), nil))
+
+			router := gin.New()
+			router.GET("/campaigns/search", campaignHandler.SearchCampaigns)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/campaigns/search?"+tt.query, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dto.CampaignListResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedTotal, response.Total)
+				assert.NotNil(t, response.Campaigns)
+			}
+			mockCampaignRepo.AssertExpectations(t)
+		})
+	}
+}
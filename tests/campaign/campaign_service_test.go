@@ -1,6 +1,7 @@
 package campaign_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	campaign "gopi.com/internal/app/campaign"
 	campaignModel "gopi.com/internal/domain/campaign/model"
+	mediaModel "gopi.com/internal/domain/media/model"
 	"gopi.com/internal/domain/model"
 	campaignMocks "gopi.com/tests/mocks/campaign"
 )
@@ -98,7 +100,7 @@ func TestCampaignService_CreateCampaign(t *testing.T) {
 			result, err := service.CreateCampaign(
 				tt.ownerID, tt.ownerUsername, tt.nameArg, tt.description, tt.condition, tt.goal, tt.location,
 				tt.mode, tt.activity, tt.targetAmount, tt.targetAmountPerKm, tt.distanceToCover,
-				tt.startDuration, tt.endDuration,
+				tt.startDuration, tt.endDuration, nil, "",
 			)
 
 			if tt.expectedErr != nil {
@@ -253,6 +255,12 @@ func TestCampaignService_GetCampaignsByOwner(t *testing.T) {
 }
 
 func TestCampaignService_JoinCampaign(t *testing.T) {
+	openCampaign := &campaignModel.Campaign{
+		Base:   model.Base{ID: "campaign123"},
+		Status: campaignModel.CampaignStatusActive,
+	}
+	ended := time.Now().Add(-time.Hour)
+
 	tests := []struct {
 		name        string
 		campaignID  string
@@ -266,6 +274,7 @@ func TestCampaignService_JoinCampaign(t *testing.T) {
 			userID:      "user123",
 			expectedErr: nil,
 			mockSetup: func(mockRepo *campaignMocks.MockCampaignRepository) {
+				mockRepo.On("GetByID", "campaign123").Return(openCampaign, nil)
 				mockRepo.On("IsMember", "campaign123", "user123").Return(false, nil)
 				mockRepo.On("AddMember", "campaign123", "user123").Return(nil)
 			},
@@ -276,6 +285,7 @@ func TestCampaignService_JoinCampaign(t *testing.T) {
 			userID:      "user123",
 			expectedErr: errors.New("user is already a member of this campaign"),
 			mockSetup: func(mockRepo *campaignMocks.MockCampaignRepository) {
+				mockRepo.On("GetByID", "campaign123").Return(openCampaign, nil)
 				mockRepo.On("IsMember", "campaign123", "user123").Return(true, nil)
 			},
 		},
@@ -285,9 +295,35 @@ func TestCampaignService_JoinCampaign(t *testing.T) {
 			userID:      "user123",
 			expectedErr: errors.New("repository error"),
 			mockSetup: func(mockRepo *campaignMocks.MockCampaignRepository) {
+				mockRepo.On("GetByID", "campaign123").Return(openCampaign, nil)
 				mockRepo.On("IsMember", "campaign123", "user123").Return(false, errors.New("repository error"))
 			},
 		},
+		{
+			name:        "campaign has ended",
+			campaignID:  "campaign123",
+			userID:      "user123",
+			expectedErr: campaignModel.ErrInvalidCampaignState,
+			mockSetup: func(mockRepo *campaignMocks.MockCampaignRepository) {
+				mockRepo.On("GetByID", "campaign123").Return(&campaignModel.Campaign{
+					Base:   model.Base{ID: "campaign123"},
+					Status: campaignModel.CampaignStatusActive,
+					EndsAt: &ended,
+				}, nil)
+			},
+		},
+		{
+			name:        "draft campaign",
+			campaignID:  "campaign123",
+			userID:      "user123",
+			expectedErr: campaignModel.ErrInvalidCampaignState,
+			mockSetup: func(mockRepo *campaignMocks.MockCampaignRepository) {
+				mockRepo.On("GetByID", "campaign123").Return(&campaignModel.Campaign{
+					Base:   model.Base{ID: "campaign123"},
+					Status: campaignModel.CampaignStatusDraft,
+				}, nil)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -336,16 +372,14 @@ func TestCampaignService_RecordActivity(t *testing.T) {
 		return r.CampaignID == "campaign123" &&
 			r.OwnerID == "user123" &&
 			r.DistanceCovered == 10.0 &&
-			r.Duration == "30:00" &&
+			r.Duration == 30*time.Minute &&
 			r.Activity == "Walking"
 	})).Return(nil)
-	mockCampaignRepo.On("Update", mock.MatchedBy(func(c *campaignModel.Campaign) bool {
-		return c.ID == "campaign123" && c.DistanceCovered == 15.0
-	})).Return(nil)
+	mockCampaignRepo.On("IncrementTotals", "campaign123", 10.0, 0.0).Return(nil)
 
 	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo)
 
-	err := service.RecordActivity("campaign123", "user123", 10.0, "30:00", "Walking")
+	err := service.RecordActivity("campaign123", "user123", 10.0, 30*time.Minute, "Walking")
 
 	assert.NoError(t, err)
 
@@ -368,7 +402,7 @@ func TestCampaignService_SponsorCampaign(t *testing.T) {
 		MoneyRaised: 100.0,
 	}
 
-	sponsors := []interface{}{"sponsor1", "sponsor2"}
+	sponsors := campaignModel.UsersByID("sponsor1", "sponsor2")
 
 	mockCampaignRepo.On("GetByID", "campaign123").Return(existingCampaign, nil)
 	mockSponsorRepo.On("Create", mock.MatchedBy(func(s *campaignModel.SponsorCampaign) bool {
@@ -378,9 +412,7 @@ func TestCampaignService_SponsorCampaign(t *testing.T) {
 			s.AmountPerKm == 5.0 &&
 			s.TotalAmount == 50.0
 	})).Return(nil)
-	mockCampaignRepo.On("Update", mock.MatchedBy(func(c *campaignModel.Campaign) bool {
-		return c.ID == "campaign123" && c.MoneyRaised == 150.0
-	})).Return(nil)
+	mockCampaignRepo.On("IncrementTotals", "campaign123", 0.0, 50.0).Return(nil)
 
 	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo)
 
@@ -418,11 +450,12 @@ func TestCampaignService_ListCampaigns(t *testing.T) {
 		},
 	}
 
-	mockCampaignRepo.On("List", 10, 0).Return(expectedCampaigns, nil)
+	mockCampaignRepo.On("Find", campaignModel.CampaignFilter{Listed: true, ViewerID: "viewer1", Limit: 10, Offset: 0}).
+		Return(expectedCampaigns, int64(2), nil)
 
 	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo)
 
-	result, err := service.ListCampaigns(10, 0)
+	result, err := service.ListCampaigns("viewer1", 10, 0)
 
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
@@ -437,16 +470,8 @@ func TestCampaignService_GetCampaignsByNonOwner(t *testing.T) {
 	mockRunnerRepo := new(campaignMocks.MockCampaignRunnerRepository)
 	mockSponsorRepo := new(campaignMocks.MockSponsorCampaignRepository)
 
-	allCampaigns := []*campaignModel.Campaign{
-		{
-			Base: model.Base{
-				ID:        "campaign1",
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			},
-			Name:    "Campaign 1",
-			OwnerID: "owner123", // This is the user we're excluding
-		},
+	// The repository does the owner filtering and paging
+	otherCampaigns := []*campaignModel.Campaign{
 		{
 			Base: model.Base{
 				ID:        "campaign2",
@@ -467,14 +492,16 @@ func TestCampaignService_GetCampaignsByNonOwner(t *testing.T) {
 		},
 	}
 
-	mockCampaignRepo.On("List", 100, 0).Return(allCampaigns, nil) // Service multiplies limit by 10
+	mockCampaignRepo.On("Find", campaignModel.CampaignFilter{ExcludeOwnerID: "owner123", Listed: true, ViewerID: "owner123", Limit: 10, Offset: 0}).
+		Return(otherCampaigns, int64(2), nil)
 
 	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo)
 
-	result, err := service.GetCampaignsByNonOwner("owner123", 10, 0)
+	result, total, err := service.GetCampaignsByNonOwner("owner123", 10, 0)
 
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
+	assert.Equal(t, int64(2), total)
 	assert.Equal(t, "campaign2", result[0].ID)
 	assert.Equal(t, "campaign3", result[1].ID)
 
@@ -562,15 +589,28 @@ func TestCampaignService_DeleteCampaign(t *testing.T) {
 
 	mockCampaignRepo.On("Delete", "campaign123").Return(nil)
 
-	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo)
+	galleries := &recordingGalleries{}
+
+	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo, campaign.WithGalleries(galleries))
 
 	err := service.DeleteCampaign("campaign123")
 
 	assert.NoError(t, err)
+	assert.Equal(t, []string{"campaign/campaign123"}, galleries.deleted)
 
 	mockCampaignRepo.AssertExpectations(t)
 }
 
+// recordingGalleries records the galleries it is asked to delete as "kind/id".
+type recordingGalleries struct {
+	deleted []string
+}
+
+func (g *recordingGalleries) DeleteGallery(ctx context.Context, kind mediaModel.SubjectKind, subjectID string) error {
+	g.deleted = append(g.deleted, string(kind)+"/"+subjectID)
+	return nil
+}
+
 func TestCampaignService_GetLeaderboard(t *testing.T) {
 	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
 	mockRunnerRepo := new(campaignMocks.MockCampaignRunnerRepository)
@@ -679,22 +719,13 @@ func TestCampaignService_FinishActivity(t *testing.T) {
 	}
 
 	mockRunnerRepo.On("GetByID", "runner123").Return(existingRunner, nil)
-	mockRunnerRepo.On("Update", mock.MatchedBy(func(r *campaignModel.CampaignRunner) bool {
-		return r.ID == "runner123" &&
-			r.DistanceCovered == 15.0 &&
-			r.Duration == "45:00" &&
-			r.MoneyRaised == 25.0
-	})).Return(nil)
+	mockRunnerRepo.On("IncrementProgress", "runner123", 10.0, 15.0, 45*time.Minute).Return(nil)
 	mockCampaignRepo.On("GetByID", "campaign123").Return(existingCampaign, nil)
-	mockCampaignRepo.On("Update", mock.MatchedBy(func(c *campaignModel.Campaign) bool {
-		return c.ID == "campaign123" &&
-			c.DistanceCovered == 20.0 &&
-			c.MoneyRaised == 35.0
-	})).Return(nil)
+	mockCampaignRepo.On("IncrementTotals", "campaign123", 10.0, 15.0).Return(nil)
 
 	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo)
 
-	err := service.FinishActivity("runner123", 10.0, "45:00", 15.0)
+	err := service.FinishActivity("runner123", 10.0, 45*time.Minute, 15.0)
 
 	assert.NoError(t, err)
 
@@ -817,7 +848,7 @@ func TestCampaignService_CreateSponsorCampaign(t *testing.T) {
 		MoneyRaised: 100.0,
 	}
 
-	sponsors := []interface{}{"sponsor1", "sponsor2"}
+	sponsors := campaignModel.UsersByID("sponsor1", "sponsor2")
 
 	mockCampaignRepo.On("GetByID", "campaign123").Return(existingCampaign, nil)
 	mockSponsorRepo.On("Create", mock.MatchedBy(func(s *campaignModel.SponsorCampaign) bool {
@@ -827,9 +858,7 @@ func TestCampaignService_CreateSponsorCampaign(t *testing.T) {
 			s.AmountPerKm == 5.0 &&
 			s.TotalAmount == 50.0
 	})).Return(nil)
-	mockCampaignRepo.On("Update", mock.MatchedBy(func(c *campaignModel.Campaign) bool {
-		return c.ID == "campaign123" && c.MoneyRaised == 150.0
-	})).Return(nil)
+	mockCampaignRepo.On("IncrementTotals", "campaign123", 0.0, 50.0).Return(nil)
 
 	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo)
 
@@ -949,16 +978,7 @@ func TestCampaignService_SearchCampaigns(t *testing.T) {
 	mockRunnerRepo := new(campaignMocks.MockCampaignRunnerRepository)
 	mockSponsorRepo := new(campaignMocks.MockSponsorCampaignRepository)
 
-	allCampaigns := []*campaignModel.Campaign{
-		{
-			Base: model.Base{
-				ID:        "campaign1",
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			},
-			Name:        "Golang Tutorial",
-			Description: "Learn Golang programming",
-		},
+	matches := []*campaignModel.Campaign{
 		{
 			Base: model.Base{
 				ID:        "campaign2",
@@ -969,17 +989,25 @@ func TestCampaignService_SearchCampaigns(t *testing.T) {
 			Description: "Python programming basics",
 		},
 	}
+	filter := campaignModel.CampaignFilter{
+		Query:    "python",
+		Activity: campaignModel.ActivityRunning,
+		Sort:     campaignModel.CampaignSortMostFunded,
+		Limit:    10,
+		Offset:   20,
+	}
 
-	mockCampaignRepo.On("List", 20, 0).Return(allCampaigns, nil) // Service multiplies limit by 2
+	// Filtering and paging happen in the repository, so matches past the first page are not missed.
+	mockCampaignRepo.On("Find", filter).Return(matches, int64(21), nil)
 
 	service := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo)
 
-	result, err := service.SearchCampaigns("python", 10, 0)
+	result, total, err := service.SearchCampaigns(filter)
 
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.Equal(t, "campaign2", result[0].ID)
-	assert.Contains(t, result[0].Description, "Python")
+	assert.Equal(t, int64(21), total)
 
 	mockCampaignRepo.AssertExpectations(t)
 }
@@ -0,0 +1,428 @@
+package campaign_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	campaign "gopi.com/internal/app/campaign"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/data/campaign/repo"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+	"gopi.com/internal/domain/model"
+	campaignMocks "gopi.com/tests/mocks/campaign"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+func setupTeamService(t *testing.T) (*campaign.CampaignService, *campaignModel.Campaign) {
+	db := testdb.Open(t, &gormmodel.Campaign{}, &gormmodel.CampaignRunner{}, &gormmodel.SponsorCampaign{},
+		&gormmodel.CampaignMember{}, &gormmodel.CampaignSponsor{}, &gormmodel.CampaignTeam{}, &gormmodel.CampaignTeamMember{})
+
+	campaignRepo := repo.NewGormCampaignRepository(db)
+	service := campaign.NewCampaignService(campaignRepo, repo.NewGormCampaignRunnerRepository(db), repo.NewGormSponsorCampaignRepository(db),
+		campaign.WithUnitOfWork(repo.NewGormUnitOfWork(db)),
+		campaign.WithTeams(repo.NewGormCampaignTeamRepository(db)))
+
+	c := &campaignModel.Campaign{
+		Base:    model.Base{ID: "team-campaign"},
+		Name:    "teams",
+		OwnerID: "owner",
+		Slug:    "teams",
+		Status:  campaignModel.CampaignStatusActive,
+	}
+	require.NoError(t, campaignRepo.Create(c))
+	return service, c
+}
+
+func TestRankTeamStandings(t *testing.T) {
+	standings := campaignModel.RankTeamStandings([]*campaignModel.TeamStanding{
+		{TeamID: "b", Name: "Bravo", DistanceCovered: 10, MoneyRaised: 5},
+		{TeamID: "a", Name: "Alpha", DistanceCovered: 10, MoneyRaised: 5},
+		{TeamID: "c", Name: "Charlie", DistanceCovered: 10, MoneyRaised: 20},
+		{TeamID: "d", Name: "Delta", DistanceCovered: 30},
+	})
+
+	var order []string
+	for i, st := range standings {
+		assert.Equal(t, i+1, st.Rank)
+		order = append(order, st.TeamID)
+	}
+	assert.Equal(t, []string{"d", "c", "a", "b"}, order)
+}
+
+func TestCampaignService_CreateAndJoinTeam(t *testing.T) {
+	service, c := setupTeamService(t)
+
+	team, err := service.CreateTeam(c.ID, "captain", "  Road Runners ")
+	require.NoError(t, err)
+	assert.Equal(t, "Road Runners", team.Name)
+	assert.Equal(t, "captain", team.CaptainID)
+	assert.Len(t, team.InviteCode, 8)
+	assert.Equal(t, []string{"captain"}, team.Members)
+
+	// The captain becomes a campaign member.
+	isMember, err := service.IsMember(c.ID, "captain")
+	require.NoError(t, err)
+	assert.True(t, isMember)
+
+	_, err = service.CreateTeam(c.ID, "other", "road runners")
+	assert.True(t, errors.Is(err, campaignModel.ErrTeamNameTaken))
+
+	_, err = service.JoinTeam(c.ID, "user1", "WRONGCODE")
+	assert.True(t, errors.Is(err, campaignModel.ErrInvalidInviteCode))
+
+	// Codes are matched case-insensitively.
+	joined, err := service.JoinTeam(c.ID, "user1", " "+string(bytes.ToLower([]byte(team.InviteCode))))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"captain", "user1"}, joined.Members)
+
+	// One team per campaign.
+	_, err = service.JoinTeam(c.ID, "user1", team.InviteCode)
+	assert.True(t, errors.Is(err, campaignModel.ErrAlreadyInTeam))
+	_, err = service.CreateTeam(c.ID, "user1", "Second")
+	assert.True(t, errors.Is(err, campaignModel.ErrAlreadyInTeam))
+
+	// Only the captain manages the team.
+	_, err = service.RegenerateInviteCode(team.ID, "user1")
+	assert.True(t, errors.Is(err, campaignModel.ErrNotTeamCaptain))
+	regenerated, err := service.RegenerateInviteCode(team.ID, "captain")
+	require.NoError(t, err)
+	assert.NotEqual(t, team.InviteCode, regenerated.InviteCode)
+	_, err = service.JoinTeam(c.ID, "user2", team.InviteCode)
+	assert.True(t, errors.Is(err, campaignModel.ErrInvalidInviteCode))
+}
+
+func TestCampaignService_TeamsFollowPrivateJoinPolicy(t *testing.T) {
+	service, c := setupTeamService(t)
+	c.Visibility = campaignModel.CampaignVisibilityPrivate
+	require.NoError(t, service.UpdateCampaign(c))
+
+	// A team cannot be used to get into a private campaign without an invite.
+	_, err := service.CreateTeam(c.ID, "stranger", "Gatecrashers")
+	assert.True(t, errors.Is(err, campaignModel.ErrInviteRequired))
+
+	team, err := service.CreateTeam(c.ID, "owner", "Hosts")
+	require.NoError(t, err)
+	_, err = service.JoinTeam(c.ID, "stranger", team.InviteCode)
+	assert.True(t, errors.Is(err, campaignModel.ErrInviteRequired))
+
+	isMember, err := service.IsMember(c.ID, "stranger")
+	require.NoError(t, err)
+	assert.False(t, isMember)
+}
+
+func TestCampaignService_LeaveTeam(t *testing.T) {
+	service, c := setupTeamService(t)
+
+	team, err := service.CreateTeam(c.ID, "captain", "Striders")
+	require.NoError(t, err)
+	_, err = service.JoinTeam(c.ID, "user1", team.InviteCode)
+	require.NoError(t, err)
+	_, err = service.JoinTeam(c.ID, "user2", team.InviteCode)
+	require.NoError(t, err)
+
+	assert.True(t, errors.Is(service.LeaveTeam(team.ID, "stranger"), campaignModel.ErrNotTeamMember))
+
+	// The captain leaving hands the team to the longest-standing member.
+	require.NoError(t, service.LeaveTeam(team.ID, "captain"))
+	got, err := service.GetTeamByID(team.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "user1", got.CaptainID)
+	assert.Equal(t, []string{"user1", "user2"}, got.Members)
+
+	// The new captain can remove a member, and the removed member may join another team.
+	assert.True(t, errors.Is(service.RemoveTeamMember(team.ID, "user2", "user1"), campaignModel.ErrNotTeamCaptain))
+	require.NoError(t, service.RemoveTeamMember(team.ID, "user1", "user2"))
+	_, err = service.CreateTeam(c.ID, "user2", "Pacers")
+	require.NoError(t, err)
+
+	// The last member leaving deletes the team.
+	require.NoError(t, service.LeaveTeam(team.ID, "user1"))
+	_, err = service.GetTeamByID(team.ID)
+	assert.Error(t, err)
+}
+
+// barrierUnitOfWork holds each unit of work until n have started, so callers that read before
+// their unit of work all do so before any of them writes.
+type barrierUnitOfWork struct {
+	inner   campaignRepo.UnitOfWork
+	arrived sync.WaitGroup
+}
+
+func (u *barrierUnitOfWork) Do(fn func(repos campaignRepo.Repositories) error) error {
+	u.arrived.Done()
+	u.arrived.Wait()
+	return u.inner.Do(fn)
+}
+
+func TestCampaignService_LeaveTeamConcurrently(t *testing.T) {
+	db := testdb.Open(t, &gormmodel.Campaign{}, &gormmodel.CampaignRunner{}, &gormmodel.SponsorCampaign{},
+		&gormmodel.CampaignMember{}, &gormmodel.CampaignSponsor{}, &gormmodel.CampaignTeam{}, &gormmodel.CampaignTeamMember{})
+
+	campaignRepository := repo.NewGormCampaignRepository(db)
+	teams := repo.NewGormCampaignTeamRepository(db)
+	require.NoError(t, campaignRepository.Create(&campaignModel.Campaign{
+		Base: model.Base{ID: "c"}, Name: "c", OwnerID: "owner", Slug: "c", Status: campaignModel.CampaignStatusActive,
+	}))
+	setup := campaign.NewCampaignService(campaignRepository, repo.NewGormCampaignRunnerRepository(db), repo.NewGormSponsorCampaignRepository(db),
+		campaign.WithUnitOfWork(repo.NewGormUnitOfWork(db)), campaign.WithTeams(teams))
+	team, err := setup.CreateTeam("c", "captain", "Striders")
+	require.NoError(t, err)
+	_, err = setup.JoinTeam("c", "user1", team.InviteCode)
+	require.NoError(t, err)
+
+	uow := &barrierUnitOfWork{inner: repo.NewGormUnitOfWork(db)}
+	uow.arrived.Add(2)
+	service := campaign.NewCampaignService(campaignRepository, repo.NewGormCampaignRunnerRepository(db), repo.NewGormSponsorCampaignRepository(db),
+		campaign.WithUnitOfWork(uow), campaign.WithTeams(teams))
+
+	// Both read two members up front; whichever commits second is the last member and must
+	// delete the team.
+	var wg sync.WaitGroup
+	for _, userID := range []string{"captain", "user1"} {
+		wg.Add(1)
+		go func(userID string) {
+			defer wg.Done()
+			assert.NoError(t, service.LeaveTeam(team.ID, userID))
+		}(userID)
+	}
+	wg.Wait()
+
+	_, err = teams.GetByID(team.ID)
+	assert.Error(t, err)
+}
+
+func TestCampaignService_TeamChangesClosedWithCampaign(t *testing.T) {
+	service, c := setupTeamService(t)
+
+	team, err := service.CreateTeam(c.ID, "captain", "Striders")
+	require.NoError(t, err)
+
+	c.Status = campaignModel.CampaignStatusCompleted
+	require.NoError(t, service.UpdateCampaign(c))
+
+	_, err = service.JoinTeam(c.ID, "user1", team.InviteCode)
+	assert.True(t, errors.Is(err, campaignModel.ErrInvalidCampaignState))
+	assert.True(t, errors.Is(service.LeaveTeam(team.ID, "captain"), campaignModel.ErrInvalidCampaignState))
+}
+
+func TestCampaignService_GetTeamLeaderboard(t *testing.T) {
+	service, c := setupTeamService(t)
+
+	fast, err := service.CreateTeam(c.ID, "a1", "Fast")
+	require.NoError(t, err)
+	_, err = service.JoinTeam(c.ID, "a2", fast.InviteCode)
+	require.NoError(t, err)
+	slow, err := service.CreateTeam(c.ID, "b1", "Slow")
+	require.NoError(t, err)
+	_, err = service.CreateTeam(c.ID, "c1", "Idle")
+	require.NoError(t, err)
+
+	// a1 has two runner rows; both count towards the team, but a1 is one member.
+	require.NoError(t, service.RecordActivity(c.ID, "a1", 5, 30*time.Minute, "Running"))
+	require.NoError(t, service.RecordActivity(c.ID, "a1", 3, 20*time.Minute, "Running"))
+	require.NoError(t, service.RecordActivity(c.ID, "a2", 4, 25*time.Minute, "Running"))
+	require.NoError(t, service.RecordActivity(c.ID, "b1", 6, 40*time.Minute, "Running"))
+	// Runners outside any team do not count.
+	require.NoError(t, service.RecordActivity(c.ID, "solo", 50, 5*time.Hour, "Running"))
+
+	standings, err := service.GetTeamLeaderboard(c.ID)
+	require.NoError(t, err)
+	require.Len(t, standings, 3)
+
+	assert.Equal(t, fast.ID, standings[0].TeamID)
+	assert.Equal(t, 1, standings[0].Rank)
+	assert.Equal(t, 2, standings[0].MemberCount)
+	assert.InDelta(t, 12.0, standings[0].DistanceCovered, 0.001)
+	assert.Equal(t, 75*time.Minute, standings[0].Duration)
+
+	assert.Equal(t, slow.ID, standings[1].TeamID)
+	assert.InDelta(t, 6.0, standings[1].DistanceCovered, 0.001)
+
+	assert.Equal(t, "Idle", standings[2].Name)
+	assert.Equal(t, 1, standings[2].MemberCount)
+	assert.Zero(t, standings[2].DistanceCovered)
+}
+
+func TestCampaignService_TeamsNotEnabled(t *testing.T) {
+	service := campaign.NewCampaignService(new(campaignMocks.MockCampaignRepository),
+		new(campaignMocks.MockCampaignRunnerRepository), new(campaignMocks.MockSponsorCampaignRepository))
+
+	_, err := service.CreateTeam("c", "u", "Team")
+	assert.True(t, errors.Is(err, campaignModel.ErrTeamsNotEnabled))
+	_, err = service.GetTeamLeaderboard("c")
+	assert.True(t, errors.Is(err, campaignModel.ErrTeamsNotEnabled))
+}
+
+func setupCampaignTeamHandlerTest(t *testing.T) (*gin.Engine, *campaignMocks.MockCampaignRepository, *campaignMocks.MockCampaignTeamRepository, *userMocks.MockUserRepository) {
+	gin.SetMode(gin.TestMode)
+
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	mockTeamRepo := new(campaignMocks.MockCampaignTeamRepository)
+	mockUserRepo := new(userMocks.MockUserRepository)
+
+	campaignService := campaign.NewCampaignService(mockCampaignRepo, new(campaignMocks.MockCampaignRunnerRepository),
+		new(campaignMocks.MockSponsorCampaignRepository), campaign.WithTeams(mockTeamRepo))
+	userService := user.NewUserService(mockUserRepo, nil)
+	campaignHandler := handler.NewCampaignHandler(campaignService, userService)
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	protected := router.Group("/campaigns")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", "test-user-id")
+		c.Next()
+	})
+	protected.GET("/:slug/leaderboard/teams", campaignHandler.GetCampaignTeamLeaderboard)
+	protected.POST("/:slug/teams", campaignHandler.CreateCampaignTeam)
+	protected.POST("/:slug/teams/join", campaignHandler.JoinCampaignTeam)
+	protected.GET("/:slug/teams/:team_id", campaignHandler.GetCampaignTeam)
+
+	return router, mockCampaignRepo, mockTeamRepo, mockUserRepo
+}
+
+func TestCampaignHandler_GetCampaignTeamLeaderboard(t *testing.T) {
+	router, mockCampaignRepo, mockTeamRepo, _ := setupCampaignTeamHandlerTest(t)
+
+	mockCampaignRepo.On("GetBySlug", "teams").Return(&campaignModel.Campaign{Base: model.Base{ID: "c1"}, Slug: "teams"}, nil)
+	mockTeamRepo.On("Standings", "c1").Return([]*campaignModel.TeamStanding{
+		{TeamID: "t2", Name: "Slow", MemberCount: 1, DistanceCovered: 3},
+		{TeamID: "t1", Name: "Fast", MemberCount: 2, DistanceCovered: 9, Duration: 61 * time.Minute},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/campaigns/teams/leaderboard/teams", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dto.TeamLeaderboardResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Leaderboard, 2)
+	assert.Equal(t, "t1", response.Leaderboard[0].TeamID)
+	assert.Equal(t, 1, response.Leaderboard[0].Rank)
+	assert.Equal(t, "1:01:00", response.Leaderboard[0].Duration)
+	assert.Equal(t, int64(3660), response.Leaderboard[0].DurationSeconds)
+	assert.Equal(t, 2, response.Leaderboard[1].Rank)
+}
+
+func TestCampaignHandler_PrivateCampaignTeamsHidden(t *testing.T) {
+	router, mockCampaignRepo, mockTeamRepo, _ := setupCampaignTeamHandlerTest(t)
+
+	mockCampaignRepo.On("GetBySlug", "teams").Return(&campaignModel.Campaign{
+		Base: model.Base{ID: "c1"}, Slug: "teams", OwnerID: "owner", Visibility: campaignModel.CampaignVisibilityPrivate,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/campaigns/teams/leaderboard/teams", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockTeamRepo.AssertNotCalled(t, "Standings", "c1")
+}
+
+func TestCampaignHandler_CreateCampaignTeam(t *testing.T) {
+	active := &campaignModel.Campaign{Base: model.Base{ID: "c1"}, Slug: "teams", Status: campaignModel.CampaignStatusActive}
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func(*campaignMocks.MockCampaignRepository, *campaignMocks.MockCampaignTeamRepository, *userMocks.MockUserRepository)
+		expectedStatus int
+	}{
+		{
+			name: "successful creation",
+			body: `{"name":"Striders"}`,
+			setupMocks: func(cr *campaignMocks.MockCampaignRepository, tr *campaignMocks.MockCampaignTeamRepository, ur *userMocks.MockUserRepository) {
+				cr.On("GetBySlug", "teams").Return(active, nil)
+				cr.On("GetByID", "c1").Return(active, nil)
+				cr.On("IsMember", "c1", "test-user-id").Return(true, nil)
+				tr.On("TeamOf", "c1", "test-user-id").Return(nil, nil)
+				tr.On("ListByCampaign", "c1").Return([]*campaignModel.CampaignTeam{}, nil)
+				tr.On("Create", mock.AnythingOfType("*model.CampaignTeam")).Return(nil)
+				tr.On("AddMember", mock.MatchedBy(func(m *campaignModel.CampaignTeamMember) bool {
+					return m.UserID == "test-user-id" && m.CampaignID == "c1"
+				})).Return(nil)
+				ur.On("GetByID", "test-user-id").Return(nil, errors.New("not found"))
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "already in a team",
+			body: `{"name":"Striders"}`,
+			setupMocks: func(cr *campaignMocks.MockCampaignRepository, tr *campaignMocks.MockCampaignTeamRepository, ur *userMocks.MockUserRepository) {
+				cr.On("GetBySlug", "teams").Return(active, nil)
+				cr.On("GetByID", "c1").Return(active, nil)
+				tr.On("TeamOf", "c1", "test-user-id").Return(&campaignModel.CampaignTeam{Base: model.Base{ID: "t1"}}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "missing name",
+			body: `{}`,
+			setupMocks: func(cr *campaignMocks.MockCampaignRepository, tr *campaignMocks.MockCampaignTeamRepository, ur *userMocks.MockUserRepository) {
+				cr.On("GetBySlug", "teams").Return(active, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockCampaignRepo, mockTeamRepo, mockUserRepo := setupCampaignTeamHandlerTest(t)
+			tt.setupMocks(mockCampaignRepo, mockTeamRepo, mockUserRepo)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/campaigns/teams/teams", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var response dto.TeamResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "Striders", response.Name)
+				assert.Equal(t, "test-user-id", response.CaptainID)
+				assert.NotEmpty(t, response.InviteCode)
+			}
+			mockTeamRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCampaignHandler_GetCampaignTeam_HidesInviteCode(t *testing.T) {
+	router, mockCampaignRepo, mockTeamRepo, mockUserRepo := setupCampaignTeamHandlerTest(t)
+
+	mockCampaignRepo.On("GetBySlug", "teams").Return(&campaignModel.Campaign{Base: model.Base{ID: "c1"}, Slug: "teams"}, nil)
+	mockTeamRepo.On("GetByID", "t1").Return(&campaignModel.CampaignTeam{
+		Base: model.Base{ID: "t1"}, CampaignID: "c1", Name: "Striders", CaptainID: "captain",
+		InviteCode: "ABCD2345", Members: []string{"captain"},
+	}, nil)
+	mockTeamRepo.On("GetByID", "other").Return(&campaignModel.CampaignTeam{Base: model.Base{ID: "other"}, CampaignID: "c2"}, nil)
+	mockUserRepo.On("GetByID", "captain").Return(nil, errors.New("not found"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/campaigns/teams/teams/t1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dto.TeamResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Empty(t, response.InviteCode)
+	assert.Equal(t, 1, response.MemberCount)
+
+	// Teams from another campaign are not found under this one.
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/campaigns/teams/teams/other", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,354 @@
+package campaign_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	campaign "gopi.com/internal/app/campaign"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/campaign/model/gorm"
+	"gopi.com/internal/data/campaign/repo"
+	notificationGorm "gopi.com/internal/data/notification/model/gorm"
+	notificationDataRepo "gopi.com/internal/data/notification/repo"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	campaignRepo "gopi.com/internal/domain/campaign/repo"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+func TestCampaignTemplate_Recurrence(t *testing.T) {
+	first := time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 2, 7, 9, 0, 0, 0, time.UTC), campaignModel.RecurrenceWeekly.Next(first))
+	assert.Equal(t, first.AddDate(0, 1, 0), campaignModel.RecurrenceMonthly.Next(first))
+
+	template := &campaignModel.CampaignTemplate{Length: 3 * time.Hour}
+	assert.ErrorIs(t, template.SetRecurrence("daily", &first, 0), campaignModel.ErrInvalidRecurrence)
+	assert.ErrorIs(t, template.SetRecurrence(campaignModel.RecurrenceWeekly, nil, 0), campaignModel.ErrInvalidRecurrence)
+	assert.ErrorIs(t, template.SetRecurrence(campaignModel.RecurrenceWeekly, &first, 8*24*time.Hour), campaignModel.ErrInvalidRecurrence,
+		"a lead longer than the interval would create editions out of order")
+
+	require.NoError(t, template.SetRecurrence(campaignModel.RecurrenceMonthly, &first, 0))
+	assert.Equal(t, campaignModel.DefaultRecurrenceLead, template.Lead)
+	assert.False(t, template.DueAt(first.Add(-8*24*time.Hour)))
+	assert.True(t, template.DueAt(first.Add(-7*24*time.Hour)))
+
+	require.NoError(t, template.SetRecurrence(campaignModel.RecurrenceNone, nil, 0))
+	assert.Nil(t, template.NextStartsAt)
+	assert.False(t, template.DueAt(first))
+
+	_, err := (&campaignModel.CampaignTemplate{}).Shifted(7)
+	assert.ErrorIs(t, err, campaignModel.ErrInvalidCampaignSchedule)
+}
+
+type templateFixture struct {
+	service   *campaign.CampaignService
+	campaigns campaignRepo.CampaignRepository
+	sponsors  campaignRepo.SponsorCampaignRepository
+	templates campaignRepo.CampaignTemplateRepository
+	source    *campaignModel.Campaign
+	emails    *recordingEmailService
+}
+
+// setupTemplateService creates a finished three-hour "Monthly Walk" with a named sponsor, an
+// open sponsorship and a milestone.
+func setupTemplateService(t *testing.T) *templateFixture {
+	db := testdb.Open(t, &gormmodel.Campaign{}, &gormmodel.CampaignRunner{}, &gormmodel.SponsorCampaign{},
+		&gormmodel.CampaignMember{}, &gormmodel.CampaignSponsor{}, &gormmodel.CampaignMilestone{},
+		&gormmodel.CampaignTemplate{}, &notificationGorm.Notification{})
+
+	users := new(userMocks.MockUserRepository)
+	for _, id := range []string{"owner", "acme", "stranger"} {
+		users.On("GetByID", id).Return(&userModel.User{Base: model.Base{ID: id}, Username: id, Email: id + "@example.com"}, nil).Maybe()
+	}
+
+	f := &templateFixture{
+		campaigns: repo.NewGormCampaignRepository(db),
+		sponsors:  repo.NewGormSponsorCampaignRepository(db),
+		templates: repo.NewGormCampaignTemplateRepository(db),
+		emails:    &recordingEmailService{},
+	}
+	milestones := repo.NewGormCampaignMilestoneRepository(db)
+	f.service = campaign.NewCampaignService(f.campaigns, repo.NewGormCampaignRunnerRepository(db), f.sponsors,
+		campaign.WithUnitOfWork(repo.NewGormUnitOfWork(db)),
+		campaign.WithMilestones(milestones, notificationDataRepo.NewGormNotificationRepository(db), users, f.emails),
+		campaign.WithTemplates(f.templates))
+
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+	f.source = &campaignModel.Campaign{
+		Base:            model.Base{ID: "walk-march"},
+		Name:            "Monthly Walk",
+		Description:     "Around the lake",
+		OwnerID:         "owner",
+		Slug:            "owner-monthly-walk-march",
+		Mode:            campaignModel.CampaignMode("Free"),
+		Location:        "Lagos",
+		Status:          campaignModel.CampaignStatusCompleted,
+		DistanceToCover: 100,
+		TargetAmount:    1000,
+		DistanceCovered: 80,
+		MoneyRaised:     300,
+		StartsAt:        &start,
+		EndsAt:          &end,
+	}
+	require.NoError(t, f.campaigns.Create(f.source))
+	require.NoError(t, f.sponsors.Create(&campaignModel.SponsorCampaign{Base: model.Base{ID: "acme-march"}, CampaignID: f.source.ID,
+		Distance: 20, AmountPerKm: 10, TotalAmount: 200, BrandImg: "/uploads/acme.png", Sponsors: campaignModel.UsersByID("acme")}))
+	require.NoError(t, f.sponsors.Create(&campaignModel.SponsorCampaign{Base: model.Base{ID: "open-march"}, CampaignID: f.source.ID,
+		Distance: 10, AmountPerKm: 10, TotalAmount: 100}))
+	require.NoError(t, milestones.ReplacePending(f.source.ID, []*campaignModel.CampaignMilestone{
+		{CampaignID: f.source.ID, Metric: campaignModel.MilestoneMetricMoney, Percent: 25},
+	}))
+	return f
+}
+
+func TestSaveAsTemplate_CreateFromTemplate(t *testing.T) {
+	f := setupTemplateService(t)
+
+	template, err := f.service.SaveAsTemplate(f.source, "owner", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Monthly Walk", template.Name)
+	assert.Equal(t, f.source.ID, template.SourceCampaignID)
+	assert.Equal(t, 3*time.Hour, template.Length)
+	require.Len(t, template.SponsorSlots, 2)
+	require.Len(t, template.Milestones, 1)
+
+	stored, err := f.service.GetTemplate(template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, template.SponsorSlots, stored.SponsorSlots)
+	assert.Equal(t, 3*time.Hour, stored.Length)
+
+	start, err := stored.Shifted(35)
+	require.NoError(t, err)
+	edition, err := f.service.CreateFromTemplate(stored, "owner", start, false)
+	require.NoError(t, err)
+	assert.NotEqual(t, f.source.ID, edition.ID)
+	assert.Equal(t, "Monthly Walk", edition.Name)
+	assert.Equal(t, "Around the lake", edition.Description)
+	assert.Equal(t, time.Date(2025, 4, 5, 9, 0, 0, 0, time.UTC), *edition.StartsAt)
+	assert.Equal(t, time.Date(2025, 4, 5, 12, 0, 0, 0, time.UTC), *edition.EndsAt)
+	assert.Zero(t, edition.DistanceCovered)
+	assert.Zero(t, edition.MoneyRaised)
+
+	sponsorships, err := f.sponsors.GetByCampaignID(edition.ID)
+	require.NoError(t, err)
+	require.Len(t, sponsorships, 2)
+	for _, sponsorship := range sponsorships {
+		assert.Equal(t, campaignModel.SponsorshipPending, sponsorship.Status)
+		assert.False(t, sponsorship.Confirmed())
+	}
+
+	milestones, err := f.service.ListMilestones(edition)
+	require.NoError(t, err)
+	require.Len(t, milestones, 1)
+	assert.Nil(t, milestones[0].ReachedAt)
+
+	stored, err = f.service.GetTemplate(template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.Editions)
+
+	service := campaign.NewCampaignService(nil, nil, nil)
+	_, err = service.SaveAsTemplate(f.source, "owner", "")
+	assert.ErrorIs(t, err, campaignModel.ErrTemplatesNotEnabled)
+}
+
+func TestConfirmSponsorship(t *testing.T) {
+	f := setupTemplateService(t)
+	template, err := f.service.SaveAsTemplate(f.source, "owner", "")
+	require.NoError(t, err)
+	edition, err := f.service.CreateFromTemplate(template, "owner", time.Now().Add(time.Hour), false)
+	require.NoError(t, err)
+
+	sponsorships, err := f.sponsors.GetByCampaignID(edition.ID)
+	require.NoError(t, err)
+	var named, open *campaignModel.SponsorCampaign
+	for _, sponsorship := range sponsorships {
+		if len(sponsorship.Sponsors) > 0 {
+			named = sponsorship
+		} else {
+			open = sponsorship
+		}
+	}
+	require.NotNil(t, named)
+	require.NotNil(t, open)
+
+	_, err = f.service.ConfirmSponsorship(edition.ID, named.ID, "stranger")
+	assert.ErrorIs(t, err, campaignModel.ErrNotSlotSponsor)
+	_, err = f.service.ConfirmSponsorship(f.source.ID, named.ID, "acme")
+	assert.ErrorIs(t, err, campaignModel.ErrSponsorshipNotFound)
+
+	confirmed, err := f.service.ConfirmSponsorship(edition.ID, named.ID, "acme")
+	require.NoError(t, err)
+	assert.True(t, confirmed.Confirmed())
+	_, err = f.service.ConfirmSponsorship(edition.ID, named.ID, "acme")
+	assert.ErrorIs(t, err, campaignModel.ErrSponsorshipNotPending)
+
+	// An open slot goes to whoever confirms it.
+	confirmed, err = f.service.ConfirmSponsorship(edition.ID, open.ID, "stranger")
+	require.NoError(t, err)
+	assert.Equal(t, campaignModel.UsersByID("stranger"), confirmed.Sponsors)
+
+	updated, err := f.campaigns.GetByID(edition.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 300.0, updated.MoneyRaised)
+	isSponsor, err := f.campaigns.IsSponsor(edition.ID, "acme")
+	require.NoError(t, err)
+	assert.True(t, isSponsor)
+
+	// The 25% money milestone fired once the pledges counted.
+	require.Len(t, f.emails.sent, 1)
+	assert.Equal(t, "Monthly Walk reached 25% of the fundraising target", f.emails.sent[0].Subject)
+}
+
+func TestCreateDueEditions(t *testing.T) {
+	f := setupTemplateService(t)
+	template, err := f.service.SaveAsTemplate(f.source, "owner", "")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	first := time.Date(2025, 5, 5, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, template.SetRecurrence(campaignModel.RecurrenceMonthly, &first, 0))
+	require.NoError(t, f.service.UpdateTemplate(template))
+
+	// Several schedulers ticking at once create the edition once.
+	const workers = 5
+	var wg sync.WaitGroup
+	counts := make(chan int, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			created, err := f.service.CreateDueEditions(now)
+			assert.NoError(t, err)
+			counts <- created
+		}()
+	}
+	wg.Wait()
+	close(counts)
+	total := 0
+	for created := range counts {
+		total += created
+	}
+	assert.Equal(t, 1, total)
+
+	stored, err := f.service.GetTemplate(template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 5, 9, 0, 0, 0, time.UTC), stored.NextStartsAt.UTC())
+	assert.Equal(t, 1, stored.Editions)
+
+	editions, err := f.campaigns.GetByOwnerID("owner")
+	require.NoError(t, err)
+	require.Len(t, editions, 2)
+
+	// Not due again until a week before June's edition.
+	created, err := f.service.CreateDueEditions(now.AddDate(0, 0, 10))
+	require.NoError(t, err)
+	assert.Zero(t, created)
+
+	// After a long outage, editions that would already be over are skipped.
+	created, err = f.service.CreateDueEditions(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Zero(t, created)
+	stored, err = f.service.GetTemplate(template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 7, 5, 9, 0, 0, 0, time.UTC), stored.NextStartsAt.UTC())
+	assert.Equal(t, 1, stored.Editions)
+}
+
+func TestCampaignHandler_Templates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := setupTemplateService(t)
+	users := new(userMocks.MockUserRepository)
+	users.On("GetByID", "owner").Return(&userModel.User{Base: model.Base{ID: "owner"}, Username: "owner"}, nil).Maybe()
+	campaignHandler := handler.NewCampaignHandler(f.service, user.NewUserService(users, nil))
+
+	serve := func(userID, method, path, body string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+			c.Next()
+		})
+		router.POST("/campaigns/:slug/template", campaignHandler.SaveCampaignTemplate)
+		router.GET("/campaigns/templates", campaignHandler.ListCampaignTemplates)
+		router.PUT("/campaigns/templates/:template_id", campaignHandler.UpdateCampaignTemplate)
+		router.POST("/campaigns/templates/:template_id/campaigns", campaignHandler.CreateCampaignFromTemplate)
+		router.GET("/campaigns/:slug/sponsorships", campaignHandler.ListCampaignSponsorships)
+		router.POST("/campaigns/:slug/sponsorships/:sponsorship_id/confirm", campaignHandler.ConfirmCampaignSponsorship)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusForbidden, serve("acme", http.MethodPost, "/campaigns/owner-monthly-walk-march/template", "").Code)
+	w := serve("owner", http.MethodPost, "/campaigns/owner-monthly-walk-march/template", `{"name":"Lake walk"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var template dto.CampaignTemplateResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &template))
+	assert.Equal(t, "Lake walk", template.Name)
+	assert.Equal(t, int64(3*60*60), template.LengthSeconds)
+	assert.Equal(t, "none", template.Recurrence)
+	require.Len(t, template.SponsorSlots, 2)
+
+	w = serve("owner", http.MethodGet, "/campaigns/templates", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var list dto.CampaignTemplateListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 1, list.Count)
+
+	templatePath := "/campaigns/templates/" + template.ID
+	assert.Equal(t, http.StatusBadRequest, serve("owner", http.MethodPut, templatePath, `{"recurrence":"daily"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve("owner", http.MethodPut, templatePath, `{"recurrence":"weekly"}`).Code,
+		"a schedule needs its first start")
+	w = serve("owner", http.MethodPut, templatePath, `{"recurrence":"weekly","next_starts_at":"2030-01-05T09:00:00Z","lead_hours":48}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &template))
+	assert.Equal(t, "weekly", template.Recurrence)
+	assert.Equal(t, 48, template.LeadHours)
+
+	assert.Equal(t, http.StatusNotFound, serve("acme", http.MethodPost, templatePath+"/campaigns", `{"shift_days":7}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve("owner", http.MethodPost, templatePath+"/campaigns", `{}`).Code)
+	w = serve("owner", http.MethodPost, templatePath+"/campaigns", `{"shift_days":7}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var edition dto.CampaignResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &edition))
+	assert.Equal(t, "Lake walk", edition.Name)
+
+	// Generated slugs contain spaces.
+	slug := url.PathEscape(edition.Slug)
+	w = serve("acme", http.MethodGet, "/campaigns/"+slug+"/sponsorships", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var sponsorships dto.CampaignSponsorshipListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &sponsorships))
+	require.Equal(t, 2, sponsorships.Count)
+	var slotID string
+	for _, sponsorship := range sponsorships.Sponsorships {
+		assert.Equal(t, "pending", sponsorship.Status)
+		if sponsorship.Sponsor == "acme" {
+			slotID = sponsorship.ID
+		}
+	}
+	require.NotEmpty(t, slotID)
+
+	confirmPath := fmt.Sprintf("/campaigns/%s/sponsorships/%s/confirm", slug, slotID)
+	assert.Equal(t, http.StatusForbidden, serve("stranger", http.MethodPost, confirmPath, "").Code)
+	w = serve("acme", http.MethodPost, confirmPath, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"status":"confirmed"`)
+	assert.Equal(t, http.StatusConflict, serve("acme", http.MethodPost, confirmPath, "").Code)
+}
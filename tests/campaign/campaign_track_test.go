@@ -0,0 +1,148 @@
+package campaign_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	"gopi.com/internal/app/campaign"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/storage"
+	campaignMocks "gopi.com/tests/mocks/campaign"
+)
+
+const campaignTrackGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk><trkseg>
+    <trkpt lat="0" lon="0"><ele>10</ele><time>2024-05-01T07:00:00Z</time></trkpt>
+    <trkpt lat="0.0045" lon="0"><ele>14</ele><time>2024-05-01T07:02:30Z</time></trkpt>
+    <trkpt lat="0.0090" lon="0"><ele>20</ele><time>2024-05-01T07:05:00Z</time></trkpt>
+  </trkseg></trk>
+</gpx>`
+
+func setupCampaignTrackTest(t *testing.T) (*gin.Engine, *campaignMocks.MockCampaignRepository, *campaignMocks.MockCampaignRunnerRepository, string) {
+	gin.SetMode(gin.TestMode)
+
+	mockCampaignRepo := new(campaignMocks.MockCampaignRepository)
+	mockRunnerRepo := new(campaignMocks.MockCampaignRunnerRepository)
+	mockSponsorRepo := new(campaignMocks.MockSponsorCampaignRepository)
+	campaignService := campaign.NewCampaignService(mockCampaignRepo, mockRunnerRepo, mockSponsorRepo)
+
+	uploads := t.TempDir()
+	st := storage.NewLocalStorage(uploads, "/uploads")
+	trackHandler := handler.NewTrackHandler(campaignService, nil, st)
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+	protected := router.Group("/campaigns")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", "test-user-id")
+		c.Next()
+	})
+	protected.POST("/:slug/finish_campaign/:runner_id/track", trackHandler.UploadCampaignRunTrack)
+
+	return router, mockCampaignRepo, mockRunnerRepo, uploads
+}
+
+func trackUploadRequest(t *testing.T, url, filename, content string) *http.Request {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, url, &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestTrackHandler_UploadCampaignRunTrack(t *testing.T) {
+	activeCampaign := &campaignModel.Campaign{
+		Base:    model.Base{ID: "campaign123"},
+		Slug:    "test-campaign-slug",
+		OwnerID: "owner",
+		Status:  campaignModel.CampaignStatusActive,
+	}
+
+	t.Run("credits the computed distance and stores the route", func(t *testing.T) {
+		router, mockCampaignRepo, mockRunnerRepo, uploads := setupCampaignTrackTest(t)
+		runner := &campaignModel.CampaignRunner{Base: model.Base{ID: "runner123"}, CampaignID: "campaign123", OwnerID: "test-user-id"}
+
+		mockCampaignRepo.On("GetBySlug", "test-campaign-slug").Return(activeCampaign, nil)
+		mockCampaignRepo.On("GetByID", "campaign123").Return(activeCampaign, nil)
+		mockRunnerRepo.On("GetByID", "runner123").Return(runner, nil)
+		var credited float64
+		mockRunnerRepo.On("IncrementProgress", "runner123", mock.AnythingOfType("float64"), 0.0, 5*time.Minute).
+			Run(func(args mock.Arguments) { credited = args.Get(1).(float64) }).Return(nil)
+		mockRunnerRepo.On("AttachTrack", "runner123", mock.MatchedBy(func(url string) bool {
+			return strings.HasPrefix(url, "/uploads/tracks/campaign-runners/runner123-")
+		}), 10.0).Return(nil)
+		mockCampaignRepo.On("IncrementTotals", "campaign123", mock.AnythingOfType("float64"), 0.0).Return(nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, trackUploadRequest(t, "/campaigns/test-campaign-slug/finish_campaign/runner123/track", "run.gpx", campaignTrackGPX))
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp dto.TrackUploadResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "gpx", resp.Format)
+		assert.InDelta(t, 1.0, resp.Distance, 0.001)
+		assert.InDelta(t, resp.Distance, credited, 1e-9)
+		assert.Equal(t, int64(300), resp.MovingTime)
+		assert.Equal(t, "05:00", resp.Duration)
+		assert.Equal(t, 10.0, resp.ElevationGain)
+		require.Len(t, resp.Splits, 1)
+		assert.Equal(t, "LineString", resp.Route.Geometry.Type)
+		assert.Len(t, resp.Route.Geometry.Coordinates, 2, "collinear midpoint is simplified away")
+
+		stored, err := os.ReadFile(filepath.Join(uploads, strings.TrimPrefix(resp.TrackURL, "/uploads/")))
+		require.NoError(t, err)
+		var feature dto.GeoJSONFeature
+		require.NoError(t, json.Unmarshal(stored, &feature))
+		assert.Equal(t, resp.Route.Geometry, feature.Geometry)
+
+		mockRunnerRepo.AssertExpectations(t)
+		mockCampaignRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects files that are not GPX or TCX", func(t *testing.T) {
+		router, mockCampaignRepo, mockRunnerRepo, _ := setupCampaignTrackTest(t)
+		runner := &campaignModel.CampaignRunner{Base: model.Base{ID: "runner123"}, CampaignID: "campaign123", OwnerID: "test-user-id"}
+		mockCampaignRepo.On("GetBySlug", "test-campaign-slug").Return(activeCampaign, nil)
+		mockRunnerRepo.On("GetByID", "runner123").Return(runner, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, trackUploadRequest(t, "/campaigns/test-campaign-slug/finish_campaign/runner123/track", "run.kml", "<kml/>"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRunnerRepo.AssertNotCalled(t, "IncrementProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects another user's runner", func(t *testing.T) {
+		router, mockCampaignRepo, mockRunnerRepo, _ := setupCampaignTrackTest(t)
+		runner := &campaignModel.CampaignRunner{Base: model.Base{ID: "runner123"}, CampaignID: "campaign123", OwnerID: "someone-else"}
+		mockCampaignRepo.On("GetBySlug", "test-campaign-slug").Return(activeCampaign, nil)
+		mockRunnerRepo.On("GetByID", "runner123").Return(runner, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, trackUploadRequest(t, "/campaigns/test-campaign-slug/finish_campaign/runner123/track", "run.gpx", campaignTrackGPX))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
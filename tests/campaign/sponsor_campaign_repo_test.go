@@ -12,6 +12,7 @@ import (
 
 	gormmodel "gopi.com/internal/data/campaign/model/gorm"
 	"gopi.com/internal/data/campaign/repo"
+	userGorm "gopi.com/internal/data/user/model/gorm"
 	campaignModel "gopi.com/internal/domain/campaign/model"
 	"gopi.com/internal/domain/model"
 )
@@ -67,7 +68,7 @@ func TestGormSponsorCampaignRepository_Create(t *testing.T) {
 				TotalAmount: 50.0,
 				BrandImg:    "brand.jpg",
 				VideoUrl:    "video.mp4",
-				Sponsors:    []interface{}{"user1", "user2"},
+				Sponsors:    campaignModel.UsersByID("user1", "user2"),
 			},
 			expectedErr: nil,
 		},
@@ -83,7 +84,7 @@ func TestGormSponsorCampaignRepository_Create(t *testing.T) {
 				Distance:    5.0,
 				AmountPerKm: 2.0,
 				TotalAmount: 10.0,
-				Sponsors:    []interface{}{"user3"},
+				Sponsors:    campaignModel.UsersByID("user3"),
 			},
 			expectedErr: nil,
 		},
@@ -99,7 +100,7 @@ func TestGormSponsorCampaignRepository_Create(t *testing.T) {
 				Distance:    15.0,
 				AmountPerKm: 3.0,
 				TotalAmount: 45.0,
-				Sponsors:    []interface{}{},
+				Sponsors:    []campaignModel.UserSummary{},
 			},
 			expectedErr: nil,
 		},
@@ -128,7 +129,7 @@ func TestGormSponsorCampaignRepository_Create(t *testing.T) {
 				assert.Equal(t, tt.sponsor.VideoUrl, dbSponsor.VideoUrl)
 
 				// Verify sponsors JSON was stored correctly
-				var sponsors []interface{}
+				var sponsors []campaignModel.UserSummary
 				if dbSponsor.Sponsors != "" {
 					err := json.Unmarshal([]byte(dbSponsor.Sponsors), &sponsors)
 					assert.NoError(t, err)
@@ -207,8 +208,8 @@ func TestGormSponsorCampaignRepository_GetByID(t *testing.T) {
 				assert.Equal(t, "brand.jpg", result.BrandImg)
 				assert.Equal(t, "video.mp4", result.VideoUrl)
 				assert.Len(t, result.Sponsors, 2)
-				assert.Contains(t, result.Sponsors, "user1")
-				assert.Contains(t, result.Sponsors, "user2")
+				assert.Contains(t, campaignModel.UserIDs(result.Sponsors), "user1")
+				assert.Contains(t, campaignModel.UserIDs(result.Sponsors), "user2")
 			}
 		})
 	}
@@ -391,7 +392,7 @@ func TestGormSponsorCampaignRepository_Update(t *testing.T) {
 				TotalAmount: 90.0,
 				BrandImg:    "updated-brand.jpg",
 				VideoUrl:    "updated-video.mp4",
-				Sponsors:    []interface{}{"user3", "user4", "user5"},
+				Sponsors:    campaignModel.UsersByID("user3", "user4", "user5"),
 			},
 			expectedErr: nil,
 		},
@@ -410,7 +411,7 @@ func TestGormSponsorCampaignRepository_Update(t *testing.T) {
 				TotalAmount: 80.0,
 				BrandImg:    "partially-updated.jpg",
 				VideoUrl:    "",
-				Sponsors:    []interface{}{"user6"},
+				Sponsors:    campaignModel.UsersByID("user6"),
 			},
 			expectedErr: nil,
 		},
@@ -437,7 +438,7 @@ func TestGormSponsorCampaignRepository_Update(t *testing.T) {
 				assert.Equal(t, tt.updateData.VideoUrl, dbSponsor.VideoUrl)
 
 				// Verify sponsors JSON was updated correctly
-				var sponsors []interface{}
+				var sponsors []campaignModel.UserSummary
 				if dbSponsor.Sponsors != "" {
 					err := json.Unmarshal([]byte(dbSponsor.Sponsors), &sponsors)
 					assert.NoError(t, err)
@@ -535,30 +536,24 @@ func TestGormSponsorCampaignRepository_JSONHandling(t *testing.T) {
 	err := db.Create(campaign).Error
 	assert.NoError(t, err)
 
-	t.Run("handle complex sponsors array", func(t *testing.T) {
-		sponsor := &campaignModel.SponsorCampaign{
-			Base: model.Base{
-				ID:        "complex-sponsor-id",
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			},
+	t.Run("handle legacy sponsors array", func(t *testing.T) {
+		// Older releases stored bare IDs, and sometimes values that are not users at all
+		err := db.Create(&gormmodel.SponsorCampaign{
+			ID:          "complex-sponsor-id",
 			CampaignID:  "test-campaign-id",
 			Distance:    25.0,
 			AmountPerKm: 8.0,
 			TotalAmount: 200.0,
-			BrandImg:    "complex-brand.jpg",
-			VideoUrl:    "complex-video.mp4",
-			Sponsors:    []interface{}{"user1", "user2", "user3", 123, true}, // Mixed types
-		}
-
-		err := repo.Create(sponsor)
+			Sponsors:    `["user1", {"id": "user2", "username": "stale"}, 123, true, "", {"name": "no id"}]`,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}).Error
 		assert.NoError(t, err)
 
-		// Retrieve and verify
 		retrieved, err := repo.GetByID("complex-sponsor-id")
 		assert.NoError(t, err)
-		assert.Equal(t, sponsor.Sponsors, retrieved.Sponsors)
-		assert.Len(t, retrieved.Sponsors, 5)
+		assert.Equal(t, []string{"user1", "user2"}, campaignModel.UserIDs(retrieved.Sponsors))
+		assert.Empty(t, retrieved.Sponsors[1].Username, "names come from the users table, not the stored list")
 	})
 
 	t.Run("handle empty sponsors array", func(t *testing.T) {
@@ -572,7 +567,7 @@ func TestGormSponsorCampaignRepository_JSONHandling(t *testing.T) {
 			Distance:    5.0,
 			AmountPerKm: 2.0,
 			TotalAmount: 10.0,
-			Sponsors:    []interface{}{},
+			Sponsors:    []campaignModel.UserSummary{},
 		}
 
 		err := repo.Create(sponsor)
@@ -624,7 +619,7 @@ func TestGormSponsorCampaignRepository_ErrorScenarios(t *testing.T) {
 			Distance:    10.0,
 			AmountPerKm: 5.0,
 			TotalAmount: 50.0,
-			Sponsors:    []interface{}{"user1"},
+			Sponsors:    campaignModel.UsersByID("user1"),
 		}
 
 		err := repo.Create(sponsor)
@@ -653,7 +648,7 @@ func TestGormSponsorCampaignRepository_ErrorScenarios(t *testing.T) {
 			Distance:    10.0,
 			AmountPerKm: 5.0,
 			TotalAmount: 50.0,
-			Sponsors:    []interface{}{"user1"},
+			Sponsors:    campaignModel.UsersByID("user1"),
 		}
 
 		err := repo.Update(sponsor)
@@ -667,3 +662,74 @@ func TestGormSponsorCampaignRepository_ErrorScenarios(t *testing.T) {
 		assert.Equal(t, "nonexistent-sponsor", retrieved.ID)
 	})
 }
+
+func TestGormSponsorCampaignRepository_SponsorNames(t *testing.T) {
+	db := setupSponsorCampaignTestDB(t)
+	sponsorRepo := repo.NewGormSponsorCampaignRepository(db)
+	campaignRepo := repo.NewGormCampaignRepository(db)
+
+	assert.NoError(t, db.Create(&userGorm.UserGORM{ID: "acme", Username: "acme", FirstName: "Acme", LastName: "Ltd",
+		Email: "acme@example.com", Password: "x"}).Error)
+	campaign := &campaignModel.Campaign{Name: "Named", Slug: "named", OwnerID: "acme",
+		Members: campaignModel.UsersByID("acme"), Sponsors: campaignModel.UsersByID("acme")}
+	assert.NoError(t, campaignRepo.Create(campaign))
+
+	sponsor := &campaignModel.SponsorCampaign{CampaignID: campaign.ID, Distance: 5, AmountPerKm: 1,
+		Sponsors: []campaignModel.UserSummary{{ID: "acme", Username: "typed-by-client"}, {ID: "gone"}}}
+	assert.NoError(t, sponsorRepo.Create(sponsor))
+
+	var stored gormmodel.SponsorCampaign
+	assert.NoError(t, db.First(&stored, "id = ?", sponsor.ID).Error)
+	assert.JSONEq(t, `[{"id":"acme"},{"id":"gone"}]`, stored.Sponsors, "only IDs are stored")
+
+	listed, err := sponsorRepo.GetByCampaignID(campaign.ID)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+	assert.Equal(t, []campaignModel.UserSummary{{ID: "acme", Username: "acme", FullName: "Acme Ltd"}, {ID: "gone"}}, listed[0].Sponsors)
+
+	got, err := campaignRepo.GetByID(campaign.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []campaignModel.UserSummary{{ID: "acme", Username: "acme", FullName: "Acme Ltd"}}, got.Members)
+	assert.Equal(t, got.Members, got.Sponsors)
+}
+
+func TestMigrateSponsorLists(t *testing.T) {
+	db := setupSponsorCampaignTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&gormmodel.SponsorObligation{}, &gormmodel.CampaignTemplate{}))
+
+	rows := []gormmodel.SponsorCampaign{
+		{ID: "legacy", CampaignID: "c", Sponsors: `["user1", 123, {"user_id": "x"}, "user2"]`},
+		{ID: "typed", CampaignID: "c", Sponsors: `[{"id":"user3"}]`},
+		{ID: "empty", CampaignID: "c", Sponsors: `[]`},
+	}
+	assert.NoError(t, db.Create(&rows).Error)
+	assert.NoError(t, db.Create(&gormmodel.SponsorObligation{ID: "o1", CampaignID: "c", SponsorCampaignID: "legacy",
+		Sponsors: `["user1"]`}).Error)
+	assert.NoError(t, db.Create(&gormmodel.CampaignTemplate{ID: "t1", OwnerID: "o", Name: "t",
+		SponsorSlots: `[{"distance":10,"amount_per_km":2,"sponsors":["user1"]},{"distance":5,"amount_per_km":1}]`}).Error)
+
+	assert.NoError(t, gormmodel.MigrateSponsorLists(db))
+	// Running again is a no-op
+	assert.NoError(t, gormmodel.MigrateSponsorLists(db))
+
+	stored := map[string]string{}
+	var all []gormmodel.SponsorCampaign
+	assert.NoError(t, db.Find(&all).Error)
+	for _, sc := range all {
+		stored[sc.ID] = sc.Sponsors
+	}
+	assert.Equal(t, `[{"id":"user1"},{"id":"user2"}]`, stored["legacy"])
+	assert.Equal(t, `[{"id":"user3"}]`, stored["typed"])
+	assert.Equal(t, "", stored["empty"])
+
+	var obligation gormmodel.SponsorObligation
+	assert.NoError(t, db.First(&obligation, "id = ?", "o1").Error)
+	assert.Equal(t, `[{"id":"user1"}]`, obligation.Sponsors)
+
+	var template gormmodel.CampaignTemplate
+	assert.NoError(t, db.First(&template, "id = ?", "t1").Error)
+	slots := gormmodel.ToDomainCampaignTemplate(&template).SponsorSlots
+	assert.Len(t, slots, 2)
+	assert.Equal(t, campaignModel.UsersByID("user1"), slots[0].Sponsors)
+	assert.Empty(t, slots[1].Sponsors)
+}
@@ -0,0 +1,267 @@
+package certificate_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	"gopi.com/internal/app/certificate"
+	campaignGorm "gopi.com/internal/data/campaign/model/gorm"
+	campaignDataRepo "gopi.com/internal/data/campaign/repo"
+	certificateGorm "gopi.com/internal/data/certificate/model/gorm"
+	certificateDataRepo "gopi.com/internal/data/certificate/repo"
+	challengeGorm "gopi.com/internal/data/challenge/model/gorm"
+	challengeDataRepo "gopi.com/internal/data/challenge/repo"
+	activityModel "gopi.com/internal/domain/activity/model"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	certificateModel "gopi.com/internal/domain/certificate/model"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	"gopi.com/internal/lib/storage"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+type certificateFixture struct {
+	service    *certificate.CertificateService
+	uploadsDir string
+}
+
+func pngLogo(t *testing.T) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 4))
+	img.Set(1, 1, color.NRGBA{B: 255, A: 255})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// setupCertificateService seeds a completed campaign with one runner, an active campaign, and
+// a cause with a finished, an unfinished and a flagged runner.
+func setupCertificateService(t *testing.T) *certificateFixture {
+	db := testdb.Open(t, &campaignGorm.Campaign{}, &campaignGorm.CampaignRunner{}, &campaignGorm.SponsorCampaign{},
+		&campaignGorm.CampaignSponsor{}, &campaignGorm.CampaignMember{}, &challengeGorm.Cause{}, &challengeGorm.CauseMember{}, &challengeGorm.CauseRunner{}, &challengeGorm.SponsorCause{},
+		&certificateGorm.Certificate{})
+
+	uploadsDir := filepath.Join(t.TempDir(), "uploads")
+	require.NoError(t, os.MkdirAll(filepath.Join(uploadsDir, "sponsors"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(uploadsDir, "sponsors", "acme.png"), pngLogo(t), 0o644))
+
+	campaigns := campaignDataRepo.NewGormCampaignRepository(db)
+	campaignRunners := campaignDataRepo.NewGormCampaignRunnerRepository(db)
+	campaignSponsors := campaignDataRepo.NewGormSponsorCampaignRepository(db)
+	causes := challengeDataRepo.NewGormCauseRepository(db)
+	causeRunners := challengeDataRepo.NewGormCauseRunnerRepository(db)
+	causeSponsors := challengeDataRepo.NewGormSponsorCauseRepository(db)
+
+	require.NoError(t, campaigns.Create(&campaignModel.Campaign{Base: model.Base{ID: "done"}, Name: "City Run", Slug: "city-run",
+		OwnerID: "owner", Status: campaignModel.CampaignStatusCompleted}))
+	require.NoError(t, campaigns.Create(&campaignModel.Campaign{Base: model.Base{ID: "live"}, Name: "Live Run", Slug: "live-run",
+		OwnerID: "owner", Status: campaignModel.CampaignStatusActive}))
+	require.NoError(t, campaignRunners.Create(&campaignModel.CampaignRunner{Base: model.Base{ID: "cr1"}, CampaignID: "done",
+		OwnerID: "ada", DistanceCovered: 12.5, Duration: 75 * time.Minute}))
+	require.NoError(t, campaignRunners.Create(&campaignModel.CampaignRunner{Base: model.Base{ID: "cr2"}, CampaignID: "live",
+		OwnerID: "ada", DistanceCovered: 3}))
+	require.NoError(t, campaignSponsors.Create(&campaignModel.SponsorCampaign{Base: model.Base{ID: "s1"}, CampaignID: "done",
+		Distance: 10, AmountPerKm: 1, BrandImg: "/uploads/sponsors/acme.png"}))
+	require.NoError(t, campaignSponsors.Create(&campaignModel.SponsorCampaign{Base: model.Base{ID: "s2"}, CampaignID: "done",
+		Distance: 10, AmountPerKm: 1, BrandImg: "http://169.254.169.254/latest/meta-data"}))
+
+	require.NoError(t, causes.Create(&challengeModel.Cause{Base: model.Base{ID: "cause1"}, Name: "Clean Water", Slug: "clean-water", OwnerID: "owner"}))
+	for _, runner := range []*challengeModel.CauseRunner{
+		{Base: model.Base{ID: "run-done"}, CauseID: "cause1", OwnerID: "ada", DistanceToCover: 5, DistanceCovered: 5.2},
+		{Base: model.Base{ID: "run-short"}, CauseID: "cause1", OwnerID: "ada", DistanceToCover: 5, DistanceCovered: 4},
+		{Base: model.Base{ID: "run-flagged"}, CauseID: "cause1", OwnerID: "ada", DistanceCovered: 8, Status: activityModel.ReviewStatusFlagged},
+	} {
+		require.NoError(t, causeRunners.Create(runner))
+	}
+
+	users := new(userMocks.MockUserRepository)
+	users.On("GetByID", "ada").Return(&userModel.User{Base: model.Base{ID: "ada"}, Username: "ada", FirstName: "Ada", LastName: "Lovelace"}, nil)
+	users.On("GetByID", "bob").Return(nil, errors.New("not found")).Maybe()
+
+	st := storage.NewLocalStorage(uploadsDir, "/uploads")
+	service := certificate.NewCertificateService(certificateDataRepo.NewGormCertificateRepository(db), users, st,
+		"https://example.com/api/certificates",
+		certificate.WithCampaigns(campaigns, campaignRunners, campaignSponsors),
+		certificate.WithCauses(causes, causeRunners, causeSponsors),
+		certificate.WithLogoLoader(certificate.NewLogoLoader("/uploads", uploadsDir)))
+	return &certificateFixture{service: service, uploadsDir: uploadsDir}
+}
+
+func TestIssueCampaignCertificate(t *testing.T) {
+	f := setupCertificateService(t)
+	ctx := context.Background()
+
+	cert, err := f.service.IssueCampaignCertificate(ctx, "ada", "cr1")
+	require.NoError(t, err)
+	assert.Equal(t, certificateModel.KindCampaign, cert.Kind)
+	assert.Equal(t, "done", cert.SubjectID)
+	assert.Equal(t, "City Run", cert.SubjectName)
+	assert.Equal(t, "Ada Lovelace", cert.RunnerName)
+	assert.Equal(t, 12.5, cert.Distance)
+	assert.Equal(t, 75*time.Minute, cert.Duration)
+	assert.Equal(t, "/uploads/certificates/"+cert.ID+".pdf", cert.FileURL)
+	assert.Equal(t, "https://example.com/api/certificates/"+cert.ID+"/verify", f.service.VerifyURL(cert.ID))
+
+	data, err := os.ReadFile(filepath.Join(f.uploadsDir, "certificates", cert.ID+".pdf"))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	assert.Contains(t, string(data), "(Ada Lovelace) Tj")
+	assert.Contains(t, string(data), "(City Run) Tj")
+	assert.Contains(t, string(data), "(12.50 km) Tj")
+	assert.Contains(t, string(data), "(1:15:00) Tj")
+	// The uploaded logo is printed; the metadata address is never fetched.
+	assert.Equal(t, 1, bytes.Count(data, []byte("/Subtype /Image")))
+
+	// Issuing again returns the stored certificate.
+	again, err := f.service.IssueCampaignCertificate(ctx, "ada", "cr1")
+	require.NoError(t, err)
+	assert.Equal(t, cert.ID, again.ID)
+
+	found, err := f.service.GetCertificate(cert.ID)
+	require.NoError(t, err)
+	assert.Equal(t, cert.RunnerName, found.RunnerName)
+	listed, err := f.service.ListUserCertificates("ada", 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+}
+
+func TestIssueCampaignCertificate_Rejected(t *testing.T) {
+	f := setupCertificateService(t)
+	ctx := context.Background()
+
+	_, err := f.service.IssueCampaignCertificate(ctx, "bob", "cr1")
+	assert.ErrorIs(t, err, certificateModel.ErrNotRunnerOwner)
+	_, err = f.service.IssueCampaignCertificate(ctx, "ada", "cr2")
+	assert.ErrorIs(t, err, certificateModel.ErrNotCompleted)
+	_, err = f.service.IssueCampaignCertificate(ctx, "ada", "missing")
+	assert.ErrorIs(t, err, certificateModel.ErrRunnerNotFound)
+
+	_, err = f.service.GetCertificate("missing")
+	assert.ErrorIs(t, err, certificateModel.ErrCertificateNotFound)
+}
+
+func TestIssueCauseCertificate(t *testing.T) {
+	f := setupCertificateService(t)
+	ctx := context.Background()
+
+	cert, err := f.service.IssueCauseCertificate(ctx, "ada", "run-done")
+	require.NoError(t, err)
+	assert.Equal(t, certificateModel.KindCause, cert.Kind)
+	assert.Equal(t, "Clean Water", cert.SubjectName)
+
+	_, err = f.service.IssueCauseCertificate(ctx, "ada", "run-short")
+	assert.ErrorIs(t, err, certificateModel.ErrNotCompleted)
+	_, err = f.service.IssueCauseCertificate(ctx, "ada", "run-flagged")
+	assert.ErrorIs(t, err, certificateModel.ErrNotCompleted)
+
+	// The same runner ID under the other kind is a different run.
+	_, err = f.service.IssueCampaignCertificate(ctx, "ada", "run-done")
+	assert.ErrorIs(t, err, certificateModel.ErrRunnerNotFound)
+}
+
+func TestIssueCertificate_KindNotEnabled(t *testing.T) {
+	service := certificate.NewCertificateService(nil, nil, nil, "https://example.com/api/certificates")
+	_, err := service.IssueCampaignCertificate(context.Background(), "ada", "cr1")
+	assert.ErrorIs(t, err, certificateModel.ErrKindNotEnabled)
+	_, err = service.IssueCauseCertificate(context.Background(), "ada", "run-done")
+	assert.ErrorIs(t, err, certificateModel.ErrKindNotEnabled)
+}
+
+func setupCertificateRouter(t *testing.T, userID string) (*gin.Engine, *certificateFixture) {
+	gin.SetMode(gin.TestMode)
+	f := setupCertificateService(t)
+	certificateHandler := handler.NewCertificateHandler(f.service)
+
+	router := gin.New()
+	router.GET("/api/certificates/:id/verify", certificateHandler.VerifyCertificate)
+	protected := router.Group("/api/certificates")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Next()
+	})
+	protected.GET("", certificateHandler.ListMyCertificates)
+	protected.POST("/campaign-runners/:runner_id", certificateHandler.IssueCampaignCertificate)
+	protected.POST("/cause-runners/:runner_id", certificateHandler.IssueCauseCertificate)
+	return router, f
+}
+
+func TestCertificateHandler_IssueAndVerify(t *testing.T) {
+	router, _ := setupCertificateRouter(t, "ada")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/certificates/campaign-runners/cr1", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var issued dto.CertificateResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &issued))
+	assert.Equal(t, "campaign", issued.Kind)
+	assert.Equal(t, "1:15:00", issued.Duration)
+	assert.Equal(t, int64(4500), issued.DurationSeconds)
+	assert.Equal(t, "https://example.com/api/certificates/"+issued.ID+"/verify", issued.VerifyURL)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/certificates/"+issued.ID+"/verify", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var verified dto.CertificateVerificationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &verified))
+	assert.True(t, verified.Valid)
+	assert.Equal(t, "Ada Lovelace", verified.Certificate.RunnerName)
+	assert.Equal(t, issued.FileURL, verified.Certificate.FileURL)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/certificates", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var listed dto.CertificateListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Equal(t, 1, listed.Count)
+}
+
+func TestCertificateHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		method         string
+		path           string
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{name: "unknown certificate", userID: "ada", method: http.MethodGet, path: "/api/certificates/forged/verify",
+			expectedStatus: http.StatusNotFound, expectedMsg: certificateModel.ErrCertificateNotFound.Error()},
+		{name: "someone else's run", userID: "bob", method: http.MethodPost, path: "/api/certificates/campaign-runners/cr1",
+			expectedStatus: http.StatusForbidden, expectedMsg: certificateModel.ErrNotRunnerOwner.Error()},
+		{name: "campaign still running", userID: "ada", method: http.MethodPost, path: "/api/certificates/campaign-runners/cr2",
+			expectedStatus: http.StatusConflict, expectedMsg: certificateModel.ErrNotCompleted.Error()},
+		{name: "unknown runner", userID: "ada", method: http.MethodPost, path: "/api/certificates/cause-runners/missing",
+			expectedStatus: http.StatusNotFound, expectedMsg: certificateModel.ErrRunnerNotFound.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := setupCertificateRouter(t, tt.userID)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var body dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedMsg, body.Message)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package certificate_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/internal/app/certificate"
+)
+
+func TestLogoLoader_Local(t *testing.T) {
+	base := t.TempDir()
+	uploads := filepath.Join(base, "uploads")
+	require.NoError(t, os.MkdirAll(filepath.Join(uploads, "sponsors"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(uploads, "sponsors", "logo.png"), []byte("logo"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "secret.txt"), []byte("secret"), 0o644))
+
+	loader := certificate.NewLogoLoader("/uploads/", uploads)
+	ctx := context.Background()
+
+	data, err := loader.Load(ctx, "/uploads/sponsors/logo.png?v=2")
+	require.NoError(t, err)
+	assert.Equal(t, "logo", string(data))
+
+	// ".." cannot climb out of the upload directory.
+	_, err = loader.Load(ctx, "/uploads/../secret.txt")
+	assert.Error(t, err)
+	_, err = loader.Load(ctx, "/etc/passwd")
+	assert.ErrorIs(t, err, certificate.ErrLogoNotAllowed)
+	_, err = loader.Load(ctx, "file:///etc/passwd")
+	assert.ErrorIs(t, err, certificate.ErrLogoNotAllowed)
+}
+
+func TestLogoLoader_Remote(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bucket/logo.png":
+			w.Write([]byte("remote logo"))
+		case "/bucket/redirect":
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+		case "/bucket/huge":
+			w.Write([]byte(strings.Repeat("x", 3<<20)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	loader := certificate.NewLogoLoader("/uploads", t.TempDir(), srv.URL+"/bucket", "")
+	ctx := context.Background()
+
+	data, err := loader.Load(ctx, srv.URL+"/bucket/logo.png")
+	require.NoError(t, err)
+	assert.Equal(t, "remote logo", string(data))
+
+	// Redirects are not followed and oversized files are refused.
+	_, err = loader.Load(ctx, srv.URL+"/bucket/redirect")
+	assert.Error(t, err)
+	_, err = loader.Load(ctx, srv.URL+"/bucket/huge")
+	assert.Error(t, err)
+
+	// Only the configured prefix is fetched, and it must match on a path boundary.
+	_, err = loader.Load(ctx, srv.URL+"/other/logo.png")
+	assert.ErrorIs(t, err, certificate.ErrLogoNotAllowed)
+	_, err = loader.Load(ctx, srv.URL+"/bucket-evil/logo.png")
+	assert.ErrorIs(t, err, certificate.ErrLogoNotAllowed)
+	_, err = loader.Load(ctx, "http://169.254.169.254/latest/meta-data")
+	assert.ErrorIs(t, err, certificate.ErrLogoNotAllowed)
+}
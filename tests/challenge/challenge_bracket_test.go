@@ -0,0 +1,395 @@
+package challenge_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	challenge "gopi.com/internal/app/challenge"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	"gopi.com/internal/data/challenge/repo"
+	activityModel "gopi.com/internal/domain/activity/model"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	challengeMocks "gopi.com/tests/mocks/challenge"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+func TestSeedEntrants(t *testing.T) {
+	users := []string{"cara", "abe", "dan", "abe", "", "bea"}
+	past := map[string]float64{"abe": 12, "bea": 30, "cara": 12}
+
+	seeds, err := challengeModel.SeedEntrants(users, challengeModel.SeedDistance, past, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bea", "abe", "cara", "dan"}, seeds, "most distance first, ties by user ID, repeats dropped")
+
+	seeds, err = challengeModel.SeedEntrants(users, challengeModel.SeedRandom, nil, rand.New(rand.NewPCG(1, 2)))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"abe", "bea", "cara", "dan"}, seeds)
+	again, err := challengeModel.SeedEntrants(users, challengeModel.SeedRandom, nil, rand.New(rand.NewPCG(1, 2)))
+	require.NoError(t, err)
+	assert.Equal(t, seeds, again, "the same source draws the same order")
+
+	_, err = challengeModel.SeedEntrants(users, "alphabetical", nil, nil)
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidSeeding)
+}
+
+func TestBracket_Draw(t *testing.T) {
+	starts := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	now := starts.Add(-time.Hour)
+	bracket := &challengeModel.Bracket{Seeding: challengeModel.SeedDistance, StartsAt: starts}
+
+	matches, err := bracket.Draw([]string{"s1", "s2", "s3", "s4", "s5"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, challengeModel.DefaultRoundLength, bracket.RoundLength)
+	assert.Equal(t, 5, bracket.Entrants)
+	assert.Equal(t, 8, bracket.Size)
+	assert.Equal(t, 3, bracket.Rounds)
+	require.Len(t, matches, 7)
+
+	pairs := [][2]string{}
+	for _, match := range matches[:4] {
+		pairs = append(pairs, [2]string{match.HomeID, match.AwayID})
+	}
+	assert.Equal(t, [][2]string{{"s1", ""}, {"s4", "s5"}, {"s2", ""}, {"s3", ""}}, pairs, "top seeds are spread apart and get the byes")
+
+	bye := matches[0]
+	assert.Equal(t, challengeModel.MatchBye, bye.Outcome)
+	assert.Equal(t, "s1", bye.WinnerID)
+	assert.Equal(t, &now, bye.DecidedAt)
+	assert.Equal(t, challengeModel.MatchPending, matches[1].Outcome)
+
+	semi := matches[4]
+	assert.Equal(t, 2, semi.Round)
+	assert.Equal(t, "s1", semi.HomeID, "bye winners advance at once")
+	assert.Equal(t, "", semi.AwayID, "the other side waits for s4 and s5")
+	assert.Equal(t, "s2", matches[5].HomeID)
+	assert.Equal(t, "s3", matches[5].AwayID)
+	assert.Equal(t, 3, matches[5].AwaySeed)
+	assert.True(t, matches[5].Ready())
+
+	final := matches[6]
+	assert.Equal(t, 3, final.Round)
+	assert.Equal(t, starts.Add(14*24*time.Hour), final.OpensAt)
+	assert.Equal(t, starts.Add(21*24*time.Hour), final.ClosesAt)
+
+	two := &challengeModel.Bracket{Seeding: challengeModel.SeedRandom, StartsAt: starts, RoundLength: time.Hour}
+	matches, err = two.Draw([]string{"a", "b"}, now)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, 1, two.Rounds)
+
+	_, err = (&challengeModel.Bracket{Seeding: challengeModel.SeedRandom, StartsAt: starts}).Draw([]string{"solo"}, now)
+	assert.ErrorIs(t, err, challengeModel.ErrTooFewEntrants)
+	_, err = (&challengeModel.Bracket{Seeding: challengeModel.SeedRandom}).Draw([]string{"a", "b"}, now)
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidBracket)
+	_, err = (&challengeModel.Bracket{Seeding: challengeModel.SeedRandom, StartsAt: starts, RoundLength: -time.Hour}).Draw([]string{"a", "b"}, now)
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidBracket)
+}
+
+func TestBracketMatch_Decide(t *testing.T) {
+	opens := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	closes := opens.Add(7 * 24 * time.Hour)
+	decided := closes.Add(time.Minute)
+	run := func(owner string, distance float64, at time.Time, status activityModel.ReviewStatus) *challengeModel.CauseRunner {
+		return &challengeModel.CauseRunner{OwnerID: owner, DistanceCovered: distance, DateJoined: at, Status: status}
+	}
+	match := func() *challengeModel.BracketMatch {
+		return &challengeModel.BracketMatch{HomeID: "home", HomeSeed: 1, AwayID: "away", AwaySeed: 4,
+			OpensAt: opens, ClosesAt: closes, Outcome: challengeModel.MatchPending}
+	}
+
+	tests := []struct {
+		name    string
+		runners []*challengeModel.CauseRunner
+		winner  string
+		outcome challengeModel.MatchOutcome
+		home    float64
+		away    float64
+	}{
+		{"longer distance wins", []*challengeModel.CauseRunner{
+			run("home", 5, opens, ""),
+			run("away", 4, opens.Add(time.Hour), activityModel.ReviewStatusAccepted),
+			run("away", 3, closes.Add(-time.Hour), activityModel.ReviewStatusApproved),
+		}, "away", challengeModel.MatchRaced, 5, 7},
+		{"runs outside the window, by others or held by review do not count", []*challengeModel.CauseRunner{
+			run("home", 2, opens.Add(time.Hour), ""),
+			run("away", 1, opens.Add(time.Hour), ""),
+			run("away", 50, opens.Add(-time.Second), ""),
+			run("away", 50, closes, ""),
+			run("away", 50, opens.Add(time.Hour), activityModel.ReviewStatusFlagged),
+			run("away", 50, opens.Add(time.Hour), activityModel.ReviewStatusRejected),
+			run("stranger", 50, opens.Add(time.Hour), ""),
+		}, "home", challengeModel.MatchRaced, 2, 1},
+		{"a tie goes to the higher seed", []*challengeModel.CauseRunner{
+			run("home", 3, opens, ""),
+			run("away", 3, opens, ""),
+		}, "home", challengeModel.MatchRaced, 3, 3},
+		{"a side that logged nothing loses by walkover", []*challengeModel.CauseRunner{
+			run("away", 1, opens, ""),
+		}, "away", challengeModel.MatchWalkover, 0, 1},
+		{"neither logging sends the higher seed through", nil, "home", challengeModel.MatchWalkover, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := match()
+			m.Decide(tt.runners, decided)
+			assert.Equal(t, tt.winner, m.WinnerID)
+			assert.Equal(t, tt.outcome, m.Outcome)
+			assert.InDelta(t, tt.home, m.HomeDistance, 1e-9)
+			assert.InDelta(t, tt.away, m.AwayDistance, 1e-9)
+			assert.Equal(t, &decided, m.DecidedAt)
+		})
+	}
+}
+
+func TestBracketTree(t *testing.T) {
+	starts := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	bracket := &challengeModel.Bracket{Seeding: challengeModel.SeedDistance, StartsAt: starts}
+	matches, err := bracket.Draw([]string{"s1", "s2", "s3"}, starts)
+	require.NoError(t, err)
+
+	tree := challengeModel.BracketTree(bracket, matches)
+	require.NotNil(t, tree)
+	assert.Equal(t, 2, tree.Match.Round)
+	assert.Equal(t, "s1", tree.Match.HomeID)
+	require.Len(t, tree.Feeders, 2)
+	assert.Equal(t, "s1", tree.Feeders[0].Match.HomeID, "home's feeder comes first")
+	assert.Equal(t, "s2", tree.Feeders[1].Match.HomeID)
+	assert.Empty(t, tree.Feeders[0].Feeders)
+
+	data, err := json.Marshal(tree)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"feeders":[{"match":`)
+
+	assert.Nil(t, challengeModel.BracketTree(bracket, nil))
+}
+
+type bracketFixture struct {
+	db        *gorm.DB
+	service   *challenge.ChallengeService
+	challenge *challengeModel.Challenge
+	cause     *challengeModel.Cause
+	runners   challengeRepo.CauseRunnerRepository
+}
+
+func newBracketFixture(t *testing.T) *bracketFixture {
+	db := testdb.Open(t, serviceTables(&gormmodel.ChallengeBracket{}, &gormmodel.BracketMatch{})...)
+
+	challenges := repo.NewGormChallengeRepository(db)
+	causeRepo := repo.NewGormCauseRepository(db)
+	f := &bracketFixture{db: db, runners: repo.NewGormCauseRunnerRepository(db)}
+	f.service = challenge.NewChallengeService(challenges, causeRepo, f.runners,
+		repo.NewGormSponsorChallengeRepository(db), repo.NewGormSponsorCauseRepository(db), repo.NewGormCauseBuyerRepository(db),
+		challenge.WithBrackets(repo.NewGormBracketRepository(db)))
+
+	f.challenge = &challengeModel.Challenge{OwnerID: "owner", Name: "knockout", Slug: "knockout", Mode: challengeModel.ChallengeModeF}
+	require.NoError(t, challenges.Create(f.challenge))
+	f.cause = &challengeModel.Cause{ChallengeID: f.challenge.ID, Name: "laps", Slug: "laps", OwnerID: "owner"}
+	require.NoError(t, causeRepo.Create(f.cause))
+	return f
+}
+
+func (f *bracketFixture) run(t *testing.T, owner string, distance float64, at time.Time, status activityModel.ReviewStatus) {
+	require.NoError(t, f.runners.Create(&challengeModel.CauseRunner{CauseID: f.cause.ID, OwnerID: owner,
+		DistanceCovered: distance, Duration: time.Hour, Status: status, DateJoined: at}))
+}
+
+func TestChallengeService_Bracket_SQLite(t *testing.T) {
+	f := newBracketFixture(t)
+	now := time.Now()
+	starts := now.Add(time.Hour)
+	day := 24 * time.Hour
+
+	_, _, err := f.service.GetBracket(f.challenge.ID)
+	assert.ErrorIs(t, err, challengeModel.ErrNoBracket)
+
+	challenges := repo.NewGormChallengeRepository(f.db)
+	require.NoError(t, challenges.AddMember(f.challenge.ID, "m1"))
+	_, _, err = f.service.CreateBracket(f.challenge.ID, challengeModel.SeedDistance, starts, day, now)
+	assert.ErrorIs(t, err, challengeModel.ErrTooFewEntrants)
+	for _, member := range []string{"m2", "m3"} {
+		require.NoError(t, challenges.AddMember(f.challenge.ID, member))
+	}
+
+	// Seeds by distance logged before the draw: m2, m1, m3.
+	f.run(t, "m1", 5, now.Add(-2*day), "")
+	f.run(t, "m2", 10, now.Add(-2*day), "")
+	f.run(t, "m3", 1, now.Add(-2*day), "")
+	f.run(t, "m3", 40, now.Add(-2*day), activityModel.ReviewStatusRejected)
+
+	_, _, err = f.service.CreateBracket(f.challenge.ID, "fastest", starts, day, now)
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidSeeding)
+	bracket, matches, err := f.service.CreateBracket(f.challenge.ID, challengeModel.SeedDistance, starts, day, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, bracket.Rounds)
+	require.Len(t, matches, 3)
+	assert.Equal(t, "m2", matches[0].HomeID)
+	assert.Equal(t, challengeModel.MatchBye, matches[0].Outcome)
+	assert.Equal(t, "m1", matches[1].HomeID)
+	assert.Equal(t, "m3", matches[1].AwayID)
+	_, _, err = f.service.CreateBracket(f.challenge.ID, challengeModel.SeedRandom, starts, day, now)
+	assert.ErrorIs(t, err, challengeModel.ErrBracketExists)
+
+	// Round one: m3 outruns m1, whose flagged run does not count.
+	f.run(t, "m1", 3, starts.Add(time.Hour), "")
+	f.run(t, "m1", 100, starts.Add(time.Hour), activityModel.ReviewStatusFlagged)
+	f.run(t, "m3", 8, starts.Add(2*time.Hour), "")
+	// Round two: only m3 logs anything.
+	f.run(t, "m3", 2, starts.Add(day+time.Hour), "")
+
+	decided, err := f.service.DecideDueMatches(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, decided, "the first round has not ended")
+	decided, err = f.service.DecideDueMatches(starts.Add(day))
+	require.NoError(t, err)
+	assert.Equal(t, 1, decided)
+
+	bracket, matches, err = f.service.GetBracket(f.challenge.ID)
+	require.NoError(t, err)
+	assert.Equal(t, challengeModel.MatchRaced, matches[1].Outcome)
+	assert.Equal(t, "m3", matches[1].WinnerID)
+	assert.InDelta(t, 3, matches[1].HomeDistance, 1e-9)
+	assert.InDelta(t, 8, matches[1].AwayDistance, 1e-9)
+	final := matches[2]
+	assert.Equal(t, "m2", final.HomeID)
+	assert.Equal(t, "m3", final.AwayID)
+	assert.Equal(t, 3, final.AwaySeed)
+	assert.Empty(t, bracket.ChampionID)
+
+	decided, err = f.service.DecideDueMatches(starts.Add(2 * day))
+	require.NoError(t, err)
+	assert.Equal(t, 1, decided)
+	decided, err = f.service.DecideDueMatches(starts.Add(3 * day))
+	require.NoError(t, err)
+	assert.Zero(t, decided, "matches are decided once")
+
+	bracket, matches, err = f.service.GetBracket(f.challenge.ID)
+	require.NoError(t, err)
+	assert.Equal(t, challengeModel.MatchWalkover, matches[2].Outcome)
+	assert.Equal(t, "m3", bracket.ChampionID)
+	require.NotNil(t, bracket.CompletedAt)
+}
+
+func TestChallengeService_Bracket_NotEnabled(t *testing.T) {
+	service := challenge.NewChallengeService(new(challengeMocks.MockChallengeRepository), new(challengeMocks.MockCauseRepository),
+		new(challengeMocks.MockCauseRunnerRepository), nil, nil, nil)
+
+	_, _, err := service.CreateBracket("c1", challengeModel.SeedRandom, time.Now(), 0, time.Now())
+	assert.ErrorIs(t, err, challengeModel.ErrBracketsNotEnabled)
+	_, _, err = service.GetBracket("c1")
+	assert.ErrorIs(t, err, challengeModel.ErrBracketsNotEnabled)
+	decided, err := service.DecideDueMatches(time.Now())
+	assert.NoError(t, err)
+	assert.Zero(t, decided)
+}
+
+func TestChallengeHandler_BracketEndpoints_SQLite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := newBracketFixture(t)
+	challenges := repo.NewGormChallengeRepository(f.db)
+	for _, member := range []string{"m1", "m2", "m3", "m4", "m5"} {
+		require.NoError(t, challenges.AddMember(f.challenge.ID, member))
+	}
+
+	userRepo := new(userMocks.MockUserRepository)
+	userRepo.On("GetByID", "m1").Return(&userModel.User{Base: model.Base{ID: "m1"}, Username: "runner1"}, nil).Maybe()
+	userRepo.On("GetByID", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Maybe()
+	h := handler.NewChallengeHandler(f.service, user.NewUserService(userRepo, nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-User"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+	router.POST("/challenges/:id/bracket", h.CreateBracket)
+	router.GET("/challenges/:challenge_id/bracket", h.GetBracket)
+
+	send := func(method, path, userID string, body interface{}) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User", userID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	path := "/challenges/" + f.challenge.ID + "/bracket"
+	assert.Equal(t, http.StatusNotFound, send(http.MethodGet, path, "", nil).Code)
+
+	starts := time.Now().Add(time.Hour).UTC()
+	request := dto.CreateBracketRequest{Seeding: "random", StartsAt: starts, RoundDays: 3}
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, path, "m1", request).Code)
+	assert.Equal(t, http.StatusBadRequest, send(http.MethodPost, path, "owner",
+		dto.CreateBracketRequest{Seeding: "fastest", StartsAt: starts}).Code)
+
+	w := send(http.MethodPost, path, "owner", request)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created dto.BracketResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, 5, created.Entrants)
+	assert.Equal(t, 3, created.Rounds)
+	assert.InDelta(t, 3, created.RoundDays, 1e-9)
+	assert.Equal(t, http.StatusConflict, send(http.MethodPost, path, "owner", request).Code)
+
+	w = send(http.MethodGet, path, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var bracket dto.BracketResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &bracket))
+	require.NotNil(t, bracket.Final)
+	assert.Equal(t, 3, bracket.Final.Match.Round)
+	assert.Equal(t, starts.Add(6*24*time.Hour), bracket.Final.Match.OpensAt.UTC())
+
+	var leaves []dto.BracketMatchResponse
+	var walk func(n dto.BracketNodeResponse)
+	walk = func(n dto.BracketNodeResponse) {
+		if len(n.Feeders) == 0 {
+			leaves = append(leaves, n.Match)
+		}
+		for _, feeder := range n.Feeders {
+			walk(feeder)
+		}
+	}
+	walk(*bracket.Final)
+	require.Len(t, leaves, 4)
+	entrants, byes := 0, 0
+	for _, leaf := range leaves {
+		assert.Equal(t, 1, leaf.Round)
+		for _, side := range []*dto.BracketEntrant{leaf.Home, leaf.Away} {
+			if side == nil {
+				continue
+			}
+			entrants++
+			if side.UserID == "m1" {
+				assert.Equal(t, "runner1", side.Username)
+			}
+		}
+		if leaf.Outcome == string(challengeModel.MatchBye) {
+			byes++
+		}
+	}
+	assert.Equal(t, 5, entrants)
+	assert.Equal(t, 3, byes)
+}
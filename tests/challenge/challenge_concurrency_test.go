@@ -0,0 +1,56 @@
+package challenge_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	challenge "gopi.com/internal/app/challenge"
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	"gopi.com/internal/data/challenge/repo"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/tests/testdb"
+)
+
+func TestChallengeService_ConcurrentCauseActivity_SQLite(t *testing.T) {
+	db := testdb.Open(t, serviceTables()...)
+
+	challengeRepo := repo.NewGormChallengeRepository(db)
+	causeRepo := repo.NewGormCauseRepository(db)
+	causeRunnerRepo := repo.NewGormCauseRunnerRepository(db)
+	service := challenge.NewChallengeService(challengeRepo, causeRepo, causeRunnerRepo,
+		repo.NewGormSponsorChallengeRepository(db), repo.NewGormSponsorCauseRepository(db), repo.NewGormCauseBuyerRepository(db),
+		challenge.WithUnitOfWork(repo.NewGormUnitOfWork(db)))
+
+	ch := &challengeModel.Challenge{OwnerID: "owner", Name: "concurrent", Mode: challengeModel.ChallengeModeF}
+	require.NoError(t, challengeRepo.Create(ch))
+	cause := &challengeModel.Cause{ChallengeID: ch.ID, Name: "concurrent", OwnerID: "owner"}
+	require.NoError(t, causeRepo.Create(cause))
+
+	const workers = 25
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- service.RecordCauseActivity(cause.ID, "runner", 5, 0.4, 4*time.Minute, "Running")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	got, err := causeRepo.GetByID(cause.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, workers*0.4, got.DistanceCovered, 1e-9)
+
+	var runners int64
+	db.Model(&gormmodel.CauseRunner{}).Where("cause_id = ?", cause.ID).Count(&runners)
+	assert.Equal(t, int64(workers), runners)
+}
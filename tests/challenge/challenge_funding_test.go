@@ -0,0 +1,241 @@
+package challenge_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	challenge "gopi.com/internal/app/challenge"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	"gopi.com/internal/data/challenge/repo"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	challengeMocks "gopi.com/tests/mocks/challenge"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+func TestSponsorCause_EarnedFor(t *testing.T) {
+	sponsorship := &challengeModel.SponsorCause{Distance: 10, AmountPerKm: 2.5}
+	assert.Equal(t, 0.0, sponsorship.EarnedFor(0))
+	assert.Equal(t, 10.63, sponsorship.EarnedFor(4.25), "rounded to the cent")
+	assert.Equal(t, 25.0, sponsorship.EarnedFor(10))
+	assert.Equal(t, 25.0, sponsorship.EarnedFor(42), "capped at the pledged distance")
+	assert.Equal(t, 0.0, sponsorship.EarnedFor(-3))
+}
+
+func TestNewCauseFunding(t *testing.T) {
+	cause := &challengeModel.Cause{Base: model.Base{ID: "cause"}, FundAmount: 200, DistanceCovered: 20}
+	sponsorships := []*challengeModel.SponsorCause{
+		{SponsorID: "acme", Distance: 50, AmountPerKm: 1, TotalAmount: 50},
+		{SponsorID: "globex", Distance: 10, AmountPerKm: 5, TotalAmount: 50},
+		{SponsorID: "acme", Distance: 100, AmountPerKm: 2, TotalAmount: 200},
+	}
+
+	funding := challengeModel.NewCauseFunding(cause, sponsorships)
+	assert.Equal(t, 300.0, funding.Pledged)
+	assert.Equal(t, 110.0, funding.Earned, "acme 20+40, globex capped at 50")
+	assert.Equal(t, 55.0, funding.Progress())
+	require.Len(t, funding.Sponsors, 2)
+	assert.Equal(t, &challengeModel.SponsorObligation{SponsorID: "acme", Sponsorships: 2, Pledged: 250, Earned: 60}, funding.Sponsors[0])
+	assert.Equal(t, &challengeModel.SponsorObligation{SponsorID: "globex", Sponsorships: 1, Pledged: 50, Earned: 50}, funding.Sponsors[1])
+
+	at := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	statements := funding.Statements(at)
+	require.Len(t, statements, 2)
+	assert.Equal(t, "acme", statements[0].SponsorID)
+	assert.Equal(t, 60.0, statements[0].AmountDue)
+	assert.Equal(t, 20.0, statements[0].DistanceCovered)
+	assert.Equal(t, at, statements[1].SettledAt)
+
+	noGoal := challengeModel.NewCauseFunding(&challengeModel.Cause{DistanceCovered: 5}, sponsorships)
+	assert.Equal(t, 0.0, noGoal.Progress())
+}
+
+type fundingFixture struct {
+	service   *challenge.ChallengeService
+	causes    challengeRepo.CauseRepository
+	challenge *challengeModel.Challenge
+	cause     *challengeModel.Cause
+}
+
+func newFundingFixture(t *testing.T) *fundingFixture {
+	db := testdb.Open(t, serviceTables(&gormmodel.ChallengeWinner{}, &gormmodel.ChallengeWinnerAudit{}, &gormmodel.SponsorStatement{})...)
+
+	challenges := repo.NewGormChallengeRepository(db)
+	f := &fundingFixture{causes: repo.NewGormCauseRepository(db)}
+	f.service = challenge.NewChallengeService(challenges, f.causes, repo.NewGormCauseRunnerRepository(db),
+		repo.NewGormSponsorChallengeRepository(db), repo.NewGormSponsorCauseRepository(db), repo.NewGormCauseBuyerRepository(db),
+		challenge.WithUnitOfWork(repo.NewGormUnitOfWork(db)),
+		challenge.WithWinners(repo.NewGormChallengeWinnerRepository(db), nil, nil, nil),
+		challenge.WithFunding(repo.NewGormSponsorStatementRepository(db)))
+
+	f.challenge = &challengeModel.Challenge{OwnerID: "owner", Name: "river run", Slug: "river-run", Mode: challengeModel.ChallengeModeF,
+		NoOfWinner: 1}
+	require.NoError(t, challenges.Create(f.challenge))
+	f.cause = &challengeModel.Cause{ChallengeID: f.challenge.ID, Name: "clean river", Slug: "clean-river", OwnerID: "owner", FundAmount: 100}
+	require.NoError(t, f.causes.Create(f.cause))
+	return f
+}
+
+func TestChallengeService_CauseFunding_SQLite(t *testing.T) {
+	f := newFundingFixture(t)
+
+	require.NoError(t, f.service.SponsorCause(f.cause.ID, "acme", 10, 4))
+	require.NoError(t, f.service.SponsorCause(f.cause.ID, "globex", 50, 1))
+
+	funding, err := f.service.GetCauseFunding(f.cause.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 90.0, funding.Pledged)
+	assert.Equal(t, 0.0, funding.Earned)
+	assert.Nil(t, funding.SettledAt)
+
+	// Earnings follow the distance runners log.
+	require.NoError(t, f.service.RecordCauseActivity(f.cause.ID, "runner", 10, 6, 30*time.Minute, "running"))
+	funding, err = f.service.GetCauseFunding(f.cause.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 6.0, funding.DistanceCovered)
+	assert.Equal(t, 30.0, funding.Earned, "acme 24, globex 6")
+	assert.Equal(t, 30.0, funding.Progress())
+
+	require.NoError(t, f.service.RecordCauseActivity(f.cause.ID, "runner", 10, 9, 40*time.Minute, "running"))
+	funding, err = f.service.GetCauseFunding(f.cause.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 55.0, funding.Earned, "acme capped at 40, globex 15")
+	assert.Equal(t, "acme", funding.Sponsors[0].SponsorID)
+
+	statements, err := f.service.ListCauseStatements(f.cause.ID)
+	require.NoError(t, err)
+	assert.Empty(t, statements, "nothing is settled before the challenge closes")
+
+	settledAt := time.Now().UTC().Truncate(time.Second)
+	closed, err := f.service.CloseOutChallenge(f.challenge, settledAt)
+	require.NoError(t, err)
+	require.True(t, closed)
+
+	statements, err = f.service.ListCauseStatements(f.cause.ID)
+	require.NoError(t, err)
+	require.Len(t, statements, 2)
+	assert.Equal(t, "acme", statements[0].SponsorID)
+	assert.Equal(t, 40.0, statements[0].AmountDue)
+	assert.Equal(t, 15.0, statements[0].DistanceCovered)
+	assert.Equal(t, 15.0, statements[1].AmountDue)
+
+	// Closing again changes nothing, and the ledger keeps showing what was billed even after
+	// more distance lands on the cause.
+	closed, err = f.service.CloseOutChallenge(f.challenge, time.Now())
+	require.NoError(t, err)
+	assert.False(t, closed)
+	require.NoError(t, f.causes.IncrementDistance(f.cause.ID, 5))
+	mine, err := f.service.ListSponsorStatements("globex")
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	assert.Equal(t, 15.0, mine[0].AmountDue)
+
+	funding, err = f.service.GetCauseFunding(f.cause.ID)
+	require.NoError(t, err)
+	require.NotNil(t, funding.SettledAt)
+	assert.True(t, settledAt.Equal(*funding.SettledAt))
+	assert.Equal(t, 15.0, funding.DistanceCovered)
+	assert.Equal(t, 55.0, funding.Earned)
+	assert.Equal(t, 90.0, funding.Pledged)
+	require.Len(t, funding.Sponsors, 2)
+	assert.Equal(t, &challengeModel.SponsorObligation{SponsorID: "acme", Sponsorships: 1, Pledged: 40, Earned: 40}, funding.Sponsors[0])
+}
+
+func TestChallengeService_CauseStatements_NotEnabled(t *testing.T) {
+	service := challenge.NewChallengeService(new(challengeMocks.MockChallengeRepository), new(challengeMocks.MockCauseRepository),
+		new(challengeMocks.MockCauseRunnerRepository), new(challengeMocks.MockSponsorChallengeRepository),
+		new(challengeMocks.MockSponsorCauseRepository), new(challengeMocks.MockCauseBuyerRepository))
+
+	_, err := service.ListCauseStatements("cause")
+	assert.ErrorIs(t, err, challengeModel.ErrFundingNotEnabled)
+	_, err = service.ListSponsorStatements("sponsor")
+	assert.ErrorIs(t, err, challengeModel.ErrFundingNotEnabled)
+}
+
+func TestChallengeService_CloseOutWithoutSponsors_SQLite(t *testing.T) {
+	f := newFundingFixture(t)
+
+	closed, err := f.service.CloseOutChallenge(f.challenge, time.Now())
+	require.NoError(t, err)
+	require.True(t, closed)
+
+	statements, err := f.service.ListCauseStatements(f.cause.ID)
+	require.NoError(t, err)
+	assert.Empty(t, statements)
+	funding, err := f.service.GetCauseFunding(f.cause.ID)
+	require.NoError(t, err)
+	assert.Nil(t, funding.SettledAt)
+}
+
+func TestChallengeHandler_CauseFundingEndpoints_SQLite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := newFundingFixture(t)
+	require.NoError(t, f.service.SponsorCause(f.cause.ID, "acme", 10, 4))
+	require.NoError(t, f.service.RecordCauseActivity(f.cause.ID, "runner", 10, 5, 30*time.Minute, "running"))
+
+	userRepo := new(userMocks.MockUserRepository)
+	userRepo.On("GetByID", "acme").Return(&userModel.User{Base: model.Base{ID: "acme"}, FirstName: "Acme", LastName: "Corp"}, nil).Maybe()
+	h := handler.NewChallengeHandler(f.service, user.NewUserService(userRepo, nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-User"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+	router.GET("/causes/:id/funding", h.GetCauseFunding)
+	router.GET("/causes/:id/statements", h.GetCauseStatements)
+	router.GET("/causes/sponsorships/statements", h.GetMySponsorStatements)
+
+	send := func(path, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-User", userID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("/causes/"+f.cause.ID+"/funding", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var funding dto.CauseFundingResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &funding))
+	assert.Equal(t, 20.0, funding.Earned)
+	assert.Equal(t, 20.0, funding.Progress)
+	require.Len(t, funding.Sponsors, 1)
+	assert.Equal(t, "Acme Corp", funding.Sponsors[0].SponsorName)
+
+	w = send("/causes/missing/funding", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	_, err := f.service.CloseOutChallenge(f.challenge, time.Now())
+	require.NoError(t, err)
+
+	w = send("/causes/"+f.cause.ID+"/statements", "acme")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = send("/causes/"+f.cause.ID+"/statements", "owner")
+	require.Equal(t, http.StatusOK, w.Code)
+	var statements dto.SponsorStatementListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &statements))
+	require.Len(t, statements.Statements, 1)
+	assert.Equal(t, 20.0, statements.TotalDue)
+
+	w = send("/causes/sponsorships/statements", "acme")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &statements))
+	require.Len(t, statements.Statements, 1)
+	assert.Equal(t, f.cause.ID, statements.Statements[0].CauseID)
+}
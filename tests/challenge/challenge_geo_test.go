@@ -0,0 +1,168 @@
+package challenge_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	"gopi.com/internal/app/challenge"
+	"gopi.com/internal/app/user"
+	"gopi.com/internal/data/challenge/repo"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	"gopi.com/internal/lib/geo"
+	challengeMocks "gopi.com/tests/mocks/challenge"
+	userMocks "gopi.com/tests/mocks/user"
+)
+
+func TestChallengeService_Nearby(t *testing.T) {
+	db := setupChallengeTestDB(t)
+	gazetteer, err := geo.ReadGazetteer(strings.NewReader("Lagos,6.5244,3.3792\nIkeja Lagos,6.6018,3.3515\nAbuja,9.0765,7.3986\n"))
+	require.NoError(t, err)
+
+	service := challenge.NewChallengeService(repo.NewGormChallengeRepository(db), repo.NewGormCauseRepository(db),
+		repo.NewGormCauseRunnerRepository(db), repo.NewGormSponsorChallengeRepository(db),
+		repo.NewGormSponsorCauseRepository(db), repo.NewGormCauseBuyerRepository(db),
+		challenge.WithGeocoder(gazetteer))
+
+	newChallenge := func(name, location string, at *model.GeoPoint) *challengeModel.Challenge {
+		c, err := service.CreateChallenge("owner1", name, "", "", "", location, challengeModel.ChallengeModeF,
+			10, 0, 0, "", "", 3, "", nil, nil, at)
+		require.NoError(t, err)
+		return c
+	}
+	ikeja := newChallenge("Ikeja Walk", "Ikeja, Lagos", nil)
+	island := newChallenge("Island Run", "Victoria Island", &model.GeoPoint{Lat: 6.4281, Lon: 3.4219})
+	newChallenge("Abuja Ride", "Abuja", nil)
+	newChallenge("Nowhere", "Somewhere Else", nil)
+
+	near := model.Near{Center: model.GeoPoint{Lat: 6.5244, Lon: 3.3792}, RadiusKm: 25}
+	challenges, total, err := service.ListChallengesNear(near, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, challenges, 2)
+	assert.Equal(t, ikeja.ID, challenges[0].ID)  // ~9 km
+	assert.Equal(t, island.ID, challenges[1].ID) // ~12 km
+	assert.Equal(t, &model.GeoPoint{Lat: 6.6018, Lon: 3.3515}, challenges[0].Coordinates)
+
+	challenges, total, err = service.ListChallengesNear(near, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, challenges, 1)
+	assert.Equal(t, island.ID, challenges[0].ID)
+
+	cause, err := service.CreateCause(ikeja.ID, "owner1", "Clean Water", "", "", "", challengeModel.ActivityRunning,
+		"Lagos", "", false, 0, 0, 0, 0, nil)
+	require.NoError(t, err)
+	_, err = service.CreateCause(ikeja.ID, "owner1", "Far Away", "", "", "", challengeModel.ActivityRunning,
+		"Abuja", "", false, 0, 0, 0, 0, nil)
+	require.NoError(t, err)
+
+	causes, total, err := service.ListCausesNear(near, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, causes, 1)
+	assert.Equal(t, cause.ID, causes[0].ID)
+}
+
+func TestChallengeHandler_Nearby(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	owner := &userModel.User{Base: model.Base{ID: "owner1"}, Username: "owner"}
+	lagos := model.GeoPoint{Lat: 6.5244, Lon: 3.3792}
+
+	tests := []struct {
+		name           string
+		target         string
+		setupMocks     func(*challengeMocks.MockChallengeRepository, *challengeMocks.MockCauseRepository, *userMocks.MockUserRepository)
+		expectedStatus int
+		expectedTotal  int
+	}{
+		{
+			name:   "challenges near a position",
+			target: "/challenges?near=6.5244,3.3792&radius_km=5&page=2&limit=1",
+			setupMocks: func(c *challengeMocks.MockChallengeRepository, _ *challengeMocks.MockCauseRepository, u *userMocks.MockUserRepository) {
+				c.On("ListNear", model.Near{Center: lagos, RadiusKm: 5}, 1, 1).
+					Return([]*challengeModel.Challenge{{Base: model.Base{ID: "c1"}, OwnerID: "owner1", Coordinates: &lagos}}, int64(3), nil)
+				u.On("GetByID", "owner1").Return(owner, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTotal:  3,
+		},
+		{
+			name:   "challenges without near are listed as before",
+			target: "/challenges",
+			setupMocks: func(c *challengeMocks.MockChallengeRepository, _ *challengeMocks.MockCauseRepository, _ *userMocks.MockUserRepository) {
+				c.On("List", 10, 0).Return([]*challengeModel.Challenge{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "malformed near",
+			target:         "/challenges?near=6.5244",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "causes near a position",
+			target: "/causes/nearby?near=6.5244,3.3792",
+			setupMocks: func(_ *challengeMocks.MockChallengeRepository, c *challengeMocks.MockCauseRepository, u *userMocks.MockUserRepository) {
+				c.On("ListNear", model.Near{Center: lagos, RadiusKm: 25}, 10, 0).
+					Return([]*challengeModel.Cause{{Base: model.Base{ID: "cause1"}, OwnerID: "owner1"}}, int64(1), nil)
+				u.On("GetByID", "owner1").Return(owner, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTotal:  1,
+		},
+		{
+			name:           "nearby causes need a position",
+			target:         "/causes/nearby",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockChallengeRepo := new(challengeMocks.MockChallengeRepository)
+			mockCauseRepo := new(challengeMocks.MockCauseRepository)
+			mockUserRepo := new(userMocks.MockUserRepository)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockChallengeRepo, mockCauseRepo, mockUserRepo)
+			}
+			challengeService := challenge.NewChallengeService(mockChallengeRepo, mockCauseRepo,
+				new(challengeMocks.MockCauseRunnerRepository), new(challengeMocks.MockSponsorChallengeRepository),
+				new(challengeMocks.MockSponsorCauseRepository), new(challengeMocks.MockCauseBuyerRepository))
+			challengeHandler := handler.NewChallengeHandler(challengeService, user.NewUserService(mockUserRepo, nil))
+
+			router := gin.New()
+			router.GET("/challenges", challengeHandler.GetChallenges)
+			router.GET("/causes/nearby", challengeHandler.GetCausesNearby)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if strings.HasPrefix(tt.target, "/causes") && w.Code == http.StatusOK {
+				var response dto.CauseListResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedTotal, response.Total)
+			} else if w.Code == http.StatusOK {
+				var response dto.ChallengeListResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedTotal, response.Total)
+				for _, c := range response.Challenges {
+					require.NotNil(t, c.Latitude)
+					assert.Equal(t, lagos.Lat, *c.Latitude)
+				}
+			}
+			mockChallengeRepo.AssertExpectations(t)
+			mockCauseRepo.AssertExpectations(t)
+		})
+	}
+}
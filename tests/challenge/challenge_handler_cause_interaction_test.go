@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -87,9 +88,8 @@ func TestChallengeHandler_JoinCause(t *testing.T) {
 				}
 
 				mockCauseRepo.On("GetByID", "cause123").Return(expectedCause, nil)
-				mockCauseRepo.On("Update", mock.MatchedBy(func(c *challengeModel.Cause) bool {
-					return c.ID == "cause123"
-				})).Return(nil)
+				mockCauseRepo.On("IsMember", "cause123", mock.Anything).Return(false, nil)
+				mockCauseRepo.On("AddMember", "cause123", mock.Anything).Return(nil)
 			},
 		},
 		{
@@ -109,7 +109,7 @@ func TestChallengeHandler_JoinCause(t *testing.T) {
 			},
 		},
 		{
-			name:           "repository error on update",
+			name:           "repository error on add member",
 			causeID:        "cause123",
 			expectedStatus: http.StatusInternalServerError,
 			mockSetup: func() {
@@ -122,7 +122,8 @@ func TestChallengeHandler_JoinCause(t *testing.T) {
 				}
 
 				mockCauseRepo.On("GetByID", "cause123").Return(expectedCause, nil)
-				mockCauseRepo.On("Update", mock.Anything).Return(errors.New("repository error"))
+				mockCauseRepo.On("IsMember", "cause123", mock.Anything).Return(false, nil)
+				mockCauseRepo.On("AddMember", "cause123", mock.Anything).Return(errors.New("repository error"))
 			},
 		},
 	}
@@ -185,14 +186,12 @@ func TestChallengeHandler_RecordCauseActivity(t *testing.T) {
 						r.OwnerID == "test-user-id" &&
 						r.DistanceToCover == 10.5 &&
 						r.DistanceCovered == 8.2 &&
-						r.Duration == "45:30" &&
+						r.Duration == 45*time.Minute + 30*time.Second &&
 						r.Activity == "Walking"
 				})).Return(nil)
 
-				// Mock cause update after activity recording
-				mockCauseRepo.On("Update", mock.MatchedBy(func(c *challengeModel.Cause) bool {
-					return c.ID == "cause123" && c.DistanceCovered == 13.2 // 5.0 + 8.2
-				})).Return(nil)
+				// Mock atomic increment of the cause's distance after activity recording
+				mockCauseRepo.On("IncrementDistance", "cause123", 8.2).Return(nil)
 			},
 		},
 		{
@@ -255,13 +254,7 @@ func TestChallengeHandler_RecordCauseActivity(t *testing.T) {
 			},
 			expectedStatus: http.StatusInternalServerError,
 			mockSetup: func() {
-				// Mock cause runner creation (succeeds)
-				mockCauseRunnerRepo.On("Create", mock.MatchedBy(func(r *challengeModel.CauseRunner) bool {
-					return r.CauseID == "nonexistent" &&
-						r.OwnerID == "test-user-id"
-				})).Return(nil)
-
-				// Mock cause lookup for update (fails because cause doesn't exist)
+				// Mock cause lookup (fails because cause doesn't exist); no runner is created
 				mockCauseRepo.On("GetByID", "nonexistent").Return(nil, errors.New("cause not found"))
 			},
 		},
@@ -428,26 +421,66 @@ func TestChallengeHandler_SponsorCause(t *testing.T) {
 }
 
 func TestChallengeHandler_BuyCause(t *testing.T) {
-	router, _, _, _, _, _, mockCauseBuyerRepo, _ := setupCauseInteractionTest(t)
+	gin.SetMode(gin.TestMode)
+
+	mockCauseRepo := new(challengeMocks.MockCauseRepository)
+	mockOrderRepo := new(challengeMocks.MockCauseOrderRepository)
+	challengeService := challenge.NewChallengeService(new(challengeMocks.MockChallengeRepository), mockCauseRepo, new(challengeMocks.MockCauseRunnerRepository),
+		new(challengeMocks.MockSponsorChallengeRepository), new(challengeMocks.MockSponsorCauseRepository), new(challengeMocks.MockCauseBuyerRepository),
+		challenge.WithCauseOrders(mockOrderRepo, nil, nil))
+	challengeHandler := handler.NewChallengeHandler(challengeService, user.NewUserService(new(userMocks.MockUserRepository), nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "test-user-id")
+		c.Next()
+	})
+	router.POST("/api/v1/causes/buy", challengeHandler.BuyCause)
+
+	// Orders are not configured on the shared cause interaction router.
+	disabledRouter, _, disabledCauseRepo, _, _, _, _, _ := setupCauseInteractionTest(t)
+
+	forSale := &challengeModel.Cause{Base: model.Base{ID: "cause123"}, IsCommercial: true, UnitPrice: 25}
+	notForSale := &challengeModel.Cause{Base: model.Base{ID: "cause123"}, UnitPrice: 25}
 
 	tests := []struct {
 		name           string
+		router         *gin.Engine
 		requestBody    dto.BuyCauseRequest
 		expectedStatus int
 		mockSetup      func()
 	}{
 		{
-			name: "successful cause purchase",
+			name: "successful cause order",
+			requestBody: dto.BuyCauseRequest{
+				CauseID:  "cause123",
+				Quantity: 2,
+				Amount:   50.0,
+			},
+			expectedStatus: http.StatusCreated,
+			mockSetup: func() {
+				mockCauseRepo.On("GetByID", "cause123").Return(forSale, nil)
+				mockOrderRepo.On("Create", mock.MatchedBy(func(o *challengeModel.CauseOrder) bool {
+					return o.BuyerID == "test-user-id" &&
+						o.CauseID == "cause123" &&
+						o.Quantity == 2 &&
+						o.UnitPrice == 25 &&
+						o.Amount == 50.0 &&
+						o.Status == challengeModel.OrderPending
+				})).Return(nil)
+			},
+		},
+		{
+			name: "quantity defaults to one",
 			requestBody: dto.BuyCauseRequest{
 				CauseID: "cause123",
-				Amount:  50.0,
+				Amount:  25.0,
 			},
-			expectedStatus: http.StatusOK,
+			expectedStatus: http.StatusCreated,
 			mockSetup: func() {
-				mockCauseBuyerRepo.On("Create", mock.MatchedBy(func(b *challengeModel.CauseBuyer) bool {
-					return b.BuyerID == "test-user-id" &&
-						b.CauseID == "cause123" &&
-						b.Amount == 50.0
+				mockCauseRepo.On("GetByID", "cause123").Return(forSale, nil)
+				mockOrderRepo.On("Create", mock.MatchedBy(func(o *challengeModel.CauseOrder) bool {
+					return o.Quantity == 1 && o.Amount == 25.0
 				})).Return(nil)
 			},
 		},
@@ -469,25 +502,71 @@ func TestChallengeHandler_BuyCause(t *testing.T) {
 			mockSetup:      func() {},
 		},
 		{
-			name: "user not authenticated",
+			name: "amount does not match quantity times unit price",
+			requestBody: dto.BuyCauseRequest{
+				CauseID:  "cause123",
+				Quantity: 2,
+				Amount:   30.0,
+			},
+			expectedStatus: http.StatusBadRequest,
+			mockSetup: func() {
+				mockCauseRepo.On("GetByID", "cause123").Return(forSale, nil)
+			},
+		},
+		{
+			name: "cause is not commercial",
+			requestBody: dto.BuyCauseRequest{
+				CauseID: "cause123",
+				Amount:  25.0,
+			},
+			expectedStatus: http.StatusBadRequest,
+			mockSetup: func() {
+				mockCauseRepo.On("GetByID", "cause123").Return(notForSale, nil)
+			},
+		},
+		{
+			name: "cause not found",
+			requestBody: dto.BuyCauseRequest{
+				CauseID: "missing",
+				Amount:  25.0,
+			},
+			expectedStatus: http.StatusNotFound,
+			mockSetup: func() {
+				mockCauseRepo.On("GetByID", "missing").Return(nil, errors.New("record not found"))
+			},
+		},
+		{
+			name:   "user not authenticated",
+			router: createUnauthenticatedCauseInteractionRouter(t),
 			requestBody: dto.BuyCauseRequest{
 				CauseID: "cause123",
 				Amount:  50.0,
 			},
 			expectedStatus: http.StatusUnauthorized,
+			mockSetup:      func() {},
+		},
+		{
+			name:   "orders not enabled",
+			router: disabledRouter,
+			requestBody: dto.BuyCauseRequest{
+				CauseID: "cause123",
+				Amount:  25.0,
+			},
+			expectedStatus: http.StatusServiceUnavailable,
 			mockSetup: func() {
-				// This test will use a modified router without authentication
+				disabledCauseRepo.On("GetByID", "cause123").Return(forSale, nil)
 			},
 		},
 		{
-			name: "repository error on create purchase",
+			name: "repository error on create order",
 			requestBody: dto.BuyCauseRequest{
 				CauseID: "cause123",
-				Amount:  50.0,
+				Amount:  25.0,
 			},
 			expectedStatus: http.StatusInternalServerError,
 			mockSetup: func() {
-				mockCauseBuyerRepo.On("Create", mock.Anything).Return(errors.New("repository error"))
+				mockCauseRepo.On("GetByID", "cause123").Return(forSale, nil)
+				mockOrderRepo.On("Create", mock.Anything).Return(errors.New("repository error"))
 			},
 		},
 	}
@@ -495,15 +574,13 @@ func TestChallengeHandler_BuyCause(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Clear previous expectations
-			mockCauseBuyerRepo.ExpectedCalls = nil
+			mockCauseRepo.ExpectedCalls = nil
+			mockOrderRepo.ExpectedCalls = nil
 
 			tt.mockSetup()
 
-			var testRouter *gin.Engine
-			if tt.name == "user not authenticated" {
-				// Create a separate router without authentication for this test
-				testRouter = createUnauthenticatedCauseInteractionRouter(t)
-			} else {
+			testRouter := tt.router
+			if testRouter == nil {
 				testRouter = router
 			}
 
@@ -515,7 +592,8 @@ func TestChallengeHandler_BuyCause(t *testing.T) {
 			testRouter.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
-			mockCauseBuyerRepo.AssertExpectations(t)
+			mockCauseRepo.AssertExpectations(t)
+			mockOrderRepo.AssertExpectations(t)
 		})
 	}
 }
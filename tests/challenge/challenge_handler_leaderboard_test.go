@@ -74,7 +74,7 @@ func TestChallengeHandler_GetLeaderboard(t *testing.T) {
 						OwnerID:         "user1",
 						DistanceToCover: 10.5,
 						DistanceCovered: 8.2,
-						Duration:        "45:30",
+						Duration:        45*time.Minute + 30*time.Second,
 						MoneyRaised:     25.0,
 						Activity:        "Walking",
 						CoverImage:      "image1.jpg",
@@ -89,7 +89,7 @@ func TestChallengeHandler_GetLeaderboard(t *testing.T) {
 						OwnerID:         "user2",
 						DistanceToCover: 15.0,
 						DistanceCovered: 12.5,
-						Duration:        "60:00",
+						Duration:        time.Hour,
 						MoneyRaised:     40.0,
 						Activity:        "Running",
 						CoverImage:      "image2.jpg",
@@ -145,7 +145,7 @@ func TestChallengeHandler_GetLeaderboard(t *testing.T) {
 						OwnerID:         "user1",
 						DistanceToCover: 10.5,
 						DistanceCovered: 8.2,
-						Duration:        "45:30",
+						Duration:        45*time.Minute + 30*time.Second,
 						MoneyRaised:     25.0,
 						Activity:        "Walking",
 					},
@@ -159,7 +159,7 @@ func TestChallengeHandler_GetLeaderboard(t *testing.T) {
 						OwnerID:         "user2",
 						DistanceToCover: 15.0,
 						DistanceCovered: 12.5,
-						Duration:        "60:00",
+						Duration:        time.Hour,
 						MoneyRaised:     40.0,
 						Activity:        "Running",
 					},
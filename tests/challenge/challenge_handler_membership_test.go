@@ -88,9 +88,8 @@ func TestChallengeHandler_JoinChallenge(t *testing.T) {
 				}
 
 				mockChallengeRepo.On("GetByID", "challenge123").Return(expectedChallenge, nil)
-				mockChallengeRepo.On("Update", mock.MatchedBy(func(c *challengeModel.Challenge) bool {
-					return c.ID == "challenge123"
-				})).Return(nil)
+				mockChallengeRepo.On("IsMember", "challenge123", mock.Anything).Return(false, nil)
+				mockChallengeRepo.On("AddMember", "challenge123", mock.Anything).Return(nil)
 			},
 		},
 		{
@@ -101,6 +100,15 @@ func TestChallengeHandler_JoinChallenge(t *testing.T) {
 				mockChallengeRepo.On("GetByID", "nonexistent").Return(nil, errors.New("challenge not found"))
 			},
 		},
+		{
+			name:           "already a member",
+			challengeID:    "challenge123",
+			expectedStatus: http.StatusConflict,
+			mockSetup: func() {
+				mockChallengeRepo.On("GetByID", "challenge123").Return(&challengeModel.Challenge{Base: model.Base{ID: "challenge123"}, OwnerID: "owner123"}, nil)
+				mockChallengeRepo.On("IsMember", "challenge123", "test-user-id").Return(true, nil)
+			},
+		},
 		{
 			name:           "user not authenticated",
 			challengeID:    "challenge123",
@@ -110,7 +118,7 @@ func TestChallengeHandler_JoinChallenge(t *testing.T) {
 			},
 		},
 		{
-			name:           "repository error on update",
+			name:           "repository error on add member",
 			challengeID:    "challenge123",
 			expectedStatus: http.StatusInternalServerError,
 			mockSetup: func() {
@@ -126,7 +134,8 @@ func TestChallengeHandler_JoinChallenge(t *testing.T) {
 				}
 
 				mockChallengeRepo.On("GetByID", "challenge123").Return(expectedChallenge, nil)
-				mockChallengeRepo.On("Update", mock.Anything).Return(errors.New("repository error"))
+				mockChallengeRepo.On("IsMember", "challenge123", mock.Anything).Return(false, nil)
+				mockChallengeRepo.On("AddMember", "challenge123", mock.Anything).Return(errors.New("repository error"))
 			},
 		},
 	}
@@ -0,0 +1,461 @@
+package challenge_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	challenge "gopi.com/internal/app/challenge"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	"gopi.com/internal/data/challenge/repo"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	challengeMocks "gopi.com/tests/mocks/challenge"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+var judgingRubric = []challengeModel.Criterion{
+	{Name: "Impact", Weight: 3, MaxScore: 10},
+	{Name: "Feasibility", Weight: 1, MaxScore: 5},
+}
+
+func TestJudgingRound_Validate(t *testing.T) {
+	opens := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	valid := func() *challengeModel.JudgingRound {
+		return &challengeModel.JudgingRound{
+			Name:     " Pitches ",
+			OpensAt:  opens,
+			ClosesAt: opens.Add(48 * time.Hour),
+			Rubric:   append([]challengeModel.Criterion(nil), judgingRubric...),
+		}
+	}
+
+	round := valid()
+	require.NoError(t, round.Validate())
+	assert.Equal(t, "Pitches", round.Name)
+
+	tests := []struct {
+		name   string
+		change func(*challengeModel.JudgingRound)
+		want   error
+	}{
+		{"no name", func(r *challengeModel.JudgingRound) { r.Name = " " }, challengeModel.ErrInvalidRound},
+		{"closes before it opens", func(r *challengeModel.JudgingRound) { r.ClosesAt = r.OpensAt }, challengeModel.ErrInvalidRound},
+		{"vote share above 1", func(r *challengeModel.JudgingRound) { r.VoteShare = 1.5 }, challengeModel.ErrInvalidVoteShare},
+		{"empty rubric", func(r *challengeModel.JudgingRound) { r.Rubric = nil }, challengeModel.ErrInvalidRubric},
+		{"repeated criterion", func(r *challengeModel.JudgingRound) { r.Rubric[1].Name = "impact" }, challengeModel.ErrInvalidRubric},
+		{"criterion without weight", func(r *challengeModel.JudgingRound) { r.Rubric[0].Weight = 0 }, challengeModel.ErrInvalidRubric},
+		{"criterion without maximum", func(r *challengeModel.JudgingRound) { r.Rubric[0].MaxScore = 0 }, challengeModel.ErrInvalidRubric},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			round := valid()
+			tt.change(round)
+			assert.ErrorIs(t, round.Validate(), tt.want)
+		})
+	}
+}
+
+func TestJudgingRound_StatusAndScore(t *testing.T) {
+	opens := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	round := &challengeModel.JudgingRound{OpensAt: opens, ClosesAt: opens.Add(time.Hour), Rubric: judgingRubric}
+
+	assert.Equal(t, challengeModel.RoundScheduled, round.Status(opens.Add(-time.Second)))
+	assert.Equal(t, challengeModel.RoundOpen, round.Status(opens))
+	assert.Equal(t, challengeModel.RoundClosed, round.Status(opens.Add(time.Hour)))
+
+	total, err := round.Score(map[string]float64{"impact": 10, " Feasibility": 0})
+	require.NoError(t, err)
+	assert.InDelta(t, 75, total, 1e-9, "criteria count by weight and names match case-insensitively")
+
+	total, err = round.Score(map[string]float64{"Impact": 5, "Feasibility": 5})
+	require.NoError(t, err)
+	assert.InDelta(t, 62.5, total, 1e-9)
+
+	for _, scores := range []map[string]float64{
+		{"Impact": 5},
+		{"Impact": 11, "Feasibility": 1},
+		{"Impact": -1, "Feasibility": 1},
+		{"Impact": 5, "Design": 1},
+	} {
+		_, err := round.Score(scores)
+		assert.ErrorIs(t, err, challengeModel.ErrInvalidScore, "%v", scores)
+	}
+}
+
+func TestJudgingRound_Tally(t *testing.T) {
+	round := &challengeModel.JudgingRound{Base: model.Base{ID: "round"}, VoteShare: 0.25, Final: true}
+	judges := []*challengeModel.Judge{{UserID: "lead", Weight: 3}, {UserID: "guest", Weight: 1}}
+	scores := []*challengeModel.JudgeScore{
+		{CauseID: "a", JudgeID: "lead", Total: 80},
+		{CauseID: "a", JudgeID: "guest", Total: 40},
+		{CauseID: "b", JudgeID: "lead", Total: 60},
+		{CauseID: "b", JudgeID: "removed", Total: 100},
+		{CauseID: "c", JudgeID: "guest", Total: 40},
+	}
+	votes := map[string]int{"b": 4, "c": 2, "d": 1}
+	challenge := &challengeModel.Challenge{CausePrice: []challengeModel.Prize{{Rank: 1, Description: "seed grant"}, {Rank: 2, Description: "mentoring"}}}
+
+	results := round.Tally(judges, scores, votes, challenge)
+	require.Len(t, results, 4)
+
+	var order []string
+	for i, res := range results {
+		order = append(order, res.CauseID)
+		assert.Equal(t, i+1, res.Rank)
+		assert.Equal(t, "round", res.RoundID)
+	}
+	assert.Equal(t, []string{"b", "a", "c", "d"}, order)
+
+	// a: judges (3*80 + 1*40) / 4 = 70, no votes: 0.75*70 = 52.5
+	assert.InDelta(t, 70, results[1].JudgeScore, 1e-9)
+	assert.InDelta(t, 52.5, results[1].Score, 1e-9)
+	// b: the removed judge's score is dropped; 0.75*60 + 0.25*100 = 70
+	assert.InDelta(t, 60, results[0].JudgeScore, 1e-9)
+	assert.Equal(t, 4, results[0].Votes)
+	assert.InDelta(t, 70, results[0].Score, 1e-9)
+	// d: votes only, 0.25 * 25 = 6.25
+	assert.InDelta(t, 6.25, results[3].Score, 1e-9)
+
+	assert.Equal(t, &challengeModel.Prize{Rank: 1, Description: "seed grant"}, results[0].Prize)
+	assert.Equal(t, &challengeModel.Prize{Rank: 2, Description: "mentoring"}, results[1].Prize)
+	assert.Nil(t, results[2].Prize, "places past CausePrice win nothing")
+
+	round.Final = false
+	for _, res := range round.Tally(judges, scores, votes, challenge) {
+		assert.Nil(t, res.Prize, "only the final round awards prizes")
+	}
+}
+
+type judgingFixture struct {
+	db        *gorm.DB
+	service   *challenge.ChallengeService
+	challenge *challengeModel.Challenge
+	causes    [3]*challengeModel.Cause
+	elsewhere *challengeModel.Cause
+	round     *challengeModel.JudgingRound
+}
+
+func newJudgingFixture(t *testing.T) *judgingFixture {
+	db := testdb.Open(t, serviceTables(&gormmodel.JudgingRound{}, &gormmodel.JudgingJudge{}, &gormmodel.JudgingScore{},
+		&gormmodel.JudgingVote{}, &gormmodel.JudgingResult{})...)
+
+	challengeRepo := repo.NewGormChallengeRepository(db)
+	causeRepo := repo.NewGormCauseRepository(db)
+	f := &judgingFixture{db: db}
+	f.service = challenge.NewChallengeService(challengeRepo, causeRepo, repo.NewGormCauseRunnerRepository(db),
+		repo.NewGormSponsorChallengeRepository(db), repo.NewGormSponsorCauseRepository(db), repo.NewGormCauseBuyerRepository(db),
+		challenge.WithUnitOfWork(repo.NewGormUnitOfWork(db)),
+		challenge.WithJudging(repo.NewGormJudgingRepository(db)))
+
+	f.challenge = &challengeModel.Challenge{OwnerID: "owner", Name: "ideas", Slug: "ideas", Mode: challengeModel.ChallengeModeF,
+		CausePrice: []challengeModel.Prize{
+			{Rank: 1, Amount: 5000, Currency: "USD", Description: "seed grant"}, {Rank: 2, Description: "mentoring"}}}
+	require.NoError(t, challengeRepo.Create(f.challenge))
+	other := &challengeModel.Challenge{OwnerID: "owner", Name: "other", Slug: "other", Mode: challengeModel.ChallengeModeF}
+	require.NoError(t, challengeRepo.Create(other))
+	for i, slug := range []string{"solar", "water", "books"} {
+		f.causes[i] = &challengeModel.Cause{ChallengeID: f.challenge.ID, Name: slug, Slug: slug, OwnerID: "owner",
+			Problem: "problem", Solution: "solution"}
+		require.NoError(t, causeRepo.Create(f.causes[i]))
+	}
+	f.elsewhere = &challengeModel.Cause{ChallengeID: other.ID, Name: "elsewhere", Slug: "elsewhere", OwnerID: "owner"}
+	require.NoError(t, causeRepo.Create(f.elsewhere))
+	for _, member := range []string{"m1", "m2", "m3"} {
+		require.NoError(t, challengeRepo.AddMember(f.challenge.ID, member))
+	}
+
+	now := time.Now()
+	f.round = &challengeModel.JudgingRound{
+		ChallengeID: f.challenge.ID,
+		Name:        "Final pitches",
+		OpensAt:     now.Add(-time.Hour),
+		ClosesAt:    now.Add(time.Hour),
+		Rubric:      append([]challengeModel.Criterion(nil), judgingRubric...),
+		VoteShare:   0.5,
+		Final:       true,
+	}
+	require.NoError(t, f.service.CreateJudgingRound(f.round))
+	return f
+}
+
+func TestChallengeService_Judging_SQLite(t *testing.T) {
+	f := newJudgingFixture(t)
+	now := time.Now()
+
+	second := &challengeModel.JudgingRound{ChallengeID: f.challenge.ID, Name: "Encore", OpensAt: now, ClosesAt: now.Add(time.Hour),
+		Rubric: judgingRubric, Final: true}
+	assert.ErrorIs(t, f.service.CreateJudgingRound(second), challengeModel.ErrFinalRoundExists)
+
+	_, err := f.service.AppointJudge(f.round.ID, "lead", 3, "staff")
+	require.NoError(t, err)
+	judge, err := f.service.AppointJudge(f.round.ID, "guest", 0, "staff")
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, judge.Weight, "weight defaults to 1")
+	_, err = f.service.AppointJudge(f.round.ID, "guest", 1, "staff")
+	assert.ErrorIs(t, err, challengeModel.ErrAlreadyJudge)
+	_, err = f.service.AppointJudge(f.round.ID, "temp", 1, "staff")
+	require.NoError(t, err)
+
+	score := func(judgeID string, cause *challengeModel.Cause, impact, feasibility float64) error {
+		_, err := f.service.ScoreCause(f.round.ID, judgeID, cause.ID,
+			map[string]float64{"Impact": impact, "Feasibility": feasibility}, "", now)
+		return err
+	}
+	require.NoError(t, score("lead", f.causes[0], 2, 1))
+	require.NoError(t, score("lead", f.causes[0], 8, 4), "rescoring replaces the earlier score")
+	require.NoError(t, score("guest", f.causes[0], 4, 2))
+	require.NoError(t, score("lead", f.causes[1], 6, 3))
+	require.NoError(t, score("temp", f.causes[1], 10, 5))
+	assert.ErrorIs(t, score("m1", f.causes[0], 5, 5), challengeModel.ErrNotJudge)
+	assert.ErrorIs(t, score("lead", f.elsewhere, 5, 5), challengeModel.ErrCauseNotInChallenge)
+	_, err = f.service.ScoreCause(f.round.ID, "lead", f.causes[2].ID, map[string]float64{"Impact": 5}, "", now)
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidScore)
+	_, err = f.service.ScoreCause(f.round.ID, "lead", f.causes[2].ID, map[string]float64{"Impact": 5, "Feasibility": 5}, "", now.Add(2*time.Hour))
+	assert.ErrorIs(t, err, challengeModel.ErrRoundNotOpen)
+
+	scores, err := f.service.ListJudgeScores(f.round.ID)
+	require.NoError(t, err)
+	assert.Len(t, scores, 4)
+
+	require.NoError(t, f.service.RemoveJudge(f.round.ID, "temp"))
+	assert.ErrorIs(t, f.service.RemoveJudge(f.round.ID, "temp"), challengeModel.ErrNotJudge)
+
+	vote := func(userID string, cause *challengeModel.Cause) error {
+		_, err := f.service.CastCauseVote(f.round.ID, userID, cause.ID, now)
+		return err
+	}
+	require.NoError(t, vote("m1", f.causes[1]))
+	require.NoError(t, vote("m2", f.causes[1]))
+	require.NoError(t, vote("m3", f.causes[2]))
+	assert.ErrorIs(t, vote("m1", f.causes[0]), challengeModel.ErrAlreadyVoted, "one vote per member per round")
+	assert.ErrorIs(t, vote("outsider", f.causes[0]), challengeModel.ErrNotMember)
+
+	_, err = f.service.GetJudgingResults(f.round.ID)
+	assert.ErrorIs(t, err, challengeModel.ErrRoundNotClosed)
+	round, results, err := f.service.GetCauseAwards(f.challenge.ID)
+	require.NoError(t, err)
+	assert.Nil(t, round)
+	assert.Empty(t, results)
+
+	closed, err := f.service.CloseDueJudgingRounds(now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, closed, "open rounds are left alone")
+	closed, err = f.service.CloseDueJudgingRounds(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, closed)
+	closed, err = f.service.CloseDueJudgingRounds(now.Add(3 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, closed, "rounds are tallied once")
+
+	results, err = f.service.GetJudgingResults(f.round.ID)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	// water: judge 60 (temp's score dropped), votes 2/2: 0.5*60 + 0.5*100 = 80
+	// solar: judges (3*80 + 1*40) / 4 = 70, no votes: 35
+	// books: votes only 1/2: 25
+	assert.Equal(t, f.causes[1].ID, results[0].CauseID)
+	assert.InDelta(t, 80, results[0].Score, 1e-9)
+	assert.Equal(t, f.causes[0].ID, results[1].CauseID)
+	assert.InDelta(t, 70, results[1].JudgeScore, 1e-9)
+	assert.InDelta(t, 35, results[1].Score, 1e-9)
+	assert.Equal(t, f.causes[2].ID, results[2].CauseID)
+	assert.Equal(t, 1, results[2].Votes)
+	assert.Equal(t, &challengeModel.Prize{Rank: 1, Amount: 5000, Currency: "USD", Description: "seed grant"}, results[0].Prize)
+	assert.Equal(t, &challengeModel.Prize{Rank: 2, Description: "mentoring"}, results[1].Prize)
+	assert.Nil(t, results[2].Prize)
+
+	round, awards, err := f.service.GetCauseAwards(f.challenge.ID)
+	require.NoError(t, err)
+	require.NotNil(t, round)
+	assert.NotNil(t, round.ClosedAt)
+	assert.Equal(t, results, awards)
+
+	_, err = f.service.AppointJudge(f.round.ID, "late", 1, "staff")
+	assert.ErrorIs(t, err, challengeModel.ErrRoundClosed)
+}
+
+func TestGormJudgingRepository_OneFinalRoundPerChallenge_SQLite(t *testing.T) {
+	f := newJudgingFixture(t)
+	judgingRepo := repo.NewGormJudgingRepository(f.db)
+	now := time.Now()
+
+	// The index holds even when the service's check is raced past.
+	final := &challengeModel.JudgingRound{ChallengeID: f.challenge.ID, Name: "Encore", OpensAt: now, ClosesAt: now.Add(time.Hour),
+		Rubric: judgingRubric, Final: true}
+	assert.Error(t, judgingRepo.CreateRound(final))
+
+	heat := &challengeModel.JudgingRound{ChallengeID: f.challenge.ID, Name: "Heat", OpensAt: now, ClosesAt: now.Add(time.Hour),
+		Rubric: judgingRubric}
+	require.NoError(t, judgingRepo.CreateRound(heat))
+	heat2 := &challengeModel.JudgingRound{ChallengeID: f.challenge.ID, Name: "Heat 2", OpensAt: now, ClosesAt: now.Add(time.Hour),
+		Rubric: judgingRubric}
+	require.NoError(t, judgingRepo.CreateRound(heat2), "only final rounds are limited")
+}
+
+func TestChallengeService_Judging_NotEnabled(t *testing.T) {
+	service := challenge.NewChallengeService(new(challengeMocks.MockChallengeRepository), new(challengeMocks.MockCauseRepository),
+		new(challengeMocks.MockCauseRunnerRepository), nil, nil, nil)
+
+	_, err := service.CastCauseVote("round", "user", "cause", time.Now())
+	assert.ErrorIs(t, err, challengeModel.ErrJudgingNotEnabled)
+	closed, err := service.CloseDueJudgingRounds(time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, closed, "rounds are not tallied without judging")
+}
+
+func TestChallengeService_CloseJudgingRound_AlreadyClosed(t *testing.T) {
+	judgingRepo := new(challengeMocks.MockJudgingRepository)
+	service := challenge.NewChallengeService(new(challengeMocks.MockChallengeRepository), new(challengeMocks.MockCauseRepository),
+		new(challengeMocks.MockCauseRunnerRepository), nil, nil, nil, challenge.WithJudging(judgingRepo))
+
+	now := time.Now()
+	round := &challengeModel.JudgingRound{Base: model.Base{ID: "round"}, ChallengeID: "challenge"}
+	judgingRepo.On("ListDueRounds", now).Return([]*challengeModel.JudgingRound{round}, nil)
+	judgingRepo.On("MarkRoundClosed", "round", now).Return(false, nil)
+
+	closed, err := service.CloseDueJudgingRounds(now)
+	require.NoError(t, err)
+	assert.Zero(t, closed, "a round closed by another instance is not tallied again")
+	judgingRepo.AssertNotCalled(t, "SaveResults", mock.Anything, mock.Anything)
+}
+
+func TestChallengeHandler_JudgingEndpoints_SQLite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := newJudgingFixture(t)
+
+	userRepo := new(userMocks.MockUserRepository)
+	userRepo.On("GetByID", "lead").Return(&userModel.User{Base: model.Base{ID: "lead"}, Username: "lead"}, nil).Maybe()
+	userRepo.On("GetByID", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Maybe()
+	h := handler.NewChallengeHandler(f.service, user.NewUserService(userRepo, nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-User"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+	router.POST("/challenges/admin/:challenge_id/rounds", h.CreateJudgingRound)
+	router.POST("/challenges/admin/rounds/:round_id/judges", h.AppointJudge)
+	router.GET("/challenges/:challenge_id/rounds", h.GetJudgingRounds)
+	router.GET("/challenges/:challenge_id/cause-awards", h.GetCauseAwards)
+	router.GET("/challenges/rounds/:round_id", h.GetJudgingRound)
+	router.GET("/challenges/rounds/:round_id/results", h.GetJudgingResults)
+	router.PUT("/challenges/rounds/:round_id/scores", h.ScoreCause)
+	router.POST("/challenges/rounds/:round_id/votes", h.CastCauseVote)
+
+	send := func(method, path, userID string, body interface{}) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User", userID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	now := time.Now().UTC()
+	w := send(http.MethodPost, "/challenges/admin/"+f.challenge.ID+"/rounds", "staff", dto.CreateJudgingRoundRequest{
+		Name:     "Shortlist",
+		OpensAt:  now.Add(time.Hour),
+		ClosesAt: now.Add(2 * time.Hour),
+		Rubric:   []dto.JudgingCriterion{{Name: "Impact", Weight: 1, MaxScore: 10}},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created dto.JudgingRoundResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "scheduled", created.Status)
+
+	w = send(http.MethodPost, "/challenges/admin/"+f.challenge.ID+"/rounds", "staff", dto.CreateJudgingRoundRequest{
+		Name: "Backwards", OpensAt: now, ClosesAt: now.Add(-time.Hour),
+		Rubric: []dto.JudgingCriterion{{Name: "Impact", Weight: 1, MaxScore: 10}},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = send(http.MethodGet, "/challenges/"+f.challenge.ID+"/rounds", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list dto.JudgingRoundListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Rounds, 2)
+	assert.Equal(t, f.round.ID, list.Rounds[0].ID, "rounds are listed in the order they open")
+	assert.Equal(t, "open", list.Rounds[0].Status)
+
+	roundPath := "/challenges/rounds/" + f.round.ID
+	w = send(http.MethodPost, "/challenges/admin/rounds/"+f.round.ID+"/judges", "staff", dto.AppointJudgeRequest{UserID: "lead", Weight: 2})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, send(http.MethodPost, "/challenges/admin/rounds/"+f.round.ID+"/judges", "staff",
+		dto.AppointJudgeRequest{UserID: "ghost"}).Code)
+	assert.Equal(t, http.StatusConflict, send(http.MethodPost, "/challenges/admin/rounds/"+f.round.ID+"/judges", "staff",
+		dto.AppointJudgeRequest{UserID: "lead"}).Code)
+
+	w = send(http.MethodGet, roundPath, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var detail dto.JudgingRoundResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+	require.Len(t, detail.Judges, 1)
+	assert.Equal(t, "lead", detail.Judges[0].Username)
+	assert.Len(t, detail.Rubric, 2)
+
+	w = send(http.MethodPut, roundPath+"/scores", "lead", dto.ScoreCauseRequest{
+		CauseID: f.causes[0].ID, Scores: map[string]float64{"Impact": 10, "Feasibility": 5}, Comment: "strong pitch",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var scored dto.JudgeScoreResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &scored))
+	assert.InDelta(t, 100, scored.Total, 1e-9)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPut, roundPath+"/scores", "m1", dto.ScoreCauseRequest{
+		CauseID: f.causes[0].ID, Scores: map[string]float64{"Impact": 1, "Feasibility": 1},
+	}).Code)
+	assert.Equal(t, http.StatusBadRequest, send(http.MethodPut, roundPath+"/scores", "lead", dto.ScoreCauseRequest{
+		CauseID: f.causes[0].ID, Scores: map[string]float64{"Impact": 20, "Feasibility": 1},
+	}).Code)
+
+	assert.Equal(t, http.StatusCreated, send(http.MethodPost, roundPath+"/votes", "m1", dto.CastVoteRequest{CauseID: f.causes[1].ID}).Code)
+	assert.Equal(t, http.StatusConflict, send(http.MethodPost, roundPath+"/votes", "m1", dto.CastVoteRequest{CauseID: f.causes[1].ID}).Code)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, roundPath+"/votes", "outsider", dto.CastVoteRequest{CauseID: f.causes[1].ID}).Code)
+	assert.Equal(t, http.StatusBadRequest, send(http.MethodPost, roundPath+"/votes", "m2", dto.CastVoteRequest{CauseID: f.elsewhere.ID}).Code)
+	assert.Equal(t, http.StatusNotFound, send(http.MethodPost, roundPath+"/votes", "m2", dto.CastVoteRequest{CauseID: "missing"}).Code)
+	assert.Equal(t, http.StatusConflict, send(http.MethodPost, "/challenges/rounds/"+created.ID+"/votes", "m2",
+		dto.CastVoteRequest{CauseID: f.causes[1].ID}).Code, "the shortlist round has not opened")
+
+	assert.Equal(t, http.StatusConflict, send(http.MethodGet, roundPath+"/results", "", nil).Code)
+	assert.Equal(t, http.StatusNotFound, send(http.MethodGet, "/challenges/rounds/missing/results", "", nil).Code)
+
+	_, err := f.service.CloseDueJudgingRounds(now.Add(90 * time.Minute))
+	require.NoError(t, err)
+
+	w = send(http.MethodGet, "/challenges/"+f.challenge.ID+"/cause-awards", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var awards dto.JudgingResultsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &awards))
+	assert.Equal(t, f.round.ID, awards.RoundID)
+	require.Len(t, awards.Results, 2)
+	// solar: judged 100, no votes: 50; water: votes only: 50. Ties go to the judges.
+	assert.Equal(t, "solar", awards.Results[0].CauseName)
+	assert.Equal(t, "water", awards.Results[1].CauseName)
+	assert.Equal(t, &dto.Prize{Rank: 2, Description: "mentoring"}, awards.Results[1].Prize)
+
+	w = send(http.MethodGet, roundPath+"/results", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var results dto.JudgingResultsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+	assert.Equal(t, awards.Results, results.Results)
+}
@@ -0,0 +1,225 @@
+package challenge_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	challenge "gopi.com/internal/app/challenge"
+	"gopi.com/internal/app/user"
+	"gopi.com/internal/data/challenge/repo"
+	activityModel "gopi.com/internal/domain/activity/model"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	"gopi.com/internal/lib/leaderboard"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+// invalidationStore never holds a board and records which scopes were invalidated.
+type invalidationStore struct {
+	invalidated []leaderboard.Scope
+}
+
+func (s *invalidationStore) Range(context.Context, leaderboard.Board, int, int) ([]*leaderboard.Entry, int, bool, error) {
+	return nil, 0, false, nil
+}
+
+func (s *invalidationStore) Find(context.Context, leaderboard.Board, string) (*leaderboard.Entry, error) {
+	return nil, nil
+}
+
+func (s *invalidationStore) Save(context.Context, leaderboard.Board, []*leaderboard.Entry, time.Duration) error {
+	return nil
+}
+
+func (s *invalidationStore) Invalidate(_ context.Context, scope leaderboard.Scope) error {
+	s.invalidated = append(s.invalidated, scope)
+	return nil
+}
+
+type leaderboardFixture struct {
+	service   *challenge.ChallengeService
+	challenge *challengeModel.Challenge
+	causes    [2]*challengeModel.Cause
+	store     *invalidationStore
+}
+
+func newLeaderboardFixture(t *testing.T) *leaderboardFixture {
+	db := testdb.Open(t, serviceTables()...)
+
+	challengeRepo := repo.NewGormChallengeRepository(db)
+	causeRepo := repo.NewGormCauseRepository(db)
+	runnerRepo := repo.NewGormCauseRunnerRepository(db)
+	store := &invalidationStore{}
+	service := challenge.NewChallengeService(challengeRepo, causeRepo, runnerRepo,
+		repo.NewGormSponsorChallengeRepository(db), repo.NewGormSponsorCauseRepository(db), repo.NewGormCauseBuyerRepository(db),
+		challenge.WithLeaderboards(leaderboard.New(store, time.Minute)))
+
+	f := &leaderboardFixture{service: service, store: store}
+	f.challenge = &challengeModel.Challenge{OwnerID: "owner", Name: "boards", Slug: "boards", Mode: challengeModel.ChallengeModeF}
+	require.NoError(t, challengeRepo.Create(f.challenge))
+	other := &challengeModel.Challenge{OwnerID: "owner", Name: "other", Slug: "other", Mode: challengeModel.ChallengeModeF}
+	require.NoError(t, challengeRepo.Create(other))
+	for i, slug := range []string{"first", "second"} {
+		f.causes[i] = &challengeModel.Cause{ChallengeID: f.challenge.ID, Name: slug, Slug: slug, OwnerID: "owner"}
+		require.NoError(t, causeRepo.Create(f.causes[i]))
+	}
+	elsewhere := &challengeModel.Cause{ChallengeID: other.ID, Name: "elsewhere", Slug: "elsewhere", OwnerID: "owner"}
+	require.NoError(t, causeRepo.Create(elsewhere))
+
+	now := time.Now()
+	longAgo := now.AddDate(0, 0, -40)
+	for _, r := range []struct {
+		cause    *challengeModel.Cause
+		owner    string
+		activity string
+		distance float64
+		at       time.Time
+		status   activityModel.ReviewStatus
+	}{
+		{f.causes[0], "alice", "Run", 5, now, ""},
+		{f.causes[1], "alice", "run", 3, now, activityModel.ReviewStatusAccepted},
+		{f.causes[0], "bob", "Walk", 7, now, activityModel.ReviewStatusApproved},
+		{f.causes[1], "carol", "Run", 20, longAgo, ""},
+		{f.causes[0], "dave", "Run", 50, now, activityModel.ReviewStatusFlagged},
+		{elsewhere, "erin", "Run", 100, now, ""},
+	} {
+		require.NoError(t, runnerRepo.Create(&challengeModel.CauseRunner{
+			Base:            model.Base{CreatedAt: r.at},
+			CauseID:         r.cause.ID,
+			OwnerID:         r.owner,
+			Activity:        r.activity,
+			DistanceCovered: r.distance,
+			Duration:        time.Duration(r.distance) * 6 * time.Minute,
+			DateJoined:      r.at,
+			Status:          r.status,
+		}))
+	}
+	return f
+}
+
+func leaderboardUsers(standings *leaderboard.Standings) []string {
+	var users []string
+	for _, e := range standings.Entries {
+		users = append(users, e.UserID)
+	}
+	return users
+}
+
+func TestChallengeService_Leaderboards_SQLite(t *testing.T) {
+	f := newLeaderboardFixture(t)
+
+	standings, err := f.service.ChallengeLeaderboard(f.challenge.ID, leaderboard.Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carol", "alice", "bob"}, leaderboardUsers(standings),
+		"held runs and other challenges are left out")
+	assert.Equal(t, 8.0, standings.Entries[1].Distance, "runs are totalled across the challenge's causes")
+	assert.Equal(t, 2, standings.Entries[1].Runs)
+
+	standings, err = f.service.ChallengeLeaderboard(f.challenge.ID, leaderboard.Query{Window: leaderboard.WindowWeekly})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, leaderboardUsers(standings))
+
+	standings, err = f.service.ChallengeLeaderboard(f.challenge.ID, leaderboard.Query{Activity: "WALK"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob"}, leaderboardUsers(standings))
+
+	standings, err = f.service.CauseLeaderboard(f.causes[1].ID, leaderboard.Query{UserID: "alice", Neighbours: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carol", "alice"}, leaderboardUsers(standings))
+	require.NotNil(t, standings.Me)
+	assert.Equal(t, 2, standings.Me.Rank)
+	assert.Len(t, standings.Neighbours, 2)
+}
+
+func TestChallengeService_RecordCauseActivity_InvalidatesLeaderboards(t *testing.T) {
+	f := newLeaderboardFixture(t)
+
+	require.NoError(t, f.service.RecordCauseActivity(f.causes[0].ID, "bob", 10, 4, 20*time.Minute, "Walk"))
+	assert.ElementsMatch(t, []leaderboard.Scope{
+		{Kind: leaderboard.ScopeCause, ID: f.causes[0].ID},
+		{Kind: leaderboard.ScopeChallenge, ID: f.challenge.ID},
+	}, f.store.invalidated)
+
+	standings, err := f.service.CauseLeaderboard(f.causes[0].ID, leaderboard.Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob", "alice"}, leaderboardUsers(standings))
+	assert.Equal(t, 11.0, standings.Entries[0].Distance)
+}
+
+func TestChallengeHandler_LeaderboardEndpoints_SQLite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := newLeaderboardFixture(t)
+
+	userRepo := new(userMocks.MockUserRepository)
+	for _, id := range []string{"alice", "bob", "carol"} {
+		userRepo.On("GetByID", id).Return(&userModel.User{Base: model.Base{ID: id}, Username: id, FirstName: id, LastName: "Runner"}, nil).Maybe()
+	}
+	h := handler.NewChallengeHandler(f.service, user.NewUserService(userRepo, nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-User"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+	router.GET("/challenges/:challenge_id/leaderboard", h.GetChallengeLeaderboard)
+	router.GET("/causes/:id/leaderboard", h.GetCauseLeaderboard)
+
+	get := func(path, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-User", userID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/challenges/"+f.challenge.ID+"/leaderboard?window=daily&neighbours=1", "bob")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp dto.ChallengeLeaderboardResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, f.challenge.ID, resp.ChallengeID)
+	assert.Equal(t, "daily", resp.Window)
+	assert.Equal(t, 2, resp.Total)
+	require.Len(t, resp.Leaderboard, 2)
+	assert.Equal(t, 1, resp.Leaderboard[0].Rank)
+	assert.Equal(t, "alice", resp.Leaderboard[0].Username)
+	assert.Equal(t, "alice Runner", resp.Leaderboard[0].FullName)
+	assert.Equal(t, int64(48*60), resp.Leaderboard[0].DurationSeconds)
+	require.NotNil(t, resp.Me)
+	assert.Equal(t, 2, resp.Me.Rank)
+	assert.Len(t, resp.Neighbours, 2)
+
+	w = get("/challenges/"+f.challenge.ID+"/leaderboard?limit=1&page=2", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = dto.ChallengeLeaderboardResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 3, resp.Total)
+	require.Len(t, resp.Leaderboard, 1)
+	assert.Equal(t, "alice", resp.Leaderboard[0].UserID)
+	assert.Nil(t, resp.Me, "anonymous callers have no place")
+
+	w = get("/causes/"+f.causes[1].ID+"/leaderboard?activity=run", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var causeResp dto.CauseLeaderboardResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &causeResp))
+	assert.Equal(t, "run", causeResp.Activity)
+	assert.Equal(t, 2, causeResp.Total)
+
+	assert.Equal(t, http.StatusBadRequest, get("/challenges/"+f.challenge.ID+"/leaderboard?window=yearly", "").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/causes/"+f.causes[0].ID+"/leaderboard?neighbours=50", "").Code)
+	assert.Equal(t, http.StatusNotFound, get("/challenges/missing/leaderboard", "").Code)
+	assert.Equal(t, http.StatusNotFound, get("/causes/missing/leaderboard", "").Code)
+}
@@ -0,0 +1,312 @@
+package challenge_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	challenge "gopi.com/internal/app/challenge"
+	"gopi.com/internal/app/media"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	"gopi.com/internal/data/challenge/repo"
+	mediaGorm "gopi.com/internal/data/media/model/gorm"
+	mediaDataRepo "gopi.com/internal/data/media/repo"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+	mediaModel "gopi.com/internal/domain/media/model"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	"gopi.com/internal/lib/storage"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+type manageFixture struct {
+	db         *gorm.DB
+	service    *challenge.ChallengeService
+	challenges challengeRepo.ChallengeRepository
+	causes     challengeRepo.CauseRepository
+	galleries  *media.MediaService
+	challenge  *challengeModel.Challenge
+	cause      *challengeModel.Cause
+}
+
+func newManageFixture(t *testing.T) *manageFixture {
+	db := testdb.Open(t, serviceTables(&gormmodel.ChallengeSponsor{}, &gormmodel.CauseSponsorMember{},
+		&gormmodel.ChallengeWinner{}, &gormmodel.ChallengeWinnerAudit{},
+		&gormmodel.JudgingRound{}, &gormmodel.JudgingJudge{}, &gormmodel.JudgingScore{}, &gormmodel.JudgingVote{}, &gormmodel.JudgingResult{},
+		&gormmodel.CauseOrder{}, &gormmodel.SponsorStatement{}, &gormmodel.ChallengeBracket{}, &gormmodel.BracketMatch{},
+		&mediaGorm.MediaItem{})...)
+
+	f := &manageFixture{db: db, challenges: repo.NewGormChallengeRepository(db), causes: repo.NewGormCauseRepository(db)}
+	f.galleries = media.NewMediaService(mediaDataRepo.NewGormMediaRepository(db), storage.NewLocalStorage(t.TempDir(), "/uploads"),
+		media.WithChallenges(f.challenges, f.causes))
+	f.service = challenge.NewChallengeService(f.challenges, f.causes, repo.NewGormCauseRunnerRepository(db),
+		repo.NewGormSponsorChallengeRepository(db), repo.NewGormSponsorCauseRepository(db), repo.NewGormCauseBuyerRepository(db),
+		challenge.WithUnitOfWork(repo.NewGormUnitOfWork(db)),
+		challenge.WithWinners(repo.NewGormChallengeWinnerRepository(db), nil, nil, nil),
+		challenge.WithFunding(repo.NewGormSponsorStatementRepository(db)),
+		challenge.WithBrackets(repo.NewGormBracketRepository(db)),
+		challenge.WithGalleries(f.galleries))
+
+	f.challenge, f.cause = f.create(t, "harbour")
+	return f
+}
+
+// create adds a challenge with one cause, both owned by "owner".
+func (f *manageFixture) create(t *testing.T, name string) (*challengeModel.Challenge, *challengeModel.Cause) {
+	c := &challengeModel.Challenge{OwnerID: "owner", Name: name + " run", Slug: name + "-run", Mode: challengeModel.ChallengeModeF,
+		NoOfWinner: 1, RankingCriterion: challengeModel.RankByDistance}
+	require.NoError(t, f.challenges.Create(c))
+	cause := &challengeModel.Cause{ChallengeID: c.ID, Name: name + " cause", Slug: name + "-cause", OwnerID: "owner"}
+	require.NoError(t, f.causes.Create(cause))
+	return c, cause
+}
+
+func (f *manageFixture) count(t *testing.T, table interface{}, where string, args ...interface{}) int64 {
+	var n int64
+	require.NoError(t, f.db.Model(table).Where(where, args...).Count(&n).Error)
+	return n
+}
+
+// addVideo adds a video to the gallery of the challenge or cause.
+func (f *manageFixture) addVideo(t *testing.T, kind mediaModel.SubjectKind, subjectID string) {
+	_, err := f.galleries.AddVideo(kind, subjectID, "owner", "https://youtu.be/dQw4w9WgXcQ", "")
+	require.NoError(t, err)
+}
+
+func TestChallengeService_DeleteChallenge_SQLite(t *testing.T) {
+	f := newManageFixture(t)
+	other, otherCause := f.create(t, "canal")
+
+	for _, c := range []*challengeModel.Challenge{f.challenge, other} {
+		require.NoError(t, f.service.JoinChallenge(c.ID, "runner"))
+	}
+	for _, cause := range []*challengeModel.Cause{f.cause, otherCause} {
+		require.NoError(t, f.service.JoinCause(cause.ID, "runner"))
+		require.NoError(t, f.service.RecordCauseActivity(cause.ID, "runner", 10, 5, 30*time.Minute, "running"))
+	}
+	require.NoError(t, f.service.SponsorCause(f.cause.ID, "acme", 10, 2))
+	require.NoError(t, f.service.JoinChallenge(f.challenge.ID, "rival"))
+	_, _, err := f.service.CreateBracket(f.challenge.ID, challengeModel.SeedRandom, time.Now(), 0, time.Now())
+	require.NoError(t, err)
+	f.addVideo(t, mediaModel.SubjectChallenge, f.challenge.ID)
+	f.addVideo(t, mediaModel.SubjectCause, f.cause.ID)
+	f.addVideo(t, mediaModel.SubjectCause, otherCause.ID)
+
+	err = f.service.DeleteChallenge(f.challenge.ID, false)
+	assert.ErrorIs(t, err, challengeModel.ErrOutstandingSponsorships)
+	_, err = f.service.GetChallengeByID(f.challenge.ID)
+	require.NoError(t, err, "a refused delete leaves the challenge alone")
+
+	require.NoError(t, f.service.DeleteChallenge(f.challenge.ID, true))
+	_, err = f.service.GetChallengeByID(f.challenge.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = f.service.GetCauseByID(f.cause.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Zero(t, f.count(t, &gormmodel.ChallengeMember{}, "challenge_id = ?", f.challenge.ID))
+	assert.Zero(t, f.count(t, &gormmodel.CauseMember{}, "cause_id = ?", f.cause.ID))
+	assert.Zero(t, f.count(t, &gormmodel.CauseRunner{}, "cause_id = ?", f.cause.ID))
+	assert.Zero(t, f.count(t, &gormmodel.SponsorCause{}, "cause_id = ?", f.cause.ID))
+	assert.Zero(t, f.count(t, &gormmodel.ChallengeBracket{}, "challenge_id = ?", f.challenge.ID))
+	assert.Zero(t, f.count(t, &gormmodel.BracketMatch{}, "1 = 1"))
+	assert.Zero(t, f.count(t, &mediaGorm.MediaItem{}, "subject_id IN ?", []string{f.challenge.ID, f.cause.ID}))
+
+	// The other challenge keeps everything.
+	assert.Equal(t, int64(1), f.count(t, &gormmodel.ChallengeMember{}, "challenge_id = ?", other.ID))
+	assert.Equal(t, int64(1), f.count(t, &gormmodel.CauseMember{}, "cause_id = ?", otherCause.ID))
+	assert.Equal(t, int64(1), f.count(t, &gormmodel.CauseRunner{}, "cause_id = ?", otherCause.ID))
+	assert.Equal(t, int64(1), f.count(t, &mediaGorm.MediaItem{}, "subject_id = ?", otherCause.ID))
+}
+
+func TestChallengeService_DeleteCause_SQLite(t *testing.T) {
+	f := newManageFixture(t)
+	require.NoError(t, f.service.SponsorChallenge(f.challenge.ID, "acme", 10, 2))
+	f.addVideo(t, mediaModel.SubjectCause, f.cause.ID)
+
+	require.NoError(t, f.service.DeleteCause(f.cause.ID, false), "challenge sponsorships do not hold up a cause")
+	_, err := f.service.GetCauseByID(f.cause.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Zero(t, f.count(t, &mediaGorm.MediaItem{}, "subject_id = ?", f.cause.ID))
+
+	err = f.service.DeleteChallenge(f.challenge.ID, false)
+	assert.ErrorIs(t, err, challengeModel.ErrOutstandingSponsorships)
+}
+
+func TestChallengeService_DeleteKeepsSettledStatements_SQLite(t *testing.T) {
+	f := newManageFixture(t)
+	require.NoError(t, f.service.SponsorCause(f.cause.ID, "acme", 10, 2))
+	closed, err := f.service.CloseOutChallenge(f.challenge, time.Now())
+	require.NoError(t, err)
+	require.True(t, closed)
+	require.Equal(t, int64(1), f.count(t, &gormmodel.SponsorStatement{}, "cause_id = ?", f.cause.ID))
+
+	// Settled sponsors are no longer owed anything, but their statements must survive.
+	assert.ErrorIs(t, f.service.DeleteCause(f.cause.ID, true), challengeModel.ErrSponsorsSettled)
+	assert.ErrorIs(t, f.service.DeleteChallenge(f.challenge.ID, true), challengeModel.ErrSponsorsSettled)
+	assert.Equal(t, int64(1), f.count(t, &gormmodel.SponsorStatement{}, "cause_id = ?", f.cause.ID))
+
+	// A closed challenge without sponsors can still be deleted.
+	other, otherCause := f.create(t, "canal")
+	_, err = f.service.CloseOutChallenge(other, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, f.service.DeleteCause(otherCause.ID, false))
+	require.NoError(t, f.service.DeleteChallenge(other.ID, false))
+}
+
+func TestChallengeService_CancelChallenge_SQLite(t *testing.T) {
+	f := newManageFixture(t)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	require.NoError(t, f.challenge.SetEnd(past))
+	require.NoError(t, f.service.UpdateChallenge(f.challenge))
+	stale := *f.challenge
+
+	cancelled, err := f.service.CancelChallenge(f.challenge.ID, time.Now())
+	require.NoError(t, err)
+	require.NotNil(t, cancelled.CancelledAt)
+
+	_, err = f.service.CancelChallenge(f.challenge.ID, time.Now())
+	assert.ErrorIs(t, err, challengeModel.ErrChallengeFinished)
+	assert.ErrorIs(t, f.service.UpdateChallenge(cancelled), challengeModel.ErrChallengeFinished)
+	assert.ErrorIs(t, f.service.JoinChallenge(f.challenge.ID, "runner"), challengeModel.ErrChallengeCancelled)
+
+	// A stale copy saved after cancelling cannot clear it, and close-out skips it.
+	stale.Name = "renamed"
+	require.NoError(t, f.challenges.Update(&stale))
+	reloaded, err := f.service.GetChallengeByID(f.challenge.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", reloaded.Name)
+	assert.NotNil(t, reloaded.CancelledAt)
+
+	closed, err := f.service.CloseDueChallenges(time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, closed)
+	ok, err := f.challenges.MarkClosed(f.challenge.ID, time.Now())
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestChallengeService_TransferOwnership_SQLite(t *testing.T) {
+	f := newManageFixture(t)
+	stale := *f.cause
+
+	_, err := f.service.TransferChallenge(f.challenge.ID, "owner", "owner")
+	assert.ErrorIs(t, err, challengeModel.ErrAlreadyOwner)
+
+	transferred, err := f.service.TransferChallenge(f.challenge.ID, "owner", "heir")
+	require.NoError(t, err)
+	assert.Equal(t, "heir", transferred.OwnerID)
+	_, err = f.service.TransferChallenge(f.challenge.ID, "owner", "someone")
+	assert.ErrorIs(t, err, challengeModel.ErrOwnerChanged)
+
+	cause, err := f.service.TransferCause(f.cause.ID, "owner", "heir")
+	require.NoError(t, err)
+	assert.Equal(t, "heir", cause.OwnerID)
+
+	// Saving a copy loaded before the transfer keeps the new owner.
+	stale.Name = "renamed"
+	require.NoError(t, f.service.UpdateCause(&stale))
+	cause, err = f.service.GetCauseByID(f.cause.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", cause.Name)
+	assert.Equal(t, "heir", cause.OwnerID)
+}
+
+func TestChallengeHandler_ManageEndpoints_SQLite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := newManageFixture(t)
+	require.NoError(t, f.service.SponsorCause(f.cause.ID, "acme", 10, 2))
+
+	userRepo := new(userMocks.MockUserRepository)
+	for _, id := range []string{"owner", "heir"} {
+		userRepo.On("GetByID", id).Return(&userModel.User{Base: model.Base{ID: id}, FirstName: id}, nil).Maybe()
+	}
+	userRepo.On("GetByID", mock.Anything).Return(nil, errors.New("record not found")).Maybe()
+	h := handler.NewChallengeHandler(f.service, user.NewUserService(userRepo, nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", c.GetHeader("X-User"))
+		c.Set("is_staff", c.GetHeader("X-Staff") == "true")
+		c.Next()
+	})
+	router.PUT("/challenges/:id", h.UpdateChallenge)
+	router.DELETE("/challenges/:id", h.DeleteChallenge)
+	router.POST("/challenges/:id/cancel", h.CancelChallenge)
+	router.POST("/challenges/:id/transfer", h.TransferChallenge)
+	router.PUT("/causes/:id", h.UpdateCause)
+	router.POST("/causes/:id/transfer", h.TransferCause)
+
+	send := func(method, path, userID string, staff bool, body interface{}) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User", userID)
+		if staff {
+			req.Header.Set("X-Staff", "true")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	challengePath := "/challenges/" + f.challenge.ID
+	w := send(http.MethodPut, challengePath, "intruder", false, map[string]interface{}{"name": "hijacked"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = send(http.MethodPut, challengePath, "owner", false, map[string]interface{}{"name": "harbour relay", "ranking_criterion": "fastest"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var updated dto.ChallengeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, "harbour relay", updated.Name)
+	assert.Equal(t, "fastest", updated.RankingCriterion)
+	w = send(http.MethodPut, challengePath, "owner", false, map[string]interface{}{"end_duration": "someday"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = send(http.MethodPut, "/causes/"+f.cause.ID, "intruder", true, map[string]interface{}{"problem": "litter"})
+	require.Equal(t, http.StatusOK, w.Code, "staff may edit any cause")
+	var cause dto.CauseResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cause))
+	assert.Equal(t, "litter", cause.Problem)
+
+	w = send(http.MethodPost, challengePath+"/transfer", "owner", false, map[string]string{"user_id": "nobody"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = send(http.MethodPost, challengePath+"/transfer", "owner", false, map[string]string{"user_id": "owner"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = send(http.MethodPost, "/causes/"+f.cause.ID+"/transfer", "owner", false, map[string]string{"user_id": "heir"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cause))
+	assert.Equal(t, "heir", cause.Owner.ID)
+
+	w = send(http.MethodPost, challengePath+"/cancel", "owner", false, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var cancelled dto.ChallengeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cancelled))
+	assert.NotNil(t, cancelled.CancelledAt)
+	w = send(http.MethodPost, challengePath+"/cancel", "owner", false, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = send(http.MethodDelete, challengePath, "owner", false, nil)
+	assert.Equal(t, http.StatusConflict, w.Code, "sponsors have pledged to its cause")
+	w = send(http.MethodDelete, challengePath+"?force=true", "owner", false, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code, "only staff can force")
+	w = send(http.MethodDelete, challengePath+"?force=true", "staff", true, nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = send(http.MethodDelete, challengePath, "owner", false, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,150 @@
+package challenge_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	challenge "gopi.com/internal/app/challenge"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	"gopi.com/internal/data/challenge/repo"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+// serviceTables returns the tables every challenge service reads and writes, followed by extra.
+func serviceTables(extra ...interface{}) []interface{} {
+	return append([]interface{}{&gormmodel.Challenge{}, &gormmodel.Cause{}, &gormmodel.CauseRunner{},
+		&gormmodel.SponsorChallenge{}, &gormmodel.SponsorCause{}, &gormmodel.CauseBuyer{},
+		&gormmodel.ChallengeMember{}, &gormmodel.CauseMember{}}, extra...)
+}
+
+func newMembershipService(t *testing.T) (*challenge.ChallengeService, *challengeModel.Challenge, *challengeModel.Cause) {
+	db := testdb.Open(t, serviceTables()...)
+
+	challengeRepo := repo.NewGormChallengeRepository(db)
+	causeRepo := repo.NewGormCauseRepository(db)
+	service := challenge.NewChallengeService(challengeRepo, causeRepo, repo.NewGormCauseRunnerRepository(db),
+		repo.NewGormSponsorChallengeRepository(db), repo.NewGormSponsorCauseRepository(db), repo.NewGormCauseBuyerRepository(db))
+
+	ch := &challengeModel.Challenge{OwnerID: "owner", Name: "members", Mode: challengeModel.ChallengeModeF}
+	require.NoError(t, challengeRepo.Create(ch))
+	cause := &challengeModel.Cause{ChallengeID: ch.ID, Name: "members", OwnerID: "owner"}
+	require.NoError(t, causeRepo.Create(cause))
+	return service, ch, cause
+}
+
+func TestChallengeService_ChallengeMembership_SQLite(t *testing.T) {
+	service, ch, _ := newMembershipService(t)
+
+	require.NoError(t, service.JoinChallenge(ch.ID, "alice"))
+	require.NoError(t, service.JoinChallenge(ch.ID, "bob"))
+	assert.ErrorIs(t, service.JoinChallenge(ch.ID, "alice"), challengeModel.ErrAlreadyMember)
+
+	got, err := service.GetChallengeByID(ch.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []interface{}{"alice", "bob"}, got.Members)
+
+	members, total, err := service.ListChallengeMembers(ch.ID, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, members, 1)
+	assert.Equal(t, "alice", members[0].UserID)
+	assert.False(t, members[0].JoinedAt.IsZero())
+
+	require.NoError(t, service.LeaveChallenge(ch.ID, "alice"))
+	assert.ErrorIs(t, service.LeaveChallenge(ch.ID, "alice"), challengeModel.ErrNotMember)
+	assert.ErrorIs(t, service.LeaveChallenge(ch.ID, "owner"), challengeModel.ErrOwnerCannotLeave)
+
+	got, err = service.GetChallengeByID(ch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"bob"}, got.Members)
+
+	// Leaving does not block joining again.
+	require.NoError(t, service.JoinChallenge(ch.ID, "alice"))
+}
+
+func TestChallengeService_CauseMembership_SQLite(t *testing.T) {
+	service, _, cause := newMembershipService(t)
+
+	require.NoError(t, service.JoinCause(cause.ID, "alice"))
+	assert.ErrorIs(t, service.JoinCause(cause.ID, "alice"), challengeModel.ErrAlreadyMember)
+
+	got, err := service.GetCauseByID(cause.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"alice"}, got.Members)
+
+	assert.ErrorIs(t, service.LeaveCause(cause.ID, "owner"), challengeModel.ErrOwnerCannotLeave)
+	require.NoError(t, service.LeaveCause(cause.ID, "alice"))
+	assert.ErrorIs(t, service.LeaveCause(cause.ID, "alice"), challengeModel.ErrNotMember)
+
+	_, total, err := service.ListCauseMembers(cause.ID, 20, 0)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func TestChallengeHandler_MembershipEndpoints_SQLite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service, ch, cause := newMembershipService(t)
+
+	userRepo := new(userMocks.MockUserRepository)
+	userRepo.On("GetByID", "alice").Return(&userModel.User{Base: model.Base{ID: "alice"}, Username: "alice", FirstName: "Alice", LastName: "Runner"}, nil).Maybe()
+	h := handler.NewChallengeHandler(service, user.NewUserService(userRepo, nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", c.GetHeader("X-User"))
+		c.Next()
+	})
+	router.POST("/challenges/:id/join", h.JoinChallenge)
+	router.POST("/challenges/:id/leave", h.LeaveChallenge)
+	router.GET("/challenges/:challenge_id/members", h.GetChallengeMembers)
+	router.POST("/causes/:id/join", h.JoinCause)
+	router.POST("/causes/:id/leave", h.LeaveCause)
+	router.GET("/causes/:id/members", h.GetCauseMembers)
+
+	do := func(method, path, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-User", userID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/challenges/"+ch.ID+"/join", "alice").Code)
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/challenges/"+ch.ID+"/join", "alice").Code)
+
+	w := do(http.MethodGet, "/challenges/"+ch.ID+"/members", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var members dto.ChallengeMemberListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &members))
+	assert.Equal(t, int64(1), members.Total)
+	require.Len(t, members.Members, 1)
+	assert.Equal(t, "alice", members.Members[0].ID)
+	assert.Equal(t, "alice", members.Members[0].Username)
+	assert.Equal(t, "Alice Runner", members.Members[0].FullName)
+
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/challenges/"+ch.ID+"/leave", "owner").Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/challenges/"+ch.ID+"/leave", "alice").Code)
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/challenges/"+ch.ID+"/leave", "alice").Code)
+
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/causes/"+cause.ID+"/join", "alice").Code)
+	w = do(http.MethodGet, "/causes/"+cause.ID+"/members", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var causeMembers dto.CauseMemberListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &causeMembers))
+	require.Len(t, causeMembers.Members, 1)
+	assert.Equal(t, "Alice Runner", causeMembers.Members[0].FullName)
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/causes/"+cause.ID+"/leave", "alice").Code)
+}
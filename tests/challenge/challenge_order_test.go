@@ -0,0 +1,312 @@
+package challenge_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	challenge "gopi.com/internal/app/challenge"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	"gopi.com/internal/data/challenge/repo"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+	"gopi.com/internal/domain/model"
+	userModel "gopi.com/internal/domain/user/model"
+	challengeMocks "gopi.com/tests/mocks/challenge"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+func TestCause_NewOrder(t *testing.T) {
+	stock := 5
+	tests := []struct {
+		name     string
+		cause    challengeModel.Cause
+		quantity int
+		amount   float64
+		want     float64
+		wantErr  error
+	}{
+		{name: "priced from unit price", cause: challengeModel.Cause{IsCommercial: true, UnitPrice: 12.5}, quantity: 3, amount: 37.5, want: 37.5},
+		{name: "rounded to the cent", cause: challengeModel.Cause{IsCommercial: true, UnitPrice: 0.1}, quantity: 3, amount: 0.3, want: 0.3},
+		{name: "not commercial", cause: challengeModel.Cause{UnitPrice: 10}, quantity: 1, amount: 10, wantErr: challengeModel.ErrCauseNotForSale},
+		{name: "no unit price", cause: challengeModel.Cause{IsCommercial: true}, quantity: 1, amount: 10, wantErr: challengeModel.ErrCauseNotForSale},
+		{name: "zero quantity", cause: challengeModel.Cause{IsCommercial: true, UnitPrice: 10}, quantity: 0, amount: 10, wantErr: challengeModel.ErrInvalidQuantity},
+		{name: "amount too low", cause: challengeModel.Cause{IsCommercial: true, UnitPrice: 10}, quantity: 2, amount: 19.99, wantErr: challengeModel.ErrAmountMismatch},
+		{name: "within stock", cause: challengeModel.Cause{IsCommercial: true, UnitPrice: 10, Stock: &stock, UnitsSold: 3}, quantity: 2, amount: 20, want: 20},
+		{name: "beyond stock", cause: challengeModel.Cause{IsCommercial: true, UnitPrice: 10, Stock: &stock, UnitsSold: 3}, quantity: 3, amount: 30, wantErr: challengeModel.ErrOutOfStock},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := tt.cause.NewOrder("buyer", tt.quantity, tt.amount)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, order.Amount)
+			assert.Equal(t, tt.quantity, order.Quantity)
+			assert.Equal(t, challengeModel.OrderPending, order.Status)
+		})
+	}
+}
+
+func TestOrderStatus_CanTransitionTo(t *testing.T) {
+	assert.True(t, challengeModel.OrderPending.CanTransitionTo(challengeModel.OrderPaid))
+	assert.True(t, challengeModel.OrderPaid.CanTransitionTo(challengeModel.OrderFulfilled))
+	assert.True(t, challengeModel.OrderPaid.CanTransitionTo(challengeModel.OrderRefunded))
+	assert.True(t, challengeModel.OrderFulfilled.CanTransitionTo(challengeModel.OrderRefunded))
+	assert.False(t, challengeModel.OrderPending.CanTransitionTo(challengeModel.OrderFulfilled))
+	assert.False(t, challengeModel.OrderPending.CanTransitionTo(challengeModel.OrderRefunded))
+	assert.False(t, challengeModel.OrderRefunded.CanTransitionTo(challengeModel.OrderPaid))
+}
+
+type orderFixture struct {
+	service *challenge.ChallengeService
+	causes  challengeRepo.CauseRepository
+	emails  *winnerEmails
+	cause   *challengeModel.Cause
+}
+
+func newOrderFixture(t *testing.T) *orderFixture {
+	db := testdb.Open(t, serviceTables(&gormmodel.CauseOrder{})...)
+
+	users := new(userMocks.MockUserRepository)
+	users.On("GetByID", "buyer").Return(&userModel.User{Base: model.Base{ID: "buyer"}, Username: "buyer", Email: "buyer@example.com"}, nil).Maybe()
+	users.On("GetByID", "seller").Return(&userModel.User{Base: model.Base{ID: "seller"}, Username: "seller"}, nil).Maybe()
+
+	challenges := repo.NewGormChallengeRepository(db)
+	f := &orderFixture{
+		causes: repo.NewGormCauseRepository(db),
+		emails: &winnerEmails{},
+	}
+	f.service = challenge.NewChallengeService(challenges, f.causes, repo.NewGormCauseRunnerRepository(db),
+		repo.NewGormSponsorChallengeRepository(db), repo.NewGormSponsorCauseRepository(db), repo.NewGormCauseBuyerRepository(db),
+		challenge.WithCauseOrders(repo.NewGormCauseOrderRepository(db), users, f.emails))
+
+	market := &challengeModel.Challenge{OwnerID: "seller", Name: "market", Slug: "market", Mode: challengeModel.ChallengeModeF}
+	require.NoError(t, challenges.Create(market))
+	stock := 3
+	f.cause = &challengeModel.Cause{ChallengeID: market.ID, Name: "tote bags", Slug: "tote-bags", OwnerID: "seller",
+		IsCommercial: true, UnitPrice: 15, Stock: &stock}
+	require.NoError(t, f.causes.Create(f.cause))
+	return f
+}
+
+func (f *orderFixture) unitsSold(t *testing.T) int {
+	cause, err := f.causes.GetByID(f.cause.ID)
+	require.NoError(t, err)
+	return cause.UnitsSold
+}
+
+func TestChallengeService_CauseOrders_SQLite(t *testing.T) {
+	f := newOrderFixture(t)
+	now := time.Now()
+
+	first, err := f.service.PlaceCauseOrder(f.cause.ID, "buyer", 2, 30)
+	require.NoError(t, err)
+	// Pending orders hold no stock, so both can be placed.
+	second, err := f.service.PlaceCauseOrder(f.cause.ID, "buyer", 2, 30)
+	require.NoError(t, err)
+	_, err = f.service.PlaceCauseOrder(f.cause.ID, "buyer", 4, 60)
+	assert.ErrorIs(t, err, challengeModel.ErrOutOfStock)
+	_, err = f.service.PlaceCauseOrder(f.cause.ID, "buyer", 1, 10)
+	assert.ErrorIs(t, err, challengeModel.ErrAmountMismatch)
+
+	paid, err := f.service.PayCauseOrder(first.ID, " txn-1 ", now)
+	require.NoError(t, err)
+	assert.Equal(t, challengeModel.OrderPaid, paid.Status)
+	assert.Equal(t, "txn-1", paid.PaymentRef)
+	require.NotNil(t, paid.PaidAt)
+	assert.Equal(t, 2, f.unitsSold(t))
+	assert.Equal(t, []string{"buyer@example.com"}, f.emails.to, "buyer gets a receipt")
+
+	_, err = f.service.PayCauseOrder(second.ID, "txn-2", now)
+	assert.ErrorIs(t, err, challengeModel.ErrOutOfStock, "only one unit is left")
+	still, err := f.service.GetCauseOrder(second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, challengeModel.OrderPending, still.Status, "a failed payment leaves the order pending")
+	assert.Equal(t, 2, f.unitsSold(t))
+
+	_, err = f.service.PayCauseOrder(first.ID, "txn-1", now)
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidOrderState)
+	_, err = f.service.FulfilCauseOrder(second.ID, now)
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidOrderState)
+
+	low := 1
+	_, err = f.service.SetCauseStock(f.cause.ID, &low)
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidStock, "stock cannot drop below units sold")
+	negative := -1
+	_, err = f.service.SetCauseStock(f.cause.ID, &negative)
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidStock)
+
+	fulfilled, err := f.service.FulfilCauseOrder(first.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, challengeModel.OrderFulfilled, fulfilled.Status)
+	require.NotNil(t, fulfilled.FulfilledAt)
+
+	refunded, err := f.service.RefundCauseOrder(first.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, challengeModel.OrderRefunded, refunded.Status)
+	require.NotNil(t, refunded.RefundedAt)
+	assert.Equal(t, 0, f.unitsSold(t), "refunded units return to stock")
+	assert.Len(t, f.emails.to, 2, "buyer is told about the refund")
+	_, err = f.service.RefundCauseOrder(first.ID, now)
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidOrderState)
+
+	_, err = f.service.PayCauseOrder(second.ID, "txn-2", now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, f.unitsSold(t))
+
+	cause, err := f.service.SetCauseStock(f.cause.ID, nil)
+	require.NoError(t, err)
+	assert.Nil(t, cause.Stock)
+	assert.Equal(t, -1, cause.Available())
+	assert.Equal(t, 2, cause.UnitsSold)
+
+	orders, total, err := f.service.ListBuyerOrders("buyer", 10, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+	assert.Len(t, orders, 2)
+	orders, total, err = f.service.ListCauseOrders(f.cause.ID, 1, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+	assert.Len(t, orders, 1)
+}
+
+func TestChallengeService_CauseOrders_NotEnabled(t *testing.T) {
+	service := challenge.NewChallengeService(new(challengeMocks.MockChallengeRepository), new(challengeMocks.MockCauseRepository),
+		new(challengeMocks.MockCauseRunnerRepository), new(challengeMocks.MockSponsorChallengeRepository),
+		new(challengeMocks.MockSponsorCauseRepository), new(challengeMocks.MockCauseBuyerRepository))
+
+	_, err := service.PlaceCauseOrder("cause", "buyer", 1, 10)
+	assert.ErrorIs(t, err, challengeModel.ErrOrdersNotEnabled)
+	_, err = service.PayCauseOrder("order", "txn", time.Now())
+	assert.ErrorIs(t, err, challengeModel.ErrOrdersNotEnabled)
+	_, err = service.SetCauseStock("cause", nil)
+	assert.ErrorIs(t, err, challengeModel.ErrOrdersNotEnabled)
+}
+
+func TestChallengeService_PayCauseOrder_LostRace(t *testing.T) {
+	orders := new(challengeMocks.MockCauseOrderRepository)
+	service := challenge.NewChallengeService(new(challengeMocks.MockChallengeRepository), new(challengeMocks.MockCauseRepository),
+		new(challengeMocks.MockCauseRunnerRepository), new(challengeMocks.MockSponsorChallengeRepository),
+		new(challengeMocks.MockSponsorCauseRepository), new(challengeMocks.MockCauseBuyerRepository),
+		challenge.WithCauseOrders(orders, nil, nil))
+
+	order := &challengeModel.CauseOrder{Base: model.Base{ID: "order"}, CauseID: "cause", Quantity: 2, Status: challengeModel.OrderPending}
+	orders.On("GetByID", "order").Return(order, nil)
+	orders.On("Transition", order, challengeModel.OrderPaid, mock.Anything, 2).Return(false, nil)
+
+	_, err := service.PayCauseOrder("order", "txn", time.Now())
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidOrderState)
+	orders.AssertExpectations(t)
+}
+
+func TestChallengeHandler_CauseOrderEndpoints_SQLite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := newOrderFixture(t)
+
+	userRepo := new(userMocks.MockUserRepository)
+	userRepo.On("GetByID", "seller").Return(&userModel.User{Base: model.Base{ID: "seller"}, Username: "seller"}, nil).Maybe()
+	h := handler.NewChallengeHandler(f.service, user.NewUserService(userRepo, nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-User"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+	router.POST("/causes/buy", h.BuyCause)
+	router.GET("/causes/orders", h.GetMyCauseOrders)
+	router.GET("/causes/orders/:order_id", h.GetCauseOrder)
+	router.POST("/causes/orders/:order_id/pay", h.PayCauseOrder)
+	router.POST("/causes/orders/:order_id/fulfil", h.FulfilCauseOrder)
+	router.POST("/causes/orders/:order_id/refund", h.RefundCauseOrder)
+	router.GET("/causes/:id/orders", h.GetCauseOrders)
+	router.PUT("/causes/:id/stock", h.SetCauseStock)
+
+	send := func(method, path, userID string, body interface{}) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User", userID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(http.MethodPost, "/causes/buy", "buyer", dto.BuyCauseRequest{CauseID: f.cause.ID, Quantity: 3, Amount: 45})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var order dto.CauseOrderResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &order))
+	assert.Equal(t, "pending", order.Status)
+	assert.Equal(t, 15.0, order.UnitPrice)
+
+	w = send(http.MethodPost, "/causes/buy", "buyer", dto.BuyCauseRequest{CauseID: f.cause.ID, Quantity: 3, Amount: 40})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = send(http.MethodPost, "/causes/buy", "buyer", dto.BuyCauseRequest{CauseID: f.cause.ID, Quantity: 4, Amount: 60})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = send(http.MethodGet, "/causes/orders/"+order.ID, "stranger", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = send(http.MethodGet, "/causes/orders/"+order.ID, "buyer", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = send(http.MethodGet, "/causes/orders/missing", "buyer", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = send(http.MethodPost, "/causes/orders/"+order.ID+"/pay", "buyer", dto.PayCauseOrderRequest{PaymentRef: "txn-1"})
+	assert.Equal(t, http.StatusForbidden, w.Code, "buyers cannot confirm their own payment")
+	w = send(http.MethodPost, "/causes/orders/"+order.ID+"/refund", "seller", nil)
+	assert.Equal(t, http.StatusConflict, w.Code, "pending orders cannot be refunded")
+	w = send(http.MethodPost, "/causes/orders/"+order.ID+"/pay", "seller", dto.PayCauseOrderRequest{PaymentRef: "txn-1"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &order))
+	assert.Equal(t, "paid", order.Status)
+	assert.Equal(t, "txn-1", order.PaymentRef)
+
+	w = send(http.MethodPut, "/causes/"+f.cause.ID+"/stock", "seller", map[string]interface{}{"stock": 2})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = send(http.MethodPut, "/causes/"+f.cause.ID+"/stock", "buyer", map[string]interface{}{"stock": 10})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = send(http.MethodPut, "/causes/"+f.cause.ID+"/stock", "seller", map[string]interface{}{"stock": 10})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var cause dto.CauseResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cause))
+	require.NotNil(t, cause.Available)
+	assert.Equal(t, 7, *cause.Available)
+	assert.Equal(t, 3, cause.UnitsSold)
+
+	w = send(http.MethodPost, "/causes/orders/"+order.ID+"/fulfil", "seller", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = send(http.MethodGet, "/causes/"+f.cause.ID+"/orders", "buyer", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = send(http.MethodGet, "/causes/"+f.cause.ID+"/orders", "seller", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list dto.CauseOrderListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.EqualValues(t, 1, list.Total)
+	assert.Equal(t, "fulfilled", list.Orders[0].Status)
+
+	w = send(http.MethodGet, "/causes/orders", "buyer", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.EqualValues(t, 1, list.Total)
+}
@@ -0,0 +1,85 @@
+package challenge_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+)
+
+func TestNormalizePrizes(t *testing.T) {
+	prizes, err := challengeModel.NormalizePrizes([]challengeModel.Prize{
+		{Rank: 5, Description: " Finisher medal "},
+		{Amount: 500, Currency: "usd"},
+		{Amount: 250, Currency: "NGN", Description: "Race kit"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []challengeModel.Prize{
+		{Rank: 2, Amount: 500, Currency: "USD"},
+		{Rank: 3, Amount: 250, Currency: "NGN", Description: "Race kit"},
+		{Rank: 5, Description: "Finisher medal"},
+	}, prizes, "prizes without a rank take their position in the list")
+
+	empty, err := challengeModel.NormalizePrizes(nil)
+	require.NoError(t, err)
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+
+	for name, bad := range map[string][]challengeModel.Prize{
+		"duplicate rank":     {{Rank: 2, Description: "Silver"}, {Description: "Also silver"}},
+		"nothing to win":     {{Rank: 1}},
+		"amount no currency": {{Amount: 10}},
+		"bad currency":       {{Amount: 10, Currency: "dollars"}},
+		"negative amount":    {{Amount: -5, Currency: "USD", Description: "Refund"}},
+		"negative rank":      {{Rank: -1, Description: "Gold"}},
+	} {
+		_, err := challengeModel.NormalizePrizes(bad)
+		assert.ErrorIs(t, err, challengeModel.ErrInvalidPrizes, name)
+	}
+
+	assert.Equal(t, "500.00 USD, Race kit", challengeModel.Prize{Amount: 500, Currency: "USD", Description: "Race kit"}.String())
+	assert.Equal(t, "Gold medal", challengeModel.Prize{Description: "Gold medal"}.String())
+}
+
+func TestMigrateChallengePrizes(t *testing.T) {
+	db := setupChallengeTestDB(t)
+	require.NoError(t, db.AutoMigrate(&gormmodel.ChallengeWinner{}, &gormmodel.JudgingRound{}, &gormmodel.JudgingResult{}))
+
+	rows := []gormmodel.Challenge{
+		{ID: "legacy", Name: "a", Slug: "a", OwnerID: "o",
+			WinningPrice: `["Gold medal", 250, {"position": 3, "prize": "100", "currency": "usd", "name": "Kit"}, null, ""]`,
+			CausePrice:   `[{"title": "Seed grant", "amount": 5000}]`},
+		{ID: "typed", Name: "b", Slug: "b", OwnerID: "o",
+			WinningPrice: `[{"rank":1,"description":"Gold"}]`, CausePrice: `[]`},
+		{ID: "garbage", Name: "c", Slug: "c", OwnerID: "o", WinningPrice: `not json`, CausePrice: `[]`},
+	}
+	require.NoError(t, db.Create(&rows).Error)
+	require.NoError(t, db.Create(&gormmodel.ChallengeWinner{ID: "w1", ChallengeID: "legacy", UserID: "u", Rank: 2, Prize: `"Silver"`}).Error)
+
+	require.NoError(t, gormmodel.MigrateChallengePrizes(db))
+	// Running again is a no-op
+	require.NoError(t, gormmodel.MigrateChallengePrizes(db))
+
+	var legacy gormmodel.Challenge
+	require.NoError(t, db.First(&legacy, "id = ?", "legacy").Error)
+	got := gormmodel.ToDomainChallenge(&legacy)
+	assert.Equal(t, []challengeModel.Prize{
+		{Rank: 1, Description: "Gold medal"},
+		{Rank: 2, Amount: 250},
+		{Rank: 3, Amount: 100, Currency: "USD", Description: "Kit"},
+	}, got.WinningPrice)
+	assert.Equal(t, []challengeModel.Prize{{Rank: 1, Amount: 5000, Description: "Seed grant"}}, got.CausePrice)
+
+	var typed, garbage gormmodel.Challenge
+	require.NoError(t, db.First(&typed, "id = ?", "typed").Error)
+	assert.Equal(t, `[{"rank":1,"description":"Gold"}]`, typed.WinningPrice)
+	require.NoError(t, db.First(&garbage, "id = ?", "garbage").Error)
+	assert.Equal(t, `not json`, garbage.WinningPrice, "unparseable lists are left for a person to fix")
+
+	var winner gormmodel.ChallengeWinner
+	require.NoError(t, db.First(&winner, "id = ?", "w1").Error)
+	assert.Equal(t, &challengeModel.Prize{Rank: 2, Description: "Silver"}, gormmodel.ToDomainChallengeWinner(&winner).Prize)
+}
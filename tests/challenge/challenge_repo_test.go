@@ -30,6 +30,8 @@ func setupChallengeTestDB(t *testing.T) *gorm.DB {
 		&gormmodel.SponsorChallenge{},
 		&gormmodel.SponsorCause{},
 		&gormmodel.CauseBuyer{},
+		&gormmodel.ChallengeMember{},
+		&gormmodel.CauseMember{},
 	)
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
@@ -370,7 +372,7 @@ func TestGormCauseRunnerRepository_GetLeaderboard(t *testing.T) {
 			OwnerID:         "user1",
 			DistanceToCover: 10.0,
 			DistanceCovered: 8.5,
-			Duration:        "45:30",
+			Duration:        45*time.Minute + 30*time.Second,
 			MoneyRaised:     25.0,
 			Activity:        "Walking",
 		},
@@ -384,7 +386,7 @@ func TestGormCauseRunnerRepository_GetLeaderboard(t *testing.T) {
 			OwnerID:         "user2",
 			DistanceToCover: 15.0,
 			DistanceCovered: 12.0,
-			Duration:        "60:00",
+			Duration:        time.Hour,
 			MoneyRaised:     40.0,
 			Activity:        "Running",
 		},
@@ -398,7 +400,7 @@ func TestGormCauseRunnerRepository_GetLeaderboard(t *testing.T) {
 			OwnerID:         "user3",
 			DistanceToCover: 8.0,
 			DistanceCovered: 6.0,
-			Duration:        "30:00",
+			Duration:        30 * time.Minute,
 			MoneyRaised:     15.0,
 			Activity:        "Cycling",
 		},
@@ -0,0 +1,153 @@
+package challenge_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopi.com/internal/app/challenge"
+	activityModel "gopi.com/internal/domain/activity/model"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/challenge/repo"
+	"gopi.com/internal/domain/model"
+	challengeMocks "gopi.com/tests/mocks/challenge"
+)
+
+func newAntiCheatChallengeService() (*challenge.ChallengeService, *challengeMocks.MockCauseRepository, *challengeMocks.MockCauseRunnerRepository) {
+	mockCauseRepo := new(challengeMocks.MockCauseRepository)
+	mockCauseRunnerRepo := new(challengeMocks.MockCauseRunnerRepository)
+	service := challenge.NewChallengeService(new(challengeMocks.MockChallengeRepository), mockCauseRepo, mockCauseRunnerRepo,
+		new(challengeMocks.MockSponsorChallengeRepository), new(challengeMocks.MockSponsorCauseRepository), new(challengeMocks.MockCauseBuyerRepository),
+		challenge.WithAntiCheat(activityModel.NewRules(nil)))
+	return service, mockCauseRepo, mockCauseRunnerRepo
+}
+
+func TestChallengeService_RecordCauseActivity_AntiCheat(t *testing.T) {
+	t.Run("plausible activity is accepted and counted", func(t *testing.T) {
+		service, mockCauseRepo, mockCauseRunnerRepo := newAntiCheatChallengeService()
+		mockCauseRepo.On("GetByID", "cause123").Return(&challengeModel.Cause{Base: model.Base{ID: "cause123"}}, nil)
+		mockCauseRunnerRepo.On("SumDistanceSince", "user123", mock.Anything).Return(0.0, nil)
+		mockCauseRunnerRepo.On("Create", mock.MatchedBy(func(r *challengeModel.CauseRunner) bool {
+			return r.Status == activityModel.ReviewStatusAccepted
+		})).Return(nil)
+		mockCauseRepo.On("IncrementDistance", "cause123", 5.0).Return(nil)
+
+		err := service.RecordCauseActivity("cause123", "user123", 10, 5, 30*time.Minute, "Running")
+
+		assert.NoError(t, err)
+		mockCauseRepo.AssertExpectations(t)
+		mockCauseRunnerRepo.AssertExpectations(t)
+	})
+
+	t.Run("implausible activity is held out of the cause total", func(t *testing.T) {
+		service, mockCauseRepo, mockCauseRunnerRepo := newAntiCheatChallengeService()
+		mockCauseRepo.On("GetByID", "cause123").Return(&challengeModel.Cause{Base: model.Base{ID: "cause123"}}, nil)
+		mockCauseRunnerRepo.On("SumDistanceSince", "user123", mock.Anything).Return(0.0, nil)
+		mockCauseRunnerRepo.On("Create", mock.MatchedBy(func(r *challengeModel.CauseRunner) bool {
+			return r.Status == activityModel.ReviewStatusFlagged && len(r.Violations) == 1
+		})).Return(nil)
+
+		err := service.RecordCauseActivity("cause123", "user123", 10, 5, 5*time.Minute, "Walking")
+
+		assert.True(t, errors.Is(err, activityModel.ErrHeldForReview))
+		mockCauseRepo.AssertNotCalled(t, "IncrementDistance", mock.Anything, mock.Anything)
+		mockCauseRunnerRepo.AssertExpectations(t)
+	})
+
+	t.Run("flagged activity is written in the unit of work", func(t *testing.T) {
+		mockCauseRepo := new(challengeMocks.MockCauseRepository)
+		mockCauseRunnerRepo := new(challengeMocks.MockCauseRunnerRepository)
+		txRunners := new(challengeMocks.MockCauseRunnerRepository)
+		uow := &recordingUnitOfWork{repos: repo.Repositories{Causes: mockCauseRepo, CauseRunners: txRunners}}
+		service := challenge.NewChallengeService(new(challengeMocks.MockChallengeRepository), mockCauseRepo, mockCauseRunnerRepo,
+			new(challengeMocks.MockSponsorChallengeRepository), new(challengeMocks.MockSponsorCauseRepository), new(challengeMocks.MockCauseBuyerRepository),
+			challenge.WithAntiCheat(activityModel.NewRules(nil)), challenge.WithUnitOfWork(uow))
+		mockCauseRepo.On("GetByID", "cause123").Return(&challengeModel.Cause{Base: model.Base{ID: "cause123"}}, nil)
+		mockCauseRunnerRepo.On("SumDistanceSince", "user123", mock.Anything).Return(0.0, nil)
+		txRunners.On("Create", mock.MatchedBy(func(r *challengeModel.CauseRunner) bool {
+			return r.Status == activityModel.ReviewStatusFlagged
+		})).Return(nil)
+
+		err := service.RecordCauseActivity("cause123", "user123", 10, 5, 5*time.Minute, "Walking")
+
+		assert.True(t, errors.Is(err, activityModel.ErrHeldForReview))
+		assert.Equal(t, 1, uow.calls)
+		txRunners.AssertExpectations(t)
+		mockCauseRunnerRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+// recordingUnitOfWork runs each unit of work against repos and counts them.
+type recordingUnitOfWork struct {
+	repos repo.Repositories
+	calls int
+}
+
+func (u *recordingUnitOfWork) Do(fn func(repos repo.Repositories) error) error {
+	u.calls++
+	return fn(u.repos)
+}
+
+func TestChallengeService_ReviewCauseRunner(t *testing.T) {
+	flagged := func() *challengeModel.CauseRunner {
+		return &challengeModel.CauseRunner{
+			Base:            model.Base{ID: "runner123"},
+			CauseID:         "cause123",
+			OwnerID:         "user123",
+			DistanceCovered: 8,
+			Status:          activityModel.ReviewStatusFlagged,
+		}
+	}
+
+	t.Run("approve adds the distance to the cause", func(t *testing.T) {
+		service, mockCauseRepo, mockCauseRunnerRepo := newAntiCheatChallengeService()
+		mockCauseRunnerRepo.On("GetByID", "runner123").Return(flagged(), nil)
+		mockCauseRunnerRepo.On("Review", "runner123", activityModel.ReviewStatusApproved, "staff1", mock.Anything).Return(true, nil)
+		mockCauseRepo.On("IncrementDistance", "cause123", 8.0).Return(nil)
+
+		runner, err := service.ReviewCauseRunner("runner123", "staff1", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, activityModel.ReviewStatusApproved, runner.Status)
+		assert.Equal(t, "staff1", runner.ReviewedBy)
+		mockCauseRepo.AssertExpectations(t)
+		mockCauseRunnerRepo.AssertExpectations(t)
+	})
+
+	t.Run("reject leaves the cause untouched", func(t *testing.T) {
+		service, mockCauseRepo, mockCauseRunnerRepo := newAntiCheatChallengeService()
+		mockCauseRunnerRepo.On("GetByID", "runner123").Return(flagged(), nil)
+		mockCauseRunnerRepo.On("Review", "runner123", activityModel.ReviewStatusRejected, "staff1", mock.Anything).Return(true, nil)
+
+		runner, err := service.ReviewCauseRunner("runner123", "staff1", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, activityModel.ReviewStatusRejected, runner.Status)
+		mockCauseRepo.AssertNotCalled(t, "IncrementDistance", mock.Anything, mock.Anything)
+	})
+
+	t.Run("already reviewed", func(t *testing.T) {
+		service, _, mockCauseRunnerRepo := newAntiCheatChallengeService()
+		runner := flagged()
+		runner.Status = activityModel.ReviewStatusApproved
+		mockCauseRunnerRepo.On("GetByID", "runner123").Return(runner, nil)
+
+		_, err := service.ReviewCauseRunner("runner123", "staff1", true)
+
+		assert.True(t, errors.Is(err, activityModel.ErrNotFlagged))
+	})
+
+	t.Run("reviewed concurrently", func(t *testing.T) {
+		service, mockCauseRepo, mockCauseRunnerRepo := newAntiCheatChallengeService()
+		mockCauseRunnerRepo.On("GetByID", "runner123").Return(flagged(), nil)
+		mockCauseRunnerRepo.On("Review", "runner123", activityModel.ReviewStatusApproved, "staff1", mock.Anything).Return(false, nil)
+
+		_, err := service.ReviewCauseRunner("runner123", "staff1", true)
+
+		assert.True(t, errors.Is(err, activityModel.ErrNotFlagged))
+		mockCauseRepo.AssertNotCalled(t, "IncrementDistance", mock.Anything, mock.Anything)
+	})
+}
@@ -3,12 +3,14 @@ package challenge_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gopi.com/internal/app/challenge"
 	challengeModel "gopi.com/internal/domain/challenge/model"
 	"gopi.com/internal/domain/model"
+	trackModel "gopi.com/internal/domain/track/model"
 	challengeMocks "gopi.com/tests/mocks/challenge"
 )
 
@@ -76,6 +78,10 @@ func TestChallengeService_CreateChallenge(t *testing.T) {
 				"2024-01-01",
 				"2024-01-31",
 				3,
+				challengeModel.RankByDistance,
+				[]challengeModel.Prize{{Description: "Gold medal"}, {Description: "Silver medal"}, {Description: "Bronze medal"}},
+				nil,
+				nil,
 			)
 
 			if tt.expectedErr {
@@ -192,9 +198,8 @@ func TestChallengeService_JoinChallenge(t *testing.T) {
 					OwnerID: "owner123",
 				}
 				mockChallengeRepo.On("GetByID", "challenge123").Return(expectedChallenge, nil)
-				mockChallengeRepo.On("Update", mock.MatchedBy(func(c *challengeModel.Challenge) bool {
-					return c.ID == "challenge123"
-				})).Return(nil)
+				mockChallengeRepo.On("IsMember", "challenge123", mock.Anything).Return(false, nil)
+				mockChallengeRepo.On("AddMember", "challenge123", mock.Anything).Return(nil)
 			},
 		},
 		{
@@ -207,7 +212,7 @@ func TestChallengeService_JoinChallenge(t *testing.T) {
 			},
 		},
 		{
-			name:        "repository error on update",
+			name:        "repository error on add member",
 			challengeID: "challenge123",
 			userID:      "user123",
 			expectedErr: true,
@@ -220,7 +225,8 @@ func TestChallengeService_JoinChallenge(t *testing.T) {
 					OwnerID: "owner123",
 				}
 				mockChallengeRepo.On("GetByID", "challenge123").Return(expectedChallenge, nil)
-				mockChallengeRepo.On("Update", mock.Anything).Return(errors.New("repository error"))
+				mockChallengeRepo.On("IsMember", "challenge123", mock.Anything).Return(false, nil)
+				mockChallengeRepo.On("AddMember", "challenge123", mock.Anything).Return(errors.New("repository error"))
 			},
 		},
 	}
@@ -386,3 +392,49 @@ func TestChallengeService_GetLeaderboard(t *testing.T) {
 		})
 	}
 }
+
+func TestChallengeService_RecordCauseRunTrack(t *testing.T) {
+	mockCauseRepo := new(challengeMocks.MockCauseRepository)
+	mockCauseRunnerRepo := new(challengeMocks.MockCauseRunnerRepository)
+	service := challenge.NewChallengeService(new(challengeMocks.MockChallengeRepository), mockCauseRepo, mockCauseRunnerRepo,
+		new(challengeMocks.MockSponsorChallengeRepository), new(challengeMocks.MockSponsorCauseRepository), new(challengeMocks.MockCauseBuyerRepository))
+
+	runner := &challengeModel.CauseRunner{Base: model.Base{ID: "runner123"}, CauseID: "cause123", DistanceCovered: 5, OwnerID: "user123"}
+	mockCauseRunnerRepo.On("GetByID", "runner123").Return(runner, nil)
+	mockCauseRunnerRepo.On("AttachTrack", "runner123", 4.25, 25*time.Minute+30*time.Second, "/uploads/tracks/run.geojson", 32.0).Return(true, nil)
+	// The client reported 5km; the track shows 4.25km, so the cause gives back the difference.
+	mockCauseRepo.On("IncrementDistance", "cause123", -0.75).Return(nil)
+
+	summary := trackModel.Summary{Distance: 4.25, MovingTime: 25*time.Minute + 30*time.Second, ElevationGain: 32}
+	err := service.RecordCauseRunTrack("runner123", nil, summary, "/uploads/tracks/run.geojson")
+
+	assert.NoError(t, err)
+	mockCauseRunnerRepo.AssertExpectations(t)
+	mockCauseRepo.AssertExpectations(t)
+}
+
+func TestChallengeService_RecordCauseRunTrack_RunnerChanged(t *testing.T) {
+	mockCauseRepo := new(challengeMocks.MockCauseRepository)
+	mockCauseRunnerRepo := new(challengeMocks.MockCauseRunnerRepository)
+	service := challenge.NewChallengeService(new(challengeMocks.MockChallengeRepository), mockCauseRepo, mockCauseRunnerRepo,
+		new(challengeMocks.MockSponsorChallengeRepository), new(challengeMocks.MockSponsorCauseRepository), new(challengeMocks.MockCauseBuyerRepository))
+
+	before := &challengeModel.CauseRunner{Base: model.Base{ID: "runner123"}, CauseID: "cause123", DistanceCovered: 5, OwnerID: "user123"}
+	after := &challengeModel.CauseRunner{Base: model.Base{ID: "runner123"}, CauseID: "cause123", DistanceCovered: 6, OwnerID: "user123"}
+	mockCauseRunnerRepo.On("GetByID", "runner123").Return(before, nil).Once()
+	mockCauseRunnerRepo.On("GetByID", "runner123").Return(after, nil).Once()
+	// The first write finds the runner moved on and is skipped; the retry works from the new distance.
+	mockCauseRunnerRepo.On("AttachTrack", "runner123", 4.25, 25*time.Minute, "/uploads/tracks/run.geojson", 0.0).Return(false, nil).Once()
+	mockCauseRunnerRepo.On("AttachTrack", "runner123", 4.25, 25*time.Minute, "/uploads/tracks/run.geojson", 0.0).Return(true, nil).Once()
+	mockCauseRepo.On("IncrementDistance", "cause123", -1.75).Return(nil)
+
+	summary := trackModel.Summary{Distance: 4.25, MovingTime: 25 * time.Minute}
+	assert.NoError(t, service.RecordCauseRunTrack("runner123", nil, summary, "/uploads/tracks/run.geojson"))
+	mockCauseRepo.AssertNumberOfCalls(t, "IncrementDistance", 1)
+
+	mockCauseRunnerRepo.On("GetByID", "runner123").Return(after, nil)
+	mockCauseRunnerRepo.On("AttachTrack", "runner123", 4.25, 25*time.Minute, "/uploads/tracks/run.geojson", 0.0).Return(false, nil)
+	err := service.RecordCauseRunTrack("runner123", nil, summary, "/uploads/tracks/run.geojson")
+	assert.ErrorIs(t, err, challengeModel.ErrRunnerChanged)
+	mockCauseRepo.AssertNumberOfCalls(t, "IncrementDistance", 1)
+}
@@ -0,0 +1,331 @@
+package challenge_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	challenge "gopi.com/internal/app/challenge"
+	"gopi.com/internal/app/user"
+	gormmodel "gopi.com/internal/data/challenge/model/gorm"
+	"gopi.com/internal/data/challenge/repo"
+	notificationGorm "gopi.com/internal/data/notification/model/gorm"
+	notificationDataRepo "gopi.com/internal/data/notification/repo"
+	activityModel "gopi.com/internal/domain/activity/model"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	challengeRepo "gopi.com/internal/domain/challenge/repo"
+	"gopi.com/internal/domain/model"
+	notificationRepo "gopi.com/internal/domain/notification/repo"
+	userModel "gopi.com/internal/domain/user/model"
+	userMocks "gopi.com/tests/mocks/user"
+	"gopi.com/tests/testdb"
+)
+
+// winnerEmails captures the emails sent to winners.
+type winnerEmails struct {
+	mu sync.Mutex
+	to []string
+}
+
+func (w *winnerEmails) SendOTPEmail(email, firstName, otp string) error      { return nil }
+func (w *winnerEmails) SendWelcomeEmail(email, firstName string) error       { return nil }
+func (w *winnerEmails) SendPasswordResetEmail(email, resetLink string) error { return nil }
+func (w *winnerEmails) SendApologyEmail(email, username string) error        { return nil }
+func (w *winnerEmails) TestEmailConnection() error                           { return nil }
+func (w *winnerEmails) GetQueueLength() int                                  { return 0 }
+
+func (w *winnerEmails) SendBulkEmail(emails []string, subject, htmlContent string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.to = append(w.to, emails...)
+	return nil
+}
+
+func TestChallenge_Rank(t *testing.T) {
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	participants := []*challengeModel.Participant{
+		{UserID: "c", Distance: 10, Duration: 60 * time.Minute, MoneyRaised: 50, JoinedAt: day},
+		{UserID: "b", Distance: 10, Duration: 50 * time.Minute, MoneyRaised: 80, JoinedAt: day},
+		{UserID: "a", Distance: 10, Duration: 50 * time.Minute, MoneyRaised: 80, JoinedAt: day},
+		{UserID: "d", Distance: 12, Duration: 90 * time.Minute, MoneyRaised: 80, JoinedAt: day.Add(-time.Hour)},
+		{UserID: "e", Distance: 5, Duration: 20 * time.Minute, MoneyRaised: 0, JoinedAt: day},
+	}
+
+	tests := []struct {
+		name      string
+		challenge challengeModel.Challenge
+		want      []string
+	}{
+		{"distance by default, ties on time then user", challengeModel.Challenge{}, []string{"d", "a", "b", "c", "e"}},
+		{"fastest only counts those who covered the distance", challengeModel.Challenge{RankingCriterion: challengeModel.RankByFastest, DistanceToCover: 10},
+			[]string{"a", "b", "c", "d"}},
+		{"money skips those who raised nothing, ties on distance", challengeModel.Challenge{RankingCriterion: challengeModel.RankByMoney},
+			[]string{"d", "a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, p := range tt.challenge.Rank(participants) {
+				got = append(got, p.UserID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChallenge_RankFastestByTimeToCoverDistance(t *testing.T) {
+	c := &challengeModel.Challenge{RankingCriterion: challengeModel.RankByFastest, DistanceToCover: 10}
+	ranked := c.Rank([]*challengeModel.Participant{
+		{UserID: "steady", Distance: 10, Duration: 50 * time.Minute},
+		// Ran twice as far in more total time, but reached 10 km in 45 minutes.
+		{UserID: "long", Distance: 20, Duration: 90 * time.Minute},
+	})
+
+	require.Len(t, ranked, 2)
+	assert.Equal(t, "long", ranked[0].UserID)
+	assert.Equal(t, 270*time.Second, ranked[0].Pace())
+	assert.Equal(t, "steady", ranked[1].UserID)
+}
+
+func TestChallenge_SelectWinners(t *testing.T) {
+	c := &challengeModel.Challenge{Base: model.Base{ID: "ch"}, NoOfWinner: 2, WinningPrice: []challengeModel.Prize{{Rank: 1, Description: "Gold"}}}
+	winners := c.SelectWinners(challengeModel.Participants([]*challengeModel.CauseRunner{
+		{OwnerID: "a", DistanceCovered: 3},
+		{OwnerID: "b", DistanceCovered: 4},
+		{OwnerID: "a", DistanceCovered: 2},
+		{OwnerID: "cheat", DistanceCovered: 100, Status: activityModel.ReviewStatusFlagged},
+		{OwnerID: "c", DistanceCovered: 1},
+	}))
+
+	require.Len(t, winners, 2)
+	assert.Equal(t, "a", winners[0].UserID)
+	assert.Equal(t, 5.0, winners[0].Distance)
+	assert.Equal(t, &challengeModel.Prize{Rank: 1, Description: "Gold"}, winners[0].Prize)
+	assert.Equal(t, "b", winners[1].UserID)
+	assert.Nil(t, winners[1].Prize)
+}
+
+type winnerFixture struct {
+	db            *gorm.DB
+	service       *challenge.ChallengeService
+	challenges    challengeRepo.ChallengeRepository
+	notifications notificationRepo.NotificationRepository
+	emails        *winnerEmails
+	users         *userMocks.MockUserRepository
+	challenge     *challengeModel.Challenge
+}
+
+func newWinnerFixture(t *testing.T) *winnerFixture {
+	db := testdb.Open(t, serviceTables(&gormmodel.ChallengeWinner{}, &gormmodel.ChallengeWinnerAudit{}, &notificationGorm.Notification{})...)
+
+	f := &winnerFixture{
+		db:            db,
+		challenges:    repo.NewGormChallengeRepository(db),
+		notifications: notificationDataRepo.NewGormNotificationRepository(db),
+		emails:        &winnerEmails{},
+		users:         new(userMocks.MockUserRepository),
+	}
+	for _, id := range []string{"alice", "bob", "carol"} {
+		f.users.On("GetByID", id).Return(&userModel.User{Base: model.Base{ID: id}, Username: id, Email: id + "@example.com"}, nil).Maybe()
+	}
+	causeRepo := repo.NewGormCauseRepository(db)
+	runnerRepo := repo.NewGormCauseRunnerRepository(db)
+	f.service = challenge.NewChallengeService(f.challenges, causeRepo, runnerRepo,
+		repo.NewGormSponsorChallengeRepository(db), repo.NewGormSponsorCauseRepository(db), repo.NewGormCauseBuyerRepository(db),
+		challenge.WithUnitOfWork(repo.NewGormUnitOfWork(db)),
+		challenge.WithWinners(repo.NewGormChallengeWinnerRepository(db), f.notifications, f.users, f.emails))
+
+	var err error
+	f.challenge, err = f.service.CreateChallenge("owner", "Spring Ten", "", "", "", "", challengeModel.ChallengeModeF,
+		10, 0, 0, "", time.Now().Add(time.Hour).UTC().Format(time.RFC3339), 2, challengeModel.RankByDistance,
+		[]challengeModel.Prize{{Description: "Gold"}, {Amount: 50, Currency: "usd"}}, nil, nil)
+	require.NoError(t, err)
+	cause := &challengeModel.Cause{ChallengeID: f.challenge.ID, Name: "Spring cause", OwnerID: "owner"}
+	require.NoError(t, causeRepo.Create(cause))
+
+	joined := time.Now().Add(-time.Hour)
+	for _, r := range []struct {
+		owner    string
+		distance float64
+		status   activityModel.ReviewStatus
+	}{
+		{"alice", 8, activityModel.ReviewStatusAccepted},
+		{"bob", 12, activityModel.ReviewStatusAccepted},
+		{"carol", 6, activityModel.ReviewStatusAccepted},
+		{"carol", 50, activityModel.ReviewStatusRejected},
+	} {
+		require.NoError(t, runnerRepo.Create(&challengeModel.CauseRunner{CauseID: cause.ID, OwnerID: r.owner,
+			DistanceCovered: r.distance, Duration: time.Hour, Status: r.status, DateJoined: joined}))
+	}
+	return f
+}
+
+func TestChallengeService_CloseDueChallenges_SQLite(t *testing.T) {
+	f := newWinnerFixture(t)
+
+	closed, err := f.service.CloseDueChallenges(time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, closed, "challenge has not ended yet")
+
+	later := time.Now().Add(2 * time.Hour)
+	closed, err = f.service.CloseDueChallenges(later)
+	require.NoError(t, err)
+	assert.Equal(t, 1, closed)
+	closed, err = f.service.CloseDueChallenges(later)
+	require.NoError(t, err)
+	assert.Zero(t, closed, "a challenge closes once")
+
+	winners, err := f.service.GetChallengeWinners(f.challenge.ID)
+	require.NoError(t, err)
+	require.Len(t, winners, 2)
+	assert.Equal(t, []string{"bob", "alice"}, challengeModel.WinnerIDs(winners))
+	assert.Equal(t, &challengeModel.Prize{Rank: 1, Description: "Gold"}, winners[0].Prize)
+	assert.Equal(t, &challengeModel.Prize{Rank: 2, Amount: 50, Currency: "USD"}, winners[1].Prize)
+	assert.Equal(t, 12.0, winners[0].Distance)
+
+	got, err := f.challenges.GetByID(f.challenge.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, got.ClosedAt)
+	assert.Equal(t, []challengeModel.Prize{{Rank: 1, Description: "Gold"}, {Rank: 2, Amount: 50, Currency: "USD"}}, got.WinningPrice)
+
+	for _, id := range []string{"bob", "alice"} {
+		unread, err := f.notifications.CountUnread(id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), unread, id)
+	}
+	unread, err := f.notifications.CountUnread("carol")
+	require.NoError(t, err)
+	assert.Zero(t, unread)
+	assert.ElementsMatch(t, []string{"bob@example.com", "alice@example.com"}, f.emails.to)
+}
+
+func TestChallengeService_CloseOutRollsBackOnFailure_SQLite(t *testing.T) {
+	f := newWinnerFixture(t)
+	require.NoError(t, f.db.Migrator().DropTable(&gormmodel.ChallengeWinner{}))
+
+	// Without a winners table the close fails, and the challenge stays open for the next tick.
+	later := time.Now().Add(2 * time.Hour)
+	closed, err := f.service.CloseDueChallenges(later)
+	require.NoError(t, err)
+	assert.Zero(t, closed)
+	got, err := f.challenges.GetByID(f.challenge.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.ClosedAt)
+
+	require.NoError(t, f.db.AutoMigrate(&gormmodel.ChallengeWinner{}))
+	closed, err = f.service.CloseDueChallenges(later)
+	require.NoError(t, err)
+	assert.Equal(t, 1, closed)
+	winners, err := f.service.GetChallengeWinners(f.challenge.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob", "alice"}, challengeModel.WinnerIDs(winners))
+}
+
+func TestChallengeService_OverrideChallengeWinners_SQLite(t *testing.T) {
+	f := newWinnerFixture(t)
+
+	_, err := f.service.OverrideChallengeWinners(f.challenge.ID, "staff", "late results", []string{"carol"})
+	assert.ErrorIs(t, err, challengeModel.ErrChallengeNotClosed)
+
+	_, err = f.service.CloseDueChallenges(time.Now().Add(2 * time.Hour))
+	require.NoError(t, err)
+	f.emails.to = nil
+
+	_, err = f.service.OverrideChallengeWinners(f.challenge.ID, "staff", " ", []string{"carol"})
+	assert.ErrorIs(t, err, challengeModel.ErrOverrideReasonRequired)
+	_, err = f.service.OverrideChallengeWinners(f.challenge.ID, "staff", "typo", []string{"dave"})
+	assert.ErrorIs(t, err, challengeModel.ErrNotParticipant)
+	_, err = f.service.OverrideChallengeWinners(f.challenge.ID, "staff", "typo", []string{"bob", "bob"})
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidWinners)
+	_, err = f.service.OverrideChallengeWinners(f.challenge.ID, "staff", "too many", []string{"bob", "alice", "carol"})
+	assert.ErrorIs(t, err, challengeModel.ErrInvalidWinners)
+
+	winners, err := f.service.OverrideChallengeWinners(f.challenge.ID, "staff", "bob's run was disqualified", []string{"alice", "carol"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "carol"}, challengeModel.WinnerIDs(winners))
+	assert.Equal(t, &challengeModel.Prize{Rank: 1, Description: "Gold"}, winners[0].Prize)
+	assert.True(t, winners[0].Overridden)
+	assert.Equal(t, 6.0, winners[1].Distance, "rejected runs stay out of the totals")
+
+	stored, err := f.service.GetChallengeWinners(f.challenge.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "carol"}, challengeModel.WinnerIDs(stored))
+
+	audits, err := f.service.ListWinnerAudits(f.challenge.ID)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	assert.Equal(t, "staff", audits[0].ActorID)
+	assert.Equal(t, "bob's run was disqualified", audits[0].Reason)
+	assert.Equal(t, []string{"bob", "alice"}, audits[0].Previous)
+	assert.Equal(t, []string{"alice", "carol"}, audits[0].Winners)
+
+	// Only the newly placed winner hears about it.
+	assert.Equal(t, []string{"carol@example.com"}, f.emails.to)
+}
+
+func TestChallengeHandler_Winners_SQLite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := newWinnerFixture(t)
+	_, err := f.service.CloseDueChallenges(time.Now().Add(2 * time.Hour))
+	require.NoError(t, err)
+
+	h := handler.NewChallengeHandler(f.service, user.NewUserService(f.users, nil))
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "staff")
+		c.Next()
+	})
+	router.GET("/challenges/:challenge_id/winners", h.GetChallengeWinners)
+	router.PUT("/challenges/admin/:challenge_id/winners", h.OverrideChallengeWinners)
+	router.GET("/challenges/admin/:challenge_id/winner-audits", h.ListChallengeWinnerAudits)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/challenges/"+f.challenge.ID+"/winners", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp dto.ChallengeWinnersResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "distance", resp.RankingCriterion)
+	assert.NotNil(t, resp.ClosedAt)
+	require.Len(t, resp.Winners, 2)
+	assert.Equal(t, "bob", resp.Winners[0].Username)
+	assert.Equal(t, &dto.Prize{Rank: 1, Description: "Gold"}, resp.Winners[0].Prize)
+	assert.Equal(t, int64(3600), resp.Winners[0].DurationSeconds)
+
+	override := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/challenges/admin/"+f.challenge.ID+"/winners", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	assert.Equal(t, http.StatusBadRequest, override(`{"user_ids":["carol"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, override(`{"user_ids":["dave"],"reason":"typo"}`).Code)
+	w = override(`{"user_ids":["carol"],"reason":"recount"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Winners, 1)
+	assert.True(t, resp.Winners[0].Overridden)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/challenges/admin/"+f.challenge.ID+"/winner-audits", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var audits dto.WinnerAuditListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &audits))
+	require.Len(t, audits.Audits, 1)
+	assert.Equal(t, "recount", audits.Audits[0].Reason)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/challenges/missing/winners", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,88 @@
+package geo_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/geo"
+)
+
+const testGazetteer = `# test places
+name,latitude,longitude
+Lagos,6.5244,3.3792
+Ikeja Lagos,6.6018,3.3515
+"Port Harcourt",4.8156,7.0498
+Nigeria,9.0820,8.6753
+`
+
+func TestGazetteer_Geocode(t *testing.T) {
+	g, err := geo.ReadGazetteer(strings.NewReader(testGazetteer))
+	require.NoError(t, err)
+	assert.Equal(t, 4, g.Len())
+
+	tests := []struct {
+		query    string
+		expected model.GeoPoint
+		notFound bool
+	}{
+		{query: "Lagos", expected: model.GeoPoint{Lat: 6.5244, Lon: 3.3792}},
+		{query: "  LAGOS ", expected: model.GeoPoint{Lat: 6.5244, Lon: 3.3792}},
+		{query: "Ikeja, Lagos", expected: model.GeoPoint{Lat: 6.6018, Lon: 3.3515}},
+		// The most specific known prefix wins over later parts.
+		{query: "Ikeja, Lagos, Nigeria", expected: model.GeoPoint{Lat: 6.6018, Lon: 3.3515}},
+		// Unknown neighbourhoods fall back to the city.
+		{query: "Lekki Phase 1, Lagos", expected: model.GeoPoint{Lat: 6.5244, Lon: 3.3792}},
+		{query: "port-harcourt", expected: model.GeoPoint{Lat: 4.8156, Lon: 7.0498}},
+		{query: "Atlantis", notFound: true},
+		{query: "", notFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := g.Geocode(context.Background(), tt.query)
+			if tt.notFound {
+				assert.True(t, errors.Is(err, geo.ErrNotFound), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestReadGazetteer_RejectsBadRows(t *testing.T) {
+	_, err := geo.ReadGazetteer(strings.NewReader("Lagos,6.5244,3.3792\nNowhere,north,east\n"))
+	assert.True(t, errors.Is(err, model.ErrInvalidCoordinates), "got %v", err)
+
+	_, err = geo.ReadGazetteer(strings.NewReader("Lagos,96.5244,3.3792\n"))
+	assert.True(t, errors.Is(err, model.ErrInvalidCoordinates), "got %v", err)
+
+	_, err = geo.ReadGazetteer(strings.NewReader("Lagos,6.5244\n"))
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	// The gazetteer shipped with the repository loads and knows its own examples.
+	g, err := geo.New(geo.Config{GazetteerPath: filepath.Join("..", "..", "data", "gazetteer.csv")})
+	require.NoError(t, err)
+	p, err := g.Geocode(context.Background(), "Lekki, Lagos")
+	require.NoError(t, err)
+	assert.InDelta(t, 6.47, p.Lat, 0.01)
+
+	g, err = geo.New(geo.Config{Backend: "none"})
+	require.NoError(t, err)
+	assert.Nil(t, g)
+	assert.Nil(t, geo.Locate(context.Background(), g, "Lagos"))
+
+	_, err = geo.New(geo.Config{GazetteerPath: filepath.Join(t.TempDir(), "missing.csv")})
+	assert.Error(t, err)
+
+	_, err = geo.New(geo.Config{Backend: "satellite"})
+	assert.Error(t, err)
+}
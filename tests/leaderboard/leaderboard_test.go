@@ -0,0 +1,234 @@
+package leaderboard_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopi.com/internal/lib/leaderboard"
+)
+
+// memStore is an in-memory leaderboard.Store.
+type memStore struct {
+	boards      map[string][]*leaderboard.Entry
+	generations map[leaderboard.Scope]int
+}
+
+func newMemStore() *memStore {
+	return &memStore{boards: map[string][]*leaderboard.Entry{}, generations: map[leaderboard.Scope]int{}}
+}
+
+func (s *memStore) key(board leaderboard.Board) string {
+	return fmt.Sprintf("%s:%d:%s", board.Scope, s.generations[board.Scope], board.Key())
+}
+
+func (s *memStore) Range(_ context.Context, board leaderboard.Board, offset, limit int) ([]*leaderboard.Entry, int, bool, error) {
+	entries, ok := s.boards[s.key(board)]
+	if !ok {
+		return nil, 0, false, nil
+	}
+	end := len(entries)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	if offset >= len(entries) {
+		return []*leaderboard.Entry{}, len(entries), true, nil
+	}
+	return entries[offset:end], len(entries), true, nil
+}
+
+func (s *memStore) Find(_ context.Context, board leaderboard.Board, userID string) (*leaderboard.Entry, error) {
+	for _, e := range s.boards[s.key(board)] {
+		if e.UserID == userID {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *memStore) Save(_ context.Context, board leaderboard.Board, entries []*leaderboard.Entry, _ time.Duration) error {
+	s.boards[s.key(board)] = entries
+	return nil
+}
+
+func (s *memStore) Invalidate(_ context.Context, scope leaderboard.Scope) error {
+	s.generations[scope]++
+	return nil
+}
+
+func TestParseWindow(t *testing.T) {
+	for in, want := range map[string]leaderboard.Window{
+		"":         leaderboard.WindowAllTime,
+		"daily":    leaderboard.WindowDaily,
+		" Weekly ": leaderboard.WindowWeekly,
+		"monthly":  leaderboard.WindowMonthly,
+		"all_time": leaderboard.WindowAllTime,
+	} {
+		got, err := leaderboard.ParseWindow(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, err := leaderboard.ParseWindow("yearly")
+	assert.ErrorIs(t, err, leaderboard.ErrInvalidWindow)
+}
+
+func TestWindow_Since(t *testing.T) {
+	// Just after midnight on Wednesday in UTC+2, still Tuesday in UTC.
+	now := time.Date(2026, 10, 14, 1, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	assert.Equal(t, time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC), leaderboard.WindowDaily.Since(now))
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), leaderboard.WindowWeekly.Since(now))
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), leaderboard.WindowMonthly.Since(now))
+	assert.True(t, leaderboard.WindowAllTime.Since(now).IsZero())
+
+	sunday := time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), leaderboard.WindowWeekly.Since(sunday))
+}
+
+func TestRank(t *testing.T) {
+	entries := leaderboard.Rank([]*leaderboard.Run{
+		{UserID: "carol", Activity: "Run", Distance: 10, Duration: 50 * time.Minute},
+		{UserID: "alice", Activity: "Run", Distance: 6, Duration: 30 * time.Minute, MoneyRaised: 5},
+		{UserID: "alice", Activity: "run", Distance: 4, Duration: 25 * time.Minute, CoverImage: "a.jpg"},
+		{UserID: "bob", Activity: "Run", Distance: 10, Duration: 50 * time.Minute},
+		{UserID: "dave", Activity: "Walk", Distance: 2},
+		{UserID: "dave", Activity: "Run", Distance: 1},
+		{UserID: "erin", Activity: "Run"},
+	})
+
+	require.Len(t, entries, 4, "users without distance are not ranked")
+	// Equal distance goes to the faster user, then to the lower user ID.
+	assert.Equal(t, "bob", entries[0].UserID)
+	assert.Equal(t, "carol", entries[1].UserID)
+	assert.Equal(t, "alice", entries[2].UserID)
+	assert.Equal(t, "dave", entries[3].UserID)
+	for i, e := range entries {
+		assert.Equal(t, i+1, e.Rank)
+	}
+
+	alice := entries[2]
+	assert.Equal(t, 10.0, alice.Distance)
+	assert.Equal(t, 55*time.Minute, alice.Duration)
+	assert.Equal(t, 5.0, alice.MoneyRaised)
+	assert.Equal(t, 2, alice.Runs)
+	assert.Equal(t, "Run", alice.Activity, "activity matches case-insensitively")
+	assert.Equal(t, "a.jpg", alice.CoverImage)
+	assert.Empty(t, entries[3].Activity, "mixed activities leave the activity blank")
+}
+
+func TestLeaderboards_Standings_PageAndNeighbours(t *testing.T) {
+	var runs []*leaderboard.Run
+	for i, user := range []string{"u1", "u2", "u3", "u4", "u5", "u6"} {
+		runs = append(runs, &leaderboard.Run{UserID: user, Distance: float64(60 - i*10)})
+	}
+	load := func(time.Time, string) ([]*leaderboard.Run, error) { return runs, nil }
+	scope := leaderboard.Scope{Kind: leaderboard.ScopeCause, ID: "cause"}
+
+	for name, l := range map[string]*leaderboard.Leaderboards{
+		"database": leaderboard.New(nil, 0),
+		"store":    leaderboard.New(newMemStore(), time.Minute),
+	} {
+		t.Run(name, func(t *testing.T) {
+			// Twice, so the store case answers the second query from the stored board.
+			for i := 0; i < 2; i++ {
+				standings, err := l.Standings(scope, leaderboard.Query{Limit: 2, Offset: 2, UserID: "u5", Neighbours: 2}, load)
+				require.NoError(t, err)
+				assert.Equal(t, 6, standings.Total)
+				assert.Equal(t, leaderboard.WindowAllTime, standings.Board.Window)
+				require.Len(t, standings.Entries, 2)
+				assert.Equal(t, "u3", standings.Entries[0].UserID)
+				assert.Equal(t, 3, standings.Entries[0].Rank)
+
+				require.NotNil(t, standings.Me)
+				assert.Equal(t, 5, standings.Me.Rank)
+				var around []string
+				for _, e := range standings.Neighbours {
+					around = append(around, e.UserID)
+				}
+				assert.Equal(t, []string{"u3", "u4", "u5", "u6"}, around, "neighbours stop at the end of the board")
+			}
+
+			standings, err := l.Standings(scope, leaderboard.Query{UserID: "u1", Neighbours: 1}, load)
+			require.NoError(t, err)
+			assert.Len(t, standings.Entries, 6)
+			require.Len(t, standings.Neighbours, 2, "neighbours stop at the top of the board")
+
+			standings, err = l.Standings(scope, leaderboard.Query{UserID: "nobody", Neighbours: 2}, load)
+			require.NoError(t, err)
+			assert.Nil(t, standings.Me)
+			assert.Empty(t, standings.Neighbours)
+		})
+	}
+}
+
+func TestLeaderboards_StoreCachesUntilInvalidated(t *testing.T) {
+	store := newMemStore()
+	l := leaderboard.New(store, time.Minute)
+	scope := leaderboard.Scope{Kind: leaderboard.ScopeChallenge, ID: "challenge"}
+
+	loads := 0
+	var gotSince time.Time
+	var gotActivity string
+	runs := []*leaderboard.Run{{UserID: "alice", Distance: 5}}
+	load := func(since time.Time, activity string) ([]*leaderboard.Run, error) {
+		loads++
+		gotSince, gotActivity = since, activity
+		return runs, nil
+	}
+
+	q := leaderboard.Query{Window: leaderboard.WindowDaily, Activity: " Run "}
+	_, err := l.Standings(scope, q, load)
+	require.NoError(t, err)
+	assert.Equal(t, leaderboard.WindowDaily.Since(time.Now()), gotSince)
+	assert.Equal(t, "run", gotActivity)
+
+	standings, err := l.Standings(scope, q, load)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loads, "the stored board is served")
+	assert.Equal(t, 1, standings.Total)
+
+	// Other windows and activities are separate boards.
+	_, err = l.Standings(scope, leaderboard.Query{Window: leaderboard.WindowDaily}, load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+
+	runs = append(runs, &leaderboard.Run{UserID: "bob", Distance: 7})
+	l.Invalidate(scope)
+	standings, err = l.Standings(scope, q, load)
+	require.NoError(t, err)
+	assert.Equal(t, 3, loads, "invalidation reloads from the database")
+	assert.Equal(t, 2, standings.Total)
+	assert.Equal(t, "bob", standings.Entries[0].UserID)
+}
+
+func TestLeaderboards_LoadError(t *testing.T) {
+	l := leaderboard.New(newMemStore(), time.Minute)
+	boom := errors.New("boom")
+	_, err := l.Standings(leaderboard.Scope{Kind: leaderboard.ScopeCampaign, ID: "c"}, leaderboard.Query{},
+		func(time.Time, string) ([]*leaderboard.Run, error) { return nil, boom })
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestLeaderboards_UnreachableRedisFallsBackToDatabase(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	defer client.Close()
+	l := leaderboard.NewLeaderboardsFactory(client, time.Minute)
+	require.True(t, l.Cached())
+
+	standings, err := l.Standings(leaderboard.Scope{Kind: leaderboard.ScopeCause, ID: "cause"}, leaderboard.Query{UserID: "alice"},
+		func(time.Time, string) ([]*leaderboard.Run, error) {
+			return []*leaderboard.Run{{UserID: "alice", Distance: 3}}, nil
+		})
+	require.NoError(t, err)
+	require.NotNil(t, standings.Me)
+	assert.Equal(t, 1, standings.Me.Rank)
+
+	assert.False(t, leaderboard.NewLeaderboardsFactory(nil, time.Minute).Cached())
+}
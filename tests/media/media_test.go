@@ -0,0 +1,393 @@
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	"gopi.com/internal/app/media"
+	campaignGorm "gopi.com/internal/data/campaign/model/gorm"
+	campaignDataRepo "gopi.com/internal/data/campaign/repo"
+	challengeGorm "gopi.com/internal/data/challenge/model/gorm"
+	challengeDataRepo "gopi.com/internal/data/challenge/repo"
+	mediaGorm "gopi.com/internal/data/media/model/gorm"
+	mediaDataRepo "gopi.com/internal/data/media/repo"
+	campaignModel "gopi.com/internal/domain/campaign/model"
+	challengeModel "gopi.com/internal/domain/challenge/model"
+	mediaModel "gopi.com/internal/domain/media/model"
+	"gopi.com/internal/domain/model"
+	"gopi.com/internal/lib/oembed"
+	"gopi.com/internal/lib/storage"
+	"gopi.com/tests/testdb"
+)
+
+type mediaFixture struct {
+	service    *media.MediaService
+	uploadsDir string
+}
+
+// newMediaFixture seeds a challenge, a cause, a campaign and a private campaign with one
+// member, all owned by "owner".
+func newMediaFixture(t *testing.T) *mediaFixture {
+	db := testdb.Open(t, &challengeGorm.Challenge{}, &challengeGorm.ChallengeMember{}, &challengeGorm.Cause{}, &challengeGorm.CauseMember{},
+		&campaignGorm.Campaign{}, &campaignGorm.CampaignMember{}, &campaignGorm.CampaignSponsor{}, &mediaGorm.MediaItem{})
+
+	challenges := challengeDataRepo.NewGormChallengeRepository(db)
+	causes := challengeDataRepo.NewGormCauseRepository(db)
+	campaigns := campaignDataRepo.NewGormCampaignRepository(db)
+	require.NoError(t, challenges.Create(&challengeModel.Challenge{Base: model.Base{ID: "ch1"}, OwnerID: "owner", Name: "Harbour run",
+		Slug: "harbour-run", Mode: challengeModel.ChallengeModeF, NoOfWinner: 1, RankingCriterion: challengeModel.RankByDistance}))
+	require.NoError(t, causes.Create(&challengeModel.Cause{Base: model.Base{ID: "cause1"}, ChallengeID: "ch1", Name: "Clean Water",
+		Slug: "clean-water", OwnerID: "owner"}))
+	require.NoError(t, campaigns.Create(&campaignModel.Campaign{Base: model.Base{ID: "camp1"}, Name: "City Run", Slug: "city-run",
+		OwnerID: "owner", Status: campaignModel.CampaignStatusActive}))
+	require.NoError(t, campaigns.Create(&campaignModel.Campaign{Base: model.Base{ID: "private1"}, Name: "Club Run", Slug: "club-run",
+		OwnerID: "owner", Status: campaignModel.CampaignStatusActive, Visibility: campaignModel.CampaignVisibilityPrivate}))
+	require.NoError(t, campaigns.AddMember("private1", "member"))
+
+	uploadsDir := t.TempDir()
+	return &mediaFixture{
+		service: media.NewMediaService(mediaDataRepo.NewGormMediaRepository(db), storage.NewLocalStorage(uploadsDir, "/uploads"),
+			media.WithChallenges(challenges, causes),
+			media.WithCampaigns(campaigns)),
+		uploadsDir: uploadsDir,
+	}
+}
+
+func pngImage(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 4, 4))))
+	return buf.Bytes()
+}
+
+func (f *mediaFixture) addImage(t *testing.T, kind mediaModel.SubjectKind, subjectID, caption string) *mediaModel.Item {
+	data := pngImage(t)
+	item, err := f.service.AddImage(context.Background(), kind, subjectID, "owner", caption,
+		bytes.NewReader(data), int64(len(data)), "image/png", ".png")
+	require.NoError(t, err)
+	return item
+}
+
+func galleryIDs(t *testing.T, s *media.MediaService, kind mediaModel.SubjectKind, subjectID string) []string {
+	items, err := s.ListGallery(kind, subjectID, "", false)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(items))
+	for i, item := range items {
+		assert.Equal(t, i+1, item.Position)
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestMediaService_Galleries(t *testing.T) {
+	f := newMediaFixture(t)
+
+	t.Run("images are stored and appended in order", func(t *testing.T) {
+		first := f.addImage(t, mediaModel.SubjectCause, "cause1", "Start line")
+		second := f.addImage(t, mediaModel.SubjectCause, "cause1", "")
+
+		assert.Equal(t, 1, first.Position)
+		assert.Equal(t, 2, second.Position)
+		assert.Equal(t, "/uploads/media/causes/cause1/"+first.ID+".png", first.URL)
+		_, err := os.Stat(filepath.Join(f.uploadsDir, "media", "causes", "cause1", first.ID+".png"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{first.ID, second.ID}, galleryIDs(t, f.service, mediaModel.SubjectCause, "cause1"))
+	})
+
+	t.Run("videos carry embed details from their link", func(t *testing.T) {
+		item, err := f.service.AddVideo(mediaModel.SubjectChallenge, "ch1", "owner", "https://youtu.be/dQw4w9WgXcQ", "Course preview")
+		require.NoError(t, err)
+
+		assert.Equal(t, mediaModel.TypeVideo, item.Type)
+		assert.Equal(t, "YouTube", item.Provider)
+		assert.Equal(t, "https://www.youtube.com/watch?v=dQw4w9WgXcQ", item.URL)
+		assert.Equal(t, "https://www.youtube.com/embed/dQw4w9WgXcQ", item.EmbedURL)
+		assert.Contains(t, item.EmbedHTML, "<iframe")
+
+		_, err = f.service.AddVideo(mediaModel.SubjectChallenge, "ch1", "owner", "https://example.com/clip.mp4", "")
+		assert.ErrorIs(t, err, oembed.ErrUnsupportedURL)
+	})
+
+	t.Run("galleries are kept apart per subject", func(t *testing.T) {
+		f.addImage(t, mediaModel.SubjectCampaign, "camp1", "")
+
+		assert.Len(t, galleryIDs(t, f.service, mediaModel.SubjectCampaign, "camp1"), 1)
+		assert.Len(t, galleryIDs(t, f.service, mediaModel.SubjectChallenge, "ch1"), 1)
+	})
+
+	t.Run("only the owner manages a gallery", func(t *testing.T) {
+		_, err := f.service.AddVideo(mediaModel.SubjectCampaign, "camp1", "intruder", "https://vimeo.com/76979871", "")
+		assert.ErrorIs(t, err, mediaModel.ErrNotSubjectOwner)
+
+		ids := galleryIDs(t, f.service, mediaModel.SubjectCause, "cause1")
+		_, err = f.service.UpdateCaption(ids[0], "intruder", "mine now")
+		assert.ErrorIs(t, err, mediaModel.ErrNotSubjectOwner)
+		assert.ErrorIs(t, f.service.DeleteItem(context.Background(), ids[0], "intruder"), mediaModel.ErrNotSubjectOwner)
+		_, err = f.service.ReorderGallery(mediaModel.SubjectCause, "cause1", "intruder", []string{ids[1], ids[0]})
+		assert.ErrorIs(t, err, mediaModel.ErrNotSubjectOwner)
+	})
+
+	t.Run("missing subjects have no gallery", func(t *testing.T) {
+		_, err := f.service.ListGallery(mediaModel.SubjectChallenge, "missing", "", false)
+		assert.ErrorIs(t, err, mediaModel.ErrSubjectNotFound)
+	})
+
+	t.Run("private campaign galleries are hidden from outsiders", func(t *testing.T) {
+		_, err := f.service.AddVideo(mediaModel.SubjectCampaign, "private1", "owner", "https://vimeo.com/76979871", "")
+		require.NoError(t, err)
+
+		for _, viewer := range []string{"", "stranger"} {
+			_, err := f.service.ListGallery(mediaModel.SubjectCampaign, "private1", viewer, false)
+			assert.ErrorIs(t, err, mediaModel.ErrSubjectNotFound, viewer)
+		}
+		for _, viewer := range []string{"owner", "member"} {
+			items, err := f.service.ListGallery(mediaModel.SubjectCampaign, "private1", viewer, false)
+			require.NoError(t, err, viewer)
+			assert.Len(t, items, 1)
+		}
+		items, err := f.service.ListGallery(mediaModel.SubjectCampaign, "private1", "stranger", true)
+		require.NoError(t, err, "staff see every gallery")
+		assert.Len(t, items, 1)
+	})
+
+	t.Run("captions are limited", func(t *testing.T) {
+		ids := galleryIDs(t, f.service, mediaModel.SubjectCause, "cause1")
+		_, err := f.service.UpdateCaption(ids[0], "owner", strings.Repeat("é", mediaModel.MaxCaptionLength+1))
+		assert.ErrorIs(t, err, mediaModel.ErrCaptionTooLong)
+
+		item, err := f.service.UpdateCaption(ids[0], "owner", strings.Repeat("é", mediaModel.MaxCaptionLength))
+		require.NoError(t, err)
+		assert.Len(t, []rune(item.Caption), mediaModel.MaxCaptionLength)
+	})
+}
+
+func TestMediaService_ReorderAndDelete(t *testing.T) {
+	f := newMediaFixture(t)
+	a := f.addImage(t, mediaModel.SubjectChallenge, "ch1", "a")
+	b, err := f.service.AddVideo(mediaModel.SubjectChallenge, "ch1", "owner", "https://www.dailymotion.com/video/x8abc12", "b")
+	require.NoError(t, err)
+	c := f.addImage(t, mediaModel.SubjectChallenge, "ch1", "c")
+
+	t.Run("orders must list every item once", func(t *testing.T) {
+		for _, ids := range [][]string{{a.ID, b.ID}, {a.ID, a.ID, b.ID}, {a.ID, b.ID, "other"}} {
+			_, err := f.service.ReorderGallery(mediaModel.SubjectChallenge, "ch1", "owner", ids)
+			assert.ErrorIs(t, err, mediaModel.ErrInvalidOrder)
+		}
+		assert.Equal(t, []string{a.ID, b.ID, c.ID}, galleryIDs(t, f.service, mediaModel.SubjectChallenge, "ch1"))
+	})
+
+	t.Run("reorder", func(t *testing.T) {
+		items, err := f.service.ReorderGallery(mediaModel.SubjectChallenge, "ch1", "owner", []string{c.ID, a.ID, b.ID})
+		require.NoError(t, err)
+		require.Len(t, items, 3)
+		assert.Equal(t, c.ID, items[0].ID)
+		assert.Equal(t, []string{c.ID, a.ID, b.ID}, galleryIDs(t, f.service, mediaModel.SubjectChallenge, "ch1"))
+	})
+
+	t.Run("delete closes the gap and removes the file", func(t *testing.T) {
+		require.NoError(t, f.service.DeleteItem(context.Background(), a.ID, "owner"))
+
+		assert.Equal(t, []string{c.ID, b.ID}, galleryIDs(t, f.service, mediaModel.SubjectChallenge, "ch1"))
+		_, err := os.Stat(filepath.Join(f.uploadsDir, filepath.FromSlash(a.FileKey)))
+		assert.True(t, os.IsNotExist(err))
+		assert.ErrorIs(t, f.service.DeleteItem(context.Background(), a.ID, "owner"), mediaModel.ErrItemNotFound)
+	})
+
+	t.Run("new items go after the last", func(t *testing.T) {
+		d := f.addImage(t, mediaModel.SubjectChallenge, "ch1", "d")
+		assert.Equal(t, 3, d.Position)
+	})
+}
+
+func TestMediaService_DeleteGallery(t *testing.T) {
+	f := newMediaFixture(t)
+	image := f.addImage(t, mediaModel.SubjectCause, "cause1", "")
+	_, err := f.service.AddVideo(mediaModel.SubjectCause, "cause1", "owner", "https://vimeo.com/76979871", "")
+	require.NoError(t, err)
+	kept := f.addImage(t, mediaModel.SubjectChallenge, "ch1", "")
+
+	require.NoError(t, f.service.DeleteGallery(context.Background(), mediaModel.SubjectCause, "cause1"))
+
+	assert.Empty(t, galleryIDs(t, f.service, mediaModel.SubjectCause, "cause1"))
+	_, err = os.Stat(filepath.Join(f.uploadsDir, filepath.FromSlash(image.FileKey)))
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, []string{kept.ID}, galleryIDs(t, f.service, mediaModel.SubjectChallenge, "ch1"))
+	_, err = os.Stat(filepath.Join(f.uploadsDir, filepath.FromSlash(kept.FileKey)))
+	assert.NoError(t, err, "other galleries keep their files")
+}
+
+func TestMediaService_GalleryFull(t *testing.T) {
+	f := newMediaFixture(t)
+	for i := 0; i < mediaModel.MaxGalleryItems; i++ {
+		_, err := f.service.AddVideo(mediaModel.SubjectCampaign, "camp1", "owner", "https://vimeo.com/76979871", "")
+		require.NoError(t, err)
+	}
+
+	_, err := f.service.AddVideo(mediaModel.SubjectCampaign, "camp1", "owner", "https://vimeo.com/76979871", "")
+	assert.ErrorIs(t, err, mediaModel.ErrGalleryFull)
+
+	data := pngImage(t)
+	_, err = f.service.AddImage(context.Background(), mediaModel.SubjectCampaign, "camp1", "owner", "",
+		bytes.NewReader(data), int64(len(data)), "image/png", ".png")
+	assert.ErrorIs(t, err, mediaModel.ErrGalleryFull)
+	entries, err := os.ReadDir(filepath.Join(f.uploadsDir, "media", "campaigns", "camp1"))
+	if err == nil {
+		assert.Empty(t, entries, "the rejected upload is not left in storage")
+	}
+}
+
+func TestMediaService_KindNotEnabled(t *testing.T) {
+	s := media.NewMediaService(nil, nil)
+
+	_, err := s.ListGallery(mediaModel.SubjectCause, "cause1", "", false)
+	assert.ErrorIs(t, err, mediaModel.ErrKindNotEnabled)
+	_, err = s.AddVideo(mediaModel.SubjectCampaign, "camp1", "owner", "https://vimeo.com/76979871", "")
+	assert.ErrorIs(t, err, mediaModel.ErrKindNotEnabled)
+}
+
+func setupMediaRouter(f *mediaFixture) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := handler.NewMediaHandler(f.service)
+
+	router := gin.New()
+	router.GET("/media/:kind/:subject_id", func(c *gin.Context) {
+		if user := c.GetHeader("X-User"); user != "" {
+			c.Set("user_id", user)
+		}
+		c.Next()
+	}, h.GetGallery)
+	protected := router.Group("/media")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", c.GetHeader("X-User"))
+		c.Next()
+	})
+	protected.POST("/:kind/:subject_id/images", h.UploadGalleryImage)
+	protected.POST("/:kind/:subject_id/videos", h.AddGalleryVideo)
+	protected.PUT("/:kind/:subject_id/order", h.ReorderGallery)
+	protected.PUT("/items/:item_id", h.UpdateGalleryCaption)
+	protected.DELETE("/items/:item_id", h.DeleteGalleryItem)
+	return router
+}
+
+func imageUploadRequest(t *testing.T, url, user string, content []byte, caption string) *http.Request {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("image", "photo.png")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteField("caption", caption))
+	require.NoError(t, w.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, url, &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("X-User", user)
+	return req
+}
+
+func jsonRequest(method, url, user string, body interface{}) *http.Request {
+	data, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, url, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User", user)
+	return req
+}
+
+func TestMediaHandler_Gallery(t *testing.T) {
+	f := newMediaFixture(t)
+	router := setupMediaRouter(f)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, imageUploadRequest(t, "/media/causes/cause1/images", "owner", pngImage(t), " Finish line "))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var image dto.MediaItemResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &image))
+	assert.Equal(t, "image", image.Type)
+	assert.Equal(t, "Finish line", image.Caption)
+	assert.Equal(t, "image/png", image.ContentType)
+	assert.Nil(t, image.Video)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, jsonRequest(http.MethodPost, "/media/causes/cause1/videos", "owner",
+		dto.AddGalleryVideoRequest{URL: "https://www.youtube.com/shorts/dQw4w9WgXcQ"}))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var video dto.MediaItemResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &video))
+	require.NotNil(t, video.Video)
+	assert.Equal(t, "YouTube", video.Video.Provider)
+	assert.Equal(t, 2, video.Position)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, jsonRequest(http.MethodPut, "/media/causes/cause1/order", "owner",
+		dto.ReorderGalleryRequest{ItemIDs: []string{video.ID, image.ID}}))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/media/causes/cause1", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var gallery dto.MediaGalleryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &gallery))
+	require.Equal(t, 2, gallery.Count)
+	assert.Equal(t, video.ID, gallery.Items[0].ID)
+	assert.Equal(t, "cause", gallery.SubjectKind)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, jsonRequest(http.MethodPut, "/media/items/"+image.ID, "owner", dto.UpdateGalleryCaptionRequest{Caption: "Podium"}))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodDelete, "/media/items/"+video.ID, nil)
+	req.Header.Set("X-User", "owner")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestMediaHandler_Errors(t *testing.T) {
+	f := newMediaFixture(t)
+	router := setupMediaRouter(f)
+	item := f.addImage(t, mediaModel.SubjectChallenge, "ch1", "")
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		code int
+	}{
+		{"unknown kind", jsonRequest(http.MethodGet, "/media/users/u1", "", nil), http.StatusNotFound},
+		{"unknown subject", jsonRequest(http.MethodGet, "/media/campaigns/missing", "", nil), http.StatusNotFound},
+		{"private campaign", jsonRequest(http.MethodGet, "/media/campaigns/private1", "stranger", nil), http.StatusNotFound},
+		{"private campaign member", jsonRequest(http.MethodGet, "/media/campaigns/private1", "member", nil), http.StatusOK},
+		{"not an image", imageUploadRequest(t, "/media/challenges/ch1/images", "owner", []byte("just some text"), ""), http.StatusBadRequest},
+		{"not the owner", imageUploadRequest(t, "/media/challenges/ch1/images", "intruder", pngImage(t), ""), http.StatusForbidden},
+		{"unsupported video", jsonRequest(http.MethodPost, "/media/challenges/ch1/videos", "owner",
+			dto.AddGalleryVideoRequest{URL: "https://example.com/v/1"}), http.StatusBadRequest},
+		{"missing video url", jsonRequest(http.MethodPost, "/media/challenges/ch1/videos", "owner", map[string]string{}), http.StatusBadRequest},
+		{"incomplete order", jsonRequest(http.MethodPut, "/media/challenges/ch1/order", "owner",
+			dto.ReorderGalleryRequest{ItemIDs: []string{}}), http.StatusBadRequest},
+		{"caption too long", jsonRequest(http.MethodPut, "/media/items/"+item.ID, "owner",
+			dto.UpdateGalleryCaptionRequest{Caption: strings.Repeat("x", mediaModel.MaxCaptionLength+1)}), http.StatusBadRequest},
+		{"unknown item", jsonRequest(http.MethodDelete, "/media/items/missing", "owner", nil), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tt.req)
+			assert.Equal(t, tt.code, w.Code, w.Body.String())
+		})
+	}
+}
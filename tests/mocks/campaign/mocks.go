@@ -1,7 +1,10 @@
 package campaign
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
+	activityModel "gopi.com/internal/domain/activity/model"
 	campaignModel "gopi.com/internal/domain/campaign/model"
 )
 
@@ -65,6 +68,14 @@ func (m *MockCampaignRepository) Search(query string, limit, offset int) ([]*cam
 	return args.Get(0).([]*campaignModel.Campaign), args.Error(1)
 }
 
+func (m *MockCampaignRepository) Find(filter campaignModel.CampaignFilter) ([]*campaignModel.Campaign, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*campaignModel.Campaign), args.Get(1).(int64), args.Error(2)
+}
+
 func (m *MockCampaignRepository) AddMember(campaignID, userID string) error {
 	args := m.Called(campaignID, userID)
 	return args.Error(0)
@@ -95,6 +106,34 @@ func (m *MockCampaignRepository) IsSponsor(campaignID, userID string) (bool, err
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockCampaignRepository) ListDueForTransition(now time.Time) ([]*campaignModel.Campaign, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*campaignModel.Campaign), args.Error(1)
+}
+
+func (m *MockCampaignRepository) UpdateStatus(id string, from, to campaignModel.CampaignStatus) (bool, error) {
+	args := m.Called(id, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCampaignRepository) IncrementTotals(id string, distance, money float64) error {
+	args := m.Called(id, distance, money)
+	return args.Error(0)
+}
+
+func (m *MockCampaignRepository) MarkAchieved(id string, at time.Time) (bool, error) {
+	args := m.Called(id, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCampaignRepository) MarkClosed(id string, at time.Time) (bool, error) {
+	args := m.Called(id, at)
+	return args.Bool(0), args.Error(1)
+}
+
 // MockCampaignRunnerRepository implements the CampaignRunnerRepository interface for testing
 type MockCampaignRunnerRepository struct {
 	mock.Mock
@@ -139,6 +178,16 @@ func (m *MockCampaignRunnerRepository) Delete(id string) error {
 	return args.Error(0)
 }
 
+func (m *MockCampaignRunnerRepository) IncrementProgress(id string, distance, money float64, duration time.Duration) error {
+	args := m.Called(id, distance, money, duration)
+	return args.Error(0)
+}
+
+func (m *MockCampaignRunnerRepository) AttachTrack(id, trackURL string, elevationGain float64) error {
+	args := m.Called(id, trackURL, elevationGain)
+	return args.Error(0)
+}
+
 // MockSponsorCampaignRepository implements the SponsorCampaignRepository interface for testing
 type MockSponsorCampaignRepository struct {
 	mock.Mock
@@ -174,3 +223,228 @@ func (m *MockSponsorCampaignRepository) Delete(id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+func (m *MockSponsorCampaignRepository) Confirm(id string, sponsors []campaignModel.UserSummary) (bool, error) {
+	args := m.Called(id, sponsors)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockCampaignResultRepository implements the CampaignResultRepository interface for testing
+type MockCampaignResultRepository struct {
+	mock.Mock
+}
+
+func (m *MockCampaignResultRepository) SaveStandings(campaignID string, standings []*campaignModel.CampaignStanding) error {
+	args := m.Called(campaignID, standings)
+	return args.Error(0)
+}
+
+func (m *MockCampaignResultRepository) GetStandings(campaignID string) ([]*campaignModel.CampaignStanding, error) {
+	args := m.Called(campaignID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*campaignModel.CampaignStanding), args.Error(1)
+}
+
+func (m *MockCampaignResultRepository) SaveObligations(campaignID string, obligations []*campaignModel.SponsorObligation) error {
+	args := m.Called(campaignID, obligations)
+	return args.Error(0)
+}
+
+func (m *MockCampaignResultRepository) GetObligations(campaignID string) ([]*campaignModel.SponsorObligation, error) {
+	args := m.Called(campaignID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*campaignModel.SponsorObligation), args.Error(1)
+}
+
+// MockCampaignRunRepository implements the CampaignRunRepository interface for testing
+type MockCampaignRunRepository struct {
+	mock.Mock
+}
+
+func (m *MockCampaignRunRepository) Create(run *campaignModel.CampaignRun) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
+func (m *MockCampaignRunRepository) GetByID(id string) (*campaignModel.CampaignRun, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*campaignModel.CampaignRun), args.Error(1)
+}
+
+func (m *MockCampaignRunRepository) ListForLeaderboard(campaignID string, since time.Time, activity string) ([]*campaignModel.CampaignRun, error) {
+	args := m.Called(campaignID, since, activity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*campaignModel.CampaignRun), args.Error(1)
+}
+
+func (m *MockCampaignRunRepository) ListByStatus(status activityModel.ReviewStatus, limit, offset int) ([]*campaignModel.CampaignRun, error) {
+	args := m.Called(status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*campaignModel.CampaignRun), args.Error(1)
+}
+
+func (m *MockCampaignRunRepository) SumDistanceSince(ownerID string, since time.Time) (float64, error) {
+	args := m.Called(ownerID, since)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockCampaignRunRepository) Review(id string, status activityModel.ReviewStatus, reviewerID string, at time.Time) (bool, error) {
+	args := m.Called(id, status, reviewerID, at)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockCampaignTeamRepository implements the CampaignTeamRepository interface for testing
+type MockCampaignTeamRepository struct {
+	mock.Mock
+}
+
+func (m *MockCampaignTeamRepository) Create(team *campaignModel.CampaignTeam) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
+func (m *MockCampaignTeamRepository) GetByID(id string) (*campaignModel.CampaignTeam, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*campaignModel.CampaignTeam), args.Error(1)
+}
+
+func (m *MockCampaignTeamRepository) GetByInviteCode(campaignID, code string) (*campaignModel.CampaignTeam, error) {
+	args := m.Called(campaignID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*campaignModel.CampaignTeam), args.Error(1)
+}
+
+func (m *MockCampaignTeamRepository) ListByCampaign(campaignID string) ([]*campaignModel.CampaignTeam, error) {
+	args := m.Called(campaignID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*campaignModel.CampaignTeam), args.Error(1)
+}
+
+func (m *MockCampaignTeamRepository) Update(team *campaignModel.CampaignTeam) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
+func (m *MockCampaignTeamRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCampaignTeamRepository) AddMember(member *campaignModel.CampaignTeamMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *MockCampaignTeamRepository) RemoveMember(teamID, userID string) error {
+	args := m.Called(teamID, userID)
+	return args.Error(0)
+}
+
+func (m *MockCampaignTeamRepository) TeamOf(campaignID, userID string) (*campaignModel.CampaignTeam, error) {
+	args := m.Called(campaignID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*campaignModel.CampaignTeam), args.Error(1)
+}
+
+func (m *MockCampaignTeamRepository) Standings(campaignID string) ([]*campaignModel.TeamStanding, error) {
+	args := m.Called(campaignID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*campaignModel.TeamStanding), args.Error(1)
+}
+
+// MockCampaignInviteRepository implements the CampaignInviteRepository interface for testing
+type MockCampaignInviteRepository struct {
+	mock.Mock
+}
+
+func (m *MockCampaignInviteRepository) Create(invite *campaignModel.CampaignInvite) error {
+	args := m.Called(invite)
+	return args.Error(0)
+}
+
+func (m *MockCampaignInviteRepository) GetByID(id string) (*campaignModel.CampaignInvite, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*campaignModel.CampaignInvite), args.Error(1)
+}
+
+func (m *MockCampaignInviteRepository) ListByCampaign(campaignID string) ([]*campaignModel.CampaignInvite, error) {
+	args := m.Called(campaignID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*campaignModel.CampaignInvite), args.Error(1)
+}
+
+func (m *MockCampaignInviteRepository) Redeem(id string, now time.Time) (bool, error) {
+	args := m.Called(id, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCampaignInviteRepository) Revoke(id string, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
+
+// MockCampaignJoinRequestRepository implements the CampaignJoinRequestRepository interface for testing
+type MockCampaignJoinRequestRepository struct {
+	mock.Mock
+}
+
+func (m *MockCampaignJoinRequestRepository) Create(request *campaignModel.CampaignJoinRequest) error {
+	args := m.Called(request)
+	return args.Error(0)
+}
+
+func (m *MockCampaignJoinRequestRepository) GetByID(id string) (*campaignModel.CampaignJoinRequest, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*campaignModel.CampaignJoinRequest), args.Error(1)
+}
+
+func (m *MockCampaignJoinRequestRepository) GetPending(campaignID, userID string) (*campaignModel.CampaignJoinRequest, error) {
+	args := m.Called(campaignID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*campaignModel.CampaignJoinRequest), args.Error(1)
+}
+
+func (m *MockCampaignJoinRequestRepository) ListByCampaign(campaignID string, status campaignModel.JoinRequestStatus) ([]*campaignModel.CampaignJoinRequest, error) {
+	args := m.Called(campaignID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*campaignModel.CampaignJoinRequest), args.Error(1)
+}
+
+func (m *MockCampaignJoinRequestRepository) Decide(id string, status campaignModel.JoinRequestStatus, deciderID string, at time.Time) (bool, error) {
+	args := m.Called(id, status, deciderID, at)
+	return args.Bool(0), args.Error(1)
+}
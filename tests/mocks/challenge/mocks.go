@@ -1,8 +1,12 @@
 package challenge
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
+	activityModel "gopi.com/internal/domain/activity/model"
 	challengeModel "gopi.com/internal/domain/challenge/model"
+	"gopi.com/internal/domain/model"
 )
 
 // MockChallengeRepository implements the ChallengeRepository interface for testing
@@ -57,7 +61,190 @@ func (m *MockChallengeRepository) List(limit, offset int) ([]*challengeModel.Cha
 	return args.Get(0).([]*challengeModel.Challenge), args.Error(1)
 }
 
+func (m *MockChallengeRepository) ListNear(near model.Near, limit, offset int) ([]*challengeModel.Challenge, int64, error) {
+	args := m.Called(near, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*challengeModel.Challenge), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockChallengeRepository) ListDueForCloseout(now time.Time) ([]*challengeModel.Challenge, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.Challenge), args.Error(1)
+}
+
+func (m *MockChallengeRepository) MarkClosed(id string, at time.Time) (bool, error) {
+	args := m.Called(id, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChallengeRepository) MarkCancelled(id string, at time.Time) (bool, error) {
+	args := m.Called(id, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChallengeRepository) TransferOwner(id, fromID, toID string) (bool, error) {
+	args := m.Called(id, fromID, toID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChallengeRepository) AddMember(challengeID, userID string) error {
+	args := m.Called(challengeID, userID)
+	return args.Error(0)
+}
+
+func (m *MockChallengeRepository) RemoveMember(challengeID, userID string) (bool, error) {
+	args := m.Called(challengeID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChallengeRepository) IsMember(challengeID, userID string) (bool, error) {
+	args := m.Called(challengeID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChallengeRepository) ListMembers(challengeID string, limit, offset int) ([]*challengeModel.Membership, int64, error) {
+	args := m.Called(challengeID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*challengeModel.Membership), args.Get(1).(int64), args.Error(2)
+}
+
+// MockChallengeWinnerRepository implements the ChallengeWinnerRepository interface for testing
+type MockChallengeWinnerRepository struct {
+	mock.Mock
+}
+
+func (m *MockChallengeWinnerRepository) SaveWinners(challengeID string, winners []*challengeModel.ChallengeWinner, audit *challengeModel.WinnerAudit) error {
+	args := m.Called(challengeID, winners, audit)
+	return args.Error(0)
+}
+
+func (m *MockChallengeWinnerRepository) GetWinners(challengeID string) ([]*challengeModel.ChallengeWinner, error) {
+	args := m.Called(challengeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.ChallengeWinner), args.Error(1)
+}
+
+func (m *MockChallengeWinnerRepository) ListAudits(challengeID string) ([]*challengeModel.WinnerAudit, error) {
+	args := m.Called(challengeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.WinnerAudit), args.Error(1)
+}
+
 // MockCauseRepository implements the CauseRepository interface for testing
+type MockJudgingRepository struct {
+	mock.Mock
+}
+
+func (m *MockJudgingRepository) CreateRound(round *challengeModel.JudgingRound) error {
+	args := m.Called(round)
+	return args.Error(0)
+}
+
+func (m *MockJudgingRepository) GetRound(id string) (*challengeModel.JudgingRound, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*challengeModel.JudgingRound), args.Error(1)
+}
+
+func (m *MockJudgingRepository) ListRounds(challengeID string) ([]*challengeModel.JudgingRound, error) {
+	args := m.Called(challengeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.JudgingRound), args.Error(1)
+}
+
+func (m *MockJudgingRepository) ListDueRounds(now time.Time) ([]*challengeModel.JudgingRound, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.JudgingRound), args.Error(1)
+}
+
+func (m *MockJudgingRepository) MarkRoundClosed(id string, at time.Time) (bool, error) {
+	args := m.Called(id, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockJudgingRepository) AddJudge(judge *challengeModel.Judge) error {
+	args := m.Called(judge)
+	return args.Error(0)
+}
+
+func (m *MockJudgingRepository) GetJudge(roundID, userID string) (*challengeModel.Judge, error) {
+	args := m.Called(roundID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*challengeModel.Judge), args.Error(1)
+}
+
+func (m *MockJudgingRepository) ListJudges(roundID string) ([]*challengeModel.Judge, error) {
+	args := m.Called(roundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.Judge), args.Error(1)
+}
+
+func (m *MockJudgingRepository) RemoveJudge(roundID, userID string) (bool, error) {
+	args := m.Called(roundID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockJudgingRepository) SaveScore(score *challengeModel.JudgeScore) error {
+	args := m.Called(score)
+	return args.Error(0)
+}
+
+func (m *MockJudgingRepository) ListScores(roundID string) ([]*challengeModel.JudgeScore, error) {
+	args := m.Called(roundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.JudgeScore), args.Error(1)
+}
+
+func (m *MockJudgingRepository) CastVote(vote *challengeModel.Vote) (bool, error) {
+	args := m.Called(vote)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockJudgingRepository) CountVotes(roundID string) (map[string]int, error) {
+	args := m.Called(roundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockJudgingRepository) SaveResults(roundID string, results []*challengeModel.RoundResult) error {
+	args := m.Called(roundID, results)
+	return args.Error(0)
+}
+
+func (m *MockJudgingRepository) GetResults(roundID string) ([]*challengeModel.RoundResult, error) {
+	args := m.Called(roundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.RoundResult), args.Error(1)
+}
+
 type MockCauseRepository struct {
 	mock.Mock
 }
@@ -109,6 +296,47 @@ func (m *MockCauseRepository) Delete(id string) error {
 	return args.Error(0)
 }
 
+func (m *MockCauseRepository) TransferOwner(id, fromID, toID string) (bool, error) {
+	args := m.Called(id, fromID, toID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCauseRepository) IncrementDistance(id string, distance float64) error {
+	args := m.Called(id, distance)
+	return args.Error(0)
+}
+
+func (m *MockCauseRepository) ListNear(near model.Near, limit, offset int) ([]*challengeModel.Cause, int64, error) {
+	args := m.Called(near, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*challengeModel.Cause), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCauseRepository) AddMember(causeID, userID string) error {
+	args := m.Called(causeID, userID)
+	return args.Error(0)
+}
+
+func (m *MockCauseRepository) RemoveMember(causeID, userID string) (bool, error) {
+	args := m.Called(causeID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCauseRepository) IsMember(causeID, userID string) (bool, error) {
+	args := m.Called(causeID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCauseRepository) ListMembers(causeID string, limit, offset int) ([]*challengeModel.Membership, int64, error) {
+	args := m.Called(causeID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*challengeModel.Membership), args.Get(1).(int64), args.Error(2)
+}
+
 // MockCauseRunnerRepository implements the CauseRunnerRepository interface for testing
 type MockCauseRunnerRepository struct {
 	mock.Mock
@@ -161,6 +389,42 @@ func (m *MockCauseRunnerRepository) GetLeaderboard() ([]*challengeModel.CauseRun
 	return args.Get(0).([]*challengeModel.CauseRunner), args.Error(1)
 }
 
+func (m *MockCauseRunnerRepository) AttachTrack(runner *challengeModel.CauseRunner, distance float64, duration time.Duration, trackURL string, elevationGain float64) (bool, error) {
+	args := m.Called(runner.ID, distance, duration, trackURL, elevationGain)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCauseRunnerRepository) SumDistanceSince(ownerID string, since time.Time) (float64, error) {
+	args := m.Called(ownerID, since)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockCauseRunnerRepository) ListForLeaderboard(causeIDs []string, since time.Time, activity string) ([]*challengeModel.CauseRunner, error) {
+	args := m.Called(causeIDs, since, activity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.CauseRunner), args.Error(1)
+}
+
+func (m *MockCauseRunnerRepository) ListByStatus(status activityModel.ReviewStatus, limit, offset int) ([]*challengeModel.CauseRunner, error) {
+	args := m.Called(status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.CauseRunner), args.Error(1)
+}
+
+func (m *MockCauseRunnerRepository) Flag(id string, violations []activityModel.Violation) error {
+	args := m.Called(id, violations)
+	return args.Error(0)
+}
+
+func (m *MockCauseRunnerRepository) Review(id string, status activityModel.ReviewStatus, reviewerID string, at time.Time) (bool, error) {
+	args := m.Called(id, status, reviewerID, at)
+	return args.Bool(0), args.Error(1)
+}
+
 // MockSponsorChallengeRepository implements the SponsorChallengeRepository interface for testing
 type MockSponsorChallengeRepository struct {
 	mock.Mock
@@ -292,3 +556,73 @@ func (m *MockCauseBuyerRepository) Delete(id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+// MockCauseOrderRepository implements the CauseOrderRepository interface for testing
+type MockCauseOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockCauseOrderRepository) Create(order *challengeModel.CauseOrder) error {
+	args := m.Called(order)
+	return args.Error(0)
+}
+
+func (m *MockCauseOrderRepository) GetByID(id string) (*challengeModel.CauseOrder, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*challengeModel.CauseOrder), args.Error(1)
+}
+
+func (m *MockCauseOrderRepository) ListByBuyer(buyerID string, limit, offset int) ([]*challengeModel.CauseOrder, int64, error) {
+	args := m.Called(buyerID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*challengeModel.CauseOrder), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCauseOrderRepository) ListByCause(causeID string, limit, offset int) ([]*challengeModel.CauseOrder, int64, error) {
+	args := m.Called(causeID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*challengeModel.CauseOrder), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCauseOrderRepository) Transition(order *challengeModel.CauseOrder, to challengeModel.OrderStatus, at time.Time, soldDelta int) (bool, error) {
+	args := m.Called(order, to, at, soldDelta)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCauseOrderRepository) SetStock(causeID string, stock *int) (bool, error) {
+	args := m.Called(causeID, stock)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockSponsorStatementRepository implements the SponsorStatementRepository interface for testing
+type MockSponsorStatementRepository struct {
+	mock.Mock
+}
+
+func (m *MockSponsorStatementRepository) SaveStatements(causeID string, statements []*challengeModel.SponsorStatement) (bool, error) {
+	args := m.Called(causeID, statements)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSponsorStatementRepository) ListByCause(causeID string) ([]*challengeModel.SponsorStatement, error) {
+	args := m.Called(causeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.SponsorStatement), args.Error(1)
+}
+
+func (m *MockSponsorStatementRepository) ListBySponsor(sponsorID string) ([]*challengeModel.SponsorStatement, error) {
+	args := m.Called(sponsorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengeModel.SponsorStatement), args.Error(1)
+}
@@ -24,6 +24,14 @@ func (m *MockUserRepository) GetByID(id string) (*userModel.User, error) {
 	return args.Get(0).(*userModel.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetByIDs(ids []string) ([]*userModel.User, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*userModel.User), args.Error(1)
+}
+
 func (m *MockUserRepository) GetByEmail(email string) (*userModel.User, error) {
 	args := m.Called(email)
 	if args.Get(0) == nil {
@@ -0,0 +1,76 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/internal/domain/model"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected time.Duration
+		wantErr  bool
+	}{
+		// Clock forms
+		{in: "45", expected: 45 * time.Second},
+		{in: "25:30", expected: 25*time.Minute + 30*time.Second},
+		{in: "1:02:03", expected: time.Hour + 2*time.Minute + 3*time.Second},
+		{in: "90:00", expected: 90 * time.Minute},
+		{in: " 10:00 ", expected: 10 * time.Minute},
+		{in: "", expected: 0},
+		// Numbers with units
+		{in: "45 min", expected: 45 * time.Minute},
+		{in: "45mins", expected: 45 * time.Minute},
+		{in: "30m", expected: 30 * time.Minute},
+		{in: "1h 30m", expected: 90 * time.Minute},
+		{in: "1h30m15s", expected: 90*time.Minute + 15*time.Second},
+		{in: "2 Hours", expected: 2 * time.Hour},
+		{in: "1 hour and 5 minutes", expected: 65 * time.Minute},
+		{in: "1.5 hrs", expected: 90 * time.Minute},
+		{in: "90 seconds", expected: 90 * time.Second},
+		// ISO-8601
+		{in: "PT45M", expected: 45 * time.Minute},
+		{in: "PT1H2M3S", expected: time.Hour + 2*time.Minute + 3*time.Second},
+		{in: "pt25m30.9s", expected: 25*time.Minute + 30*time.Second},
+		{in: "P1DT2H", expected: 26 * time.Hour},
+		// Invalid
+		{in: "1:2:3:4", wantErr: true},
+		{in: "-5:00", wantErr: true},
+		{in: "half an hour", wantErr: true},
+		{in: "45 furlongs", wantErr: true},
+		{in: "-10", wantErr: true},
+		{in: "P", wantErr: true},
+		{in: "PT", wantErr: true},
+		{in: "P1W", wantErr: true},
+		{in: "PT5X", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := model.ParseDuration(tt.in)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, model.ErrInvalidDuration), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "00:00", model.FormatDuration(0))
+	assert.Equal(t, "05:07", model.FormatDuration(5*time.Minute+7*time.Second))
+	assert.Equal(t, "1:02:03", model.FormatDuration(time.Hour+2*time.Minute+3*time.Second))
+	assert.Equal(t, "26:00:00", model.FormatDuration(26*time.Hour))
+}
+
+func TestSeconds(t *testing.T) {
+	assert.Equal(t, int64(2730), model.Seconds(45*time.Minute+30*time.Second+900*time.Millisecond))
+	assert.Equal(t, 45*time.Minute+30*time.Second, model.FromSeconds(2730))
+}
@@ -0,0 +1,92 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/internal/domain/model"
+)
+
+func TestParseGeoPoint(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected model.GeoPoint
+		wantErr  bool
+	}{
+		{in: "6.5244,3.3792", expected: model.GeoPoint{Lat: 6.5244, Lon: 3.3792}},
+		{in: " -33.9249 , 18.4241 ", expected: model.GeoPoint{Lat: -33.9249, Lon: 18.4241}},
+		{in: "90,-180", expected: model.GeoPoint{Lat: 90, Lon: -180}},
+		{in: "6.5244", wantErr: true},
+		{in: "lagos,nigeria", wantErr: true},
+		{in: "91,0", wantErr: true},
+		{in: "0,181", wantErr: true},
+		{in: "NaN,0", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := model.ParseGeoPoint(tt.in)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, model.ErrInvalidCoordinates), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestGeoPoint_DistanceKm(t *testing.T) {
+	london := model.GeoPoint{Lat: 51.5074, Lon: -0.1278}
+	paris := model.GeoPoint{Lat: 48.8566, Lon: 2.3522}
+
+	assert.InDelta(t, 343.6, london.DistanceKm(paris), 0.1)
+	assert.InDelta(t, london.DistanceKm(paris), paris.DistanceKm(london), 1e-9)
+	assert.Zero(t, london.DistanceKm(london))
+
+	// Across the antimeridian the short way round is used.
+	fiji := model.GeoPoint{Lat: -17.8, Lon: 179.9}
+	samoa := model.GeoPoint{Lat: -17.8, Lon: -179.9}
+	assert.Less(t, fiji.DistanceKm(samoa), 25.0)
+}
+
+func TestGeoPoint_BoundingBox(t *testing.T) {
+	lagos := model.GeoPoint{Lat: 6.5244, Lon: 3.3792}
+	box := lagos.BoundingBox(50)
+	assert.False(t, box.CrossesAntimeridian())
+
+	// Points on the circle in each direction are inside the box.
+	for _, p := range []model.GeoPoint{
+		{Lat: lagos.Lat + 0.449, Lon: lagos.Lon},
+		{Lat: lagos.Lat - 0.449, Lon: lagos.Lon},
+		{Lat: lagos.Lat, Lon: lagos.Lon + 0.45},
+		{Lat: lagos.Lat, Lon: lagos.Lon - 0.45},
+	} {
+		require.LessOrEqual(t, lagos.DistanceKm(p), 50.0)
+		assert.True(t, p.Lat >= box.MinLat && p.Lat <= box.MaxLat && p.Lon >= box.MinLon && p.Lon <= box.MaxLon, "%v outside %v", p, box)
+	}
+
+	fiji := model.GeoPoint{Lat: -17.8, Lon: 179.9}
+	wrapped := fiji.BoundingBox(100)
+	assert.True(t, wrapped.CrossesAntimeridian())
+	assert.Greater(t, wrapped.MinLon, 178.0)
+	assert.Less(t, wrapped.MaxLon, -179.0)
+
+	// A radius reaching the pole covers every longitude.
+	polar := model.GeoPoint{Lat: 89.5, Lon: 10}.BoundingBox(100)
+	assert.Equal(t, 90.0, polar.MaxLat)
+	assert.Equal(t, -180.0, polar.MinLon)
+	assert.Equal(t, 180.0, polar.MaxLon)
+}
+
+func TestNear_Contains(t *testing.T) {
+	near := model.Near{Center: model.GeoPoint{Lat: 6.5244, Lon: 3.3792}, RadiusKm: 25}
+
+	assert.True(t, near.Contains(&model.GeoPoint{Lat: 6.6018, Lon: 3.3515}))  // Ikeja, ~9 km
+	assert.True(t, near.Contains(&model.GeoPoint{Lat: 6.4698, Lon: 3.5852}))  // Lekki, ~24 km
+	assert.False(t, near.Contains(&model.GeoPoint{Lat: 9.0765, Lon: 7.3986})) // Abuja, ~526 km
+	assert.False(t, near.Contains(nil))
+}
@@ -0,0 +1,128 @@
+package notification_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/dto"
+	"gopi.com/api/http/handler"
+	"gopi.com/internal/app/notification"
+	notificationGorm "gopi.com/internal/data/notification/model/gorm"
+	notificationDataRepo "gopi.com/internal/data/notification/repo"
+	notificationModel "gopi.com/internal/domain/notification/model"
+	notificationRepo "gopi.com/internal/domain/notification/repo"
+	"gopi.com/tests/testdb"
+)
+
+func setupNotificationRepo(t *testing.T) notificationRepo.NotificationRepository {
+	db := testdb.Open(t, &notificationGorm.Notification{})
+	return notificationDataRepo.NewGormNotificationRepository(db)
+}
+
+func milestoneNotification(userID, sourceID, title string) *notificationModel.Notification {
+	return &notificationModel.Notification{
+		UserID:   userID,
+		Kind:     notificationModel.KindCampaignMilestone,
+		SourceID: sourceID,
+		Title:    title,
+	}
+}
+
+func TestNotificationRepository(t *testing.T) {
+	repo := setupNotificationRepo(t)
+
+	require.NoError(t, repo.CreateBatch([]*notificationModel.Notification{
+		milestoneNotification("ada", "m1", "first"),
+		milestoneNotification("bob", "m1", "first"),
+	}))
+	// Delivering the same event again is a no-op.
+	require.NoError(t, repo.CreateBatch([]*notificationModel.Notification{milestoneNotification("ada", "m1", "again")}))
+	require.NoError(t, repo.CreateBatch([]*notificationModel.Notification{milestoneNotification("ada", "m2", "second")}))
+
+	all, err := repo.ListByUser("ada", false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	titles := []string{all[0].Title, all[1].Title}
+	assert.ElementsMatch(t, []string{"first", "second"}, titles)
+
+	unread, err := repo.CountUnread("ada")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), unread)
+
+	assert.ErrorIs(t, repo.MarkRead(all[0].ID, "bob", time.Now()), notificationModel.ErrNotificationNotFound)
+	require.NoError(t, repo.MarkRead(all[0].ID, "ada", time.Now()))
+	require.NoError(t, repo.MarkRead(all[0].ID, "ada", time.Now()), "marking again is a no-op")
+
+	unreadOnly, err := repo.ListByUser("ada", true, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, unreadOnly, 1)
+	assert.Equal(t, all[1].ID, unreadOnly[0].ID)
+
+	marked, err := repo.MarkAllRead("ada", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), marked)
+	unread, err = repo.CountUnread("bob")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), unread, "other users are untouched")
+}
+
+func TestNotificationHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := setupNotificationRepo(t)
+	require.NoError(t, repo.CreateBatch([]*notificationModel.Notification{
+		milestoneNotification("ada", "m1", "Coast Run reached 25% of the distance goal"),
+		milestoneNotification("ada", "m2", "Coast Run reached 50% of the distance goal"),
+		milestoneNotification("bob", "m1", "Coast Run reached 25% of the distance goal"),
+	}))
+
+	notificationHandler := handler.NewNotificationHandler(notification.NewNotificationService(repo))
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "ada")
+		c.Next()
+	})
+	router.GET("/notifications", notificationHandler.ListNotifications)
+	router.POST("/notifications/read_all", notificationHandler.MarkAllNotificationsRead)
+	router.POST("/notifications/:id/read", notificationHandler.MarkNotificationRead)
+
+	list := func(query string) dto.NotificationListResponse {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/notifications"+query, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var response dto.NotificationListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	response := list("")
+	require.Equal(t, 2, response.Count)
+	assert.Equal(t, int64(2), response.Unread)
+	assert.False(t, response.Notifications[0].Read)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/notifications/"+response.Notifications[0].ID+"/read", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	response = list("?unread=true")
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, int64(1), response.Unread)
+
+	bobs, err := repo.ListByUser("bob", false, 10, 0)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/notifications/"+bobs[0].ID+"/read", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code, "another user's notification")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/notifications/read_all", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var marked dto.MarkAllReadResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &marked))
+	assert.Equal(t, int64(1), marked.Marked)
+	assert.Equal(t, int64(0), list("").Unread)
+}
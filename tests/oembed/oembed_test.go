@@ -0,0 +1,71 @@
+package oembed_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/internal/lib/oembed"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		provider  string
+		videoID   string
+		embedURL  string
+		thumbnail string
+		width     int
+		height    int
+	}{
+		{"youtube watch", "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42s", "YouTube", "dQw4w9WgXcQ",
+			"https://www.youtube.com/embed/dQw4w9WgXcQ", "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg", 640, 360},
+		{"youtube mobile", "https://m.youtube.com/watch?v=dQw4w9WgXcQ", "YouTube", "dQw4w9WgXcQ",
+			"https://www.youtube.com/embed/dQw4w9WgXcQ", "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg", 640, 360},
+		{"youtube short link", "https://youtu.be/dQw4w9WgXcQ?si=abc", "YouTube", "dQw4w9WgXcQ",
+			"https://www.youtube.com/embed/dQw4w9WgXcQ", "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg", 640, 360},
+		{"youtube embed", "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ", "YouTube", "dQw4w9WgXcQ",
+			"https://www.youtube.com/embed/dQw4w9WgXcQ", "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg", 640, 360},
+		{"youtube shorts are portrait", "https://youtube.com/shorts/dQw4w9WgXcQ", "YouTube", "dQw4w9WgXcQ",
+			"https://www.youtube.com/embed/dQw4w9WgXcQ", "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg", 360, 640},
+		{"vimeo", "https://vimeo.com/76979871", "Vimeo", "76979871", "https://player.vimeo.com/video/76979871", "", 640, 360},
+		{"vimeo channel", "https://vimeo.com/channels/staffpicks/76979871", "Vimeo", "76979871",
+			"https://player.vimeo.com/video/76979871", "", 640, 360},
+		{"vimeo player", "https://player.vimeo.com/video/76979871", "Vimeo", "76979871", "https://player.vimeo.com/video/76979871", "", 640, 360},
+		{"dailymotion with slug", "https://www.dailymotion.com/video/x8abc12_city-marathon", "Dailymotion", "x8abc12",
+			"https://www.dailymotion.com/embed/video/x8abc12", "https://www.dailymotion.com/thumbnail/video/x8abc12", 640, 360},
+		{"dailymotion short link", "https://dai.ly/x8abc12", "Dailymotion", "x8abc12",
+			"https://www.dailymotion.com/embed/video/x8abc12", "https://www.dailymotion.com/thumbnail/video/x8abc12", 640, 360},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			video, err := oembed.Parse(tt.url)
+			require.NoError(t, err)
+			assert.Equal(t, tt.provider, video.ProviderName)
+			assert.Equal(t, tt.videoID, video.VideoID)
+			assert.Equal(t, tt.embedURL, video.EmbedURL)
+			assert.Equal(t, tt.thumbnail, video.ThumbnailURL)
+			assert.Equal(t, tt.width, video.Width)
+			assert.Equal(t, tt.height, video.Height)
+			assert.Contains(t, video.HTML, `src="`+tt.embedURL+`"`)
+		})
+	}
+}
+
+func TestParse_Unsupported(t *testing.T) {
+	for _, url := range []string{
+		"",
+		"not a url",
+		"javascript:alert(1)",
+		"https://example.com/watch?v=dQw4w9WgXcQ",
+		"https://www.youtube.com/watch?v=short",
+		"https://www.youtube.com/channel/UC123",
+		"https://youtu.be/dQw4w9WgXcQ\"><script>",
+		"https://vimeo.com/about",
+		"https://www.dailymotion.com/user/someone",
+	} {
+		_, err := oembed.Parse(url)
+		assert.ErrorIs(t, err, oembed.ErrUnsupportedURL, url)
+	}
+}
@@ -0,0 +1,115 @@
+package pdf_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/internal/lib/pdf"
+)
+
+func write(t *testing.T, doc *pdf.Document) []byte {
+	var buf bytes.Buffer
+	n, err := doc.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+	return buf.Bytes()
+}
+
+// checkXref follows startxref and checks every entry points at its object.
+func checkXref(t *testing.T, data []byte) {
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	require.NotNil(t, m, "trailer")
+	start, _ := strconv.Atoi(string(m[1]))
+	require.True(t, bytes.HasPrefix(data[start:], []byte("xref\n")))
+
+	lines := strings.Split(string(data[start:]), "\n")
+	var count int
+	fmt.Sscanf(lines[1], "0 %d", &count)
+	require.Greater(t, count, 1)
+	for obj := 1; obj < count; obj++ {
+		offset, err := strconv.Atoi(lines[2+obj][:10])
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data[offset:], []byte(fmt.Sprintf("%d 0 obj\n", obj))), "object %d", obj)
+	}
+}
+
+func testImages(t *testing.T) (pngData, jpegData []byte) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	var p, j bytes.Buffer
+	require.NoError(t, png.Encode(&p, img))
+	require.NoError(t, jpeg.Encode(&j, img, nil))
+	return p.Bytes(), j.Bytes()
+}
+
+func TestDocument_Structure(t *testing.T) {
+	doc := pdf.NewDocument(pdf.A4Height, pdf.A4Width)
+	doc.SetTitle("Results")
+	page := doc.AddPage()
+	page.FillRect(10, 20, 100, 50, color.RGBA{R: 255, A: 255})
+	page.StrokeRect(10, 20, 100, 50, 2, color.Black)
+	page.Line(0, 0, 10, 10, 1, nil)
+	page.Text(50, 100, pdf.HelveticaBold, 24, color.Black, `Tom (and \ Jerry) – café`)
+	doc.AddPage().Text(10, 10, pdf.Helvetica, 10, nil, "Page two ☃")
+
+	data := write(t, doc)
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	checkXref(t, data)
+
+	s := string(data)
+	assert.Contains(t, s, "/Count 2 /MediaBox [0 0 841.89 595.28]")
+	assert.Contains(t, s, "/BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding")
+	assert.Contains(t, s, "/Title (Results)")
+	// Top-left coordinates are flipped to the PDF's bottom-left origin.
+	assert.Contains(t, s, "1.000 0.000 0.000 rg 10 525.28 100 50 re f")
+	// Delimiters are escaped; en dash and é are Windows-1252 bytes; the snowman is not encodable.
+	assert.Contains(t, s, `/F2 24 Tf 50 495.28 Td (Tom \(and \\ Jerry\) \226 caf\351) Tj`)
+	assert.Contains(t, s, `(Page two ?) Tj`)
+}
+
+func TestDocument_Images(t *testing.T) {
+	pngData, jpegData := testImages(t)
+
+	doc := pdf.NewDocument(200, 200)
+	logo, err := doc.AddImage(pngData)
+	require.NoError(t, err)
+	photo, err := doc.AddImage(jpegData)
+	require.NoError(t, err)
+	assert.Equal(t, 4, logo.Width())
+	assert.Equal(t, 2, logo.Height())
+
+	page := doc.AddPage()
+	page.Image(logo, 0, 0, 40, 20)
+	page.Image(logo, 50, 0, 40, 20)
+	page.Image(photo, 100, 0, 40, 20)
+
+	data := write(t, doc)
+	checkXref(t, data)
+	s := string(data)
+	assert.Contains(t, s, "/Width 4 /Height 2 /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode")
+	assert.Contains(t, s, "/Filter /DCTDecode")
+	assert.True(t, bytes.Contains(data, jpegData), "JPEG is embedded unchanged")
+	assert.Contains(t, s, "/XObject << /Im1 5 0 R /Im2 6 0 R >>")
+	assert.Equal(t, 2, strings.Count(s, "/Im1 Do"))
+
+	_, err = doc.AddImage([]byte("<svg/>"))
+	assert.ErrorIs(t, err, pdf.ErrUnsupportedImage)
+}
+
+func TestTextWidth(t *testing.T) {
+	// H e l l o = 722 + 556 + 222 + 222 + 556 thousandths of the size.
+	assert.InDelta(t, 27.336, pdf.TextWidth(pdf.Helvetica, 12, "Hello"), 1e-9)
+	assert.Greater(t, pdf.TextWidth(pdf.HelveticaBold, 12, "Hello"), pdf.TextWidth(pdf.Helvetica, 12, "Hello"))
+	// Accented letters measure like their base letter.
+	assert.Equal(t, pdf.TextWidth(pdf.Helvetica, 10, "Zoe"), pdf.TextWidth(pdf.Helvetica, 10, "Zoë"))
+}
@@ -35,6 +35,10 @@ func (m *MockStorage) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+func (m *MockStorage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return "mock-signed-url", nil
+}
+
 // Ensure MockStorage implements storage.Storage
 var _ storage.Storage = (*MockStorage)(nil)
 
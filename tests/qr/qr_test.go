@@ -0,0 +1,106 @@
+package qr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/internal/lib/qr"
+)
+
+// formatBits reads the 15 format bits next to the top-left finder pattern, most
+// significant first.
+func formatBits(code *qr.Code) int {
+	bits := 0
+	read := func(x, y int) {
+		bits <<= 1
+		if code.Dark(x, y) {
+			bits |= 1
+		}
+	}
+	for x := 0; x <= 5; x++ {
+		read(x, 8)
+	}
+	read(7, 8)
+	read(8, 8)
+	read(8, 7)
+	for y := 5; y >= 0; y-- {
+		read(8, y)
+	}
+	return bits
+}
+
+func TestEncode_Versions(t *testing.T) {
+	tests := []struct {
+		length  int
+		version int
+	}{
+		{length: 14, version: 1},
+		{length: 15, version: 2}, // one byte over version 1 at level M
+		{length: 62, version: 4},
+		{length: 152, version: 8},
+		{length: 213, version: 10},
+	}
+
+	for _, tt := range tests {
+		code, err := qr.Encode(strings.Repeat("a", tt.length))
+		require.NoError(t, err)
+		assert.Equal(t, tt.version, code.Version(), "length %d", tt.length)
+		assert.Equal(t, tt.version*4+17, code.Size())
+	}
+
+	_, err := qr.Encode(strings.Repeat("a", 214))
+	assert.ErrorIs(t, err, qr.ErrTooLong)
+}
+
+func TestEncode_FunctionPatterns(t *testing.T) {
+	code, err := qr.Encode("https://example.com/api/certificates/0f3c9a/verify")
+	require.NoError(t, err)
+	size := code.Size()
+
+	// Finder patterns: dark 7x7 ring, light ring, dark 3x3 centre.
+	for _, corner := range [][2]int{{0, 0}, {size - 7, 0}, {0, size - 7}} {
+		x, y := corner[0], corner[1]
+		assert.True(t, code.Dark(x, y))
+		assert.True(t, code.Dark(x+6, y+6))
+		assert.False(t, code.Dark(x+1, y+1))
+		assert.True(t, code.Dark(x+3, y+3))
+	}
+
+	// Timing patterns alternate between the finders.
+	for i := 8; i < size-8; i++ {
+		assert.Equal(t, i%2 == 0, code.Dark(i, 6), "row timing %d", i)
+		assert.Equal(t, i%2 == 0, code.Dark(6, i), "column timing %d", i)
+	}
+
+	assert.True(t, code.Dark(8, size-8), "dark module")
+	assert.False(t, code.Dark(-1, 0))
+	assert.False(t, code.Dark(size, size))
+}
+
+func TestEncodeWithMask_FormatBits(t *testing.T) {
+	// Level M with mask 0 has all-zero data, so only the fixed XOR mask is left.
+	code, err := qr.EncodeWithMask("hello", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0b101010000010010, formatBits(code))
+
+	code, err = qr.EncodeWithMask("hello", 5)
+	require.NoError(t, err)
+	assert.Equal(t, 0b100000011001110, formatBits(code))
+
+	_, err = qr.EncodeWithMask("hello", 8)
+	assert.Error(t, err)
+}
+
+func TestEncode_Deterministic(t *testing.T) {
+	a, err := qr.Encode("same text")
+	require.NoError(t, err)
+	b, err := qr.Encode("same text")
+	require.NoError(t, err)
+	for y := 0; y < a.Size(); y++ {
+		for x := 0; x < a.Size(); x++ {
+			require.Equal(t, a.Dark(x, y), b.Dark(x, y))
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package storage_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/api/http/routes"
+	"gopi.com/internal/lib/storage"
+)
+
+func setupLocalStorage(t *testing.T) (*storage.LocalStorage, string, string) {
+	base := t.TempDir()
+	publicDir := filepath.Join(base, "uploads")
+	privateDir := filepath.Join(base, "private")
+	st, err := storage.NewLocalStorageFromConfig(storage.Config{
+		Backend:            "local",
+		LocalBaseDir:       publicDir,
+		LocalPublicBaseURL: "/uploads",
+		LocalPrivateDir:    privateDir,
+		LocalSignedBaseURL: "/api/files",
+		PrivatePrefixes:    []string{"exports/", "contracts/"},
+		SigningSecret:      "test-secret",
+	})
+	require.NoError(t, err)
+	return st, publicDir, privateDir
+}
+
+func setupFileRouter(st *storage.LocalStorage) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	routes.RegisterFileRoutes(r, st)
+	return r
+}
+
+func TestLocalStorage_PrivateObjectsStayOutOfPublicDir(t *testing.T) {
+	st, publicDir, privateDir := setupLocalStorage(t)
+	ctx := context.Background()
+
+	u, err := st.Save(ctx, "exports/report.csv", strings.NewReader("a,b\n"), 4, "text/csv")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/files/exports/report.csv", u)
+
+	_, err = os.Stat(filepath.Join(privateDir, "exports", "report.csv"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(publicDir, "exports", "report.csv"))
+	assert.True(t, os.IsNotExist(err))
+
+	u, err = st.Save(ctx, "profile/me.png", strings.NewReader("png"), 3, "image/png")
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/profile/me.png", u)
+}
+
+func TestLocalStorage_SignedURLPublicKey(t *testing.T) {
+	st, _, _ := setupLocalStorage(t)
+
+	u, err := st.SignedURL(context.Background(), "profile/me.png", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/profile/me.png", u)
+}
+
+func TestLocalStorage_SignedURLWithoutSecret(t *testing.T) {
+	st, err := storage.NewLocalStorageFromConfig(storage.Config{
+		LocalBaseDir:       t.TempDir(),
+		LocalPrivateDir:    t.TempDir(),
+		LocalSignedBaseURL: "/api/files",
+		PrivatePrefixes:    []string{"exports/"},
+	})
+	require.NoError(t, err)
+
+	_, err = st.SignedURL(context.Background(), "exports/report.csv", time.Minute)
+	assert.ErrorIs(t, err, storage.ErrSigningDisabled)
+}
+
+func TestLocalStorage_PrivatePrefixesNeedPrivateDir(t *testing.T) {
+	base := t.TempDir()
+	for name, privateDir := range map[string]string{
+		"missing":        "",
+		"same as public": base,
+		"inside public":  filepath.Join(base, "private"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := storage.NewLocalStorageFromConfig(storage.Config{
+				LocalBaseDir:    base,
+				LocalPrivateDir: privateDir,
+				PrivatePrefixes: []string{"exports/"},
+			})
+			assert.ErrorIs(t, err, storage.ErrNoPrivateStore)
+		})
+	}
+
+	_, err := storage.NewLocalStorageFromConfig(storage.Config{LocalBaseDir: base})
+	assert.NoError(t, err, "without private prefixes no private dir is needed")
+}
+
+func TestS3Storage_PrivatePrefixesNeedPrivateBucket(t *testing.T) {
+	for name, privateBucket := range map[string]string{"missing": "", "same as public": "uploads"} {
+		t.Run(name, func(t *testing.T) {
+			_, err := storage.NewS3Storage(storage.Config{
+				S3Endpoint:      "localhost:9000",
+				S3Bucket:        "uploads",
+				S3PrivateBucket: privateBucket,
+				PrivatePrefixes: []string{"exports/"},
+			})
+			assert.ErrorIs(t, err, storage.ErrNoPrivateStore)
+		})
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	st, _, _ := setupLocalStorage(t)
+	ctx := context.Background()
+	_, err := st.Save(ctx, "contracts/sponsor deal.pdf", strings.NewReader("0123456789"), 10, "application/pdf")
+	require.NoError(t, err)
+	router := setupFileRouter(st)
+
+	signed, err := st.SignedURL(ctx, "contracts/sponsor deal.pdf", time.Minute)
+	require.NoError(t, err)
+
+	t.Run("valid signature streams file", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, signed, nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "0123456789", w.Body.String())
+		assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("range request returns partial content", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, signed, nil)
+		req.Header.Set("Range", "bytes=2-5")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "2345", w.Body.String())
+		assert.Equal(t, "bytes 2-5/10", w.Header().Get("Content-Range"))
+	})
+
+	t.Run("tampered key is rejected", func(t *testing.T) {
+		tampered := strings.Replace(signed, "sponsor%20deal.pdf", "other.pdf", 1)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tampered, nil))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("expired link is rejected", func(t *testing.T) {
+		expired, err := st.SignedURL(ctx, "contracts/sponsor deal.pdf", -time.Minute)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, expired, nil))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "expired")
+	})
+
+	t.Run("missing signature is a bad request", func(t *testing.T) {
+		u, _ := url.Parse(signed)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, u.EscapedPath(), nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("public keys are not served", func(t *testing.T) {
+		_, err := st.Save(ctx, "profile/me.png", strings.NewReader("png"), 3, "image/png")
+		require.NoError(t, err)
+		expires := time.Now().Add(time.Minute).Unix()
+		sig := storage.NewSigner("test-secret").Sign("profile/me.png", expires)
+		target := "/api/files/profile/me.png?expires=" + strconv.FormatInt(expires, 10) + "&signature=" + sig
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestLocalStorage_KeyTraversalIsContained(t *testing.T) {
+	st, _, privateDir := setupLocalStorage(t)
+
+	_, err := st.Save(context.Background(), "exports/../../exports/escape.txt", strings.NewReader("x"), 1, "text/plain")
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(privateDir, "exports", "escape.txt"))
+	assert.NoError(t, err)
+}
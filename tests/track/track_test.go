@@ -0,0 +1,177 @@
+package track_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	trackModel "gopi.com/internal/domain/track/model"
+	"gopi.com/internal/lib/gpstrack"
+)
+
+// metersPerDegreeLat is one degree of latitude along a meridian, in meters.
+const metersPerDegreeLat = 111195.08
+
+// straightTrack heads north in equal steps, one point every interval.
+func straightTrack(points int, stepMeters float64, interval time.Duration) *trackModel.Track {
+	start := time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)
+	t := &trackModel.Track{}
+	for i := 0; i < points; i++ {
+		t.Points = append(t.Points, trackModel.Point{
+			Lat:  float64(i) * stepMeters / metersPerDegreeLat,
+			Lon:  0,
+			Time: start.Add(time.Duration(i) * interval),
+		})
+	}
+	return t
+}
+
+func TestHaversine(t *testing.T) {
+	// London to Paris is roughly 343.5km.
+	london := trackModel.Point{Lat: 51.5074, Lon: -0.1278}
+	paris := trackModel.Point{Lat: 48.8566, Lon: 2.3522}
+	assert.InDelta(t, 343500, trackModel.Haversine(london, paris), 1000)
+	assert.Zero(t, trackModel.Haversine(london, london))
+}
+
+func TestTrack_Summarize(t *testing.T) {
+	t.Run("distance, moving time and splits", func(t *testing.T) {
+		// 2.5km in 100m steps at 10s per step (6 min/km).
+		track := straightTrack(26, 100, 10*time.Second)
+
+		summary, err := track.Summarize()
+		require.NoError(t, err)
+		assert.InDelta(t, 2.5, summary.Distance, 0.001)
+		assert.Equal(t, 250*time.Second, summary.MovingTime)
+		assert.Equal(t, 250*time.Second, summary.ElapsedTime)
+
+		require.Len(t, summary.Splits, 3)
+		assert.Equal(t, 1, summary.Splits[0].Index)
+		assert.InDelta(t, 1.0, summary.Splits[0].Distance, 0.001)
+		assert.InDelta(t, 100, summary.Splits[0].Seconds, 1)
+		assert.InDelta(t, 100, summary.Splits[1].Seconds, 1)
+		assert.InDelta(t, 0.5, summary.Splits[2].Distance, 0.001)
+		assert.InDelta(t, 50, summary.Splits[2].Seconds, 1)
+	})
+
+	t.Run("stops do not count as moving time", func(t *testing.T) {
+		track := straightTrack(11, 100, 10*time.Second)
+		// Stand still for five minutes at the last point.
+		last := track.Points[len(track.Points)-1]
+		last.Time = last.Time.Add(5 * time.Minute)
+		track.Points = append(track.Points, last)
+
+		summary, err := track.Summarize()
+		require.NoError(t, err)
+		assert.Equal(t, 100*time.Second, summary.MovingTime)
+		assert.Equal(t, 400*time.Second, summary.ElapsedTime)
+	})
+
+	t.Run("elevation gain ignores jitter", func(t *testing.T) {
+		track := straightTrack(8, 100, 10*time.Second)
+		for i, ele := range []float64{10, 11, 10, 11, 20, 18, 25, 24} {
+			track.Points[i].Elevation, track.Points[i].HasEle = ele, true
+		}
+
+		summary, err := track.Summarize()
+		require.NoError(t, err)
+		// 10 -> 20 and 18 -> 25; the 1m wobbles are noise.
+		assert.InDelta(t, 17, summary.ElevationGain, 0.01)
+	})
+
+	t.Run("too few points", func(t *testing.T) {
+		_, err := (&trackModel.Track{Points: []trackModel.Point{{Lat: 1, Lon: 1}}}).Summarize()
+		assert.ErrorIs(t, err, trackModel.ErrEmptyTrack)
+	})
+}
+
+func TestTrack_Simplify(t *testing.T) {
+	track := straightTrack(50, 20, time.Second)
+	// Veer out to a 50m detour and back in straight lines: only the corner must survive.
+	for i := range track.Points {
+		offset := 50 * (1 - math.Abs(float64(i-25))/25)
+		track.Points[i].Lon = math.Max(0, offset) / metersPerDegreeLat
+	}
+
+	simplified := track.Simplify(5)
+	require.Len(t, simplified.Points, 3)
+	assert.Equal(t, track.Points[0], simplified.Points[0])
+	assert.Equal(t, track.Points[25], simplified.Points[1])
+	assert.Equal(t, track.Points[49], simplified.Points[2])
+	assert.Len(t, track.Points, 50, "the original track is left untouched")
+}
+
+func TestTrack_GeoJSON(t *testing.T) {
+	track := &trackModel.Track{Points: []trackModel.Point{
+		{Lat: 1, Lon: 2, Elevation: 30, HasEle: true},
+		{Lat: 3, Lon: 4},
+	}}
+
+	feature := track.GeoJSON(map[string]interface{}{"distance": 1.5})
+	assert.Equal(t, "Feature", feature.Type)
+	assert.Equal(t, "LineString", feature.Geometry.Type)
+	assert.Equal(t, [][]float64{{2, 1, 30}, {4, 3}}, feature.Geometry.Coordinates)
+	assert.Equal(t, 1.5, feature.Properties["distance"])
+}
+
+const sampleGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk><name>Morning Run</name><trkseg>
+    <trkpt lat="0" lon="0"><ele>10</ele><time>2024-05-01T07:00:00Z</time></trkpt>
+    <trkpt lat="0.0009" lon="0"><ele>12.5</ele><time>2024-05-01T07:00:30Z</time></trkpt>
+    <trkpt lat="0.0018" lon="0"><ele>15</ele><time>2024-05-01T07:01:00Z</time></trkpt>
+  </trkseg></trk>
+</gpx>`
+
+const sampleTCX = `<?xml version="1.0" encoding="UTF-8"?>
+<TrainingCenterDatabase xmlns="http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2">
+  <Activities><Activity Sport="Running"><Id>2024-05-01T07:00:00Z</Id>
+    <Lap StartTime="2024-05-01T07:00:00Z"><Track>
+      <Trackpoint><Time>2024-05-01T07:00:00Z</Time><HeartRateBpm><Value>120</Value></HeartRateBpm></Trackpoint>
+      <Trackpoint><Time>2024-05-01T07:00:00Z</Time><Position><LatitudeDegrees>0</LatitudeDegrees><LongitudeDegrees>0</LongitudeDegrees></Position><AltitudeMeters>5</AltitudeMeters></Trackpoint>
+      <Trackpoint><Time>2024-05-01T07:00:45Z</Time><Position><LatitudeDegrees>0.0018</LatitudeDegrees><LongitudeDegrees>0</LongitudeDegrees></Position><AltitudeMeters>9</AltitudeMeters></Trackpoint>
+    </Track></Lap>
+  </Activity></Activities>
+</TrainingCenterDatabase>`
+
+func TestParse(t *testing.T) {
+	t.Run("gpx", func(t *testing.T) {
+		track, format, err := gpstrack.Parse(strings.NewReader(sampleGPX))
+		require.NoError(t, err)
+		assert.Equal(t, gpstrack.FormatGPX, format)
+		require.Len(t, track.Points, 3)
+		assert.Equal(t, 0.0009, track.Points[1].Lat)
+		assert.True(t, track.Points[1].HasEle)
+		assert.Equal(t, 12.5, track.Points[1].Elevation)
+		assert.Equal(t, time.Date(2024, 5, 1, 7, 0, 30, 0, time.UTC), track.Points[1].Time)
+
+		summary, err := track.Summarize()
+		require.NoError(t, err)
+		assert.InDelta(t, 0.2, summary.Distance, 0.001)
+		assert.InDelta(t, 5, summary.ElevationGain, 0.01)
+	})
+
+	t.Run("tcx skips points without a position", func(t *testing.T) {
+		track, format, err := gpstrack.Parse(strings.NewReader(sampleTCX))
+		require.NoError(t, err)
+		assert.Equal(t, gpstrack.FormatTCX, format)
+		require.Len(t, track.Points, 2)
+		assert.Equal(t, 9.0, track.Points[1].Elevation)
+	})
+
+	t.Run("unsupported document", func(t *testing.T) {
+		_, _, err := gpstrack.Parse(strings.NewReader(`<kml><Document/></kml>`))
+		assert.ErrorIs(t, err, gpstrack.ErrUnsupportedFormat)
+
+		_, _, err = gpstrack.Parse(strings.NewReader(`not xml`))
+		assert.ErrorIs(t, err, gpstrack.ErrUnsupportedFormat)
+	})
+
+	t.Run("no track points", func(t *testing.T) {
+		_, _, err := gpstrack.Parse(strings.NewReader(`<gpx><trk><trkseg/></trk></gpx>`))
+		assert.ErrorIs(t, err, trackModel.ErrEmptyTrack)
+	})
+}
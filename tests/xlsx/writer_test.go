@@ -0,0 +1,76 @@
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopi.com/internal/lib/xlsx"
+)
+
+func readParts(t *testing.T, data []byte) map[string][]byte {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	parts := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		parts[f.Name] = content
+	}
+	return parts
+}
+
+func TestWriter_Workbook(t *testing.T) {
+	var buf bytes.Buffer
+	w := xlsx.NewWriter(&buf)
+
+	require.NoError(t, w.NewSheet("Totals: 2026/Q1"))
+	require.NoError(t, w.WriteHeader("name", "km"))
+	require.NoError(t, w.WriteRow("Tom & <Jerry>", 12.5))
+	cells := make([]interface{}, 28)
+	cells[27] = int64(7)
+	require.NoError(t, w.WriteRow(cells...))
+	require.NoError(t, w.NewSheet(""))
+	require.NoError(t, w.WriteRow(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), 3))
+	require.NoError(t, w.Close())
+
+	parts := readParts(t, buf.Bytes())
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels",
+		"xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		require.Contains(t, parts, name)
+		assert.NoError(t, xml.Unmarshal(parts[name], new(interface{})), name)
+	}
+
+	// Reserved characters are replaced and unnamed sheets get a default name.
+	assert.Contains(t, string(parts["xl/workbook.xml"]), `<sheet name="Totals_ 2026_Q1" sheetId="1" r:id="rId1"/>`)
+	assert.Contains(t, string(parts["xl/workbook.xml"]), `<sheet name="Sheet2" sheetId="2" r:id="rId2"/>`)
+
+	sheet1 := string(parts["xl/worksheets/sheet1.xml"])
+	assert.Contains(t, sheet1, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">name</t></is></c>`)
+	assert.Contains(t, sheet1, `Tom &amp; &lt;Jerry&gt;`)
+	assert.Contains(t, sheet1, `<c r="B2" s="0"><v>12.5</v></c>`)
+	assert.Contains(t, sheet1, `<row r="3"><c r="AB3" s="0"><v>7</v></c></row>`)
+
+	sheet2 := string(parts["xl/worksheets/sheet2.xml"])
+	assert.Contains(t, sheet2, `2026-01-02T03:04:05Z`)
+	assert.Contains(t, sheet2, `<c r="B1" s="0"><v>3</v></c>`)
+}
+
+func TestWriter_RowBeforeSheet(t *testing.T) {
+	w := xlsx.NewWriter(io.Discard)
+	assert.Error(t, w.WriteRow("x"))
+
+	// Closing an empty workbook still produces a valid file with one sheet.
+	var buf bytes.Buffer
+	require.NoError(t, xlsx.NewWriter(&buf).Close())
+	assert.Contains(t, readParts(t, buf.Bytes()), "xl/worksheets/sheet1.xml")
+}